@@ -0,0 +1,366 @@
+package claudecode
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Cassettes are JSON lines files holding a header record followed by the raw
+// output of one claude run, in the order it was produced:
+//
+//	{"kind":"header","version":1,"args":["--output-format","stream-json",...]}
+//	{"kind":"stdout","offset_ms":812,"data":"{\"type\":\"system\",...}"}
+//	{"kind":"stderr","offset_ms":900,"data":"warning: ..."}
+//	{"kind":"exit","offset_ms":5120,"exit_code":0}
+const cassetteVersion = 1
+
+// Cassette record kinds
+const (
+	cassetteHeader = "header"
+	cassetteStdout = "stdout"
+	cassetteStderr = "stderr"
+	cassetteExit   = "exit"
+)
+
+// CassetteRecord is a single line of a cassette file
+type CassetteRecord struct {
+	Kind     string   `json:"kind"`
+	OffsetMS int64    `json:"offset_ms"`
+	Data     string   `json:"data,omitempty"`
+	ExitCode int      `json:"exit_code,omitempty"`
+	Version  int      `json:"version,omitempty"`
+	Args     []string `json:"args,omitempty"`
+}
+
+// ExitError is returned by replayed sessions whose recording exited non-zero
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ReadCassette reads all records from a cassette file
+func ReadCassette(path string) ([]CassetteRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer f.Close()
+
+	var records []CassetteRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0), 20*1024*1024) // Recorded lines can be up to 10MB before escaping
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record CassetteRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid cassette record: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	if len(records) == 0 || records[0].Kind != cassetteHeader {
+		return nil, fmt.Errorf("cassette %s has no header", path)
+	}
+	if records[0].Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d", records[0].Version)
+	}
+	return records, nil
+}
+
+// cassetteWriter appends records to a cassette file from concurrent readers
+type cassetteWriter struct {
+	mu    sync.Mutex
+	f     *os.File
+	enc   *json.Encoder
+	start time.Time
+}
+
+func newCassetteWriter(path string, args []string) (*cassetteWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cassette: %w", err)
+	}
+	w := &cassetteWriter{f: f, enc: json.NewEncoder(f), start: time.Now()}
+	if err := w.write(CassetteRecord{Kind: cassetteHeader, Version: cassetteVersion, Args: args}); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *cassetteWriter) write(record CassetteRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if record.Kind != cassetteHeader {
+		record.OffsetMS = time.Since(w.start).Milliseconds()
+	}
+	return w.enc.Encode(record)
+}
+
+func (w *cassetteWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
+
+// recordingRunner tees the output of another runner's processes to a cassette
+type recordingRunner struct {
+	inner Runner
+	path  string
+}
+
+// Start implements Runner
+func (r recordingRunner) Start(ctx context.Context, spec RunSpec) (Process, error) {
+	w, err := newCassetteWriter(r.path, spec.Args)
+	if err != nil {
+		return nil, err
+	}
+	proc, err := r.inner.Start(ctx, spec)
+	if err != nil {
+		_ = w.close()
+		return nil, err
+	}
+	return &recordingProcess{
+		Process: proc,
+		w:       w,
+		stdout:  &lineRecorder{ReadCloser: proc.Stdout(), w: w, kind: cassetteStdout},
+		stderr:  &chunkRecorder{ReadCloser: proc.Stderr(), w: w},
+	}, nil
+}
+
+// recordingProcess records the exit code of a process once it is reaped
+type recordingProcess struct {
+	Process
+	w      *cassetteWriter
+	stdout *lineRecorder
+	stderr *chunkRecorder
+}
+
+func (p *recordingProcess) Stdout() io.ReadCloser { return p.stdout }
+func (p *recordingProcess) Stderr() io.ReadCloser { return p.stderr }
+
+func (p *recordingProcess) SignalGroup(sig os.Signal) error {
+	return signalGroup(p.Process, sig)
+}
+
+func (p *recordingProcess) Wait() error {
+	err := p.Process.Wait()
+
+	// Record a trailing line without a newline, if any
+	p.stdout.flush()
+
+	code := 0
+	var exitErr *exec.ExitError
+	var replayErr *ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		code = exitErr.ExitCode()
+	case errors.As(err, &replayErr):
+		code = replayErr.Code
+	case err != nil:
+		code = -1
+	}
+	_ = p.w.write(CassetteRecord{Kind: cassetteExit, ExitCode: code})
+	_ = p.w.close()
+	return err
+}
+
+// lineRecorder records every complete line read through it
+type lineRecorder struct {
+	io.ReadCloser
+	w    *cassetteWriter
+	kind string
+
+	mu      sync.Mutex
+	pending []byte
+}
+
+func (r *lineRecorder) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.mu.Lock()
+		r.pending = append(r.pending, p[:n]...)
+		for {
+			i := bytes.IndexByte(r.pending, '\n')
+			if i < 0 {
+				break
+			}
+			_ = r.w.write(CassetteRecord{Kind: r.kind, Data: string(r.pending[:i])})
+			r.pending = r.pending[i+1:]
+		}
+		r.mu.Unlock()
+	}
+	return n, err
+}
+
+func (r *lineRecorder) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pending) > 0 {
+		_ = r.w.write(CassetteRecord{Kind: r.kind, Data: string(r.pending)})
+		r.pending = nil
+	}
+}
+
+// chunkRecorder records stderr verbatim, since it is not line oriented
+type chunkRecorder struct {
+	io.ReadCloser
+	w *cassetteWriter
+}
+
+func (r *chunkRecorder) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		_ = r.w.write(CassetteRecord{Kind: cassetteStderr, Data: string(p[:n])})
+	}
+	return n, err
+}
+
+// ReplayRunner is a Runner that plays back a cassette instead of running
+// claude. Speed scales the recorded timing: 1 is real time, 10 is ten times
+// faster and 0 or less replays without any delay.
+type ReplayRunner struct {
+	Path  string
+	Speed float64
+}
+
+// NewReplayClient creates a client whose sessions are served from the
+// cassette at path rather than a claude process. The returned client is a
+// regular *Client, so it can stand in wherever one is expected.
+func NewReplayClient(cassettePath string, speed float64) *Client {
+	return NewClientWithRunner("claude", ReplayRunner{Path: cassettePath, Speed: speed})
+}
+
+// Start implements Runner
+func (r ReplayRunner) Start(ctx context.Context, spec RunSpec) (Process, error) {
+	records, err := ReadCassette(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+	p := &replayProcess{
+		stdout: stdoutR,
+		stderr: stderrR,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if spec.Stdin {
+		// Input is accepted and discarded; the recording already holds the replies
+		stdinR, stdinW := io.Pipe()
+		go func() { _, _ = io.Copy(io.Discard, stdinR) }()
+		p.stdin = stdinW
+	}
+
+	go p.play(records[1:], r.Speed, stdoutW, stderrW)
+	return p, nil
+}
+
+// replayProcess is a Process that writes recorded output on schedule
+type replayProcess struct {
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+	err      error
+}
+
+func (p *replayProcess) play(records []CassetteRecord, speed float64, stdout, stderr *io.PipeWriter) {
+	// stderr gets its own writer, buffering like an OS pipe would, since some
+	// parsers only read it after stdout reaches EOF
+	stderrCh := make(chan string, len(records))
+	stderrDone := make(chan struct{})
+	go func() {
+		defer close(stderrDone)
+		for data := range stderrCh {
+			if _, err := io.WriteString(stderr, data); err != nil {
+				return
+			}
+		}
+	}()
+	defer func() {
+		_ = stdout.Close()
+		close(stderrCh)
+		<-stderrDone
+		_ = stderr.Close()
+		close(p.done)
+	}()
+
+	start := time.Now()
+	for _, record := range records {
+		if speed > 0 {
+			due := start.Add(time.Duration(float64(record.OffsetMS)/speed) * time.Millisecond)
+			select {
+			case <-time.After(time.Until(due)):
+			case <-p.stop:
+				p.err = errors.New("replay killed")
+				return
+			}
+		}
+
+		select {
+		case <-p.stop:
+			p.err = errors.New("replay killed")
+			return
+		default:
+		}
+
+		switch record.Kind {
+		case cassetteStdout:
+			if _, err := io.WriteString(stdout, record.Data+"\n"); err != nil {
+				p.err = errors.New("replay killed")
+				return
+			}
+		case cassetteStderr:
+			stderrCh <- record.Data
+		case cassetteExit:
+			if record.ExitCode != 0 {
+				p.err = &ExitError{Code: record.ExitCode}
+			}
+			return
+		}
+	}
+}
+
+func (p *replayProcess) Stdout() io.ReadCloser { return p.stdout }
+func (p *replayProcess) Stderr() io.ReadCloser { return p.stderr }
+func (p *replayProcess) Stdin() io.WriteCloser { return p.stdin }
+
+func (p *replayProcess) Wait() error {
+	<-p.done
+	if p.stdin != nil {
+		_ = p.stdin.Close()
+	}
+	return p.err
+}
+
+// Signal stops the replay, standing in for the process exiting on a signal
+func (p *replayProcess) Signal(sig os.Signal) error {
+	return p.Kill()
+}
+
+func (p *replayProcess) Kill() error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	return nil
+}
@@ -0,0 +1,138 @@
+package claudecode_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectEvents drains a session and returns its events and final error
+func collectEvents(t *testing.T, session *claudecode.Session) ([]claudecode.StreamEvent, *claudecode.Result, error) {
+	t.Helper()
+	var events []claudecode.StreamEvent
+	for event := range session.Events {
+		events = append(events, event)
+	}
+	result, err := session.Wait()
+	return events, result, err
+}
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo '{"type":"system","subtype":"init","session_id":"rec-session"}'
+sleep 0.3
+echo '{"type":"assistant","session_id":"rec-session","message":{"id":"msg_1","role":"assistant","content":[{"type":"text","text":"hi"}]}}'
+echo '{"type":"result","subtype":"success","session_id":"rec-session","result":"recorded","total_cost_usd":0.5}'
+`)
+	cassette := filepath.Join(t.TempDir(), "session.jsonl")
+
+	config := claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+	}
+
+	// Record a live run
+	recordConfig := config
+	recordConfig.CassettePath = cassette
+	session, err := claudecode.NewClientWithPath(mockPath).Launch(recordConfig)
+	require.NoError(t, err)
+	recorded, recordedResult, err := collectEvents(t, session)
+	require.NoError(t, err)
+	require.Len(t, recorded, 3)
+
+	records, err := claudecode.ReadCassette(cassette)
+	require.NoError(t, err)
+	assert.Equal(t, "header", records[0].Kind)
+	assert.Contains(t, records[0].Args, "stream-json")
+	assert.Equal(t, "exit", records[len(records)-1].Kind)
+	assert.GreaterOrEqual(t, records[2].OffsetMS, int64(250), "timing between lines should be recorded")
+
+	// Replay without delays
+	start := time.Now()
+	session, err = claudecode.NewReplayClient(cassette, 0).Launch(config)
+	require.NoError(t, err)
+	replayed, replayedResult, err := collectEvents(t, session)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	require.Len(t, replayed, len(recorded))
+	for i := range recorded {
+		assert.Equal(t, string(recorded[i].Raw()), string(replayed[i].Raw()))
+	}
+	assert.Equal(t, recordedResult, replayedResult)
+	assert.Equal(t, "rec-session", session.ID)
+
+	// Replay with original timing
+	start = time.Now()
+	session, err = claudecode.NewReplayClient(cassette, 1).Launch(config)
+	require.NoError(t, err)
+	_, _, err = collectEvents(t, session)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+}
+
+func TestCassette_ReplayFailure(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo 'Error: invalid API key' >&2
+exit 3
+`)
+	cassette := filepath.Join(t.TempDir(), "failure.jsonl")
+
+	session, err := claudecode.NewClientWithPath(mockPath).Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+		CassettePath: cassette,
+	})
+	require.NoError(t, err)
+	_, _, err = collectEvents(t, session)
+	require.Error(t, err)
+
+	records, err := claudecode.ReadCassette(cassette)
+	require.NoError(t, err)
+	last := records[len(records)-1]
+	assert.Equal(t, "exit", last.Kind)
+	assert.Equal(t, 3, last.ExitCode)
+
+	session, err = claudecode.NewReplayClient(cassette, 0).Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	require.NoError(t, err)
+	_, _, err = collectEvents(t, session)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid API key")
+}
+
+func TestCassette_ReplayCanceled(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo '{"type":"system","subtype":"init","session_id":"slow"}'
+sleep 1
+echo '{"type":"result","subtype":"success","session_id":"slow","result":"late"}'
+`)
+	cassette := filepath.Join(t.TempDir(), "slow.jsonl")
+	session, err := claudecode.NewClientWithPath(mockPath).Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+		CassettePath: cassette,
+	})
+	require.NoError(t, err)
+	_, _, err = collectEvents(t, session)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	session, err = claudecode.NewReplayClient(cassette, 1).LaunchContext(ctx, claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = session.WaitContext(ctx)
+	var canceled *claudecode.CanceledError
+	require.ErrorAs(t, err, &canceled)
+	assert.True(t, canceled.Timeout())
+	assert.Less(t, time.Since(start), 900*time.Millisecond)
+}
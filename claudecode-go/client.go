@@ -7,11 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -43,6 +44,12 @@ func isClosedPipeError(err error) bool {
 // Client provides methods to interact with the Claude Code SDK
 type Client struct {
 	claudePath string
+	runner     Runner
+
+	mu           sync.Mutex
+	version      *Version // Set by DetectVersion or SetVersion, enables capability checks
+	logger       *slog.Logger
+	logSensitive bool
 }
 
 // shouldSkipPath checks if a path should be skipped during search
@@ -98,7 +105,7 @@ func NewClient() (*Client, error) {
 		return &Client{claudePath: shellPath}, nil
 	}
 
-	return nil, fmt.Errorf("claude binary not found in PATH or common locations")
+	return nil, &ClaudeError{Kind: ErrBinaryMissing, ExitCode: -1, Err: fmt.Errorf("claude binary not found in PATH or common locations")}
 }
 
 // NewClientWithPath creates a new client with a specific claude binary path
@@ -108,6 +115,24 @@ func NewClientWithPath(claudePath string) *Client {
 	}
 }
 
+// NewClientWithRunner creates a new client that starts claude through runner,
+// e.g. a DockerRunner or SSHRunner. claudePath is resolved on the runner's
+// target host.
+func NewClientWithRunner(claudePath string, runner Runner) *Client {
+	return &Client{
+		claudePath: claudePath,
+		runner:     runner,
+	}
+}
+
+// getRunner returns the configured runner, defaulting to a local process
+func (c *Client) getRunner() Runner {
+	if c.runner != nil {
+		return c.runner
+	}
+	return LocalRunner{}
+}
+
 // GetPath returns the path to the Claude binary
 func (c *Client) GetPath() string {
 	return c.claudePath
@@ -146,6 +171,85 @@ func (c *Client) GetVersion() (string, error) {
 	return version, nil
 }
 
+// DetectVersion runs claude --version and remembers the result, so that later
+// launches check options against the version's capabilities
+func (c *Client) DetectVersion() (Version, error) {
+	raw, err := c.GetVersion()
+	if err != nil {
+		return Version{}, err
+	}
+	v, err := ParseVersion(raw)
+	if err != nil {
+		return Version{}, err
+	}
+	c.SetVersion(v)
+	return v, nil
+}
+
+// SetVersion sets the CLI version options are checked against, for example
+// when claude runs on a remote host where DetectVersion cannot reach it
+func (c *Client) SetVersion(v Version) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version = &v
+}
+
+// Version returns the version set by DetectVersion or SetVersion, and false
+// if there is none. Without a version, options are passed to the CLI
+// unchecked.
+func (c *Client) Version() (Version, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version == nil {
+		return Version{}, false
+	}
+	return *c.version, true
+}
+
+// checkCapabilities refuses options the CLI version does not support. Options
+// that only refine the output, partial messages and the fallback model, are
+// dropped with a warning instead.
+func (c *Client) checkCapabilities(config *SessionConfig) error {
+	v, ok := c.Version()
+	if !ok {
+		return nil
+	}
+	logger, _ := c.log()
+
+	if config.IncludePartialMessages && !v.Supports(CapPartialMessages) {
+		logger.Warn("claude does not support --include-partial-messages, continuing without partial messages", "version", v.String())
+		config.IncludePartialMessages = false
+	}
+	if config.FallbackModel != "" && !v.Supports(CapFallbackModel) {
+		logger.Warn("claude does not support --fallback-model, continuing without a fallback model", "version", v.String())
+		config.FallbackModel = ""
+	}
+
+	required := []struct {
+		cap    Capability
+		used   bool
+		option string
+	}{
+		{CapStreamJSONInput, config.InputFormat == InputStreamJSON, "InputFormat stream-json"},
+		{CapForkSession, config.ForkSession, "ForkSession"},
+		{CapSessionID, config.NewSessionID != "", "NewSessionID"},
+		{CapJSONSchema, len(config.JSONSchema) > 0, "JSONSchema"},
+		{CapStrictMCPConfig, config.StrictMCPConfig, "StrictMCPConfig"},
+		{CapSettings, config.Settings != "", "Settings"},
+		{CapSettings, len(config.Hooks) > 0, "Hooks"},
+		{CapSettingSources, config.SettingSources != nil, "SettingSources"},
+		{CapAgents, len(config.Agents) > 0, "Agents"},
+		{CapSystemPrompt, config.SystemPrompt != "", "SystemPrompt"},
+		{CapAppendSystemPrompt, config.AppendSystemPrompt != "", "AppendSystemPrompt"},
+	}
+	for _, r := range required {
+		if r.used && !v.Supports(r.cap) {
+			return unsupported(v, r.cap, r.option)
+		}
+	}
+	return nil
+}
+
 // isExecutable checks if file is executable
 func isExecutable(path string) error {
 	info, err := os.Stat(path)
@@ -179,24 +283,57 @@ func tryLoginShell() string {
 	return ""
 }
 
-// buildArgs converts SessionConfig into command line arguments
-func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
-	args := []string{}
+// buildArgs converts SessionConfig into command line arguments. cleanup
+// removes the temporary files the arguments refer to, once the CLI has exited.
+func (c *Client) buildArgs(config SessionConfig) (args []string, cleanup func(), err error) {
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkCapabilities(&config); err != nil {
+		return nil, nil, err
+	}
+	logger, sensitive := c.log()
+
+	var tempFiles []string
+	cleanup = func() {
+		for _, path := range tempFiles {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				logger.Warn("failed to remove temporary file", "path", path, "error", err)
+			}
+		}
+	}
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
 
 	// Session management
 	if config.SessionID != "" {
 		args = append(args, "--resume", config.SessionID)
-
-		// Add fork flag if specified
-		if config.ForkSession {
-			args = append(args, "--fork-session")
-		}
+	} else if config.Continue {
+		args = append(args, "--continue")
+	}
+	if config.ForkSession {
+		args = append(args, "--fork-session")
+	}
+	if config.NewSessionID != "" {
+		args = append(args, "--session-id", config.NewSessionID)
 	}
 
 	// Model
 	if config.Model != "" {
 		args = append(args, "--model", string(config.Model))
 	}
+	if config.FallbackModel != "" {
+		args = append(args, "--fallback-model", string(config.FallbackModel))
+	}
+
+	// Input format - stream-json input only works with stream-json output
+	interactive := config.InputFormat == InputStreamJSON
+	if interactive {
+		args = append(args, "--input-format", string(config.InputFormat))
+	}
 
 	// Output format
 	if config.OutputFormat != "" {
@@ -207,40 +344,87 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 		}
 	}
 
+	// Partial messages are only emitted as stream-json events
+	if config.IncludePartialMessages {
+		args = append(args, "--include-partial-messages")
+	}
+
+	// Structured output
+	if len(config.JSONSchema) > 0 {
+		args = append(args, "--json-schema", string(config.JSONSchema))
+	}
+
 	// MCP configuration
 	if config.MCPConfig != nil {
-		// Convert MCP config to JSON and pass inline
+		// Convert MCP config to JSON and pass it in a temp file
 		mcpJSON, err := json.Marshal(config.MCPConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal MCP config: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal MCP config: %w", err)
 		}
 
-		// Log MCP config for debugging
-		log.Printf("MCP config JSON: %s", string(mcpJSON))
+		if sensitive {
+			logger.Debug("MCP config", "config", string(mcpJSON))
+		} else if safeJSON, err := json.Marshal(config.MCPConfig.Redacted()); err == nil {
+			logger.Debug("MCP config", "config", string(safeJSON))
+		}
 
-		// Create a temp file for MCP config
 		tmpFile, err := os.CreateTemp("", "mcp-config-*.json")
 		if err != nil {
-			return nil, fmt.Errorf("failed to create temp MCP config file: %w", err)
+			return nil, nil, fmt.Errorf("failed to create temp MCP config file: %w", err)
 		}
+		tempFiles = append(tempFiles, tmpFile.Name())
 
 		if _, err := tmpFile.Write(mcpJSON); err != nil {
 			_ = tmpFile.Close()
-			return nil, fmt.Errorf("failed to write MCP config: %w", err)
+			return nil, nil, fmt.Errorf("failed to write MCP config: %w", err)
 		}
 		_ = tmpFile.Close()
 
-		log.Printf("MCP config written to: %s", tmpFile.Name())
+		logger.Debug("MCP config written", "path", tmpFile.Name())
 
 		args = append(args, "--mcp-config", tmpFile.Name())
-		// Note: temp file will be cleaned up when process exits
 	}
 
-	// Permission prompt tool
+	if config.StrictMCPConfig {
+		args = append(args, "--strict-mcp-config")
+	}
+
+	// Permissions
+	if config.PermissionMode != "" && config.PermissionMode != PermissionModeDefault {
+		args = append(args, "--permission-mode", string(config.PermissionMode))
+	}
 	if config.PermissionPromptTool != "" {
 		args = append(args, "--permission-prompt-tool", config.PermissionPromptTool)
 	}
 
+	// Settings and subagents. Hooks go in a settings file of their own,
+	// which takes over the other settings as the CLI accepts only one.
+	if len(config.Hooks) > 0 {
+		settingsPath, err := writeHookSettings(config.Settings, config.Hooks)
+		if err != nil {
+			return nil, nil, err
+		}
+		tempFiles = append(tempFiles, settingsPath)
+		logger.Debug("hook settings written", "path", settingsPath, "events", len(config.Hooks))
+		args = append(args, "--settings", settingsPath)
+	} else if config.Settings != "" {
+		args = append(args, "--settings", config.Settings)
+	}
+	if config.SettingSources != nil {
+		sources := make([]string, len(config.SettingSources))
+		for i, source := range config.SettingSources {
+			sources[i] = string(source)
+		}
+		args = append(args, "--setting-sources", strings.Join(sources, ","))
+	}
+	if len(config.Agents) > 0 {
+		agentsJSON, err := json.Marshal(config.Agents)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal agents: %w", err)
+		}
+		args = append(args, "--agents", string(agentsJSON))
+	}
+
 	// Max turns
 	if config.MaxTurns > 0 {
 		args = append(args, "--max-turns", fmt.Sprintf("%d", config.MaxTurns))
@@ -264,7 +448,7 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 
 	// Additional directories
 	if len(config.AdditionalDirectories) > 0 {
-		log.Printf("Processing %d additional directories", len(config.AdditionalDirectories))
+		logger.Debug("processing additional directories", "count", len(config.AdditionalDirectories))
 		for _, dir := range config.AdditionalDirectories {
 			// Expand tilde if present
 			expandedDir := dir
@@ -281,16 +465,14 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 			// Convert to absolute path
 			absPath, err := filepath.Abs(expandedDir)
 			if err == nil {
-				log.Printf("Adding directory (expanded): %s -> %s", dir, absPath)
+				logger.Debug("adding directory", "dir", dir, "expanded", absPath)
 				args = append(args, "--add-dir", absPath)
 			} else {
 				// Fallback to original if absolute path conversion fails
-				log.Printf("Adding directory (original, expansion failed): %s", dir)
+				logger.Debug("adding directory, expansion failed", "dir", dir, "error", err)
 				args = append(args, "--add-dir", dir)
 			}
 		}
-	} else {
-		log.Printf("No additional directories to add")
 	}
 
 	// Verbose
@@ -301,80 +483,99 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 	// Always use print mode for SDK - MUST be the last flag before --
 	// The -- separator tells the CLI parser to stop interpreting flags
 	// Correct order: <all flags> --print -- <query>
-	if config.Query != "" {
+	// In stream-json input mode the query is written to stdin instead
+	if interactive {
+		args = append(args, "--print")
+	} else if config.Query != "" {
 		args = append(args, "--print")
 		args = append(args, "--")
 		args = append(args, config.Query)
 	}
 
-	return args, nil
+	return args, cleanup, nil
 }
 
 // Launch starts a new Claude session and returns immediately
 func (c *Client) Launch(config SessionConfig) (*Session, error) {
-	args, err := c.buildArgs(config)
+	return c.LaunchContext(context.Background(), config)
+}
+
+// LaunchContext starts a new Claude session bound to ctx and returns immediately.
+// When ctx is canceled or its deadline expires, the Claude process is killed,
+// the output parsers are drained and the session ends with a *CanceledError.
+func (c *Client) LaunchContext(ctx context.Context, config SessionConfig) (*Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &CanceledError{Cause: err}
+	}
+
+	args, cleanup, err := c.buildArgs(config)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("Executing Claude command: %s %v", c.claudePath, args)
-	cmd := exec.Command(c.claudePath, args...)
+	// runCtx is canceled either by the caller's ctx or by WaitContext giving up
+	runCtx, cancel := context.WithCancelCause(ctx)
 
-	// Set environment variables if specified
-	if len(config.Env) > 0 {
-		cmd.Env = os.Environ() // Start with current environment
-		for key, value := range config.Env {
-			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
-		}
+	logger, sensitive := c.log()
+	if sensitive {
+		logger.Info("executing claude command", "path", c.claudePath, "args", args)
+	} else {
+		logger.Info("executing claude command", "path", c.claudePath, "args", redactArgs(args))
+	}
+	runner := c.getRunner()
+	if config.CassettePath != "" {
+		runner = recordingRunner{inner: runner, path: config.CassettePath}
+	}
+	proc, err := runner.Start(runCtx, RunSpec{
+		Path:       c.claudePath,
+		Args:       args,
+		Env:        config.Env,
+		WorkingDir: config.WorkingDir,
+		Stdin:      config.InputFormat == InputStreamJSON,
+	})
+	if err != nil {
+		cancel(nil)
+		cleanup()
+		return nil, fmt.Errorf("failed to start claude: %w", newStartError(err))
 	}
+	stdout, stderr, stdin := proc.Stdout(), proc.Stderr(), proc.Stdin()
 
-	// Set working directory if specified
-	if config.WorkingDir != "" {
-		workingDir := config.WorkingDir
+	// exited is closed once the process has been reaped, aborted once it has
+	// been killed because runCtx was canceled first
+	exited := make(chan struct{})
+	aborted := make(chan struct{})
 
-		// Expand tilde to user home directory
-		if strings.HasPrefix(workingDir, "~/") {
-			if home, err := os.UserHomeDir(); err == nil {
-				workingDir = filepath.Join(home, workingDir[2:])
-			}
-		} else if workingDir == "~" {
-			if home, err := os.UserHomeDir(); err == nil {
-				workingDir = home
-			}
+	// On cancellation kill the process and close our ends of the pipes, so the
+	// parsers unblock even if a grandchild process still holds them open
+	go func() {
+		select {
+		case <-runCtx.Done():
+		case <-exited:
+			return
 		}
-
-		// Convert to absolute path and clean it
-		if absPath, err := filepath.Abs(workingDir); err == nil {
-			cmd.Dir = filepath.Clean(absPath)
-		} else {
-			// Fallback to original if absolute path conversion fails
-			cmd.Dir = workingDir
+		select {
+		case <-exited:
+		default:
+			close(aborted)
+			_ = proc.Kill()
+			_ = stdout.Close()
+			_ = stderr.Close()
 		}
-	}
-
-	// Set up pipes for stdout/stderr
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
-
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start claude: %w", err)
-	}
+	}()
 
 	session := &Session{
 		Config:    config,
 		StartTime: time.Now(),
-		cmd:       cmd,
+		proc:      proc,
+		stdin:     stdin,
+		aborted:   aborted,
+		cancel:    cancel,
 		done:      make(chan struct{}),
-		Events:    make(chan StreamEvent, 100),
+		Events:    make(chan StreamEvent, eventBuffer(config)),
+		logger:    logger,
+		sensitive: sensitive,
 	}
+	session.sink = newEventSink(session, config)
 
 	// Create a channel to signal parsing completion
 	parseDone := make(chan struct{})
@@ -403,18 +604,39 @@ func (c *Client) Launch(config SessionConfig) (*Session, error) {
 
 	// Wait for process to complete in background
 	go func() {
+		// IMPORTANT: Wait for parsing to complete before reaping the process.
+		// cmd.Wait closes the stdout/stderr pipes, so calling it while the
+		// parsers are still reading can drop the tail of the output (including
+		// the final result). This also ensures that all output has been read and
+		// processed before the session is considered complete.
+		<-parseDone
+
 		// Wait for the command to exit
-		session.SetError(cmd.Wait())
+		waitErr := proc.Wait()
+		close(exited)
+		if runCtx.Err() == nil {
+			session.setExitError(waitErr)
+		}
 
-		// IMPORTANT: Wait for parsing to complete before signaling done.
-		// This ensures that all output has been read and processed before
-		// the session is considered complete. Without this synchronization,
-		// Wait() might return before the result is available.
-		<-parseDone
+		// Cancellation is the root cause of whatever the parsers saw after the
+		// process was killed, so it takes precedence over any earlier error
+		if runCtx.Err() != nil {
+			session.setCanceled(context.Cause(runCtx))
+		}
+		cancel(nil)
+		cleanup()
 
 		close(session.done)
 	}()
 
+	// Deliver the initial query as the first turn
+	if stdin != nil && config.Query != "" {
+		if err := session.SendUserMessage(config.Query); err != nil {
+			_ = session.Kill()
+			return nil, fmt.Errorf("failed to send initial query: %w", err)
+		}
+	}
+
 	return session, nil
 }
 
@@ -429,9 +651,6 @@ func (c *Client) LaunchAndWait(config SessionConfig) (*Result, error) {
 }
 
 // Wait blocks until the session completes and returns the result
-// TODO: Add context support to allow cancellation/timeout. This would help prevent
-// indefinite blocking when waiting for interrupted sessions or hanging processes.
-// Consider adding WaitContext(ctx context.Context) method or updating Wait() signature.
 func (s *Session) Wait() (*Result, error) {
 	<-s.done
 
@@ -442,22 +661,155 @@ func (s *Session) Wait() (*Result, error) {
 	return s.result, nil
 }
 
-// Kill terminates the session
+// WaitContext blocks until the session completes or ctx is done. If ctx is done
+// first, the Claude process is killed and, once the output has been drained,
+// a *CanceledError is returned together with any result captured so far.
+func (s *Session) WaitContext(ctx context.Context) (*Result, error) {
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		if s.cancel != nil {
+			s.cancel(ctx.Err())
+		}
+		<-s.done
+	}
+
+	var canceled *CanceledError
+	if errors.As(s.Error(), &canceled) {
+		return s.result, canceled
+	}
+	return s.Wait()
+}
+
+// userInputMessage is a single user turn written to stdin in stream-json input mode
+type userInputMessage struct {
+	Type    string `json:"type"`
+	Message struct {
+		Role    string             `json:"role"`
+		Content []userInputContent `json:"content"`
+	} `json:"message"`
+}
+
+// userInputContent is a text content block of a user turn
+type userInputContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SendUserMessage starts a new turn on a session launched with InputStreamJSON.
+// Events for the turn are delivered on the same Events channel, ending with a
+// "result" event.
+func (s *Session) SendUserMessage(text string) error {
+	if s.stdin == nil {
+		return ErrNotInteractive
+	}
+
+	msg := userInputMessage{Type: "user"}
+	msg.Message.Role = "user"
+	msg.Message.Content = []userInputContent{{Type: "text", Text: text}}
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user message: %w", err)
+	}
+
+	s.stdinMu.Lock()
+	defer s.stdinMu.Unlock()
+	if s.stdinClosed {
+		return ErrInputClosed
+	}
+	if _, err := s.stdin.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write user message: %w", err)
+	}
+	return nil
+}
+
+// CloseInput closes stdin of a session launched with InputStreamJSON. Claude
+// finishes the current turn and exits, after which Wait returns.
+func (s *Session) CloseInput() error {
+	if s.stdin == nil {
+		return ErrNotInteractive
+	}
+
+	s.stdinMu.Lock()
+	defer s.stdinMu.Unlock()
+	if s.stdinClosed {
+		return nil
+	}
+	s.stdinClosed = true
+	if err := s.stdin.Close(); err != nil && !isClosedPipeError(err) {
+		return fmt.Errorf("failed to close stdin: %w", err)
+	}
+	return nil
+}
+
+// Kill terminates the session. Local processes are killed together with the
+// subprocesses they spawned.
 func (s *Session) Kill() error {
-	if s.cmd.Process != nil {
-		return s.cmd.Process.Kill()
+	if s.proc != nil {
+		return s.proc.Kill()
 	}
 	return nil
 }
 
 // Interrupt sends a SIGINT signal to the session process
 func (s *Session) Interrupt() error {
-	if s.cmd.Process != nil {
-		return s.cmd.Process.Signal(syscall.SIGINT)
+	if s.proc != nil {
+		return s.proc.Signal(syscall.SIGINT)
 	}
 	return nil
 }
 
+// Stop shuts the session down, escalating from SIGINT to SIGTERM to SIGKILL.
+// Signals go to the whole process group, so MCP servers and tool subprocesses
+// are not orphaned. Each of the first two signals gets grace to take effect;
+// when ctx is done the remaining steps are skipped and the group is killed.
+// Stop returns once the session has finished, with the first error from
+// delivering a signal, if any.
+func (s *Session) Stop(ctx context.Context, grace time.Duration) error {
+	if s.proc == nil {
+		return nil
+	}
+
+	var firstErr error
+	record := func(err error) {
+		if err != nil && !errors.Is(err, os.ErrProcessDone) && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, sig := range []os.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		if ctx.Err() != nil {
+			break
+		}
+		record(signalGroup(s.proc, sig))
+
+		timer := time.NewTimer(grace)
+		select {
+		case <-s.done:
+			timer.Stop()
+			return firstErr
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+
+	select {
+	case <-s.done:
+		return firstErr
+	default:
+	}
+
+	// Kill the group, then cancel the run so the output pipes are closed even
+	// if a process that left the group still holds them open
+	record(s.proc.Kill())
+	if s.cancel != nil {
+		s.cancel(ErrStopped)
+	}
+	<-s.done
+	return firstErr
+}
+
 // parseStreamingJSON reads and parses streaming JSON output
 func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 	scanner := bufio.NewScanner(stdout)
@@ -489,9 +841,14 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 		var event StreamEvent
 		if err := json.Unmarshal([]byte(line), &event); err != nil {
 			// Log parse error but continue
-			log.Printf("WARNING: Failed to unmarshal event, dropping it: %v\nRaw data: %s", err, line)
+			raw := line
+			if !s.sensitive {
+				raw = redacted(line)
+			}
+			s.log().Warn("failed to unmarshal event, dropping it", "error", err, "raw", raw)
 			continue
 		}
+		event.raw = json.RawMessage(line)
 
 		// Store session ID if we see it
 		if event.SessionID != "" && s.ID == "" {
@@ -513,12 +870,14 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 				Usage:             event.Usage,
 				Error:             event.Error,
 				PermissionDenials: event.PermissionDenials,
+				StructuredOutput:  event.StructuredOutput,
 				UUID:              event.UUID,
 			}
 		}
 
-		// Send event to channel
-		s.Events <- event
+		// Deliver the event. Once the session is canceled nobody is expected
+		// to read anymore, so keep draining stdout instead of blocking.
+		s.sink.send(event)
 	}
 
 	// Check for scanner errors including buffer overflow
@@ -535,11 +894,11 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 
 	// If we got stderr output, that's an error
 	if stderrOutput := stderrBuf.String(); stderrOutput != "" {
-		s.SetError(fmt.Errorf("claude error: %s", stderrOutput))
+		s.SetError(newStderrError(stderrOutput))
 	}
 
 	// Close events channel when done parsing
-	close(s.Events)
+	s.sink.close()
 }
 
 // parseSingleJSON reads and parses single JSON result
@@ -583,7 +942,7 @@ func (s *Session) parseSingleJSON(stdout, stderr io.Reader) {
 	if stderrOutput := stderrBuf.String(); stderrOutput != "" {
 		// Don't override result if we got valid JSON
 		if s.result == nil {
-			s.SetError(fmt.Errorf("claude error: %s", stderrOutput))
+			s.SetError(newStderrError(stderrOutput))
 		}
 	}
 }
@@ -615,6 +974,6 @@ func (s *Session) parseTextOutput(stdout, stderr io.Reader) {
 
 	// If we got stderr output, that's an error
 	if stderrOutput := stderrBuf.String(); stderrOutput != "" {
-		s.SetError(fmt.Errorf("claude error: %s", stderrOutput))
+		s.SetError(newStderrError(stderrOutput))
 	}
 }
@@ -53,7 +53,7 @@ func TestBuildArgsWithDashPrefixedQuery(t *testing.T) {
 			}
 
 			// Call the private buildArgs method directly (accessible in same package)
-			args, err := client.buildArgs(config)
+			args, _, err := client.buildArgs(config)
 			if err != nil {
 				t.Fatalf("buildArgs failed: %v", err)
 			}
@@ -117,7 +117,7 @@ func TestBuildArgsWithComplexConfigs(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			args, err := client.buildArgs(tc.config)
+			args, _, err := client.buildArgs(tc.config)
 			if err != nil {
 				t.Fatalf("buildArgs failed: %v", err)
 			}
@@ -197,3 +197,49 @@ func TestBuildArgsWithComplexConfigs(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildArgsStreamJSONInput tests that interactive sessions keep the query off the command line
+func TestBuildArgsStreamJSONInput(t *testing.T) {
+	client := NewClientWithPath("/usr/bin/claude")
+
+	t.Run("stream-json input with stream-json output", func(t *testing.T) {
+		args, _, err := client.buildArgs(SessionConfig{
+			Query:        "first turn",
+			InputFormat:  InputStreamJSON,
+			OutputFormat: OutputStreamJSON,
+		})
+		if err != nil {
+			t.Fatalf("buildArgs failed: %v", err)
+		}
+
+		if args[len(args)-1] != "--print" {
+			t.Errorf("expected --print as last arg, got %v", args)
+		}
+		for _, arg := range args {
+			if arg == "--" || arg == "first turn" {
+				t.Errorf("query must be sent over stdin, not argv: %v", args)
+			}
+		}
+
+		found := false
+		for i, arg := range args {
+			if arg == "--input-format" && i+1 < len(args) && args[i+1] == "stream-json" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected --input-format stream-json in args: %v", args)
+		}
+	})
+
+	t.Run("stream-json input requires stream-json output", func(t *testing.T) {
+		_, _, err := client.buildArgs(SessionConfig{
+			Query:        "first turn",
+			InputFormat:  InputStreamJSON,
+			OutputFormat: OutputJSON,
+		})
+		if err == nil {
+			t.Fatal("expected error for stream-json input with json output")
+		}
+	})
+}
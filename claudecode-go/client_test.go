@@ -1,7 +1,10 @@
 package claudecode_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -646,3 +649,337 @@ func TestClaudeDetectionWithExcludedPaths(t *testing.T) {
 		})
 	}
 }
+
+// writeMockClaude writes an executable shell script standing in for the claude binary
+func writeMockClaude(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("failed to write mock claude: %v", err)
+	}
+	return path
+}
+
+func TestSession_WaitContextTimeout(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo '{"type":"system","subtype":"init","session_id":"mock-session"}'
+sleep 30
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "hang forever",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	// Nobody reads Events here: cancellation must still drain the parser
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = session.WaitContext(ctx)
+	if time.Since(start) > 10*time.Second {
+		t.Fatalf("WaitContext did not return promptly after deadline")
+	}
+
+	var canceled *claudecode.CanceledError
+	if !errors.As(err, &canceled) {
+		t.Fatalf("expected *CanceledError, got %T: %v", err, err)
+	}
+	assert.True(t, canceled.Timeout(), "expected timeout")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The events channel must be closed once the session is done
+	for range session.Events {
+	}
+}
+
+func TestClient_LaunchContextCancel(t *testing.T) {
+	mockPath := writeMockClaude(t, "sleep 30\n")
+	client := claudecode.NewClientWithPath(mockPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session, err := client.LaunchContext(ctx, claudecode.SessionConfig{
+		Query:        "hang forever",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	cancel()
+
+	_, err = session.Wait()
+	var canceled *claudecode.CanceledError
+	if !errors.As(err, &canceled) {
+		t.Fatalf("expected *CanceledError, got %T: %v", err, err)
+	}
+	assert.False(t, canceled.Timeout())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Launching with an already canceled context fails fast
+	_, err = client.LaunchContext(ctx, claudecode.SessionConfig{Query: "too late"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSession_StopEscalatesToProcessGroup(t *testing.T) {
+	// The mock ignores SIGINT and SIGTERM and leaves a subprocess ticking into
+	// a file, the way an MCP server or Bash tool would outlive a killed claude
+	ticks := filepath.Join(t.TempDir(), "ticks")
+	mockPath := writeMockClaude(t, `trap '' INT TERM
+(while :; do echo tick >> "$TICKS"; sleep 0.05; done) &
+echo '{"type":"system","subtype":"init","session_id":"mock-session"}'
+wait
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "ignore signals",
+		OutputFormat: claudecode.OutputStreamJSON,
+		Env:          map[string]string{"TICKS": ticks},
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+	// Wait until the traps are installed
+	<-session.Events
+	go func() {
+		for range session.Events {
+		}
+	}()
+
+	start := time.Now()
+	if err := session.Stop(context.Background(), 100*time.Millisecond); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond, "expected both grace periods to elapse")
+	assert.ErrorIs(t, session.Error(), claudecode.ErrStopped)
+
+	// The subprocess was killed with the group
+	before, err := os.ReadFile(ticks)
+	if err != nil {
+		t.Fatalf("subprocess never ran: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	after, _ := os.ReadFile(ticks)
+	assert.Equal(t, len(before), len(after), "subprocess is still running")
+}
+
+func TestSession_StopGraceful(t *testing.T) {
+	mockPath := writeMockClaude(t, `trap '' INT
+trap 'exit 0' TERM
+sleep 30 &
+echo '{"type":"system","subtype":"init","session_id":"mock-session"}'
+wait
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "exit on sigterm",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+	// Wait until the traps are installed
+	<-session.Events
+	go func() {
+		for range session.Events {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := session.Stop(ctx, 200*time.Millisecond); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+
+	var canceled *claudecode.CanceledError
+	assert.False(t, errors.As(session.Error(), &canceled), "session should not have been killed: %v", session.Error())
+
+	// Stopping a finished session is a no-op
+	assert.NoError(t, session.Stop(ctx, time.Second))
+}
+
+func TestSession_WaitContextCompleted(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo '{"type":"result","subtype":"success","session_id":"mock-session","result":"done"}'
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "finish quickly",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+	go func() {
+		for range session.Events {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := session.WaitContext(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assert.NotNil(t, result) {
+		assert.Equal(t, "done", result.Result)
+	}
+}
+
+func TestSession_SendUserMessageMultiTurn(t *testing.T) {
+	// The mock answers every stdin line with an assistant echo and a result event
+	mockPath := writeMockClaude(t, `echo '{"type":"system","subtype":"init","session_id":"mock-session"}'
+turn=0
+while IFS= read -r line; do
+  turn=$((turn + 1))
+  echo '{"type":"assistant","session_id":"mock-session","message":{"id":"msg","type":"message","role":"assistant","content":[{"type":"text","text":"got it"}]}}'
+  echo "{\"type\":\"result\",\"subtype\":\"success\",\"session_id\":\"mock-session\",\"result\":\"turn $turn\",\"num_turns\":$turn}"
+done
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "first",
+		InputFormat:  claudecode.InputStreamJSON,
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	waitForResult := func(expected string) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case event, ok := <-session.Events:
+				if !ok {
+					t.Fatalf("events channel closed before result %q", expected)
+				}
+				if event.Type == "result" {
+					assert.Equal(t, expected, event.Result)
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for result %q", expected)
+			}
+		}
+	}
+
+	waitForResult("turn 1")
+
+	if err := session.SendUserMessage("second"); err != nil {
+		t.Fatalf("failed to send second message: %v", err)
+	}
+	waitForResult("turn 2")
+
+	if err := session.CloseInput(); err != nil {
+		t.Fatalf("failed to close input: %v", err)
+	}
+	assert.ErrorIs(t, session.SendUserMessage("third"), claudecode.ErrInputClosed)
+
+	result, err := session.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, "turn 2", result.Result)
+	assert.Equal(t, 2, result.NumTurns)
+}
+
+func TestSession_SendUserMessageNotInteractive(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo '{"type":"result","subtype":"success","session_id":"mock-session","result":"done"}'
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	assert.ErrorIs(t, session.SendUserMessage("more"), claudecode.ErrNotInteractive)
+	_, _ = session.Wait()
+}
+
+func TestSession_TypedEvents(t *testing.T) {
+	mockPath := writeMockClaude(t, `echo '{"type":"system","subtype":"init","session_id":"mock-session","model":"mock-model"}'
+echo '{"type":"assistant","session_id":"mock-session","message":{"id":"msg","role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Read","input":{}}]}}'
+echo '{"type":"system","subtype":"hook_response","session_id":"mock-session","extra":true}'
+echo '{"type":"result","subtype":"success","session_id":"mock-session","result":"done"}'
+`)
+	client := claudecode.NewClientWithPath(mockPath)
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	var kinds []string
+	for event := range session.TypedEvents() {
+		switch e := event.(type) {
+		case *claudecode.SystemInitEvent:
+			assert.Equal(t, "mock-model", e.Model)
+			kinds = append(kinds, "init")
+		case *claudecode.AssistantEvent:
+			assert.IsType(t, &claudecode.ToolUseBlock{}, e.Content[0])
+			kinds = append(kinds, "assistant")
+		case *claudecode.UnknownEvent:
+			assert.Contains(t, string(e.Raw), `"extra":true`)
+			kinds = append(kinds, "unknown")
+		case *claudecode.ResultEvent:
+			assert.Equal(t, "done", e.Result)
+			kinds = append(kinds, "result")
+		}
+	}
+	assert.Equal(t, []string{"init", "assistant", "unknown", "result"}, kinds)
+
+	_, err = session.Wait()
+	assert.NoError(t, err)
+}
+
+func TestClient_LaunchLogsRedactedAndRemovesMCPConfig(t *testing.T) {
+	// The mock only reports success if it was handed a readable MCP config
+	mockPath := writeMockClaude(t, `while [ $# -gt 0 ]; do
+  if [ "$1" = "--mcp-config" ] && [ -f "$2" ]; then
+    echo '{"type":"result","subtype":"success","result":"ok","session_id":"s1"}'
+  fi
+  shift
+done
+`)
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	var logs strings.Builder
+	client := claudecode.NewClientWithPath(mockPath)
+	client.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	result, err := client.LaunchAndWait(claudecode.SessionConfig{
+		Query:        "the secret plan",
+		OutputFormat: claudecode.OutputJSON,
+		MCPConfig: &claudecode.MCPConfig{MCPServers: map[string]claudecode.MCPServer{
+			"tools": {Command: "tools-server", Env: map[string]string{"OPENAI_API_KEY": "sk-leaked"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("launch failed: %v", err)
+	}
+	assert.Equal(t, "ok", result.Result)
+
+	assert.Contains(t, logs.String(), "executing claude command")
+	assert.NotContains(t, logs.String(), "sk-leaked")
+	assert.NotContains(t, logs.String(), "the secret plan")
+
+	leftover, _ := filepath.Glob(filepath.Join(tmpDir, "mcp-config-*.json"))
+	assert.Empty(t, leftover, "the MCP config file should be removed when the session ends")
+}
@@ -0,0 +1,280 @@
+package claudecode
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// OverflowPolicy decides what happens to stream events when Session.Events is
+// full because the consumer is not keeping up
+type OverflowPolicy string
+
+const (
+	// OverflowBlock waits for the consumer. Reading the CLI's output stalls
+	// meanwhile, and with it the claude process.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest discards the oldest buffered event to make room
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowSpill writes events to a temporary file until the consumer
+	// catches up. No events are lost and they keep their order.
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+// DefaultEventBuffer is the capacity of Session.Events when
+// SessionConfig.EventBuffer is not set
+const DefaultEventBuffer = 100
+
+// eventBuffer returns the capacity of Session.Events for config
+func eventBuffer(config SessionConfig) int {
+	if config.EventBuffer > 0 {
+		return config.EventBuffer
+	}
+	return DefaultEventBuffer
+}
+
+// Dropped returns the number of events discarded by OverflowDropOldest
+func (s *Session) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Spilled returns the number of events written to disk by OverflowSpill
+func (s *Session) Spilled() int64 {
+	return s.spilled.Load()
+}
+
+// eventSink delivers parsed stream events to the consumer according to the
+// session's delivery options
+type eventSink struct {
+	s       *Session
+	handler func(StreamEvent)
+	policy  OverflowPolicy
+	spill   *spillQueue // Created on the first overflow under OverflowSpill
+	dir     string
+	pumped  chan struct{} // Closed when the spill pump has stopped
+}
+
+func newEventSink(s *Session, config SessionConfig) *eventSink {
+	policy := config.OverflowPolicy
+	if policy == "" {
+		policy = OverflowBlock
+	}
+	return &eventSink{s: s, handler: config.EventHandler, policy: policy, dir: config.SpillDir}
+}
+
+// send delivers an event. It only blocks under OverflowBlock, or while the
+// event handler runs.
+func (k *eventSink) send(event StreamEvent) {
+	if k.handler != nil {
+		k.handler(event)
+		return
+	}
+
+	switch k.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case k.s.Events <- event:
+				return
+			case <-k.s.abortedCh():
+				return
+			default:
+			}
+			select {
+			case <-k.s.Events:
+				k.s.dropped.Add(1)
+			default:
+			}
+		}
+
+	case OverflowSpill:
+		select {
+		case <-k.s.abortedCh():
+			return
+		default:
+		}
+		if k.spill == nil || k.spill.empty() {
+			select {
+			case k.s.Events <- event:
+				return
+			default:
+			}
+		}
+		if err := k.spillEvent(event); err != nil {
+			// Without a spill file the only lossless option left is to wait
+			k.s.log().Warn("failed to spill event to disk, waiting for the consumer", "error", err)
+			k.block(event)
+		}
+
+	default:
+		k.block(event)
+	}
+}
+
+// block waits until the consumer takes the event or the session is aborted
+func (k *eventSink) block(event StreamEvent) {
+	select {
+	case k.s.Events <- event:
+	case <-k.s.abortedCh():
+	}
+}
+
+func (k *eventSink) spillEvent(event StreamEvent) error {
+	if k.spill == nil {
+		spill, err := newSpillQueue(k.dir)
+		if err != nil {
+			return err
+		}
+		k.spill = spill
+		k.pumped = make(chan struct{})
+		go k.pump()
+	}
+	if err := k.spill.push(event); err != nil {
+		return err
+	}
+	k.s.spilled.Add(1)
+	return nil
+}
+
+// pump moves spilled events to Events in order until the sink is closed and
+// the spill file has been drained, or the session is aborted
+func (k *eventSink) pump() {
+	defer close(k.pumped)
+	defer k.spill.remove()
+	for {
+		event, ok, err := k.spill.pop()
+		if err != nil {
+			k.s.SetError(fmt.Errorf("failed to read spilled event: %w", err))
+			return
+		}
+		if !ok {
+			return
+		}
+		select {
+		case k.s.Events <- event:
+			k.spill.delivered()
+		case <-k.s.abortedCh():
+			return
+		}
+	}
+}
+
+// close signals that no more events will be sent. Events is closed once the
+// spilled events have been delivered, without holding up the caller.
+func (k *eventSink) close() {
+	if k.spill == nil {
+		close(k.s.Events)
+		return
+	}
+	k.spill.close()
+	go func() {
+		<-k.pumped
+		close(k.s.Events)
+	}()
+}
+
+// spillQueue is a FIFO of events backed by a temporary file
+type spillQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	path    string
+	w       *os.File
+	r       *bufio.Reader
+	rf      *os.File
+	pending int // Events pushed but not yet delivered, including one in flight
+	unread  int // Events pushed but not yet popped
+	closed  bool
+}
+
+func newSpillQueue(dir string) (*spillQueue, error) {
+	w, err := os.CreateTemp(dir, "claude-events-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spill file: %w", err)
+	}
+	rf, err := os.Open(w.Name())
+	if err != nil {
+		_ = w.Close()
+		_ = os.Remove(w.Name())
+		return nil, fmt.Errorf("failed to open spill file: %w", err)
+	}
+	q := &spillQueue{path: w.Name(), w: w, rf: rf, r: bufio.NewReader(rf)}
+	q.cond = sync.NewCond(&q.mu)
+	return q, nil
+}
+
+// empty reports whether every spilled event has been delivered, so new
+// events can bypass the file without overtaking older ones
+func (q *spillQueue) empty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending == 0
+}
+
+func (q *spillQueue) push(event StreamEvent) error {
+	line := event.raw
+	if len(line) == 0 {
+		var err error
+		if line, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, err := q.w.Write(append(append([]byte{}, line...), '\n')); err != nil {
+		return err
+	}
+	q.pending++
+	q.unread++
+	q.cond.Signal()
+	return nil
+}
+
+// pop returns the next spilled event, waiting for one to be pushed. It
+// returns false once the queue is closed and drained.
+func (q *spillQueue) pop() (StreamEvent, bool, error) {
+	q.mu.Lock()
+	for q.unread == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.unread == 0 {
+		q.mu.Unlock()
+		return StreamEvent{}, false, nil
+	}
+	q.unread--
+	q.mu.Unlock()
+
+	line, err := q.r.ReadBytes('\n')
+	if err != nil {
+		return StreamEvent{}, false, err
+	}
+	line = line[:len(line)-1]
+	var event StreamEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		return StreamEvent{}, false, err
+	}
+	event.raw = json.RawMessage(line)
+	return event, true, nil
+}
+
+// delivered marks the event returned by the last pop as handed over
+func (q *spillQueue) delivered() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending--
+}
+
+func (q *spillQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Signal()
+}
+
+func (q *spillQueue) remove() {
+	_ = q.w.Close()
+	_ = q.rf.Close()
+	_ = os.Remove(q.path)
+}
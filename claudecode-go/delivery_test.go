@@ -0,0 +1,106 @@
+package claudecode_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// burstScript prints n assistant events and a result as fast as it can
+func burstScript(n int) string {
+	return fmt.Sprintf(`i=0
+while [ $i -lt %d ]; do
+  echo "{\"type\":\"assistant\",\"session_id\":\"s1\",\"uuid\":\"$i\"}"
+  i=$((i+1))
+done
+echo '{"type":"result","subtype":"success","result":"done","session_id":"s1"}'
+`, n)
+}
+
+func TestSessionEventsSpill(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeMockClaude(t, burstScript(50)))
+	spillDir := t.TempDir()
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:          "burst",
+		OutputFormat:   claudecode.OutputStreamJSON,
+		EventBuffer:    2,
+		OverflowPolicy: claudecode.OverflowSpill,
+		SpillDir:       spillDir,
+	})
+	require.NoError(t, err)
+
+	// Nobody reads while the CLI runs, yet it is not held up
+	result, err := session.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.Result)
+	assert.Greater(t, session.Spilled(), int64(0))
+
+	var uuids []string
+	for event := range session.Events {
+		uuids = append(uuids, event.UUID)
+	}
+	require.Len(t, uuids, 51, "no event is lost")
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, fmt.Sprint(i), uuids[i], "events keep their order")
+	}
+
+	entries, err := os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the spill file is removed once drained")
+}
+
+func TestSessionEventsDropOldest(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeMockClaude(t, burstScript(50)))
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:          "burst",
+		OutputFormat:   claudecode.OutputStreamJSON,
+		EventBuffer:    5,
+		OverflowPolicy: claudecode.OverflowDropOldest,
+	})
+	require.NoError(t, err)
+
+	_, err = session.Wait()
+	require.NoError(t, err)
+
+	var events []claudecode.StreamEvent
+	for event := range session.Events {
+		events = append(events, event)
+	}
+	require.Len(t, events, 5)
+	assert.Equal(t, int64(46), session.Dropped())
+	assert.Equal(t, "46", events[0].UUID, "the newest events are kept")
+	assert.Equal(t, "result", events[4].Type)
+}
+
+func TestSessionEventHandler(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeMockClaude(t, burstScript(3)))
+
+	var mu sync.Mutex
+	var types []string
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "burst",
+		OutputFormat: claudecode.OutputStreamJSON,
+		EventHandler: func(event claudecode.StreamEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			types = append(types, event.Type)
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = session.Wait()
+	require.NoError(t, err)
+
+	_, open := <-session.Events
+	assert.False(t, open, "events go to the handler instead of the channel")
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"assistant", "assistant", "assistant", "result"}, types)
+}
@@ -0,0 +1,310 @@
+package claudecode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for interactive (stream-json input) sessions
+var (
+	// ErrNotInteractive is returned when sending input to a session that was not
+	// launched with InputStreamJSON
+	ErrNotInteractive = errors.New("session was not launched with stream-json input")
+
+	// ErrInputClosed is returned when sending input after CloseInput was called
+	ErrInputClosed = errors.New("session input is closed")
+)
+
+// ErrStopped is the cause of the *CanceledError a session ends with when Stop
+// had to kill it after the grace periods ran out
+var ErrStopped = errors.New("session stopped")
+
+// CanceledError is returned when a session is stopped because its context was
+// canceled or its deadline expired before the Claude process exited on its own.
+// It unwraps to context.Canceled or context.DeadlineExceeded, or to ErrStopped
+// when Session.Stop killed the process.
+type CanceledError struct {
+	SessionID string
+	Cause     error
+}
+
+func (e *CanceledError) Error() string {
+	reason := "canceled"
+	if e.Timeout() {
+		reason = "timed out"
+	}
+	if e.SessionID != "" {
+		return fmt.Sprintf("claude session %s %s: %v", e.SessionID, reason, e.Cause)
+	}
+	return fmt.Sprintf("claude session %s: %v", reason, e.Cause)
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Cause
+}
+
+// Timeout reports whether the session was stopped because a deadline expired
+func (e *CanceledError) Timeout() bool {
+	return errors.Is(e.Cause, context.DeadlineExceeded)
+}
+
+// ErrorKind identifies a class of Claude CLI failure. Every *ClaudeError has a
+// kind, and errors.Is(err, ErrRateLimited) etc. matches on it.
+type ErrorKind struct {
+	name      string
+	desc      string
+	retryable bool
+}
+
+func (k *ErrorKind) Error() string { return k.desc }
+
+// String returns a stable identifier suitable for storage, e.g. "rate_limited"
+func (k *ErrorKind) String() string { return k.name }
+
+// Retryable reports whether failures of this kind are transient
+func (k *ErrorKind) Retryable() bool { return k.retryable }
+
+// Failure kinds reported by ClaudeError
+var (
+	ErrRateLimited    = &ErrorKind{"rate_limited", "rate limited", true}
+	ErrOverloaded     = &ErrorKind{"overloaded", "API overloaded", true}
+	ErrNetwork        = &ErrorKind{"network", "network error", true}
+	ErrAuth           = &ErrorKind{"auth", "authentication failed", false}
+	ErrBilling        = &ErrorKind{"billing", "credit balance too low", false}
+	ErrContextTooLong = &ErrorKind{"context_too_long", "context too long", false}
+	ErrMaxTurns       = &ErrorKind{"max_turns", "max turns reached", false}
+	ErrExecution      = &ErrorKind{"execution", "error during execution", false}
+	ErrBinaryMissing  = &ErrorKind{"binary_missing", "claude binary not found", false}
+	ErrInvalidArgs    = &ErrorKind{"invalid_args", "invalid arguments", false}
+	ErrUnsupported    = &ErrorKind{"unsupported", "option not supported by this claude version", false}
+	ErrUnknown        = &ErrorKind{"unknown", "claude failed", false}
+)
+
+// errorKinds lists all kinds for LookupErrorKind
+var errorKinds = []*ErrorKind{
+	ErrRateLimited, ErrOverloaded, ErrNetwork, ErrAuth, ErrBilling, ErrContextTooLong,
+	ErrMaxTurns, ErrExecution, ErrBinaryMissing, ErrInvalidArgs, ErrUnsupported, ErrUnknown,
+}
+
+// LookupErrorKind returns the kind with the given String() name, or nil
+func LookupErrorKind(name string) *ErrorKind {
+	for _, k := range errorKinds {
+		if k.name == name {
+			return k
+		}
+	}
+	return nil
+}
+
+// ClaudeError is a classified Claude CLI failure. It is derived from the
+// result subtype, the exit code and known stderr / error message patterns.
+//
+//	var claudeErr *claudecode.ClaudeError
+//	if errors.As(err, &claudeErr) && claudeErr.Kind.Retryable() {
+//	    time.Sleep(claudeErr.RetryAfter)
+//	}
+type ClaudeError struct {
+	Kind *ErrorKind
+
+	// Message is the stderr output or result error text, if any
+	Message string
+
+	// ExitCode of the process, or -1 if unknown or the process did not exit
+	ExitCode int
+
+	// Subtype is the result event subtype, e.g. "error_max_turns"
+	Subtype string
+
+	// RetryAfter is how long to wait before retrying, when the CLI said so
+	RetryAfter time.Duration
+
+	// Err is the underlying error, if any
+	Err error
+}
+
+func (e *ClaudeError) Error() string {
+	switch {
+	case e.Message != "":
+		return "claude error: " + e.Message
+	case e.Err != nil:
+		return e.Err.Error()
+	default:
+		return "claude error: " + e.Kind.Error()
+	}
+}
+
+func (e *ClaudeError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is match the error kind
+func (e *ClaudeError) Is(target error) bool {
+	k, ok := target.(*ErrorKind)
+	return ok && k == e.Kind
+}
+
+// IsRetryable reports whether err is a ClaudeError of a transient kind
+func IsRetryable(err error) bool {
+	var claudeErr *ClaudeError
+	return errors.As(err, &claudeErr) && claudeErr.Kind.Retryable()
+}
+
+// errorPatterns maps lowercase message fragments to kinds, checked in order
+var errorPatterns = []struct {
+	kind     *ErrorKind
+	patterns []string
+}{
+	{ErrAuth, []string{"invalid api key", "authentication_error", "api error: 401", "please run /login", "oauth token has expired", "not logged in"}},
+	{ErrBilling, []string{"credit balance is too low"}},
+	{ErrRateLimited, []string{"rate_limit_error", "rate limit", "api error: 429", "usage limit reached"}},
+	{ErrOverloaded, []string{"overloaded_error", "overloaded", "api error: 529", "api error: 503"}},
+	{ErrContextTooLong, []string{"prompt is too long", "context length", "context window", "input length and `max_tokens` exceed"}},
+	{ErrInvalidArgs, []string{"unknown option", "error: option", "invalid value for", "too many arguments", "missing required argument"}},
+	{ErrNetwork, []string{"econnrefused", "econnreset", "enotfound", "etimedout", "fetch failed", "connection error", "socket hang up"}},
+}
+
+var (
+	// "retry-after: 30", "retry after 30 seconds", "try again in 2 minutes"
+	retryAfterPattern = regexp.MustCompile(`(?i)(?:retry[- ]after:?|try again in)\s*(\d+)\s*(ms|milliseconds?|s|secs?|seconds?|m|mins?|minutes?|h|hours?)?`)
+	// "Claude AI usage limit reached|1752170400"
+	usageLimitResetPattern = regexp.MustCompile(`(?i)usage limit reached\|(\d{9,})`)
+)
+
+// classifyMessage finds the kind and retry delay for an error message
+func classifyMessage(message string) (*ErrorKind, time.Duration) {
+	lower := strings.ToLower(message)
+	for _, entry := range errorPatterns {
+		for _, pattern := range entry.patterns {
+			if strings.Contains(lower, pattern) {
+				if entry.kind == ErrRateLimited {
+					return entry.kind, parseRetryAfter(message)
+				}
+				return entry.kind, 0
+			}
+		}
+	}
+	return nil, 0
+}
+
+// parseRetryAfter extracts a retry delay from a rate limit message
+func parseRetryAfter(message string) time.Duration {
+	if m := usageLimitResetPattern.FindStringSubmatch(message); m != nil {
+		if ts, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			if d := time.Until(time.Unix(ts, 0)); d > 0 {
+				return d
+			}
+		}
+		return 0
+	}
+
+	m := retryAfterPattern.FindStringSubmatch(message)
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0
+	}
+	unit := strings.ToLower(m[2])
+	switch {
+	case strings.HasPrefix(unit, "ms") || strings.HasPrefix(unit, "milli"):
+		return time.Duration(n) * time.Millisecond
+	case strings.HasPrefix(unit, "m"):
+		return time.Duration(n) * time.Minute
+	case strings.HasPrefix(unit, "h"):
+		return time.Duration(n) * time.Hour
+	default:
+		return time.Duration(n) * time.Second
+	}
+}
+
+// newStderrError classifies stderr output of a failed run
+func newStderrError(stderr string) *ClaudeError {
+	kind, retryAfter := classifyMessage(stderr)
+	if kind == nil {
+		kind = ErrUnknown
+	}
+	return &ClaudeError{Kind: kind, Message: stderr, ExitCode: -1, RetryAfter: retryAfter}
+}
+
+// newExitError classifies a process exit that produced no other error
+func newExitError(waitErr error, code int) *ClaudeError {
+	kind := ErrUnknown
+	if code == 127 {
+		// sh convention for "command not found", e.g. in remote runners
+		kind = ErrBinaryMissing
+	}
+	return &ClaudeError{Kind: kind, ExitCode: code, Err: waitErr}
+}
+
+// newStartError classifies a failure to start the process
+func newStartError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return &ClaudeError{Kind: ErrBinaryMissing, ExitCode: -1, Err: err}
+	}
+	return err
+}
+
+// invalidArgs reports a SessionConfig that cannot be turned into CLI flags
+func invalidArgs(format string, args ...interface{}) error {
+	return &ClaudeError{Kind: ErrInvalidArgs, ExitCode: -1, Err: fmt.Errorf(format, args...)}
+}
+
+// unsupported returns the error for an option that needs a newer CLI
+func unsupported(v Version, c Capability, option string) error {
+	return &ClaudeError{Kind: ErrUnsupported, ExitCode: -1, Err: fmt.Errorf(
+		"%s requires claude %s or later, but %s is installed; run `claude update` to upgrade",
+		option, CapabilityVersions[c], v)}
+}
+
+// exitCode extracts the exit code from a Process.Wait error
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	var replayErr *ExitError
+	if errors.As(err, &replayErr) {
+		return replayErr.Code
+	}
+	return -1
+}
+
+// Err returns a classified *ClaudeError if the result reports a failure, or
+// nil for successful results
+func (r *Result) Err() error {
+	if r == nil || (!r.IsError && (r.Subtype == "" || r.Subtype == "success")) {
+		return nil
+	}
+
+	message := r.Error
+	if message == "" {
+		message = r.Result
+	}
+
+	kind, retryAfter := classifyMessage(message)
+	if kind == nil {
+		switch r.Subtype {
+		case "error_max_turns":
+			kind = ErrMaxTurns
+		case "error_during_execution":
+			kind = ErrExecution
+		default:
+			kind = ErrUnknown
+		}
+	}
+	if message == "" {
+		message = kind.Error()
+	}
+	return &ClaudeError{Kind: kind, Message: message, ExitCode: -1, Subtype: r.Subtype, RetryAfter: retryAfter}
+}
@@ -0,0 +1,117 @@
+package claudecode
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClassifyMessage(t *testing.T) {
+	cases := []struct {
+		message    string
+		kind       *ErrorKind
+		retryAfter time.Duration
+	}{
+		{"API Error: 429 rate_limit_error, retry-after: 30", ErrRateLimited, 30 * time.Second},
+		{"Rate limit exceeded. Please try again in 2 minutes.", ErrRateLimited, 2 * time.Minute},
+		{"API Error: 529 {\"type\":\"overloaded_error\"}", ErrOverloaded, 0},
+		{"Invalid API key · Please run /login", ErrAuth, 0},
+		{"Credit balance is too low", ErrBilling, 0},
+		{"Prompt is too long", ErrContextTooLong, 0},
+		{"error: unknown option '--bogus'", ErrInvalidArgs, 0},
+		{"API Error: Connection error. (fetch failed)", ErrNetwork, 0},
+		{"something unexpected happened", nil, 0},
+	}
+	for _, tc := range cases {
+		kind, retryAfter := classifyMessage(tc.message)
+		if kind != tc.kind {
+			t.Errorf("classifyMessage(%q) kind = %v, want %v", tc.message, kind, tc.kind)
+		}
+		if retryAfter != tc.retryAfter {
+			t.Errorf("classifyMessage(%q) retryAfter = %v, want %v", tc.message, retryAfter, tc.retryAfter)
+		}
+	}
+}
+
+func TestParseRetryAfterUsageLimitReset(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	d := parseRetryAfter("Claude AI usage limit reached|" + strconv.FormatInt(reset, 10))
+	if d < 59*time.Minute || d > time.Hour {
+		t.Errorf("expected about an hour until reset, got %v", d)
+	}
+}
+
+func TestResultErr(t *testing.T) {
+	if err := (&Result{Subtype: "success"}).Err(); err != nil {
+		t.Errorf("expected nil for successful result, got %v", err)
+	}
+
+	err := (&Result{Subtype: "error_max_turns", IsError: true}).Err()
+	if !errors.Is(err, ErrMaxTurns) {
+		t.Errorf("expected ErrMaxTurns, got %v", err)
+	}
+
+	err = (&Result{Subtype: "success", IsError: true, Result: "API Error: 429 rate limit, retry after 10 seconds"}).Err()
+	var claudeErr *ClaudeError
+	if !errors.As(err, &claudeErr) {
+		t.Fatalf("expected *ClaudeError, got %T", err)
+	}
+	if claudeErr.Kind != ErrRateLimited || claudeErr.RetryAfter != 10*time.Second || !IsRetryable(err) {
+		t.Errorf("unexpected classification: %+v", claudeErr)
+	}
+}
+
+func TestErrorKindLookup(t *testing.T) {
+	for _, kind := range errorKinds {
+		if LookupErrorKind(kind.String()) != kind {
+			t.Errorf("lookup of %q failed", kind.String())
+		}
+	}
+	if LookupErrorKind("nope") != nil {
+		t.Error("expected nil for unknown kind")
+	}
+}
+
+func TestLaunchErrorClassification(t *testing.T) {
+	t.Run("missing binary", func(t *testing.T) {
+		client := NewClientWithPath("/nonexistent/claude")
+		_, err := client.Launch(SessionConfig{Query: "hi"})
+		if !errors.Is(err, ErrBinaryMissing) {
+			t.Errorf("expected ErrBinaryMissing, got %v", err)
+		}
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		client := NewClientWithPath("/nonexistent/claude")
+		_, err := client.Launch(SessionConfig{Query: "hi", InputFormat: InputStreamJSON})
+		if !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected ErrInvalidArgs, got %v", err)
+		}
+	})
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	t.Run("stderr with exit code", func(t *testing.T) {
+		client := NewClientWithRunner("sh", LocalRunner{})
+		session, err := client.Launch(SessionConfig{Query: "hi", OutputFormat: OutputStreamJSON})
+		if err != nil {
+			t.Fatalf("launch failed: %v", err)
+		}
+		for range session.Events {
+		}
+		// sh treats the generated flags as a script path and fails
+		_, err = session.WaitContext(context.Background())
+		var claudeErr *ClaudeError
+		if !errors.As(err, &claudeErr) {
+			t.Fatalf("expected *ClaudeError, got %T: %v", err, err)
+		}
+		if claudeErr.ExitCode == 0 || claudeErr.Message == "" {
+			t.Errorf("expected exit code and stderr to be recorded: %+v", claudeErr)
+		}
+	})
+}
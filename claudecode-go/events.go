@@ -0,0 +1,398 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event is a typed stream event. The concrete type is one of *SystemInitEvent,
+// *AssistantEvent, *UserEvent, *ResultEvent, *PartialEvent or *UnknownEvent.
+// Use a type switch or Visit to handle it.
+type Event interface {
+	// EventType returns the "type" discriminator of the event
+	EventType() string
+	isEvent()
+}
+
+// SystemInitEvent is emitted once per turn when Claude starts up
+type SystemInitEvent struct {
+	SessionID      string      `json:"session_id"`
+	UUID           string      `json:"uuid,omitempty"`
+	CWD            string      `json:"cwd"`
+	Model          string      `json:"model"`
+	PermissionMode string      `json:"permissionMode"`
+	APIKeySource   string      `json:"apiKeySource"`
+	Tools          []string    `json:"tools"`
+	MCPServers     []MCPStatus `json:"mcp_servers"`
+}
+
+// AssistantEvent carries a message produced by the model
+type AssistantEvent struct {
+	SessionID       string
+	UUID            string
+	ParentToolUseID string // Set for sub-task (Task tool) messages
+	MessageID       string
+	Model           string
+	Content         []ContentBlock
+	Usage           *Usage
+}
+
+// UserEvent carries a user message, usually tool results fed back to the model
+type UserEvent struct {
+	SessionID       string
+	UUID            string
+	ParentToolUseID string
+	Content         []ContentBlock
+}
+
+// ResultEvent is the final event of a turn
+type ResultEvent struct {
+	Subtype           string                      `json:"subtype"`
+	SessionID         string                      `json:"session_id"`
+	UUID              string                      `json:"uuid,omitempty"`
+	CostUSD           float64                     `json:"total_cost_usd"`
+	IsError           bool                        `json:"is_error"`
+	DurationMS        int                         `json:"duration_ms"`
+	DurationAPI       int                         `json:"duration_api_ms"`
+	NumTurns          int                         `json:"num_turns"`
+	Result            string                      `json:"result"`
+	Usage             *Usage                      `json:"usage,omitempty"`
+	ModelUsage        map[string]ModelUsageDetail `json:"modelUsage,omitempty"`
+	Error             string                      `json:"error,omitempty"`
+	PermissionDenials *PermissionDenials          `json:"permission_denials,omitempty"`
+	StructuredOutput  json.RawMessage             `json:"structured_output,omitempty"`
+}
+
+// UnknownEvent is any event this package has no typed representation for,
+// including system events other than init. Raw holds the original JSON.
+type UnknownEvent struct {
+	Type    string
+	Subtype string
+	Raw     json.RawMessage
+}
+
+func (*SystemInitEvent) EventType() string { return "system" }
+func (*AssistantEvent) EventType() string  { return "assistant" }
+func (*UserEvent) EventType() string       { return "user" }
+func (*ResultEvent) EventType() string     { return "result" }
+func (e *UnknownEvent) EventType() string  { return e.Type }
+
+func (*SystemInitEvent) isEvent() {}
+func (*AssistantEvent) isEvent()  {}
+func (*UserEvent) isEvent()       {}
+func (*ResultEvent) isEvent()     {}
+func (*UnknownEvent) isEvent()    {}
+
+// ToResult converts the event to the Result returned by Session.Wait
+func (e *ResultEvent) ToResult() *Result {
+	return &Result{
+		Type:              "result",
+		Subtype:           e.Subtype,
+		CostUSD:           e.CostUSD,
+		IsError:           e.IsError,
+		DurationMS:        e.DurationMS,
+		DurationAPI:       e.DurationAPI,
+		NumTurns:          e.NumTurns,
+		Result:            e.Result,
+		SessionID:         e.SessionID,
+		Usage:             e.Usage,
+		ModelUsage:        e.ModelUsage,
+		Error:             e.Error,
+		PermissionDenials: e.PermissionDenials,
+		StructuredOutput:  e.StructuredOutput,
+		UUID:              e.UUID,
+	}
+}
+
+// ContentBlock is a typed message content block. The concrete type is one of
+// *TextBlock, *ToolUseBlock, *ToolResultBlock, *ThinkingBlock or *UnknownBlock.
+type ContentBlock interface {
+	// BlockType returns the "type" discriminator of the block
+	BlockType() string
+	isContentBlock()
+}
+
+// TextBlock is plain text output
+type TextBlock struct {
+	Text string `json:"text"`
+}
+
+// ToolUseBlock is a tool invocation requested by the model
+type ToolUseBlock struct {
+	ID    string                 `json:"id"`
+	Name  string                 `json:"name"`
+	Input map[string]interface{} `json:"input"`
+}
+
+// ToolResultBlock is the output of a tool fed back to the model
+type ToolResultBlock struct {
+	ToolUseID string       `json:"tool_use_id"`
+	Content   ContentField `json:"content"`
+	IsError   bool         `json:"is_error,omitempty"`
+}
+
+// ThinkingBlock is extended thinking output
+type ThinkingBlock struct {
+	Thinking  string `json:"thinking"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// UnknownBlock is any content block this package has no typed representation
+// for. Raw holds the original JSON.
+type UnknownBlock struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (*TextBlock) BlockType() string       { return "text" }
+func (*ToolUseBlock) BlockType() string    { return "tool_use" }
+func (*ToolResultBlock) BlockType() string { return "tool_result" }
+func (*ThinkingBlock) BlockType() string   { return "thinking" }
+func (b *UnknownBlock) BlockType() string  { return b.Type }
+
+func (*TextBlock) isContentBlock()       {}
+func (*ToolUseBlock) isContentBlock()    {}
+func (*ToolResultBlock) isContentBlock() {}
+func (*ThinkingBlock) isContentBlock()   {}
+func (*UnknownBlock) isContentBlock()    {}
+
+// envelope holds the discriminators and shared fields of every stream line
+type envelope struct {
+	Type            string `json:"type"`
+	Subtype         string `json:"subtype,omitempty"`
+	SessionID       string `json:"session_id,omitempty"`
+	UUID            string `json:"uuid,omitempty"`
+	ParentToolUseID string `json:"parent_tool_use_id,omitempty"`
+}
+
+// messageEnvelope is the shape of assistant and user events
+type messageEnvelope struct {
+	Message *struct {
+		ID      string          `json:"id"`
+		Model   string          `json:"model,omitempty"`
+		Content json.RawMessage `json:"content"`
+		Usage   *Usage          `json:"usage,omitempty"`
+	} `json:"message"`
+}
+
+// DecodeEvent decodes a single stream-json line into a typed event. Lines with
+// an unrecognized type are returned as *UnknownEvent rather than an error.
+func DecodeEvent(data []byte) (Event, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	switch {
+	case env.Type == "system" && env.Subtype == "init":
+		var e SystemInitEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to decode system init event: %w", err)
+		}
+		return &e, nil
+
+	case env.Type == "assistant":
+		var msg messageEnvelope
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to decode assistant event: %w", err)
+		}
+		e := &AssistantEvent{
+			SessionID:       env.SessionID,
+			UUID:            env.UUID,
+			ParentToolUseID: env.ParentToolUseID,
+		}
+		if msg.Message != nil {
+			e.MessageID = msg.Message.ID
+			e.Model = msg.Message.Model
+			e.Usage = msg.Message.Usage
+			blocks, err := decodeContentBlocks(msg.Message.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode assistant content: %w", err)
+			}
+			e.Content = blocks
+		}
+		return e, nil
+
+	case env.Type == "user":
+		var msg messageEnvelope
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to decode user event: %w", err)
+		}
+		e := &UserEvent{
+			SessionID:       env.SessionID,
+			UUID:            env.UUID,
+			ParentToolUseID: env.ParentToolUseID,
+		}
+		if msg.Message != nil {
+			blocks, err := decodeContentBlocks(msg.Message.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode user content: %w", err)
+			}
+			e.Content = blocks
+		}
+		return e, nil
+
+	case env.Type == "result":
+		var e ResultEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to decode result event: %w", err)
+		}
+		return &e, nil
+
+	case env.Type == "stream_event":
+		return decodePartialEvent(env, data)
+	}
+
+	raw := append(json.RawMessage(nil), data...)
+	return &UnknownEvent{Type: env.Type, Subtype: env.Subtype, Raw: raw}, nil
+}
+
+// decodeContentBlocks decodes message content, which is either a plain string
+// (user prompts) or an array of typed blocks
+func decodeContentBlocks(data json.RawMessage) ([]ContentBlock, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return []ContentBlock{&TextBlock{Text: text}}, nil
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("content is neither string nor array: %w", err)
+	}
+
+	blocks := make([]ContentBlock, 0, len(raws))
+	for _, raw := range raws {
+		var head struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &head); err != nil {
+			return nil, err
+		}
+
+		var block ContentBlock
+		switch head.Type {
+		case "text":
+			block = &TextBlock{}
+		case "tool_use":
+			block = &ToolUseBlock{}
+		case "tool_result":
+			block = &ToolResultBlock{}
+		case "thinking":
+			block = &ThinkingBlock{}
+		default:
+			blocks = append(blocks, &UnknownBlock{Type: head.Type, Raw: raw})
+			continue
+		}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, fmt.Errorf("failed to decode %s block: %w", head.Type, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// Typed decodes the event into its typed representation. Events read from a
+// live session are decoded from the original JSON line, so fields that
+// StreamEvent does not model are still available on UnknownEvent.Raw.
+func (e StreamEvent) Typed() (Event, error) {
+	data := e.raw
+	if len(data) == 0 {
+		var err error
+		if data, err = json.Marshal(e); err != nil {
+			return nil, fmt.Errorf("failed to marshal event: %w", err)
+		}
+	}
+	return DecodeEvent(data)
+}
+
+// Raw returns the original JSON line the event was parsed from, or nil if the
+// event was not read from a Claude process
+func (e StreamEvent) Raw() json.RawMessage {
+	return e.raw
+}
+
+// EventVisitor handles typed events. Embed BaseVisitor to implement only the
+// methods you care about. Returning an error stops VisitEvents.
+type EventVisitor interface {
+	VisitSystemInit(*SystemInitEvent) error
+	VisitAssistant(*AssistantEvent) error
+	VisitUser(*UserEvent) error
+	VisitResult(*ResultEvent) error
+	VisitPartial(*PartialEvent) error
+	VisitUnknown(*UnknownEvent) error
+}
+
+// BaseVisitor is a no-op EventVisitor meant for embedding
+type BaseVisitor struct{}
+
+func (BaseVisitor) VisitSystemInit(*SystemInitEvent) error { return nil }
+func (BaseVisitor) VisitAssistant(*AssistantEvent) error   { return nil }
+func (BaseVisitor) VisitUser(*UserEvent) error             { return nil }
+func (BaseVisitor) VisitResult(*ResultEvent) error         { return nil }
+func (BaseVisitor) VisitPartial(*PartialEvent) error       { return nil }
+func (BaseVisitor) VisitUnknown(*UnknownEvent) error       { return nil }
+
+// Visit dispatches a typed event to the matching visitor method
+func Visit(e Event, v EventVisitor) error {
+	switch e := e.(type) {
+	case *SystemInitEvent:
+		return v.VisitSystemInit(e)
+	case *AssistantEvent:
+		return v.VisitAssistant(e)
+	case *UserEvent:
+		return v.VisitUser(e)
+	case *ResultEvent:
+		return v.VisitResult(e)
+	case *PartialEvent:
+		return v.VisitPartial(e)
+	case *UnknownEvent:
+		return v.VisitUnknown(e)
+	default:
+		return fmt.Errorf("unsupported event type %T", e)
+	}
+}
+
+// TypedEvents returns a channel of typed events decoded from Events. It
+// consumes Events, so use one or the other. Events that fail to decode are
+// logged and dropped. The channel is closed when Events is closed.
+func (s *Session) TypedEvents() <-chan Event {
+	out := make(chan Event, cap(s.Events))
+	go func() {
+		defer close(out)
+		for event := range s.Events {
+			typed, err := event.Typed()
+			if err != nil {
+				s.log().Warn("failed to decode typed event, dropping it", "error", err)
+				continue
+			}
+			select {
+			case out <- typed:
+			case <-s.abortedCh():
+			}
+		}
+	}()
+	return out
+}
+
+// VisitEvents reads Events until the channel is closed, dispatching each
+// typed event to v. It consumes Events and returns the first visitor error,
+// after which remaining events are drained so the process is not blocked.
+func (s *Session) VisitEvents(v EventVisitor) error {
+	var visitErr error
+	for event := range s.Events {
+		if visitErr != nil {
+			continue
+		}
+		typed, err := event.Typed()
+		if err != nil {
+			s.log().Warn("failed to decode typed event, dropping it", "error", err)
+			continue
+		}
+		visitErr = Visit(typed, v)
+	}
+	return visitErr
+}
@@ -0,0 +1,182 @@
+package claudecode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	t.Run("system init", func(t *testing.T) {
+		event, err := DecodeEvent([]byte(`{"type":"system","subtype":"init","session_id":"s1","cwd":"/tmp","model":"claude-sonnet","tools":["Read","Bash"],"mcp_servers":[{"name":"approvals","status":"connected"}]}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		init, ok := event.(*SystemInitEvent)
+		if !ok {
+			t.Fatalf("expected *SystemInitEvent, got %T", event)
+		}
+		if init.SessionID != "s1" || init.CWD != "/tmp" || len(init.Tools) != 2 || init.MCPServers[0].Name != "approvals" {
+			t.Errorf("unexpected init event: %+v", init)
+		}
+	})
+
+	t.Run("assistant with mixed blocks", func(t *testing.T) {
+		event, err := DecodeEvent([]byte(`{"type":"assistant","session_id":"s1","parent_tool_use_id":"toolu_parent","message":{"id":"msg_1","model":"claude-sonnet","role":"assistant","content":[
+			{"type":"thinking","thinking":"hmm","signature":"sig"},
+			{"type":"text","text":"hello"},
+			{"type":"tool_use","id":"toolu_1","name":"Read","input":{"file_path":"/tmp/a"}},
+			{"type":"server_tool_use","id":"srv_1"}
+		],"usage":{"input_tokens":10,"output_tokens":5}}}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assistant, ok := event.(*AssistantEvent)
+		if !ok {
+			t.Fatalf("expected *AssistantEvent, got %T", event)
+		}
+		if assistant.MessageID != "msg_1" || assistant.ParentToolUseID != "toolu_parent" || assistant.Usage.OutputTokens != 5 {
+			t.Errorf("unexpected assistant event: %+v", assistant)
+		}
+		if len(assistant.Content) != 4 {
+			t.Fatalf("expected 4 blocks, got %d", len(assistant.Content))
+		}
+		if b, ok := assistant.Content[0].(*ThinkingBlock); !ok || b.Thinking != "hmm" || b.Signature != "sig" {
+			t.Errorf("unexpected thinking block: %#v", assistant.Content[0])
+		}
+		if b, ok := assistant.Content[1].(*TextBlock); !ok || b.Text != "hello" {
+			t.Errorf("unexpected text block: %#v", assistant.Content[1])
+		}
+		if b, ok := assistant.Content[2].(*ToolUseBlock); !ok || b.Name != "Read" || b.Input["file_path"] != "/tmp/a" {
+			t.Errorf("unexpected tool use block: %#v", assistant.Content[2])
+		}
+		if b, ok := assistant.Content[3].(*UnknownBlock); !ok || b.Type != "server_tool_use" || len(b.Raw) == 0 {
+			t.Errorf("unexpected unknown block: %#v", assistant.Content[3])
+		}
+	})
+
+	t.Run("user with tool result", func(t *testing.T) {
+		event, err := DecodeEvent([]byte(`{"type":"user","session_id":"s1","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":[{"type":"text","text":"file body"}],"is_error":true}]}}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		user, ok := event.(*UserEvent)
+		if !ok {
+			t.Fatalf("expected *UserEvent, got %T", event)
+		}
+		block, ok := user.Content[0].(*ToolResultBlock)
+		if !ok || block.ToolUseID != "toolu_1" || block.Content.Value != "file body" || !block.IsError {
+			t.Errorf("unexpected tool result block: %#v", user.Content[0])
+		}
+	})
+
+	t.Run("user with string content", func(t *testing.T) {
+		event, err := DecodeEvent([]byte(`{"type":"user","message":{"role":"user","content":"plain prompt"}}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		user := event.(*UserEvent)
+		if b, ok := user.Content[0].(*TextBlock); !ok || b.Text != "plain prompt" {
+			t.Errorf("unexpected content: %#v", user.Content)
+		}
+	})
+
+	t.Run("result", func(t *testing.T) {
+		event, err := DecodeEvent([]byte(`{"type":"result","subtype":"success","session_id":"s1","total_cost_usd":0.25,"num_turns":3,"result":"done","modelUsage":{"claude-sonnet":{"inputTokens":1,"costUSD":0.25}}}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, ok := event.(*ResultEvent)
+		if !ok {
+			t.Fatalf("expected *ResultEvent, got %T", event)
+		}
+		converted := result.ToResult()
+		if converted.Type != "result" || converted.CostUSD != 0.25 || converted.NumTurns != 3 || converted.ModelUsage["claude-sonnet"].CostUSD != 0.25 {
+			t.Errorf("unexpected result: %+v", converted)
+		}
+	})
+
+	t.Run("unknown keeps raw json", func(t *testing.T) {
+		line := `{"type":"system","subtype":"thinking_tokens","tokens":42}`
+		event, err := DecodeEvent([]byte(line))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		unknown, ok := event.(*UnknownEvent)
+		if !ok {
+			t.Fatalf("expected *UnknownEvent, got %T", event)
+		}
+		if unknown.EventType() != "system" || unknown.Subtype != "thinking_tokens" || string(unknown.Raw) != line {
+			t.Errorf("unexpected unknown event: %+v", unknown)
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		if _, err := DecodeEvent([]byte(`{not json`)); err == nil {
+			t.Error("expected error for invalid json")
+		}
+	})
+}
+
+func TestStreamEventTyped(t *testing.T) {
+	// Events built in code have no raw line and are decoded from their marshaled form
+	event := StreamEvent{
+		Type:      "assistant",
+		SessionID: "s1",
+		Message: &Message{
+			ID:      "msg_1",
+			Role:    "assistant",
+			Content: []Content{{Type: "tool_use", ID: "toolu_1", Name: "Bash", Input: map[string]interface{}{"command": "ls"}}},
+		},
+	}
+
+	typed, err := event.Typed()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assistant := typed.(*AssistantEvent)
+	if b, ok := assistant.Content[0].(*ToolUseBlock); !ok || b.Name != "Bash" {
+		t.Errorf("unexpected content: %#v", assistant.Content)
+	}
+}
+
+type countingVisitor struct {
+	BaseVisitor
+	assistant int
+	result    *ResultEvent
+}
+
+func (v *countingVisitor) VisitAssistant(*AssistantEvent) error {
+	v.assistant++
+	return nil
+}
+
+func (v *countingVisitor) VisitResult(e *ResultEvent) error {
+	v.result = e
+	return errors.New("stop")
+}
+
+func TestVisit(t *testing.T) {
+	v := &countingVisitor{}
+	events := []Event{
+		&SystemInitEvent{SessionID: "s1"},
+		&AssistantEvent{},
+		&AssistantEvent{},
+		&UnknownEvent{Type: "stream_event"},
+	}
+	for _, e := range events {
+		if err := Visit(e, v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if v.assistant != 2 {
+		t.Errorf("expected 2 assistant visits, got %d", v.assistant)
+	}
+
+	err := Visit(&ResultEvent{Result: "done"}, v)
+	if err == nil || err.Error() != "stop" {
+		t.Errorf("expected visitor error to propagate, got %v", err)
+	}
+	if v.result == nil || v.result.Result != "done" {
+		t.Errorf("expected result to be visited")
+	}
+}
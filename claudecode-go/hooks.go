@@ -0,0 +1,209 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// HookEvent is a point in Claude's lifecycle where hooks run
+type HookEvent string
+
+const (
+	HookPreToolUse       HookEvent = "PreToolUse"       // Before a tool runs, can block it
+	HookPostToolUse      HookEvent = "PostToolUse"      // After a tool succeeds
+	HookUserPromptSubmit HookEvent = "UserPromptSubmit" // Before a prompt is sent, stdout is added as context
+	HookStop             HookEvent = "Stop"             // When the main agent finishes responding
+	HookSubagentStop     HookEvent = "SubagentStop"     // When a subagent finishes
+)
+
+// toolEvents are the hook events whose matchers select tools
+var toolEvents = map[HookEvent]bool{
+	HookPreToolUse:  true,
+	HookPostToolUse: true,
+}
+
+// HookCommand is a single hook. Claude runs the command with the hook's JSON
+// payload on stdin.
+type HookCommand struct {
+	Type    string `json:"type"`              // Always "command"
+	Command string `json:"command"`           // Shell command to run
+	Timeout int    `json:"timeout,omitempty"` // Seconds, Claude's default if zero
+}
+
+// HookMatcher groups the hooks that run for the tools matching Matcher, a
+// tool name or a regex such as "Edit|Write". An empty matcher matches every
+// tool, and is required for events that do not involve a tool.
+type HookMatcher struct {
+	Matcher string        `json:"matcher,omitempty"`
+	Hooks   []HookCommand `json:"hooks"`
+}
+
+// Hooks is the "hooks" section of Claude's settings, keyed by event. Build it
+// with the methods below:
+//
+//	hooks := claudecode.Hooks{}.
+//		PostToolUse("Edit|Write", claudecode.CommandHook("make fmt")).
+//		Stop(claudecode.HTTPHook("http://localhost:7777/hooks"))
+type Hooks map[HookEvent][]HookMatcher
+
+// CommandHook returns a hook that runs command in a shell
+func CommandHook(command string) HookCommand {
+	return HookCommand{Type: "command", Command: command}
+}
+
+// HTTPHook returns a hook that POSTs its JSON payload to url with curl. A
+// failed request is ignored, so an unreachable endpoint never blocks Claude.
+func HTTPHook(url string) HookCommand {
+	return CommandHook(fmt.Sprintf(
+		"curl -sS -o /dev/null --max-time 10 -X POST -H 'Content-Type: application/json' --data-binary @- %s || true",
+		shellQuote(url)))
+}
+
+// WithTimeout returns a copy of the hook that Claude cancels after d
+func (h HookCommand) WithTimeout(d time.Duration) HookCommand {
+	h.Timeout = int((d + time.Second - 1) / time.Second)
+	return h
+}
+
+// Add appends hooks for event, returning the updated set. The receiver may be
+// nil.
+func (h Hooks) Add(event HookEvent, matcher string, hooks ...HookCommand) Hooks {
+	if h == nil {
+		h = Hooks{}
+	}
+	h[event] = append(h[event], HookMatcher{Matcher: matcher, Hooks: hooks})
+	return h
+}
+
+// PreToolUse adds hooks that run before the tools matching matcher
+func (h Hooks) PreToolUse(matcher string, hooks ...HookCommand) Hooks {
+	return h.Add(HookPreToolUse, matcher, hooks...)
+}
+
+// PostToolUse adds hooks that run after the tools matching matcher
+func (h Hooks) PostToolUse(matcher string, hooks ...HookCommand) Hooks {
+	return h.Add(HookPostToolUse, matcher, hooks...)
+}
+
+// UserPromptSubmit adds hooks that run when a prompt is submitted
+func (h Hooks) UserPromptSubmit(hooks ...HookCommand) Hooks {
+	return h.Add(HookUserPromptSubmit, "", hooks...)
+}
+
+// Stop adds hooks that run when Claude finishes responding
+func (h Hooks) Stop(hooks ...HookCommand) Hooks {
+	return h.Add(HookStop, "", hooks...)
+}
+
+// SubagentStop adds hooks that run when a subagent finishes
+func (h Hooks) SubagentStop(hooks ...HookCommand) Hooks {
+	return h.Add(HookSubagentStop, "", hooks...)
+}
+
+// Merge returns a new set with the hooks of both h and other
+func (h Hooks) Merge(other Hooks) Hooks {
+	out := Hooks{}
+	for _, set := range []Hooks{h, other} {
+		for event, matchers := range set {
+			out[event] = append(out[event], matchers...)
+		}
+	}
+	return out
+}
+
+// validate checks the hooks before they are written to a settings file
+func (h Hooks) validate() error {
+	for event, matchers := range h {
+		switch event {
+		case HookPreToolUse, HookPostToolUse, HookUserPromptSubmit, HookStop, HookSubagentStop:
+		default:
+			return invalidArgs("unknown hook event %q", event)
+		}
+		for _, matcher := range matchers {
+			if matcher.Matcher != "" && !toolEvents[event] {
+				return invalidArgs("%s hooks do not take a matcher", event)
+			}
+			if len(matcher.Hooks) == 0 {
+				return invalidArgs("%s matcher %q has no hooks", event, matcher.Matcher)
+			}
+			for _, hook := range matcher.Hooks {
+				if hook.Type != "command" {
+					return invalidArgs("%s hook has unsupported type %q", event, hook.Type)
+				}
+				if strings.TrimSpace(hook.Command) == "" {
+					return invalidArgs("%s hook has no command", event)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeHookSettings writes settings combined with hooks to a temporary file
+// and returns its path. settings is a settings file path or inline JSON, as
+// in SessionConfig.Settings, and hooks it already defines are kept.
+func writeHookSettings(settings string, hooks Hooks) (string, error) {
+	var base []byte
+	switch trimmed := strings.TrimSpace(settings); {
+	case trimmed == "":
+	case strings.HasPrefix(trimmed, "{"):
+		base = []byte(trimmed)
+	default:
+		data, err := os.ReadFile(settings)
+		if err != nil {
+			return "", fmt.Errorf("failed to read settings: %w", err)
+		}
+		base = data
+	}
+
+	merged := map[string]json.RawMessage{}
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &merged); err != nil {
+			return "", fmt.Errorf("failed to parse settings: %w", err)
+		}
+	}
+	// Hooks are merged as raw JSON so fields this package does not model,
+	// such as other hook types, survive
+	combined := map[string][]json.RawMessage{}
+	if existing, ok := merged["hooks"]; ok {
+		if err := json.Unmarshal(existing, &combined); err != nil {
+			return "", fmt.Errorf("failed to parse hooks in settings: %w", err)
+		}
+	}
+	for event, matchers := range hooks {
+		for _, matcher := range matchers {
+			matcherJSON, err := json.Marshal(matcher)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal hooks: %w", err)
+			}
+			combined[string(event)] = append(combined[string(event)], matcherJSON)
+		}
+	}
+	hooksJSON, err := json.Marshal(combined)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal hooks: %w", err)
+	}
+	merged["hooks"] = hooksJSON
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	tmpFile, err := os.CreateTemp("", "claude-settings-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp settings file: %w", err)
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return "", fmt.Errorf("failed to write settings: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return "", fmt.Errorf("failed to write settings: %w", err)
+	}
+	return tmpFile.Name(), nil
+}
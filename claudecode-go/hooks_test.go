@@ -0,0 +1,123 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHooksBuilder(t *testing.T) {
+	hooks := Hooks{}.
+		PostToolUse("Edit|Write", CommandHook("gofmt -w .").WithTimeout(1500*time.Millisecond)).
+		Stop(CommandHook("notify-send done"))
+
+	got, err := json.Marshal(hooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"PostToolUse":[{"matcher":"Edit|Write","hooks":[{"type":"command","command":"gofmt -w .","timeout":2}]}],` +
+		`"Stop":[{"hooks":[{"type":"command","command":"notify-send done"}]}]}`
+	if string(got) != want {
+		t.Errorf("hooks JSON =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHooksValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		hooks Hooks
+		want  string
+	}{
+		{"unknown event", Hooks{}.Add("OnSave", "", CommandHook("true")), `unknown hook event "OnSave"`},
+		{"matcher on a tool-less event", Hooks{}.Add(HookStop, "Bash", CommandHook("true")), "Stop hooks do not take a matcher"},
+		{"empty command", Hooks{}.PreToolUse("Bash", CommandHook(" ")), "PreToolUse hook has no command"},
+		{"no hooks", Hooks{}.PreToolUse("Bash"), `PreToolUse matcher "Bash" has no hooks`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SessionConfig{Query: "hi", Hooks: tc.hooks}.Validate()
+			if !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Validate() = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildArgsHooksSettingsFile(t *testing.T) {
+	client := NewClientWithPath("claude")
+	args, cleanup, err := client.buildArgs(SessionConfig{
+		Query:    "hi",
+		Settings: `{"model":"sonnet","hooks":{"Stop":[{"hooks":[{"type":"prompt","prompt":"check"}]}]}}`,
+		Hooks:    Hooks{}.Stop(CommandHook("echo stop")),
+	})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+
+	var path string
+	for i, arg := range args {
+		if arg == "--settings" {
+			path = args[i+1]
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected a settings file, got args %v: %v", args, err)
+	}
+	var settings struct {
+		Model string
+		Hooks map[string][]map[string]interface{}
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings.Model != "sonnet" {
+		t.Errorf("expected the inline settings to be kept, got %s", data)
+	}
+	if len(settings.Hooks["Stop"]) != 2 || !strings.Contains(string(data), `"prompt":"check"`) {
+		t.Errorf("expected existing and new Stop hooks, got %s", data)
+	}
+
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected cleanup to remove %s", path)
+	}
+}
+
+func TestHTTPHookPostsPayload(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl not installed")
+	}
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	hook := HTTPHook(server.URL + "/hooks?session=it's")
+	cmd := exec.Command("sh", "-c", hook.Command)
+	cmd.Stdin = strings.NewReader(`{"hook_event_name":"Stop"}`)
+	out, err := cmd.CombinedOutput()
+	if err != nil || len(out) > 0 {
+		t.Fatalf("hook command failed: %v %s", err, out)
+	}
+	if body := <-received; body != `{"hook_event_name":"Stop"}` {
+		t.Errorf("server received %q", body)
+	}
+
+	// An unreachable endpoint is not an error for Claude
+	server.Close()
+	cmd = exec.Command("sh", "-c", hook.Command)
+	cmd.Stdin = strings.NewReader(`{}`)
+	if err := cmd.Run(); err != nil {
+		t.Errorf("expected failures to be ignored, got %v", err)
+	}
+}
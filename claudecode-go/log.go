@@ -0,0 +1,147 @@
+package claudecode
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// SetLogger sets the logger the client and the sessions it launches write to.
+// A nil logger restores the default, slog.Default().
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger = logger
+}
+
+// SetLogSensitive controls whether prompts, MCP server credentials and raw
+// CLI output are logged verbatim. They are redacted by default; enable this
+// only for local debugging.
+func (c *Client) SetLogSensitive(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logSensitive = enabled
+}
+
+// log returns the client's logger and whether sensitive values may be logged
+func (c *Client) log() (*slog.Logger, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.logger == nil {
+		return slog.Default(), c.logSensitive
+	}
+	return c.logger, c.logSensitive
+}
+
+// log returns the session's logger
+func (s *Session) log() *slog.Logger {
+	if s.logger == nil {
+		return slog.Default()
+	}
+	return s.logger
+}
+
+// redacted stands in for a value that is not logged
+func redacted(value string) string {
+	return fmt.Sprintf("[redacted %d bytes]", len(value))
+}
+
+var secretKeyPattern = regexp.MustCompile(`(?i)(key|token|secret|passw|auth|credential|cookie|session|private|signature)`)
+
+// secretValuePrefixes are the prefixes of well-known credential formats
+var secretValuePrefixes = []string{"sk-", "Bearer ", "Basic ", "ghp_", "gho_", "github_pat_", "xoxb-", "xoxp-", "AKIA"}
+
+// isSecret reports whether a named value looks like a credential, either by
+// its name or by its format
+func isSecret(name, value string) bool {
+	if secretKeyPattern.MatchString(name) {
+		return true
+	}
+	for _, prefix := range secretValuePrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactMap returns a copy of m with secret-looking values redacted
+func redactMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if isSecret(k, v) {
+			v = redacted(v)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// redactFlagValues redacts the values of secret-looking flags in a command
+// line, in both the "--token value" and "--token=value" forms
+func redactFlagValues(args []string) []string {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		out[i] = arg
+		if name, value, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(name, "-") {
+			if isSecret(name, value) {
+				out[i] = name + "=" + redacted(value)
+			}
+			continue
+		}
+		if isSecret("", arg) || (i > 0 && strings.HasPrefix(args[i-1], "-") && isSecret(args[i-1], "")) {
+			out[i] = redacted(arg)
+		}
+	}
+	return out
+}
+
+// Redacted returns a copy of the config that is safe to log, with
+// secret-looking env vars, headers and flag values replaced by their length
+func (c *MCPConfig) Redacted() *MCPConfig {
+	if c == nil {
+		return nil
+	}
+	out := &MCPConfig{MCPServers: make(map[string]MCPServer, len(c.MCPServers))}
+	for name, server := range c.MCPServers {
+		server.Args = redactFlagValues(server.Args)
+		server.Env = redactMap(server.Env)
+		server.Headers = redactMap(server.Headers)
+		out.MCPServers[name] = server
+	}
+	return out
+}
+
+// promptFlags take prompt text, or JSON embedding prompts, as their value
+var promptFlags = map[string]bool{
+	"--system-prompt":        true,
+	"--append-system-prompt": true,
+	"--agents":               true,
+}
+
+// redactArgs returns a CLI command line that is safe to log: the query and
+// prompts are replaced by their length, as are inline settings, which may
+// carry environment variables
+func redactArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		out[i] = arg
+		if i == 0 {
+			continue
+		}
+		prev := args[i-1]
+		switch {
+		case prev == "--":
+			out[i] = redacted(arg)
+		case promptFlags[prev]:
+			out[i] = redacted(arg)
+		case prev == "--settings" && strings.HasPrefix(strings.TrimSpace(arg), "{"):
+			out[i] = redacted(arg)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package claudecode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	args := []string{
+		"--model", "sonnet",
+		"--settings", `{"env":{"API_KEY":"sk-123"}}`,
+		"--system-prompt", "You are a pirate",
+		"--mcp-config", "/tmp/mcp-config-1.json",
+		"--print", "--", "deploy with password hunter2",
+	}
+	got := strings.Join(redactArgs(args), " ")
+
+	for _, leaked := range []string{"sk-123", "pirate", "hunter2"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("expected %q to be redacted: %s", leaked, got)
+		}
+	}
+	for _, kept := range []string{"--model sonnet", "/tmp/mcp-config-1.json", "--print --"} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("expected %q to be kept: %s", kept, got)
+		}
+	}
+
+	// Settings files are paths, not secrets
+	if got := redactArgs([]string{"--settings", "/etc/settings.json"}); got[1] != "/etc/settings.json" {
+		t.Errorf("expected settings path to be kept, got %v", got)
+	}
+}
+
+func TestRedactMCPConfig(t *testing.T) {
+	config := &MCPConfig{MCPServers: map[string]MCPServer{
+		"local": {
+			Command: "node",
+			Args:    []string{"server.js", "--api-key", "abc123", "--token=def456", "--port", "8080"},
+			Env:     map[string]string{"GITHUB_TOKEN": "t0ken", "DEBUG": "true", "UPSTREAM": "Bearer xyz"},
+		},
+		"remote": {
+			Type:    "http",
+			URL:     "https://example.com/mcp",
+			Headers: map[string]string{"Authorization": "Bearer xyz", "X-Request-Source": "hld"},
+		},
+	}}
+
+	safe := config.Redacted()
+
+	local := safe.MCPServers["local"]
+	if strings.Contains(strings.Join(local.Args, " "), "abc123") || strings.Contains(strings.Join(local.Args, " "), "def456") {
+		t.Errorf("expected flag values to be redacted: %v", local.Args)
+	}
+	if local.Args[5] != "8080" {
+		t.Errorf("expected non-secret flags to be kept: %v", local.Args)
+	}
+	if local.Env["GITHUB_TOKEN"] == "t0ken" || local.Env["UPSTREAM"] == "Bearer xyz" || local.Env["DEBUG"] != "true" {
+		t.Errorf("unexpected env redaction: %v", local.Env)
+	}
+
+	remote := safe.MCPServers["remote"]
+	if remote.Headers["Authorization"] == "Bearer xyz" || remote.Headers["X-Request-Source"] != "hld" {
+		t.Errorf("unexpected header redaction: %v", remote.Headers)
+	}
+
+	// The original config is passed to the CLI and must be left alone
+	if config.MCPServers["local"].Env["GITHUB_TOKEN"] != "t0ken" {
+		t.Error("expected the original config to be unchanged")
+	}
+}
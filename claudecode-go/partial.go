@@ -0,0 +1,357 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Partial event kinds, mirroring the Anthropic streaming API. With
+// IncludePartialMessages set, each assistant message is preceded by a
+// message_start, then a start/delta.../stop sequence per content block, and
+// finally message_delta and message_stop.
+const (
+	PartialMessageStart      = "message_start"
+	PartialContentBlockStart = "content_block_start"
+	PartialContentBlockDelta = "content_block_delta"
+	PartialContentBlockStop  = "content_block_stop"
+	PartialMessageDelta      = "message_delta"
+	PartialMessageStop       = "message_stop"
+)
+
+// PartialEvent is an incremental update to the assistant message being
+// generated, emitted as a "stream_event" line when IncludePartialMessages is
+// set. Which fields are populated depends on Kind.
+type PartialEvent struct {
+	SessionID       string
+	UUID            string
+	ParentToolUseID string
+	Kind            string // One of the Partial* kinds, or an unrecognized API event type
+
+	MessageID  string       // message_start
+	Model      string       // message_start
+	Index      int          // content_block_start, content_block_delta, content_block_stop
+	Block      ContentBlock // content_block_start, the initial state of the block
+	Delta      Delta        // content_block_delta
+	StopReason string       // message_delta
+	Usage      *Usage       // message_start, message_delta
+}
+
+func (*PartialEvent) EventType() string { return "stream_event" }
+func (*PartialEvent) isEvent()          {}
+
+// Delta is an increment to a content block. The concrete type is one of
+// *TextDelta, *InputJSONDelta, *ThinkingDelta, *SignatureDelta or *UnknownDelta.
+type Delta interface {
+	// DeltaType returns the "type" discriminator of the delta
+	DeltaType() string
+	isDelta()
+}
+
+// TextDelta appends text to a text block
+type TextDelta struct {
+	Text string `json:"text"`
+}
+
+// InputJSONDelta appends a fragment of the JSON input of a tool_use block. The
+// fragments only form valid JSON once the block is complete.
+type InputJSONDelta struct {
+	PartialJSON string `json:"partial_json"`
+}
+
+// ThinkingDelta appends text to a thinking block
+type ThinkingDelta struct {
+	Thinking string `json:"thinking"`
+}
+
+// SignatureDelta sets the signature of a thinking block
+type SignatureDelta struct {
+	Signature string `json:"signature"`
+}
+
+// UnknownDelta is any delta this package has no typed representation for.
+// Raw holds the original JSON.
+type UnknownDelta struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (*TextDelta) DeltaType() string      { return "text_delta" }
+func (*InputJSONDelta) DeltaType() string { return "input_json_delta" }
+func (*ThinkingDelta) DeltaType() string  { return "thinking_delta" }
+func (*SignatureDelta) DeltaType() string { return "signature_delta" }
+func (d *UnknownDelta) DeltaType() string { return d.Type }
+
+func (*TextDelta) isDelta()      {}
+func (*InputJSONDelta) isDelta() {}
+func (*ThinkingDelta) isDelta()  {}
+func (*SignatureDelta) isDelta() {}
+func (*UnknownDelta) isDelta()   {}
+
+// partialEnvelope is the shape of stream_event lines
+type partialEnvelope struct {
+	Event struct {
+		Type    string `json:"type"`
+		Index   int    `json:"index"`
+		Message *struct {
+			ID    string `json:"id"`
+			Model string `json:"model"`
+			Usage *Usage `json:"usage,omitempty"`
+		} `json:"message,omitempty"`
+		ContentBlock json.RawMessage `json:"content_block,omitempty"`
+		Delta        json.RawMessage `json:"delta,omitempty"`
+		Usage        *Usage          `json:"usage,omitempty"`
+	} `json:"event"`
+}
+
+// decodePartialEvent decodes a stream_event line
+func decodePartialEvent(env envelope, data []byte) (*PartialEvent, error) {
+	var p partialEnvelope
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode stream event: %w", err)
+	}
+
+	e := &PartialEvent{
+		SessionID:       env.SessionID,
+		UUID:            env.UUID,
+		ParentToolUseID: env.ParentToolUseID,
+		Kind:            p.Event.Type,
+		Index:           p.Event.Index,
+		Usage:           p.Event.Usage,
+	}
+
+	switch e.Kind {
+	case PartialMessageStart:
+		if p.Event.Message != nil {
+			e.MessageID = p.Event.Message.ID
+			e.Model = p.Event.Message.Model
+			e.Usage = p.Event.Message.Usage
+		}
+
+	case PartialContentBlockStart:
+		blocks, err := decodeContentBlocks(json.RawMessage("[" + string(p.Event.ContentBlock) + "]"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode content block start: %w", err)
+		}
+		if len(blocks) == 1 {
+			e.Block = blocks[0]
+		}
+
+	case PartialContentBlockDelta:
+		delta, err := decodeDelta(p.Event.Delta)
+		if err != nil {
+			return nil, err
+		}
+		e.Delta = delta
+
+	case PartialMessageDelta:
+		var d struct {
+			StopReason string `json:"stop_reason"`
+		}
+		if len(p.Event.Delta) > 0 {
+			if err := json.Unmarshal(p.Event.Delta, &d); err != nil {
+				return nil, fmt.Errorf("failed to decode message delta: %w", err)
+			}
+		}
+		e.StopReason = d.StopReason
+	}
+	return e, nil
+}
+
+func decodeDelta(data json.RawMessage) (Delta, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("failed to decode delta: %w", err)
+	}
+
+	var delta Delta
+	switch head.Type {
+	case "text_delta":
+		delta = &TextDelta{}
+	case "input_json_delta":
+		delta = &InputJSONDelta{}
+	case "thinking_delta":
+		delta = &ThinkingDelta{}
+	case "signature_delta":
+		delta = &SignatureDelta{}
+	default:
+		return &UnknownDelta{Type: head.Type, Raw: append(json.RawMessage(nil), data...)}, nil
+	}
+	if err := json.Unmarshal(data, delta); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", head.Type, err)
+	}
+	return delta, nil
+}
+
+// MessageAssembler rebuilds content blocks from the partial events of a
+// stream. Feed it every *PartialEvent in order; a message_start resets it for
+// the next message. It is not safe for concurrent use.
+type MessageAssembler struct {
+	messageID string
+	model     string
+	blocks    map[int]*assemblingBlock
+	complete  bool
+}
+
+// assemblingBlock is a content block under construction
+type assemblingBlock struct {
+	block ContentBlock
+	input strings.Builder // Accumulated tool_use input JSON
+	done  bool
+}
+
+// NewMessageAssembler creates an empty assembler
+func NewMessageAssembler() *MessageAssembler {
+	return &MessageAssembler{blocks: make(map[int]*assemblingBlock)}
+}
+
+// Add applies a partial event. When the event completes a content block, the
+// finished block is returned along with true.
+func (a *MessageAssembler) Add(e *PartialEvent) (ContentBlock, bool, error) {
+	switch e.Kind {
+	case PartialMessageStart:
+		a.messageID = e.MessageID
+		a.model = e.Model
+		a.blocks = make(map[int]*assemblingBlock)
+		a.complete = false
+
+	case PartialContentBlockStart:
+		if e.Block == nil {
+			return nil, false, fmt.Errorf("content block %d started without a block", e.Index)
+		}
+		a.blocks[e.Index] = &assemblingBlock{block: copyBlock(e.Block)}
+
+	case PartialContentBlockDelta:
+		b, ok := a.blocks[e.Index]
+		if !ok {
+			return nil, false, fmt.Errorf("delta for unknown content block %d", e.Index)
+		}
+		if err := b.apply(e.Delta); err != nil {
+			return nil, false, fmt.Errorf("content block %d: %w", e.Index, err)
+		}
+
+	case PartialContentBlockStop:
+		b, ok := a.blocks[e.Index]
+		if !ok {
+			return nil, false, fmt.Errorf("stop for unknown content block %d", e.Index)
+		}
+		if err := b.finish(); err != nil {
+			return nil, false, fmt.Errorf("content block %d: %w", e.Index, err)
+		}
+		return b.block, true, nil
+
+	case PartialMessageStop:
+		a.complete = true
+	}
+	return nil, false, nil
+}
+
+// MessageID returns the ID of the message being assembled
+func (a *MessageAssembler) MessageID() string {
+	return a.messageID
+}
+
+// Complete reports whether message_stop has been seen for the current message
+func (a *MessageAssembler) Complete() bool {
+	return a.complete
+}
+
+// Block returns the block at index as assembled so far. Tool inputs are only
+// populated once the block is complete.
+func (a *MessageAssembler) Block(index int) (ContentBlock, bool) {
+	b, ok := a.blocks[index]
+	if !ok {
+		return nil, false
+	}
+	return b.block, true
+}
+
+// Blocks returns the blocks of the current message in index order, including
+// blocks that are still being generated
+func (a *MessageAssembler) Blocks() []ContentBlock {
+	indexes := make([]int, 0, len(a.blocks))
+	for i := range a.blocks {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	blocks := make([]ContentBlock, 0, len(indexes))
+	for _, i := range indexes {
+		blocks = append(blocks, a.blocks[i].block)
+	}
+	return blocks
+}
+
+// Message returns the assembled message as an AssistantEvent, as if it had
+// been received whole
+func (a *MessageAssembler) Message() *AssistantEvent {
+	return &AssistantEvent{MessageID: a.messageID, Model: a.model, Content: a.Blocks()}
+}
+
+func (b *assemblingBlock) apply(delta Delta) error {
+	if b.done {
+		return fmt.Errorf("delta after block stop")
+	}
+	switch d := delta.(type) {
+	case *TextDelta:
+		if block, ok := b.block.(*TextBlock); ok {
+			block.Text += d.Text
+			return nil
+		}
+	case *ThinkingDelta:
+		if block, ok := b.block.(*ThinkingBlock); ok {
+			block.Thinking += d.Thinking
+			return nil
+		}
+	case *SignatureDelta:
+		if block, ok := b.block.(*ThinkingBlock); ok {
+			block.Signature += d.Signature
+			return nil
+		}
+	case *InputJSONDelta:
+		if _, ok := b.block.(*ToolUseBlock); ok {
+			b.input.WriteString(d.PartialJSON)
+			return nil
+		}
+	case *UnknownDelta:
+		// Nothing to apply, but newer CLIs should not break assembly
+		return nil
+	}
+	return fmt.Errorf("%s does not apply to %s block", delta.DeltaType(), b.block.BlockType())
+}
+
+func (b *assemblingBlock) finish() error {
+	b.done = true
+	block, ok := b.block.(*ToolUseBlock)
+	if !ok || b.input.Len() == 0 {
+		return nil
+	}
+	var input map[string]interface{}
+	if err := json.Unmarshal([]byte(b.input.String()), &input); err != nil {
+		return fmt.Errorf("invalid tool input: %w", err)
+	}
+	block.Input = input
+	return nil
+}
+
+// copyBlock returns a copy of a block so deltas do not modify the event
+func copyBlock(block ContentBlock) ContentBlock {
+	switch b := block.(type) {
+	case *TextBlock:
+		c := *b
+		return &c
+	case *ThinkingBlock:
+		c := *b
+		return &c
+	case *ToolUseBlock:
+		c := *b
+		return &c
+	case *ToolResultBlock:
+		c := *b
+		return &c
+	}
+	return block
+}
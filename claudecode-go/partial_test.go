@@ -0,0 +1,137 @@
+package claudecode
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// partialStream is a text block, a tool call and a thinking block as emitted
+// with --include-partial-messages
+const partialStream = `{"type":"stream_event","session_id":"s1","uuid":"u1","event":{"type":"message_start","message":{"id":"msg_1","model":"claude-sonnet","usage":{"input_tokens":12,"output_tokens":1}}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"Let me "}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"look."}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":0,"delta":{"type":"signature_delta","signature":"sig"}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_stop","index":0}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Reading "}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"the file"}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_stop","index":1}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_start","index":2,"content_block":{"type":"tool_use","id":"toolu_1","name":"Read","input":{}}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"{\"file_"}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"path\": \"/tmp/a\"}"}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"content_block_stop","index":2}}
+{"type":"stream_event","session_id":"s1","event":{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":42}}}
+{"type":"stream_event","session_id":"s1","event":{"type":"message_stop"}}`
+
+func TestDecodePartialEvent(t *testing.T) {
+	lines := strings.Split(partialStream, "\n")
+
+	event, err := DecodeEvent([]byte(lines[0]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	start, ok := event.(*PartialEvent)
+	if !ok {
+		t.Fatalf("expected *PartialEvent, got %T", event)
+	}
+	if start.Kind != PartialMessageStart || start.MessageID != "msg_1" || start.Model != "claude-sonnet" || start.UUID != "u1" || start.Usage.InputTokens != 12 {
+		t.Errorf("unexpected message start: %+v", start)
+	}
+
+	event, err = DecodeEvent([]byte(lines[7]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	delta := event.(*PartialEvent)
+	if d, ok := delta.Delta.(*TextDelta); !ok || d.Text != "Reading " || delta.Index != 1 {
+		t.Errorf("unexpected text delta: %+v", delta)
+	}
+
+	event, err = DecodeEvent([]byte(lines[14]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md := event.(*PartialEvent); md.StopReason != "tool_use" || md.Usage.OutputTokens != 42 {
+		t.Errorf("unexpected message delta: %+v", md)
+	}
+
+	event, err = DecodeEvent([]byte(`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"citations_delta","citation":{}}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d, ok := event.(*PartialEvent).Delta.(*UnknownDelta); !ok || d.Type != "citations_delta" || len(d.Raw) == 0 {
+		t.Errorf("expected unknown delta, got %#v", event.(*PartialEvent).Delta)
+	}
+}
+
+func TestMessageAssembler(t *testing.T) {
+	a := NewMessageAssembler()
+	var completed []ContentBlock
+	for _, line := range strings.Split(partialStream, "\n") {
+		event, err := DecodeEvent([]byte(line))
+		if err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		block, done, err := a.Add(event.(*PartialEvent))
+		if err != nil {
+			t.Fatalf("assemble failed: %v", err)
+		}
+		if done {
+			completed = append(completed, block)
+		}
+
+		// In-progress text is visible before the block completes
+		if p := event.(*PartialEvent); p.Index == 1 && p.Kind == PartialContentBlockDelta {
+			if b, _ := a.Block(1); !strings.HasPrefix("Reading the file", b.(*TextBlock).Text) {
+				t.Errorf("unexpected in-progress text %q", b.(*TextBlock).Text)
+			}
+		}
+	}
+
+	if len(completed) != 3 || !a.Complete() || a.MessageID() != "msg_1" {
+		t.Fatalf("expected 3 completed blocks of a complete message, got %d (complete=%v)", len(completed), a.Complete())
+	}
+	if b := completed[0].(*ThinkingBlock); b.Thinking != "Let me look." || b.Signature != "sig" {
+		t.Errorf("unexpected thinking block: %+v", b)
+	}
+	if b := completed[1].(*TextBlock); b.Text != "Reading the file" {
+		t.Errorf("unexpected text block: %+v", b)
+	}
+	if b := completed[2].(*ToolUseBlock); b.ID != "toolu_1" || b.Input["file_path"] != "/tmp/a" {
+		t.Errorf("unexpected tool use block: %+v", b)
+	}
+
+	msg := a.Message()
+	if len(msg.Content) != 3 || msg.Model != "claude-sonnet" {
+		t.Errorf("unexpected assembled message: %+v", msg)
+	}
+
+	// A new message resets the assembler
+	if _, _, err := a.Add(&PartialEvent{Kind: PartialMessageStart, MessageID: "msg_2"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Blocks()) != 0 || a.Complete() {
+		t.Errorf("expected assembler to reset on message_start")
+	}
+	if _, _, err := a.Add(&PartialEvent{Kind: PartialContentBlockDelta, Index: 0, Delta: &TextDelta{Text: "x"}}); err == nil {
+		t.Error("expected error for delta without block start")
+	}
+}
+
+func TestIncludePartialMessagesArgs(t *testing.T) {
+	client := &Client{claudePath: "claude"}
+
+	args, _, err := client.buildArgs(SessionConfig{Query: "hi", OutputFormat: OutputStreamJSON, IncludePartialMessages: true})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	if !slices.Contains(args, "--include-partial-messages") {
+		t.Errorf("expected --include-partial-messages in %v", args)
+	}
+
+	if _, _, err := client.buildArgs(SessionConfig{Query: "hi", OutputFormat: OutputJSON, IncludePartialMessages: true}); err == nil {
+		t.Error("expected partial messages without stream-json output to be rejected")
+	}
+}
@@ -0,0 +1,167 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ModelPrice is the price of a model in USD per million tokens. Cache prices
+// left at zero are derived from Input using the standard multipliers: 1.25x
+// for 5 minute cache writes, 2x for 1 hour cache writes and 0.1x for reads.
+type ModelPrice struct {
+	Input        float64 `json:"input"`
+	Output       float64 `json:"output"`
+	CacheWrite5m float64 `json:"cache_write_5m,omitempty"`
+	CacheWrite1h float64 `json:"cache_write_1h,omitempty"`
+	CacheRead    float64 `json:"cache_read,omitempty"`
+}
+
+// withDerivedCachePrices fills in cache prices that were not set
+func (p ModelPrice) withDerivedCachePrices() ModelPrice {
+	if p.CacheWrite5m == 0 {
+		p.CacheWrite5m = p.Input * 1.25
+	}
+	if p.CacheWrite1h == 0 {
+		p.CacheWrite1h = p.Input * 2
+	}
+	if p.CacheRead == 0 {
+		p.CacheRead = p.Input * 0.1
+	}
+	return p
+}
+
+// Cost returns the cost of u in USD. Cache creation tokens without a TTL
+// breakdown are charged at the 5 minute rate.
+func (p ModelPrice) Cost(u *Usage) float64 {
+	if u == nil {
+		return 0
+	}
+	p = p.withDerivedCachePrices()
+
+	write5m, write1h := u.CacheCreationInputTokens, 0
+	if u.CacheCreation != nil && u.CacheCreation.Ephemeral5MInputTokens+u.CacheCreation.Ephemeral1HInputTokens > 0 {
+		write5m = u.CacheCreation.Ephemeral5MInputTokens
+		write1h = u.CacheCreation.Ephemeral1HInputTokens
+	}
+
+	total := float64(u.InputTokens)*p.Input +
+		float64(u.OutputTokens)*p.Output +
+		float64(write5m)*p.CacheWrite5m +
+		float64(write1h)*p.CacheWrite1h +
+		float64(u.CacheReadInputTokens)*p.CacheRead
+	return total / 1_000_000
+}
+
+// defaultPrices are Anthropic's list prices, keyed by model ID prefix. Opus
+// 4 and 4.1 keep their launch price under their own keys, so the family key
+// prices newer Opus 4 releases at the current one.
+var defaultPrices = map[string]ModelPrice{
+	"claude-opus-4":          {Input: 5, Output: 25},
+	"claude-opus-4-0":        {Input: 15, Output: 75},
+	"claude-opus-4-20250514": {Input: 15, Output: 75},
+	"claude-opus-4-1":        {Input: 15, Output: 75},
+	"claude-sonnet-4":        {Input: 3, Output: 15},
+	"claude-haiku-4-5":       {Input: 1, Output: 5},
+	"claude-3-7-sonnet":      {Input: 3, Output: 15},
+	"claude-3-5-sonnet":      {Input: 3, Output: 15},
+	"claude-3-5-haiku":       {Input: 0.8, Output: 4},
+	"claude-3-opus":          {Input: 15, Output: 75},
+	"claude-3-haiku":         {Input: 0.25, Output: 1.25},
+}
+
+// PricingTable maps model IDs to prices. A model matches the longest key
+// that is a prefix of its ID, so "claude-sonnet-4" prices every dated
+// Sonnet 4 release. Provider prefixes such as "anthropic/" are ignored when
+// nothing matches the full ID. A PricingTable is safe for concurrent use.
+type PricingTable struct {
+	mu     sync.RWMutex
+	prices map[string]ModelPrice
+}
+
+// NewPricingTable returns a table holding prices
+func NewPricingTable(prices map[string]ModelPrice) *PricingTable {
+	t := &PricingTable{prices: make(map[string]ModelPrice, len(prices))}
+	for model, price := range prices {
+		t.prices[model] = price
+	}
+	return t
+}
+
+// DefaultPricing returns a table with Anthropic's list prices
+func DefaultPricing() *PricingTable {
+	return NewPricingTable(defaultPrices)
+}
+
+// pricingFile is the format read by LoadPricing
+type pricingFile struct {
+	Models map[string]ModelPrice `json:"models"`
+}
+
+// LoadPricing returns the default prices overridden and extended by the
+// models in a JSON file of the form
+//
+//	{"models": {"openrouter/qwen3-coder": {"input": 0.4, "output": 1.6}}}
+func LoadPricing(path string) (*PricingTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing file: %w", err)
+	}
+	var file pricingFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse pricing file %s: %w", path, err)
+	}
+
+	t := DefaultPricing()
+	for model, price := range file.Models {
+		if price.Input < 0 || price.Output < 0 || price.CacheWrite5m < 0 || price.CacheWrite1h < 0 || price.CacheRead < 0 {
+			return nil, fmt.Errorf("pricing file %s: negative price for %s", path, model)
+		}
+		t.Set(model, price)
+	}
+	return t, nil
+}
+
+// Set adds or replaces the price for a model ID prefix
+func (t *PricingTable) Set(model string, price ModelPrice) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prices[model] = price
+}
+
+// Lookup returns the price for a model ID
+func (t *PricingTable) Lookup(model string) (ModelPrice, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if price, ok := t.lookup(model); ok {
+		return price, true
+	}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		return t.lookup(model[i+1:])
+	}
+	return ModelPrice{}, false
+}
+
+func (t *PricingTable) lookup(model string) (ModelPrice, bool) {
+	var best string
+	var found bool
+	for key := range t.prices {
+		if strings.HasPrefix(model, key) && (!found || len(key) > len(best)) {
+			best, found = key, true
+		}
+	}
+	return t.prices[best], found
+}
+
+// Cost returns the cost of u for a model in USD, and false if the model has
+// no price
+func (t *PricingTable) Cost(model string, u *Usage) (float64, bool) {
+	price, ok := t.Lookup(model)
+	if !ok {
+		return 0, false
+	}
+	return price.Cost(u), true
+}
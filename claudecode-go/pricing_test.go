@@ -0,0 +1,93 @@
+package claudecode
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPricingLookup(t *testing.T) {
+	table := DefaultPricing()
+
+	testCases := []struct {
+		model string
+		input float64
+		found bool
+	}{
+		{"claude-sonnet-4-5-20250929", 3, true},
+		{"claude-opus-4-1-20250805", 15, true},
+		{"claude-opus-4-20250514", 15, true},
+		{"claude-opus-4-0", 15, true},
+		{"claude-opus-4-5-20251101", 5, true},
+		{"claude-opus-4-6", 5, true},
+		{"anthropic/claude-3-5-haiku-20241022", 0.8, true},
+		{"gpt-4o", 0, false},
+	}
+	for _, tc := range testCases {
+		price, ok := table.Lookup(tc.model)
+		if ok != tc.found || price.Input != tc.input {
+			t.Errorf("Lookup(%q) = %+v, %v; want input %v, %v", tc.model, price, ok, tc.input, tc.found)
+		}
+	}
+}
+
+func TestModelPriceCost(t *testing.T) {
+	price := ModelPrice{Input: 3, Output: 15}
+
+	usage := &Usage{
+		InputTokens:              1_000_000,
+		OutputTokens:             100_000,
+		CacheReadInputTokens:     2_000_000,
+		CacheCreationInputTokens: 400_000,
+	}
+	// 3 + 1.5 + 2*0.3 + 0.4*3.75
+	if cost := price.Cost(usage); math.Abs(cost-6.6) > 1e-9 {
+		t.Errorf("expected 6.6, got %v", cost)
+	}
+
+	// The TTL breakdown takes precedence over the 5 minute default
+	usage.CacheCreation = &CacheCreation{Ephemeral5MInputTokens: 100_000, Ephemeral1HInputTokens: 300_000}
+	// 3 + 1.5 + 0.6 + 0.1*3.75 + 0.3*6
+	if cost := price.Cost(usage); math.Abs(cost-7.275) > 1e-9 {
+		t.Errorf("expected 7.275, got %v", cost)
+	}
+
+	if cost := price.Cost(nil); cost != 0 {
+		t.Errorf("expected no cost without usage, got %v", cost)
+	}
+}
+
+func TestLoadPricing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pricing.json")
+	err := os.WriteFile(path, []byte(`{"models": {
+		"qwen3-coder": {"input": 0.4, "output": 1.6, "cache_read": 0.04},
+		"claude-sonnet-4": {"input": 2, "output": 10}
+	}}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := LoadPricing(path)
+	if err != nil {
+		t.Fatalf("LoadPricing failed: %v", err)
+	}
+
+	cost, ok := table.Cost("openrouter/qwen3-coder", &Usage{InputTokens: 1_000_000, CacheReadInputTokens: 1_000_000})
+	if !ok || math.Abs(cost-0.44) > 1e-9 {
+		t.Errorf("expected 0.44 for the added model, got %v, %v", cost, ok)
+	}
+	if price, _ := table.Lookup("claude-sonnet-4-20250514"); price.Input != 2 {
+		t.Errorf("expected the file to override the default price, got %+v", price)
+	}
+	if _, ok := table.Lookup("claude-3-haiku-20240307"); !ok {
+		t.Error("expected defaults to be kept")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"models": {"bad": {"input": -1}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPricing(path); err == nil {
+		t.Error("expected negative prices to be rejected")
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !unix
+
+package claudecode
+
+import (
+	"os"
+	"os/exec"
+)
+
+const processGroupsSupported = false
+
+// setProcessGroup is a no-op where process groups are not available
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalProcessGroup signals only pid where process groups are not available
+func signalProcessGroup(pid int, sig os.Signal) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Signal(sig)
+}
@@ -0,0 +1,445 @@
+package claudecode
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RunSpec describes a single claude invocation handed to a Runner
+type RunSpec struct {
+	// Path is the claude binary as seen by the runner's target host
+	Path string
+	// Args are the command line arguments produced by buildArgs
+	Args []string
+	// Env holds variables to set on top of the target environment
+	Env map[string]string
+	// WorkingDir is the directory to run in, empty for the runner default
+	WorkingDir string
+	// Stdin requests a stdin pipe (stream-json input mode)
+	Stdin bool
+}
+
+// Runner starts claude processes for a Client. LocalRunner is the default.
+// Implementations let sessions run on remote or sandboxed hosts, or be
+// replaced by in-memory fakes in tests.
+//
+// Note that buildArgs writes the MCP config to a local temp file and resolves
+// AdditionalDirectories locally, so remote runners need those paths to exist
+// on the target host.
+type Runner interface {
+	// Start launches the process. ctx only bounds starting it; sessions stop
+	// running processes through Process.Kill.
+	Start(ctx context.Context, spec RunSpec) (Process, error)
+}
+
+// Process is a running claude invocation started by a Runner
+type Process interface {
+	// Stdout returns the process stdout stream
+	Stdout() io.ReadCloser
+	// Stderr returns the process stderr stream
+	Stderr() io.ReadCloser
+	// Stdin returns the process stdin stream, nil unless RunSpec.Stdin was set
+	Stdin() io.WriteCloser
+	// Wait blocks until the process exits. It is called only after stdout and
+	// stderr have been read to EOF.
+	Wait() error
+	// Signal delivers a signal such as SIGINT to the process
+	Signal(sig os.Signal) error
+	// Kill forcefully terminates the process
+	Kill() error
+}
+
+// GroupSignaler is implemented by processes started in their own process
+// group. SignalGroup reaches the MCP servers and tool subprocesses claude has
+// spawned as well as claude itself. Session.Stop falls back to Signal for
+// processes that do not implement it.
+type GroupSignaler interface {
+	SignalGroup(sig os.Signal) error
+}
+
+// signalGroup delivers sig to p's process group if it has one, otherwise to p
+func signalGroup(p Process, sig os.Signal) error {
+	if g, ok := p.(GroupSignaler); ok {
+		return g.SignalGroup(sig)
+	}
+	return p.Signal(sig)
+}
+
+// cmdProcess is a Process backed by a local exec.Cmd
+type cmdProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+	group  bool // Started as the leader of its own process group
+}
+
+// startCmd wires up pipes for cmd and starts it
+func startCmd(cmd *exec.Cmd, withStdin bool) (*cmdProcess, error) {
+	p := &cmdProcess{cmd: cmd}
+
+	var err error
+	if p.stdout, err = cmd.StdoutPipe(); err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	if p.stderr, err = cmd.StderrPipe(); err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if withStdin {
+		if p.stdin, err = cmd.StdinPipe(); err != nil {
+			return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *cmdProcess) Stdout() io.ReadCloser { return p.stdout }
+func (p *cmdProcess) Stderr() io.ReadCloser { return p.stderr }
+func (p *cmdProcess) Stdin() io.WriteCloser { return p.stdin }
+func (p *cmdProcess) Wait() error           { return p.cmd.Wait() }
+
+func (p *cmdProcess) Signal(sig os.Signal) error {
+	if p.cmd.Process == nil {
+		return nil
+	}
+	return p.cmd.Process.Signal(sig)
+}
+
+func (p *cmdProcess) SignalGroup(sig os.Signal) error {
+	if !p.group {
+		return p.Signal(sig)
+	}
+	if p.cmd.Process == nil {
+		return nil
+	}
+	return signalProcessGroup(p.cmd.Process.Pid, sig)
+}
+
+func (p *cmdProcess) Kill() error {
+	if p.cmd.Process == nil {
+		return nil
+	}
+	if p.group {
+		return signalProcessGroup(p.cmd.Process.Pid, os.Kill)
+	}
+	return p.cmd.Process.Kill()
+}
+
+// LocalRunner runs claude as a child process of the current process. The
+// child leads its own process group, so Kill and Session.Stop also terminate
+// the subprocesses it spawns, and a Ctrl-C aimed at the parent does not reach
+// it directly.
+type LocalRunner struct{}
+
+// Start implements Runner
+func (LocalRunner) Start(ctx context.Context, spec RunSpec) (Process, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(spec.Path, spec.Args...)
+
+	// Set environment variables if specified
+	if len(spec.Env) > 0 {
+		cmd.Env = os.Environ() // Start with current environment
+		for key, value := range spec.Env {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+
+	// Set working directory if specified
+	if spec.WorkingDir != "" {
+		workingDir := spec.WorkingDir
+
+		// Expand tilde to user home directory
+		if strings.HasPrefix(workingDir, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				workingDir = filepath.Join(home, workingDir[2:])
+			}
+		} else if workingDir == "~" {
+			if home, err := os.UserHomeDir(); err == nil {
+				workingDir = home
+			}
+		}
+
+		// Convert to absolute path and clean it
+		if absPath, err := filepath.Abs(workingDir); err == nil {
+			cmd.Dir = filepath.Clean(absPath)
+		} else {
+			// Fallback to original if absolute path conversion fails
+			cmd.Dir = workingDir
+		}
+	}
+
+	setProcessGroup(cmd)
+	p, err := startCmd(cmd, spec.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	p.group = processGroupsSupported
+	return p, nil
+}
+
+// DockerRunner runs claude inside an existing container via docker exec. The
+// claude binary, MCP config and working directory must exist in the container.
+type DockerRunner struct {
+	Container  string
+	DockerPath string // Defaults to "docker"
+	User       string // Optional --user for docker exec
+}
+
+// Start implements Runner
+func (r DockerRunner) Start(ctx context.Context, spec RunSpec) (Process, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if r.Container == "" {
+		return nil, fmt.Errorf("docker runner: container not set")
+	}
+
+	pidFile := remotePIDFile()
+	cmd := exec.Command(r.dockerPath(), r.execArgs(spec, pidFile)...)
+	// execArgs names the variables only; the docker client forwards their
+	// values from its own environment, which keeps them out of ps
+	if len(spec.Env) > 0 {
+		cmd.Env = os.Environ()
+		for key, value := range spec.Env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
+	p, err := startCmd(cmd, spec.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteProcess{cmdProcess: p, pidFile: pidFile, control: r.control}, nil
+}
+
+func (r DockerRunner) dockerPath() string {
+	if r.DockerPath != "" {
+		return r.DockerPath
+	}
+	return "docker"
+}
+
+// execArgs builds the docker exec argument list for spec
+func (r DockerRunner) execArgs(spec RunSpec, pidFile string) []string {
+	args := []string{"exec", "-i"}
+	if r.User != "" {
+		args = append(args, "--user", r.User)
+	}
+	if spec.WorkingDir != "" {
+		args = append(args, "--workdir", spec.WorkingDir)
+	}
+	for _, key := range sortedKeys(spec.Env) {
+		args = append(args, "--env", key)
+	}
+	args = append(args, r.Container)
+	return append(args, pidWrapper(pidFile, spec.Path, spec.Args)...)
+}
+
+// control runs a short shell script inside the container
+func (r DockerRunner) control(script string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), controlTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, r.dockerPath(), "exec", r.Container, "sh", "-c", script).Run()
+}
+
+// SSHRunner runs claude on a remote host over ssh. The claude binary, MCP
+// config and working directory must exist on the remote host.
+type SSHRunner struct {
+	Host    string   // Destination, e.g. "user@build-box"
+	SSHPath string   // Defaults to "ssh"
+	Options []string // Extra ssh arguments, e.g. []string{"-i", "~/.ssh/agent"}
+}
+
+// Start implements Runner
+func (r SSHRunner) Start(ctx context.Context, spec RunSpec) (Process, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if r.Host == "" {
+		return nil, fmt.Errorf("ssh runner: host not set")
+	}
+
+	pidFile := remotePIDFile()
+	// The environment goes to a private file on the remote host rather than
+	// onto either command line, where ps would show it
+	envFile := ""
+	if len(spec.Env) > 0 {
+		envFile = strings.TrimSuffix(pidFile, ".pid") + ".env"
+		if err := r.writeEnvFile(ctx, envFile, spec.Env); err != nil {
+			return nil, fmt.Errorf("ssh runner: failed to write environment: %w", err)
+		}
+	}
+
+	cmd := exec.Command(r.sshPath(), r.sshArgs(r.remoteCommand(spec, pidFile, envFile))...)
+	p, err := startCmd(cmd, spec.Stdin)
+	if err != nil {
+		if envFile != "" {
+			_ = r.control("rm -f " + shellQuote(envFile))
+		}
+		return nil, err
+	}
+	return &remoteProcess{cmdProcess: p, pidFile: pidFile, envFile: envFile, control: r.control}, nil
+}
+
+func (r SSHRunner) sshPath() string {
+	if r.SSHPath != "" {
+		return r.SSHPath
+	}
+	return "ssh"
+}
+
+// sshArgs builds the ssh argument list for a remote shell command
+func (r SSHRunner) sshArgs(command string) []string {
+	args := append([]string{}, r.Options...)
+	return append(args, "-T", r.Host, command)
+}
+
+// controlArgs builds the ssh argument list for a control command, which must
+// fail fast rather than prompt or wait out an unreachable host
+func (r SSHRunner) controlArgs(command string) []string {
+	args := append([]string{}, r.Options...)
+	args = append(args, "-o", "BatchMode=yes", "-o", fmt.Sprintf("ConnectTimeout=%d", int(controlTimeout/time.Second)))
+	return append(args, "-T", r.Host, command)
+}
+
+// remoteCommand renders spec as a single shell command line, since ssh joins
+// its arguments with spaces before handing them to the remote shell. envFile,
+// if set, is sourced and removed before claude starts.
+func (r SSHRunner) remoteCommand(spec RunSpec, pidFile, envFile string) string {
+	var parts []string
+	if spec.WorkingDir != "" {
+		parts = append(parts, "cd", shellQuote(spec.WorkingDir), "&&")
+	}
+	if envFile != "" {
+		parts = append(parts, ".", shellQuote(envFile), "&&", "rm", "-f", shellQuote(envFile), "&&")
+	}
+	for _, arg := range pidWrapper(pidFile, spec.Path, spec.Args) {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// control runs a short shell script on the remote host
+func (r SSHRunner) control(script string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), controlTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, r.sshPath(), r.controlArgs(script)...).Run()
+}
+
+// writeEnvFile writes env as a file of exports readable only by the remote
+// user. The values travel over the ssh connection's stdin.
+func (r SSHRunner) writeEnvFile(ctx context.Context, envFile string, env map[string]string) error {
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, r.sshPath(), r.controlArgs("umask 077 && cat > "+shellQuote(envFile))...)
+	cmd.Stdin = strings.NewReader(envScript(env))
+	return cmd.Run()
+}
+
+// envScript renders env as sh export statements
+func envScript(env map[string]string) string {
+	var b strings.Builder
+	for _, key := range sortedKeys(env) {
+		fmt.Fprintf(&b, "export %s=%s\n", key, shellQuote(env[key]))
+	}
+	return b.String()
+}
+
+// controlTimeout bounds the docker exec and ssh commands that signal a remote
+// process and clean up after it, so an unreachable host can't hang them
+const controlTimeout = 10 * time.Second
+
+// remoteProcess is a claude process running behind a docker exec or ssh
+// client. Signals are delivered to the remote pid recorded in pidFile, since
+// neither client forwards them.
+type remoteProcess struct {
+	*cmdProcess
+	pidFile string
+	envFile string // Removed by the remote command itself unless it failed to start
+	control func(script string) error
+}
+
+func (p *remoteProcess) Signal(sig os.Signal) error {
+	num, ok := sig.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("unsupported signal %v", sig)
+	}
+	return p.control(fmt.Sprintf("kill -%d $(cat %s)", int(num), shellQuote(p.pidFile)))
+}
+
+// SignalGroup signals the remote claude process only; the pid wrapper does
+// not start it in a group of its own
+func (p *remoteProcess) SignalGroup(sig os.Signal) error {
+	return p.Signal(sig)
+}
+
+func (p *remoteProcess) Kill() error {
+	err := p.Signal(syscall.SIGKILL)
+	// Always tear down the local client too, even if the remote kill failed
+	if killErr := p.cmdProcess.Kill(); err == nil {
+		err = killErr
+	}
+	return err
+}
+
+func (p *remoteProcess) Wait() error {
+	err := p.cmdProcess.Wait()
+	// Cleanup is best effort, and the connection may have gone down with the
+	// process, so it must not hold up the exit
+	script := "rm -f " + shellQuote(p.pidFile)
+	if p.envFile != "" {
+		script += " " + shellQuote(p.envFile)
+	}
+	go func() { _ = p.control(script) }()
+	return err
+}
+
+// pidWrapper returns an argv that records the shell pid in pidFile and then
+// execs claude in its place, so the pid stays valid for signaling
+func pidWrapper(pidFile, path string, args []string) []string {
+	script := fmt.Sprintf(`echo $$ > %s && exec "$@"`, shellQuote(pidFile))
+	return append([]string{"sh", "-c", script, "sh", path}, args...)
+}
+
+// remotePIDFile returns a unique pid file path on the target host
+func remotePIDFile() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "/tmp/claudecode-" + hex.EncodeToString(b) + ".pid"
+}
+
+// shellQuote quotes s for POSIX sh
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:@,+", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sortedKeys returns the keys of m in a stable order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,235 @@
+package claudecode
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProcess is an in-memory Process that replays canned stdout
+type fakeProcess struct {
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+
+	mu      sync.Mutex
+	signals []os.Signal
+	killed  bool
+}
+
+func (p *fakeProcess) Stdout() io.ReadCloser { return p.stdout }
+func (p *fakeProcess) Stderr() io.ReadCloser { return p.stderr }
+func (p *fakeProcess) Stdin() io.WriteCloser { return nil }
+func (p *fakeProcess) Wait() error           { return nil }
+
+func (p *fakeProcess) Signal(sig os.Signal) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.signals = append(p.signals, sig)
+	return nil
+}
+
+func (p *fakeProcess) Kill() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.killed = true
+	return nil
+}
+
+type fakeRunner struct {
+	output string
+	spec   RunSpec
+	proc   *fakeProcess
+}
+
+func (r *fakeRunner) Start(ctx context.Context, spec RunSpec) (Process, error) {
+	r.spec = spec
+	r.proc = &fakeProcess{
+		stdout: io.NopCloser(strings.NewReader(r.output)),
+		stderr: io.NopCloser(strings.NewReader("")),
+	}
+	return r.proc, nil
+}
+
+func TestClientWithFakeRunner(t *testing.T) {
+	runner := &fakeRunner{output: `{"type":"system","subtype":"init","session_id":"fake"}
+{"type":"result","subtype":"success","session_id":"fake","result":"from memory"}
+`}
+	client := NewClientWithRunner("claude", runner)
+
+	session, err := client.Launch(SessionConfig{
+		Query:        "hello",
+		OutputFormat: OutputStreamJSON,
+		WorkingDir:   "/work",
+		Env:          map[string]string{"FOO": "bar"},
+	})
+	if err != nil {
+		t.Fatalf("launch failed: %v", err)
+	}
+
+	if err := session.Interrupt(); err != nil {
+		t.Fatalf("interrupt failed: %v", err)
+	}
+
+	result, err := session.Wait()
+	if err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+	if result.Result != "from memory" || session.ID != "fake" {
+		t.Errorf("unexpected result %+v for session %q", result, session.ID)
+	}
+
+	// The runner gets the same args buildArgs produces for a local launch
+	expected, _, err := client.buildArgs(session.Config)
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	if !reflect.DeepEqual(runner.spec.Args, expected) {
+		t.Errorf("expected args %v, got %v", expected, runner.spec.Args)
+	}
+	if runner.spec.Path != "claude" || runner.spec.WorkingDir != "/work" || runner.spec.Env["FOO"] != "bar" {
+		t.Errorf("unexpected spec: %+v", runner.spec)
+	}
+	if len(runner.proc.signals) != 1 {
+		t.Errorf("expected interrupt to be forwarded to the process, got %v", runner.proc.signals)
+	}
+}
+
+func TestDockerRunnerExecArgs(t *testing.T) {
+	r := DockerRunner{Container: "sandbox", User: "agent"}
+	args := r.execArgs(RunSpec{
+		Path:       "claude",
+		Args:       []string{"--print", "--", "it's a query"},
+		Env:        map[string]string{"B": "2", "A": "1"},
+		WorkingDir: "/src",
+	}, "/tmp/x.pid")
+
+	expected := []string{
+		"exec", "-i", "--user", "agent", "--workdir", "/src", "--env", "A", "--env", "B", "sandbox",
+		"sh", "-c", `echo $$ > /tmp/x.pid && exec "$@"`, "sh", "claude", "--print", "--", "it's a query",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected docker args:\n got %q\nwant %q", args, expected)
+	}
+}
+
+func TestDockerRunnerKeepsEnvOffCommandLine(t *testing.T) {
+	// A stand-in docker that prints the value it would forward for SECRET
+	dockerPath := t.TempDir() + "/docker"
+	if err := os.WriteFile(dockerPath, []byte("#!/bin/sh\nprintf %s \"$SECRET\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r := DockerRunner{Container: "sandbox", DockerPath: dockerPath}
+	p, err := r.Start(context.Background(), RunSpec{Path: "claude", Env: map[string]string{"SECRET": "hunter2"}})
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	out, _ := io.ReadAll(p.Stdout())
+	_ = p.Wait()
+
+	if string(out) != "hunter2" {
+		t.Errorf("expected the docker client to get the value from its environment, got %q", out)
+	}
+	cmd := p.(*remoteProcess).cmd
+	if strings.Contains(strings.Join(cmd.Args, " "), "hunter2") {
+		t.Errorf("secret on the command line: %q", cmd.Args)
+	}
+}
+
+func TestSSHRunnerRemoteCommand(t *testing.T) {
+	r := SSHRunner{Host: "dev@box", Options: []string{"-p", "2222"}}
+	spec := RunSpec{
+		Path:       "claude",
+		Args:       []string{"--print", "--", "it's a query; rm -rf /"},
+		Env:        map[string]string{"TOKEN": "a b"},
+		WorkingDir: "/home/dev/my repo",
+	}
+	command := r.remoteCommand(spec, "/tmp/x.pid", "/tmp/x.env")
+
+	args := r.sshArgs(command)
+	if !reflect.DeepEqual(args[:4], []string{"-p", "2222", "-T", "dev@box"}) {
+		t.Errorf("unexpected ssh args: %q", args)
+	}
+	if strings.Contains(command, "TOKEN") {
+		t.Errorf("environment on the command line: %s", command)
+	}
+
+	// Run the rendered command through a local shell, with printf standing in
+	// for claude, to verify the quoting round-trips
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	echoSpec := spec
+	echoSpec.WorkingDir = ""
+	echoSpec.Path = "printf"
+	echoSpec.Args = append([]string{"%s|"}, spec.Args...)
+	dir := t.TempDir()
+	pidFile, envFile := dir+"/pid", dir+"/env"
+	if err := os.WriteFile(envFile, []byte(envScript(spec.Env)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("sh", "-c", ". "+envFile+` && printf %s "$TOKEN"`).Output(); err != nil || string(out) != "a b" {
+		t.Errorf("environment did not round-trip: %q, %v", out, err)
+	}
+	out, err := exec.Command("sh", "-c", r.remoteCommand(echoSpec, pidFile, envFile)).Output()
+	if err != nil {
+		t.Fatalf("rendered command failed: %v", err)
+	}
+	if string(out) != "--print|--|it's a query; rm -rf /|" {
+		t.Errorf("quoting did not round-trip: %q", out)
+	}
+	if pid, err := os.ReadFile(pidFile); err != nil || len(strings.TrimSpace(string(pid))) == 0 {
+		t.Errorf("expected pid file to be written: %v", err)
+	}
+	if _, err := os.Stat(envFile); !os.IsNotExist(err) {
+		t.Errorf("expected env file to be removed before claude starts: %v", err)
+	}
+	if !strings.HasPrefix(command, "cd '/home/dev/my repo' && . /tmp/x.env && rm -f /tmp/x.env && ") {
+		t.Errorf("unexpected remote command prefix: %s", command)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"plain":      "plain",
+		"":           "''",
+		"with space": "'with space'",
+		"it's":       `'it'\''s'`,
+		"$HOME":      "'$HOME'",
+		"--flag=a,b": "--flag=a,b",
+	}
+	for in, want := range cases {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRemoteProcessWaitDoesNotBlockOnCleanup(t *testing.T) {
+	p, err := startCmd(exec.Command("true"), false)
+	if err != nil {
+		t.Skipf("true not available: %v", err)
+	}
+	unreachable := make(chan struct{})
+	defer close(unreachable)
+	remote := &remoteProcess{cmdProcess: p, pidFile: "/tmp/x.pid", control: func(string) error {
+		<-unreachable
+		return nil
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- remote.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("wait failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait blocked on pid file cleanup")
+	}
+}
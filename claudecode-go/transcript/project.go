@@ -0,0 +1,281 @@
+package transcript
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProjectsDir returns the directory the CLI keeps transcripts in, honoring
+// CLAUDE_CONFIG_DIR like the CLI does
+func ProjectsDir() (string, error) {
+	configDir := os.Getenv("CLAUDE_CONFIG_DIR")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		configDir = filepath.Join(home, ".claude")
+	}
+	return filepath.Join(configDir, "projects"), nil
+}
+
+// EncodePath returns the directory name the CLI uses for a working
+// directory: every character other than an ASCII letter or digit becomes a dash
+func EncodePath(path string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, path)
+}
+
+// ProjectDir returns the transcript directory for sessions run in workingDir
+func ProjectDir(workingDir string) (string, error) {
+	root, err := ProjectsDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(workingDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, EncodePath(abs)), nil
+}
+
+// FindSession searches all projects for a session's transcript and returns
+// its path, for when the session's working directory is not known
+func FindSession(sessionID string) (string, error) {
+	root, err := ProjectsDir()
+	if err != nil {
+		return "", err
+	}
+	matches, err := filepath.Glob(filepath.Join(root, "*", sessionID+".jsonl"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("transcript for session %s: %w", sessionID, os.ErrNotExist)
+	}
+	return matches[0], nil
+}
+
+// SessionFile describes a transcript file in a project directory
+type SessionFile struct {
+	ID      string
+	Path    string
+	ModTime time.Time
+	Size    int64
+}
+
+// Project reads the transcripts of one working directory. Parsed transcripts
+// are cached, so a Project reflects the files as they were when first read.
+// It is safe for concurrent use.
+type Project struct {
+	dir string
+
+	mu          sync.Mutex
+	transcripts map[string]*Transcript
+	// origins maps each entry UUID to the session it was first written in,
+	// built on demand when following chains across files
+	origins map[string]*Transcript
+}
+
+// OpenProject returns the project for sessions run in workingDir
+func OpenProject(workingDir string) (*Project, error) {
+	dir, err := ProjectDir(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewProject(dir), nil
+}
+
+// NewProject returns a project reading transcripts from dir
+func NewProject(dir string) *Project {
+	return &Project{
+		dir:         dir,
+		transcripts: make(map[string]*Transcript),
+	}
+}
+
+// Dir returns the project's transcript directory
+func (p *Project) Dir() string {
+	return p.dir
+}
+
+// Sessions lists the project's transcripts, most recently modified first
+func (p *Project) Sessions() ([]SessionFile, error) {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []SessionFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, SessionFile{
+			ID:      strings.TrimSuffix(entry.Name(), ".jsonl"),
+			Path:    filepath.Join(p.dir, entry.Name()),
+			ModTime: info.ModTime(),
+			Size:    info.Size(),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+	return files, nil
+}
+
+// Transcript returns the parsed transcript of a session
+func (p *Project) Transcript(sessionID string) (*Transcript, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.transcript(sessionID)
+}
+
+func (p *Project) transcript(sessionID string) (*Transcript, error) {
+	if t, ok := p.transcripts[sessionID]; ok {
+		return t, nil
+	}
+	t, err := ReadFile(filepath.Join(p.dir, sessionID+".jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	p.transcripts[sessionID] = t
+	return t, nil
+}
+
+// loadOrigins parses every transcript in the project and records, for each
+// entry, the earliest started session containing it. Forks copy the entries
+// they inherit into their own file, so an entry's origin is the session that
+// produced it rather than any of its forks.
+func (p *Project) loadOrigins() error {
+	if p.origins != nil {
+		return nil
+	}
+
+	files, err := p.Sessions()
+	if err != nil {
+		return err
+	}
+	origins := make(map[string]*Transcript)
+	for _, file := range files {
+		t, err := p.transcript(file.ID)
+		if err != nil {
+			return err
+		}
+		started := t.Started()
+		for _, entry := range t.Entries {
+			if entry.UUID == "" {
+				continue
+			}
+			if current, ok := origins[entry.UUID]; !ok || started.Before(current.Started()) {
+				origins[entry.UUID] = t
+			}
+		}
+	}
+	p.origins = origins
+	return nil
+}
+
+// Conversation reconstructs the main thread of a session, from the first
+// message to the latest one. Entries the session inherited from the session
+// it was forked or resumed from are included, read from that session's file
+// if they were not copied.
+func (p *Project) Conversation(sessionID string) (*Conversation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t, err := p.transcript(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := &Conversation{SessionID: sessionID}
+	lookup := func(uuid string) (*Entry, bool) {
+		if entry, ok := t.Entry(uuid); ok {
+			return entry, true
+		}
+		if err := p.loadOrigins(); err != nil {
+			return nil, false
+		}
+		if origin, ok := p.origins[uuid]; ok {
+			return origin.Entry(uuid)
+		}
+		return nil, false
+	}
+
+	// Walk from the leaf up to the root
+	seen := make(map[string]bool)
+	for entry := t.Leaf(); entry != nil && !seen[entry.UUID]; {
+		seen[entry.UUID] = true
+		conv.Entries = append(conv.Entries, entry)
+
+		parent := entry.ParentUUID
+		if parent == "" {
+			parent = entry.LogicalParentUUID
+		}
+		if parent == "" {
+			break
+		}
+		next, ok := lookup(parent)
+		if !ok {
+			conv.Truncated = true
+			break
+		}
+		entry = next
+	}
+	for i, j := 0, len(conv.Entries)-1; i < j; i, j = i+1, j-1 {
+		conv.Entries[i], conv.Entries[j] = conv.Entries[j], conv.Entries[i]
+	}
+
+	lineage, err := p.lineage(t, conv.Entries, map[string]bool{sessionID: true})
+	if err != nil {
+		return nil, err
+	}
+	conv.Lineage = lineage
+	return conv, nil
+}
+
+// lineage returns the sessions t descends from, oldest first. The parent is
+// the origin of the latest entry in the thread that t did not produce itself.
+func (p *Project) lineage(t *Transcript, thread []*Entry, visited map[string]bool) ([]string, error) {
+	if err := p.loadOrigins(); err != nil {
+		return nil, err
+	}
+
+	var parent *Transcript
+	for i := len(thread) - 1; i >= 0; i-- {
+		if origin, ok := p.origins[thread[i].UUID]; ok && origin != t {
+			parent = origin
+			break
+		}
+	}
+	if parent == nil || visited[parent.SessionID] {
+		return nil, nil
+	}
+	visited[parent.SessionID] = true
+
+	var parentThread []*Entry
+	for _, entry := range thread {
+		if p.origins[entry.UUID] != t {
+			parentThread = append(parentThread, entry)
+		}
+	}
+	ancestors, err := p.lineage(parent, parentThread, visited)
+	if err != nil {
+		return nil, err
+	}
+	return append(ancestors, parent.SessionID), nil
+}
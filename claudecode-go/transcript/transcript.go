@@ -0,0 +1,242 @@
+// Package transcript reads the session transcripts the Claude CLI keeps on
+// disk under ~/.claude/projects/<encoded-cwd>/<session-id>.jsonl.
+//
+// Transcripts cover sessions regardless of how they were started, so they
+// can be used to show history for sessions launched outside the SDK, or to
+// check a recorded event stream against what the CLI itself saw.
+//
+// Basic usage:
+//
+//	project, err := transcript.OpenProject("/path/to/repo")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	conv, err := project.Conversation(sessionID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	turns := conv.Turns()
+//	for turns.Next() {
+//	    turn := turns.Turn()
+//	    fmt.Println(turn.Prompt.Content[0].Text, turn.Usage.OutputTokens)
+//	}
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// Entry types that carry conversation content. Transcripts also contain
+// bookkeeping lines (queue operations, cost state, last prompt markers, ...)
+// which are kept as entries but have no message.
+const (
+	EntryUser       = "user"
+	EntryAssistant  = "assistant"
+	EntrySystem     = "system"
+	EntryAttachment = "attachment"
+	EntrySummary    = "summary"
+)
+
+// Entry is a single line of a transcript
+type Entry struct {
+	Type       string `json:"type"`
+	UUID       string `json:"uuid,omitempty"`
+	ParentUUID string `json:"parentUuid,omitempty"`
+	// LogicalParentUUID links a compaction boundary to the conversation it
+	// summarizes, when ParentUUID is empty
+	LogicalParentUUID string    `json:"logicalParentUuid,omitempty"`
+	SessionID         string    `json:"sessionId,omitempty"`
+	Timestamp         time.Time `json:"timestamp,omitempty"`
+	CWD               string    `json:"cwd,omitempty"`
+	GitBranch         string    `json:"gitBranch,omitempty"`
+	Version           string    `json:"version,omitempty"`
+	IsSidechain       bool      `json:"isSidechain,omitempty"`
+	IsMeta            bool      `json:"isMeta,omitempty"`
+	Subtype           string    `json:"subtype,omitempty"`
+	RequestID         string    `json:"requestId,omitempty"`
+
+	// Summary and LeafUUID are set on summary lines
+	Summary  string `json:"summary,omitempty"`
+	LeafUUID string `json:"leafUuid,omitempty"`
+
+	// RawMessage is the API message of user and assistant entries
+	RawMessage json.RawMessage `json:"message,omitempty"`
+
+	// Raw is the complete line as read from the transcript
+	Raw json.RawMessage `json:"-"`
+}
+
+// rawMessage mirrors claudecode.Message, except that user content may be a
+// plain string in transcripts
+type rawMessage struct {
+	ID      string            `json:"id"`
+	Type    string            `json:"type"`
+	Role    string            `json:"role"`
+	Model   string            `json:"model,omitempty"`
+	Content json.RawMessage   `json:"content"`
+	Usage   *claudecode.Usage `json:"usage,omitempty"`
+}
+
+// Message decodes the entry's API message. It returns false for entries that
+// do not carry one.
+func (e *Entry) Message() (*claudecode.Message, bool) {
+	if len(e.RawMessage) == 0 || (e.Type != EntryUser && e.Type != EntryAssistant) {
+		return nil, false
+	}
+
+	var raw rawMessage
+	if err := json.Unmarshal(e.RawMessage, &raw); err != nil {
+		return nil, false
+	}
+	msg := &claudecode.Message{
+		ID:    raw.ID,
+		Type:  raw.Type,
+		Role:  raw.Role,
+		Model: raw.Model,
+		Usage: raw.Usage,
+	}
+
+	var text string
+	if err := json.Unmarshal(raw.Content, &text); err == nil {
+		msg.Content = []claudecode.Content{{Type: "text", Text: text}}
+	} else if err := json.Unmarshal(raw.Content, &msg.Content); err != nil {
+		return nil, false
+	}
+	return msg, true
+}
+
+// isPrompt reports whether the entry is a user prompt, as opposed to tool
+// results and injected meta messages that are also recorded as user entries
+func (e *Entry) isPrompt() bool {
+	if e.Type != EntryUser || e.IsMeta || e.IsSidechain {
+		return false
+	}
+	msg, ok := e.Message()
+	if !ok {
+		return false
+	}
+	for _, content := range msg.Content {
+		if content.Type != "tool_result" {
+			return true
+		}
+	}
+	return false
+}
+
+// Transcript is the parsed content of one session file
+type Transcript struct {
+	SessionID string
+	Path      string
+	// Entries holds every line in file order
+	Entries []*Entry
+	// Skipped counts lines that could not be parsed, such as a trailing line
+	// the CLI was still writing
+	Skipped int
+
+	byUUID map[string]*Entry
+}
+
+// ReadFile parses the transcript at path. The session ID is taken from the
+// file name.
+func ReadFile(path string) (*Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := Parse(f, strings.TrimSuffix(filepath.Base(path), ".jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript %s: %w", path, err)
+	}
+	t.Path = path
+	return t, nil
+}
+
+// Parse reads a transcript from r
+func Parse(r io.Reader, sessionID string) (*Transcript, error) {
+	t := &Transcript{
+		SessionID: sessionID,
+		byUUID:    make(map[string]*Entry),
+	}
+
+	// Tool results can make single lines very large, so read without a limit
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if trimmed := strings.TrimSpace(string(line)); trimmed != "" {
+			entry := &Entry{}
+			if jsonErr := json.Unmarshal([]byte(trimmed), entry); jsonErr != nil {
+				t.Skipped++
+			} else {
+				entry.Raw = json.RawMessage(trimmed)
+				t.Entries = append(t.Entries, entry)
+				if entry.UUID != "" {
+					t.byUUID[entry.UUID] = entry
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Entry looks up an entry by UUID
+func (t *Transcript) Entry(uuid string) (*Entry, bool) {
+	entry, ok := t.byUUID[uuid]
+	return entry, ok
+}
+
+// Leaf returns the last entry of the main conversation thread, or nil if
+// the transcript has none
+func (t *Transcript) Leaf() *Entry {
+	for i := len(t.Entries) - 1; i >= 0; i-- {
+		entry := t.Entries[i]
+		if entry.UUID == "" || entry.IsSidechain {
+			continue
+		}
+		switch entry.Type {
+		case EntryUser, EntryAssistant, EntrySystem, EntryAttachment:
+			return entry
+		}
+	}
+	return nil
+}
+
+// Started returns when the session was started. Forked sessions copy the
+// entries they inherit, timestamps included, so bookkeeping lines without a
+// UUID are preferred since they are never copied.
+func (t *Transcript) Started() time.Time {
+	var started, fallback time.Time
+	for _, entry := range t.Entries {
+		if entry.Timestamp.IsZero() {
+			continue
+		}
+		if entry.UUID == "" && (started.IsZero() || entry.Timestamp.Before(started)) {
+			started = entry.Timestamp
+		}
+		if fallback.IsZero() || entry.Timestamp.Before(fallback) {
+			fallback = entry.Timestamp
+		}
+	}
+	if started.IsZero() {
+		return fallback
+	}
+	return started
+}
@@ -0,0 +1,164 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTranscript writes lines as a session file in dir
+func writeTranscript(t *testing.T, dir, sessionID string, lines ...string) {
+	t.Helper()
+	path := filepath.Join(dir, sessionID+".jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+}
+
+func TestProjectDir(t *testing.T) {
+	assert.Equal(t, "-Users-dex-src-my-app-v2", EncodePath("/Users/dex/src/my_app.v2"))
+
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+
+	dir, err := ProjectDir("/work/repo")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, "projects", "-work-repo"), dir)
+
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	writeTranscript(t, dir, "s1", `{"type":"queue-operation","operation":"enqueue","sessionId":"s1"}`)
+	path, err := FindSession("s1")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "s1.jsonl"), path)
+
+	_, err = FindSession("missing")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestConversationTurns(t *testing.T) {
+	dir := t.TempDir()
+	writeTranscript(t, dir, "s1",
+		`{"type":"queue-operation","operation":"enqueue","timestamp":"2025-01-01T10:00:00Z","sessionId":"s1","content":"List files"}`,
+		`{"type":"user","uuid":"u1","parentUuid":null,"sessionId":"s1","timestamp":"2025-01-01T10:00:01Z","message":{"role":"user","content":"List files"}}`,
+		`{"type":"attachment","uuid":"a1","parentUuid":"u1","sessionId":"s1","timestamp":"2025-01-01T10:00:01Z","attachment":{"type":"environment"}}`,
+		`{"type":"assistant","uuid":"m1","parentUuid":"a1","sessionId":"s1","timestamp":"2025-01-01T10:00:02Z","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4","content":[{"type":"text","text":"Listing."}],"usage":{"input_tokens":10,"output_tokens":5}}}`,
+		`{"type":"assistant","uuid":"m2","parentUuid":"m1","sessionId":"s1","timestamp":"2025-01-01T10:00:03Z","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}],"usage":{"input_tokens":10,"output_tokens":20}}}`,
+		`{"type":"user","uuid":"r1","parentUuid":"m2","sessionId":"s1","timestamp":"2025-01-01T10:00:04Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"a.go\nb.go"}]}}`,
+		`{"type":"assistant","uuid":"m3","parentUuid":"r1","sessionId":"s1","timestamp":"2025-01-01T10:00:05Z","message":{"id":"msg_2","type":"message","role":"assistant","content":[{"type":"text","text":"Two files."}],"usage":{"input_tokens":30,"output_tokens":4,"cache_read_input_tokens":100}}}`,
+		`{"type":"user","uuid":"meta","parentUuid":"m3","isMeta":true,"sessionId":"s1","timestamp":"2025-01-01T10:01:00Z","message":{"role":"user","content":"<command-name>/clear</command-name>"}}`,
+		`{"type":"user","uuid":"u2","parentUuid":"meta","sessionId":"s1","timestamp":"2025-01-01T10:01:00Z","message":{"role":"user","content":[{"type":"text","text":"Thanks"}]}}`,
+		`{"type":"assistant","uuid":"side","parentUuid":"u2","isSidechain":true,"sessionId":"s1","message":{"id":"msg_side","role":"assistant","content":[]}}`,
+		`{"type":"assistant","uuid":"m4","parentUuid":"u2","sessionId":"s1","timestamp":"2025-01-01T10:01:01Z","message":{"id":"msg_3","type":"message","role":"assistant","content":[{"type":"text","text":"Welcome."}],"usage":{"input_tokens":40,"output_tokens":2}}}`,
+		`{"type":"last-prompt","lastPrompt":"Thanks","leafUuid":"m4","sessionId":"s1"}`,
+		`{"type":"assistant","uuid":"partial`,
+	)
+
+	project := NewProject(dir)
+	transcript, err := project.Transcript("s1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, transcript.Skipped)
+	assert.Equal(t, "m4", transcript.Leaf().UUID)
+
+	conv, err := project.Conversation("s1")
+	require.NoError(t, err)
+	assert.Empty(t, conv.Lineage)
+	assert.False(t, conv.Truncated)
+	assert.Len(t, conv.Entries, 9, "sidechain and bookkeeping entries are not part of the thread")
+
+	// Split assistant entries are merged into one message
+	messages := conv.Messages()
+	require.Len(t, messages, 6)
+	assert.Equal(t, "List files", messages[0].Content[0].Text)
+	assert.Equal(t, "msg_1", messages[1].ID)
+	require.Len(t, messages[1].Content, 2)
+	assert.Equal(t, "Bash", messages[1].Content[1].Name)
+	assert.Equal(t, 20, messages[1].Usage.OutputTokens)
+	assert.Equal(t, "a.go\nb.go", messages[2].Content[0].Content.Value)
+
+	turns := conv.Turns()
+	require.True(t, turns.Next())
+	first := turns.Turn()
+	assert.Equal(t, 0, first.Index)
+	assert.Equal(t, "u1", first.UUID)
+	assert.Equal(t, "List files", first.Prompt.Content[0].Text)
+	assert.Len(t, first.Messages, 3)
+	assert.Equal(t, 40, first.Usage.InputTokens)
+	assert.Equal(t, 24, first.Usage.OutputTokens)
+	assert.Equal(t, 100, first.Usage.CacheReadInputTokens)
+	assert.Equal(t, "2025-01-01T10:00:05Z", first.EndedAt.Format("2006-01-02T15:04:05Z"))
+
+	require.True(t, turns.Next())
+	second := turns.Turn()
+	assert.Equal(t, "Thanks", second.Prompt.Content[0].Text)
+	require.Len(t, second.Messages, 1)
+	assert.Equal(t, "Welcome.", second.Messages[0].Content[0].Text)
+
+	assert.False(t, turns.Next())
+	assert.Nil(t, turns.Turn())
+}
+
+func TestConversationFollowsForks(t *testing.T) {
+	dir := t.TempDir()
+
+	parent := []string{
+		`{"type":"user","uuid":"p1","parentUuid":null,"sessionId":"parent","timestamp":"2025-01-01T10:00:00Z","message":{"role":"user","content":"one"}}`,
+		`{"type":"assistant","uuid":"p2","parentUuid":"p1","sessionId":"parent","timestamp":"2025-01-01T10:00:01Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"text","text":"1"}]}}`,
+	}
+	writeTranscript(t, dir, "parent", append([]string{
+		`{"type":"queue-operation","operation":"enqueue","timestamp":"2025-01-01T10:00:00Z","sessionId":"parent"}`,
+	}, parent...)...)
+
+	// A fork copies the history it inherits under its own session ID
+	fork := strings.ReplaceAll(strings.Join(parent, "\n"), `"sessionId":"parent"`, `"sessionId":"fork"`)
+	writeTranscript(t, dir, "fork",
+		`{"type":"queue-operation","operation":"enqueue","timestamp":"2025-01-02T10:00:00Z","sessionId":"fork"}`,
+		fork,
+		`{"type":"user","uuid":"f1","parentUuid":"p2","sessionId":"fork","timestamp":"2025-01-02T10:00:00Z","message":{"role":"user","content":"two"}}`,
+		`{"type":"assistant","uuid":"f2","parentUuid":"f1","sessionId":"fork","timestamp":"2025-01-02T10:00:01Z","message":{"id":"msg_2","role":"assistant","content":[{"type":"text","text":"2"}]}}`,
+	)
+
+	// Older CLI versions started resumed sessions without copying history
+	writeTranscript(t, dir, "resumed",
+		`{"type":"queue-operation","operation":"enqueue","timestamp":"2025-01-03T10:00:00Z","sessionId":"resumed"}`,
+		`{"type":"user","uuid":"r1","parentUuid":"f2","sessionId":"resumed","timestamp":"2025-01-03T10:00:00Z","message":{"role":"user","content":"three"}}`,
+		`{"type":"assistant","uuid":"r2","parentUuid":"r1","sessionId":"resumed","timestamp":"2025-01-03T10:00:01Z","message":{"id":"msg_3","role":"assistant","content":[{"type":"text","text":"3"}]}}`,
+	)
+
+	project := NewProject(dir)
+
+	conv, err := project.Conversation("parent")
+	require.NoError(t, err)
+	assert.Empty(t, conv.Lineage)
+
+	conv, err = project.Conversation("fork")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"parent"}, conv.Lineage)
+	assert.Len(t, conv.Entries, 4)
+
+	conv, err = project.Conversation("resumed")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"parent", "fork"}, conv.Lineage)
+	assert.False(t, conv.Truncated)
+
+	var prompts []string
+	turns := conv.Turns()
+	for turns.Next() {
+		prompts = append(prompts, turns.Turn().Prompt.Content[0].Text)
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, prompts)
+
+	// A parent that cannot be found ends the thread early
+	writeTranscript(t, dir, "orphan",
+		`{"type":"user","uuid":"o1","parentUuid":"gone","sessionId":"orphan","message":{"role":"user","content":"four"}}`,
+	)
+	conv, err = project.Conversation("orphan")
+	require.NoError(t, err)
+	assert.True(t, conv.Truncated)
+	assert.Len(t, conv.Entries, 1)
+
+	sessions, err := project.Sessions()
+	require.NoError(t, err)
+	assert.Len(t, sessions, 4)
+}
@@ -0,0 +1,140 @@
+package transcript
+
+import (
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// Conversation is the main thread of a session, root first
+type Conversation struct {
+	SessionID string
+	// Lineage lists the sessions this one was forked or resumed from, oldest
+	// first. It is empty for sessions started from scratch.
+	Lineage []string
+	// Entries holds the thread's entries, including attachments and system
+	// entries, from the first message to the latest
+	Entries []*Entry
+	// Truncated is set when the thread refers to a parent entry that could
+	// not be found in any transcript of the project
+	Truncated bool
+}
+
+// Messages returns the user and assistant messages of the thread. The CLI
+// records each content block of an assistant response as a separate entry;
+// these are merged back into a single message. Meta messages the CLI injects,
+// such as slash command output, are left out.
+func (c *Conversation) Messages() []claudecode.Message {
+	var messages []claudecode.Message
+	for _, entry := range c.Entries {
+		if entry.IsMeta {
+			continue
+		}
+		msg, ok := entry.Message()
+		if !ok {
+			continue
+		}
+		if last := len(messages) - 1; last >= 0 && msg.Role == "assistant" && msg.ID != "" &&
+			messages[last].Role == "assistant" && messages[last].ID == msg.ID {
+			messages[last].Content = append(messages[last].Content, msg.Content...)
+			if msg.Usage != nil {
+				messages[last].Usage = msg.Usage
+			}
+			continue
+		}
+		messages = append(messages, *msg)
+	}
+	return messages
+}
+
+// Turn is a user prompt together with everything Claude did in response
+type Turn struct {
+	// Index is the zero-based position of the turn in the conversation
+	Index int
+	// UUID identifies the prompt entry
+	UUID string
+	// Prompt is the user message that started the turn
+	Prompt claudecode.Message
+	// Messages holds the assistant messages and tool results that followed
+	Messages []claudecode.Message
+	// Usage sums the token usage of the turn's assistant messages
+	Usage claudecode.Usage
+	// StartedAt and EndedAt are the timestamps of the prompt and of the last
+	// entry of the response
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// TurnIterator steps through the turns of a conversation:
+//
+//	turns := conv.Turns()
+//	for turns.Next() {
+//	    turn := turns.Turn()
+//	}
+type TurnIterator struct {
+	entries []*Entry
+	pos     int
+	index   int
+	turn    *Turn
+}
+
+// Turns returns an iterator over the conversation's turns. Entries before
+// the first prompt, such as summaries of compacted history, are skipped.
+func (c *Conversation) Turns() *TurnIterator {
+	return &TurnIterator{entries: c.Entries}
+}
+
+// Next advances to the next turn, returning false when there are no more
+func (it *TurnIterator) Next() bool {
+	for it.pos < len(it.entries) && !it.entries[it.pos].isPrompt() {
+		it.pos++
+	}
+	if it.pos >= len(it.entries) {
+		it.turn = nil
+		return false
+	}
+
+	prompt := it.entries[it.pos]
+	msg, _ := prompt.Message()
+	turn := &Turn{
+		Index:     it.index,
+		UUID:      prompt.UUID,
+		Prompt:    *msg,
+		StartedAt: prompt.Timestamp,
+		EndedAt:   prompt.Timestamp,
+	}
+	it.pos++
+	it.index++
+
+	// Collect the response, merging split assistant messages as Messages does
+	var response Conversation
+	for ; it.pos < len(it.entries) && !it.entries[it.pos].isPrompt(); it.pos++ {
+		entry := it.entries[it.pos]
+		response.Entries = append(response.Entries, entry)
+		if !entry.Timestamp.IsZero() && !entry.IsMeta {
+			turn.EndedAt = entry.Timestamp
+		}
+	}
+	turn.Messages = response.Messages()
+	for _, msg := range turn.Messages {
+		if msg.Role == "assistant" && msg.Usage != nil {
+			addUsage(&turn.Usage, msg.Usage)
+		}
+	}
+
+	it.turn = turn
+	return true
+}
+
+// Turn returns the current turn
+func (it *TurnIterator) Turn() *Turn {
+	return it.turn
+}
+
+// addUsage adds the token counts of u to total
+func addUsage(total *claudecode.Usage, u *claudecode.Usage) {
+	total.InputTokens += u.InputTokens
+	total.OutputTokens += u.OutputTokens
+	total.CacheCreationInputTokens += u.CacheCreationInputTokens
+	total.CacheReadInputTokens += u.CacheReadInputTokens
+}
@@ -1,11 +1,15 @@
 package claudecode
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
+	"io"
+	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -27,6 +31,45 @@ const (
 	OutputStreamJSON OutputFormat = "stream-json"
 )
 
+// InputFormat specifies how the query is delivered to Claude CLI
+type InputFormat string
+
+const (
+	// InputText passes the query as a command line argument (one-shot run)
+	InputText InputFormat = "text"
+	// InputStreamJSON keeps stdin open and reads user messages as JSON lines,
+	// allowing several turns to run on one live process
+	InputStreamJSON InputFormat = "stream-json"
+)
+
+// PermissionMode controls how Claude asks before using tools
+type PermissionMode string
+
+const (
+	// PermissionModeDefault leaves the mode to Claude's settings
+	PermissionModeDefault           PermissionMode = "default"
+	PermissionModeAcceptEdits       PermissionMode = "acceptEdits"
+	PermissionModePlan              PermissionMode = "plan"
+	PermissionModeBypassPermissions PermissionMode = "bypassPermissions"
+)
+
+// SettingSource is a settings location Claude loads configuration from
+type SettingSource string
+
+const (
+	SettingSourceUser    SettingSource = "user"
+	SettingSourceProject SettingSource = "project"
+	SettingSourceLocal   SettingSource = "local"
+)
+
+// AgentDefinition is an inline subagent passed with --agents
+type AgentDefinition struct {
+	Description string   `json:"description"`
+	Prompt      string   `json:"prompt"`
+	Tools       []string `json:"tools,omitempty"`
+	Model       string   `json:"model,omitempty"`
+}
+
 // MCPServer represents a single MCP server configuration
 // It can be either a stdio-based server (with command/args/env) or an HTTP server (with type/url/headers)
 type MCPServer struct {
@@ -52,24 +95,43 @@ type SessionConfig struct {
 	Query string
 
 	// Session management
-	SessionID   string // If set, resumes this session
-	ForkSession bool   // If true with SessionID, forks instead of resuming
+	SessionID    string // If set, resumes this session
+	ForkSession  bool   // If true with SessionID or Continue, forks instead of resuming
+	Continue     bool   // Continue the most recent conversation in WorkingDir
+	NewSessionID string // Use this UUID as the ID of the new conversation
 
 	// Optional
-	Model                 Model
-	OutputFormat          OutputFormat
-	MCPConfig             *MCPConfig
-	PermissionPromptTool  string
-	WorkingDir            string
-	MaxTurns              int
-	SystemPrompt          string
-	AppendSystemPrompt    string
-	AllowedTools          []string
-	DisallowedTools       []string
-	AdditionalDirectories []string
-	CustomInstructions    string
-	Verbose               bool
-	Env                   map[string]string // Environment variables to set for the Claude process
+	Model                  Model
+	OutputFormat           OutputFormat
+	InputFormat            InputFormat // InputStreamJSON enables interactive multi-turn sessions via SendUserMessage
+	IncludePartialMessages bool        // Emit stream_event deltas as the response is generated, requires OutputStreamJSON
+	MCPConfig              *MCPConfig
+	PermissionPromptTool   string
+	WorkingDir             string
+	MaxTurns               int
+	SystemPrompt           string
+	AppendSystemPrompt     string
+	AllowedTools           []string
+	DisallowedTools        []string
+	AdditionalDirectories  []string
+	CustomInstructions     string
+	Verbose                bool
+	PermissionMode         PermissionMode
+	FallbackModel          Model
+	Settings               string                     // Path to a settings file, or inline settings JSON
+	Hooks                  Hooks                      // Merged into Settings and passed in a per-session settings file
+	SettingSources         []SettingSource            // Nil loads Claude's defaults, empty loads none
+	Agents                 map[string]AgentDefinition // Inline subagents, keyed by name
+	StrictMCPConfig        bool                       // Only use servers from MCPConfig
+	JSONSchema             json.RawMessage            // Validate the final result against this schema
+	Env                    map[string]string          // Environment variables to set for the Claude process
+	CassettePath           string                     // Record raw CLI output to this file for replay with NewReplayClient
+
+	// Event delivery, for OutputStreamJSON
+	EventHandler   func(StreamEvent) `json:"-"` // Called for each event instead of sending it on Events
+	EventBuffer    int               // Capacity of Events, DefaultEventBuffer if zero
+	OverflowPolicy OverflowPolicy    // What to do when Events is full, OverflowBlock if empty
+	SpillDir       string            // Directory for OverflowSpill files, os.TempDir() if empty
 }
 
 // StreamEvent represents a single event from the streaming JSON output
@@ -101,7 +163,14 @@ type StreamEvent struct {
 	ModelUsage        map[string]ModelUsageDetail `json:"modelUsage,omitempty"`
 	Error             string                      `json:"error,omitempty"`
 	PermissionDenials *PermissionDenials          `json:"permission_denials,omitempty"`
+	StructuredOutput  json.RawMessage             `json:"structured_output,omitempty"`
 	UUID              string                      `json:"uuid,omitempty"`
+
+	// Partial message fields (when type="stream_event"), decode with Typed
+	Event json.RawMessage `json:"event,omitempty"`
+
+	// Original JSON line, used by Typed to decode without losing fields
+	raw json.RawMessage
 }
 
 // MCPStatus represents the status of an MCP server
@@ -276,6 +345,7 @@ type Result struct {
 	ModelUsage        map[string]ModelUsageDetail `json:"modelUsage,omitempty"`
 	Error             string                      `json:"error,omitempty"`
 	PermissionDenials *PermissionDenials          `json:"permission_denials,omitempty"`
+	StructuredOutput  json.RawMessage             `json:"structured_output,omitempty"`
 	UUID              string                      `json:"uuid,omitempty"`
 }
 
@@ -289,13 +359,28 @@ type Session struct {
 	Events chan StreamEvent
 
 	// Process management
-	cmd    *exec.Cmd
-	done   chan struct{}
-	result *Result
+	proc    Process
+	stdin   io.WriteCloser // Only set in stream-json input mode
+	cancel  context.CancelCauseFunc
+	aborted chan struct{} // Closed when the process is killed due to cancellation
+	done    chan struct{}
+	result  *Result
+
+	// Serializes writes to stdin
+	stdinMu     sync.Mutex
+	stdinClosed bool
 
 	// Thread-safe error handling
 	mu  sync.RWMutex
 	err error
+
+	logger    *slog.Logger
+	sensitive bool // Log raw output verbatim
+
+	// Event delivery
+	sink    *eventSink
+	dropped atomic.Int64
+	spilled atomic.Int64
 }
 
 // SetError safely sets the error
@@ -313,3 +398,37 @@ func (s *Session) Error() error {
 	defer s.mu.RUnlock()
 	return s.err
 }
+
+// setExitError records the exit code on a classified stderr error, or
+// classifies a non-zero exit if nothing else went wrong
+func (s *Session) setExitError(waitErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := exitCode(waitErr)
+	var claudeErr *ClaudeError
+	switch {
+	case errors.As(s.err, &claudeErr):
+		claudeErr.ExitCode = code
+		if claudeErr.Kind == ErrUnknown && code == 127 {
+			claudeErr.Kind = ErrBinaryMissing
+		}
+	case s.err == nil && waitErr != nil:
+		s.err = newExitError(waitErr, code)
+	}
+}
+
+// setCanceled records cancellation as the session error, replacing any error
+// that was only a side effect of the process being killed
+func (s *Session) setCanceled(cause error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = &CanceledError{SessionID: s.ID, Cause: cause}
+}
+
+// abortedCh returns a channel that is closed once the process is killed
+// because the session was canceled, or nil for sessions that were not
+// started through LaunchContext
+func (s *Session) abortedCh() <-chan struct{} {
+	return s.aborted
+}
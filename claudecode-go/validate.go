@@ -0,0 +1,68 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validate reports option combinations the CLI would reject, so they fail
+// before a process is started. The returned error wraps ErrInvalidArgs.
+func (c SessionConfig) Validate() error {
+	// Session selection: resume, continue and an explicit new ID are exclusive,
+	// except that a fork of a resumed or continued session may pick its new ID
+	if c.SessionID != "" && c.Continue {
+		return invalidArgs("SessionID and Continue are mutually exclusive")
+	}
+	if c.ForkSession && c.SessionID == "" && !c.Continue {
+		return invalidArgs("ForkSession requires SessionID or Continue")
+	}
+	if c.NewSessionID != "" {
+		if !uuidPattern.MatchString(c.NewSessionID) {
+			return invalidArgs("NewSessionID %q is not a valid UUID", c.NewSessionID)
+		}
+		if (c.SessionID != "" || c.Continue) && !c.ForkSession {
+			return invalidArgs("NewSessionID can only be combined with SessionID or Continue when forking")
+		}
+	}
+
+	// Formats
+	if c.InputFormat == InputStreamJSON && c.OutputFormat != OutputStreamJSON {
+		return invalidArgs("input format %q requires output format %q", InputStreamJSON, OutputStreamJSON)
+	}
+	if c.IncludePartialMessages && c.OutputFormat != OutputStreamJSON {
+		return invalidArgs("partial messages require output format %q", OutputStreamJSON)
+	}
+	if len(c.JSONSchema) > 0 {
+		if c.OutputFormat != OutputJSON && c.OutputFormat != OutputStreamJSON {
+			return invalidArgs("JSONSchema requires output format %q or %q", OutputJSON, OutputStreamJSON)
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal(c.JSONSchema, &schema); err != nil {
+			return invalidArgs("JSONSchema is not a JSON object: %v", err)
+		}
+	}
+
+	// Settings
+	if strings.HasPrefix(strings.TrimSpace(c.Settings), "{") && !json.Valid([]byte(c.Settings)) {
+		return invalidArgs("inline Settings are not valid JSON")
+	}
+	for _, source := range c.SettingSources {
+		switch source {
+		case SettingSourceUser, SettingSourceProject, SettingSourceLocal:
+		default:
+			return invalidArgs("unknown setting source %q", source)
+		}
+	}
+	if err := c.Hooks.validate(); err != nil {
+		return err
+	}
+	for name, agent := range c.Agents {
+		if agent.Description == "" || agent.Prompt == "" {
+			return invalidArgs("agent %q needs a description and a prompt", name)
+		}
+	}
+	return nil
+}
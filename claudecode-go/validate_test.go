@@ -0,0 +1,96 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSessionConfigValidate(t *testing.T) {
+	const uuid = "3f2c8a9e-1b4d-4e6f-9a7b-2c5d8e1f0a3b"
+
+	testCases := []struct {
+		name   string
+		config SessionConfig
+		valid  bool
+	}{
+		{"empty", SessionConfig{Query: "hi"}, true},
+		{"resume and continue", SessionConfig{SessionID: "abc", Continue: true}, false},
+		{"fork without source", SessionConfig{ForkSession: true}, false},
+		{"fork continued session", SessionConfig{Continue: true, ForkSession: true}, true},
+		{"new session id", SessionConfig{NewSessionID: uuid}, true},
+		{"new session id not a uuid", SessionConfig{NewSessionID: "abc"}, false},
+		{"new session id on resume", SessionConfig{SessionID: "abc", NewSessionID: uuid}, false},
+		{"new session id on fork", SessionConfig{SessionID: "abc", ForkSession: true, NewSessionID: uuid}, true},
+		{"schema with text output", SessionConfig{JSONSchema: json.RawMessage(`{"type":"object"}`)}, false},
+		{"schema with json output", SessionConfig{OutputFormat: OutputJSON, JSONSchema: json.RawMessage(`{"type":"object"}`)}, true},
+		{"schema not an object", SessionConfig{OutputFormat: OutputJSON, JSONSchema: json.RawMessage(`[1]`)}, false},
+		{"invalid inline settings", SessionConfig{Settings: `{"hooks":`}, false},
+		{"settings file", SessionConfig{Settings: "/etc/claude/settings.json"}, true},
+		{"unknown setting source", SessionConfig{SettingSources: []SettingSource{"global"}}, false},
+		{"agent without prompt", SessionConfig{Agents: map[string]AgentDefinition{"reviewer": {Description: "Reviews code"}}}, false},
+		{"partials without stream-json", SessionConfig{IncludePartialMessages: true}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.valid && err != nil {
+				t.Errorf("expected valid config, got %v", err)
+			}
+			if !tc.valid && !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("expected ErrInvalidArgs, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildArgsExtendedFlags(t *testing.T) {
+	client := NewClientWithPath("/usr/bin/claude")
+
+	args, _, err := client.buildArgs(SessionConfig{
+		Query:           "hi",
+		Continue:        true,
+		ForkSession:     true,
+		NewSessionID:    "3f2c8a9e-1b4d-4e6f-9a7b-2c5d8e1f0a3b",
+		FallbackModel:   ModelHaiku,
+		OutputFormat:    OutputJSON,
+		JSONSchema:      json.RawMessage(`{"type":"object"}`),
+		StrictMCPConfig: true,
+		PermissionMode:  PermissionModePlan,
+		Settings:        `{"model":"opus"}`,
+		SettingSources:  []SettingSource{SettingSourceProject, SettingSourceLocal},
+		Agents:          map[string]AgentDefinition{"reviewer": {Description: "Reviews code", Prompt: "Review it"}},
+	})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+
+	expected := []string{
+		"--continue", "--fork-session", "--session-id", "3f2c8a9e-1b4d-4e6f-9a7b-2c5d8e1f0a3b",
+		"--fallback-model", "haiku",
+		"--output-format", "json",
+		"--json-schema", `{"type":"object"}`,
+		"--strict-mcp-config",
+		"--permission-mode", "plan",
+		"--settings", `{"model":"opus"}`,
+		"--setting-sources", "project,local",
+		"--agents", `{"reviewer":{"description":"Reviews code","prompt":"Review it"}}`,
+		"--print", "--", "hi",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected args:\n got %q\nwant %q", args, expected)
+	}
+
+	// The default permission mode and nil setting sources add no flags, while
+	// empty setting sources load none
+	args, _, err = client.buildArgs(SessionConfig{Query: "hi", PermissionMode: PermissionModeDefault, SettingSources: []SettingSource{}})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	expected = []string{"--setting-sources", "", "--print", "--", "hi"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected args:\n got %q\nwant %q", args, expected)
+	}
+}
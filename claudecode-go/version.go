@@ -0,0 +1,155 @@
+package claudecode
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed Claude CLI version
+type Version struct {
+	Major, Minor, Patch int
+	// Prerelease is the part after the hyphen, e.g. "dev.20250101" for
+	// development builds
+	Prerelease string
+	// Raw is the output of claude --version the version was parsed from
+	Raw string
+}
+
+var versionPattern = regexp.MustCompile(`\bv?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?`)
+
+// ParseVersion parses the output of claude --version, e.g.
+// "1.0.110 (Claude Code)"
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("no version number in %q", strings.TrimSpace(s))
+	}
+	v := Version{Prerelease: m[4], Raw: strings.TrimSpace(s)}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	return v, nil
+}
+
+// mustVersion parses a version literal from the capability matrix
+func mustVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the version without build metadata, e.g. "1.0.110"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as or newer than o.
+// Prereleases sort before the release they lead up to.
+func (v Version) Compare(o Version) int {
+	for _, d := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if d[0] != d[1] {
+			if d[0] < d[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	case v.Prerelease < o.Prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// AtLeast reports whether v is o or newer. A prerelease counts as the release
+// it leads up to, since development builds already carry its features.
+func (v Version) AtLeast(o Version) bool {
+	v.Prerelease, o.Prerelease = "", ""
+	return v.Compare(o) >= 0
+}
+
+// Capability is a CLI feature that only some versions support
+type Capability string
+
+const (
+	CapAppendSystemPrompt Capability = "append_system_prompt" // --append-system-prompt
+	CapStreamJSONInput    Capability = "stream_json_input"    // --input-format stream-json
+	CapStrictMCPConfig    Capability = "strict_mcp_config"    // --strict-mcp-config
+	CapFallbackModel      Capability = "fallback_model"       // --fallback-model
+	CapSettings           Capability = "settings"             // --settings
+	CapSessionID          Capability = "session_id"           // --session-id
+	CapPartialMessages    Capability = "partial_messages"     // --include-partial-messages
+	CapForkSession        Capability = "fork_session"         // --fork-session
+	CapAgents             Capability = "agents"               // --agents
+	CapSettingSources     Capability = "setting_sources"      // --setting-sources
+	CapSystemPrompt       Capability = "system_prompt"        // --system-prompt
+	CapJSONSchema         Capability = "json_schema"          // --json-schema
+)
+
+// CapabilityVersions is the capability matrix: the first CLI release that
+// supports each capability. It may be adjusted for forks or private builds.
+var CapabilityVersions = map[Capability]Version{
+	CapAppendSystemPrompt: mustVersion("0.2.0"),
+	CapStreamJSONInput:    mustVersion("1.0.0"),
+	CapStrictMCPConfig:    mustVersion("1.0.30"),
+	CapFallbackModel:      mustVersion("1.0.30"),
+	CapSettings:           mustVersion("1.0.50"),
+	CapSessionID:          mustVersion("1.0.70"),
+	CapPartialMessages:    mustVersion("1.0.80"),
+	CapForkSession:        mustVersion("1.0.80"),
+	CapAgents:             mustVersion("2.0.0"),
+	CapSettingSources:     mustVersion("2.0.0"),
+	CapSystemPrompt:       mustVersion("2.0.14"),
+	CapJSONSchema:         mustVersion("2.0.40"),
+}
+
+// Supports reports whether v has capability c. Capabilities missing from the
+// matrix are assumed to be supported.
+func (v Version) Supports(c Capability) bool {
+	first, ok := CapabilityVersions[c]
+	return !ok || v.AtLeast(first)
+}
+
+// Capabilities returns every capability in the matrix and whether v has it
+func (v Version) Capabilities() map[Capability]bool {
+	caps := make(map[Capability]bool, len(CapabilityVersions))
+	for c := range CapabilityVersions {
+		caps[c] = v.Supports(c)
+	}
+	return caps
+}
+
+// Unsupported returns the capabilities v lacks, sorted by the version that
+// added them
+func (v Version) Unsupported() []Capability {
+	var missing []Capability
+	for c := range CapabilityVersions {
+		if !v.Supports(c) {
+			missing = append(missing, c)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		a, b := missing[i], missing[j]
+		if c := CapabilityVersions[a].Compare(CapabilityVersions[b]); c != 0 {
+			return c < 0
+		}
+		return a < b
+	})
+	return missing
+}
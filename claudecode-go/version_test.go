@@ -0,0 +1,110 @@
+package claudecode
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"1.0.110 (Claude Code)", "1.0.110", false},
+		{"2.1.280-dev.20260921.t204017.sha80abbfe (Claude Code) (v2.1.280 release candidate)", "2.1.280-dev.20260921.t204017.sha80abbfe", false},
+		{"v0.2.125\n", "0.2.125", false},
+		{"Claude Code", "", true},
+	}
+	for _, tc := range testCases {
+		v, err := ParseVersion(tc.raw)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseVersion(%q) error = %v", tc.raw, err)
+			continue
+		}
+		if err == nil && v.String() != tc.want {
+			t.Errorf("ParseVersion(%q) = %s, want %s", tc.raw, v, tc.want)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	v := func(s string) Version { return mustVersion(s) }
+
+	if v("1.0.9").Compare(v("1.0.10")) != -1 {
+		t.Error("expected numeric comparison of patch versions")
+	}
+	if v("2.0.0-dev.1").Compare(v("2.0.0")) != -1 {
+		t.Error("expected a prerelease to sort before its release")
+	}
+	if !v("2.0.0-dev.1").AtLeast(v("2.0.0")) {
+		t.Error("expected a prerelease to have the features of its release")
+	}
+	if v("1.0.80").AtLeast(v("2.0.0")) {
+		t.Error("expected 1.0.80 to be older than 2.0.0")
+	}
+}
+
+func TestVersionCapabilities(t *testing.T) {
+	old := mustVersion("1.0.60")
+	if !old.Supports(CapSettings) || old.Supports(CapForkSession) {
+		t.Errorf("unexpected capabilities for %s: %v", old, old.Capabilities())
+	}
+	want := []Capability{CapSessionID, CapForkSession, CapPartialMessages, CapAgents, CapSettingSources, CapSystemPrompt, CapJSONSchema}
+	if got := old.Unsupported(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unsupported() = %v, want %v", got, want)
+	}
+	if got := mustVersion("2.1.0").Unsupported(); len(got) != 0 {
+		t.Errorf("expected a current CLI to support everything, missing %v", got)
+	}
+}
+
+func TestBuildArgsCapabilityGating(t *testing.T) {
+	client := NewClientWithPath("claude")
+	config := SessionConfig{
+		Query:                  "hi",
+		SessionID:              "abc",
+		ForkSession:            true,
+		OutputFormat:           OutputStreamJSON,
+		IncludePartialMessages: true,
+		FallbackModel:          ModelSonnet,
+	}
+
+	// Without a known version options are passed through
+	if _, _, err := client.buildArgs(config); err != nil {
+		t.Fatalf("expected unchecked options without a version, got %v", err)
+	}
+
+	client.SetVersion(mustVersion("1.0.60"))
+	_, _, err := client.buildArgs(config)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ForkSession requires claude 1.0.80 or later, but 1.0.60 is installed") {
+		t.Errorf("expected an actionable message, got %q", err)
+	}
+
+	// Optional options are dropped instead
+	config.ForkSession = false
+	args, _, err := client.buildArgs(config)
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	if containsFlag(args, "--include-partial-messages") {
+		t.Errorf("expected partial messages to be dropped, got %v", args)
+	}
+	if !containsFlag(args, "--fallback-model") {
+		t.Errorf("expected 1.0.60 to keep the fallback model, got %v", args)
+	}
+}
+
+func containsFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == flag {
+			return true
+		}
+	}
+	return false
+}
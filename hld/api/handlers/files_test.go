@@ -266,19 +266,10 @@ func TestFuzzySearchFiles_AbsolutePathNonExistent(t *testing.T) {
 
 // Helper to setup router with file handlers
 func setupTestRouterFiles(t *testing.T, files *handlers.FileHandlers) *gin.Engine {
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
-
-	// Create server implementation with file handlers
-	// Pass nil for handlers we don't need in these tests
-	settingsHandlers := handlers.NewSettingsHandlers(nil)
-	serverImpl := handlers.NewServerImpl(nil, nil, files, nil, settingsHandlers, nil, nil)
-	strictHandler := api.NewStrictHandler(serverImpl, nil)
-
-	api.RegisterHandlersWithOptions(router, strictHandler,
-		api.GinServerOptions{BaseURL: "/api/v1"})
-
-	return router
+	return newTestRouter(&handlers.ServerImpl{
+		FileHandlers:     files,
+		SettingsHandlers: handlers.NewSettingsHandlers(nil),
+	})
 }
 
 // Helper to create test file structure
@@ -67,6 +67,15 @@ func (h *FolderHandlers) CreateFolder(ctx context.Context, req api.CreateFolderR
 		}, nil
 	}
 
+	if msg := validateFolderBudget(req.Body.DefaultMaxCostUsd, req.Body.DefaultMaxOutputTokens, req.Body.DefaultMaxWallClock); msg != "" {
+		return api.CreateFolder400JSONResponse{
+			Error: api.ErrorDetail{
+				Code:    "HLD-4001",
+				Message: msg,
+			},
+		}, nil
+	}
+
 	// Check depth constraint if parent_id is specified
 	if req.Body.ParentId != nil {
 		parentDepth, err := h.store.GetFolderDepth(ctx, *req.Body.ParentId)
@@ -101,6 +110,16 @@ func (h *FolderHandlers) CreateFolder(ctx context.Context, req api.CreateFolderR
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
+	// Zero limits set no default
+	if c := req.Body.DefaultMaxCostUsd; c != nil && *c > 0 {
+		folder.DefaultMaxCostUSD = c
+	}
+	if t := req.Body.DefaultMaxOutputTokens; t != nil && *t > 0 {
+		folder.DefaultMaxOutputTokens = t
+	}
+	if w := req.Body.DefaultMaxWallClock; w != nil && *w > 0 {
+		folder.DefaultMaxWallClockMs = w
+	}
 
 	if err := h.store.CreateFolder(ctx, folder); err != nil {
 		slog.Error("failed to create folder", "error", err)
@@ -188,6 +207,15 @@ func (h *FolderHandlers) UpdateFolder(ctx context.Context, req api.UpdateFolderR
 		}
 	}
 
+	if msg := validateFolderBudget(req.Body.DefaultMaxCostUsd, req.Body.DefaultMaxOutputTokens, req.Body.DefaultMaxWallClock); msg != "" {
+		return api.UpdateFolder400JSONResponse{
+			Error: api.ErrorDetail{
+				Code:    "HLD-4001",
+				Message: msg,
+			},
+		}, nil
+	}
+
 	// Validate depth if parent_id is changing
 	if req.Body.ParentId != nil && *req.Body.ParentId != "" {
 		newParentID := *req.Body.ParentId
@@ -280,6 +308,25 @@ func (h *FolderHandlers) UpdateFolder(ctx context.Context, req api.UpdateFolderR
 	if req.Body.Position != nil {
 		updates.Position = req.Body.Position
 	}
+	// Zero removes a budget default
+	if c := req.Body.DefaultMaxCostUsd; c != nil {
+		if *c == 0 {
+			c = nil
+		}
+		updates.DefaultMaxCostUSD = &c
+	}
+	if t := req.Body.DefaultMaxOutputTokens; t != nil {
+		if *t == 0 {
+			t = nil
+		}
+		updates.DefaultMaxOutputTokens = &t
+	}
+	if w := req.Body.DefaultMaxWallClock; w != nil {
+		if *w == 0 {
+			w = nil
+		}
+		updates.DefaultMaxWallClockMs = &w
+	}
 	if req.Body.Archived != nil {
 		updates.Archived = req.Body.Archived
 		// If archiving, cascade to sessions
@@ -438,6 +485,24 @@ func folderToAPI(f *store.Folder) api.Folder {
 		SessionCount: &f.SessionCount,
 		CreatedAt:    f.CreatedAt,
 		UpdatedAt:    f.UpdatedAt,
+
+		DefaultMaxCostUsd:      f.DefaultMaxCostUSD,
+		DefaultMaxOutputTokens: f.DefaultMaxOutputTokens,
+		DefaultMaxWallClock:    f.DefaultMaxWallClockMs,
 	}
 	return apiFolder
 }
+
+// validateFolderBudget returns why a folder's budget defaults are invalid, or
+// an empty string when they are valid
+func validateFolderBudget(costUSD *float64, outputTokens *int, wallClockMs *int64) string {
+	switch {
+	case costUSD != nil && *costUSD < 0:
+		return "default_max_cost_usd must not be negative"
+	case outputTokens != nil && *outputTokens < 0:
+		return "default_max_output_tokens must not be negative"
+	case wallClockMs != nil && *wallClockMs < 0:
+		return "default_max_wall_clock must not be negative"
+	}
+	return ""
+}
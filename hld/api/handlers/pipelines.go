@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/humanlayer/humanlayer/hld/api"
+	"github.com/humanlayer/humanlayer/hld/session"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+type PipelineHandlers struct {
+	store  store.ConversationStore
+	runner *session.PipelineRunner
+}
+
+func NewPipelineHandlers(store store.ConversationStore, runner *session.PipelineRunner) *PipelineHandlers {
+	return &PipelineHandlers{
+		store:  store,
+		runner: runner,
+	}
+}
+
+// pipelineFromAPI builds and validates a pipeline from its API definition
+func pipelineFromAPI(id string, input api.PipelineInput) (*store.Pipeline, error) {
+	steps, err := json.Marshal(input.Steps)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := &store.Pipeline{
+		ID:    id,
+		Name:  input.Name,
+		Steps: string(steps),
+	}
+	if input.Description != nil {
+		pipeline.Description = *input.Description
+	}
+	if len(pipeline.Name) < 1 || len(pipeline.Name) > 100 {
+		return nil, errors.New("pipeline name must be between 1 and 100 characters")
+	}
+	if _, err := session.ParsePipelineSteps(pipeline.Steps); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// ListPipelines returns all pipelines
+func (h *PipelineHandlers) ListPipelines(ctx context.Context, req api.ListPipelinesRequestObject) (api.ListPipelinesResponseObject, error) {
+	pipelines, err := h.store.ListPipelines(ctx)
+	if err != nil {
+		slog.Error("failed to list pipelines", "error", err)
+		return api.ListPipelines500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to list pipelines"),
+		}, nil
+	}
+
+	data := make([]api.Pipeline, len(pipelines))
+	for i, pipeline := range pipelines {
+		data[i] = pipelineToAPI(pipeline)
+	}
+	return api.ListPipelines200JSONResponse{Data: data}, nil
+}
+
+// CreatePipeline creates a pipeline
+func (h *PipelineHandlers) CreatePipeline(ctx context.Context, req api.CreatePipelineRequestObject) (api.CreatePipelineResponseObject, error) {
+	pipeline, err := pipelineFromAPI("pipe_"+uuid.New().String()[:8], *req.Body)
+	if err != nil {
+		return api.CreatePipeline400JSONResponse{BadRequestJSONResponse: pipelineBadRequest(err.Error())}, nil
+	}
+
+	if err := h.store.CreatePipeline(ctx, pipeline); err != nil {
+		slog.Error("failed to create pipeline", "error", err)
+		return api.CreatePipeline500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to create pipeline"),
+		}, nil
+	}
+	return api.CreatePipeline201JSONResponse{Data: pipelineToAPI(pipeline)}, nil
+}
+
+// GetPipeline returns a single pipeline
+func (h *PipelineHandlers) GetPipeline(ctx context.Context, req api.GetPipelineRequestObject) (api.GetPipelineResponseObject, error) {
+	pipeline, err := h.store.GetPipeline(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.GetPipeline404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline not found")}, nil
+		}
+		slog.Error("failed to get pipeline", "pipeline_id", req.Id, "error", err)
+		return api.GetPipeline500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to get pipeline"),
+		}, nil
+	}
+	return api.GetPipeline200JSONResponse{Data: pipelineToAPI(pipeline)}, nil
+}
+
+// UpdatePipeline replaces a pipeline's name, description and steps
+func (h *PipelineHandlers) UpdatePipeline(ctx context.Context, req api.UpdatePipelineRequestObject) (api.UpdatePipelineResponseObject, error) {
+	existing, err := h.store.GetPipeline(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.UpdatePipeline404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline not found")}, nil
+		}
+		slog.Error("failed to get pipeline for update", "pipeline_id", req.Id, "error", err)
+		return api.UpdatePipeline500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to get pipeline"),
+		}, nil
+	}
+
+	pipeline, err := pipelineFromAPI(req.Id, *req.Body)
+	if err != nil {
+		return api.UpdatePipeline400JSONResponse{BadRequestJSONResponse: pipelineBadRequest(err.Error())}, nil
+	}
+	pipeline.CreatedAt = existing.CreatedAt
+
+	if err := h.store.UpdatePipeline(ctx, pipeline); err != nil {
+		slog.Error("failed to update pipeline", "pipeline_id", req.Id, "error", err)
+		return api.UpdatePipeline500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to update pipeline"),
+		}, nil
+	}
+	return api.UpdatePipeline200JSONResponse{Data: pipelineToAPI(pipeline)}, nil
+}
+
+// DeletePipeline deletes a pipeline and its runs
+func (h *PipelineHandlers) DeletePipeline(ctx context.Context, req api.DeletePipelineRequestObject) (api.DeletePipelineResponseObject, error) {
+	if err := h.store.DeletePipeline(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.DeletePipeline404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline not found")}, nil
+		}
+		slog.Error("failed to delete pipeline", "pipeline_id", req.Id, "error", err)
+		return api.DeletePipeline500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to delete pipeline"),
+		}, nil
+	}
+	return api.DeletePipeline204Response{}, nil
+}
+
+// ListPipelineRuns returns the runs of a pipeline
+func (h *PipelineHandlers) ListPipelineRuns(ctx context.Context, req api.ListPipelineRunsRequestObject) (api.ListPipelineRunsResponseObject, error) {
+	if _, err := h.store.GetPipeline(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.ListPipelineRuns404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline not found")}, nil
+		}
+		slog.Error("failed to get pipeline", "pipeline_id", req.Id, "error", err)
+		return api.ListPipelineRuns500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to get pipeline"),
+		}, nil
+	}
+
+	runs, err := h.store.ListPipelineRuns(ctx, req.Id, "")
+	if err != nil {
+		slog.Error("failed to list pipeline runs", "pipeline_id", req.Id, "error", err)
+		return api.ListPipelineRuns500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to list pipeline runs"),
+		}, nil
+	}
+
+	data := make([]api.PipelineRun, len(runs))
+	for i, run := range runs {
+		data[i] = pipelineRunToAPI(run)
+	}
+	return api.ListPipelineRuns200JSONResponse{Data: data}, nil
+}
+
+// StartPipelineRun starts a run of a pipeline
+func (h *PipelineHandlers) StartPipelineRun(ctx context.Context, req api.StartPipelineRunRequestObject) (api.StartPipelineRunResponseObject, error) {
+	var workingDir string
+	if req.Body.WorkingDir != nil {
+		workingDir = *req.Body.WorkingDir
+	}
+	vars := map[string]string{}
+	if req.Body.Variables != nil {
+		vars = *req.Body.Variables
+	}
+
+	run, err := h.runner.StartRun(ctx, req.Id, workingDir, vars)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.StartPipelineRun404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline not found")}, nil
+		}
+		var missingErr *session.MissingVariablesError
+		if errors.As(err, &missingErr) {
+			return api.StartPipelineRun400JSONResponse{BadRequestJSONResponse: pipelineBadRequest(err.Error())}, nil
+		}
+		slog.Error("failed to start pipeline run", "pipeline_id", req.Id, "error", err)
+		return api.StartPipelineRun500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError(err.Error()),
+		}, nil
+	}
+	return api.StartPipelineRun201JSONResponse{Data: pipelineRunToAPI(run)}, nil
+}
+
+// GetPipelineRun returns a pipeline run with the status of its steps
+func (h *PipelineHandlers) GetPipelineRun(ctx context.Context, req api.GetPipelineRunRequestObject) (api.GetPipelineRunResponseObject, error) {
+	run, err := h.store.GetPipelineRun(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.GetPipelineRun404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline run not found")}, nil
+		}
+		slog.Error("failed to get pipeline run", "pipeline_run_id", req.Id, "error", err)
+		return api.GetPipelineRun500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError("Failed to get pipeline run"),
+		}, nil
+	}
+	return api.GetPipelineRun200JSONResponse{Data: pipelineRunToAPI(run)}, nil
+}
+
+// RetryPipelineStep launches a failed step of a pipeline run again
+func (h *PipelineHandlers) RetryPipelineStep(ctx context.Context, req api.RetryPipelineStepRequestObject) (api.RetryPipelineStepResponseObject, error) {
+	run, err := h.runner.RetryStep(ctx, req.Id, req.Step)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.RetryPipelineStep404JSONResponse{NotFoundJSONResponse: pipelineNotFound("Pipeline run not found")}, nil
+		}
+		var stateErr *session.PipelineStateError
+		if errors.As(err, &stateErr) {
+			return api.RetryPipelineStep400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3002",
+					Message: stateErr.Error(),
+				},
+			}, nil
+		}
+		slog.Error("failed to retry pipeline step", "pipeline_run_id", req.Id, "step", req.Step, "error", err)
+		return api.RetryPipelineStep500JSONResponse{
+			InternalErrorJSONResponse: pipelineInternalError(err.Error()),
+		}, nil
+	}
+	return api.RetryPipelineStep200JSONResponse{Data: pipelineRunToAPI(run)}, nil
+}
+
+func pipelineToAPI(pipeline *store.Pipeline) api.Pipeline {
+	out := api.Pipeline{
+		Id:        pipeline.ID,
+		Name:      pipeline.Name,
+		Steps:     []api.PipelineStep{},
+		Variables: []string{},
+		CreatedAt: pipeline.CreatedAt,
+		UpdatedAt: pipeline.UpdatedAt,
+	}
+	if pipeline.Description != "" {
+		out.Description = &pipeline.Description
+	}
+	if err := json.Unmarshal([]byte(pipeline.Steps), &out.Steps); err != nil {
+		slog.Warn("failed to decode pipeline steps", "pipeline_id", pipeline.ID, "error", err)
+	}
+	if steps, err := session.ParsePipelineSteps(pipeline.Steps); err == nil {
+		if vars := session.PipelineVariables(steps); vars != nil {
+			out.Variables = vars
+		}
+	}
+	return out
+}
+
+func pipelineRunToAPI(run *store.PipelineRun) api.PipelineRun {
+	out := api.PipelineRun{
+		Id:           run.ID,
+		PipelineId:   run.PipelineID,
+		PipelineName: run.PipelineName,
+		Status:       run.Status,
+		Steps:        make([]api.PipelineRunStep, len(run.Steps)),
+		CreatedAt:    run.CreatedAt,
+		CompletedAt:  run.CompletedAt,
+	}
+	if run.WorkingDir != "" {
+		out.WorkingDir = &run.WorkingDir
+	}
+	if run.Error != "" {
+		out.Error = &run.Error
+	}
+	if run.Variables != "" {
+		var vars map[string]string
+		if err := json.Unmarshal([]byte(run.Variables), &vars); err != nil {
+			slog.Warn("failed to decode pipeline run variables", "pipeline_run_id", run.ID, "error", err)
+		} else if len(vars) > 0 {
+			out.Variables = &vars
+		}
+	}
+	for i, step := range run.Steps {
+		out.Steps[i] = api.PipelineRunStep{
+			Name:        step.Name,
+			Status:      step.Status,
+			Attempts:    step.Attempts,
+			StartedAt:   step.StartedAt,
+			CompletedAt: step.CompletedAt,
+		}
+		if step.SessionID != "" {
+			out.Steps[i].SessionId = &step.SessionID
+		}
+		if step.Error != "" {
+			out.Steps[i].Error = &step.Error
+		}
+	}
+	return out
+}
+
+func pipelineNotFound(message string) api.NotFoundJSONResponse {
+	return api.NotFoundJSONResponse{
+		Error: api.ErrorDetail{
+			Code:    "HLD-1002",
+			Message: message,
+		},
+	}
+}
+
+func pipelineBadRequest(message string) api.BadRequestJSONResponse {
+	return api.BadRequestJSONResponse{
+		Error: api.ErrorDetail{
+			Code:    "HLD-3001",
+			Message: message,
+		},
+	}
+}
+
+func pipelineInternalError(message string) api.InternalErrorJSONResponse {
+	return api.InternalErrorJSONResponse{
+		Error: api.ErrorDetail{
+			Code:    "HLD-4001",
+			Message: message,
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/humanlayer/humanlayer/hld/api"
+	"github.com/humanlayer/humanlayer/hld/api/handlers"
+	"github.com/humanlayer/humanlayer/hld/session"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupPipelineRouter(t *testing.T, manager session.SessionManager) (*gin.Engine, *store.SQLiteStore) {
+	sqliteStore := newTestStore(t)
+	runner := session.NewPipelineRunner(sqliteStore, manager, nil, time.Minute)
+	return newTestRouter(&handlers.ServerImpl{PipelineHandlers: handlers.NewPipelineHandlers(sqliteStore, runner)}), sqliteStore
+}
+
+func TestPipelineHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockManager := session.NewMockSessionManager(ctrl)
+	router, sqliteStore := setupPipelineRouter(t, mockManager)
+
+	w := makeRequest(t, router, "POST", "/api/v1/pipelines", api.PipelineInput{
+		Name: "Ticket",
+		Steps: []api.PipelineStep{
+			{Name: "research", Launch: api.ScheduleLaunch{Query: "Research {{ticket}}"}},
+			{
+				Name:      "plan",
+				DependsOn: &[]string{"research"},
+				Launch:    api.ScheduleLaunch{Query: "Plan from {{steps.research.thoughts}}"},
+			},
+		},
+	})
+	var created api.PipelineResponse
+	assertJSONResponse(t, w, http.StatusCreated, &created)
+	pipeline := created.Data
+	assert.Equal(t, []string{"ticket"}, pipeline.Variables)
+	require.Len(t, pipeline.Steps, 2)
+	assert.Equal(t, []string{"research"}, *pipeline.Steps[1].DependsOn)
+
+	w = makeRequest(t, router, "POST", "/api/v1/pipelines/"+pipeline.Id+"/runs", api.StartPipelineRunRequest{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assertErrorResponse(t, w, "HLD-3001", "missing template variables: ticket")
+
+	mockManager.EXPECT().
+		LaunchSession(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(ctx context.Context, config session.LaunchSessionConfig, _ bool) (*session.Session, error) {
+			assert.Equal(t, "Research ENG-1", config.Query)
+			assert.Equal(t, "Ticket: research", config.Title)
+			require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+				ID: "sess_1", RunID: "run_1", Query: config.Query, Status: store.SessionStatusRunning,
+			}))
+			return &session.Session{ID: "sess_1", RunID: "run_1"}, nil
+		})
+
+	w = makeRequest(t, router, "POST", "/api/v1/pipelines/"+pipeline.Id+"/runs", api.StartPipelineRunRequest{
+		WorkingDir: stringPtr("~/repo"),
+		Variables:  &map[string]string{"ticket": "ENG-1"},
+	})
+	var started api.PipelineRunResponse
+	assertJSONResponse(t, w, http.StatusCreated, &started)
+	run := started.Data
+	assert.Equal(t, "running", run.Status)
+	require.Len(t, run.Steps, 2)
+	assert.Equal(t, "running", run.Steps[0].Status)
+	assert.Equal(t, "sess_1", *run.Steps[0].SessionId)
+	assert.Equal(t, 1, run.Steps[0].Attempts)
+	assert.Equal(t, "pending", run.Steps[1].Status)
+
+	w = makeRequest(t, router, "POST", "/api/v1/pipeline-runs/"+run.Id+"/steps/research/retry", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assertErrorResponse(t, w, "HLD-3002", "step research is running, only failed steps can be retried")
+
+	w = makeRequest(t, router, "GET", "/api/v1/pipelines/"+pipeline.Id+"/runs", nil)
+	var runs api.PipelineRunsResponse
+	assertJSONResponse(t, w, http.StatusOK, &runs)
+	require.Len(t, runs.Data, 1)
+	assert.Equal(t, map[string]string{"ticket": "ENG-1"}, *runs.Data[0].Variables)
+
+	w = makeRequest(t, router, "DELETE", "/api/v1/pipelines/"+pipeline.Id, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = makeRequest(t, router, "GET", "/api/v1/pipeline-runs/"+run.Id, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assertErrorResponse(t, w, "HLD-1002", "Pipeline run not found")
+}
+
+func TestPipelineHandlersValidation(t *testing.T) {
+	router, _ := setupPipelineRouter(t, nil)
+
+	tests := []struct {
+		name    string
+		request api.PipelineInput
+		message string
+	}{
+		{
+			name:    "empty name",
+			request: api.PipelineInput{Steps: []api.PipelineStep{{Name: "a", Launch: api.ScheduleLaunch{Query: "q"}}}},
+			message: "pipeline name must be between 1 and 100 characters",
+		},
+		{
+			name:    "no steps",
+			request: api.PipelineInput{Name: "empty", Steps: []api.PipelineStep{}},
+			message: "a pipeline needs at least one step",
+		},
+		{
+			name: "cycle",
+			request: api.PipelineInput{Name: "loop", Steps: []api.PipelineStep{
+				{Name: "a", DependsOn: &[]string{"b"}, Launch: api.ScheduleLaunch{Query: "q"}},
+				{Name: "b", DependsOn: &[]string{"a"}, Launch: api.ScheduleLaunch{Query: "q"}},
+			}},
+			message: "dependency cycle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := makeRequest(t, router, "POST", "/api/v1/pipelines", tt.request)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assertErrorResponse(t, w, "HLD-3001", tt.message)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humanlayer/humanlayer/hld/api"
+	"github.com/humanlayer/humanlayer/hld/session"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// defaultScheduleRunsLimit is how many runs are listed when no limit is given
+const defaultScheduleRunsLimit = 50
+
+type ScheduleHandlers struct {
+	store store.ConversationStore
+}
+
+func NewScheduleHandlers(store store.ConversationStore) *ScheduleHandlers {
+	return &ScheduleHandlers{store: store}
+}
+
+// ListSchedules returns all schedules
+func (h *ScheduleHandlers) ListSchedules(ctx context.Context, req api.ListSchedulesRequestObject) (api.ListSchedulesResponseObject, error) {
+	schedules, err := h.store.ListSchedules(ctx)
+	if err != nil {
+		slog.Error("failed to list schedules", "error", err)
+		return api.ListSchedules500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to list schedules"),
+		}, nil
+	}
+
+	data := make([]api.Schedule, len(schedules))
+	for i, schedule := range schedules {
+		data[i] = scheduleToAPI(schedule)
+	}
+	return api.ListSchedules200JSONResponse{Data: data}, nil
+}
+
+// CreateSchedule creates a schedule and computes its first run
+func (h *ScheduleHandlers) CreateSchedule(ctx context.Context, req api.CreateScheduleRequestObject) (api.CreateScheduleResponseObject, error) {
+	launchConfig, err := json.Marshal(req.Body.Launch)
+	if err != nil {
+		return api.CreateSchedule400JSONResponse{BadRequestJSONResponse: scheduleBadRequest(err.Error())}, nil
+	}
+
+	now := time.Now()
+	schedule := &store.Schedule{
+		ID:           "sched_" + uuid.New().String()[:8],
+		Name:         req.Body.Name,
+		RunAt:        req.Body.RunAt,
+		CatchUp:      session.CatchUpSkip,
+		Enabled:      true,
+		LaunchConfig: string(launchConfig),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if req.Body.Cron != nil {
+		schedule.CronExpr = *req.Body.Cron
+	}
+	if req.Body.CatchUp != nil {
+		schedule.CatchUp = *req.Body.CatchUp
+	}
+	if req.Body.Enabled != nil {
+		schedule.Enabled = *req.Body.Enabled
+	}
+	if err := session.ValidateSchedule(schedule); err != nil {
+		return api.CreateSchedule400JSONResponse{BadRequestJSONResponse: scheduleBadRequest(err.Error())}, nil
+	}
+	if schedule.NextRunAt, err = session.NextScheduleRun(schedule, now); err != nil {
+		return api.CreateSchedule400JSONResponse{BadRequestJSONResponse: scheduleBadRequest(err.Error())}, nil
+	}
+
+	if err := h.store.CreateSchedule(ctx, schedule); err != nil {
+		slog.Error("failed to create schedule", "error", err)
+		return api.CreateSchedule500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to create schedule"),
+		}, nil
+	}
+	slog.Info("created schedule",
+		"schedule_id", schedule.ID,
+		"cron", schedule.CronExpr,
+		"next_run_at", schedule.NextRunAt)
+
+	return api.CreateSchedule201JSONResponse{Data: scheduleToAPI(schedule)}, nil
+}
+
+// GetSchedule returns a single schedule
+func (h *ScheduleHandlers) GetSchedule(ctx context.Context, req api.GetScheduleRequestObject) (api.GetScheduleResponseObject, error) {
+	schedule, err := h.store.GetSchedule(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.GetSchedule404JSONResponse{NotFoundJSONResponse: scheduleNotFound()}, nil
+		}
+		slog.Error("failed to get schedule", "schedule_id", req.Id, "error", err)
+		return api.GetSchedule500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to get schedule"),
+		}, nil
+	}
+	return api.GetSchedule200JSONResponse{Data: scheduleToAPI(schedule)}, nil
+}
+
+// UpdateSchedule updates a schedule. Changing its timing, or enabling it,
+// recomputes the next run from now.
+func (h *ScheduleHandlers) UpdateSchedule(ctx context.Context, req api.UpdateScheduleRequestObject) (api.UpdateScheduleResponseObject, error) {
+	schedule, err := h.store.GetSchedule(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.UpdateSchedule404JSONResponse{NotFoundJSONResponse: scheduleNotFound()}, nil
+		}
+		slog.Error("failed to get schedule for update", "schedule_id", req.Id, "error", err)
+		return api.UpdateSchedule500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to get schedule"),
+		}, nil
+	}
+
+	update := store.ScheduleUpdate{}
+	retime := false
+	if req.Body.Name != nil {
+		schedule.Name = *req.Body.Name
+		update.Name = req.Body.Name
+	}
+	if req.Body.Cron != nil {
+		schedule.CronExpr = *req.Body.Cron
+		schedule.RunAt = nil
+		update.CronExpr = req.Body.Cron
+		update.RunAt = &schedule.RunAt
+		retime = true
+	}
+	if req.Body.RunAt != nil {
+		schedule.RunAt = req.Body.RunAt
+		schedule.CronExpr = ""
+		schedule.LastRunAt = nil
+		update.RunAt = &schedule.RunAt
+		update.CronExpr = &schedule.CronExpr
+		retime = true
+	}
+	if req.Body.CatchUp != nil {
+		schedule.CatchUp = *req.Body.CatchUp
+		update.CatchUp = req.Body.CatchUp
+	}
+	if req.Body.Enabled != nil {
+		retime = retime || (*req.Body.Enabled && !schedule.Enabled)
+		schedule.Enabled = *req.Body.Enabled
+		update.Enabled = req.Body.Enabled
+	}
+	if req.Body.Launch != nil {
+		launchConfig, err := json.Marshal(req.Body.Launch)
+		if err != nil {
+			return api.UpdateSchedule400JSONResponse{BadRequestJSONResponse: scheduleBadRequest(err.Error())}, nil
+		}
+		schedule.LaunchConfig = string(launchConfig)
+		update.LaunchConfig = &schedule.LaunchConfig
+	}
+	if req.Body.Cron != nil && req.Body.RunAt != nil {
+		return api.UpdateSchedule400JSONResponse{
+			BadRequestJSONResponse: scheduleBadRequest("a schedule takes either cron or run_at, not both"),
+		}, nil
+	}
+	if err := session.ValidateSchedule(schedule); err != nil {
+		return api.UpdateSchedule400JSONResponse{BadRequestJSONResponse: scheduleBadRequest(err.Error())}, nil
+	}
+	if retime {
+		if schedule.NextRunAt, err = session.NextScheduleRun(schedule, time.Now()); err != nil {
+			return api.UpdateSchedule400JSONResponse{BadRequestJSONResponse: scheduleBadRequest(err.Error())}, nil
+		}
+		update.NextRunAt = &schedule.NextRunAt
+	}
+
+	if err := h.store.UpdateSchedule(ctx, req.Id, update); err != nil {
+		slog.Error("failed to update schedule", "schedule_id", req.Id, "error", err)
+		return api.UpdateSchedule500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to update schedule"),
+		}, nil
+	}
+
+	updated, err := h.store.GetSchedule(ctx, req.Id)
+	if err != nil {
+		slog.Error("failed to get updated schedule", "schedule_id", req.Id, "error", err)
+		return api.UpdateSchedule500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to get schedule"),
+		}, nil
+	}
+	return api.UpdateSchedule200JSONResponse{Data: scheduleToAPI(updated)}, nil
+}
+
+// DeleteSchedule deletes a schedule and its run history
+func (h *ScheduleHandlers) DeleteSchedule(ctx context.Context, req api.DeleteScheduleRequestObject) (api.DeleteScheduleResponseObject, error) {
+	if err := h.store.DeleteSchedule(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.DeleteSchedule404JSONResponse{NotFoundJSONResponse: scheduleNotFound()}, nil
+		}
+		slog.Error("failed to delete schedule", "schedule_id", req.Id, "error", err)
+		return api.DeleteSchedule500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to delete schedule"),
+		}, nil
+	}
+	return api.DeleteSchedule204Response{}, nil
+}
+
+// ListScheduleRuns returns the run history of a schedule
+func (h *ScheduleHandlers) ListScheduleRuns(ctx context.Context, req api.ListScheduleRunsRequestObject) (api.ListScheduleRunsResponseObject, error) {
+	if _, err := h.store.GetSchedule(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.ListScheduleRuns404JSONResponse{NotFoundJSONResponse: scheduleNotFound()}, nil
+		}
+		slog.Error("failed to get schedule", "schedule_id", req.Id, "error", err)
+		return api.ListScheduleRuns500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to get schedule"),
+		}, nil
+	}
+
+	limit := defaultScheduleRunsLimit
+	if req.Params.Limit != nil && *req.Params.Limit > 0 {
+		limit = *req.Params.Limit
+	}
+	runs, err := h.store.ListScheduleRuns(ctx, req.Id, limit)
+	if err != nil {
+		slog.Error("failed to list schedule runs", "schedule_id", req.Id, "error", err)
+		return api.ListScheduleRuns500JSONResponse{
+			InternalErrorJSONResponse: scheduleInternalError("Failed to list schedule runs"),
+		}, nil
+	}
+
+	data := make([]api.ScheduleRun, len(runs))
+	for i, run := range runs {
+		data[i] = api.ScheduleRun{
+			Id:           run.ID,
+			ScheduleId:   run.ScheduleID,
+			ScheduledFor: run.ScheduledFor,
+			TriggeredAt:  run.TriggeredAt,
+			Status:       run.Status,
+		}
+		if run.SessionID != "" {
+			data[i].SessionId = &run.SessionID
+		}
+		if run.Error != "" {
+			data[i].Error = &run.Error
+		}
+	}
+	return api.ListScheduleRuns200JSONResponse{Data: data}, nil
+}
+
+func scheduleToAPI(schedule *store.Schedule) api.Schedule {
+	out := api.Schedule{
+		Id:        schedule.ID,
+		Name:      schedule.Name,
+		RunAt:     schedule.RunAt,
+		CatchUp:   schedule.CatchUp,
+		Enabled:   schedule.Enabled,
+		NextRunAt: schedule.NextRunAt,
+		LastRunAt: schedule.LastRunAt,
+		CreatedAt: schedule.CreatedAt,
+		UpdatedAt: schedule.UpdatedAt,
+	}
+	if schedule.CronExpr != "" {
+		out.Cron = &schedule.CronExpr
+	}
+	if err := json.Unmarshal([]byte(schedule.LaunchConfig), &out.Launch); err != nil {
+		slog.Warn("failed to decode schedule launch configuration", "schedule_id", schedule.ID, "error", err)
+	}
+	return out
+}
+
+func scheduleNotFound() api.NotFoundJSONResponse {
+	return api.NotFoundJSONResponse{
+		Error: api.ErrorDetail{
+			Code:    "HLD-1002",
+			Message: "Schedule not found",
+		},
+	}
+}
+
+func scheduleBadRequest(message string) api.BadRequestJSONResponse {
+	return api.BadRequestJSONResponse{
+		Error: api.ErrorDetail{
+			Code:    "HLD-3001",
+			Message: message,
+		},
+	}
+}
+
+func scheduleInternalError(message string) api.InternalErrorJSONResponse {
+	return api.InternalErrorJSONResponse{
+		Error: api.ErrorDetail{
+			Code:    "HLD-4001",
+			Message: message,
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/hld/api"
+	"github.com/humanlayer/humanlayer/hld/api/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleHandlers(t *testing.T) {
+	router := newTestRouter(&handlers.ServerImpl{ScheduleHandlers: handlers.NewScheduleHandlers(newTestStore(t))})
+
+	w := makeRequest(t, router, "POST", "/api/v1/schedules", api.CreateScheduleRequest{
+		Name:   "Dependency audit",
+		Cron:   stringPtr("0 9 * * mon"),
+		Launch: api.ScheduleLaunch{Query: "Audit our dependencies", WorkingDir: stringPtr("~/repo")},
+	})
+	var created api.ScheduleResponse
+	assertJSONResponse(t, w, http.StatusCreated, &created)
+	schedule := created.Data
+	assert.Equal(t, "skip", schedule.CatchUp)
+	assert.True(t, schedule.Enabled)
+	require.NotNil(t, schedule.NextRunAt)
+	assert.Equal(t, 9, schedule.NextRunAt.Local().Hour())
+	assert.Equal(t, "~/repo", *schedule.Launch.WorkingDir)
+
+	w = makeRequest(t, router, "PATCH", "/api/v1/schedules/"+schedule.Id, api.UpdateScheduleRequest{
+		Enabled: boolPtr(false),
+	})
+	var updated api.ScheduleResponse
+	assertJSONResponse(t, w, http.StatusOK, &updated)
+	assert.False(t, updated.Data.Enabled)
+	assert.Equal(t, "Audit our dependencies", updated.Data.Launch.Query)
+
+	w = makeRequest(t, router, "GET", "/api/v1/schedules/"+schedule.Id+"/runs", nil)
+	var runs api.ScheduleRunsResponse
+	assertJSONResponse(t, w, http.StatusOK, &runs)
+	assert.Empty(t, runs.Data)
+
+	w = makeRequest(t, router, "DELETE", "/api/v1/schedules/"+schedule.Id, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = makeRequest(t, router, "GET", "/api/v1/schedules/"+schedule.Id, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assertErrorResponse(t, w, "HLD-1002", "Schedule not found")
+}
+
+func TestScheduleHandlersValidation(t *testing.T) {
+	router := newTestRouter(&handlers.ServerImpl{ScheduleHandlers: handlers.NewScheduleHandlers(newTestStore(t))})
+
+	tests := []struct {
+		name    string
+		request api.CreateScheduleRequest
+		message string
+	}{
+		{
+			name:    "invalid cron",
+			request: api.CreateScheduleRequest{Name: "bad", Cron: stringPtr("0 9 * *"), Launch: api.ScheduleLaunch{Query: "q"}},
+			message: "must have 5 fields",
+		},
+		{
+			name:    "no timing",
+			request: api.CreateScheduleRequest{Name: "bad", Launch: api.ScheduleLaunch{Query: "q"}},
+			message: "a schedule needs cron or run_at",
+		},
+		{
+			name: "invalid catch-up",
+			request: api.CreateScheduleRequest{
+				Name: "bad", Cron: stringPtr("@daily"), CatchUp: stringPtr("all"), Launch: api.ScheduleLaunch{Query: "q"},
+			},
+			message: `invalid catch_up "all"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := makeRequest(t, router, "POST", "/api/v1/schedules", tt.request)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assertErrorResponse(t, w, "HLD-3001", tt.message)
+		})
+	}
+}
@@ -14,10 +14,13 @@ type ServerImpl struct {
 	*AgentHandlers
 	*FolderHandlers
 	*ThoughtHandlers
+	*ScheduleHandlers
+	*TemplateHandlers
+	*PipelineHandlers
 }
 
 // NewServerImpl creates a new server implementation
-func NewServerImpl(sessions *SessionHandlers, approvals *ApprovalHandlers, files *FileHandlers, sse *SSEHandler, settings *SettingsHandlers, agents *AgentHandlers, folders *FolderHandlers, thoughts *ThoughtHandlers) api.StrictServerInterface {
+func NewServerImpl(sessions *SessionHandlers, approvals *ApprovalHandlers, files *FileHandlers, sse *SSEHandler, settings *SettingsHandlers, agents *AgentHandlers, folders *FolderHandlers, thoughts *ThoughtHandlers, schedules *ScheduleHandlers, templates *TemplateHandlers, pipelines *PipelineHandlers) api.StrictServerInterface {
 	return &ServerImpl{
 		SessionHandlers:  sessions,
 		ApprovalHandlers: approvals,
@@ -27,6 +30,9 @@ func NewServerImpl(sessions *SessionHandlers, approvals *ApprovalHandlers, files
 		AgentHandlers:    agents,
 		FolderHandlers:   folders,
 		ThoughtHandlers:  thoughts,
+		ScheduleHandlers: schedules,
+		TemplateHandlers: templates,
+		PipelineHandlers: pipelines,
 	}
 }
 
@@ -218,6 +218,130 @@ func (h *SessionHandlers) CreateSession(ctx context.Context, req api.CreateSessi
 	if req.Body.Verbose != nil {
 		config.Verbose = *req.Body.Verbose
 	}
+	if req.Body.IncludePartialMessages != nil {
+		config.IncludePartialMessages = *req.Body.IncludePartialMessages
+	}
+	if req.Body.PermissionMode != nil {
+		config.PermissionMode = claudecode.PermissionMode(*req.Body.PermissionMode)
+	}
+	if req.Body.FallbackModel != nil {
+		config.FallbackModel = claudecode.Model(*req.Body.FallbackModel)
+	}
+	if req.Body.Settings != nil {
+		config.Settings = *req.Body.Settings
+	}
+	if req.Body.SettingSources != nil {
+		config.SettingSources = make([]claudecode.SettingSource, len(*req.Body.SettingSources))
+		for i, source := range *req.Body.SettingSources {
+			config.SettingSources[i] = claudecode.SettingSource(source)
+		}
+	}
+	if req.Body.Agents != nil {
+		config.Agents = make(map[string]claudecode.AgentDefinition, len(*req.Body.Agents))
+		for name, agent := range *req.Body.Agents {
+			def := claudecode.AgentDefinition{Description: agent.Description, Prompt: agent.Prompt}
+			if agent.Tools != nil {
+				def.Tools = *agent.Tools
+			}
+			if agent.Model != nil {
+				def.Model = *agent.Model
+			}
+			config.Agents[name] = def
+		}
+	}
+	if req.Body.StrictMcpConfig != nil {
+		config.StrictMCPConfig = *req.Body.StrictMcpConfig
+	}
+	config.Hooks = h.mapper.HooksFromAPI(req.Body.Hooks)
+	if req.Body.ForwardHooks != nil {
+		config.ForwardHooks = *req.Body.ForwardHooks
+	}
+	if req.Body.Priority != nil {
+		priority := session.Priority(*req.Body.Priority)
+		if !priority.Valid() {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("invalid priority %q: must be low, normal or high", priority),
+					},
+				},
+			}, nil
+		}
+		config.Priority = priority
+	}
+	if req.Body.Worktree != nil {
+		config.Worktree = &session.WorktreeConfig{}
+		if req.Body.Worktree.Branch != nil {
+			config.Worktree.Branch = *req.Body.Worktree.Branch
+		}
+		if req.Body.Worktree.BaseRef != nil {
+			config.Worktree.BaseRef = *req.Body.Worktree.BaseRef
+		}
+	}
+	if req.Body.FolderId != nil && *req.Body.FolderId != "" {
+		folder, err := h.store.GetFolder(ctx, *req.Body.FolderId)
+		if err != nil {
+			slog.Error("failed to get folder", "folder_id", *req.Body.FolderId, "error", err)
+			return api.CreateSession500JSONResponse{
+				InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-4001",
+						Message: "Failed to get folder",
+					},
+				},
+			}, nil
+		}
+		if folder == nil {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("folder %s not found", *req.Body.FolderId),
+					},
+				},
+			}, nil
+		}
+		config.FolderID = folder.ID
+	}
+	if req.Body.MaxCostUsd != nil {
+		config.Budget.MaxCostUSD = *req.Body.MaxCostUsd
+	}
+	if req.Body.MaxOutputTokens != nil {
+		config.Budget.MaxOutputTokens = *req.Body.MaxOutputTokens
+	}
+	if req.Body.MaxWallClock != nil {
+		config.Budget.MaxWallClock = time.Duration(*req.Body.MaxWallClock) * time.Millisecond
+	}
+	if req.Body.StallPolicy != nil {
+		config.StallPolicy = session.StallPolicy(*req.Body.StallPolicy)
+	}
+	if req.Body.MaxRetries != nil {
+		config.Retry = &session.RetryPolicy{MaxAttempts: *req.Body.MaxRetries}
+		if req.Body.RetryBackoff != nil {
+			config.Retry.Backoff = time.Duration(*req.Body.RetryBackoff) * time.Millisecond
+		}
+	}
+	if req.Body.ClaudeSessionId != nil {
+		config.NewSessionID = req.Body.ClaudeSessionId.String()
+	}
+	if req.Body.Continue != nil {
+		config.Continue = *req.Body.Continue
+	}
+	if req.Body.JsonSchema != nil {
+		schema, err := json.Marshal(*req.Body.JsonSchema)
+		if err != nil {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("invalid json_schema: %v", err),
+					},
+				},
+			}, nil
+		}
+		config.JSONSchema = schema
+	}
 	if req.Body.AutoAcceptEdits != nil {
 		config.AutoAcceptEdits = *req.Body.AutoAcceptEdits
 	}
@@ -247,6 +371,18 @@ func (h *SessionHandlers) CreateSession(ctx context.Context, req api.CreateSessi
 		config.CreateDirectoryIfNotExists = true
 	}
 
+	// Reject option combinations the CLI would refuse before creating the session
+	if err := config.Validate(); err != nil {
+		return api.CreateSession400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
 	// Check for draft flag in request
 	isDraft := req.Body.Draft != nil && *req.Body.Draft
 
@@ -263,6 +399,17 @@ func (h *SessionHandlers) CreateSession(ctx context.Context, req api.CreateSessi
 				RequiresCreation: true,
 			}, nil
 		}
+		var worktreeErr *session.WorktreeStateError
+		if errors.As(err, &worktreeErr) {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: worktreeErr.Error(),
+					},
+				},
+			}, nil
+		}
 		slog.Error("Failed to launch session",
 			"error", fmt.Sprintf("%v", err),
 			"query", config.Query,
@@ -285,6 +432,96 @@ func (h *SessionHandlers) CreateSession(ctx context.Context, req api.CreateSessi
 	return api.CreateSession201JSONResponse(resp), nil
 }
 
+// CreateSessionFromTemplate implements POST /sessions/from-template/{id}
+func (h *SessionHandlers) CreateSessionFromTemplate(ctx context.Context, req api.CreateSessionFromTemplateRequestObject) (api.CreateSessionFromTemplateResponseObject, error) {
+	template, err := h.store.GetSessionTemplate(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.CreateSessionFromTemplate404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session template not found",
+					},
+				},
+			}, nil
+		}
+		slog.Error("failed to get session template", "template_id", req.Id, "error", err)
+		return api.CreateSessionFromTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: "Failed to get session template",
+				},
+			},
+		}, nil
+	}
+
+	var vars map[string]string
+	if req.Body.Variables != nil {
+		vars = *req.Body.Variables
+	}
+	config, err := session.TemplateLaunchConfig(template, vars)
+	if err == nil && req.Body.Priority != nil {
+		config.Priority = session.Priority(*req.Body.Priority)
+		if !config.Priority.Valid() {
+			err = fmt.Errorf("invalid priority %q: must be low, normal or high", config.Priority)
+		}
+	}
+	if err == nil {
+		if req.Body.Title != nil {
+			config.Title = *req.Body.Title
+		}
+		if req.Body.WorkingDir != nil {
+			config.WorkingDir = *req.Body.WorkingDir
+		}
+		err = config.Validate()
+	}
+	if err != nil {
+		return api.CreateSessionFromTemplate400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	isDraft := req.Body.Draft != nil && *req.Body.Draft
+	sess, err := h.manager.LaunchSession(ctx, config, isDraft)
+	if err != nil {
+		var dirNotFound *session.DirectoryNotFoundError
+		if errors.As(err, &dirNotFound) {
+			return api.CreateSessionFromTemplate422JSONResponse{
+				Error:            "directory_not_found",
+				Message:          dirNotFound.Message,
+				Path:             dirNotFound.Path,
+				RequiresCreation: true,
+			}, nil
+		}
+		slog.Error("Failed to launch session from template",
+			"error", fmt.Sprintf("%v", err),
+			"template_id", req.Id,
+			"working_dir", config.WorkingDir,
+			"operation", "CreateSessionFromTemplate",
+		)
+		return api.CreateSessionFromTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-1001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	resp := api.CreateSessionResponse{}
+	resp.Data.SessionId = sess.ID
+	resp.Data.RunId = sess.RunID
+	return api.CreateSessionFromTemplate201JSONResponse(resp), nil
+}
+
 // ListSessions implements GET /sessions
 func (h *SessionHandlers) ListSessions(ctx context.Context, req api.ListSessionsRequestObject) (api.ListSessionsResponseObject, error) {
 	// NEW: leavesOnly parameter (renamed from leafOnly, default true)
@@ -390,6 +627,7 @@ func (h *SessionHandlers) ListSessions(ctx context.Context, req api.ListSessions
 			LastActivityAt:                      info.LastActivityAt,
 			CompletedAt:                         info.EndTime,
 			ErrorMessage:                        info.Error,
+			ErrorKind:                           info.ErrorKind,
 			AutoAcceptEdits:                     info.AutoAcceptEdits,
 			DangerouslySkipPermissions:          info.DangerouslySkipPermissions,
 			DangerouslySkipPermissionsExpiresAt: info.DangerouslySkipPermissionsExpiresAt,
@@ -400,6 +638,20 @@ func (h *SessionHandlers) ListSessions(ctx context.Context, req api.ListSessions
 			ProxyModelOverride:                  info.ProxyModelOverride,
 			ProxyAPIKey:                         info.ProxyAPIKey,
 			FolderID:                            info.FolderID,
+			WorktreePath:                        info.WorktreePath,
+			WorktreeBranch:                      info.WorktreeBranch,
+			WorktreeBaseRef:                     info.WorktreeBaseRef,
+			ForkedFromSequence:                  info.ForkedFromSequence,
+			MaxCostUSD:                          info.MaxCostUSD,
+			MaxOutputTokens:                     info.MaxOutputTokens,
+			MaxWallClockMs:                      info.MaxWallClockMs,
+			StopReason:                          info.StopReason,
+			StallPolicy:                         info.StallPolicy,
+			StalledAt:                           info.StalledAt,
+			MaxRetries:                          info.MaxRetries,
+			RetryCount:                          info.RetryCount,
+			RetryAt:                             info.RetryAt,
+			RecoveredAt:                         info.RecoveredAt,
 		}
 
 		// Copy result data if available
@@ -496,6 +748,44 @@ func (h *SessionHandlers) UpdateSession(ctx context.Context, req api.UpdateSessi
 		update.Archived = req.Body.Archived
 	}
 
+	// Archiving can remove the session's worktree too, before it is archived
+	if req.Body.Archived != nil && *req.Body.Archived && req.Body.RemoveWorktree != nil && *req.Body.RemoveWorktree {
+		if err := h.removeWorktreeOnArchive(ctx, string(req.Id)); err != nil {
+			var worktreeErr *session.WorktreeStateError
+			if errors.As(err, &worktreeErr) {
+				return api.UpdateSession400JSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3002",
+						Message: worktreeErr.Error(),
+					},
+				}, nil
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				return api.UpdateSession404JSONResponse{
+					NotFoundJSONResponse: api.NotFoundJSONResponse{
+						Error: api.ErrorDetail{
+							Code:    "HLD-1002",
+							Message: "Session not found",
+						},
+					},
+				}, nil
+			}
+			slog.Error("Failed to remove worktree of archived session",
+				"error", fmt.Sprintf("%v", err),
+				"session_id", req.Id,
+				"operation", "UpdateSession",
+			)
+			return api.UpdateSession500JSONResponse{
+				InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-4001",
+						Message: err.Error(),
+					},
+				},
+			}, nil
+		}
+	}
+
 	// Update title if specified
 	if req.Body.Title != nil {
 		update.Title = req.Body.Title
@@ -985,6 +1275,15 @@ func (h *SessionHandlers) LaunchDraftSession(ctx context.Context, req api.Launch
 				RequiresCreation: true,
 			}, nil
 		}
+		var worktreeErr *session.WorktreeStateError
+		if errors.As(err, &worktreeErr) {
+			return api.LaunchDraftSession400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: worktreeErr.Error(),
+				},
+			}, nil
+		}
 		slog.Error("Failed to launch draft session",
 			"error", fmt.Sprintf("%v", err),
 			"session_id", req.Id,
@@ -1100,6 +1399,15 @@ func (h *SessionHandlers) ContinueSession(ctx context.Context, req api.ContinueS
 
 	result, err := h.manager.ContinueSession(ctx, continueConfig)
 	if err != nil {
+		var budgetErr *session.BudgetExceededError
+		if errors.As(err, &budgetErr) {
+			return api.ContinueSession400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: budgetErr.Error(),
+				},
+			}, nil
+		}
 		slog.Error("Failed to continue session",
 			"error", fmt.Sprintf("%v", err),
 			"parent_session_id", req.Id,
@@ -1143,6 +1451,180 @@ func (h *SessionHandlers) ContinueSession(ctx context.Context, req api.ContinueS
 	return api.ContinueSession201JSONResponse(resp), nil
 }
 
+// ForkSession creates a child session from a point in a session's conversation
+func (h *SessionHandlers) ForkSession(ctx context.Context, req api.ForkSessionRequestObject) (api.ForkSessionResponseObject, error) {
+	if _, err := h.store.GetSession(ctx, string(req.Id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.ForkSession404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		return api.ForkSession500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	forkConfig := session.ForkSessionConfig{
+		ParentSessionID: string(req.Id),
+		Query:           req.Body.Query,
+	}
+	switch {
+	case req.Body.MessageUuid != nil && *req.Body.MessageUuid != "":
+		forkConfig.MessageUUID = *req.Body.MessageUuid
+	case req.Body.Sequence != nil:
+		forkConfig.Sequence = *req.Body.Sequence
+	default:
+		return api.ForkSession400JSONResponse{
+			Error: api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "sequence or message_uuid is required",
+			},
+		}, nil
+	}
+
+	result, err := h.manager.ForkSession(ctx, forkConfig)
+	if err != nil {
+		var forkPointErr *session.ForkPointError
+		if errors.As(err, &forkPointErr) {
+			return api.ForkSession400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: forkPointErr.Error(),
+				},
+			}, nil
+		}
+		var budgetErr *session.BudgetExceededError
+		if errors.As(err, &budgetErr) {
+			return api.ForkSession400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: budgetErr.Error(),
+				},
+			}, nil
+		}
+		slog.Error("Failed to fork session",
+			"error", fmt.Sprintf("%v", err),
+			"parent_session_id", req.Id,
+			"operation", "ForkSession",
+		)
+		return api.ForkSession500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	newSession, err := h.store.GetSession(ctx, result.ID)
+	if err != nil {
+		return api.ForkSession500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: "Failed to get created session details",
+				},
+			},
+		}, nil
+	}
+
+	resp := api.ContinueSessionResponse{}
+	resp.Data.SessionId = result.ID
+	resp.Data.RunId = result.RunID
+	resp.Data.ClaudeSessionId = newSession.ClaudeSessionID
+	resp.Data.ParentSessionId = string(req.Id)
+	return api.ForkSession201JSONResponse(resp), nil
+}
+
+// RecoverSession resumes a session cut off by a daemon restart
+func (h *SessionHandlers) RecoverSession(ctx context.Context, req api.RecoverSessionRequestObject) (api.RecoverSessionResponseObject, error) {
+	if _, err := h.store.GetSession(ctx, string(req.Id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.RecoverSession404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		return api.RecoverSession500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	result, err := h.manager.RecoverSession(ctx, string(req.Id))
+	if err != nil {
+		var recoveryErr *session.RecoveryError
+		if errors.As(err, &recoveryErr) {
+			return api.RecoverSession400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: recoveryErr.Error(),
+				},
+			}, nil
+		}
+		var budgetErr *session.BudgetExceededError
+		if errors.As(err, &budgetErr) {
+			return api.RecoverSession400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: budgetErr.Error(),
+				},
+			}, nil
+		}
+		slog.Error("Failed to recover session",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "RecoverSession",
+		)
+		return api.RecoverSession500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	newSession, err := h.store.GetSession(ctx, result.ID)
+	if err != nil {
+		return api.RecoverSession500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: "Failed to get created session details",
+				},
+			},
+		}, nil
+	}
+
+	resp := api.ContinueSessionResponse{}
+	resp.Data.SessionId = result.ID
+	resp.Data.RunId = result.RunID
+	resp.Data.ClaudeSessionId = newSession.ClaudeSessionID
+	resp.Data.ParentSessionId = string(req.Id)
+	return api.RecoverSession201JSONResponse(resp), nil
+}
+
 // InterruptSession sends an interrupt signal to a running session
 func (h *SessionHandlers) InterruptSession(ctx context.Context, req api.InterruptSessionRequestObject) (api.InterruptSessionResponseObject, error) {
 	session, err := h.store.GetSession(ctx, string(req.Id))
@@ -1297,6 +1779,200 @@ func (h *SessionHandlers) GetSessionSnapshots(ctx context.Context, req api.GetSe
 	}, nil
 }
 
+// MergeSessionWorktree merges a session's worktree branch into its base branch
+func (h *SessionHandlers) MergeSessionWorktree(ctx context.Context, req api.MergeSessionWorktreeRequestObject) (api.MergeSessionWorktreeResponseObject, error) {
+	if _, err := h.store.GetSession(ctx, string(req.Id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.MergeSessionWorktree404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		return api.MergeSessionWorktree500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	err := h.manager.MergeWorktree(ctx, string(req.Id))
+	if err == nil && req.Body != nil && req.Body.RemoveWorktree != nil && *req.Body.RemoveWorktree {
+		err = h.manager.RemoveWorktree(ctx, string(req.Id), true)
+	}
+	if err != nil {
+		var worktreeErr *session.WorktreeStateError
+		if errors.As(err, &worktreeErr) {
+			return api.MergeSessionWorktree400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3002",
+					Message: worktreeErr.Error(),
+				},
+			}, nil
+		}
+		slog.Error("Failed to merge session worktree",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "MergeSessionWorktree",
+		)
+		return api.MergeSessionWorktree500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	sess, err := h.store.GetSession(ctx, string(req.Id))
+	if err != nil {
+		return api.MergeSessionWorktree500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+	return api.MergeSessionWorktree200JSONResponse{Data: h.mapper.SessionToAPI(*sess)}, nil
+}
+
+// RemoveSessionWorktree removes a session's worktree
+func (h *SessionHandlers) RemoveSessionWorktree(ctx context.Context, req api.RemoveSessionWorktreeRequestObject) (api.RemoveSessionWorktreeResponseObject, error) {
+	if _, err := h.store.GetSession(ctx, string(req.Id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.RemoveSessionWorktree404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		return api.RemoveSessionWorktree500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	deleteBranch := req.Params.DeleteBranch != nil && *req.Params.DeleteBranch
+	if err := h.manager.RemoveWorktree(ctx, string(req.Id), deleteBranch); err != nil {
+		var worktreeErr *session.WorktreeStateError
+		if errors.As(err, &worktreeErr) {
+			return api.RemoveSessionWorktree400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3002",
+					Message: worktreeErr.Error(),
+				},
+			}, nil
+		}
+		slog.Error("Failed to remove session worktree",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "RemoveSessionWorktree",
+		)
+		return api.RemoveSessionWorktree500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	sess, err := h.store.GetSession(ctx, string(req.Id))
+	if err != nil {
+		return api.RemoveSessionWorktree500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+	return api.RemoveSessionWorktree200JSONResponse{Data: h.mapper.SessionToAPI(*sess)}, nil
+}
+
+// GetSessionUsage retrieves the per-message token usage and cost of a session
+func (h *SessionHandlers) GetSessionUsage(ctx context.Context, req api.GetSessionUsageRequestObject) (api.GetSessionUsageResponseObject, error) {
+	// Verify session exists
+	_, err := h.store.GetSession(ctx, string(req.Id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.GetSessionUsage404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		slog.Error("Failed to verify session exists for usage",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "GetSessionUsage",
+		)
+		return api.GetSessionUsage500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	turns, err := h.store.GetTurnUsage(ctx, string(req.Id))
+	if err != nil {
+		slog.Error("Failed to get turn usage",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "GetSessionUsage",
+		)
+		return api.GetSessionUsage500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	return api.GetSessionUsage200JSONResponse{
+		Data: h.mapper.SessionUsageToAPI(string(req.Id), turns),
+	}, nil
+}
+
+// removeWorktreeOnArchive removes the worktree of a session being archived,
+// keeping its branch. Sessions without a worktree are left alone.
+func (h *SessionHandlers) removeWorktreeOnArchive(ctx context.Context, sessionID string) error {
+	sess, err := h.store.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if sess.WorktreePath == "" {
+		return nil
+	}
+	return h.manager.RemoveWorktree(ctx, sessionID, false)
+}
+
 // BulkArchiveSessions archives or unarchives multiple sessions
 func (h *SessionHandlers) BulkArchiveSessions(ctx context.Context, req api.BulkArchiveSessionsRequestObject) (api.BulkArchiveSessionsResponseObject, error) {
 	if len(req.Body.SessionIds) == 0 {
@@ -1310,8 +1986,19 @@ func (h *SessionHandlers) BulkArchiveSessions(ctx context.Context, req api.BulkA
 		}, nil
 	}
 
+	removeWorktrees := req.Body.Archived && req.Body.RemoveWorktree != nil && *req.Body.RemoveWorktree
 	var failedSessions []string
 	for _, sessionID := range req.Body.SessionIds {
+		if removeWorktrees {
+			if err := h.removeWorktreeOnArchive(ctx, sessionID); err != nil {
+				slog.Warn("Failed to remove worktree of archived session",
+					"error", err,
+					"session_id", sessionID,
+				)
+				failedSessions = append(failedSessions, sessionID)
+				continue
+			}
+		}
 		update := store.SessionUpdate{
 			Archived: &req.Body.Archived,
 		}
@@ -1440,14 +2127,19 @@ func (h *SessionHandlers) GetHealth(ctx context.Context, req api.GetHealthReques
 	// Check Claude version if available
 	var claudeVersion *string
 	var versionError *string
+	var capabilities *map[string]bool
+	var upgradeHint *string
 	if claudeAvailable {
-		if version, err := h.sessionManager.GetClaudeVersion(); err != nil {
+		if version, err := h.sessionManager.DetectClaudeVersion(); err != nil {
 			versionErrorMsg := err.Error()
 			versionError = &versionErrorMsg
 			slog.Warn("Failed to get Claude version", "error", err, "path", claudePath)
 		} else {
-			claudeVersion = &version
-			slog.Debug("Claude version retrieved", "version", version, "path", claudePath)
+			claudeVersion = &version.Raw
+			caps := capabilitiesToAPI(version)
+			capabilities = &caps
+			upgradeHint = claudeUpgradeHint(version)
+			slog.Debug("Claude version retrieved", "version", version.Raw, "path", claudePath)
 		}
 	}
 
@@ -1465,13 +2157,17 @@ func (h *SessionHandlers) GetHealth(ctx context.Context, req api.GetHealthReques
 
 	// Always add dependencies to show Claude status
 	claudeInfo := struct {
-		Available    bool    `json:"available"`
-		Error        *string `json:"error"`
-		Path         *string `json:"path"`
-		Version      *string `json:"version"`
-		VersionError *string `json:"version_error"`
+		Available    bool             `json:"available"`
+		Capabilities *map[string]bool `json:"capabilities,omitempty"`
+		Error        *string          `json:"error"`
+		Path         *string          `json:"path"`
+		UpgradeHint  *string          `json:"upgrade_hint"`
+		Version      *string          `json:"version"`
+		VersionError *string          `json:"version_error"`
 	}{
 		Available:    claudeAvailable,
+		Capabilities: capabilities,
+		UpgradeHint:  upgradeHint,
 		Version:      claudeVersion,
 		VersionError: versionError,
 	}
@@ -1487,11 +2183,13 @@ func (h *SessionHandlers) GetHealth(ctx context.Context, req api.GetHealthReques
 
 	response.Dependencies = &struct {
 		Claude *struct {
-			Available    bool    `json:"available"`
-			Error        *string `json:"error"`
-			Path         *string `json:"path"`
-			Version      *string `json:"version"`
-			VersionError *string `json:"version_error"`
+			Available    bool             `json:"available"`
+			Capabilities *map[string]bool `json:"capabilities,omitempty"`
+			Error        *string          `json:"error"`
+			Path         *string          `json:"path"`
+			UpgradeHint  *string          `json:"upgrade_hint"`
+			Version      *string          `json:"version"`
+			VersionError *string          `json:"version_error"`
 		} `json:"claude,omitempty"`
 	}{
 		Claude: &claudeInfo,
@@ -1500,6 +2198,31 @@ func (h *SessionHandlers) GetHealth(ctx context.Context, req api.GetHealthReques
 	return response, nil
 }
 
+// capabilitiesToAPI lists the capabilities of a Claude version by name
+func capabilitiesToAPI(version claudecode.Version) map[string]bool {
+	caps := make(map[string]bool)
+	for capability, supported := range version.Capabilities() {
+		caps[string(capability)] = supported
+	}
+	return caps
+}
+
+// claudeUpgradeHint explains what an outdated Claude version is missing, or
+// returns nil if it supports everything
+func claudeUpgradeHint(version claudecode.Version) *string {
+	missing := version.Unsupported()
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, len(missing))
+	for i, capability := range missing {
+		names[i] = string(capability)
+	}
+	hint := fmt.Sprintf("Claude %s does not support %s; launches using these options will be refused. Run `claude update` to upgrade.",
+		version, strings.Join(names, ", "))
+	return &hint
+}
+
 // GetConfig retrieves the current daemon configuration
 func (h *SessionHandlers) GetConfig(ctx context.Context, req api.GetConfigRequestObject) (api.GetConfigResponseObject, error) {
 	detectedPath := h.sessionManager.GetClaudeBinaryPath()
@@ -1611,6 +2334,14 @@ func (h *SessionHandlers) GetDebugInfo(ctx context.Context, req api.GetDebugInfo
 		response.LastModified = lastModified
 	}
 
+	if h.sessionManager.IsClaudeAvailable() {
+		if version, err := h.sessionManager.DetectClaudeVersion(); err == nil {
+			caps := capabilitiesToAPI(version)
+			response.ClaudeVersion = &version.Raw
+			response.ClaudeCapabilities = &caps
+		}
+	}
+
 	return response, nil
 }
 
@@ -1819,3 +2550,56 @@ func (h *SessionHandlers) SearchSessions(ctx context.Context, req api.SearchSess
 		Data: apiSessions,
 	}, nil
 }
+
+// ReceiveHookEvent implements POST /sessions/{id}/hooks
+func (h *SessionHandlers) ReceiveHookEvent(ctx context.Context, req api.ReceiveHookEventRequestObject) (api.ReceiveHookEventResponseObject, error) {
+	// Verify session exists
+	_, err := h.store.GetSession(ctx, string(req.Id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.ReceiveHookEvent404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		slog.Error("Failed to verify session exists for hook event",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "ReceiveHookEvent",
+		)
+		return api.ReceiveHookEvent500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	var payload map[string]interface{}
+	if req.Body != nil {
+		payload = *req.Body
+	}
+	if err := h.manager.HandleHookEvent(ctx, string(req.Id), payload); err != nil {
+		slog.Error("Failed to handle hook event",
+			"error", fmt.Sprintf("%v", err),
+			"session_id", req.Id,
+			"operation", "ReceiveHookEvent",
+		)
+		return api.ReceiveHookEvent500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	return api.ReceiveHookEvent204Response{}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -67,6 +68,19 @@ func (m *MockStore) GetExpiredDangerousPermissionsSessions(ctx context.Context)
 	return args.Get(0).([]*store.Session), args.Error(1)
 }
 
+func (m *MockStore) GetIdleSessions(ctx context.Context, status string, idleSince time.Time) ([]*store.Session, error) {
+	args := m.Called(ctx, status, idleSince)
+	return args.Get(0).([]*store.Session), args.Error(1)
+}
+
+func (m *MockStore) GetLatestChildSession(ctx context.Context, parentSessionID string) (*store.Session, error) {
+	args := m.Called(ctx, parentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.Session), args.Error(1)
+}
+
 func (m *MockStore) AddConversationEvent(ctx context.Context, event *store.ConversationEvent) error {
 	args := m.Called(ctx, event)
 	return args.Error(0)
@@ -169,6 +183,11 @@ func (m *MockStore) UpdateApprovalResponse(ctx context.Context, id string, statu
 	return args.Error(0)
 }
 
+func (m *MockStore) ReassignApproval(ctx context.Context, id string, sessionID string, runID string, toolUseID string) error {
+	args := m.Called(ctx, id, sessionID, runID, toolUseID)
+	return args.Error(0)
+}
+
 func (m *MockStore) CreateFileSnapshot(ctx context.Context, snapshot *store.FileSnapshot) error {
 	args := m.Called(ctx, snapshot)
 	return args.Error(0)
@@ -179,6 +198,164 @@ func (m *MockStore) GetFileSnapshots(ctx context.Context, sessionID string) ([]s
 	return args.Get(0).([]store.FileSnapshot), args.Error(1)
 }
 
+func (m *MockStore) UpsertTurnUsage(ctx context.Context, usage *store.TurnUsage) error {
+	args := m.Called(ctx, usage)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetTurnUsage(ctx context.Context, sessionID string) ([]*store.TurnUsage, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.TurnUsage), args.Error(1)
+}
+
+func (m *MockStore) CreateSchedule(ctx context.Context, schedule *store.Schedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetSchedule(ctx context.Context, id string) (*store.Schedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.Schedule), args.Error(1)
+}
+
+func (m *MockStore) ListSchedules(ctx context.Context) ([]*store.Schedule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.Schedule), args.Error(1)
+}
+
+func (m *MockStore) UpdateSchedule(ctx context.Context, id string, updates store.ScheduleUpdate) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockStore) DeleteSchedule(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateScheduleRun(ctx context.Context, run *store.ScheduleRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListScheduleRuns(ctx context.Context, scheduleID string, limit int) ([]*store.ScheduleRun, error) {
+	args := m.Called(ctx, scheduleID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.ScheduleRun), args.Error(1)
+}
+
+func (m *MockStore) CreateSessionTemplate(ctx context.Context, template *store.SessionTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetSessionTemplate(ctx context.Context, id string) (*store.SessionTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.SessionTemplate), args.Error(1)
+}
+
+func (m *MockStore) ListSessionTemplates(ctx context.Context) ([]*store.SessionTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.SessionTemplate), args.Error(1)
+}
+
+func (m *MockStore) UpdateSessionTemplate(ctx context.Context, template *store.SessionTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockStore) DeleteSessionTemplate(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreatePipeline(ctx context.Context, pipeline *store.Pipeline) error {
+	args := m.Called(ctx, pipeline)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetPipeline(ctx context.Context, id string) (*store.Pipeline, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.Pipeline), args.Error(1)
+}
+
+func (m *MockStore) ListPipelines(ctx context.Context) ([]*store.Pipeline, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.Pipeline), args.Error(1)
+}
+
+func (m *MockStore) UpdatePipeline(ctx context.Context, pipeline *store.Pipeline) error {
+	args := m.Called(ctx, pipeline)
+	return args.Error(0)
+}
+
+func (m *MockStore) DeletePipeline(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreatePipelineRun(ctx context.Context, run *store.PipelineRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetPipelineRun(ctx context.Context, id string) (*store.PipelineRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PipelineRun), args.Error(1)
+}
+
+func (m *MockStore) ListPipelineRuns(ctx context.Context, pipelineID, status string) ([]*store.PipelineRun, error) {
+	args := m.Called(ctx, pipelineID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PipelineRun), args.Error(1)
+}
+
+func (m *MockStore) UpdatePipelineRun(ctx context.Context, id string, updates store.PipelineRunUpdate) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockStore) UpdatePipelineStepRun(ctx context.Context, runID, name string, updates store.PipelineStepRunUpdate) error {
+	args := m.Called(ctx, runID, name, updates)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetPipelineStepRunBySession(ctx context.Context, sessionID string) (*store.PipelineStepRun, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PipelineStepRun), args.Error(1)
+}
+
 func (m *MockStore) GetRecentWorkingDirs(ctx context.Context, limit int) ([]store.RecentPath, error) {
 	args := m.Called(ctx, limit)
 	return args.Get(0).([]store.RecentPath), args.Error(1)
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 	"github.com/humanlayer/humanlayer/hld/api"
 	"github.com/humanlayer/humanlayer/hld/api/handlers"
@@ -21,6 +22,7 @@ import (
 func TestSessionHandlers_CreateSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
+	claudeSessionUUID := uuid.MustParse("3f2c8a9e-1b4d-4e6f-9a7b-2c5d8e1f0a3b")
 
 	mockManager := session.NewMockSessionManager(ctrl)
 	mockStore := store.NewMockConversationStore(ctrl)
@@ -121,6 +123,163 @@ func TestSessionHandlers_CreateSession(t *testing.T) {
 				assert.Equal(t, "run-012", resp.Data.RunId)
 			},
 		},
+		{
+			name: "with extended CLI options",
+			request: api.CreateSessionRequest{
+				Query:           "Plan it",
+				PermissionMode:  stringPtr("plan"),
+				FallbackModel:   stringPtr("haiku"),
+				SettingSources:  &[]string{"project"},
+				StrictMcpConfig: boolPtr(true),
+				Agents: &map[string]api.AgentDefinition{
+					"reviewer": {Description: "Reviews code", Prompt: "Review it", Tools: &[]string{"Read"}},
+				},
+				JsonSchema: &map[string]interface{}{"type": "object"},
+			},
+			mockSetup: func() {
+				mockManager.EXPECT().
+					LaunchSession(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, config session.LaunchSessionConfig, isDraft bool) (*session.Session, error) {
+						assert.Equal(t, claudecode.PermissionModePlan, config.PermissionMode)
+						assert.Equal(t, claudecode.ModelHaiku, config.FallbackModel)
+						assert.Equal(t, []claudecode.SettingSource{claudecode.SettingSourceProject}, config.SettingSources)
+						assert.True(t, config.StrictMCPConfig)
+						assert.Equal(t, []string{"Read"}, config.Agents["reviewer"].Tools)
+						assert.JSONEq(t, `{"type":"object"}`, string(config.JSONSchema))
+						return &session.Session{ID: "sess-opts", RunID: "run-opts"}, nil
+					})
+			},
+			expectedStatus: 201,
+		},
+		{
+			name: "with hooks",
+			request: api.CreateSessionRequest{
+				Query: "Edit and format",
+				Hooks: &map[string][]api.HookMatcher{
+					"PostToolUse": {{Matcher: stringPtr("Edit|Write"), Hooks: []api.HookCommand{{Command: "gofmt -w .", Timeout: intPtr(30)}}}},
+				},
+				ForwardHooks: boolPtr(true),
+			},
+			mockSetup: func() {
+				mockManager.EXPECT().
+					LaunchSession(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, config session.LaunchSessionConfig, isDraft bool) (*session.Session, error) {
+						want := claudecode.Hooks{}.PostToolUse("Edit|Write", claudecode.CommandHook("gofmt -w .").WithTimeout(30*time.Second))
+						assert.Equal(t, want, config.Hooks)
+						assert.True(t, config.ForwardHooks)
+						return &session.Session{ID: "sess-hooks", RunID: "run-hooks"}, nil
+					})
+			},
+			expectedStatus: 201,
+		},
+		{
+			name: "invalid hooks",
+			request: api.CreateSessionRequest{
+				Query: "Stop with a matcher",
+				Hooks: &map[string][]api.HookMatcher{
+					"Stop": {{Matcher: stringPtr("Bash"), Hooks: []api.HookCommand{{Command: "true"}}}},
+				},
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "Stop hooks do not take a matcher",
+			},
+		},
+		{
+			name: "invalid priority",
+			request: api.CreateSessionRequest{
+				Query:    "Jump the queue",
+				Priority: stringPtr("urgent"),
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: `invalid priority "urgent": must be low, normal or high`,
+			},
+		},
+		{
+			name: "with budget limits in a folder",
+			request: api.CreateSessionRequest{
+				Query:           "Run unattended",
+				FolderId:        stringPtr("folder_1"),
+				MaxCostUsd:      floatPtr(2.5),
+				MaxOutputTokens: intPtr(50000),
+				MaxWallClock:    int64Ptr(600000),
+			},
+			mockSetup: func() {
+				mockStore.EXPECT().
+					GetFolder(gomock.Any(), "folder_1").
+					Return(&store.Folder{ID: "folder_1", Name: "Nightly"}, nil)
+				mockManager.EXPECT().
+					LaunchSession(gomock.Any(), gomock.Any(), false).
+					DoAndReturn(func(ctx context.Context, config session.LaunchSessionConfig, isDraft bool) (*session.Session, error) {
+						assert.Equal(t, "folder_1", config.FolderID)
+						assert.Equal(t, session.Budget{
+							MaxCostUSD:      2.5,
+							MaxOutputTokens: 50000,
+							MaxWallClock:    10 * time.Minute,
+						}, config.Budget)
+						return &session.Session{ID: "sess-budget", RunID: "run-budget"}, nil
+					})
+			},
+			expectedStatus: 201,
+			validateBody: func(t *testing.T, resp *api.CreateSessionResponse) {
+				assert.Equal(t, "sess-budget", resp.Data.SessionId)
+			},
+		},
+		{
+			name: "unknown folder",
+			request: api.CreateSessionRequest{
+				Query:    "File it",
+				FolderId: stringPtr("folder_missing"),
+			},
+			mockSetup: func() {
+				mockStore.EXPECT().GetFolder(gomock.Any(), "folder_missing").Return(nil, nil)
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "folder folder_missing not found",
+			},
+		},
+		{
+			name: "negative budget limit",
+			request: api.CreateSessionRequest{
+				Query:      "Spend nothing",
+				MaxCostUsd: floatPtr(-1),
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "max_cost_usd must not be negative",
+			},
+		},
+		{
+			name: "unknown stall policy",
+			request: api.CreateSessionRequest{
+				Query:       "Restart when stuck",
+				StallPolicy: stringPtr("restart"),
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: `invalid stall_policy "restart": must be notify, interrupt or kill_resume`,
+			},
+		},
+		{
+			name: "mutually exclusive options",
+			request: api.CreateSessionRequest{
+				Query:           "Continue and pin an ID",
+				Continue:        boolPtr(true),
+				ClaudeSessionId: &claudeSessionUUID,
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "NewSessionID can only be combined with SessionID or Continue when forking",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -326,6 +485,341 @@ func TestSessionHandlers_GetSession(t *testing.T) {
 	})
 }
 
+func TestSessionHandlers_GetSessionUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("usage timeline with totals", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123"}, nil)
+		mockStore.EXPECT().
+			GetTurnUsage(gomock.Any(), "sess-123").
+			Return([]*store.TurnUsage{
+				{MessageID: "msg_1", Model: "claude-sonnet-4", InputTokens: 100, OutputTokens: 20, CacheReadInputTokens: 1000, CostUSD: floatPtr(0.002)},
+				{MessageID: "msg_2", Model: "claude-3-5-haiku", ParentToolUseID: "toolu_1", InputTokens: 50, OutputTokens: 5, CostUSD: floatPtr(0.0001)},
+				{MessageID: "msg_3", Model: "unknown", InputTokens: 1, OutputTokens: 1},
+			}, nil)
+
+		w := makeRequest(t, router, "GET", "/api/v1/sessions/sess-123/usage", nil)
+
+		var resp struct {
+			Data api.SessionUsage `json:"data"`
+		}
+		assertJSONResponse(t, w, 200, &resp)
+
+		assert.Equal(t, "sess-123", resp.Data.SessionId)
+		assert.InDelta(t, 0.0021, resp.Data.TotalCostUsd, 1e-9)
+		assert.Equal(t, 151, resp.Data.InputTokens)
+		assert.Equal(t, 1000, resp.Data.CacheReadInputTokens)
+		require.Len(t, resp.Data.Turns, 3)
+		assert.Nil(t, resp.Data.Turns[0].ParentToolUseId)
+		require.NotNil(t, resp.Data.Turns[1].ParentToolUseId)
+		assert.Equal(t, "toolu_1", *resp.Data.Turns[1].ParentToolUseId)
+		assert.Nil(t, resp.Data.Turns[2].CostUsd)
+	})
+
+	t.Run("session not found", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-999").
+			Return(nil, sql.ErrNoRows)
+
+		w := makeRequest(t, router, "GET", "/api/v1/sessions/sess-999/usage", nil)
+
+		assertErrorResponse(t, w, "HLD-1002", "Session not found")
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestSessionHandlers_ReceiveHookEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("payload is handed to the manager", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123"}, nil)
+		mockManager.EXPECT().
+			HandleHookEvent(gomock.Any(), "sess-123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, sessionID string, payload map[string]interface{}) error {
+				assert.Equal(t, "PostToolUse", payload["hook_event_name"])
+				assert.Equal(t, map[string]interface{}{"command": "ls"}, payload["tool_input"])
+				return nil
+			})
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/hooks", map[string]interface{}{
+			"hook_event_name": "PostToolUse",
+			"tool_name":       "Bash",
+			"tool_input":      map[string]interface{}{"command": "ls"},
+		})
+
+		assert.Equal(t, 204, w.Code)
+	})
+
+	t.Run("session not found", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-999").
+			Return(nil, sql.ErrNoRows)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-999/hooks", map[string]interface{}{"hook_event_name": "Stop"})
+
+		assertErrorResponse(t, w, "HLD-1002", "Session not found")
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestSessionHandlers_SessionWorktree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("merge and remove", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", WorktreePath: "/wt/app/eng-1", WorktreeBranch: "eng-1"}, nil)
+		mockManager.EXPECT().MergeWorktree(gomock.Any(), "sess-123").Return(nil)
+		mockManager.EXPECT().RemoveWorktree(gomock.Any(), "sess-123", true).Return(nil)
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", WorktreeBranch: "eng-1", WorktreeBaseRef: "main"}, nil)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/worktree/merge", api.MergeWorktreeRequest{
+			RemoveWorktree: boolPtr(true),
+		})
+
+		var resp api.SessionResponse
+		assertJSONResponse(t, w, 200, &resp)
+		assert.Nil(t, resp.Data.WorktreePath)
+		require.NotNil(t, resp.Data.WorktreeBranch)
+		assert.Equal(t, "eng-1", *resp.Data.WorktreeBranch)
+	})
+
+	t.Run("session is still running", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123"}, nil)
+		mockManager.EXPECT().
+			RemoveWorktree(gomock.Any(), "sess-123", false).
+			Return(&session.WorktreeStateError{Message: "session is still running"})
+
+		w := makeRequest(t, router, "DELETE", "/api/v1/sessions/sess-123/worktree", nil)
+
+		assertErrorResponse(t, w, "HLD-3002", "session is still running")
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("archiving removes the worktree", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", WorktreePath: "/wt/app/eng-1", WorktreeBranch: "eng-1"}, nil)
+		mockManager.EXPECT().RemoveWorktree(gomock.Any(), "sess-123", false).Return(nil)
+		mockManager.EXPECT().
+			UpdateSessionSettings(gomock.Any(), "sess-123", store.SessionUpdate{Archived: boolPtr(true)}).
+			Return(nil)
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", WorktreeBranch: "eng-1", Archived: true}, nil)
+
+		w := makeRequest(t, router, "PATCH", "/api/v1/sessions/sess-123", api.UpdateSessionRequest{
+			Archived:       boolPtr(true),
+			RemoveWorktree: boolPtr(true),
+		})
+
+		var resp api.SessionResponse
+		assertJSONResponse(t, w, 200, &resp)
+		assert.Nil(t, resp.Data.WorktreePath)
+	})
+
+	t.Run("bulk archive skips sessions whose worktree is in use", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", WorktreePath: "/wt/app/eng-1"}, nil)
+		mockManager.EXPECT().
+			RemoveWorktree(gomock.Any(), "sess-123", false).
+			Return(&session.WorktreeStateError{Message: "worktree is in use"})
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-456").
+			Return(&store.Session{ID: "sess-456"}, nil)
+		mockStore.EXPECT().
+			UpdateSession(gomock.Any(), "sess-456", store.SessionUpdate{Archived: boolPtr(true)}).
+			Return(nil)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/archive", api.BulkArchiveRequest{
+			SessionIds:     []string{"sess-123", "sess-456"},
+			Archived:       true,
+			RemoveWorktree: boolPtr(true),
+		})
+
+		var resp api.BulkArchiveResponse
+		assertJSONResponse(t, w, 207, &resp)
+		require.NotNil(t, resp.Data.FailedSessions)
+		assert.Equal(t, []string{"sess-123"}, *resp.Data.FailedSessions)
+	})
+}
+
+func TestSessionHandlers_ContinueSessionOverBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	mockStore.EXPECT().
+		GetSession(gomock.Any(), "sess-123").
+		Return(&store.Session{ID: "sess-123", Status: store.SessionStatusInterrupted}, nil)
+	mockManager.EXPECT().
+		ContinueSession(gomock.Any(), session.ContinueSessionConfig{ParentSessionID: "sess-123", Query: "Keep going"}).
+		Return(nil, &session.BudgetExceededError{
+			Message: "cannot continue session: budget exceeded: max_cost_usd reached $5.10, limit $5.00",
+		})
+
+	w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/continue", api.ContinueSessionRequest{
+		Query: "Keep going",
+	})
+
+	assertErrorResponse(t, w, "HLD-3001", "max_cost_usd reached $5.10")
+	assert.Equal(t, 400, w.Code)
+}
+
+func TestSessionHandlers_ForkSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("fork from a sequence", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", ClaudeSessionID: "claude-123"}, nil)
+		mockManager.EXPECT().
+			ForkSession(gomock.Any(), session.ForkSessionConfig{
+				ParentSessionID: "sess-123",
+				Sequence:        3,
+				Query:           "Try another approach",
+			}).
+			Return(&session.Session{ID: "sess-456", RunID: "run-456"}, nil)
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-456").
+			Return(&store.Session{ID: "sess-456", ClaudeSessionID: "claude-456"}, nil)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/fork", api.ForkSessionRequest{
+			Query:    "Try another approach",
+			Sequence: intPtr(3),
+		})
+
+		var resp api.ContinueSessionResponse
+		assertJSONResponse(t, w, 201, &resp)
+		assert.Equal(t, "sess-456", resp.Data.SessionId)
+		assert.Equal(t, "claude-456", resp.Data.ClaudeSessionId)
+		assert.Equal(t, "sess-123", resp.Data.ParentSessionId)
+	})
+
+	t.Run("missing fork point", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123"}, nil)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/fork", api.ForkSessionRequest{
+			Query: "Try another approach",
+		})
+
+		assertErrorResponse(t, w, "HLD-3001", "sequence or message_uuid is required")
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123"}, nil)
+		mockManager.EXPECT().
+			ForkSession(gomock.Any(), gomock.Any()).
+			Return(nil, &session.ForkPointError{Message: "no event with message UUID msg-1"})
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/fork", api.ForkSessionRequest{
+			Query:       "Try another approach",
+			MessageUuid: stringPtr("msg-1"),
+		})
+
+		assertErrorResponse(t, w, "HLD-3001", "no event with message UUID msg-1")
+		assert.Equal(t, 400, w.Code)
+	})
+}
+
+func TestSessionHandlers_RecoverSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("resume a session cut off by a restart", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", StopReason: session.StopReasonDaemonRestarted}, nil)
+		mockManager.EXPECT().
+			RecoverSession(gomock.Any(), "sess-123").
+			Return(&session.Session{ID: "sess-456", RunID: "run-456"}, nil)
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-456").
+			Return(&store.Session{ID: "sess-456", ClaudeSessionID: "claude-456"}, nil)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/recover", nil)
+
+		var resp api.ContinueSessionResponse
+		assertJSONResponse(t, w, 201, &resp)
+		assert.Equal(t, "sess-456", resp.Data.SessionId)
+		assert.Equal(t, "run-456", resp.Data.RunId)
+		assert.Equal(t, "sess-123", resp.Data.ParentSessionId)
+	})
+
+	t.Run("session not cut off by a restart", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123"}, nil)
+		mockManager.EXPECT().
+			RecoverSession(gomock.Any(), "sess-123").
+			Return(nil, &session.RecoveryError{Message: "session sess-123 was not cut off by a daemon restart"})
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/recover", nil)
+
+		assertErrorResponse(t, w, "HLD-3001", "session sess-123 was not cut off by a daemon restart")
+		assert.Equal(t, 400, w.Code)
+	})
+}
+
 func TestSessionHandlers_UpdateSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -658,7 +1152,8 @@ func TestSessionHandlers_GetHealth(t *testing.T) {
 		// Expect the new method calls
 		mockManager.EXPECT().IsClaudeAvailable().Return(true).Times(1)
 		mockManager.EXPECT().GetClaudeBinaryPath().Return("/usr/local/bin/claude").Times(1)
-		mockManager.EXPECT().GetClaudeVersion().Return("1.0.110", nil).Times(1)
+		version, _ := claudecode.ParseVersion("2.1.0 (Claude Code)")
+		mockManager.EXPECT().DetectClaudeVersion().Return(version, nil).Times(1)
 
 		w := makeRequest(t, router, "GET", "/api/v1/health", nil)
 
@@ -672,9 +1167,33 @@ func TestSessionHandlers_GetHealth(t *testing.T) {
 		if resp.Dependencies != nil && resp.Dependencies.Claude != nil {
 			assert.True(t, resp.Dependencies.Claude.Available)
 			assert.NotNil(t, resp.Dependencies.Claude.Path)
+			assert.Equal(t, "2.1.0 (Claude Code)", *resp.Dependencies.Claude.Version)
+			require.NotNil(t, resp.Dependencies.Claude.Capabilities)
+			assert.True(t, (*resp.Dependencies.Claude.Capabilities)["fork_session"])
+			assert.Nil(t, resp.Dependencies.Claude.UpgradeHint)
 		}
 	})
 
+	t.Run("health check reports missing capabilities", func(t *testing.T) {
+		mockManager.EXPECT().IsClaudeAvailable().Return(true).Times(1)
+		mockManager.EXPECT().GetClaudeBinaryPath().Return("/usr/local/bin/claude").Times(1)
+		version, _ := claudecode.ParseVersion("1.0.60 (Claude Code)")
+		mockManager.EXPECT().DetectClaudeVersion().Return(version, nil).Times(1)
+
+		w := makeRequest(t, router, "GET", "/api/v1/health", nil)
+
+		var resp api.HealthResponse
+		assertJSONResponse(t, w, 200, &resp)
+
+		require.NotNil(t, resp.Dependencies)
+		require.NotNil(t, resp.Dependencies.Claude)
+		require.NotNil(t, resp.Dependencies.Claude.Capabilities)
+		assert.False(t, (*resp.Dependencies.Claude.Capabilities)["fork_session"])
+		require.NotNil(t, resp.Dependencies.Claude.UpgradeHint)
+		assert.Contains(t, *resp.Dependencies.Claude.UpgradeHint, "fork_session")
+		assert.Contains(t, *resp.Dependencies.Claude.UpgradeHint, "claude update")
+	})
+
 	t.Run("health check returns degraded when claude unavailable", func(t *testing.T) {
 		// Expect the new method calls
 		mockManager.EXPECT().IsClaudeAvailable().Return(false).Times(1)
@@ -1043,6 +1562,10 @@ func intPtr(i int) *int {
 	return &i
 }
 
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -33,6 +33,24 @@ func parseEventTypes(types []string) []bus.EventType {
 			eventTypes = append(eventTypes, bus.EventConversationUpdated)
 		case "session_settings_changed":
 			eventTypes = append(eventTypes, bus.EventSessionSettingsChanged)
+		case "assistant_delta":
+			eventTypes = append(eventTypes, bus.EventAssistantDelta)
+		case "hook_received":
+			eventTypes = append(eventTypes, bus.EventHookReceived)
+		case "session_queue_updated":
+			eventTypes = append(eventTypes, bus.EventSessionQueueUpdated)
+		case "pipeline_run_status_changed":
+			eventTypes = append(eventTypes, bus.EventPipelineRunStatusChanged)
+		case "pipeline_step_status_changed":
+			eventTypes = append(eventTypes, bus.EventPipelineStepStatusChanged)
+		case "session_budget_exceeded":
+			eventTypes = append(eventTypes, bus.EventSessionBudgetExceeded)
+		case "session_stalled":
+			eventTypes = append(eventTypes, bus.EventSessionStalled)
+		case "session_stall_cleared":
+			eventTypes = append(eventTypes, bus.EventSessionStallCleared)
+		case "session_retry_scheduled":
+			eventTypes = append(eventTypes, bus.EventSessionRetryScheduled)
 		}
 		// Ignore unknown event types
 	}
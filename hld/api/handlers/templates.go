@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humanlayer/humanlayer/hld/api"
+	"github.com/humanlayer/humanlayer/hld/api/mapper"
+	"github.com/humanlayer/humanlayer/hld/session"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+type TemplateHandlers struct {
+	store  store.ConversationStore
+	mapper *mapper.Mapper
+}
+
+func NewTemplateHandlers(store store.ConversationStore) *TemplateHandlers {
+	return &TemplateHandlers{
+		store:  store,
+		mapper: &mapper.Mapper{},
+	}
+}
+
+// validateSessionTemplate checks a template's settings by building a launch
+// configuration with placeholder values for its variables
+func validateSessionTemplate(template *store.SessionTemplate) error {
+	if len(template.Name) < 1 || len(template.Name) > 100 {
+		return errors.New("template name must be between 1 and 100 characters")
+	}
+	if strings.TrimSpace(template.QueryTemplate) == "" {
+		return errors.New("query_template is required")
+	}
+	switch template.Model {
+	case "", "opus", "sonnet", "haiku":
+	default:
+		return fmt.Errorf("invalid model %q: must be opus, sonnet or haiku", template.Model)
+	}
+
+	vars := make(map[string]string)
+	for _, name := range session.TemplateVariables(template) {
+		vars[name] = name
+	}
+	config, err := session.TemplateLaunchConfig(template, vars)
+	if err != nil {
+		return err
+	}
+	return config.Validate()
+}
+
+// ListSessionTemplates returns all session templates
+func (h *TemplateHandlers) ListSessionTemplates(ctx context.Context, req api.ListSessionTemplatesRequestObject) (api.ListSessionTemplatesResponseObject, error) {
+	templates, err := h.store.ListSessionTemplates(ctx)
+	if err != nil {
+		slog.Error("failed to list session templates", "error", err)
+		return api.ListSessionTemplates500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-4001", Message: "Failed to list session templates"},
+			},
+		}, nil
+	}
+
+	data := make([]api.SessionTemplate, len(templates))
+	for i, template := range templates {
+		data[i] = h.mapper.SessionTemplateToAPI(template)
+	}
+	return api.ListSessionTemplates200JSONResponse{Data: data}, nil
+}
+
+// CreateSessionTemplate creates a session template
+func (h *TemplateHandlers) CreateSessionTemplate(ctx context.Context, req api.CreateSessionTemplateRequestObject) (api.CreateSessionTemplateResponseObject, error) {
+	template, err := h.mapper.SessionTemplateFromAPI("tmpl_"+uuid.New().String()[:8], *req.Body)
+	if err == nil {
+		err = validateSessionTemplate(template)
+	}
+	if err != nil {
+		return api.CreateSessionTemplate400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-3001", Message: err.Error()},
+			},
+		}, nil
+	}
+
+	if err := h.store.CreateSessionTemplate(ctx, template); err != nil {
+		slog.Error("failed to create session template", "error", err)
+		return api.CreateSessionTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-4001", Message: "Failed to create session template"},
+			},
+		}, nil
+	}
+	return api.CreateSessionTemplate201JSONResponse{Data: h.mapper.SessionTemplateToAPI(template)}, nil
+}
+
+// GetSessionTemplate returns a single session template
+func (h *TemplateHandlers) GetSessionTemplate(ctx context.Context, req api.GetSessionTemplateRequestObject) (api.GetSessionTemplateResponseObject, error) {
+	template, err := h.store.GetSessionTemplate(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.GetSessionTemplate404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{Code: "HLD-1002", Message: "Session template not found"},
+				},
+			}, nil
+		}
+		slog.Error("failed to get session template", "template_id", req.Id, "error", err)
+		return api.GetSessionTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-4001", Message: "Failed to get session template"},
+			},
+		}, nil
+	}
+	return api.GetSessionTemplate200JSONResponse{Data: h.mapper.SessionTemplateToAPI(template)}, nil
+}
+
+// UpdateSessionTemplate replaces a session template's settings, keeping the
+// stored proxy API key when none is given
+func (h *TemplateHandlers) UpdateSessionTemplate(ctx context.Context, req api.UpdateSessionTemplateRequestObject) (api.UpdateSessionTemplateResponseObject, error) {
+	existing, err := h.store.GetSessionTemplate(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.UpdateSessionTemplate404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{Code: "HLD-1002", Message: "Session template not found"},
+				},
+			}, nil
+		}
+		slog.Error("failed to get session template for update", "template_id", req.Id, "error", err)
+		return api.UpdateSessionTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-4001", Message: "Failed to get session template"},
+			},
+		}, nil
+	}
+
+	template, err := h.mapper.SessionTemplateFromAPI(req.Id, *req.Body)
+	if err == nil {
+		err = validateSessionTemplate(template)
+	}
+	if err != nil {
+		return api.UpdateSessionTemplate400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-3001", Message: err.Error()},
+			},
+		}, nil
+	}
+	if req.Body.ProxyApiKey == nil {
+		template.ProxyAPIKey = existing.ProxyAPIKey
+	}
+	template.CreatedAt = existing.CreatedAt
+
+	if err := h.store.UpdateSessionTemplate(ctx, template); err != nil {
+		slog.Error("failed to update session template", "template_id", req.Id, "error", err)
+		return api.UpdateSessionTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-4001", Message: "Failed to update session template"},
+			},
+		}, nil
+	}
+	return api.UpdateSessionTemplate200JSONResponse{Data: h.mapper.SessionTemplateToAPI(template)}, nil
+}
+
+// DeleteSessionTemplate deletes a session template
+func (h *TemplateHandlers) DeleteSessionTemplate(ctx context.Context, req api.DeleteSessionTemplateRequestObject) (api.DeleteSessionTemplateResponseObject, error) {
+	if err := h.store.DeleteSessionTemplate(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.DeleteSessionTemplate404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{Code: "HLD-1002", Message: "Session template not found"},
+				},
+			}, nil
+		}
+		slog.Error("failed to delete session template", "template_id", req.Id, "error", err)
+		return api.DeleteSessionTemplate500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{Code: "HLD-4001", Message: "Failed to delete session template"},
+			},
+		}, nil
+	}
+	return api.DeleteSessionTemplate204Response{}, nil
+}
@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/humanlayer/humanlayer/hld/api"
+	"github.com/humanlayer/humanlayer/hld/api/handlers"
+	"github.com/humanlayer/humanlayer/hld/session"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupTemplateRouter(t *testing.T, manager session.SessionManager) *gin.Engine {
+	sqliteStore := newTestStore(t)
+	return newTestRouter(&handlers.ServerImpl{
+		SessionHandlers:  handlers.NewSessionHandlers(manager, sqliteStore, nil),
+		TemplateHandlers: handlers.NewTemplateHandlers(sqliteStore),
+	})
+}
+
+func TestTemplateHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockManager := session.NewMockSessionManager(ctrl)
+	router := setupTemplateRouter(t, mockManager)
+
+	w := makeRequest(t, router, "POST", "/api/v1/templates", api.SessionTemplateInput{
+		Name:            "Fix a ticket",
+		QueryTemplate:   "Fix {{ticket}} on {{branch}}",
+		WorkingDir:      stringPtr("~/src/app"),
+		Model:           stringPtr("opus"),
+		AllowedTools:    &[]string{"Read", "Edit"},
+		ProxyEnabled:    boolPtr(true),
+		ProxyApiKey:     stringPtr("secret"),
+		AutoAcceptEdits: boolPtr(true),
+	})
+	var created api.SessionTemplateResponse
+	assertJSONResponse(t, w, http.StatusCreated, &created)
+	template := created.Data
+	assert.Equal(t, []string{"ticket", "branch"}, template.Variables)
+	assert.Equal(t, []string{"Read", "Edit"}, *template.AllowedTools)
+	assert.Nil(t, template.ProxyApiKey, "the proxy API key is never returned")
+
+	// Replacing the template without a key keeps the stored one
+	w = makeRequest(t, router, "PUT", "/api/v1/templates/"+template.Id, api.SessionTemplateInput{
+		Name:          "Fix a ticket",
+		QueryTemplate: "Fix {{ticket}} on {{branch}}",
+		WorkingDir:    stringPtr("~/src/app"),
+		ProxyEnabled:  boolPtr(true),
+	})
+	var updated api.SessionTemplateResponse
+	assertJSONResponse(t, w, http.StatusOK, &updated)
+	assert.Nil(t, updated.Data.Model)
+	assert.Nil(t, updated.Data.AllowedTools)
+
+	mockManager.EXPECT().
+		LaunchSession(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, config session.LaunchSessionConfig, _ bool) (*session.Session, error) {
+			assert.Equal(t, "Fix ENG-1234 on main", config.Query)
+			assert.Equal(t, "~/src/app", config.WorkingDir)
+			assert.Equal(t, "ENG-1234", config.Title)
+			assert.Equal(t, "secret", config.ProxyAPIKey)
+			return &session.Session{ID: "sess-123", RunID: "run-456"}, nil
+		})
+	w = makeRequest(t, router, "POST", "/api/v1/sessions/from-template/"+template.Id, api.CreateSessionFromTemplateRequest{
+		Variables: &map[string]string{"ticket": "ENG-1234", "branch": "main"},
+		Title:     stringPtr("ENG-1234"),
+	})
+	var launched api.CreateSessionResponse
+	assertJSONResponse(t, w, http.StatusCreated, &launched)
+	assert.Equal(t, "sess-123", launched.Data.SessionId)
+
+	w = makeRequest(t, router, "POST", "/api/v1/sessions/from-template/"+template.Id, api.CreateSessionFromTemplateRequest{
+		Variables: &map[string]string{"ticket": "ENG-1234"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assertErrorResponse(t, w, "HLD-3001", "missing template variables: branch")
+
+	w = makeRequest(t, router, "GET", "/api/v1/templates", nil)
+	var list api.SessionTemplatesResponse
+	assertJSONResponse(t, w, http.StatusOK, &list)
+	assert.Len(t, list.Data, 1)
+
+	w = makeRequest(t, router, "DELETE", "/api/v1/templates/"+template.Id, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = makeRequest(t, router, "POST", "/api/v1/sessions/from-template/"+template.Id, api.CreateSessionFromTemplateRequest{})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assertErrorResponse(t, w, "HLD-1002", "Session template not found")
+}
+
+func TestTemplateHandlersValidation(t *testing.T) {
+	router := setupTemplateRouter(t, nil)
+
+	tests := []struct {
+		name    string
+		request api.SessionTemplateInput
+		message string
+	}{
+		{"empty query", api.SessionTemplateInput{Name: "t", QueryTemplate: " "}, "query_template is required"},
+		{"unknown model", api.SessionTemplateInput{Name: "t", QueryTemplate: "q", Model: stringPtr("gpt")}, `invalid model "gpt"`},
+		{"empty name", api.SessionTemplateInput{Name: "", QueryTemplate: "q"}, "template name must be between 1 and 100 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := makeRequest(t, router, "POST", "/api/v1/templates", tt.request)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assertErrorResponse(t, w, "HLD-3001", tt.message)
+		})
+	}
+}
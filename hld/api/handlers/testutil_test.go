@@ -9,39 +9,51 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/humanlayer/humanlayer/hld/api"
 	"github.com/humanlayer/humanlayer/hld/api/handlers"
+	"github.com/humanlayer/humanlayer/hld/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // setupTestRouter creates a test Gin router with the given handlers
 func setupTestRouter(t *testing.T, sessionHandlers *handlers.SessionHandlers, approvalHandlers *handlers.ApprovalHandlers, sseHandler *handlers.SSEHandler) *gin.Engine {
+	return newTestRouter(&handlers.ServerImpl{
+		SessionHandlers:  sessionHandlers,
+		ApprovalHandlers: approvalHandlers,
+		FileHandlers:     handlers.NewFileHandlers(),
+		SSEHandler:       sseHandler,
+		// Settings are not used in these tests
+		SettingsHandlers: handlers.NewSettingsHandlers(nil),
+	})
+}
+
+// newTestRouter creates a test Gin router serving the handlers set in server.
+// Handlers a test doesn't need are left nil.
+func newTestRouter(server *handlers.ServerImpl) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 
 	router := gin.New()
 
-	// Create a mock settings handler for tests (not used in these tests)
-	settingsHandlers := handlers.NewSettingsHandlers(nil)
-	fileHandlers := handlers.NewFileHandlers()
-
-	// Create server implementation (pass nil for AgentHandlers and FolderHandlers)
-	serverImpl := handlers.NewServerImpl(sessionHandlers, approvalHandlers, fileHandlers, sseHandler, settingsHandlers, nil, nil)
-
-	// Create strict handler
-	strictHandler := api.NewStrictHandler(serverImpl, nil)
-
 	// Register handlers using generated code with base URL
-	api.RegisterHandlersWithOptions(router, strictHandler, api.GinServerOptions{
+	api.RegisterHandlersWithOptions(router, api.NewStrictHandler(server, nil), api.GinServerOptions{
 		BaseURL: "/api/v1",
 	})
 
 	// Register SSE endpoint
-	if sseHandler != nil {
-		router.GET("/api/v1/events", sseHandler.StreamEvents)
+	if server.SSEHandler != nil {
+		router.GET("/api/v1/events", server.SSEHandler.StreamEvents)
 	}
 
 	return router
 }
 
+// newTestStore creates an in-memory store that is closed when the test ends
+func newTestStore(t *testing.T) *store.SQLiteStore {
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+	return sqliteStore
+}
+
 // makeRequest is a helper to make HTTP requests in tests
 func makeRequest(t *testing.T, router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
 	var reqBody []byte
@@ -5,6 +5,7 @@ import (
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 	"github.com/humanlayer/humanlayer/hld/api"
 	"github.com/humanlayer/humanlayer/hld/rpc"
+	"github.com/humanlayer/humanlayer/hld/session"
 	"github.com/humanlayer/humanlayer/hld/store"
 )
 
@@ -57,6 +58,33 @@ func (m *Mapper) SessionToAPI(s store.Session) api.Session {
 	if s.ErrorMessage != "" {
 		session.ErrorMessage = &s.ErrorMessage
 	}
+	if s.ErrorKind != "" {
+		session.ErrorKind = &s.ErrorKind
+	}
+	if s.WorktreePath != "" {
+		session.WorktreePath = &s.WorktreePath
+	}
+	if s.WorktreeBranch != "" {
+		session.WorktreeBranch = &s.WorktreeBranch
+	}
+	if s.WorktreeBaseRef != "" {
+		session.WorktreeBaseRef = &s.WorktreeBaseRef
+	}
+	session.ForkedFromSequence = s.ForkedFromSequence
+	session.MaxCostUsd = s.MaxCostUSD
+	session.MaxOutputTokens = s.MaxOutputTokens
+	session.MaxWallClock = s.MaxWallClockMs
+	if s.StopReason != "" {
+		session.StopReason = &s.StopReason
+	}
+	if s.StallPolicy != "" {
+		session.StallPolicy = &s.StallPolicy
+	}
+	session.StalledAt = s.StalledAt
+	session.MaxRetries = &s.MaxRetries
+	session.RetryCount = &s.RetryCount
+	session.RetryAt = s.RetryAt
+	session.RecoveredAt = s.RecoveredAt
 	if s.CostUSD != nil && *s.CostUSD > 0 {
 		costUsd := float32(*s.CostUSD)
 		session.CostUsd = &costUsd
@@ -197,6 +225,9 @@ func (m *Mapper) ConversationEventToAPI(e store.ConversationEvent) api.Conversat
 	if e.ToolResultContent != "" {
 		event.ToolResultContent = &e.ToolResultContent
 	}
+	if e.MessageUUID != "" {
+		event.MessageUuid = &e.MessageUUID
+	}
 
 	event.IsCompleted = &e.IsCompleted
 	if e.ApprovalStatus != "" {
@@ -260,6 +291,148 @@ func (m *Mapper) MCPConfigFromAPI(config *api.MCPConfig) *claudecode.MCPConfig {
 	}
 }
 
+// HooksFromAPI converts API hooks, keyed by event, to Claude hooks
+func (m *Mapper) HooksFromAPI(hooks *map[string][]api.HookMatcher) claudecode.Hooks {
+	if hooks == nil || len(*hooks) == 0 {
+		return nil
+	}
+
+	out := make(claudecode.Hooks, len(*hooks))
+	for event, matchers := range *hooks {
+		for _, matcher := range matchers {
+			commands := make([]claudecode.HookCommand, len(matcher.Hooks))
+			for i, hook := range matcher.Hooks {
+				commands[i] = claudecode.CommandHook(hook.Command)
+				if hook.Type != nil {
+					commands[i].Type = *hook.Type
+				}
+				if hook.Timeout != nil {
+					commands[i].Timeout = *hook.Timeout
+				}
+			}
+			var pattern string
+			if matcher.Matcher != nil {
+				pattern = *matcher.Matcher
+			}
+			out = out.Add(claudecode.HookEvent(event), pattern, commands...)
+		}
+	}
+	return out
+}
+
+// SessionTemplate conversions
+
+// SessionTemplateToAPI converts a session template to its API form, leaving
+// out the proxy API key
+func (m *Mapper) SessionTemplateToAPI(t *store.SessionTemplate) api.SessionTemplate {
+	out := api.SessionTemplate{
+		Id:            t.ID,
+		Name:          t.Name,
+		QueryTemplate: t.QueryTemplate,
+		Variables:     session.TemplateVariables(t),
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+	for _, field := range []struct {
+		value string
+		dest  **string
+	}{
+		{t.Description, &out.Description},
+		{t.WorkingDir, &out.WorkingDir},
+		{t.Model, &out.Model},
+		{t.PermissionMode, &out.PermissionMode},
+		{t.SystemPrompt, &out.SystemPrompt},
+		{t.AppendSystemPrompt, &out.AppendSystemPrompt},
+		{t.ProxyBaseURL, &out.ProxyBaseUrl},
+		{t.ProxyModelOverride, &out.ProxyModelOverride},
+	} {
+		if field.value != "" {
+			value := field.value
+			*field.dest = &value
+		}
+	}
+	for _, field := range []struct {
+		value string
+		dest  interface{}
+	}{
+		{t.AllowedTools, &out.AllowedTools},
+		{t.DisallowedTools, &out.DisallowedTools},
+		{t.AdditionalDirectories, &out.AdditionalDirectories},
+		{t.MCPConfig, &out.McpConfig},
+	} {
+		if field.value != "" {
+			_ = json.Unmarshal([]byte(field.value), field.dest)
+		}
+	}
+	if t.ProxyEnabled {
+		out.ProxyEnabled = &t.ProxyEnabled
+	}
+	if t.AutoAcceptEdits {
+		out.AutoAcceptEdits = &t.AutoAcceptEdits
+	}
+	if t.DangerouslySkipPermissions {
+		out.DangerouslySkipPermissions = &t.DangerouslySkipPermissions
+	}
+	return out
+}
+
+// SessionTemplateFromAPI converts a session template request to a stored
+// template with the given ID
+func (m *Mapper) SessionTemplateFromAPI(id string, in api.SessionTemplateInput) (*store.SessionTemplate, error) {
+	t := &store.SessionTemplate{
+		ID:            id,
+		Name:          in.Name,
+		QueryTemplate: in.QueryTemplate,
+	}
+	for _, field := range []struct {
+		value *string
+		dest  *string
+	}{
+		{in.Description, &t.Description},
+		{in.WorkingDir, &t.WorkingDir},
+		{in.Model, &t.Model},
+		{in.PermissionMode, &t.PermissionMode},
+		{in.SystemPrompt, &t.SystemPrompt},
+		{in.AppendSystemPrompt, &t.AppendSystemPrompt},
+		{in.ProxyBaseUrl, &t.ProxyBaseURL},
+		{in.ProxyModelOverride, &t.ProxyModelOverride},
+		{in.ProxyApiKey, &t.ProxyAPIKey},
+	} {
+		if field.value != nil {
+			*field.dest = *field.value
+		}
+	}
+	for _, field := range []struct {
+		value interface{}
+		set   bool
+		dest  *string
+	}{
+		{in.AllowedTools, in.AllowedTools != nil, &t.AllowedTools},
+		{in.DisallowedTools, in.DisallowedTools != nil, &t.DisallowedTools},
+		{in.AdditionalDirectories, in.AdditionalDirectories != nil, &t.AdditionalDirectories},
+		{m.MCPConfigFromAPI(in.McpConfig), in.McpConfig != nil, &t.MCPConfig},
+	} {
+		if !field.set {
+			continue
+		}
+		data, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.dest = string(data)
+	}
+	if in.ProxyEnabled != nil {
+		t.ProxyEnabled = *in.ProxyEnabled
+	}
+	if in.AutoAcceptEdits != nil {
+		t.AutoAcceptEdits = *in.AutoAcceptEdits
+	}
+	if in.DangerouslySkipPermissions != nil {
+		t.DangerouslySkipPermissions = *in.DangerouslySkipPermissions
+	}
+	return t, nil
+}
+
 // FileSnapshot conversions
 func (m *Mapper) SnapshotToAPI(s store.FileSnapshot) api.FileSnapshot {
 	return api.FileSnapshot{
@@ -278,6 +451,46 @@ func (m *Mapper) SnapshotsToAPI(snapshots []store.FileSnapshot) []api.FileSnapsh
 	return result
 }
 
+// TurnUsage conversions
+func (m *Mapper) TurnUsageToAPI(u *store.TurnUsage) api.TurnUsage {
+	turn := api.TurnUsage{
+		MessageId:                u.MessageID,
+		Model:                    u.Model,
+		InputTokens:              u.InputTokens,
+		OutputTokens:             u.OutputTokens,
+		CacheCreationInputTokens: u.CacheCreationInputTokens,
+		CacheReadInputTokens:     u.CacheReadInputTokens,
+		CostUsd:                  u.CostUSD,
+		CreatedAt:                u.CreatedAt,
+	}
+	if u.ClaudeSessionID != "" {
+		turn.ClaudeSessionId = &u.ClaudeSessionID
+	}
+	if u.ParentToolUseID != "" {
+		turn.ParentToolUseId = &u.ParentToolUseID
+	}
+	return turn
+}
+
+// SessionUsageToAPI returns the usage timeline of a session with its totals
+func (m *Mapper) SessionUsageToAPI(sessionID string, turns []*store.TurnUsage) api.SessionUsage {
+	usage := api.SessionUsage{
+		SessionId: sessionID,
+		Turns:     make([]api.TurnUsage, len(turns)),
+	}
+	for i, u := range turns {
+		usage.Turns[i] = m.TurnUsageToAPI(u)
+		usage.InputTokens += u.InputTokens
+		usage.OutputTokens += u.OutputTokens
+		usage.CacheCreationInputTokens += u.CacheCreationInputTokens
+		usage.CacheReadInputTokens += u.CacheReadInputTokens
+		if u.CostUSD != nil {
+			usage.TotalCostUsd += *u.CostUSD
+		}
+	}
+	return usage
+}
+
 // RecentPath conversions
 func (m *Mapper) RecentPathToAPI(p store.RecentPath) api.RecentPath {
 	return api.RecentPath{
@@ -20,6 +20,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // Defines values for AgentSource.
@@ -46,6 +47,7 @@ const (
 // Defines values for ConversationEventEventType.
 const (
 	ConversationEventEventTypeMessage    ConversationEventEventType = "message"
+	ConversationEventEventTypeRecovered  ConversationEventEventType = "recovered"
 	ConversationEventEventTypeSystem     ConversationEventEventType = "system"
 	ConversationEventEventTypeThinking   ConversationEventEventType = "thinking"
 	ConversationEventEventTypeToolCall   ConversationEventEventType = "tool_call"
@@ -74,11 +76,20 @@ const (
 
 // Defines values for EventType.
 const (
-	ApprovalResolved       EventType = "approval_resolved"
-	ConversationUpdated    EventType = "conversation_updated"
-	NewApproval            EventType = "new_approval"
-	SessionSettingsChanged EventType = "session_settings_changed"
-	SessionStatusChanged   EventType = "session_status_changed"
+	ApprovalResolved          EventType = "approval_resolved"
+	AssistantDelta            EventType = "assistant_delta"
+	ConversationUpdated       EventType = "conversation_updated"
+	HookReceived              EventType = "hook_received"
+	NewApproval               EventType = "new_approval"
+	PipelineRunStatusChanged  EventType = "pipeline_run_status_changed"
+	PipelineStepStatusChanged EventType = "pipeline_step_status_changed"
+	SessionBudgetExceeded     EventType = "session_budget_exceeded"
+	SessionQueueUpdated       EventType = "session_queue_updated"
+	SessionRetryScheduled     EventType = "session_retry_scheduled"
+	SessionSettingsChanged    EventType = "session_settings_changed"
+	SessionStallCleared       EventType = "session_stall_cleared"
+	SessionStalled            EventType = "session_stalled"
+	SessionStatusChanged      EventType = "session_status_changed"
 )
 
 // Defines values for HealthResponseStatus.
@@ -100,6 +111,7 @@ const (
 	SessionStatusFailed       SessionStatus = "failed"
 	SessionStatusInterrupted  SessionStatus = "interrupted"
 	SessionStatusInterrupting SessionStatus = "interrupting"
+	SessionStatusQueued       SessionStatus = "queued"
 	SessionStatusRunning      SessionStatus = "running"
 	SessionStatusStarting     SessionStatus = "starting"
 	SessionStatusWaitingInput SessionStatus = "waiting_input"
@@ -159,6 +171,21 @@ type Agent struct {
 // AgentSource Whether agent is from local or global directory
 type AgentSource string
 
+// AgentDefinition defines model for AgentDefinition.
+type AgentDefinition struct {
+	// Description When Claude should delegate to this agent
+	Description string `json:"description"`
+
+	// Model Model override for the agent
+	Model *string `json:"model,omitempty"`
+
+	// Prompt System prompt for the agent
+	Prompt string `json:"prompt"`
+
+	// Tools Tools the agent may use, all tools if omitted
+	Tools *[]string `json:"tools,omitempty"`
+}
+
 // Approval defines model for Approval.
 type Approval struct {
 	// Comment Approver's comment
@@ -207,6 +234,11 @@ type BulkArchiveRequest struct {
 	// Archived True to archive, false to unarchive
 	Archived bool `json:"archived"`
 
+	// RemoveWorktree When archiving, also remove the sessions' git worktrees, keeping
+	// their branches. A session whose worktree can't be removed is not
+	// archived and is reported as failed.
+	RemoveWorktree *bool `json:"remove_worktree,omitempty"`
+
 	// SessionIds Sessions to archive/unarchive
 	SessionIds []string `json:"session_ids"`
 }
@@ -323,6 +355,9 @@ type ConversationEvent struct {
 	// IsCompleted Whether tool call has received result
 	IsCompleted *bool `json:"is_completed,omitempty"`
 
+	// MessageUuid UUID of the Claude message the event came from, usable as a fork point
+	MessageUuid *string `json:"message_uuid,omitempty"`
+
 	// ParentToolUseId Parent tool use ID for nested calls
 	ParentToolUseId *string `json:"parent_tool_use_id,omitempty"`
 
@@ -385,6 +420,15 @@ type CreateApprovalResponse struct {
 
 // CreateFolderRequest defines model for CreateFolderRequest.
 type CreateFolderRequest struct {
+	// DefaultMaxCostUsd Cost limit for sessions in the folder that set none
+	DefaultMaxCostUsd *float64 `json:"default_max_cost_usd,omitempty"`
+
+	// DefaultMaxOutputTokens Output token limit for sessions in the folder that set none
+	DefaultMaxOutputTokens *int `json:"default_max_output_tokens,omitempty"`
+
+	// DefaultMaxWallClock Running time limit in milliseconds for sessions in the folder that set none
+	DefaultMaxWallClock *int64 `json:"default_max_wall_clock,omitempty"`
+
 	// Name Folder display name
 	Name string `json:"name"`
 
@@ -392,11 +436,49 @@ type CreateFolderRequest struct {
 	ParentId *string `json:"parent_id"`
 }
 
+// CreateScheduleRequest defines model for CreateScheduleRequest.
+type CreateScheduleRequest struct {
+	// CatchUp skip or once
+	CatchUp *string `json:"catch_up,omitempty"`
+
+	// Cron Five-field cron expression or @hourly, @daily, @weekly, @monthly
+	Cron    *string `json:"cron,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+
+	// Launch Launch settings of the sessions a schedule or pipeline step creates
+	Launch ScheduleLaunch `json:"launch"`
+	Name   string         `json:"name"`
+
+	// RunAt Launch once at this time instead of on a cron expression
+	RunAt *time.Time `json:"run_at,omitempty"`
+}
+
+// CreateSessionFromTemplateRequest defines model for CreateSessionFromTemplateRequest.
+type CreateSessionFromTemplateRequest struct {
+	// Draft Create a draft session instead of launching it
+	Draft *bool `json:"draft,omitempty"`
+
+	// Priority Launch priority (low, normal or high)
+	Priority *string `json:"priority,omitempty"`
+
+	// Title Session title
+	Title *string `json:"title,omitempty"`
+
+	// Variables Values of the template variables
+	Variables *map[string]string `json:"variables,omitempty"`
+
+	// WorkingDir Overrides the template's working directory
+	WorkingDir *string `json:"working_dir,omitempty"`
+}
+
 // CreateSessionRequest defines model for CreateSessionRequest.
 type CreateSessionRequest struct {
 	// AdditionalDirectories Additional directories Claude can access
 	AdditionalDirectories *[]string `json:"additional_directories,omitempty"`
 
+	// Agents Inline subagent definitions keyed by name
+	Agents *map[string]AgentDefinition `json:"agents,omitempty"`
+
 	// AllowedTools Whitelist of allowed tools
 	AllowedTools *[]string `json:"allowed_tools,omitempty"`
 
@@ -406,6 +488,12 @@ type CreateSessionRequest struct {
 	// AutoAcceptEdits Enable auto-accept for edit tools
 	AutoAcceptEdits *bool `json:"auto_accept_edits,omitempty"`
 
+	// ClaudeSessionId Use this ID for the new Claude conversation
+	ClaudeSessionId *openapi_types.UUID `json:"claude_session_id,omitempty"`
+
+	// Continue Continue the most recent Claude conversation in working_dir
+	Continue *bool `json:"continue,omitempty"`
+
 	// CreateDirectoryIfNotExists Create the working directory if it does not exist
 	CreateDirectoryIfNotExists *bool `json:"createDirectoryIfNotExists,omitempty"`
 
@@ -424,16 +512,61 @@ type CreateSessionRequest struct {
 	// Draft Create session in draft state without launching Claude
 	Draft *bool `json:"draft,omitempty"`
 
+	// FallbackModel Model to fall back to when the primary model is overloaded
+	FallbackModel *string `json:"fallback_model,omitempty"`
+
+	// FolderId Folder to file the session in. The folder's budget defaults apply
+	// to the limits the request leaves unset.
+	FolderId *string `json:"folder_id,omitempty"`
+
+	// ForwardHooks Send every hook payload to the daemon, which publishes them as
+	// hook_received events
+	ForwardHooks *bool `json:"forward_hooks,omitempty"`
+
+	// Hooks Claude hooks keyed by event: PreToolUse, PostToolUse,
+	// UserPromptSubmit, Stop or SubagentStop
+	Hooks *map[string][]HookMatcher `json:"hooks,omitempty"`
+
+	// IncludePartialMessages Stream assistant output as it is generated, published as
+	// assistant_delta events on /stream/events
+	IncludePartialMessages *bool `json:"include_partial_messages,omitempty"`
+
+	// JsonSchema JSON Schema the final result must conform to (structured output)
+	JsonSchema *map[string]interface{} `json:"json_schema,omitempty"`
+
+	// MaxCostUsd Interrupt the session once it has cost this much
+	MaxCostUsd *float64 `json:"max_cost_usd,omitempty"`
+
+	// MaxOutputTokens Interrupt the session once it has generated this many output tokens
+	MaxOutputTokens *int `json:"max_output_tokens,omitempty"`
+
+	// MaxRetries Resume the session up to this many times when it fails with a
+	// transient error, like a rate limit, an overloaded API or a CLI
+	// crash. Defaults to the daemon's retry_max_attempts setting; 0
+	// disables retrying.
+	MaxRetries *int `json:"max_retries,omitempty"`
+
 	// MaxTurns Maximum conversation turns
-	MaxTurns  *int       `json:"max_turns,omitempty"`
-	McpConfig *MCPConfig `json:"mcp_config,omitempty"`
+	MaxTurns *int `json:"max_turns,omitempty"`
+
+	// MaxWallClock Interrupt the session after running this many milliseconds
+	MaxWallClock *int64     `json:"max_wall_clock,omitempty"`
+	McpConfig    *MCPConfig `json:"mcp_config,omitempty"`
 
 	// Model Model to use for the session
 	Model *CreateSessionRequestModel `json:"model,omitempty"`
 
+	// PermissionMode Claude permission mode for the session: default, acceptEdits,
+	// plan or bypassPermissions
+	PermissionMode *string `json:"permission_mode,omitempty"`
+
 	// PermissionPromptTool MCP tool for permission prompts
 	PermissionPromptTool *string `json:"permission_prompt_tool,omitempty"`
 
+	// Priority Launch priority when the daemon's concurrency limits are reached:
+	// low, normal or high. Queued sessions start in priority order.
+	Priority *string `json:"priority,omitempty"`
+
 	// ProxyApiKey API key for proxy authentication
 	ProxyApiKey *string `json:"proxy_api_key,omitempty"`
 
@@ -449,6 +582,27 @@ type CreateSessionRequest struct {
 	// Query Initial query for Claude
 	Query string `json:"query"`
 
+	// RetryBackoff Milliseconds to wait before the first retry, doubled for each one
+	// after it. Used with max_retries; defaults to the daemon's
+	// retry_backoff setting.
+	RetryBackoff *int64 `json:"retry_backoff,omitempty"`
+
+	// SettingSources Setting sources Claude loads (user, project, local). Omit for
+	// Claude's defaults; an empty list loads none.
+	SettingSources *[]string `json:"setting_sources,omitempty"`
+
+	// Settings Path to a Claude settings file, or inline settings JSON
+	Settings *string `json:"settings,omitempty"`
+
+	// StallPolicy What to do once the session goes without events for the stall
+	// timeout: notify only flags it, interrupt stops it, kill_resume
+	// kills its process and resumes the conversation in a new session.
+	// Defaults to notify.
+	StallPolicy *string `json:"stall_policy,omitempty"`
+
+	// StrictMcpConfig Only use the MCP servers from mcp_config
+	StrictMcpConfig *bool `json:"strict_mcp_config,omitempty"`
+
 	// SystemPrompt Override system prompt
 	SystemPrompt *string `json:"system_prompt,omitempty"`
 
@@ -460,6 +614,10 @@ type CreateSessionRequest struct {
 
 	// WorkingDir Working directory for the session
 	WorkingDir *string `json:"working_dir,omitempty"`
+
+	// Worktree Run the session in a new git worktree of the working directory's
+	// repository, on a new branch. Worktrees are created under ~/wt/<repo>/.
+	Worktree *WorktreeOptions `json:"worktree,omitempty"`
 }
 
 // CreateSessionRequestModel Model to use for the session
@@ -478,6 +636,12 @@ type CreateSessionResponse struct {
 
 // DebugInfoResponse defines model for DebugInfoResponse.
 type DebugInfoResponse struct {
+	// ClaudeCapabilities Launch options supported by the detected Claude version, keyed by capability
+	ClaudeCapabilities *map[string]bool `json:"claude_capabilities,omitempty"`
+
+	// ClaudeVersion Detected Claude CLI version
+	ClaudeVersion *string `json:"claude_version,omitempty"`
+
 	// CliCommand CLI command configured for MCP servers
 	CliCommand string `json:"cli_command"`
 
@@ -602,6 +766,15 @@ type Folder struct {
 	Archived  *bool     `json:"archived,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 
+	// DefaultMaxCostUsd Cost limit for sessions in the folder that set none
+	DefaultMaxCostUsd *float64 `json:"default_max_cost_usd,omitempty"`
+
+	// DefaultMaxOutputTokens Output token limit for sessions in the folder that set none
+	DefaultMaxOutputTokens *int `json:"default_max_output_tokens,omitempty"`
+
+	// DefaultMaxWallClock Running time limit in milliseconds for sessions in the folder that set none
+	DefaultMaxWallClock *int64 `json:"default_max_wall_clock,omitempty"`
+
 	// Id Unique folder identifier
 	Id string `json:"id"`
 
@@ -629,6 +802,18 @@ type FoldersResponse struct {
 	Data []Folder `json:"data"`
 }
 
+// ForkSessionRequest defines model for ForkSessionRequest.
+type ForkSessionRequest struct {
+	// MessageUuid UUID of the Claude message to fork after, instead of sequence
+	MessageUuid *string `json:"message_uuid,omitempty"`
+
+	// Query Query for the forked session
+	Query string `json:"query"`
+
+	// Sequence Sequence of the event to fork after
+	Sequence *int `json:"sequence,omitempty"`
+}
+
 // FuzzySearchFilesRequest defines model for FuzzySearchFilesRequest.
 type FuzzySearchFilesRequest struct {
 	// FilesOnly Return only files, exclude directories
@@ -662,12 +847,19 @@ type HealthResponse struct {
 			// Available Whether Claude binary is available
 			Available bool `json:"available"`
 
+			// Capabilities Launch options supported by the detected Claude version,
+			// keyed by capability (e.g. fork_session, partial_messages, agents)
+			Capabilities *map[string]bool `json:"capabilities,omitempty"`
+
 			// Error Error message if Claude is not available
 			Error *string `json:"error"`
 
 			// Path Path to Claude binary if available
 			Path *string `json:"path"`
 
+			// UpgradeHint Set when the Claude version lacks capabilities, naming them and how to upgrade
+			UpgradeHint *string `json:"upgrade_hint"`
+
 			// Version Claude binary version (e.g., "1.0.110")
 			Version *string `json:"version"`
 
@@ -682,6 +874,32 @@ type HealthResponse struct {
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// HookCommand defines model for HookCommand.
+type HookCommand struct {
+	// Command Shell command Claude runs with the hook payload on stdin
+	Command string `json:"command"`
+
+	// Timeout Seconds before Claude cancels the command
+	Timeout *int `json:"timeout,omitempty"`
+
+	// Type Hook type, only command is supported
+	Type *string `json:"type,omitempty"`
+}
+
+// HookMatcher defines model for HookMatcher.
+type HookMatcher struct {
+	Hooks []HookCommand `json:"hooks"`
+
+	// Matcher Tool name or regex the hooks apply to, for PreToolUse and
+	// PostToolUse. Empty matches every tool.
+	Matcher *string `json:"matcher,omitempty"`
+}
+
+// HookPayload Claude hook payload, with hook_event_name, session_id and
+// transcript_path, plus tool_name, tool_input and tool_response for
+// tool hooks
+type HookPayload map[string]interface{}
+
 // InterruptSessionResponse defines model for InterruptSessionResponse.
 type InterruptSessionResponse struct {
 	Data struct {
@@ -721,6 +939,101 @@ type MCPServer struct {
 	Url *string `json:"url,omitempty"`
 }
 
+// MergeWorktreeRequest defines model for MergeWorktreeRequest.
+type MergeWorktreeRequest struct {
+	// RemoveWorktree Remove the worktree and delete its branch after merging
+	RemoveWorktree *bool `json:"remove_worktree,omitempty"`
+}
+
+// Pipeline defines model for Pipeline.
+type Pipeline struct {
+	CreatedAt   time.Time      `json:"created_at"`
+	Description *string        `json:"description,omitempty"`
+	Id          string         `json:"id"`
+	Name        string         `json:"name"`
+	Steps       []PipelineStep `json:"steps"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+
+	// Variables Variables a run needs values for
+	Variables []string `json:"variables"`
+}
+
+// PipelineInput defines model for PipelineInput.
+type PipelineInput struct {
+	Description *string        `json:"description,omitempty"`
+	Name        string         `json:"name"`
+	Steps       []PipelineStep `json:"steps"`
+}
+
+// PipelineResponse defines model for PipelineResponse.
+type PipelineResponse struct {
+	Data Pipeline `json:"data"`
+}
+
+// PipelineRun defines model for PipelineRun.
+type PipelineRun struct {
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Error        *string    `json:"error,omitempty"`
+	Id           string     `json:"id"`
+	PipelineId   string     `json:"pipeline_id"`
+	PipelineName string     `json:"pipeline_name"`
+
+	// Status running, completed or failed
+	Status     string             `json:"status"`
+	Steps      []PipelineRunStep  `json:"steps"`
+	Variables  *map[string]string `json:"variables,omitempty"`
+	WorkingDir *string            `json:"working_dir,omitempty"`
+}
+
+// PipelineRunResponse defines model for PipelineRunResponse.
+type PipelineRunResponse struct {
+	Data PipelineRun `json:"data"`
+}
+
+// PipelineRunStep defines model for PipelineRunStep.
+type PipelineRunStep struct {
+	// Attempts Number of times the step was launched
+	Attempts    int        `json:"attempts"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+	Name        string     `json:"name"`
+
+	// SessionId Session of the latest attempt
+	SessionId *string    `json:"session_id,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Status pending, running, completed or failed
+	Status string `json:"status"`
+}
+
+// PipelineRunsResponse defines model for PipelineRunsResponse.
+type PipelineRunsResponse struct {
+	Data []PipelineRun `json:"data"`
+}
+
+// PipelineStep defines model for PipelineStep.
+type PipelineStep struct {
+	// ContinueFrom Step whose session to continue instead of launching a new one. It
+	// is a dependency of this step. Only the query, prompts, tools,
+	// additional directories and max turns of launch apply.
+	ContinueFrom *string `json:"continue_from,omitempty"`
+
+	// DependsOn Steps that must complete before this one starts
+	DependsOn *[]string `json:"depends_on,omitempty"`
+
+	// Launch Launch settings of the sessions a schedule or pipeline step creates
+	Launch ScheduleLaunch `json:"launch"`
+
+	// Name Step name, made of letters, digits, - and _
+	Name string `json:"name"`
+}
+
+// PipelinesResponse defines model for PipelinesResponse.
+type PipelinesResponse struct {
+	Data []Pipeline `json:"data"`
+}
+
 // RecentPath defines model for RecentPath.
 type RecentPath struct {
 	// LastUsed Last time this path was used
@@ -738,6 +1051,105 @@ type RecentPathsResponse struct {
 	Data []RecentPath `json:"data"`
 }
 
+// Schedule defines model for Schedule.
+type Schedule struct {
+	// CatchUp What to do with runs missed while the daemon was down: skip
+	// records them as missed, once launches a single session on startup
+	CatchUp   string    `json:"catch_up"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Cron Five-field cron expression, for recurring schedules
+	Cron      *string    `json:"cron,omitempty"`
+	Enabled   bool       `json:"enabled"`
+	Id        string     `json:"id"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+
+	// Launch Launch settings of the sessions a schedule or pipeline step creates
+	Launch ScheduleLaunch `json:"launch"`
+	Name   string         `json:"name"`
+
+	// NextRunAt Next launch, absent once a one-shot schedule has run
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+
+	// RunAt Launch time, for one-shot schedules
+	RunAt     *time.Time `json:"run_at,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ScheduleLaunch Launch settings of the sessions a schedule or pipeline step creates
+type ScheduleLaunch struct {
+	AdditionalDirectories *[]string `json:"additional_directories,omitempty"`
+	AllowedTools          *[]string `json:"allowed_tools,omitempty"`
+	AppendSystemPrompt    *string   `json:"append_system_prompt,omitempty"`
+	DisallowedTools       *[]string `json:"disallowed_tools,omitempty"`
+
+	// MaxCostUsd Interrupt the session once it has cost this much
+	MaxCostUsd *float64 `json:"max_cost_usd,omitempty"`
+
+	// MaxOutputTokens Interrupt the session once it has generated this many output tokens
+	MaxOutputTokens *int `json:"max_output_tokens,omitempty"`
+
+	// MaxRetries Resume the session up to this many times after transient failures
+	MaxRetries *int `json:"max_retries,omitempty"`
+	MaxTurns   *int `json:"max_turns,omitempty"`
+
+	// MaxWallClock Interrupt the session after running this many milliseconds
+	MaxWallClock *int64 `json:"max_wall_clock,omitempty"`
+
+	// Model Model to use (opus, sonnet or haiku)
+	Model *string `json:"model,omitempty"`
+
+	// PermissionMode Claude permission mode
+	PermissionMode *string `json:"permission_mode,omitempty"`
+
+	// Priority Launch priority (low, normal or high)
+	Priority *string `json:"priority,omitempty"`
+
+	// Query Initial query for Claude
+	Query string `json:"query"`
+
+	// RetryBackoff Milliseconds to wait before the first retry, used with max_retries
+	RetryBackoff *int64 `json:"retry_backoff,omitempty"`
+
+	// StallPolicy What to do when the session stalls (notify, interrupt or kill_resume)
+	StallPolicy  *string `json:"stall_policy,omitempty"`
+	SystemPrompt *string `json:"system_prompt,omitempty"`
+
+	// Title Session title, named after the schedule or pipeline step if not set
+	Title      *string `json:"title,omitempty"`
+	WorkingDir *string `json:"working_dir,omitempty"`
+}
+
+// ScheduleResponse defines model for ScheduleResponse.
+type ScheduleResponse struct {
+	Data Schedule `json:"data"`
+}
+
+// ScheduleRun defines model for ScheduleRun.
+type ScheduleRun struct {
+	Error        *string   `json:"error,omitempty"`
+	Id           int64     `json:"id"`
+	ScheduleId   string    `json:"schedule_id"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+
+	// SessionId The launched session
+	SessionId *string `json:"session_id,omitempty"`
+
+	// Status launched, failed or missed
+	Status      string    `json:"status"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+// ScheduleRunsResponse defines model for ScheduleRunsResponse.
+type ScheduleRunsResponse struct {
+	Data []ScheduleRun `json:"data"`
+}
+
+// SchedulesResponse defines model for SchedulesResponse.
+type SchedulesResponse struct {
+	Data []Schedule `json:"data"`
+}
+
 // SearchMetadata defines model for SearchMetadata.
 type SearchMetadata struct {
 	// DurationMs Search duration in milliseconds
@@ -800,12 +1212,19 @@ type Session struct {
 	// EffectiveContextTokens Total tokens counting toward context window limit
 	EffectiveContextTokens *int `json:"effective_context_tokens"`
 
+	// ErrorKind Classified cause of a failed session, such as rate_limited,
+	// overloaded, auth or max_turns
+	ErrorKind *string `json:"error_kind,omitempty"`
+
 	// ErrorMessage Error message if session failed
 	ErrorMessage *string `json:"error_message,omitempty"`
 
 	// FolderId Folder this session belongs to
 	FolderId *string `json:"folder_id"`
 
+	// ForkedFromSequence Sequence of the parent event this session was forked after
+	ForkedFromSequence *int `json:"forked_from_sequence,omitempty"`
+
 	// Id Unique session identifier
 	Id string `json:"id"`
 
@@ -815,6 +1234,18 @@ type Session struct {
 	// LastActivityAt Last activity timestamp
 	LastActivityAt time.Time `json:"last_activity_at"`
 
+	// MaxCostUsd Cost limit the session was launched with
+	MaxCostUsd *float64 `json:"max_cost_usd,omitempty"`
+
+	// MaxOutputTokens Output token limit the session was launched with
+	MaxOutputTokens *int `json:"max_output_tokens,omitempty"`
+
+	// MaxRetries How many times the session is resumed after a transient failure
+	MaxRetries *int `json:"max_retries,omitempty"`
+
+	// MaxWallClock Running time limit in milliseconds the session was launched with
+	MaxWallClock *int64 `json:"max_wall_clock,omitempty"`
+
 	// Model Model used for this session
 	Model *string `json:"model,omitempty"`
 
@@ -839,12 +1270,34 @@ type Session struct {
 	// Query Initial query that started the session
 	Query string `json:"query"`
 
+	// RecoveredAt When this session, cut off by a daemon restart, was resumed in a
+	// new session. Unset until then.
+	RecoveredAt *time.Time `json:"recovered_at,omitempty"`
+
+	// RetryAt When this failed session will be retried, unset if no retry is pending
+	RetryAt *time.Time `json:"retry_at,omitempty"`
+
+	// RetryCount Which retry of the original session this is, 0 for the original
+	RetryCount *int `json:"retry_count,omitempty"`
+
 	// RunId Unique run identifier
 	RunId string `json:"run_id"`
 
+	// StallPolicy What happens when the session stalls: notify, interrupt or
+	// kill_resume. Empty means notify.
+	StallPolicy *string `json:"stall_policy,omitempty"`
+
+	// StalledAt When the session was flagged as stalled, unset while it produces events
+	StalledAt *time.Time `json:"stalled_at,omitempty"`
+
 	// Status Current status of the session
 	Status SessionStatus `json:"status"`
 
+	// StopReason Why the daemon stopped the session: budget_exceeded when it
+	// crossed one of its limits, stalled when it stopped producing events,
+	// daemon_restarted when it was active while the daemon went down
+	StopReason *string `json:"stop_reason,omitempty"`
+
 	// Summary AI-generated summary of the session
 	Summary *string `json:"summary,omitempty"`
 
@@ -853,6 +1306,15 @@ type Session struct {
 
 	// WorkingDir Working directory for the session
 	WorkingDir *string `json:"working_dir,omitempty"`
+
+	// WorktreeBaseRef Ref the worktree branch was created from
+	WorktreeBaseRef *string `json:"worktree_base_ref,omitempty"`
+
+	// WorktreeBranch Branch of the session's worktree
+	WorktreeBranch *string `json:"worktree_branch,omitempty"`
+
+	// WorktreePath Git worktree the session runs in, until it is removed
+	WorktreePath *string `json:"worktree_path,omitempty"`
 }
 
 // SessionResponse defines model for SessionResponse.
@@ -868,6 +1330,112 @@ type SessionSearchResponse struct {
 // SessionStatus Current status of the session
 type SessionStatus string
 
+// SessionTemplate defines model for SessionTemplate.
+type SessionTemplate struct {
+	AdditionalDirectories *[]string `json:"additional_directories,omitempty"`
+	AllowedTools          *[]string `json:"allowed_tools,omitempty"`
+
+	// AppendSystemPrompt Text appended to the system prompt, may contain {{variable}} placeholders
+	AppendSystemPrompt         *string    `json:"append_system_prompt,omitempty"`
+	AutoAcceptEdits            *bool      `json:"auto_accept_edits,omitempty"`
+	CreatedAt                  time.Time  `json:"created_at"`
+	DangerouslySkipPermissions *bool      `json:"dangerously_skip_permissions,omitempty"`
+	Description                *string    `json:"description,omitempty"`
+	DisallowedTools            *[]string  `json:"disallowed_tools,omitempty"`
+	Id                         string     `json:"id"`
+	McpConfig                  *MCPConfig `json:"mcp_config,omitempty"`
+
+	// Model Model to use (opus, sonnet or haiku)
+	Model *string `json:"model,omitempty"`
+	Name  string  `json:"name"`
+
+	// PermissionMode Claude permission mode
+	PermissionMode *string `json:"permission_mode,omitempty"`
+
+	// ProxyApiKey Proxy API key, never returned
+	ProxyApiKey        *string `json:"proxy_api_key,omitempty"`
+	ProxyBaseUrl       *string `json:"proxy_base_url,omitempty"`
+	ProxyEnabled       *bool   `json:"proxy_enabled,omitempty"`
+	ProxyModelOverride *string `json:"proxy_model_override,omitempty"`
+
+	// QueryTemplate Initial query, with {{variable}} placeholders
+	QueryTemplate string `json:"query_template"`
+
+	// SystemPrompt System prompt, may contain {{variable}} placeholders
+	SystemPrompt *string   `json:"system_prompt,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Variables Variables the template uses, in order of first use
+	Variables []string `json:"variables"`
+
+	// WorkingDir Working directory, may contain {{variable}} placeholders
+	WorkingDir *string `json:"working_dir,omitempty"`
+}
+
+// SessionTemplateInput defines model for SessionTemplateInput.
+type SessionTemplateInput struct {
+	AdditionalDirectories *[]string `json:"additional_directories,omitempty"`
+	AllowedTools          *[]string `json:"allowed_tools,omitempty"`
+
+	// AppendSystemPrompt Text appended to the system prompt, may contain {{variable}} placeholders
+	AppendSystemPrompt         *string    `json:"append_system_prompt,omitempty"`
+	AutoAcceptEdits            *bool      `json:"auto_accept_edits,omitempty"`
+	DangerouslySkipPermissions *bool      `json:"dangerously_skip_permissions,omitempty"`
+	Description                *string    `json:"description,omitempty"`
+	DisallowedTools            *[]string  `json:"disallowed_tools,omitempty"`
+	McpConfig                  *MCPConfig `json:"mcp_config,omitempty"`
+
+	// Model Model to use (opus, sonnet or haiku)
+	Model *string `json:"model,omitempty"`
+	Name  string  `json:"name"`
+
+	// PermissionMode Claude permission mode
+	PermissionMode *string `json:"permission_mode,omitempty"`
+
+	// ProxyApiKey Proxy API key, never returned
+	ProxyApiKey        *string `json:"proxy_api_key,omitempty"`
+	ProxyBaseUrl       *string `json:"proxy_base_url,omitempty"`
+	ProxyEnabled       *bool   `json:"proxy_enabled,omitempty"`
+	ProxyModelOverride *string `json:"proxy_model_override,omitempty"`
+
+	// QueryTemplate Initial query, with {{variable}} placeholders
+	QueryTemplate string `json:"query_template"`
+
+	// SystemPrompt System prompt, may contain {{variable}} placeholders
+	SystemPrompt *string `json:"system_prompt,omitempty"`
+
+	// WorkingDir Working directory, may contain {{variable}} placeholders
+	WorkingDir *string `json:"working_dir,omitempty"`
+}
+
+// SessionTemplateResponse defines model for SessionTemplateResponse.
+type SessionTemplateResponse struct {
+	Data SessionTemplate `json:"data"`
+}
+
+// SessionTemplatesResponse defines model for SessionTemplatesResponse.
+type SessionTemplatesResponse struct {
+	Data []SessionTemplate `json:"data"`
+}
+
+// SessionUsage defines model for SessionUsage.
+type SessionUsage struct {
+	CacheCreationInputTokens int    `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int    `json:"cache_read_input_tokens"`
+	InputTokens              int    `json:"input_tokens"`
+	OutputTokens             int    `json:"output_tokens"`
+	SessionId                string `json:"session_id"`
+
+	// TotalCostUsd Sum of the priced messages' costs
+	TotalCostUsd float64     `json:"total_cost_usd"`
+	Turns        []TurnUsage `json:"turns"`
+}
+
+// SessionUsageResponse defines model for SessionUsageResponse.
+type SessionUsageResponse struct {
+	Data SessionUsage `json:"data"`
+}
+
 // SessionsResponse defines model for SessionsResponse.
 type SessionsResponse struct {
 	// Counts Session counts by category
@@ -906,6 +1474,15 @@ type SnapshotsResponse struct {
 	Data []FileSnapshot `json:"data"`
 }
 
+// StartPipelineRunRequest defines model for StartPipelineRunRequest.
+type StartPipelineRunRequest struct {
+	// Variables Values of the variables the steps use
+	Variables *map[string]string `json:"variables,omitempty"`
+
+	// WorkingDir Working directory of the steps that don't set one
+	WorkingDir *string `json:"working_dir,omitempty"`
+}
+
 // Thought defines model for Thought.
 type Thought struct {
 	// Content Full markdown content (only in getThought response)
@@ -948,6 +1525,30 @@ type ThoughtsResponse struct {
 	Data []Thought `json:"data"`
 }
 
+// TurnUsage defines model for TurnUsage.
+type TurnUsage struct {
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+
+	// ClaudeSessionId Claude session that produced the message
+	ClaudeSessionId *string `json:"claude_session_id,omitempty"`
+
+	// CostUsd Cost in USD, null when the model has no price
+	CostUsd     *float64  `json:"cost_usd"`
+	CreatedAt   time.Time `json:"created_at"`
+	InputTokens int       `json:"input_tokens"`
+
+	// MessageId Assistant message the usage belongs to
+	MessageId string `json:"message_id"`
+
+	// Model Model the message was priced as
+	Model        string `json:"model"`
+	OutputTokens int    `json:"output_tokens"`
+
+	// ParentToolUseId Task tool call of the subagent that produced the message, empty for the main thread
+	ParentToolUseId *string `json:"parent_tool_use_id,omitempty"`
+}
+
 // UpdateConfigRequest defines model for UpdateConfigRequest.
 type UpdateConfigRequest struct {
 	// ClaudePath Path to Claude binary (empty string for auto-detection)
@@ -959,6 +1560,15 @@ type UpdateFolderRequest struct {
 	// Archived Archive/unarchive the folder (cascades to sessions)
 	Archived *bool `json:"archived,omitempty"`
 
+	// DefaultMaxCostUsd New cost limit for sessions in the folder (0 to remove it)
+	DefaultMaxCostUsd *float64 `json:"default_max_cost_usd,omitempty"`
+
+	// DefaultMaxOutputTokens New output token limit for sessions in the folder (0 to remove it)
+	DefaultMaxOutputTokens *int `json:"default_max_output_tokens,omitempty"`
+
+	// DefaultMaxWallClock New running time limit in milliseconds (0 to remove it)
+	DefaultMaxWallClock *int64 `json:"default_max_wall_clock,omitempty"`
+
 	// Name New folder name
 	Name *string `json:"name,omitempty"`
 
@@ -969,6 +1579,22 @@ type UpdateFolderRequest struct {
 	Position *int `json:"position,omitempty"`
 }
 
+// UpdateScheduleRequest defines model for UpdateScheduleRequest.
+type UpdateScheduleRequest struct {
+	CatchUp *string `json:"catch_up,omitempty"`
+
+	// Cron Set to switch the schedule to a cron expression
+	Cron    *string `json:"cron,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+
+	// Launch Launch settings of the sessions a schedule or pipeline step creates
+	Launch *ScheduleLaunch `json:"launch,omitempty"`
+	Name   *string         `json:"name,omitempty"`
+
+	// RunAt Set to switch the schedule to a single launch
+	RunAt *time.Time `json:"run_at,omitempty"`
+}
+
 // UpdateSessionRequest defines model for UpdateSessionRequest.
 type UpdateSessionRequest struct {
 	// AdditionalDirectories Update additional directories Claude can access
@@ -1010,6 +1636,10 @@ type UpdateSessionRequest struct {
 	// ProxyModelOverride Model identifier for proxy routing
 	ProxyModelOverride *string `json:"proxy_model_override,omitempty"`
 
+	// RemoveWorktree When archiving, also remove the session's git worktree, keeping its
+	// branch. The session is not archived if the worktree can't be removed.
+	RemoveWorktree *bool `json:"remove_worktree,omitempty"`
+
 	// Status Current status of the session
 	Status *SessionStatus `json:"status,omitempty"`
 
@@ -1069,12 +1699,34 @@ type ValidateDirectoryResponse struct {
 	IsDirectory *bool `json:"isDirectory,omitempty"`
 }
 
+// WorktreeOptions Run the session in a new git worktree of the working directory's
+// repository, on a new branch. Worktrees are created under ~/wt/<repo>/.
+type WorktreeOptions struct {
+	// BaseRef Ref to branch from, defaults to the repository's current branch
+	BaseRef *string `json:"base_ref,omitempty"`
+
+	// Branch Branch to create, defaults to hld/<session id prefix>
+	Branch *string `json:"branch,omitempty"`
+}
+
 // ApprovalId defines model for approvalId.
 type ApprovalId = string
 
+// PipelineId defines model for pipelineId.
+type PipelineId = string
+
+// PipelineRunId defines model for pipelineRunId.
+type PipelineRunId = string
+
+// ScheduleId defines model for scheduleId.
+type ScheduleId = string
+
 // SessionId defines model for sessionId.
 type SessionId = string
 
+// TemplateId defines model for templateId.
+type TemplateId = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
@@ -1114,6 +1766,12 @@ type GetRecentPathsParams struct {
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// ListScheduleRunsParams defines parameters for ListScheduleRuns.
+type ListScheduleRunsParams struct {
+	// Limit Maximum number of runs to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListSessionsParams defines parameters for ListSessions.
 type ListSessionsParams struct {
 	// LeavesOnly Return only leaf sessions (sessions with no children)
@@ -1147,6 +1805,12 @@ type LaunchDraftSessionJSONBody struct {
 	Prompt string `json:"prompt"`
 }
 
+// RemoveSessionWorktreeParams defines parameters for RemoveSessionWorktree.
+type RemoveSessionWorktreeParams struct {
+	// DeleteBranch Also delete the worktree's branch
+	DeleteBranch *bool `form:"delete_branch,omitempty" json:"delete_branch,omitempty"`
+}
+
 // GetSlashCommandsParams defines parameters for GetSlashCommands.
 type GetSlashCommandsParams struct {
 	// WorkingDir Working directory to search for commands
@@ -1201,12 +1865,30 @@ type UpdateFolderJSONRequestBody = UpdateFolderRequest
 // FuzzySearchFilesJSONRequestBody defines body for FuzzySearchFiles for application/json ContentType.
 type FuzzySearchFilesJSONRequestBody = FuzzySearchFilesRequest
 
+// CreatePipelineJSONRequestBody defines body for CreatePipeline for application/json ContentType.
+type CreatePipelineJSONRequestBody = PipelineInput
+
+// UpdatePipelineJSONRequestBody defines body for UpdatePipeline for application/json ContentType.
+type UpdatePipelineJSONRequestBody = PipelineInput
+
+// StartPipelineRunJSONRequestBody defines body for StartPipelineRun for application/json ContentType.
+type StartPipelineRunJSONRequestBody = StartPipelineRunRequest
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody = CreateScheduleRequest
+
+// UpdateScheduleJSONRequestBody defines body for UpdateSchedule for application/json ContentType.
+type UpdateScheduleJSONRequestBody = UpdateScheduleRequest
+
 // CreateSessionJSONRequestBody defines body for CreateSession for application/json ContentType.
 type CreateSessionJSONRequestBody = CreateSessionRequest
 
 // BulkArchiveSessionsJSONRequestBody defines body for BulkArchiveSessions for application/json ContentType.
 type BulkArchiveSessionsJSONRequestBody = BulkArchiveRequest
 
+// CreateSessionFromTemplateJSONRequestBody defines body for CreateSessionFromTemplate for application/json ContentType.
+type CreateSessionFromTemplateJSONRequestBody = CreateSessionFromTemplateRequest
+
 // BulkMoveSessionsJSONRequestBody defines body for BulkMoveSessions for application/json ContentType.
 type BulkMoveSessionsJSONRequestBody = BulkMoveSessionsRequest
 
@@ -1219,9 +1901,24 @@ type UpdateSessionJSONRequestBody = UpdateSessionRequest
 // ContinueSessionJSONRequestBody defines body for ContinueSession for application/json ContentType.
 type ContinueSessionJSONRequestBody = ContinueSessionRequest
 
+// ForkSessionJSONRequestBody defines body for ForkSession for application/json ContentType.
+type ForkSessionJSONRequestBody = ForkSessionRequest
+
+// ReceiveHookEventJSONRequestBody defines body for ReceiveHookEvent for application/json ContentType.
+type ReceiveHookEventJSONRequestBody = HookPayload
+
 // LaunchDraftSessionJSONRequestBody defines body for LaunchDraftSession for application/json ContentType.
 type LaunchDraftSessionJSONRequestBody LaunchDraftSessionJSONBody
 
+// MergeSessionWorktreeJSONRequestBody defines body for MergeSessionWorktree for application/json ContentType.
+type MergeSessionWorktreeJSONRequestBody = MergeWorktreeRequest
+
+// CreateSessionTemplateJSONRequestBody defines body for CreateSessionTemplate for application/json ContentType.
+type CreateSessionTemplateJSONRequestBody = SessionTemplateInput
+
+// UpdateSessionTemplateJSONRequestBody defines body for UpdateSessionTemplate for application/json ContentType.
+type UpdateSessionTemplateJSONRequestBody = SessionTemplateInput
+
 // UpdateUserSettingsJSONRequestBody defines body for UpdateUserSettings for application/json ContentType.
 type UpdateUserSettingsJSONRequestBody = UpdateUserSettingsRequest
 
@@ -1275,9 +1972,54 @@ type ServerInterface interface {
 	// Health check
 	// (GET /health)
 	GetHealth(c *gin.Context)
+	// Get a pipeline run
+	// (GET /pipeline-runs/{id})
+	GetPipelineRun(c *gin.Context, id PipelineRunId)
+	// Retry a failed pipeline step
+	// (POST /pipeline-runs/{id}/steps/{step}/retry)
+	RetryPipelineStep(c *gin.Context, id PipelineRunId, step string)
+	// List pipelines
+	// (GET /pipelines)
+	ListPipelines(c *gin.Context)
+	// Create a pipeline
+	// (POST /pipelines)
+	CreatePipeline(c *gin.Context)
+	// Delete a pipeline
+	// (DELETE /pipelines/{id})
+	DeletePipeline(c *gin.Context, id PipelineId)
+	// Get a pipeline
+	// (GET /pipelines/{id})
+	GetPipeline(c *gin.Context, id PipelineId)
+	// Replace a pipeline
+	// (PUT /pipelines/{id})
+	UpdatePipeline(c *gin.Context, id PipelineId)
+	// List pipeline runs
+	// (GET /pipelines/{id}/runs)
+	ListPipelineRuns(c *gin.Context, id PipelineId)
+	// Start a pipeline run
+	// (POST /pipelines/{id}/runs)
+	StartPipelineRun(c *gin.Context, id PipelineId)
 	// Get recent working directories
 	// (GET /recent-paths)
 	GetRecentPaths(c *gin.Context, params GetRecentPathsParams)
+	// List schedules
+	// (GET /schedules)
+	ListSchedules(c *gin.Context)
+	// Create a schedule
+	// (POST /schedules)
+	CreateSchedule(c *gin.Context)
+	// Delete a schedule
+	// (DELETE /schedules/{id})
+	DeleteSchedule(c *gin.Context, id ScheduleId)
+	// Get schedule details
+	// (GET /schedules/{id})
+	GetSchedule(c *gin.Context, id ScheduleId)
+	// Update a schedule
+	// (PATCH /schedules/{id})
+	UpdateSchedule(c *gin.Context, id ScheduleId)
+	// List schedule runs
+	// (GET /schedules/{id}/runs)
+	ListScheduleRuns(c *gin.Context, id ScheduleId, params ListScheduleRunsParams)
 	// List sessions
 	// (GET /sessions)
 	ListSessions(c *gin.Context, params ListSessionsParams)
@@ -1287,6 +2029,9 @@ type ServerInterface interface {
 	// Bulk archive/unarchive sessions
 	// (POST /sessions/archive)
 	BulkArchiveSessions(c *gin.Context)
+	// Launch a session from a template
+	// (POST /sessions/from-template/{id})
+	CreateSessionFromTemplate(c *gin.Context, id TemplateId)
 	// Move sessions to a folder
 	// (POST /sessions/move)
 	BulkMoveSessions(c *gin.Context)
@@ -1305,9 +2050,15 @@ type ServerInterface interface {
 	// Continue or fork a session
 	// (POST /sessions/{id}/continue)
 	ContinueSession(c *gin.Context, id SessionId)
+	// Fork a session from a point in its conversation
+	// (POST /sessions/{id}/fork)
+	ForkSession(c *gin.Context, id SessionId)
 	// Permanently delete an empty draft session
 	// (DELETE /sessions/{id}/hard-delete-empty)
 	HardDeleteEmptyDraftSession(c *gin.Context, id SessionId)
+	// Receive a Claude hook payload
+	// (POST /sessions/{id}/hooks)
+	ReceiveHookEvent(c *gin.Context, id SessionId)
 	// Interrupt a running session
 	// (POST /sessions/{id}/interrupt)
 	InterruptSession(c *gin.Context, id SessionId)
@@ -1320,12 +2071,39 @@ type ServerInterface interface {
 	// Get conversation messages
 	// (GET /sessions/{id}/messages)
 	GetSessionMessages(c *gin.Context, id SessionId)
+	// Resume a session cut off by a daemon restart
+	// (POST /sessions/{id}/recover)
+	RecoverSession(c *gin.Context, id SessionId)
 	// Get file snapshots
 	// (GET /sessions/{id}/snapshots)
 	GetSessionSnapshots(c *gin.Context, id SessionId)
+	// Get token usage timeline
+	// (GET /sessions/{id}/usage)
+	GetSessionUsage(c *gin.Context, id SessionId)
+	// Remove a session's worktree
+	// (DELETE /sessions/{id}/worktree)
+	RemoveSessionWorktree(c *gin.Context, id SessionId, params RemoveSessionWorktreeParams)
+	// Merge a session's worktree branch
+	// (POST /sessions/{id}/worktree/merge)
+	MergeSessionWorktree(c *gin.Context, id SessionId)
 	// Get available slash commands
 	// (GET /slash-commands)
 	GetSlashCommands(c *gin.Context, params GetSlashCommandsParams)
+	// List session templates
+	// (GET /templates)
+	ListSessionTemplates(c *gin.Context)
+	// Create a session template
+	// (POST /templates)
+	CreateSessionTemplate(c *gin.Context)
+	// Delete a session template
+	// (DELETE /templates/{id})
+	DeleteSessionTemplate(c *gin.Context, id TemplateId)
+	// Get a session template
+	// (GET /templates/{id})
+	GetSessionTemplate(c *gin.Context, id TemplateId)
+	// Replace a session template
+	// (PUT /templates/{id})
+	UpdateSessionTemplate(c *gin.Context, id TemplateId)
 	// List thought files (research, plans, etc.)
 	// (GET /thoughts)
 	ListThoughts(c *gin.Context, params ListThoughtsParams)
@@ -1617,19 +2395,17 @@ func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
 	siw.Handler.GetHealth(c)
 }
 
-// GetRecentPaths operation middleware
-func (siw *ServerInterfaceWrapper) GetRecentPaths(c *gin.Context) {
+// GetPipelineRun operation middleware
+func (siw *ServerInterfaceWrapper) GetPipelineRun(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetRecentPathsParams
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "id" -------------
+	var id PipelineRunId
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1640,67 +2416,32 @@ func (siw *ServerInterfaceWrapper) GetRecentPaths(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetRecentPaths(c, params)
+	siw.Handler.GetPipelineRun(c, id)
 }
 
-// ListSessions operation middleware
-func (siw *ServerInterfaceWrapper) ListSessions(c *gin.Context) {
+// RetryPipelineStep operation middleware
+func (siw *ServerInterfaceWrapper) RetryPipelineStep(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListSessionsParams
-
-	// ------------- Optional query parameter "leavesOnly" -------------
+	// ------------- Path parameter "id" -------------
+	var id PipelineRunId
 
-	err = runtime.BindQueryParameter("form", true, false, "leavesOnly", c.Request.URL.Query(), &params.LeavesOnly)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter leavesOnly: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "filter" -------------
+	// ------------- Path parameter "step" -------------
+	var step string
 
-	err = runtime.BindQueryParameter("form", true, false, "filter", c.Request.URL.Query(), &params.Filter)
+	err = runtime.BindStyledParameterWithOptions("simple", "step", c.Param("step"), &step, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filter: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter step: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "folder_id" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "folder_id", c.Request.URL.Query(), &params.FolderId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter folder_id: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.ListSessions(c, params)
-}
-
-// CreateSession operation middleware
-func (siw *ServerInterfaceWrapper) CreateSession(c *gin.Context) {
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.CreateSession(c)
-}
-
-// BulkArchiveSessions operation middleware
-func (siw *ServerInterfaceWrapper) BulkArchiveSessions(c *gin.Context) {
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -1708,11 +2449,11 @@ func (siw *ServerInterfaceWrapper) BulkArchiveSessions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.BulkArchiveSessions(c)
+	siw.Handler.RetryPipelineStep(c, id, step)
 }
 
-// BulkMoveSessions operation middleware
-func (siw *ServerInterfaceWrapper) BulkMoveSessions(c *gin.Context) {
+// ListPipelines operation middleware
+func (siw *ServerInterfaceWrapper) ListPipelines(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -1721,11 +2462,11 @@ func (siw *ServerInterfaceWrapper) BulkMoveSessions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.BulkMoveSessions(c)
+	siw.Handler.ListPipelines(c)
 }
 
-// BulkRestoreDrafts operation middleware
-func (siw *ServerInterfaceWrapper) BulkRestoreDrafts(c *gin.Context) {
+// CreatePipeline operation middleware
+func (siw *ServerInterfaceWrapper) CreatePipeline(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -1734,30 +2475,20 @@ func (siw *ServerInterfaceWrapper) BulkRestoreDrafts(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.BulkRestoreDrafts(c)
+	siw.Handler.CreatePipeline(c)
 }
 
-// SearchSessions operation middleware
-func (siw *ServerInterfaceWrapper) SearchSessions(c *gin.Context) {
+// DeletePipeline operation middleware
+func (siw *ServerInterfaceWrapper) DeletePipeline(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params SearchSessionsParams
-
-	// ------------- Optional query parameter "query" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "query", c.Request.URL.Query(), &params.Query)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter query: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "id" -------------
+	var id PipelineId
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1768,16 +2499,16 @@ func (siw *ServerInterfaceWrapper) SearchSessions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.SearchSessions(c, params)
+	siw.Handler.DeletePipeline(c, id)
 }
 
-// GetSession operation middleware
-func (siw *ServerInterfaceWrapper) GetSession(c *gin.Context) {
+// GetPipeline operation middleware
+func (siw *ServerInterfaceWrapper) GetPipeline(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id PipelineId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1792,16 +2523,16 @@ func (siw *ServerInterfaceWrapper) GetSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetSession(c, id)
+	siw.Handler.GetPipeline(c, id)
 }
 
-// UpdateSession operation middleware
-func (siw *ServerInterfaceWrapper) UpdateSession(c *gin.Context) {
+// UpdatePipeline operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePipeline(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id PipelineId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1816,16 +2547,16 @@ func (siw *ServerInterfaceWrapper) UpdateSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateSession(c, id)
+	siw.Handler.UpdatePipeline(c, id)
 }
 
-// ContinueSession operation middleware
-func (siw *ServerInterfaceWrapper) ContinueSession(c *gin.Context) {
+// ListPipelineRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListPipelineRuns(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id PipelineId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1840,16 +2571,16 @@ func (siw *ServerInterfaceWrapper) ContinueSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ContinueSession(c, id)
+	siw.Handler.ListPipelineRuns(c, id)
 }
 
-// HardDeleteEmptyDraftSession operation middleware
-func (siw *ServerInterfaceWrapper) HardDeleteEmptyDraftSession(c *gin.Context) {
+// StartPipelineRun operation middleware
+func (siw *ServerInterfaceWrapper) StartPipelineRun(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id PipelineId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1864,20 +2595,22 @@ func (siw *ServerInterfaceWrapper) HardDeleteEmptyDraftSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.HardDeleteEmptyDraftSession(c, id)
+	siw.Handler.StartPipelineRun(c, id)
 }
 
-// InterruptSession operation middleware
-func (siw *ServerInterfaceWrapper) InterruptSession(c *gin.Context) {
+// GetRecentPaths operation middleware
+func (siw *ServerInterfaceWrapper) GetRecentPaths(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id SessionId
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRecentPathsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1888,22 +2621,24 @@ func (siw *ServerInterfaceWrapper) InterruptSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.InterruptSession(c, id)
+	siw.Handler.GetRecentPaths(c, params)
 }
 
-// DeleteDraftSession operation middleware
-func (siw *ServerInterfaceWrapper) DeleteDraftSession(c *gin.Context) {
+// ListSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListSchedules(c *gin.Context) {
 
-	var err error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	// ------------- Path parameter "id" -------------
-	var id SessionId
+	siw.Handler.ListSchedules(c)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
-		return
-	}
+// CreateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchedule(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -1912,16 +2647,16 @@ func (siw *ServerInterfaceWrapper) DeleteDraftSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteDraftSession(c, id)
+	siw.Handler.CreateSchedule(c)
 }
 
-// LaunchDraftSession operation middleware
-func (siw *ServerInterfaceWrapper) LaunchDraftSession(c *gin.Context) {
+// DeleteSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchedule(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id ScheduleId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1936,16 +2671,16 @@ func (siw *ServerInterfaceWrapper) LaunchDraftSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.LaunchDraftSession(c, id)
+	siw.Handler.DeleteSchedule(c, id)
 }
 
-// GetSessionMessages operation middleware
-func (siw *ServerInterfaceWrapper) GetSessionMessages(c *gin.Context) {
+// GetSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetSchedule(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id ScheduleId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1960,16 +2695,16 @@ func (siw *ServerInterfaceWrapper) GetSessionMessages(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetSessionMessages(c, id)
+	siw.Handler.GetSchedule(c, id)
 }
 
-// GetSessionSnapshots operation middleware
-func (siw *ServerInterfaceWrapper) GetSessionSnapshots(c *gin.Context) {
+// UpdateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSchedule(c *gin.Context) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id SessionId
+	var id ScheduleId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
@@ -1984,37 +2719,31 @@ func (siw *ServerInterfaceWrapper) GetSessionSnapshots(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetSessionSnapshots(c, id)
+	siw.Handler.UpdateSchedule(c, id)
 }
 
-// GetSlashCommands operation middleware
-func (siw *ServerInterfaceWrapper) GetSlashCommands(c *gin.Context) {
+// ListScheduleRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListScheduleRuns(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSlashCommandsParams
-
-	// ------------- Required query parameter "working_dir" -------------
-
-	if paramValue := c.Query("working_dir"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument working_dir is required, but not found"), http.StatusBadRequest)
-		return
-	}
+	// ------------- Path parameter "id" -------------
+	var id ScheduleId
 
-	err = runtime.BindQueryParameter("form", true, true, "working_dir", c.Request.URL.Query(), &params.WorkingDir)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter working_dir: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "query" -------------
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListScheduleRunsParams
 
-	err = runtime.BindQueryParameter("form", true, false, "query", c.Request.URL.Query(), &params.Query)
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter query: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -2025,37 +2754,38 @@ func (siw *ServerInterfaceWrapper) GetSlashCommands(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetSlashCommands(c, params)
+	siw.Handler.ListScheduleRuns(c, id, params)
 }
 
-// ListThoughts operation middleware
-func (siw *ServerInterfaceWrapper) ListThoughts(c *gin.Context) {
+// ListSessions operation middleware
+func (siw *ServerInterfaceWrapper) ListSessions(c *gin.Context) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListThoughtsParams
+	var params ListSessionsParams
 
-	// ------------- Optional query parameter "type" -------------
+	// ------------- Optional query parameter "leavesOnly" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	err = runtime.BindQueryParameter("form", true, false, "leavesOnly", c.Request.URL.Query(), &params.LeavesOnly)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter leavesOnly: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Required query parameter "workingDir" -------------
-
-	if paramValue := c.Query("workingDir"); paramValue != "" {
+	// ------------- Optional query parameter "filter" -------------
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument workingDir is required, but not found"), http.StatusBadRequest)
+	err = runtime.BindQueryParameter("form", true, false, "filter", c.Request.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filter: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "workingDir", c.Request.URL.Query(), &params.WorkingDir)
+	// ------------- Optional query parameter "folder_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "folder_id", c.Request.URL.Query(), &params.FolderId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter workingDir: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter folder_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -2066,44 +2796,46 @@ func (siw *ServerInterfaceWrapper) ListThoughts(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListThoughts(c, params)
+	siw.Handler.ListSessions(c, params)
 }
 
-// GetThought operation middleware
-func (siw *ServerInterfaceWrapper) GetThought(c *gin.Context) {
-
-	var err error
+// CreateSession operation middleware
+func (siw *ServerInterfaceWrapper) CreateSession(c *gin.Context) {
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetThoughtParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	// ------------- Required query parameter "path" -------------
+	siw.Handler.CreateSession(c)
+}
 
-	if paramValue := c.Query("path"); paramValue != "" {
+// BulkArchiveSessions operation middleware
+func (siw *ServerInterfaceWrapper) BulkArchiveSessions(c *gin.Context) {
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument path is required, but not found"), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "path", c.Request.URL.Query(), &params.Path)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
-		return
-	}
+	siw.Handler.BulkArchiveSessions(c)
+}
 
-	// ------------- Required query parameter "workingDir" -------------
+// CreateSessionFromTemplate operation middleware
+func (siw *ServerInterfaceWrapper) CreateSessionFromTemplate(c *gin.Context) {
 
-	if paramValue := c.Query("workingDir"); paramValue != "" {
+	var err error
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument workingDir is required, but not found"), http.StatusBadRequest)
-		return
-	}
+	// ------------- Path parameter "id" -------------
+	var id TemplateId
 
-	err = runtime.BindQueryParameter("form", true, true, "workingDir", c.Request.URL.Query(), &params.WorkingDir)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter workingDir: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -2114,11 +2846,11 @@ func (siw *ServerInterfaceWrapper) GetThought(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetThought(c, params)
+	siw.Handler.CreateSessionFromTemplate(c, id)
 }
 
-// GetUserSettings operation middleware
-func (siw *ServerInterfaceWrapper) GetUserSettings(c *gin.Context) {
+// BulkMoveSessions operation middleware
+func (siw *ServerInterfaceWrapper) BulkMoveSessions(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -2127,11 +2859,11 @@ func (siw *ServerInterfaceWrapper) GetUserSettings(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUserSettings(c)
+	siw.Handler.BulkMoveSessions(c)
 }
 
-// UpdateUserSettings operation middleware
-func (siw *ServerInterfaceWrapper) UpdateUserSettings(c *gin.Context) {
+// BulkRestoreDrafts operation middleware
+func (siw *ServerInterfaceWrapper) BulkRestoreDrafts(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -2140,1419 +2872,3135 @@ func (siw *ServerInterfaceWrapper) UpdateUserSettings(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateUserSettings(c)
+	siw.Handler.BulkRestoreDrafts(c)
 }
 
-// ValidateDirectory operation middleware
-func (siw *ServerInterfaceWrapper) ValidateDirectory(c *gin.Context) {
+// SearchSessions operation middleware
+func (siw *ServerInterfaceWrapper) SearchSessions(c *gin.Context) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	var err error
 
-	siw.Handler.ValidateDirectory(c)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchSessionsParams
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+	// ------------- Optional query parameter "query" -------------
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	err = runtime.BindQueryParameter("form", true, false, "query", c.Request.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter query: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	router.POST(options.BaseURL+"/agents/discover", wrapper.DiscoverAgents)
-	router.GET(options.BaseURL+"/approvals", wrapper.ListApprovals)
-	router.POST(options.BaseURL+"/approvals", wrapper.CreateApproval)
-	router.GET(options.BaseURL+"/approvals/:id", wrapper.GetApproval)
-	router.POST(options.BaseURL+"/approvals/:id/decide", wrapper.DecideApproval)
-	router.GET(options.BaseURL+"/config", wrapper.GetConfig)
-	router.PATCH(options.BaseURL+"/config", wrapper.UpdateConfig)
-	router.GET(options.BaseURL+"/debug-info", wrapper.GetDebugInfo)
-	router.POST(options.BaseURL+"/directories", wrapper.CreateDirectory)
-	router.GET(options.BaseURL+"/folders", wrapper.ListFolders)
-	router.POST(options.BaseURL+"/folders", wrapper.CreateFolder)
-	router.GET(options.BaseURL+"/folders/:id", wrapper.GetFolder)
-	router.PATCH(options.BaseURL+"/folders/:id", wrapper.UpdateFolder)
-	router.POST(options.BaseURL+"/fuzzy-search/files", wrapper.FuzzySearchFiles)
-	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
-	router.GET(options.BaseURL+"/recent-paths", wrapper.GetRecentPaths)
-	router.GET(options.BaseURL+"/sessions", wrapper.ListSessions)
-	router.POST(options.BaseURL+"/sessions", wrapper.CreateSession)
-	router.POST(options.BaseURL+"/sessions/archive", wrapper.BulkArchiveSessions)
-	router.POST(options.BaseURL+"/sessions/move", wrapper.BulkMoveSessions)
-	router.POST(options.BaseURL+"/sessions/restore", wrapper.BulkRestoreDrafts)
-	router.GET(options.BaseURL+"/sessions/search", wrapper.SearchSessions)
-	router.GET(options.BaseURL+"/sessions/:id", wrapper.GetSession)
-	router.PATCH(options.BaseURL+"/sessions/:id", wrapper.UpdateSession)
-	router.POST(options.BaseURL+"/sessions/:id/continue", wrapper.ContinueSession)
-	router.DELETE(options.BaseURL+"/sessions/:id/hard-delete-empty", wrapper.HardDeleteEmptyDraftSession)
-	router.POST(options.BaseURL+"/sessions/:id/interrupt", wrapper.InterruptSession)
-	router.DELETE(options.BaseURL+"/sessions/:id/launch", wrapper.DeleteDraftSession)
-	router.POST(options.BaseURL+"/sessions/:id/launch", wrapper.LaunchDraftSession)
-	router.GET(options.BaseURL+"/sessions/:id/messages", wrapper.GetSessionMessages)
-	router.GET(options.BaseURL+"/sessions/:id/snapshots", wrapper.GetSessionSnapshots)
-	router.GET(options.BaseURL+"/slash-commands", wrapper.GetSlashCommands)
-	router.GET(options.BaseURL+"/thoughts", wrapper.ListThoughts)
-	router.GET(options.BaseURL+"/thoughts/detail", wrapper.GetThought)
-	router.GET(options.BaseURL+"/user-settings", wrapper.GetUserSettings)
-	router.PATCH(options.BaseURL+"/user-settings", wrapper.UpdateUserSettings)
-	router.POST(options.BaseURL+"/validate-directory", wrapper.ValidateDirectory)
+	siw.Handler.SearchSessions(c, params)
 }
 
-type BadRequestJSONResponse ErrorResponse
+// GetSession operation middleware
+func (siw *ServerInterfaceWrapper) GetSession(c *gin.Context) {
 
-type InternalErrorJSONResponse ErrorResponse
+	var err error
 
-type NotFoundJSONResponse ErrorResponse
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type DiscoverAgentsRequestObject struct {
-	Body *DiscoverAgentsJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type DiscoverAgentsResponseObject interface {
-	VisitDiscoverAgentsResponse(w http.ResponseWriter) error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type DiscoverAgents200JSONResponse struct {
-	Agents []Agent `json:"agents"`
+	siw.Handler.GetSession(c, id)
 }
 
-func (response DiscoverAgents200JSONResponse) VisitDiscoverAgentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// UpdateSession operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSession(c *gin.Context) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type DiscoverAgents400JSONResponse struct{ BadRequestJSONResponse }
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-func (response DiscoverAgents400JSONResponse) VisitDiscoverAgentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateSession(c, id)
 }
 
-type DiscoverAgents500JSONResponse struct{ InternalErrorJSONResponse }
+// ContinueSession operation middleware
+func (siw *ServerInterfaceWrapper) ContinueSession(c *gin.Context) {
 
-func (response DiscoverAgents500JSONResponse) VisitDiscoverAgentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type ListApprovalsRequestObject struct {
-	Params ListApprovalsParams
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type ListApprovalsResponseObject interface {
-	VisitListApprovalsResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ContinueSession(c, id)
 }
 
-type ListApprovals200JSONResponse ApprovalsResponse
+// ForkSession operation middleware
+func (siw *ServerInterfaceWrapper) ForkSession(c *gin.Context) {
 
-func (response ListApprovals200JSONResponse) VisitListApprovalsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type ListApprovals500JSONResponse struct{ InternalErrorJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response ListApprovals500JSONResponse) VisitListApprovalsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.ForkSession(c, id)
 }
 
-type CreateApprovalRequestObject struct {
-	Body *CreateApprovalJSONRequestBody
-}
+// HardDeleteEmptyDraftSession operation middleware
+func (siw *ServerInterfaceWrapper) HardDeleteEmptyDraftSession(c *gin.Context) {
 
-type CreateApprovalResponseObject interface {
-	VisitCreateApprovalResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type CreateApproval201JSONResponse CreateApprovalResponse
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-func (response CreateApproval201JSONResponse) VisitCreateApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type CreateApproval400JSONResponse struct{ BadRequestJSONResponse }
+	siw.Handler.HardDeleteEmptyDraftSession(c, id)
+}
 
-func (response CreateApproval400JSONResponse) VisitCreateApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ReceiveHookEvent operation middleware
+func (siw *ServerInterfaceWrapper) ReceiveHookEvent(c *gin.Context) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type CreateApproval500JSONResponse struct{ InternalErrorJSONResponse }
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-func (response CreateApproval500JSONResponse) VisitCreateApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type GetApprovalRequestObject struct {
-	Id ApprovalId `json:"id"`
+	siw.Handler.ReceiveHookEvent(c, id)
 }
 
-type GetApprovalResponseObject interface {
-	VisitGetApprovalResponse(w http.ResponseWriter) error
-}
+// InterruptSession operation middleware
+func (siw *ServerInterfaceWrapper) InterruptSession(c *gin.Context) {
 
-type GetApproval200JSONResponse ApprovalResponse
+	var err error
 
-func (response GetApproval200JSONResponse) VisitGetApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetApproval404JSONResponse struct{ NotFoundJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetApproval404JSONResponse) VisitGetApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.InterruptSession(c, id)
 }
 
-type GetApproval500JSONResponse struct{ InternalErrorJSONResponse }
+// DeleteDraftSession operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDraftSession(c *gin.Context) {
 
-func (response GetApproval500JSONResponse) VisitGetApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type DecideApprovalRequestObject struct {
-	Id   ApprovalId `json:"id"`
-	Body *DecideApprovalJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type DecideApprovalResponseObject interface {
-	VisitDecideApprovalResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteDraftSession(c, id)
 }
 
-type DecideApproval200JSONResponse DecideApprovalResponse
+// LaunchDraftSession operation middleware
+func (siw *ServerInterfaceWrapper) LaunchDraftSession(c *gin.Context) {
 
-func (response DecideApproval200JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type DecideApproval400JSONResponse ErrorResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response DecideApproval400JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.LaunchDraftSession(c, id)
 }
 
-type DecideApproval404JSONResponse struct{ NotFoundJSONResponse }
+// GetSessionMessages operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionMessages(c *gin.Context) {
 
-func (response DecideApproval404JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type DecideApproval500JSONResponse struct{ InternalErrorJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response DecideApproval500JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.GetSessionMessages(c, id)
 }
 
-type GetConfigRequestObject struct {
-}
+// RecoverSession operation middleware
+func (siw *ServerInterfaceWrapper) RecoverSession(c *gin.Context) {
 
-type GetConfigResponseObject interface {
-	VisitGetConfigResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetConfig200JSONResponse ConfigResponse
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-func (response GetConfig200JSONResponse) VisitGetConfigResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RecoverSession(c, id)
 }
 
-type GetConfig500JSONResponse struct{ InternalErrorJSONResponse }
+// GetSessionSnapshots operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionSnapshots(c *gin.Context) {
 
-func (response GetConfig500JSONResponse) VisitGetConfigResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type UpdateConfigRequestObject struct {
-	Body *UpdateConfigJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type UpdateConfigResponseObject interface {
-	VisitUpdateConfigResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSessionSnapshots(c, id)
 }
 
-type UpdateConfig200JSONResponse ConfigResponse
+// GetSessionUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionUsage(c *gin.Context) {
 
-func (response UpdateConfig200JSONResponse) VisitUpdateConfigResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type UpdateConfig400JSONResponse struct{ BadRequestJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response UpdateConfig400JSONResponse) VisitUpdateConfigResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.GetSessionUsage(c, id)
 }
 
-type UpdateConfig500JSONResponse struct{ InternalErrorJSONResponse }
+// RemoveSessionWorktree operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSessionWorktree(c *gin.Context) {
 
-func (response UpdateConfig500JSONResponse) VisitUpdateConfigResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-type GetDebugInfoRequestObject struct {
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetDebugInfoResponseObject interface {
-	VisitGetDebugInfoResponse(w http.ResponseWriter) error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveSessionWorktreeParams
 
-type GetDebugInfo200JSONResponse DebugInfoResponse
+	// ------------- Optional query parameter "delete_branch" -------------
 
-func (response GetDebugInfo200JSONResponse) VisitGetDebugInfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("form", true, false, "delete_branch", c.Request.URL.Query(), &params.DeleteBranch)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter delete_branch: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type CreateDirectoryRequestObject struct {
-	Body *CreateDirectoryJSONRequestBody
+	siw.Handler.RemoveSessionWorktree(c, id, params)
 }
 
-type CreateDirectoryResponseObject interface {
-	VisitCreateDirectoryResponse(w http.ResponseWriter) error
-}
+// MergeSessionWorktree operation middleware
+func (siw *ServerInterfaceWrapper) MergeSessionWorktree(c *gin.Context) {
 
-type CreateDirectory200JSONResponse struct {
-	// Created Whether the directory was created
-	Created *bool `json:"created,omitempty"`
+	var err error
 
-	// Path The created directory path
-	Path *string `json:"path,omitempty"`
-}
+	// ------------- Path parameter "id" -------------
+	var id SessionId
 
-func (response CreateDirectory200JSONResponse) VisitCreateDirectoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.MergeSessionWorktree(c, id)
 }
 
-type CreateDirectory500JSONResponse struct{ InternalErrorJSONResponse }
+// GetSlashCommands operation middleware
+func (siw *ServerInterfaceWrapper) GetSlashCommands(c *gin.Context) {
 
-func (response CreateDirectory500JSONResponse) VisitCreateDirectoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSlashCommandsParams
 
-type ListFoldersRequestObject struct {
-	Params ListFoldersParams
-}
+	// ------------- Required query parameter "working_dir" -------------
 
-type ListFoldersResponseObject interface {
-	VisitListFoldersResponse(w http.ResponseWriter) error
-}
+	if paramValue := c.Query("working_dir"); paramValue != "" {
 
-type ListFolders200JSONResponse FoldersResponse
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument working_dir is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-func (response ListFolders200JSONResponse) VisitListFoldersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("form", true, true, "working_dir", c.Request.URL.Query(), &params.WorkingDir)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter working_dir: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "query" -------------
 
-type ListFolders500JSONResponse struct{ InternalErrorJSONResponse }
+	err = runtime.BindQueryParameter("form", true, false, "query", c.Request.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter query: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response ListFolders500JSONResponse) VisitListFoldersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.GetSlashCommands(c, params)
 }
 
-type CreateFolderRequestObject struct {
-	Body *CreateFolderJSONRequestBody
-}
+// ListSessionTemplates operation middleware
+func (siw *ServerInterfaceWrapper) ListSessionTemplates(c *gin.Context) {
 
-type CreateFolderResponseObject interface {
-	VisitCreateFolderResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSessionTemplates(c)
 }
 
-type CreateFolder201JSONResponse FolderResponse
+// CreateSessionTemplate operation middleware
+func (siw *ServerInterfaceWrapper) CreateSessionTemplate(c *gin.Context) {
 
-func (response CreateFolder201JSONResponse) VisitCreateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.CreateSessionTemplate(c)
 }
 
-type CreateFolder400JSONResponse ErrorResponse
+// DeleteSessionTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionTemplate(c *gin.Context) {
 
-func (response CreateFolder400JSONResponse) VisitCreateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id TemplateId
 
-type CreateFolder500JSONResponse struct{ InternalErrorJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response CreateFolder500JSONResponse) VisitCreateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.DeleteSessionTemplate(c, id)
 }
 
-type GetFolderRequestObject struct {
-	Id string `json:"id"`
-}
+// GetSessionTemplate operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionTemplate(c *gin.Context) {
 
-type GetFolderResponseObject interface {
-	VisitGetFolderResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetFolder200JSONResponse FolderResponse
+	// ------------- Path parameter "id" -------------
+	var id TemplateId
 
-func (response GetFolder200JSONResponse) VisitGetFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSessionTemplate(c, id)
 }
 
-type GetFolder404JSONResponse struct{ NotFoundJSONResponse }
+// UpdateSessionTemplate operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSessionTemplate(c *gin.Context) {
 
-func (response GetFolder404JSONResponse) VisitGetFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id TemplateId
 
-type GetFolder500JSONResponse struct{ InternalErrorJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetFolder500JSONResponse) VisitGetFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.UpdateSessionTemplate(c, id)
 }
 
-type UpdateFolderRequestObject struct {
-	Id   string `json:"id"`
-	Body *UpdateFolderJSONRequestBody
-}
+// ListThoughts operation middleware
+func (siw *ServerInterfaceWrapper) ListThoughts(c *gin.Context) {
 
-type UpdateFolderResponseObject interface {
-	VisitUpdateFolderResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type UpdateFolder200JSONResponse FolderResponse
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListThoughtsParams
 
-func (response UpdateFolder200JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Optional query parameter "type" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type UpdateFolder400JSONResponse ErrorResponse
+	// ------------- Required query parameter "workingDir" -------------
 
-func (response UpdateFolder400JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	if paramValue := c.Query("workingDir"); paramValue != "" {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument workingDir is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-type UpdateFolder404JSONResponse struct{ NotFoundJSONResponse }
+	err = runtime.BindQueryParameter("form", true, true, "workingDir", c.Request.URL.Query(), &params.WorkingDir)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter workingDir: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response UpdateFolder404JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.ListThoughts(c, params)
 }
 
-type UpdateFolder500JSONResponse struct{ InternalErrorJSONResponse }
+// GetThought operation middleware
+func (siw *ServerInterfaceWrapper) GetThought(c *gin.Context) {
 
-func (response UpdateFolder500JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetThoughtParams
 
-type FuzzySearchFilesRequestObject struct {
-	Body *FuzzySearchFilesJSONRequestBody
-}
+	// ------------- Required query parameter "path" -------------
 
-type FuzzySearchFilesResponseObject interface {
-	VisitFuzzySearchFilesResponse(w http.ResponseWriter) error
-}
+	if paramValue := c.Query("path"); paramValue != "" {
 
-type FuzzySearchFiles200JSONResponse FuzzySearchFilesResponse
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument path is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-func (response FuzzySearchFiles200JSONResponse) VisitFuzzySearchFilesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("form", true, true, "path", c.Request.URL.Query(), &params.Path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Required query parameter "workingDir" -------------
 
-type FuzzySearchFiles400JSONResponse struct{ BadRequestJSONResponse }
+	if paramValue := c.Query("workingDir"); paramValue != "" {
 
-func (response FuzzySearchFiles400JSONResponse) VisitFuzzySearchFilesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument workingDir is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, true, "workingDir", c.Request.URL.Query(), &params.WorkingDir)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter workingDir: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type FuzzySearchFiles500JSONResponse struct{ InternalErrorJSONResponse }
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-func (response FuzzySearchFiles500JSONResponse) VisitFuzzySearchFilesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	siw.Handler.GetThought(c, params)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// GetUserSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetUserSettings(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserSettings(c)
 }
 
-type GetHealthRequestObject struct {
+// UpdateUserSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUserSettings(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateUserSettings(c)
 }
 
-type GetHealthResponseObject interface {
-	VisitGetHealthResponse(w http.ResponseWriter) error
+// ValidateDirectory operation middleware
+func (siw *ServerInterfaceWrapper) ValidateDirectory(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ValidateDirectory(c)
 }
 
-type GetHealth200JSONResponse HealthResponse
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
 
-func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
 
-	return json.NewEncoder(w).Encode(response)
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.POST(options.BaseURL+"/agents/discover", wrapper.DiscoverAgents)
+	router.GET(options.BaseURL+"/approvals", wrapper.ListApprovals)
+	router.POST(options.BaseURL+"/approvals", wrapper.CreateApproval)
+	router.GET(options.BaseURL+"/approvals/:id", wrapper.GetApproval)
+	router.POST(options.BaseURL+"/approvals/:id/decide", wrapper.DecideApproval)
+	router.GET(options.BaseURL+"/config", wrapper.GetConfig)
+	router.PATCH(options.BaseURL+"/config", wrapper.UpdateConfig)
+	router.GET(options.BaseURL+"/debug-info", wrapper.GetDebugInfo)
+	router.POST(options.BaseURL+"/directories", wrapper.CreateDirectory)
+	router.GET(options.BaseURL+"/folders", wrapper.ListFolders)
+	router.POST(options.BaseURL+"/folders", wrapper.CreateFolder)
+	router.GET(options.BaseURL+"/folders/:id", wrapper.GetFolder)
+	router.PATCH(options.BaseURL+"/folders/:id", wrapper.UpdateFolder)
+	router.POST(options.BaseURL+"/fuzzy-search/files", wrapper.FuzzySearchFiles)
+	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
+	router.GET(options.BaseURL+"/pipeline-runs/:id", wrapper.GetPipelineRun)
+	router.POST(options.BaseURL+"/pipeline-runs/:id/steps/:step/retry", wrapper.RetryPipelineStep)
+	router.GET(options.BaseURL+"/pipelines", wrapper.ListPipelines)
+	router.POST(options.BaseURL+"/pipelines", wrapper.CreatePipeline)
+	router.DELETE(options.BaseURL+"/pipelines/:id", wrapper.DeletePipeline)
+	router.GET(options.BaseURL+"/pipelines/:id", wrapper.GetPipeline)
+	router.PUT(options.BaseURL+"/pipelines/:id", wrapper.UpdatePipeline)
+	router.GET(options.BaseURL+"/pipelines/:id/runs", wrapper.ListPipelineRuns)
+	router.POST(options.BaseURL+"/pipelines/:id/runs", wrapper.StartPipelineRun)
+	router.GET(options.BaseURL+"/recent-paths", wrapper.GetRecentPaths)
+	router.GET(options.BaseURL+"/schedules", wrapper.ListSchedules)
+	router.POST(options.BaseURL+"/schedules", wrapper.CreateSchedule)
+	router.DELETE(options.BaseURL+"/schedules/:id", wrapper.DeleteSchedule)
+	router.GET(options.BaseURL+"/schedules/:id", wrapper.GetSchedule)
+	router.PATCH(options.BaseURL+"/schedules/:id", wrapper.UpdateSchedule)
+	router.GET(options.BaseURL+"/schedules/:id/runs", wrapper.ListScheduleRuns)
+	router.GET(options.BaseURL+"/sessions", wrapper.ListSessions)
+	router.POST(options.BaseURL+"/sessions", wrapper.CreateSession)
+	router.POST(options.BaseURL+"/sessions/archive", wrapper.BulkArchiveSessions)
+	router.POST(options.BaseURL+"/sessions/from-template/:id", wrapper.CreateSessionFromTemplate)
+	router.POST(options.BaseURL+"/sessions/move", wrapper.BulkMoveSessions)
+	router.POST(options.BaseURL+"/sessions/restore", wrapper.BulkRestoreDrafts)
+	router.GET(options.BaseURL+"/sessions/search", wrapper.SearchSessions)
+	router.GET(options.BaseURL+"/sessions/:id", wrapper.GetSession)
+	router.PATCH(options.BaseURL+"/sessions/:id", wrapper.UpdateSession)
+	router.POST(options.BaseURL+"/sessions/:id/continue", wrapper.ContinueSession)
+	router.POST(options.BaseURL+"/sessions/:id/fork", wrapper.ForkSession)
+	router.DELETE(options.BaseURL+"/sessions/:id/hard-delete-empty", wrapper.HardDeleteEmptyDraftSession)
+	router.POST(options.BaseURL+"/sessions/:id/hooks", wrapper.ReceiveHookEvent)
+	router.POST(options.BaseURL+"/sessions/:id/interrupt", wrapper.InterruptSession)
+	router.DELETE(options.BaseURL+"/sessions/:id/launch", wrapper.DeleteDraftSession)
+	router.POST(options.BaseURL+"/sessions/:id/launch", wrapper.LaunchDraftSession)
+	router.GET(options.BaseURL+"/sessions/:id/messages", wrapper.GetSessionMessages)
+	router.POST(options.BaseURL+"/sessions/:id/recover", wrapper.RecoverSession)
+	router.GET(options.BaseURL+"/sessions/:id/snapshots", wrapper.GetSessionSnapshots)
+	router.GET(options.BaseURL+"/sessions/:id/usage", wrapper.GetSessionUsage)
+	router.DELETE(options.BaseURL+"/sessions/:id/worktree", wrapper.RemoveSessionWorktree)
+	router.POST(options.BaseURL+"/sessions/:id/worktree/merge", wrapper.MergeSessionWorktree)
+	router.GET(options.BaseURL+"/slash-commands", wrapper.GetSlashCommands)
+	router.GET(options.BaseURL+"/templates", wrapper.ListSessionTemplates)
+	router.POST(options.BaseURL+"/templates", wrapper.CreateSessionTemplate)
+	router.DELETE(options.BaseURL+"/templates/:id", wrapper.DeleteSessionTemplate)
+	router.GET(options.BaseURL+"/templates/:id", wrapper.GetSessionTemplate)
+	router.PUT(options.BaseURL+"/templates/:id", wrapper.UpdateSessionTemplate)
+	router.GET(options.BaseURL+"/thoughts", wrapper.ListThoughts)
+	router.GET(options.BaseURL+"/thoughts/detail", wrapper.GetThought)
+	router.GET(options.BaseURL+"/user-settings", wrapper.GetUserSettings)
+	router.PATCH(options.BaseURL+"/user-settings", wrapper.UpdateUserSettings)
+	router.POST(options.BaseURL+"/validate-directory", wrapper.ValidateDirectory)
 }
 
-type GetRecentPathsRequestObject struct {
-	Params GetRecentPathsParams
+type BadRequestJSONResponse ErrorResponse
+
+type InternalErrorJSONResponse ErrorResponse
+
+type NotFoundJSONResponse ErrorResponse
+
+type DiscoverAgentsRequestObject struct {
+	Body *DiscoverAgentsJSONRequestBody
 }
 
-type GetRecentPathsResponseObject interface {
-	VisitGetRecentPathsResponse(w http.ResponseWriter) error
+type DiscoverAgentsResponseObject interface {
+	VisitDiscoverAgentsResponse(w http.ResponseWriter) error
 }
 
-type GetRecentPaths200JSONResponse RecentPathsResponse
+type DiscoverAgents200JSONResponse struct {
+	Agents []Agent `json:"agents"`
+}
 
-func (response GetRecentPaths200JSONResponse) VisitGetRecentPathsResponse(w http.ResponseWriter) error {
+func (response DiscoverAgents200JSONResponse) VisitDiscoverAgentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRecentPaths500JSONResponse struct{ InternalErrorJSONResponse }
+type DiscoverAgents400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response GetRecentPaths500JSONResponse) VisitGetRecentPathsResponse(w http.ResponseWriter) error {
+func (response DiscoverAgents400JSONResponse) VisitDiscoverAgentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiscoverAgents500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DiscoverAgents500JSONResponse) VisitDiscoverAgentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListSessionsRequestObject struct {
-	Params ListSessionsParams
+type ListApprovalsRequestObject struct {
+	Params ListApprovalsParams
 }
 
-type ListSessionsResponseObject interface {
-	VisitListSessionsResponse(w http.ResponseWriter) error
+type ListApprovalsResponseObject interface {
+	VisitListApprovalsResponse(w http.ResponseWriter) error
 }
 
-type ListSessions200JSONResponse SessionsResponse
+type ListApprovals200JSONResponse ApprovalsResponse
 
-func (response ListSessions200JSONResponse) VisitListSessionsResponse(w http.ResponseWriter) error {
+func (response ListApprovals200JSONResponse) VisitListApprovalsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListSessions500JSONResponse struct{ InternalErrorJSONResponse }
+type ListApprovals500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response ListSessions500JSONResponse) VisitListSessionsResponse(w http.ResponseWriter) error {
+func (response ListApprovals500JSONResponse) VisitListApprovalsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateSessionRequestObject struct {
-	Body *CreateSessionJSONRequestBody
+type CreateApprovalRequestObject struct {
+	Body *CreateApprovalJSONRequestBody
 }
 
-type CreateSessionResponseObject interface {
-	VisitCreateSessionResponse(w http.ResponseWriter) error
+type CreateApprovalResponseObject interface {
+	VisitCreateApprovalResponse(w http.ResponseWriter) error
 }
 
-type CreateSession201JSONResponse CreateSessionResponse
+type CreateApproval201JSONResponse CreateApprovalResponse
 
-func (response CreateSession201JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+func (response CreateApproval201JSONResponse) VisitCreateApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateSession400JSONResponse struct{ BadRequestJSONResponse }
+type CreateApproval400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response CreateSession400JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+func (response CreateApproval400JSONResponse) VisitCreateApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateSession422JSONResponse DirectoryNotFoundResponse
-
-func (response CreateSession422JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateSession500JSONResponse struct{ InternalErrorJSONResponse }
+type CreateApproval500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response CreateSession500JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+func (response CreateApproval500JSONResponse) VisitCreateApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkArchiveSessionsRequestObject struct {
-	Body *BulkArchiveSessionsJSONRequestBody
+type GetApprovalRequestObject struct {
+	Id ApprovalId `json:"id"`
 }
 
-type BulkArchiveSessionsResponseObject interface {
-	VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error
+type GetApprovalResponseObject interface {
+	VisitGetApprovalResponse(w http.ResponseWriter) error
 }
 
-type BulkArchiveSessions200JSONResponse BulkArchiveResponse
+type GetApproval200JSONResponse ApprovalResponse
 
-func (response BulkArchiveSessions200JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+func (response GetApproval200JSONResponse) VisitGetApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkArchiveSessions207JSONResponse BulkArchiveResponse
-
-func (response BulkArchiveSessions207JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(207)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type BulkArchiveSessions400JSONResponse struct{ BadRequestJSONResponse }
+type GetApproval404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response BulkArchiveSessions400JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+func (response GetApproval404JSONResponse) VisitGetApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkArchiveSessions500JSONResponse struct{ InternalErrorJSONResponse }
+type GetApproval500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response BulkArchiveSessions500JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+func (response GetApproval500JSONResponse) VisitGetApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkMoveSessionsRequestObject struct {
-	Body *BulkMoveSessionsJSONRequestBody
+type DecideApprovalRequestObject struct {
+	Id   ApprovalId `json:"id"`
+	Body *DecideApprovalJSONRequestBody
 }
 
-type BulkMoveSessionsResponseObject interface {
-	VisitBulkMoveSessionsResponse(w http.ResponseWriter) error
+type DecideApprovalResponseObject interface {
+	VisitDecideApprovalResponse(w http.ResponseWriter) error
 }
 
-type BulkMoveSessions200JSONResponse BulkMoveSessionsResponse
+type DecideApproval200JSONResponse DecideApprovalResponse
 
-func (response BulkMoveSessions200JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+func (response DecideApproval200JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkMoveSessions207JSONResponse BulkMoveSessionsResponse
+type DecideApproval400JSONResponse ErrorResponse
 
-func (response BulkMoveSessions207JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+func (response DecideApproval400JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(207)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkMoveSessions400JSONResponse struct{ BadRequestJSONResponse }
+type DecideApproval404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response BulkMoveSessions400JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+func (response DecideApproval404JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkMoveSessions500JSONResponse struct{ InternalErrorJSONResponse }
+type DecideApproval500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response BulkMoveSessions500JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+func (response DecideApproval500JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkRestoreDraftsRequestObject struct {
-	Body *BulkRestoreDraftsJSONRequestBody
+type GetConfigRequestObject struct {
 }
 
-type BulkRestoreDraftsResponseObject interface {
-	VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error
+type GetConfigResponseObject interface {
+	VisitGetConfigResponse(w http.ResponseWriter) error
 }
 
-type BulkRestoreDrafts200JSONResponse BulkRestoreDraftsResponse
+type GetConfig200JSONResponse ConfigResponse
 
-func (response BulkRestoreDrafts200JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
+func (response GetConfig200JSONResponse) VisitGetConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type BulkRestoreDrafts207JSONResponse BulkRestoreDraftsResponse
+type GetConfig500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response BulkRestoreDrafts207JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(207)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type BulkRestoreDrafts400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response BulkRestoreDrafts400JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type BulkRestoreDrafts500JSONResponse struct{ InternalErrorJSONResponse }
-
-func (response BulkRestoreDrafts500JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
+func (response GetConfig500JSONResponse) VisitGetConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SearchSessionsRequestObject struct {
-	Params SearchSessionsParams
+type UpdateConfigRequestObject struct {
+	Body *UpdateConfigJSONRequestBody
 }
 
-type SearchSessionsResponseObject interface {
-	VisitSearchSessionsResponse(w http.ResponseWriter) error
+type UpdateConfigResponseObject interface {
+	VisitUpdateConfigResponse(w http.ResponseWriter) error
 }
 
-type SearchSessions200JSONResponse SessionSearchResponse
+type UpdateConfig200JSONResponse ConfigResponse
 
-func (response SearchSessions200JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+func (response UpdateConfig200JSONResponse) VisitUpdateConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SearchSessions400JSONResponse struct{ BadRequestJSONResponse }
+type UpdateConfig400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response SearchSessions400JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+func (response UpdateConfig400JSONResponse) VisitUpdateConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SearchSessions500JSONResponse struct{ InternalErrorJSONResponse }
+type UpdateConfig500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response SearchSessions500JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+func (response UpdateConfig500JSONResponse) VisitUpdateConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionRequestObject struct {
-	Id SessionId `json:"id"`
+type GetDebugInfoRequestObject struct {
 }
 
-type GetSessionResponseObject interface {
-	VisitGetSessionResponse(w http.ResponseWriter) error
+type GetDebugInfoResponseObject interface {
+	VisitGetDebugInfoResponse(w http.ResponseWriter) error
 }
 
-type GetSession200JSONResponse SessionResponse
+type GetDebugInfo200JSONResponse DebugInfoResponse
 
-func (response GetSession200JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+func (response GetDebugInfo200JSONResponse) VisitGetDebugInfoResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSession404JSONResponse struct{ NotFoundJSONResponse }
+type CreateDirectoryRequestObject struct {
+	Body *CreateDirectoryJSONRequestBody
+}
 
-func (response GetSession404JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+type CreateDirectoryResponseObject interface {
+	VisitCreateDirectoryResponse(w http.ResponseWriter) error
+}
+
+type CreateDirectory200JSONResponse struct {
+	// Created Whether the directory was created
+	Created *bool `json:"created,omitempty"`
+
+	// Path The created directory path
+	Path *string `json:"path,omitempty"`
+}
+
+func (response CreateDirectory200JSONResponse) VisitCreateDirectoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSession500JSONResponse struct{ InternalErrorJSONResponse }
+type CreateDirectory500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetSession500JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+func (response CreateDirectory500JSONResponse) VisitCreateDirectoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSessionRequestObject struct {
-	Id   SessionId `json:"id"`
-	Body *UpdateSessionJSONRequestBody
+type ListFoldersRequestObject struct {
+	Params ListFoldersParams
 }
 
-type UpdateSessionResponseObject interface {
-	VisitUpdateSessionResponse(w http.ResponseWriter) error
+type ListFoldersResponseObject interface {
+	VisitListFoldersResponse(w http.ResponseWriter) error
 }
 
-type UpdateSession200JSONResponse SessionResponse
+type ListFolders200JSONResponse FoldersResponse
 
-func (response UpdateSession200JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+func (response ListFolders200JSONResponse) VisitListFoldersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSession400JSONResponse ErrorResponse
+type ListFolders500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response UpdateSession400JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+func (response ListFolders500JSONResponse) VisitListFoldersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSession404JSONResponse struct{ NotFoundJSONResponse }
+type CreateFolderRequestObject struct {
+	Body *CreateFolderJSONRequestBody
+}
 
-func (response UpdateSession404JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+type CreateFolderResponseObject interface {
+	VisitCreateFolderResponse(w http.ResponseWriter) error
+}
+
+type CreateFolder201JSONResponse FolderResponse
+
+func (response CreateFolder201JSONResponse) VisitCreateFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSession500JSONResponse struct{ InternalErrorJSONResponse }
+type CreateFolder400JSONResponse ErrorResponse
 
-func (response UpdateSession500JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+func (response CreateFolder400JSONResponse) VisitCreateFolderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFolder500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateFolder500JSONResponse) VisitCreateFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ContinueSessionRequestObject struct {
-	Id   SessionId `json:"id"`
-	Body *ContinueSessionJSONRequestBody
+type GetFolderRequestObject struct {
+	Id string `json:"id"`
 }
 
-type ContinueSessionResponseObject interface {
-	VisitContinueSessionResponse(w http.ResponseWriter) error
+type GetFolderResponseObject interface {
+	VisitGetFolderResponse(w http.ResponseWriter) error
 }
 
-type ContinueSession201JSONResponse ContinueSessionResponse
+type GetFolder200JSONResponse FolderResponse
 
-func (response ContinueSession201JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+func (response GetFolder200JSONResponse) VisitGetFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ContinueSession404JSONResponse struct{ NotFoundJSONResponse }
+type GetFolder404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response ContinueSession404JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+func (response GetFolder404JSONResponse) VisitGetFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ContinueSession500JSONResponse struct{ InternalErrorJSONResponse }
+type GetFolder500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response ContinueSession500JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+func (response GetFolder500JSONResponse) VisitGetFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type HardDeleteEmptyDraftSessionRequestObject struct {
-	Id SessionId `json:"id"`
+type UpdateFolderRequestObject struct {
+	Id   string `json:"id"`
+	Body *UpdateFolderJSONRequestBody
 }
 
-type HardDeleteEmptyDraftSessionResponseObject interface {
-	VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error
+type UpdateFolderResponseObject interface {
+	VisitUpdateFolderResponse(w http.ResponseWriter) error
 }
 
-type HardDeleteEmptyDraftSession204Response struct {
-}
+type UpdateFolder200JSONResponse FolderResponse
 
-func (response HardDeleteEmptyDraftSession204Response) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+func (response UpdateFolder200JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type HardDeleteEmptyDraftSession400JSONResponse ErrorResponse
+type UpdateFolder400JSONResponse ErrorResponse
 
-func (response HardDeleteEmptyDraftSession400JSONResponse) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+func (response UpdateFolder400JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type HardDeleteEmptyDraftSession404JSONResponse struct{ NotFoundJSONResponse }
+type UpdateFolder404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response HardDeleteEmptyDraftSession404JSONResponse) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+func (response UpdateFolder404JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type HardDeleteEmptyDraftSession500JSONResponse struct{ InternalErrorJSONResponse }
+type UpdateFolder500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response HardDeleteEmptyDraftSession500JSONResponse) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+func (response UpdateFolder500JSONResponse) VisitUpdateFolderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type InterruptSessionRequestObject struct {
-	Id SessionId `json:"id"`
+type FuzzySearchFilesRequestObject struct {
+	Body *FuzzySearchFilesJSONRequestBody
 }
 
-type InterruptSessionResponseObject interface {
-	VisitInterruptSessionResponse(w http.ResponseWriter) error
+type FuzzySearchFilesResponseObject interface {
+	VisitFuzzySearchFilesResponse(w http.ResponseWriter) error
 }
 
-type InterruptSession200JSONResponse InterruptSessionResponse
+type FuzzySearchFiles200JSONResponse FuzzySearchFilesResponse
 
-func (response InterruptSession200JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+func (response FuzzySearchFiles200JSONResponse) VisitFuzzySearchFilesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type InterruptSession400JSONResponse ErrorResponse
+type FuzzySearchFiles400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response InterruptSession400JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+func (response FuzzySearchFiles400JSONResponse) VisitFuzzySearchFilesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type InterruptSession404JSONResponse struct{ NotFoundJSONResponse }
+type FuzzySearchFiles500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response InterruptSession404JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+func (response FuzzySearchFiles500JSONResponse) VisitFuzzySearchFilesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type InterruptSession500JSONResponse struct{ InternalErrorJSONResponse }
-
-func (response InterruptSession500JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
-
-	return json.NewEncoder(w).Encode(response)
+type GetHealthRequestObject struct {
 }
 
-type DeleteDraftSessionRequestObject struct {
-	Id SessionId `json:"id"`
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
 }
 
-type DeleteDraftSessionResponseObject interface {
-	VisitDeleteDraftSessionResponse(w http.ResponseWriter) error
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDraftSession204Response struct {
+type GetPipelineRunRequestObject struct {
+	Id PipelineRunId `json:"id"`
 }
 
-func (response DeleteDraftSession204Response) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+type GetPipelineRunResponseObject interface {
+	VisitGetPipelineRunResponse(w http.ResponseWriter) error
 }
 
-type DeleteDraftSession400JSONResponse ErrorResponse
+type GetPipelineRun200JSONResponse PipelineRunResponse
 
-func (response DeleteDraftSession400JSONResponse) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+func (response GetPipelineRun200JSONResponse) VisitGetPipelineRunResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDraftSession404JSONResponse struct{ NotFoundJSONResponse }
+type GetPipelineRun404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response DeleteDraftSession404JSONResponse) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+func (response GetPipelineRun404JSONResponse) VisitGetPipelineRunResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDraftSession500JSONResponse struct{ InternalErrorJSONResponse }
+type GetPipelineRun500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response DeleteDraftSession500JSONResponse) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+func (response GetPipelineRun500JSONResponse) VisitGetPipelineRunResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LaunchDraftSessionRequestObject struct {
-	Id   SessionId `json:"id"`
-	Body *LaunchDraftSessionJSONRequestBody
+type RetryPipelineStepRequestObject struct {
+	Id   PipelineRunId `json:"id"`
+	Step string        `json:"step"`
 }
 
-type LaunchDraftSessionResponseObject interface {
-	VisitLaunchDraftSessionResponse(w http.ResponseWriter) error
+type RetryPipelineStepResponseObject interface {
+	VisitRetryPipelineStepResponse(w http.ResponseWriter) error
 }
 
-type LaunchDraftSession200JSONResponse SessionResponse
+type RetryPipelineStep200JSONResponse PipelineRunResponse
 
-func (response LaunchDraftSession200JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+func (response RetryPipelineStep200JSONResponse) VisitRetryPipelineStepResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LaunchDraftSession400JSONResponse ErrorResponse
+type RetryPipelineStep400JSONResponse ErrorResponse
 
-func (response LaunchDraftSession400JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+func (response RetryPipelineStep400JSONResponse) VisitRetryPipelineStepResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LaunchDraftSession404JSONResponse struct{ NotFoundJSONResponse }
+type RetryPipelineStep404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response LaunchDraftSession404JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+func (response RetryPipelineStep404JSONResponse) VisitRetryPipelineStepResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LaunchDraftSession422JSONResponse DirectoryNotFoundResponse
+type RetryPipelineStep500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response LaunchDraftSession422JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+func (response RetryPipelineStep500JSONResponse) VisitRetryPipelineStepResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LaunchDraftSession500JSONResponse struct{ InternalErrorJSONResponse }
+type ListPipelinesRequestObject struct {
+}
 
-func (response LaunchDraftSession500JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+type ListPipelinesResponseObject interface {
+	VisitListPipelinesResponse(w http.ResponseWriter) error
+}
+
+type ListPipelines200JSONResponse PipelinesResponse
+
+func (response ListPipelines200JSONResponse) VisitListPipelinesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionMessagesRequestObject struct {
-	Id SessionId `json:"id"`
-}
+type ListPipelines500JSONResponse struct{ InternalErrorJSONResponse }
 
-type GetSessionMessagesResponseObject interface {
-	VisitGetSessionMessagesResponse(w http.ResponseWriter) error
+func (response ListPipelines500JSONResponse) VisitListPipelinesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionMessages200JSONResponse ConversationResponse
+type CreatePipelineRequestObject struct {
+	Body *CreatePipelineJSONRequestBody
+}
 
-func (response GetSessionMessages200JSONResponse) VisitGetSessionMessagesResponse(w http.ResponseWriter) error {
+type CreatePipelineResponseObject interface {
+	VisitCreatePipelineResponse(w http.ResponseWriter) error
+}
+
+type CreatePipeline201JSONResponse PipelineResponse
+
+func (response CreatePipeline201JSONResponse) VisitCreatePipelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePipeline400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreatePipeline400JSONResponse) VisitCreatePipelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePipeline500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreatePipeline500JSONResponse) VisitCreatePipelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePipelineRequestObject struct {
+	Id PipelineId `json:"id"`
+}
+
+type DeletePipelineResponseObject interface {
+	VisitDeletePipelineResponse(w http.ResponseWriter) error
+}
+
+type DeletePipeline204Response struct {
+}
+
+func (response DeletePipeline204Response) VisitDeletePipelineResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeletePipeline404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeletePipeline404JSONResponse) VisitDeletePipelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePipeline500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeletePipeline500JSONResponse) VisitDeletePipelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPipelineRequestObject struct {
+	Id PipelineId `json:"id"`
+}
+
+type GetPipelineResponseObject interface {
+	VisitGetPipelineResponse(w http.ResponseWriter) error
+}
+
+type GetPipeline200JSONResponse PipelineResponse
+
+func (response GetPipeline200JSONResponse) VisitGetPipelineResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionMessages404JSONResponse struct{ NotFoundJSONResponse }
+type GetPipeline404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response GetSessionMessages404JSONResponse) VisitGetSessionMessagesResponse(w http.ResponseWriter) error {
+func (response GetPipeline404JSONResponse) VisitGetPipelineResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionMessages500JSONResponse struct{ InternalErrorJSONResponse }
+type GetPipeline500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetSessionMessages500JSONResponse) VisitGetSessionMessagesResponse(w http.ResponseWriter) error {
+func (response GetPipeline500JSONResponse) VisitGetPipelineResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionSnapshotsRequestObject struct {
-	Id SessionId `json:"id"`
+type UpdatePipelineRequestObject struct {
+	Id   PipelineId `json:"id"`
+	Body *UpdatePipelineJSONRequestBody
 }
 
-type GetSessionSnapshotsResponseObject interface {
-	VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error
+type UpdatePipelineResponseObject interface {
+	VisitUpdatePipelineResponse(w http.ResponseWriter) error
 }
 
-type GetSessionSnapshots200JSONResponse SnapshotsResponse
+type UpdatePipeline200JSONResponse PipelineResponse
 
-func (response GetSessionSnapshots200JSONResponse) VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error {
+func (response UpdatePipeline200JSONResponse) VisitUpdatePipelineResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionSnapshots404JSONResponse struct{ NotFoundJSONResponse }
+type UpdatePipeline400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response GetSessionSnapshots404JSONResponse) VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error {
+func (response UpdatePipeline400JSONResponse) VisitUpdatePipelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePipeline404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdatePipeline404JSONResponse) VisitUpdatePipelineResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSessionSnapshots500JSONResponse struct{ InternalErrorJSONResponse }
+type UpdatePipeline500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetSessionSnapshots500JSONResponse) VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error {
+func (response UpdatePipeline500JSONResponse) VisitUpdatePipelineResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSlashCommandsRequestObject struct {
-	Params GetSlashCommandsParams
+type ListPipelineRunsRequestObject struct {
+	Id PipelineId `json:"id"`
 }
 
-type GetSlashCommandsResponseObject interface {
-	VisitGetSlashCommandsResponse(w http.ResponseWriter) error
+type ListPipelineRunsResponseObject interface {
+	VisitListPipelineRunsResponse(w http.ResponseWriter) error
 }
 
-type GetSlashCommands200JSONResponse SlashCommandsResponse
+type ListPipelineRuns200JSONResponse PipelineRunsResponse
 
-func (response GetSlashCommands200JSONResponse) VisitGetSlashCommandsResponse(w http.ResponseWriter) error {
+func (response ListPipelineRuns200JSONResponse) VisitListPipelineRunsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSlashCommands400JSONResponse struct{ BadRequestJSONResponse }
+type ListPipelineRuns404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response GetSlashCommands400JSONResponse) VisitGetSlashCommandsResponse(w http.ResponseWriter) error {
+func (response ListPipelineRuns404JSONResponse) VisitListPipelineRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPipelineRuns500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListPipelineRuns500JSONResponse) VisitListPipelineRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartPipelineRunRequestObject struct {
+	Id   PipelineId `json:"id"`
+	Body *StartPipelineRunJSONRequestBody
+}
+
+type StartPipelineRunResponseObject interface {
+	VisitStartPipelineRunResponse(w http.ResponseWriter) error
+}
+
+type StartPipelineRun201JSONResponse PipelineRunResponse
+
+func (response StartPipelineRun201JSONResponse) VisitStartPipelineRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartPipelineRun400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response StartPipelineRun400JSONResponse) VisitStartPipelineRunResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSlashCommands500JSONResponse struct{ InternalErrorJSONResponse }
+type StartPipelineRun404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response GetSlashCommands500JSONResponse) VisitGetSlashCommandsResponse(w http.ResponseWriter) error {
+func (response StartPipelineRun404JSONResponse) VisitStartPipelineRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartPipelineRun500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response StartPipelineRun500JSONResponse) VisitStartPipelineRunResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListThoughtsRequestObject struct {
-	Params ListThoughtsParams
+type GetRecentPathsRequestObject struct {
+	Params GetRecentPathsParams
 }
 
-type ListThoughtsResponseObject interface {
-	VisitListThoughtsResponse(w http.ResponseWriter) error
+type GetRecentPathsResponseObject interface {
+	VisitGetRecentPathsResponse(w http.ResponseWriter) error
 }
 
-type ListThoughts200JSONResponse ThoughtsResponse
+type GetRecentPaths200JSONResponse RecentPathsResponse
 
-func (response ListThoughts200JSONResponse) VisitListThoughtsResponse(w http.ResponseWriter) error {
+func (response GetRecentPaths200JSONResponse) VisitGetRecentPathsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListThoughts500JSONResponse struct{ InternalErrorJSONResponse }
+type GetRecentPaths500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response ListThoughts500JSONResponse) VisitListThoughtsResponse(w http.ResponseWriter) error {
+func (response GetRecentPaths500JSONResponse) VisitGetRecentPathsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetThoughtRequestObject struct {
-	Params GetThoughtParams
+type ListSchedulesRequestObject struct {
 }
 
-type GetThoughtResponseObject interface {
-	VisitGetThoughtResponse(w http.ResponseWriter) error
+type ListSchedulesResponseObject interface {
+	VisitListSchedulesResponse(w http.ResponseWriter) error
 }
 
-type GetThought200JSONResponse ThoughtResponse
+type ListSchedules200JSONResponse SchedulesResponse
 
-func (response GetThought200JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+func (response ListSchedules200JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetThought400JSONResponse struct{ BadRequestJSONResponse }
+type ListSchedules500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetThought400JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+func (response ListSchedules500JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateScheduleRequestObject struct {
+	Body *CreateScheduleJSONRequestBody
+}
+
+type CreateScheduleResponseObject interface {
+	VisitCreateScheduleResponse(w http.ResponseWriter) error
+}
+
+type CreateSchedule201JSONResponse ScheduleResponse
+
+func (response CreateSchedule201JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchedule400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateSchedule400JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetThought404JSONResponse struct{ NotFoundJSONResponse }
+type CreateSchedule500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetThought404JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+func (response CreateSchedule500JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteScheduleRequestObject struct {
+	Id ScheduleId `json:"id"`
+}
+
+type DeleteScheduleResponseObject interface {
+	VisitDeleteScheduleResponse(w http.ResponseWriter) error
+}
+
+type DeleteSchedule204Response struct {
+}
+
+func (response DeleteSchedule204Response) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteSchedule404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteSchedule404JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetThought500JSONResponse struct{ InternalErrorJSONResponse }
+type DeleteSchedule500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetThought500JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+func (response DeleteSchedule500JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetUserSettingsRequestObject struct {
+type GetScheduleRequestObject struct {
+	Id ScheduleId `json:"id"`
 }
 
-type GetUserSettingsResponseObject interface {
-	VisitGetUserSettingsResponse(w http.ResponseWriter) error
+type GetScheduleResponseObject interface {
+	VisitGetScheduleResponse(w http.ResponseWriter) error
 }
 
-type GetUserSettings200JSONResponse UserSettingsResponse
+type GetSchedule200JSONResponse ScheduleResponse
 
-func (response GetUserSettings200JSONResponse) VisitGetUserSettingsResponse(w http.ResponseWriter) error {
+func (response GetSchedule200JSONResponse) VisitGetScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetUserSettings500JSONResponse struct{ InternalErrorJSONResponse }
+type GetSchedule404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response GetUserSettings500JSONResponse) VisitGetUserSettingsResponse(w http.ResponseWriter) error {
+func (response GetSchedule404JSONResponse) VisitGetScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchedule500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSchedule500JSONResponse) VisitGetScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateUserSettingsRequestObject struct {
-	Body *UpdateUserSettingsJSONRequestBody
+type UpdateScheduleRequestObject struct {
+	Id   ScheduleId `json:"id"`
+	Body *UpdateScheduleJSONRequestBody
 }
 
-type UpdateUserSettingsResponseObject interface {
-	VisitUpdateUserSettingsResponse(w http.ResponseWriter) error
+type UpdateScheduleResponseObject interface {
+	VisitUpdateScheduleResponse(w http.ResponseWriter) error
 }
 
-type UpdateUserSettings200JSONResponse UserSettingsResponse
+type UpdateSchedule200JSONResponse ScheduleResponse
 
-func (response UpdateUserSettings200JSONResponse) VisitUpdateUserSettingsResponse(w http.ResponseWriter) error {
+func (response UpdateSchedule200JSONResponse) VisitUpdateScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateUserSettings400JSONResponse struct{ BadRequestJSONResponse }
+type UpdateSchedule400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response UpdateUserSettings400JSONResponse) VisitUpdateUserSettingsResponse(w http.ResponseWriter) error {
+func (response UpdateSchedule400JSONResponse) VisitUpdateScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateUserSettings500JSONResponse struct{ InternalErrorJSONResponse }
+type UpdateSchedule404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response UpdateUserSettings500JSONResponse) VisitUpdateUserSettingsResponse(w http.ResponseWriter) error {
+func (response UpdateSchedule404JSONResponse) VisitUpdateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSchedule500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response UpdateSchedule500JSONResponse) VisitUpdateScheduleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ValidateDirectoryRequestObject struct {
-	Body *ValidateDirectoryJSONRequestBody
+type ListScheduleRunsRequestObject struct {
+	Id     ScheduleId `json:"id"`
+	Params ListScheduleRunsParams
 }
 
-type ValidateDirectoryResponseObject interface {
-	VisitValidateDirectoryResponse(w http.ResponseWriter) error
+type ListScheduleRunsResponseObject interface {
+	VisitListScheduleRunsResponse(w http.ResponseWriter) error
 }
 
-type ValidateDirectory200JSONResponse ValidateDirectoryResponse
+type ListScheduleRuns200JSONResponse ScheduleRunsResponse
 
-func (response ValidateDirectory200JSONResponse) VisitValidateDirectoryResponse(w http.ResponseWriter) error {
+func (response ListScheduleRuns200JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ValidateDirectory500JSONResponse struct{ InternalErrorJSONResponse }
+type ListScheduleRuns404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response ValidateDirectory500JSONResponse) VisitValidateDirectoryResponse(w http.ResponseWriter) error {
+func (response ListScheduleRuns404JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListScheduleRuns500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListScheduleRuns500JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Discover available agents
-	// (POST /agents/discover)
-	DiscoverAgents(ctx context.Context, request DiscoverAgentsRequestObject) (DiscoverAgentsResponseObject, error)
-	// List approval requests
-	// (GET /approvals)
-	ListApprovals(ctx context.Context, request ListApprovalsRequestObject) (ListApprovalsResponseObject, error)
-	// Create approval request
-	// (POST /approvals)
-	CreateApproval(ctx context.Context, request CreateApprovalRequestObject) (CreateApprovalResponseObject, error)
-	// Get approval details
-	// (GET /approvals/{id})
-	GetApproval(ctx context.Context, request GetApprovalRequestObject) (GetApprovalResponseObject, error)
-	// Decide on approval request
-	// (POST /approvals/{id}/decide)
-	DecideApproval(ctx context.Context, request DecideApprovalRequestObject) (DecideApprovalResponseObject, error)
-	// Get daemon configuration
-	// (GET /config)
-	GetConfig(ctx context.Context, request GetConfigRequestObject) (GetConfigResponseObject, error)
-	// Update daemon configuration
-	// (PATCH /config)
-	UpdateConfig(ctx context.Context, request UpdateConfigRequestObject) (UpdateConfigResponseObject, error)
+type ListSessionsRequestObject struct {
+	Params ListSessionsParams
+}
+
+type ListSessionsResponseObject interface {
+	VisitListSessionsResponse(w http.ResponseWriter) error
+}
+
+type ListSessions200JSONResponse SessionsResponse
+
+func (response ListSessions200JSONResponse) VisitListSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListSessions500JSONResponse) VisitListSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionRequestObject struct {
+	Body *CreateSessionJSONRequestBody
+}
+
+type CreateSessionResponseObject interface {
+	VisitCreateSessionResponse(w http.ResponseWriter) error
+}
+
+type CreateSession201JSONResponse CreateSessionResponse
+
+func (response CreateSession201JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSession400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateSession400JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSession422JSONResponse DirectoryNotFoundResponse
+
+func (response CreateSession422JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateSession500JSONResponse) VisitCreateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkArchiveSessionsRequestObject struct {
+	Body *BulkArchiveSessionsJSONRequestBody
+}
+
+type BulkArchiveSessionsResponseObject interface {
+	VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error
+}
+
+type BulkArchiveSessions200JSONResponse BulkArchiveResponse
+
+func (response BulkArchiveSessions200JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkArchiveSessions207JSONResponse BulkArchiveResponse
+
+func (response BulkArchiveSessions207JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(207)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkArchiveSessions400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response BulkArchiveSessions400JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkArchiveSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response BulkArchiveSessions500JSONResponse) VisitBulkArchiveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionFromTemplateRequestObject struct {
+	Id   TemplateId `json:"id"`
+	Body *CreateSessionFromTemplateJSONRequestBody
+}
+
+type CreateSessionFromTemplateResponseObject interface {
+	VisitCreateSessionFromTemplateResponse(w http.ResponseWriter) error
+}
+
+type CreateSessionFromTemplate201JSONResponse CreateSessionResponse
+
+func (response CreateSessionFromTemplate201JSONResponse) VisitCreateSessionFromTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionFromTemplate400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateSessionFromTemplate400JSONResponse) VisitCreateSessionFromTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionFromTemplate404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreateSessionFromTemplate404JSONResponse) VisitCreateSessionFromTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionFromTemplate422JSONResponse DirectoryNotFoundResponse
+
+func (response CreateSessionFromTemplate422JSONResponse) VisitCreateSessionFromTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionFromTemplate500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateSessionFromTemplate500JSONResponse) VisitCreateSessionFromTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkMoveSessionsRequestObject struct {
+	Body *BulkMoveSessionsJSONRequestBody
+}
+
+type BulkMoveSessionsResponseObject interface {
+	VisitBulkMoveSessionsResponse(w http.ResponseWriter) error
+}
+
+type BulkMoveSessions200JSONResponse BulkMoveSessionsResponse
+
+func (response BulkMoveSessions200JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkMoveSessions207JSONResponse BulkMoveSessionsResponse
+
+func (response BulkMoveSessions207JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(207)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkMoveSessions400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response BulkMoveSessions400JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkMoveSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response BulkMoveSessions500JSONResponse) VisitBulkMoveSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkRestoreDraftsRequestObject struct {
+	Body *BulkRestoreDraftsJSONRequestBody
+}
+
+type BulkRestoreDraftsResponseObject interface {
+	VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error
+}
+
+type BulkRestoreDrafts200JSONResponse BulkRestoreDraftsResponse
+
+func (response BulkRestoreDrafts200JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkRestoreDrafts207JSONResponse BulkRestoreDraftsResponse
+
+func (response BulkRestoreDrafts207JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(207)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkRestoreDrafts400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response BulkRestoreDrafts400JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkRestoreDrafts500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response BulkRestoreDrafts500JSONResponse) VisitBulkRestoreDraftsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchSessionsRequestObject struct {
+	Params SearchSessionsParams
+}
+
+type SearchSessionsResponseObject interface {
+	VisitSearchSessionsResponse(w http.ResponseWriter) error
+}
+
+type SearchSessions200JSONResponse SessionSearchResponse
+
+func (response SearchSessions200JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchSessions400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response SearchSessions400JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response SearchSessions500JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type GetSessionResponseObject interface {
+	VisitGetSessionResponse(w http.ResponseWriter) error
+}
+
+type GetSession200JSONResponse SessionResponse
+
+func (response GetSession200JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSession404JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSession500JSONResponse) VisitGetSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSessionRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *UpdateSessionJSONRequestBody
+}
+
+type UpdateSessionResponseObject interface {
+	VisitUpdateSessionResponse(w http.ResponseWriter) error
+}
+
+type UpdateSession200JSONResponse SessionResponse
+
+func (response UpdateSession200JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSession400JSONResponse ErrorResponse
+
+func (response UpdateSession400JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateSession404JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response UpdateSession500JSONResponse) VisitUpdateSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ContinueSessionRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *ContinueSessionJSONRequestBody
+}
+
+type ContinueSessionResponseObject interface {
+	VisitContinueSessionResponse(w http.ResponseWriter) error
+}
+
+type ContinueSession201JSONResponse ContinueSessionResponse
+
+func (response ContinueSession201JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ContinueSession400JSONResponse ErrorResponse
+
+func (response ContinueSession400JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ContinueSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ContinueSession404JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ContinueSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ContinueSession500JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkSessionRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *ForkSessionJSONRequestBody
+}
+
+type ForkSessionResponseObject interface {
+	VisitForkSessionResponse(w http.ResponseWriter) error
+}
+
+type ForkSession201JSONResponse ContinueSessionResponse
+
+func (response ForkSession201JSONResponse) VisitForkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkSession400JSONResponse ErrorResponse
+
+func (response ForkSession400JSONResponse) VisitForkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ForkSession404JSONResponse) VisitForkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ForkSession500JSONResponse) VisitForkSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HardDeleteEmptyDraftSessionRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type HardDeleteEmptyDraftSessionResponseObject interface {
+	VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error
+}
+
+type HardDeleteEmptyDraftSession204Response struct {
+}
+
+func (response HardDeleteEmptyDraftSession204Response) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type HardDeleteEmptyDraftSession400JSONResponse ErrorResponse
+
+func (response HardDeleteEmptyDraftSession400JSONResponse) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HardDeleteEmptyDraftSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response HardDeleteEmptyDraftSession404JSONResponse) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HardDeleteEmptyDraftSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response HardDeleteEmptyDraftSession500JSONResponse) VisitHardDeleteEmptyDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceiveHookEventRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *ReceiveHookEventJSONRequestBody
+}
+
+type ReceiveHookEventResponseObject interface {
+	VisitReceiveHookEventResponse(w http.ResponseWriter) error
+}
+
+type ReceiveHookEvent204Response struct {
+}
+
+func (response ReceiveHookEvent204Response) VisitReceiveHookEventResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ReceiveHookEvent404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ReceiveHookEvent404JSONResponse) VisitReceiveHookEventResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceiveHookEvent500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ReceiveHookEvent500JSONResponse) VisitReceiveHookEventResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InterruptSessionRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type InterruptSessionResponseObject interface {
+	VisitInterruptSessionResponse(w http.ResponseWriter) error
+}
+
+type InterruptSession200JSONResponse InterruptSessionResponse
+
+func (response InterruptSession200JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InterruptSession400JSONResponse ErrorResponse
+
+func (response InterruptSession400JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InterruptSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response InterruptSession404JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InterruptSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response InterruptSession500JSONResponse) VisitInterruptSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDraftSessionRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type DeleteDraftSessionResponseObject interface {
+	VisitDeleteDraftSessionResponse(w http.ResponseWriter) error
+}
+
+type DeleteDraftSession204Response struct {
+}
+
+func (response DeleteDraftSession204Response) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDraftSession400JSONResponse ErrorResponse
+
+func (response DeleteDraftSession400JSONResponse) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDraftSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteDraftSession404JSONResponse) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDraftSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteDraftSession500JSONResponse) VisitDeleteDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LaunchDraftSessionRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *LaunchDraftSessionJSONRequestBody
+}
+
+type LaunchDraftSessionResponseObject interface {
+	VisitLaunchDraftSessionResponse(w http.ResponseWriter) error
+}
+
+type LaunchDraftSession200JSONResponse SessionResponse
+
+func (response LaunchDraftSession200JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LaunchDraftSession400JSONResponse ErrorResponse
+
+func (response LaunchDraftSession400JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LaunchDraftSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response LaunchDraftSession404JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LaunchDraftSession422JSONResponse DirectoryNotFoundResponse
+
+func (response LaunchDraftSession422JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LaunchDraftSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response LaunchDraftSession500JSONResponse) VisitLaunchDraftSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionMessagesRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type GetSessionMessagesResponseObject interface {
+	VisitGetSessionMessagesResponse(w http.ResponseWriter) error
+}
+
+type GetSessionMessages200JSONResponse ConversationResponse
+
+func (response GetSessionMessages200JSONResponse) VisitGetSessionMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionMessages404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSessionMessages404JSONResponse) VisitGetSessionMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionMessages500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSessionMessages500JSONResponse) VisitGetSessionMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecoverSessionRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type RecoverSessionResponseObject interface {
+	VisitRecoverSessionResponse(w http.ResponseWriter) error
+}
+
+type RecoverSession201JSONResponse ContinueSessionResponse
+
+func (response RecoverSession201JSONResponse) VisitRecoverSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecoverSession400JSONResponse ErrorResponse
+
+func (response RecoverSession400JSONResponse) VisitRecoverSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecoverSession404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RecoverSession404JSONResponse) VisitRecoverSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecoverSession500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response RecoverSession500JSONResponse) VisitRecoverSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionSnapshotsRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type GetSessionSnapshotsResponseObject interface {
+	VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error
+}
+
+type GetSessionSnapshots200JSONResponse SnapshotsResponse
+
+func (response GetSessionSnapshots200JSONResponse) VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionSnapshots404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSessionSnapshots404JSONResponse) VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionSnapshots500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSessionSnapshots500JSONResponse) VisitGetSessionSnapshotsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionUsageRequestObject struct {
+	Id SessionId `json:"id"`
+}
+
+type GetSessionUsageResponseObject interface {
+	VisitGetSessionUsageResponse(w http.ResponseWriter) error
+}
+
+type GetSessionUsage200JSONResponse SessionUsageResponse
+
+func (response GetSessionUsage200JSONResponse) VisitGetSessionUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionUsage404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSessionUsage404JSONResponse) VisitGetSessionUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionUsage500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSessionUsage500JSONResponse) VisitGetSessionUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSessionWorktreeRequestObject struct {
+	Id     SessionId `json:"id"`
+	Params RemoveSessionWorktreeParams
+}
+
+type RemoveSessionWorktreeResponseObject interface {
+	VisitRemoveSessionWorktreeResponse(w http.ResponseWriter) error
+}
+
+type RemoveSessionWorktree200JSONResponse SessionResponse
+
+func (response RemoveSessionWorktree200JSONResponse) VisitRemoveSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSessionWorktree400JSONResponse ErrorResponse
+
+func (response RemoveSessionWorktree400JSONResponse) VisitRemoveSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSessionWorktree404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RemoveSessionWorktree404JSONResponse) VisitRemoveSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveSessionWorktree500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response RemoveSessionWorktree500JSONResponse) VisitRemoveSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeSessionWorktreeRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *MergeSessionWorktreeJSONRequestBody
+}
+
+type MergeSessionWorktreeResponseObject interface {
+	VisitMergeSessionWorktreeResponse(w http.ResponseWriter) error
+}
+
+type MergeSessionWorktree200JSONResponse SessionResponse
+
+func (response MergeSessionWorktree200JSONResponse) VisitMergeSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeSessionWorktree400JSONResponse ErrorResponse
+
+func (response MergeSessionWorktree400JSONResponse) VisitMergeSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeSessionWorktree404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response MergeSessionWorktree404JSONResponse) VisitMergeSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeSessionWorktree500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response MergeSessionWorktree500JSONResponse) VisitMergeSessionWorktreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSlashCommandsRequestObject struct {
+	Params GetSlashCommandsParams
+}
+
+type GetSlashCommandsResponseObject interface {
+	VisitGetSlashCommandsResponse(w http.ResponseWriter) error
+}
+
+type GetSlashCommands200JSONResponse SlashCommandsResponse
+
+func (response GetSlashCommands200JSONResponse) VisitGetSlashCommandsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSlashCommands400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetSlashCommands400JSONResponse) VisitGetSlashCommandsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSlashCommands500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSlashCommands500JSONResponse) VisitGetSlashCommandsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSessionTemplatesRequestObject struct {
+}
+
+type ListSessionTemplatesResponseObject interface {
+	VisitListSessionTemplatesResponse(w http.ResponseWriter) error
+}
+
+type ListSessionTemplates200JSONResponse SessionTemplatesResponse
+
+func (response ListSessionTemplates200JSONResponse) VisitListSessionTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSessionTemplates500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListSessionTemplates500JSONResponse) VisitListSessionTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionTemplateRequestObject struct {
+	Body *CreateSessionTemplateJSONRequestBody
+}
+
+type CreateSessionTemplateResponseObject interface {
+	VisitCreateSessionTemplateResponse(w http.ResponseWriter) error
+}
+
+type CreateSessionTemplate201JSONResponse SessionTemplateResponse
+
+func (response CreateSessionTemplate201JSONResponse) VisitCreateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionTemplate400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateSessionTemplate400JSONResponse) VisitCreateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSessionTemplate500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateSessionTemplate500JSONResponse) VisitCreateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSessionTemplateRequestObject struct {
+	Id TemplateId `json:"id"`
+}
+
+type DeleteSessionTemplateResponseObject interface {
+	VisitDeleteSessionTemplateResponse(w http.ResponseWriter) error
+}
+
+type DeleteSessionTemplate204Response struct {
+}
+
+func (response DeleteSessionTemplate204Response) VisitDeleteSessionTemplateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteSessionTemplate404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteSessionTemplate404JSONResponse) VisitDeleteSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSessionTemplate500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteSessionTemplate500JSONResponse) VisitDeleteSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionTemplateRequestObject struct {
+	Id TemplateId `json:"id"`
+}
+
+type GetSessionTemplateResponseObject interface {
+	VisitGetSessionTemplateResponse(w http.ResponseWriter) error
+}
+
+type GetSessionTemplate200JSONResponse SessionTemplateResponse
+
+func (response GetSessionTemplate200JSONResponse) VisitGetSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionTemplate404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSessionTemplate404JSONResponse) VisitGetSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionTemplate500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetSessionTemplate500JSONResponse) VisitGetSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSessionTemplateRequestObject struct {
+	Id   TemplateId `json:"id"`
+	Body *UpdateSessionTemplateJSONRequestBody
+}
+
+type UpdateSessionTemplateResponseObject interface {
+	VisitUpdateSessionTemplateResponse(w http.ResponseWriter) error
+}
+
+type UpdateSessionTemplate200JSONResponse SessionTemplateResponse
+
+func (response UpdateSessionTemplate200JSONResponse) VisitUpdateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSessionTemplate400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateSessionTemplate400JSONResponse) VisitUpdateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSessionTemplate404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateSessionTemplate404JSONResponse) VisitUpdateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSessionTemplate500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response UpdateSessionTemplate500JSONResponse) VisitUpdateSessionTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThoughtsRequestObject struct {
+	Params ListThoughtsParams
+}
+
+type ListThoughtsResponseObject interface {
+	VisitListThoughtsResponse(w http.ResponseWriter) error
+}
+
+type ListThoughts200JSONResponse ThoughtsResponse
+
+func (response ListThoughts200JSONResponse) VisitListThoughtsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThoughts500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListThoughts500JSONResponse) VisitListThoughtsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetThoughtRequestObject struct {
+	Params GetThoughtParams
+}
+
+type GetThoughtResponseObject interface {
+	VisitGetThoughtResponse(w http.ResponseWriter) error
+}
+
+type GetThought200JSONResponse ThoughtResponse
+
+func (response GetThought200JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetThought400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetThought400JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetThought404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetThought404JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetThought500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetThought500JSONResponse) VisitGetThoughtResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserSettingsRequestObject struct {
+}
+
+type GetUserSettingsResponseObject interface {
+	VisitGetUserSettingsResponse(w http.ResponseWriter) error
+}
+
+type GetUserSettings200JSONResponse UserSettingsResponse
+
+func (response GetUserSettings200JSONResponse) VisitGetUserSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserSettings500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetUserSettings500JSONResponse) VisitGetUserSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserSettingsRequestObject struct {
+	Body *UpdateUserSettingsJSONRequestBody
+}
+
+type UpdateUserSettingsResponseObject interface {
+	VisitUpdateUserSettingsResponse(w http.ResponseWriter) error
+}
+
+type UpdateUserSettings200JSONResponse UserSettingsResponse
+
+func (response UpdateUserSettings200JSONResponse) VisitUpdateUserSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserSettings400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateUserSettings400JSONResponse) VisitUpdateUserSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserSettings500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response UpdateUserSettings500JSONResponse) VisitUpdateUserSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ValidateDirectoryRequestObject struct {
+	Body *ValidateDirectoryJSONRequestBody
+}
+
+type ValidateDirectoryResponseObject interface {
+	VisitValidateDirectoryResponse(w http.ResponseWriter) error
+}
+
+type ValidateDirectory200JSONResponse ValidateDirectoryResponse
+
+func (response ValidateDirectory200JSONResponse) VisitValidateDirectoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ValidateDirectory500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ValidateDirectory500JSONResponse) VisitValidateDirectoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Discover available agents
+	// (POST /agents/discover)
+	DiscoverAgents(ctx context.Context, request DiscoverAgentsRequestObject) (DiscoverAgentsResponseObject, error)
+	// List approval requests
+	// (GET /approvals)
+	ListApprovals(ctx context.Context, request ListApprovalsRequestObject) (ListApprovalsResponseObject, error)
+	// Create approval request
+	// (POST /approvals)
+	CreateApproval(ctx context.Context, request CreateApprovalRequestObject) (CreateApprovalResponseObject, error)
+	// Get approval details
+	// (GET /approvals/{id})
+	GetApproval(ctx context.Context, request GetApprovalRequestObject) (GetApprovalResponseObject, error)
+	// Decide on approval request
+	// (POST /approvals/{id}/decide)
+	DecideApproval(ctx context.Context, request DecideApprovalRequestObject) (DecideApprovalResponseObject, error)
+	// Get daemon configuration
+	// (GET /config)
+	GetConfig(ctx context.Context, request GetConfigRequestObject) (GetConfigResponseObject, error)
+	// Update daemon configuration
+	// (PATCH /config)
+	UpdateConfig(ctx context.Context, request UpdateConfigRequestObject) (UpdateConfigResponseObject, error)
 	// Get debug information
 	// (GET /debug-info)
 	GetDebugInfo(ctx context.Context, request GetDebugInfoRequestObject) (GetDebugInfoResponseObject, error)
@@ -3577,9 +6025,54 @@ type StrictServerInterface interface {
 	// Health check
 	// (GET /health)
 	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Get a pipeline run
+	// (GET /pipeline-runs/{id})
+	GetPipelineRun(ctx context.Context, request GetPipelineRunRequestObject) (GetPipelineRunResponseObject, error)
+	// Retry a failed pipeline step
+	// (POST /pipeline-runs/{id}/steps/{step}/retry)
+	RetryPipelineStep(ctx context.Context, request RetryPipelineStepRequestObject) (RetryPipelineStepResponseObject, error)
+	// List pipelines
+	// (GET /pipelines)
+	ListPipelines(ctx context.Context, request ListPipelinesRequestObject) (ListPipelinesResponseObject, error)
+	// Create a pipeline
+	// (POST /pipelines)
+	CreatePipeline(ctx context.Context, request CreatePipelineRequestObject) (CreatePipelineResponseObject, error)
+	// Delete a pipeline
+	// (DELETE /pipelines/{id})
+	DeletePipeline(ctx context.Context, request DeletePipelineRequestObject) (DeletePipelineResponseObject, error)
+	// Get a pipeline
+	// (GET /pipelines/{id})
+	GetPipeline(ctx context.Context, request GetPipelineRequestObject) (GetPipelineResponseObject, error)
+	// Replace a pipeline
+	// (PUT /pipelines/{id})
+	UpdatePipeline(ctx context.Context, request UpdatePipelineRequestObject) (UpdatePipelineResponseObject, error)
+	// List pipeline runs
+	// (GET /pipelines/{id}/runs)
+	ListPipelineRuns(ctx context.Context, request ListPipelineRunsRequestObject) (ListPipelineRunsResponseObject, error)
+	// Start a pipeline run
+	// (POST /pipelines/{id}/runs)
+	StartPipelineRun(ctx context.Context, request StartPipelineRunRequestObject) (StartPipelineRunResponseObject, error)
 	// Get recent working directories
 	// (GET /recent-paths)
 	GetRecentPaths(ctx context.Context, request GetRecentPathsRequestObject) (GetRecentPathsResponseObject, error)
+	// List schedules
+	// (GET /schedules)
+	ListSchedules(ctx context.Context, request ListSchedulesRequestObject) (ListSchedulesResponseObject, error)
+	// Create a schedule
+	// (POST /schedules)
+	CreateSchedule(ctx context.Context, request CreateScheduleRequestObject) (CreateScheduleResponseObject, error)
+	// Delete a schedule
+	// (DELETE /schedules/{id})
+	DeleteSchedule(ctx context.Context, request DeleteScheduleRequestObject) (DeleteScheduleResponseObject, error)
+	// Get schedule details
+	// (GET /schedules/{id})
+	GetSchedule(ctx context.Context, request GetScheduleRequestObject) (GetScheduleResponseObject, error)
+	// Update a schedule
+	// (PATCH /schedules/{id})
+	UpdateSchedule(ctx context.Context, request UpdateScheduleRequestObject) (UpdateScheduleResponseObject, error)
+	// List schedule runs
+	// (GET /schedules/{id}/runs)
+	ListScheduleRuns(ctx context.Context, request ListScheduleRunsRequestObject) (ListScheduleRunsResponseObject, error)
 	// List sessions
 	// (GET /sessions)
 	ListSessions(ctx context.Context, request ListSessionsRequestObject) (ListSessionsResponseObject, error)
@@ -3589,6 +6082,9 @@ type StrictServerInterface interface {
 	// Bulk archive/unarchive sessions
 	// (POST /sessions/archive)
 	BulkArchiveSessions(ctx context.Context, request BulkArchiveSessionsRequestObject) (BulkArchiveSessionsResponseObject, error)
+	// Launch a session from a template
+	// (POST /sessions/from-template/{id})
+	CreateSessionFromTemplate(ctx context.Context, request CreateSessionFromTemplateRequestObject) (CreateSessionFromTemplateResponseObject, error)
 	// Move sessions to a folder
 	// (POST /sessions/move)
 	BulkMoveSessions(ctx context.Context, request BulkMoveSessionsRequestObject) (BulkMoveSessionsResponseObject, error)
@@ -3607,9 +6103,15 @@ type StrictServerInterface interface {
 	// Continue or fork a session
 	// (POST /sessions/{id}/continue)
 	ContinueSession(ctx context.Context, request ContinueSessionRequestObject) (ContinueSessionResponseObject, error)
+	// Fork a session from a point in its conversation
+	// (POST /sessions/{id}/fork)
+	ForkSession(ctx context.Context, request ForkSessionRequestObject) (ForkSessionResponseObject, error)
 	// Permanently delete an empty draft session
 	// (DELETE /sessions/{id}/hard-delete-empty)
 	HardDeleteEmptyDraftSession(ctx context.Context, request HardDeleteEmptyDraftSessionRequestObject) (HardDeleteEmptyDraftSessionResponseObject, error)
+	// Receive a Claude hook payload
+	// (POST /sessions/{id}/hooks)
+	ReceiveHookEvent(ctx context.Context, request ReceiveHookEventRequestObject) (ReceiveHookEventResponseObject, error)
 	// Interrupt a running session
 	// (POST /sessions/{id}/interrupt)
 	InterruptSession(ctx context.Context, request InterruptSessionRequestObject) (InterruptSessionResponseObject, error)
@@ -3622,12 +6124,39 @@ type StrictServerInterface interface {
 	// Get conversation messages
 	// (GET /sessions/{id}/messages)
 	GetSessionMessages(ctx context.Context, request GetSessionMessagesRequestObject) (GetSessionMessagesResponseObject, error)
+	// Resume a session cut off by a daemon restart
+	// (POST /sessions/{id}/recover)
+	RecoverSession(ctx context.Context, request RecoverSessionRequestObject) (RecoverSessionResponseObject, error)
 	// Get file snapshots
 	// (GET /sessions/{id}/snapshots)
 	GetSessionSnapshots(ctx context.Context, request GetSessionSnapshotsRequestObject) (GetSessionSnapshotsResponseObject, error)
+	// Get token usage timeline
+	// (GET /sessions/{id}/usage)
+	GetSessionUsage(ctx context.Context, request GetSessionUsageRequestObject) (GetSessionUsageResponseObject, error)
+	// Remove a session's worktree
+	// (DELETE /sessions/{id}/worktree)
+	RemoveSessionWorktree(ctx context.Context, request RemoveSessionWorktreeRequestObject) (RemoveSessionWorktreeResponseObject, error)
+	// Merge a session's worktree branch
+	// (POST /sessions/{id}/worktree/merge)
+	MergeSessionWorktree(ctx context.Context, request MergeSessionWorktreeRequestObject) (MergeSessionWorktreeResponseObject, error)
 	// Get available slash commands
 	// (GET /slash-commands)
 	GetSlashCommands(ctx context.Context, request GetSlashCommandsRequestObject) (GetSlashCommandsResponseObject, error)
+	// List session templates
+	// (GET /templates)
+	ListSessionTemplates(ctx context.Context, request ListSessionTemplatesRequestObject) (ListSessionTemplatesResponseObject, error)
+	// Create a session template
+	// (POST /templates)
+	CreateSessionTemplate(ctx context.Context, request CreateSessionTemplateRequestObject) (CreateSessionTemplateResponseObject, error)
+	// Delete a session template
+	// (DELETE /templates/{id})
+	DeleteSessionTemplate(ctx context.Context, request DeleteSessionTemplateRequestObject) (DeleteSessionTemplateResponseObject, error)
+	// Get a session template
+	// (GET /templates/{id})
+	GetSessionTemplate(ctx context.Context, request GetSessionTemplateRequestObject) (GetSessionTemplateResponseObject, error)
+	// Replace a session template
+	// (PUT /templates/{id})
+	UpdateSessionTemplate(ctx context.Context, request UpdateSessionTemplateRequestObject) (UpdateSessionTemplateResponseObject, error)
 	// List thought files (research, plans, etc.)
 	// (GET /thoughts)
 	ListThoughts(ctx context.Context, request ListThoughtsRequestObject) (ListThoughtsResponseObject, error)
@@ -3645,35 +6174,453 @@ type StrictServerInterface interface {
 	ValidateDirectory(ctx context.Context, request ValidateDirectoryRequestObject) (ValidateDirectoryResponseObject, error)
 }
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// DiscoverAgents operation middleware
+func (sh *strictHandler) DiscoverAgents(ctx *gin.Context) {
+	var request DiscoverAgentsRequestObject
+
+	var body DiscoverAgentsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DiscoverAgents(ctx, request.(DiscoverAgentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DiscoverAgents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DiscoverAgentsResponseObject); ok {
+		if err := validResponse.VisitDiscoverAgentsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListApprovals operation middleware
+func (sh *strictHandler) ListApprovals(ctx *gin.Context, params ListApprovalsParams) {
+	var request ListApprovalsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListApprovals(ctx, request.(ListApprovalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListApprovals")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListApprovalsResponseObject); ok {
+		if err := validResponse.VisitListApprovalsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateApproval operation middleware
+func (sh *strictHandler) CreateApproval(ctx *gin.Context) {
+	var request CreateApprovalRequestObject
+
+	var body CreateApprovalJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateApproval(ctx, request.(CreateApprovalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateApproval")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateApprovalResponseObject); ok {
+		if err := validResponse.VisitCreateApprovalResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApproval operation middleware
+func (sh *strictHandler) GetApproval(ctx *gin.Context, id ApprovalId) {
+	var request GetApprovalRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApproval(ctx, request.(GetApprovalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApproval")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApprovalResponseObject); ok {
+		if err := validResponse.VisitGetApprovalResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DecideApproval operation middleware
+func (sh *strictHandler) DecideApproval(ctx *gin.Context, id ApprovalId) {
+	var request DecideApprovalRequestObject
+
+	request.Id = id
+
+	var body DecideApprovalJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DecideApproval(ctx, request.(DecideApprovalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DecideApproval")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DecideApprovalResponseObject); ok {
+		if err := validResponse.VisitDecideApprovalResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetConfig operation middleware
+func (sh *strictHandler) GetConfig(ctx *gin.Context) {
+	var request GetConfigRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConfig(ctx, request.(GetConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConfig")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetConfigResponseObject); ok {
+		if err := validResponse.VisitGetConfigResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateConfig operation middleware
+func (sh *strictHandler) UpdateConfig(ctx *gin.Context) {
+	var request UpdateConfigRequestObject
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
+	var body UpdateConfigJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateConfig(ctx, request.(UpdateConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateConfig")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateConfigResponseObject); ok {
+		if err := validResponse.VisitUpdateConfigResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+// GetDebugInfo operation middleware
+func (sh *strictHandler) GetDebugInfo(ctx *gin.Context) {
+	var request GetDebugInfoRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDebugInfo(ctx, request.(GetDebugInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDebugInfo")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetDebugInfoResponseObject); ok {
+		if err := validResponse.VisitGetDebugInfoResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// DiscoverAgents operation middleware
-func (sh *strictHandler) DiscoverAgents(ctx *gin.Context) {
-	var request DiscoverAgentsRequestObject
+// CreateDirectory operation middleware
+func (sh *strictHandler) CreateDirectory(ctx *gin.Context) {
+	var request CreateDirectoryRequestObject
+
+	var body CreateDirectoryJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDirectory(ctx, request.(CreateDirectoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDirectory")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateDirectoryResponseObject); ok {
+		if err := validResponse.VisitCreateDirectoryResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFolders operation middleware
+func (sh *strictHandler) ListFolders(ctx *gin.Context, params ListFoldersParams) {
+	var request ListFoldersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFolders(ctx, request.(ListFoldersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFolders")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListFoldersResponseObject); ok {
+		if err := validResponse.VisitListFoldersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFolder operation middleware
+func (sh *strictHandler) CreateFolder(ctx *gin.Context) {
+	var request CreateFolderRequestObject
+
+	var body CreateFolderJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFolder(ctx, request.(CreateFolderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFolder")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateFolderResponseObject); ok {
+		if err := validResponse.VisitCreateFolderResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFolder operation middleware
+func (sh *strictHandler) GetFolder(ctx *gin.Context, id string) {
+	var request GetFolderRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFolder(ctx, request.(GetFolderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFolder")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetFolderResponseObject); ok {
+		if err := validResponse.VisitGetFolderResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateFolder operation middleware
+func (sh *strictHandler) UpdateFolder(ctx *gin.Context, id string) {
+	var request UpdateFolderRequestObject
+
+	request.Id = id
+
+	var body UpdateFolderJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateFolder(ctx, request.(UpdateFolderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateFolder")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateFolderResponseObject); ok {
+		if err := validResponse.VisitUpdateFolderResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// FuzzySearchFiles operation middleware
+func (sh *strictHandler) FuzzySearchFiles(ctx *gin.Context) {
+	var request FuzzySearchFilesRequestObject
+
+	var body FuzzySearchFilesJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.FuzzySearchFiles(ctx, request.(FuzzySearchFilesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FuzzySearchFiles")
+	}
+
+	response, err := handler(ctx, request)
 
-	var body DiscoverAgentsJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
+	if err != nil {
 		ctx.Error(err)
-		return
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(FuzzySearchFilesResponseObject); ok {
+		if err := validResponse.VisitFuzzySearchFilesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
 	}
-	request.Body = &body
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(ctx *gin.Context) {
+	var request GetHealthRequestObject
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.DiscoverAgents(ctx, request.(DiscoverAgentsRequestObject))
+		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DiscoverAgents")
+		handler = middleware(handler, "GetHealth")
 	}
 
 	response, err := handler(ctx, request)
@@ -3681,8 +6628,8 @@ func (sh *strictHandler) DiscoverAgents(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(DiscoverAgentsResponseObject); ok {
-		if err := validResponse.VisitDiscoverAgentsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		if err := validResponse.VisitGetHealthResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3690,17 +6637,17 @@ func (sh *strictHandler) DiscoverAgents(ctx *gin.Context) {
 	}
 }
 
-// ListApprovals operation middleware
-func (sh *strictHandler) ListApprovals(ctx *gin.Context, params ListApprovalsParams) {
-	var request ListApprovalsRequestObject
+// GetPipelineRun operation middleware
+func (sh *strictHandler) GetPipelineRun(ctx *gin.Context, id PipelineRunId) {
+	var request GetPipelineRunRequestObject
 
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.ListApprovals(ctx, request.(ListApprovalsRequestObject))
+		return sh.ssi.GetPipelineRun(ctx, request.(GetPipelineRunRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListApprovals")
+		handler = middleware(handler, "GetPipelineRun")
 	}
 
 	response, err := handler(ctx, request)
@@ -3708,8 +6655,8 @@ func (sh *strictHandler) ListApprovals(ctx *gin.Context, params ListApprovalsPar
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(ListApprovalsResponseObject); ok {
-		if err := validResponse.VisitListApprovalsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetPipelineRunResponseObject); ok {
+		if err := validResponse.VisitGetPipelineRunResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3717,23 +6664,18 @@ func (sh *strictHandler) ListApprovals(ctx *gin.Context, params ListApprovalsPar
 	}
 }
 
-// CreateApproval operation middleware
-func (sh *strictHandler) CreateApproval(ctx *gin.Context) {
-	var request CreateApprovalRequestObject
+// RetryPipelineStep operation middleware
+func (sh *strictHandler) RetryPipelineStep(ctx *gin.Context, id PipelineRunId, step string) {
+	var request RetryPipelineStepRequestObject
 
-	var body CreateApprovalJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
-		ctx.Error(err)
-		return
-	}
-	request.Body = &body
+	request.Id = id
+	request.Step = step
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateApproval(ctx, request.(CreateApprovalRequestObject))
+		return sh.ssi.RetryPipelineStep(ctx, request.(RetryPipelineStepRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateApproval")
+		handler = middleware(handler, "RetryPipelineStep")
 	}
 
 	response, err := handler(ctx, request)
@@ -3741,8 +6683,8 @@ func (sh *strictHandler) CreateApproval(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(CreateApprovalResponseObject); ok {
-		if err := validResponse.VisitCreateApprovalResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(RetryPipelineStepResponseObject); ok {
+		if err := validResponse.VisitRetryPipelineStepResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3750,17 +6692,15 @@ func (sh *strictHandler) CreateApproval(ctx *gin.Context) {
 	}
 }
 
-// GetApproval operation middleware
-func (sh *strictHandler) GetApproval(ctx *gin.Context, id ApprovalId) {
-	var request GetApprovalRequestObject
-
-	request.Id = id
+// ListPipelines operation middleware
+func (sh *strictHandler) ListPipelines(ctx *gin.Context) {
+	var request ListPipelinesRequestObject
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetApproval(ctx, request.(GetApprovalRequestObject))
+		return sh.ssi.ListPipelines(ctx, request.(ListPipelinesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetApproval")
+		handler = middleware(handler, "ListPipelines")
 	}
 
 	response, err := handler(ctx, request)
@@ -3768,8 +6708,8 @@ func (sh *strictHandler) GetApproval(ctx *gin.Context, id ApprovalId) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetApprovalResponseObject); ok {
-		if err := validResponse.VisitGetApprovalResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(ListPipelinesResponseObject); ok {
+		if err := validResponse.VisitListPipelinesResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3777,13 +6717,11 @@ func (sh *strictHandler) GetApproval(ctx *gin.Context, id ApprovalId) {
 	}
 }
 
-// DecideApproval operation middleware
-func (sh *strictHandler) DecideApproval(ctx *gin.Context, id ApprovalId) {
-	var request DecideApprovalRequestObject
-
-	request.Id = id
+// CreatePipeline operation middleware
+func (sh *strictHandler) CreatePipeline(ctx *gin.Context) {
+	var request CreatePipelineRequestObject
 
-	var body DecideApprovalJSONRequestBody
+	var body CreatePipelineJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -3792,10 +6730,10 @@ func (sh *strictHandler) DecideApproval(ctx *gin.Context, id ApprovalId) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.DecideApproval(ctx, request.(DecideApprovalRequestObject))
+		return sh.ssi.CreatePipeline(ctx, request.(CreatePipelineRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DecideApproval")
+		handler = middleware(handler, "CreatePipeline")
 	}
 
 	response, err := handler(ctx, request)
@@ -3803,8 +6741,8 @@ func (sh *strictHandler) DecideApproval(ctx *gin.Context, id ApprovalId) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(DecideApprovalResponseObject); ok {
-		if err := validResponse.VisitDecideApprovalResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(CreatePipelineResponseObject); ok {
+		if err := validResponse.VisitCreatePipelineResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3812,15 +6750,17 @@ func (sh *strictHandler) DecideApproval(ctx *gin.Context, id ApprovalId) {
 	}
 }
 
-// GetConfig operation middleware
-func (sh *strictHandler) GetConfig(ctx *gin.Context) {
-	var request GetConfigRequestObject
+// DeletePipeline operation middleware
+func (sh *strictHandler) DeletePipeline(ctx *gin.Context, id PipelineId) {
+	var request DeletePipelineRequestObject
+
+	request.Id = id
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetConfig(ctx, request.(GetConfigRequestObject))
+		return sh.ssi.DeletePipeline(ctx, request.(DeletePipelineRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetConfig")
+		handler = middleware(handler, "DeletePipeline")
 	}
 
 	response, err := handler(ctx, request)
@@ -3828,8 +6768,8 @@ func (sh *strictHandler) GetConfig(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetConfigResponseObject); ok {
-		if err := validResponse.VisitGetConfigResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(DeletePipelineResponseObject); ok {
+		if err := validResponse.VisitDeletePipelineResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3837,11 +6777,40 @@ func (sh *strictHandler) GetConfig(ctx *gin.Context) {
 	}
 }
 
-// UpdateConfig operation middleware
-func (sh *strictHandler) UpdateConfig(ctx *gin.Context) {
-	var request UpdateConfigRequestObject
+// GetPipeline operation middleware
+func (sh *strictHandler) GetPipeline(ctx *gin.Context, id PipelineId) {
+	var request GetPipelineRequestObject
 
-	var body UpdateConfigJSONRequestBody
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPipeline(ctx, request.(GetPipelineRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPipeline")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetPipelineResponseObject); ok {
+		if err := validResponse.VisitGetPipelineResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdatePipeline operation middleware
+func (sh *strictHandler) UpdatePipeline(ctx *gin.Context, id PipelineId) {
+	var request UpdatePipelineRequestObject
+
+	request.Id = id
+
+	var body UpdatePipelineJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -3850,10 +6819,10 @@ func (sh *strictHandler) UpdateConfig(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateConfig(ctx, request.(UpdateConfigRequestObject))
+		return sh.ssi.UpdatePipeline(ctx, request.(UpdatePipelineRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateConfig")
+		handler = middleware(handler, "UpdatePipeline")
 	}
 
 	response, err := handler(ctx, request)
@@ -3861,8 +6830,8 @@ func (sh *strictHandler) UpdateConfig(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(UpdateConfigResponseObject); ok {
-		if err := validResponse.VisitUpdateConfigResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(UpdatePipelineResponseObject); ok {
+		if err := validResponse.VisitUpdatePipelineResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3870,15 +6839,17 @@ func (sh *strictHandler) UpdateConfig(ctx *gin.Context) {
 	}
 }
 
-// GetDebugInfo operation middleware
-func (sh *strictHandler) GetDebugInfo(ctx *gin.Context) {
-	var request GetDebugInfoRequestObject
+// ListPipelineRuns operation middleware
+func (sh *strictHandler) ListPipelineRuns(ctx *gin.Context, id PipelineId) {
+	var request ListPipelineRunsRequestObject
+
+	request.Id = id
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDebugInfo(ctx, request.(GetDebugInfoRequestObject))
+		return sh.ssi.ListPipelineRuns(ctx, request.(ListPipelineRunsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDebugInfo")
+		handler = middleware(handler, "ListPipelineRuns")
 	}
 
 	response, err := handler(ctx, request)
@@ -3886,8 +6857,8 @@ func (sh *strictHandler) GetDebugInfo(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetDebugInfoResponseObject); ok {
-		if err := validResponse.VisitGetDebugInfoResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(ListPipelineRunsResponseObject); ok {
+		if err := validResponse.VisitListPipelineRunsResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3895,11 +6866,13 @@ func (sh *strictHandler) GetDebugInfo(ctx *gin.Context) {
 	}
 }
 
-// CreateDirectory operation middleware
-func (sh *strictHandler) CreateDirectory(ctx *gin.Context) {
-	var request CreateDirectoryRequestObject
+// StartPipelineRun operation middleware
+func (sh *strictHandler) StartPipelineRun(ctx *gin.Context, id PipelineId) {
+	var request StartPipelineRunRequestObject
 
-	var body CreateDirectoryJSONRequestBody
+	request.Id = id
+
+	var body StartPipelineRunJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -3908,10 +6881,10 @@ func (sh *strictHandler) CreateDirectory(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateDirectory(ctx, request.(CreateDirectoryRequestObject))
+		return sh.ssi.StartPipelineRun(ctx, request.(StartPipelineRunRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateDirectory")
+		handler = middleware(handler, "StartPipelineRun")
 	}
 
 	response, err := handler(ctx, request)
@@ -3919,8 +6892,8 @@ func (sh *strictHandler) CreateDirectory(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(CreateDirectoryResponseObject); ok {
-		if err := validResponse.VisitCreateDirectoryResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(StartPipelineRunResponseObject); ok {
+		if err := validResponse.VisitStartPipelineRunResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3928,17 +6901,17 @@ func (sh *strictHandler) CreateDirectory(ctx *gin.Context) {
 	}
 }
 
-// ListFolders operation middleware
-func (sh *strictHandler) ListFolders(ctx *gin.Context, params ListFoldersParams) {
-	var request ListFoldersRequestObject
+// GetRecentPaths operation middleware
+func (sh *strictHandler) GetRecentPaths(ctx *gin.Context, params GetRecentPathsParams) {
+	var request GetRecentPathsRequestObject
 
 	request.Params = params
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.ListFolders(ctx, request.(ListFoldersRequestObject))
+		return sh.ssi.GetRecentPaths(ctx, request.(GetRecentPathsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListFolders")
+		handler = middleware(handler, "GetRecentPaths")
 	}
 
 	response, err := handler(ctx, request)
@@ -3946,8 +6919,8 @@ func (sh *strictHandler) ListFolders(ctx *gin.Context, params ListFoldersParams)
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(ListFoldersResponseObject); ok {
-		if err := validResponse.VisitListFoldersResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetRecentPathsResponseObject); ok {
+		if err := validResponse.VisitGetRecentPathsResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3955,23 +6928,15 @@ func (sh *strictHandler) ListFolders(ctx *gin.Context, params ListFoldersParams)
 	}
 }
 
-// CreateFolder operation middleware
-func (sh *strictHandler) CreateFolder(ctx *gin.Context) {
-	var request CreateFolderRequestObject
-
-	var body CreateFolderJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
-		ctx.Error(err)
-		return
-	}
-	request.Body = &body
+// ListSchedules operation middleware
+func (sh *strictHandler) ListSchedules(ctx *gin.Context) {
+	var request ListSchedulesRequestObject
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateFolder(ctx, request.(CreateFolderRequestObject))
+		return sh.ssi.ListSchedules(ctx, request.(ListSchedulesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateFolder")
+		handler = middleware(handler, "ListSchedules")
 	}
 
 	response, err := handler(ctx, request)
@@ -3979,8 +6944,8 @@ func (sh *strictHandler) CreateFolder(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(CreateFolderResponseObject); ok {
-		if err := validResponse.VisitCreateFolderResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(ListSchedulesResponseObject); ok {
+		if err := validResponse.VisitListSchedulesResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -3988,17 +6953,23 @@ func (sh *strictHandler) CreateFolder(ctx *gin.Context) {
 	}
 }
 
-// GetFolder operation middleware
-func (sh *strictHandler) GetFolder(ctx *gin.Context, id string) {
-	var request GetFolderRequestObject
+// CreateSchedule operation middleware
+func (sh *strictHandler) CreateSchedule(ctx *gin.Context) {
+	var request CreateScheduleRequestObject
 
-	request.Id = id
+	var body CreateScheduleJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetFolder(ctx, request.(GetFolderRequestObject))
+		return sh.ssi.CreateSchedule(ctx, request.(CreateScheduleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetFolder")
+		handler = middleware(handler, "CreateSchedule")
 	}
 
 	response, err := handler(ctx, request)
@@ -4006,8 +6977,8 @@ func (sh *strictHandler) GetFolder(ctx *gin.Context, id string) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetFolderResponseObject); ok {
-		if err := validResponse.VisitGetFolderResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(CreateScheduleResponseObject); ok {
+		if err := validResponse.VisitCreateScheduleResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -4015,25 +6986,17 @@ func (sh *strictHandler) GetFolder(ctx *gin.Context, id string) {
 	}
 }
 
-// UpdateFolder operation middleware
-func (sh *strictHandler) UpdateFolder(ctx *gin.Context, id string) {
-	var request UpdateFolderRequestObject
+// DeleteSchedule operation middleware
+func (sh *strictHandler) DeleteSchedule(ctx *gin.Context, id ScheduleId) {
+	var request DeleteScheduleRequestObject
 
 	request.Id = id
 
-	var body UpdateFolderJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
-		ctx.Error(err)
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateFolder(ctx, request.(UpdateFolderRequestObject))
+		return sh.ssi.DeleteSchedule(ctx, request.(DeleteScheduleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateFolder")
+		handler = middleware(handler, "DeleteSchedule")
 	}
 
 	response, err := handler(ctx, request)
@@ -4041,8 +7004,8 @@ func (sh *strictHandler) UpdateFolder(ctx *gin.Context, id string) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(UpdateFolderResponseObject); ok {
-		if err := validResponse.VisitUpdateFolderResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(DeleteScheduleResponseObject); ok {
+		if err := validResponse.VisitDeleteScheduleResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -4050,23 +7013,17 @@ func (sh *strictHandler) UpdateFolder(ctx *gin.Context, id string) {
 	}
 }
 
-// FuzzySearchFiles operation middleware
-func (sh *strictHandler) FuzzySearchFiles(ctx *gin.Context) {
-	var request FuzzySearchFilesRequestObject
+// GetSchedule operation middleware
+func (sh *strictHandler) GetSchedule(ctx *gin.Context, id ScheduleId) {
+	var request GetScheduleRequestObject
 
-	var body FuzzySearchFilesJSONRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.Status(http.StatusBadRequest)
-		ctx.Error(err)
-		return
-	}
-	request.Body = &body
+	request.Id = id
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.FuzzySearchFiles(ctx, request.(FuzzySearchFilesRequestObject))
+		return sh.ssi.GetSchedule(ctx, request.(GetScheduleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "FuzzySearchFiles")
+		handler = middleware(handler, "GetSchedule")
 	}
 
 	response, err := handler(ctx, request)
@@ -4074,8 +7031,8 @@ func (sh *strictHandler) FuzzySearchFiles(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(FuzzySearchFilesResponseObject); ok {
-		if err := validResponse.VisitFuzzySearchFilesResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetScheduleResponseObject); ok {
+		if err := validResponse.VisitGetScheduleResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -4083,15 +7040,25 @@ func (sh *strictHandler) FuzzySearchFiles(ctx *gin.Context) {
 	}
 }
 
-// GetHealth operation middleware
-func (sh *strictHandler) GetHealth(ctx *gin.Context) {
-	var request GetHealthRequestObject
+// UpdateSchedule operation middleware
+func (sh *strictHandler) UpdateSchedule(ctx *gin.Context, id ScheduleId) {
+	var request UpdateScheduleRequestObject
+
+	request.Id = id
+
+	var body UpdateScheduleJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
+		return sh.ssi.UpdateSchedule(ctx, request.(UpdateScheduleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetHealth")
+		handler = middleware(handler, "UpdateSchedule")
 	}
 
 	response, err := handler(ctx, request)
@@ -4099,8 +7066,8 @@ func (sh *strictHandler) GetHealth(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
-		if err := validResponse.VisitGetHealthResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(UpdateScheduleResponseObject); ok {
+		if err := validResponse.VisitUpdateScheduleResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -4108,17 +7075,18 @@ func (sh *strictHandler) GetHealth(ctx *gin.Context) {
 	}
 }
 
-// GetRecentPaths operation middleware
-func (sh *strictHandler) GetRecentPaths(ctx *gin.Context, params GetRecentPathsParams) {
-	var request GetRecentPathsRequestObject
+// ListScheduleRuns operation middleware
+func (sh *strictHandler) ListScheduleRuns(ctx *gin.Context, id ScheduleId, params ListScheduleRunsParams) {
+	var request ListScheduleRunsRequestObject
 
+	request.Id = id
 	request.Params = params
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetRecentPaths(ctx, request.(GetRecentPathsRequestObject))
+		return sh.ssi.ListScheduleRuns(ctx, request.(ListScheduleRunsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetRecentPaths")
+		handler = middleware(handler, "ListScheduleRuns")
 	}
 
 	response, err := handler(ctx, request)
@@ -4126,8 +7094,8 @@ func (sh *strictHandler) GetRecentPaths(ctx *gin.Context, params GetRecentPathsP
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetRecentPathsResponseObject); ok {
-		if err := validResponse.VisitGetRecentPathsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(ListScheduleRunsResponseObject); ok {
+		if err := validResponse.VisitListScheduleRunsResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -4208,10 +7176,45 @@ func (sh *strictHandler) BulkArchiveSessions(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.BulkArchiveSessions(ctx, request.(BulkArchiveSessionsRequestObject))
+		return sh.ssi.BulkArchiveSessions(ctx, request.(BulkArchiveSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BulkArchiveSessions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(BulkArchiveSessionsResponseObject); ok {
+		if err := validResponse.VisitBulkArchiveSessionsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateSessionFromTemplate operation middleware
+func (sh *strictHandler) CreateSessionFromTemplate(ctx *gin.Context, id TemplateId) {
+	var request CreateSessionFromTemplateRequestObject
+
+	request.Id = id
+
+	var body CreateSessionFromTemplateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateSessionFromTemplate(ctx, request.(CreateSessionFromTemplateRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "BulkArchiveSessions")
+		handler = middleware(handler, "CreateSessionFromTemplate")
 	}
 
 	response, err := handler(ctx, request)
@@ -4219,8 +7222,8 @@ func (sh *strictHandler) BulkArchiveSessions(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(BulkArchiveSessionsResponseObject); ok {
-		if err := validResponse.VisitBulkArchiveSessionsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(CreateSessionFromTemplateResponseObject); ok {
+		if err := validResponse.VisitCreateSessionFromTemplateResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -4418,6 +7421,41 @@ func (sh *strictHandler) ContinueSession(ctx *gin.Context, id SessionId) {
 	}
 }
 
+// ForkSession operation middleware
+func (sh *strictHandler) ForkSession(ctx *gin.Context, id SessionId) {
+	var request ForkSessionRequestObject
+
+	request.Id = id
+
+	var body ForkSessionJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ForkSession(ctx, request.(ForkSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ForkSession")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ForkSessionResponseObject); ok {
+		if err := validResponse.VisitForkSessionResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // HardDeleteEmptyDraftSession operation middleware
 func (sh *strictHandler) HardDeleteEmptyDraftSession(ctx *gin.Context, id SessionId) {
 	var request HardDeleteEmptyDraftSessionRequestObject
@@ -4445,6 +7483,41 @@ func (sh *strictHandler) HardDeleteEmptyDraftSession(ctx *gin.Context, id Sessio
 	}
 }
 
+// ReceiveHookEvent operation middleware
+func (sh *strictHandler) ReceiveHookEvent(ctx *gin.Context, id SessionId) {
+	var request ReceiveHookEventRequestObject
+
+	request.Id = id
+
+	var body ReceiveHookEventJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReceiveHookEvent(ctx, request.(ReceiveHookEventRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReceiveHookEvent")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ReceiveHookEventResponseObject); ok {
+		if err := validResponse.VisitReceiveHookEventResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // InterruptSession operation middleware
 func (sh *strictHandler) InterruptSession(ctx *gin.Context, id SessionId) {
 	var request InterruptSessionRequestObject
@@ -4561,6 +7634,33 @@ func (sh *strictHandler) GetSessionMessages(ctx *gin.Context, id SessionId) {
 	}
 }
 
+// RecoverSession operation middleware
+func (sh *strictHandler) RecoverSession(ctx *gin.Context, id SessionId) {
+	var request RecoverSessionRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RecoverSession(ctx, request.(RecoverSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RecoverSession")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RecoverSessionResponseObject); ok {
+		if err := validResponse.VisitRecoverSessionResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSessionSnapshots operation middleware
 func (sh *strictHandler) GetSessionSnapshots(ctx *gin.Context, id SessionId) {
 	var request GetSessionSnapshotsRequestObject
@@ -4588,6 +7688,96 @@ func (sh *strictHandler) GetSessionSnapshots(ctx *gin.Context, id SessionId) {
 	}
 }
 
+// GetSessionUsage operation middleware
+func (sh *strictHandler) GetSessionUsage(ctx *gin.Context, id SessionId) {
+	var request GetSessionUsageRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSessionUsage(ctx, request.(GetSessionUsageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSessionUsage")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetSessionUsageResponseObject); ok {
+		if err := validResponse.VisitGetSessionUsageResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveSessionWorktree operation middleware
+func (sh *strictHandler) RemoveSessionWorktree(ctx *gin.Context, id SessionId, params RemoveSessionWorktreeParams) {
+	var request RemoveSessionWorktreeRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveSessionWorktree(ctx, request.(RemoveSessionWorktreeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveSessionWorktree")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RemoveSessionWorktreeResponseObject); ok {
+		if err := validResponse.VisitRemoveSessionWorktreeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MergeSessionWorktree operation middleware
+func (sh *strictHandler) MergeSessionWorktree(ctx *gin.Context, id SessionId) {
+	var request MergeSessionWorktreeRequestObject
+
+	request.Id = id
+
+	var body MergeSessionWorktreeJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.MergeSessionWorktree(ctx, request.(MergeSessionWorktreeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MergeSessionWorktree")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(MergeSessionWorktreeResponseObject); ok {
+		if err := validResponse.VisitMergeSessionWorktreeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSlashCommands operation middleware
 func (sh *strictHandler) GetSlashCommands(ctx *gin.Context, params GetSlashCommandsParams) {
 	var request GetSlashCommandsRequestObject
@@ -4615,6 +7805,153 @@ func (sh *strictHandler) GetSlashCommands(ctx *gin.Context, params GetSlashComma
 	}
 }
 
+// ListSessionTemplates operation middleware
+func (sh *strictHandler) ListSessionTemplates(ctx *gin.Context) {
+	var request ListSessionTemplatesRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSessionTemplates(ctx, request.(ListSessionTemplatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSessionTemplates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListSessionTemplatesResponseObject); ok {
+		if err := validResponse.VisitListSessionTemplatesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateSessionTemplate operation middleware
+func (sh *strictHandler) CreateSessionTemplate(ctx *gin.Context) {
+	var request CreateSessionTemplateRequestObject
+
+	var body CreateSessionTemplateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateSessionTemplate(ctx, request.(CreateSessionTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateSessionTemplate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateSessionTemplateResponseObject); ok {
+		if err := validResponse.VisitCreateSessionTemplateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteSessionTemplate operation middleware
+func (sh *strictHandler) DeleteSessionTemplate(ctx *gin.Context, id TemplateId) {
+	var request DeleteSessionTemplateRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSessionTemplate(ctx, request.(DeleteSessionTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSessionTemplate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteSessionTemplateResponseObject); ok {
+		if err := validResponse.VisitDeleteSessionTemplateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSessionTemplate operation middleware
+func (sh *strictHandler) GetSessionTemplate(ctx *gin.Context, id TemplateId) {
+	var request GetSessionTemplateRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSessionTemplate(ctx, request.(GetSessionTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSessionTemplate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetSessionTemplateResponseObject); ok {
+		if err := validResponse.VisitGetSessionTemplateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateSessionTemplate operation middleware
+func (sh *strictHandler) UpdateSessionTemplate(ctx *gin.Context, id TemplateId) {
+	var request UpdateSessionTemplateRequestObject
+
+	request.Id = id
+
+	var body UpdateSessionTemplateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateSessionTemplate(ctx, request.(UpdateSessionTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateSessionTemplate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateSessionTemplateResponseObject); ok {
+		if err := validResponse.VisitUpdateSessionTemplateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListThoughts operation middleware
 func (sh *strictHandler) ListThoughts(ctx *gin.Context, params ListThoughtsParams) {
 	var request ListThoughtsRequestObject
@@ -4763,175 +8100,293 @@ func (sh *strictHandler) ValidateDirectory(ctx *gin.Context) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+R9/XPbOLLgv4LSXdXaVZJlO85k1ldXdUmczPguyeTFmd27W6dUMAlJWJMABwDtaFJ5",
-	"f/srNAASIMEP2bKdfS8/xSI+G41Gf/e3ScLzgjPClJycfpsUWOCcKCLgL1wUgt/g7DzVf6VEJoIWinI2",
-	"OZ28tN/Q+dlkOiFfcV5kZHIKfRZfN3+++Pmvk+mE6qYFVuvJdMJwrhvQdDKdCPJHSQVJJ6dKlGQ6kcma",
-	"5FjPojaFbiWVoGw1+f59OpFESspZbBEX5lNzDbrHAl8lKVkeHT87ef7TTlbyXTeWBWeSAHRe4fQT+aMk",
-	"Uum/Es4UYcqCLaMJ1muc/1PqhX6rF/dtQoTgwnRJ9QS/vjubPTs8mkwnOZESr/Rv76mUlK2QWx1aUpKl",
-	"6C9/lERs/mLAUi30vwuynJxO/tu8Psu5+Srnb/Rkn+yyzSZCEL7CKcyit/F9OjlnigiGszf1Iu+zrxPY",
-	"V0oUphkATQmckAVNNaZcJUfHz/Sk9b7d9EgScUMEMmPucLsdE0wnH7h6y0uW3n/PR4fHwVk6JGVcoSVM",
-	"scP9fCKSlyIh0dEB4i9XdiuF4AURihrsDYZp3qvf4D84Q97PaCl4jv7fy/fv9P+YyrFSREymzXuit850",
-	"h8/kq2oPrX9FiqNSErTkAtnGMrjA/wvrRc80UK+wJLOMJ1jx6GTmLreok+6P9LfOZdezjZnGQLk90d/X",
-	"RK2JQLBgRKWZTg+UIS7QKuNXGoxUkERxsdHzsjKfnP5jAm0m04lpMvkyjZC+mjj9w2w0BG61rLozv/on",
-	"SeAmOwLdPvqE57nFiRhNJ+IvErk2Ppzs5xTdUrVGCS6hWwRYiSBYkXSBI3O81t80OimaE6lwXkymkyUX",
-	"uW48SbEiM/0lNiyNvAC/M/pHSZB7qRBNNXyWtHHE8CpZghMZ2dD1tGPJ7v4NL5mVWYav9IzmMWlPVLJF",
-	"bBsvpeQJ1UBDomy9Z7pX9aS2UdPQl6FxZc9bmZKleSXbgyusSjlEphyuXZjW36cTxXm2oKwoDRVNU2oo",
-	"ykcPEw2MGuSB8wxBP+TxIlOf5mrUxJpQT0SOZmKJ5iov5so+YK17ACuJUwmYzD5++rV1WBQAiHwlSanI",
-	"wk07dE8NV2HOOTicCpjBBfEXGICt705XL0KbrGOFx55Wa+nQuW/eiwobGpe6FELTP7NBxJdIrUkATkv0",
-	"CsJSDbSp5S1JCuwBoySNUMB6Yjm8Y6pILsdvvZoMC4E340HxqsyuX4pkTW+Ix/2FS8Lme+Q+fhYl0a+f",
-	"bTFFS5xJ+KVk9rcawa44zwhm4R2XnVyw9Aae+8NVuPwPc9sNEYT/6lv/ZVrDrv2WU3ZuPh4NQMxf4rQG",
-	"wSAMh841/HWJaUbShZ2sFxhrrJBpDvAtNKGOQENT1V4QhLueTmSZJETKgBMMyH11bk0I2Y5tkGyDfO/5",
-	"DXGb7MTAJc9SIqJPwmcsVkTziroFOj9De/rV0iDK+Q2gouBczUu21KDbD2ihHbZ6SAffuzF4i87PpJv+",
-	"SbB1HKQfCU87oPCvhqWfiFRckDOBl6obTXvRA/o6tgUwRJhBDY+dUplgkWrOxj2rPw7qNLb/SLhj4fMv",
-	"jj6vOVvSVTfQkgyXKVngG0wt8ekSyl5DSy2VVY0RVsCbJDBJKUiKrFKo/ejaiVKiSKK5NWjYZrFLxXOs",
-	"aIKzbINcYze37oP2crxBKV0uiTC4W8++H5WfzMTx+SyvlW38PXizDTKo/ujTNjQ7jkRRVjpq2M34ZBm/",
-	"JelCs7ERvH1pPiP4jDIqNfs7HidxodnHhdxIRfJFIXhexIVYwuA6mIbINozBuZSK5wvKpBJlouKX7TU0",
-	"QkGjyFgplQO7P6ta3BUAOf66UKWIrfI9/qrx4YYIacVraAd0jeaa+a7JGmWKrAhovfKkWBg0GuKc37/+",
-	"aC6m7lYQkVNDBQ10Yc+RVb3+CHsFTU/dKQpAUG22h/hAbhF80ieaWDwEDUTAmXzgtwinqdHnoTVmaaYl",
-	"OsXhtpsBo/JtPzL9dkOEoCkZwqXGFTN7GXWTtnsa7G0NRX5Pk1V/XiRrmqWxLRdY04/OMaCzadOlLSnb",
-	"vfRvMGOXHqFvNugYnazz6fVl7DZQYpu814NU3as3N1FtqhN1h5QwOLCaDLLP1bCyQ/CurDBW8tb3DC6c",
-	"fo3kSMEb9F88s9La4KK2wMEOBPL06w16YZTmyDUY1C2OUxwSfWgL83NLHNoUBPFlSDyhgwc9p8y3ChoN",
-	"XPd/QWSZgSoWKIT+eU3ZtZ75S6cOs4LW0fGzE0+XSJn66WQSI9RULjRRzohyaoUl1vOeggJh2sEAVaiA",
-	"1lgiQRKiZXJUrbnN89h7A1srJYni80doYwYvJdEypMY7RqRGcYd5bbLBY7yaO3L9Fe0Zi4D5BQ5B7nvH",
-	"UEpQ62IpqVSYeVD/EiU5f5SExZT2F/YLYmV+RQSiLDh+/2F5HjuMXmLWrWU2yr20Qw9J2Q03hiYQyqub",
-	"XIOhY0BWlGrhbFPhwP/74rcPyLQHpVytXK3GB2QenKRHfwpGli2HMwi46KQDVjGrG/XRAn+sJRfdsIVF",
-	"nZ8htabSjUuBWo5T54ZaXIdXAWEJKNPQK7IjbWb7YbqzWhPMMqTWL3cw+F32i09gtDDPT0PzO9KKsWuD",
-	"wTZ2gA8aha3SWj2ETaBiVbbQ9TdPZDtGsZchMUM3uZGGtYyR2zEsmT/RPVgsWNFbUC92Il/88EwnlFJZ",
-	"ZHiDLHDrzbwVQEBS9FFwPR0IRfjrO8JWWrw+OjwEIan6u5tj7nkLa22qewk17uzl+Ct6hjJyQzIZ1aSa",
-	"kQ3PPMB4xczB3aAclNSrC7ZwxumYY8DkZdUOee2cviHBDGGj7wl0Tv8+P1iXOWYZ3hAxz/hKf5/fYPj/",
-	"PN/gothOHTUgWv99TRXR4rS+xYGQHa5LEJwuljTTGHIrqCLmjy+710I4Nwc8XhuBS8UXGpqFWpCUKjnM",
-	"571hRqdVKj4zPQH/dO9q+xG9FmDImfNJOF9+4OrNVyrHzGiwCyjlLReaza2dGxBdIqpQyokEdxTy1Sg4",
-	"Iiu4o+IFdmdwL6qDwWxFBC9ltlnIa1osfJXD4Nbe4ZIl68pODk4O3ohIj+grMRAB6KfRHfYtZaHlFF6q",
-	"YEl/PdT/pt2OONAO2a6aZc1pllFJEs5SA5i+xU4iUkYHwfEY3WGl1qsMJ9fu5qUNDVd4+ZqP5la3LhV4",
-	"qUajpztDylBqrAhK/6yPVAMvg5PWuNvEJe8E+5VtNC/zuMKtlu0OH0j7lvOUxJRt+mfftQo0XwYSnhDF",
-	"C7CVSM4Y0bdzjel1GRWg7qnls6QuKgsWgn/dLHBBF9ckovR7+fEcXZONGVA31SRuTZiyrnjdQ15hSRal",
-	"iKzyFZYE/f7pnTeoJOKGJiG7sFaqkKfzOS8IE7xURBxgOscFnd8cdU/rSMFYim3m1+NrLDSHRaV3WhHJ",
-	"HCaCs19wq5bsQoLaC8rbrZ0t2K3eJabzVaFmJ1soZc8ZVRRnVjEbEOV67F9JVqCcIHhoEUYfN2rNmdXF",
-	"ajwtBNesA3p98Tek32H5gAra6URRFdM/VBQWvsfuTbUhvc6PZs361C46lco3RFxxSUZjg22PeKm0NBA7",
-	"ffvYamYtwv60XuK+bczXPCfzUhIxLwxLfB99dshtbiekdEmTTj7pcIVj5HaUljk+aJ8f3EiZJ6aGvrvs",
-	"c0auytU5W/I+kyetns32xt6dI/vRNwlqFNCU2Tg6h/6162wT9XLNsFSaxGjSEZnpHZYKmc9J7cTpJGe9",
-	"QU1+kWWw6+mOD49PZodHs6Pnn48OT58dnh4e/v/RXp9xK+hHrNbOunPxb+80heme38N4Xy5JMck5O0iv",
-	"oqhE/4xpDumf8f1qVuNqo0iDAzj5+fmLn0YpeDWHIrtVH9/GjNGwN7r16aGpVDRpOFI60V1OTo+eW2WW",
-	"nJweP3tR3SQ5OT05jnpVasK1SHgZU999MGpVDSfdTGrg+BAbULA2Lo41VMOBhBM7qE2DCxK/YwlNh9Vb",
-	"nZ7R1SthW6C9OjJDc96EbUL5/h3n1xJJvCTVS0ei1riUJFRGnfAr207VpGbirBHH2HA2w87j1RBjgLMd",
-	"Ea9CIBpPGxhinS6fLq2bSvSqPZ2vSSUEu/CP7t337hPCP/zzr57iBeNqYQIzoqESNkqkOeyvmkzNBMEp",
-	"cAjEh2YwUVsKD+Vv5BE/Rm5nnU9+F6X9rC9uNTj4s4DbUUvMj9LbgSntIUkXFRBjNVP92BDr7FSvJHGB",
-	"BKDSt2c93RKDzKFOPQOfpTathcWwB87+DIKbYrQkxp/X6IL2yMHqYIpMyNBRSD7qOKIIwaiCqcZryT01",
-	"HrErYMqEkLR2dX+cbEc8DfokmfvjBusE9ojrORhOZQ8sjgrRmeM2f0cOx58CDDSTBUk0BwXPYdRSUYWZ",
-	"tNHnBiyvW4fOONt3L3D02J91wyZorIHLn7aTotajdJrarajWNLIzcrvwrC2VYaFyTqj5e+PtsEjWmK3g",
-	"g6+JWRhX76A9UVr0rXvE1B1vaUbeY5WsI0dtjAsfowTyE8mwojfW1w/YAWuL2BPuk+JoSYVUSBIskrVp",
-	"SpfIhhdeZSS8/1Ikc3BiIkLOl+Wff24uoOPBikcjo2T1kHXEHNCl0TBQiXBNRF38gV60k8CrRViJNaYZ",
-	"U8mapOcsJV9jNoPXayxwoohABZfUKG75EtluVmmQuEahlvD42fTZ0fTZT9NnL6bPfp4++2tES+hxvE01",
-	"YYeL5pXkWansCSleLQU4d713sMaEb9jvUsM+JTdOSp5veSgy4SKmodFzoz9KnFG1QdAI7a3pak2EPp0r",
-	"ohQRATb8PJpH9vHULaB1XiG6xO6wvgkXDBdyzaNMcofVXndz5nqEFZJ2CNRFle7iy6OPbDEsE/bJgO48",
-	"c0zZQbG5l6sGMCWJUy04mPkTV640YzQLbl5/n7W/1KCPgbGFDgUkjfEcsgZNTS5c325T0nYn2BPG6WaN",
-	"B3E2o09GxgL/VzEQTyeO3AbH3LIpXXChEBd6ObdUrSlDZhK0dzijQCN8r/SI29Og1F/FbIDcD442Tfoa",
-	"9auyD/cW2BRz1rEHHMRYeiN335v7RVXauzda9DTtdxXY6Ga/q//P2/o502S8J7JMf/2NZZthWvKJqFIw",
-	"xFm2MdzFFJGvSVamxPcliNKVjOY0NPcdt/DYWeFYhXfGs8uGCum5zU22FrjDw0GDnKa3Uc99X/yF8S0f",
-	"Zy6Q78Tfx0FENQD4q4s7OuyNQuo0xsDReYylIiJU+BueBZqFpO34+U+DpE0QLa+oX6iiK1YxNPZQYkLO",
-	"W5pp1o+Xyhz63Fx+aZguTfUOVm4wt9wYEkRNEO6IxqFw183KicJjrrQZ7L1rbaChMayDq7McZb1lyYXm",
-	"C642SJCM3GDjNTjuQlfSyNCddmua1vuKgedXgjO17iE3pCAsJSyxf8cCDyKcxegorCvKsNgEwVjRqz9W",
-	"n1gHdzGugjGH38le9rGx3uV2Y2sxNKrICoe1zZwS6HJydHB4cHR0eDnZ32KWxVhguemSNUmua1Xsdi5n",
-	"fTFiMRtBHbRQ+R5cg8Z6JXBqhHDPEn096Ydm3fTw4OjgcNhI56JC3RixSwHpgERZqDtaMO/oCd6GDHUL",
-	"sYED9VDBl4fQnseTVNxdp177qrQJb1JcWHNkj6VrwBHGjNC2d73HhWE+IbETuKWbkDGwiLY8+y0rY+IH",
-	"QEZaSb2vGWg4Z5pr0durs43kSTEzg8+8nhHM/x4Hil13RDhbxZQo1qKLxarMNQiMj71UKeXOprsfOlj5",
-	"K596Au92nlbddma7IsWRdeUaWlIHyGIROuymDyMiSvDQjeKGCs7AMHeDBTVGx4HFfZucvXn1+y9aQhcl",
-	"iaaOWROcDuDqwMp+/fz5I7LDaMBRZvhfWBt8jC/t/84sQZqdn1lyov+w+dLaGuOovtUgHNIf0d5aqQI1",
-	"Z50inlPjMwqA2m+5QsUOK+peBcMSlhacMgV+Vv17hNFP53NIg7XmUp2+ePHihXW0mudJESXwrZ1/Iglh",
-	"yilmw4sF3gyl7PRkAOcFkE9BL3iLJYLW9/NMCIWFAR2UtM7QMSjrt3uEhZ3mpFp37XkwWmVYAymc8ksv",
-	"sHcltnrHd2fRtcGltxdkif/7aCYFkJpck6ZbbQjSn2Iio4Z/+lupuvXuTlTEEikicspAV5iaREDOFXiM",
-	"3l1xhTMjaMiYZlLhzGq2pTG7oSuy1FIWLopsoyUvI1Z7c50cR/ekh7pIMGPRHEYwUS11N0Qe2y2A3Mmz",
-	"F4P4GEza2OzUP0QP5nF0kI5l/NcOedhaZ1t5PQ8obe8QaOCmqCMLEBZB4EGXghgna7JwtnobrKj4NYn5",
-	"VddUDbp5Jn6I77LdAgerwzFu7GYREPyx3QJ0l87JnxuH/RHTxwKmY8LhXySidX7SqJviqOhqGyccTWbo",
-	"bPO21ahMjMMh4cabYOGp7ULOET6jW8pSfmuoUOWiarzZ/UP96eexgOXwdnXSKP1dk/TfLwIgHh4cPvd2",
-	"usw46Ig75jOEbiitZQXWu6e3vF/0yt/XhCFYOMKQzsslAaguap2kBvuJPHmpNPsALiEyCLQdG85CvhZU",
-	"EBmFy/nFbzUo0K1eZG9MjcYGZAdEe9y63e3fGTPdu7HIexKQjXn+T56PREpNIrkAFwXSEZF9lfErTWRM",
-	"UxucAo4DQcqr4OJ/u3RmwMvJKfxf8owcZHy1d3l5OVmTLOP6P/v/43IyvZwkpZBcfLQGocvJ6fHJ9zHw",
-	"IsslSRS9IQt3p7topbli5isCntEkXLnFInXuRcGND2jn0UjSDQquRadLUkvR5chmt7dhT2o8ayn0QzHQ",
-	"Fck4W2nh7X6Z8HqsntW7HTd7xrKZt4cf+a71vKSjzgPEBawxhKpN9MaDaOVa3IEM9kY3aUElFi7jQcvF",
-	"NcUHjp97mWXmHeo6A/PsznhRytnJ7Gh2fHj8/PDnw+exeUwUx4izMA3jnMWYs4gm8olan2tmInQEWnJx",
-	"XYdEtLGuNw3Q6IAr6y9fx1w1gPvAIVeOdzXz0ypuc/dhVzb2DiJHqx13xVtxKWdHx4dXdw67AtcTqTCY",
-	"mLqCfVwQliBLrCUZs2Hrbjk6PbUlVKLsJFIDKapHZZG2z3GdRFqWeY5jgHh5PlsRRoTxujGtHJrFoPDJ",
-	"7p6kjUBCfevLjGwRL/a7JGKmH27wgXUXyzT2p3y/Qed5wYXCTKHPWEbNG08b1dXIVO3sJc7SGjhQtOh+",
-	"j+h9Pz8KJ79vof8xaANqlh3ppapF3F0p5ePyyITZ7WBdE/YMhyOsAUiUjJn/1QmhppOK32mYi6o/4eMt",
-	"pvp3m3UE/AVNDtWoQ+xw7ltg+2SfkKm/o6sNSrAiK1MAYWyu7PqhdG18zjgS/VSHiMeHaXHX7TGYZlGy",
-	"vkFMC7THOJu5dU2R/guG3+8bP6bAfmS0zLBcv64tPGMSrDi7D1jUjAFDkyWph0KFIEv6NaREhnAsigyz",
-	"bUppXJhCJvYuuIjGmS2msSdIwfe9mhp7ILRqqrffW1WjXpj7NqrORk9lDR+Iu1KEBwdz9+O1Dqg78yrz",
-	"HYHvvKrPa16u1lt5EgNDjsV1ym9Z5VK8B55klKEVUXZM5Coy7Xf5CjucDqJRn8+OjmfHJzP740EelxS9",
-	"ejEDgLLLeev16DQQhfEC1gVTmQHkPGTC11iQdC6IsSHMRy99TLyHXXM04sMahyoA2hF7TvdtCKwW0pGo",
-	"0ttYn2y8RqyB27oZ9Y6MZXuJXqESbKzv4xXzihc0iZcqGwGcLo7gIuAELDqglCdlHobJOJaAQlaOlTCW",
-	"CMcLRB9yu4r78WXuFm977ftjgXo26o5eswz2KaHJtc1ZwlK+XE6mE65Fu75N74oUVtu/KxX8HbDcJTfv",
-	"CkDuy/wdd1DbI3mhNi7LoxYRwCpjEpFTzkKr+7yUwtjc51eUzROXtmPYE6xjQwM527rZu5fNSiUmbsOo",
-	"4PYSLBOcEutraziq/aiA3pHSj9y6sVoO/2bhD+Tvrycumj7/e1wgqLKhj0dwrva3d+dvTiK5UFWAVejJ",
-	"P5L1NHDYVaY4MxrCP6L1dBjv+lRAHfbSdiKXeUrlXRKyDVt+IkljTJwh/HfQonLnFGVRs4mXMuduycjc",
-	"mu6QkeyHtq5sY2t4z2889REHZawhfa4cjyBQkAcKRphv9yzGMyZv2B4vSjlFRos+1UhmnheT5QLUrPuP",
-	"p29/Nns+MxPMjg+PT44Oj48fJpuYt5/rGRezg4ODHzvH2F1yig24Bz9QijHM1FpolnnuDvXAHeoOVcVd",
-	"2lrzKHWraR0v8BnLa/QBx01SvWpaO0U8I2avxtbEB/2Tr9mgO2D3860HubDh7T1vOMSepFpiuKHOp3Xo",
-	"WXG9kOuFjD0+/ojxQi0oWyiSkZyomNb+t0LNKNMz8ELN9MPBl/pZgGeAJQRh5gp4CFJwEXq8+27sbVh4",
-	"ULjX9jv3jDJ6TdBvBWGf4MbuLBx2NNxsdsUtobWDqMoI+LYLrwxx9D5CaHDOo0Wvv+GMpn7K286LMsal",
-	"Vz+VN3bEO6W9ielaRi67UxGPmclv1x2GpYI8PpoRvyJV5LpRrUmiEF2ahD5gIQZ76v69wrQAYhZc/Y4Z",
-	"xEtBPLwB2zq6tK8FZilJP3bmM3ItahUc+nfk5Rm5Syqj3hwc/h5M7o8gD0cX/PVDvT8cG1nBIth5JIIH",
-	"HEWW3OlecaJqtb9J7/NOC2Dooiw0RZlYX/+KYalltIOU3LTDHT69ufiMNLsFrv/1eCbTHtIYC1ggp5a+",
-	"giHBPs45ZnhFcsLU9JJVqen1m7rM+K2cAsETBGdAtUz6GCSVIDjXwyS4wFc0o/pKHFwadZHKGhs7Mwtx",
-	"6/Siw04hAu/QUGTCcEEnp5NnNtKsigueQ51vqQW9hLtoHi5VtNoXtJC2NHhKlpTZZCjA0h8YdsiO2IiI",
-	"riB1nnpjQVVzabNTEale8XQzol59XWo+JBqWXTmLcTXOghdnaYxJxm7MLm4zSOi8+eK4WTfWiF/V5ZZm",
-	"uceHh/fYrAHz+ELBqzHlNOyg8d00AGqi7ZYlVAm0MCMpskN8n05OzP5iq6rgMH+FU/d4fZ9Ono/pcm5d",
-	"ioE0wxYqv4oKs/wCiQ7JjGb8HxOLdV90z3nFzS+A459/q72PvkPgjqH8Gr7QvE4x+W2yIjF3MSpVXYjC",
-	"IrY0NNm5f9ZefRBbbhid8IroYapi0ab+l6sFcvqPb/EQ9atN6GVN9Tfn/WCJom1wDh4SFWo18fzLPVF1",
-	"TMnqmnOKYNc7V/ugAsEusCN+Nj5qVNN9MfrKroL/BGHEyG1rMKAm8KogQW4ouW0dbFgG5R60r7eQTrT6",
-	"zSiadPRgi+g+7SozqGXfnop6uKNtHGoHggT0YP6Npt87icIvRD+YyhS21RyLlln0PcVXWmzEqMqhF5k7",
-	"xJ9fiPKQp0EWYluvm1SrPU8nj3LFR525y/8IZ34yfIAus+lOTlwfDG6uZOxxz1NINNvNM5nuRgdB2AZh",
-	"Nny+YfLa+x/x7olLPPfwAzA82yyiG9HObKpgJEjCwTespi47WUqYyDOygnMG8mKV91jjQ4UHOBMEpxtk",
-	"cCl9mmtgoIk424b21ZU+ojTvE1GCkhuCEusbaIWmIIGB538VWoGtw0aL9tlMDA+IWY1y3ZHzfB3sQNh9",
-	"pkh6LPHOqFMMat6hVMqjL8Y/J1l3anRFyUDQjJ6DLJM1wnLMKfiG/wfiX2K+BY9MYLZFA6sybCHBU/Ax",
-	"9sDHo46+zim5Klczp07pYWOuylWEhzH58GHC+k6nfsp+aRQeFgubq2rd9KqMxORBn5FmrYroC9Lccted",
-	"b9/eZlcf/rbUq4F+6IowIHrU2gtIqcI2iBG9DHNnDbXt0b+8Dgum7UwBMz7hOres/l2VyQ+tXXGCyEjl",
-	"7S2WlewSr0LcBRinsE6bWTYGDWYRPA1zyT/Ei9TGQA+hIUudxWebPqGPOygFc5k0kRLExhnJwNU+qhex",
-	"mSaHtCLnNjdN5W3vUjpQVrnZdmhJbFqbl3XWgRpRmsFZLV32Q0pWzSSbPaoTdwK7U5yAv5cDfXXo9peR",
-	"ChN73ksuEBcrzOifnspchglkXVrZ/Q4C9tZlYX04FUroDfjICpRGFtfIWdsA367r/qhSzt9qm5ix4tpU",
-	"gPpEU1KoNSJfE0JSkk4RtRKRvm77uyVMNZJFkdSjTbtS2VSztViYCkH7tbfOrbLXGwrIlH0bHJVKJ010",
-	"fCql7mhUfXJtzzJcRwch6xWk7BA1x3CAzEsB9bXdZ9/jV5POisZZ11aqDjpEqx8QbR5KxLsDfX0CpB2Q",
-	"7X40+rr/NLcrvB17AiJtpijnN2TqmLD9bqJc/vnnZmbjgkztjk456KPxGpIIOtWJmI1FzXgSuUsKCa7t",
-	"lbMk22O3jW3dMaMu2VgkzbFhTqsiHzPgUNCartYZXa3BQ9F7JA4u2SWEDZNEST8/9NXGeZ0eIJurzUW6",
-	"VKt8jpzfMbhCwdIuWYEFxMq7nOAmKN86LANCGCeBkKA0c0g/ELPUlW39sS90V8bsmP06hP6PobgJUp9X",
-	"RWw8fJYd4tYakmF3MjOvIU0yXQZaGols6DWMb0bYxNgYk2n7IdUwjVze0eMCt2O9arfSEHRmCJMQukvJ",
-	"IiA746zyfunXW5vW2cYkaWk6jlBi4gL+KGlyXceAtIDn5ZgcetHbBQCq9PxV+v+YtOqyEUVk1OPDsGJA",
-	"f8GAB2URY8k2Iwdtmpmd74zpM0cZO8NAH2oDKQyy1BVDez09mpxd7eRROXccoFcV2XcE3VSRyAiuq4tc",
-	"sr1wJMZRsqZZKgjb18+F0u1vTLWK/2kKXSmOViRcRewZ0Eu9qONEerHQr3IRrA/1LK8LM6v1xtGzK693",
-	"h4NLNf/VBtkabrFZDeAbM/rDzWzWg1PUkfXAO5NZpT86bedtACjpNtDrtBGRY79CJjueU6X0HO78X757",
-	"50GW8Rpd9i/9jBk2g4MXAeYyQ8SKpI4AXBXFd4DeNCMtgwOGihfQ+KAL0FU40FPJna2UHj0KsepC70wj",
-	"5qfGaBORIX0YS022I6u0sJa31zz1wytieq+L6uvDKb4aUZRP4jrUTL8TZQu8NJW7YeJOjo93Z17qLMfb",
-	"q75vVLyFZCWEpMAJ1E7uu8FjXLJkbVHQCxrtfxPnlhj1uL7YWFQuUB2YmpeZokWdZgpU8RhJylYZqb2p",
-	"W2j/qsyu7YDeK/YQyO/N9EQyTLCCbmTRzWqI1WKMRorjwxePvZyPVjp1NTieSH4CqOBWQHQ/nQ4QO+d9",
-	"WA1Brm0shnDXWrnQoRDWi9MDPAIK+9M8IR6Hyxik4hJ0RW0ivmt8HrusBlKjGZI8947dhP5A/VWNNU+E",
-	"837gtfQjr0dguyBS2RpocYT/ZBrUOF9lWGvyulc4udaz259d8r32FbBDnul2D3kHgnme8BI01tHjEZtl",
-	"BnoS2XN5+KswenE/CIEfjY8jkN9m4+kS7y9qLVyF5KVmVdDFv71D787/zxvIW0qJRDgRXEoTjT11+TtN",
-	"fJdJbbqkJEu1ZK5F4UoEvLTC3eWkKWhDBThPLFVmd/a/bsvTUENQ67EVLzwhVKQQmHO1Qc2kl0jvmLCU",
-	"stXBJXtHc6oMPTs+RDmXqlaB5Tw1b1tN/MLo3ZjWwUBwrN7BwtsCjItarY9XmDKobxPCF7RvNXghg56s",
-	"TqdLJ+H+rC9JM1nOoFQ9rlLnlqq6I19V9/wpNXXxBKTdOnRXsPGJaIJdxRY3f0eG/y4p/ReiahF9O/f9",
-	"OjzrMU54jGT95AZ72VhIl66l12TvBpHW57Xyd/YzG0GtBi48NR9wMY5sewy+oTe3NMu0PG7NwzESGKSk",
-	"ujc2PJQV/i7KnidBxh/CEO/COWyuYyUws0nLuPAzA5iMAk9pim9i/UjSONdwpKwkIzyRPbWRSeDu+tqo",
-	"cMyMEsvz8pteMsrWRFBjNVdS97khQhqwralm8Tax2/Tajv3j3qfGCp9KfdpcRTcyf/DOL4i+fGyUdWvW",
-	"l2jJxbV+aEerIwFr11iks5RkRJEZJPkyaAtZRGMuJDlmhrk1bRC2IoTJGGSliipSWyOzsZ7bkAa6RFTZ",
-	"pBbZxqQVO7hkL/2yZQlnkhr226QdM53WWGrWPSeYUbZallmdEphxx+YybrjbaWU6hERW8MFPBbcfuym/",
-	"YpGewbbAxgPy3YPwJCeRlCmw00Acg2R5IbgfPwzvoj4X0K3DMrkw5bbNeud1AvknuQQxrGR2pQFAx96J",
-	"Kl1+Nym/IBBCgqqmSNIVZEPkCFcuIo52owQbIRgSQF4yp3hGK4ETAg9yDB+bdal/VMa4s352Hz7VJQme",
-	"ljFxC9IITVl9dJBb8knwuQJnG5PGYnAGBqo+Un4Wku+AGzGUVqErQhgyQ5EUbUgs7lqP8qiE8ixYryWL",
-	"PwYKWRpJmafPJU8Vmxw73u1M7pWVMxhj6vHulqTBM28aKd64QS2nGhh0pxiziyC8JAzuO19+4OqNl4qs",
-	"r7SUZevbSZIM35JyItlfrFm6q7pUXqjuSk/mu0mCbUqvv3YZuwfiAM3AjxEJuCNZtaI2/8ku9H9R/4g7",
-	"sV9e9qgBZ1PIFV9mWVQWNlnwa7JVBVhfMjfD1KuQaiwP8LdVzcZYslpL+d6t8gdlyl57IBkIyK9BV4H+",
-	"yRSXSXQ5IzFHuso3w6gDIQ9Ve5TgQpUCKrKDX5+XrneK5JrfAt7Ar5BJ3FU8R1jVqu2CU6bAPUfRnPSj",
-	"T1Wk54fVdreqCMVCfQIoPmF8WriObnTJsFzPbFWpEVhiylu59l5+PCjWQzztduv1j569XzNqyLTXrvwH",
-	"DEBlX03qcWJGMz9d9TaRa21vWD+8wk0yzkb4qE6t0XpcfZ6twdk+lR0OcmlVaNVYUzceQ8LTOWQ/dVkW",
-	"XfEoD6vb/uyuHs745IiuNk+P67j9FDHPTnCWdRfz0avQDcb4ZENSfS9zMWcKU2ZIdV00q+cinG15Dx4S",
-	"V1tViXrQ1MHfRPftzAs7GBbtuZOZIjiYKSIqOfDjDivEMQhYAd0YGDtx7peqSNtwBEVPQTQ/SbIJ4GwW",
-	"RdP7OMjTLv8FG8x7DzL4nxEB+/Dvs4cgTtN+V+fwJ+EJVGwDnQhdSiJm0ise0M8YQNnJQpAlEYQlNtrQ",
-	"sxi2bkGQs/4BTzaaZT9yvLpdbdh/4HRspT/Z3fKwbQfwdlWMB825Fiu/8cg6lrHn7tr8iKnXRqDJdygI",
-	"ZioizFI/1X6Hyd3F8OJW1QDAoFubmYqqRjGEFkq16jA8EEZ1lql4ZITqrjvRq2XyXDmMGmUnCOIW0zxE",
-	"TQpiwd26MxE3cU7jHeSttwHdpllQ4+B0bmojrrlUpy9evHjhijLpJ9lO1bIHQsS0jbJ2UWqqlIiw1KgF",
-	"an7ARle3WYxKCUqXJNkkGfGqIXjd64i8juwmNkeULbXhueLWg7yt8lw1x4A6CTPKZmpNZhnnBWpXYajH",
-	"eemlGm8/lh1VGurub25s3vt4eSlTT6oCodHoZYAmhlX0StHYET/qLpNoTCdB0pySlWVNOesbunLxUw42",
-	"ltNuRWeFlQ6gf+yAXq46NvXJMqtVnVNDg2heZDCEOTAnGdnRKj7l+5fv/xEAAP//b361e6njAAA=",
+	"H4sIAAAAAAAC/+y9e3PcOJI4+FUQdRdhe4NSyY+entHGRazbj2nd2d1ey56+u6mOCohEVWHFAjgAKLna",
+	"5/3sF5kASJAEHyWVLM3Or/9pq4hHIpFIJPL5dZbKbSEFE0bPTr/OCqrolhmm8C9aFEpe0fwsg78yplPF",
+	"C8OlmJ3OXrpv5Oz1LJmxL3Rb5Gx2in2WX3Z//Pjnv8ySGYemBTWbWTITdAsNeDZLZor9o+SKZbNTo0qW",
+	"zHS6YVsKs5hdAa20UVysZ9++JbOCFyzngsWg+OC+taGAPkt6kT599vzFDweG42MpBkFRpeiAo0pxaHDg",
+	"W1bmUbScu29tOLDPwQFhWnMZxcm5/dQBg2kNUGRshYD86UCQGLYtcmrYECi+TRsmsy3yw2LmGzTWhRSa",
+	"4Wn6iWYf2T9Kpg38lUphmDDumOU8pQDo/L80QPu1BuzrjCklle2SwQQ/v3t99Pzk6SyZbZnWdA2/veda",
+	"c7EmHjqy4izPyKN/lEztHlX0YgH93xVbzU5n/9u8Pvtz+1XP38BkHx3YdhFNPP5EM6LcMr4lszNhmBI0",
+	"f1MDeZt1vcB1ZcxQniPSjKIpW/JsdjqzmzP7Fq7bT080U1dMETvmAZfbM0Ey+0Wat7IU2e3X/PTkWWMv",
+	"PaUKacgKpzjgej4yLUuVsujoiPGXa7eUQsmCKcMt9TaGaf05+xX/QXMS/ExWSm7J//Py/Tv4lzBbagxT",
+	"s6R9TmDpAjp8Yl9Md2j4lRhJSs3ISiriGuvG4f0PCkAfAVIvqGZHuUypkdHJ7Fnu3GbQn8C3XrDr2aZM",
+	"Y7Hcnei3DTMbpggCTLi208FAOZGKrHN5AWjkiqVGqh3MK8rt7PTvM2wzS2a2yez3JMIAa+b0d7vQJnIr",
+	"sOrO8uK/WIonGVHwmq244H6T96CA3zZMkFc5LTNG9EaWeUYylrM1cFojidlwbdfcwORHdsXZtSaAUdzd",
+	"i3KtoyQiM5Z3Z30PPxN5xZTibgSzYZGJNpRflrGBCyW3RYTsznfasC2xnzsDd4YxUuY6Qrzwc92TbOkO",
+	"CDkhNM8J9iF8ReSWG8OyWTLjhm115C6pZqRK0V1nq8NJqyVF99gJbd3NTeV26859TM5j6pEmvk2IWPc5",
+	"I9fcbEhKSwdEZwGpYtSA8BGZ4xV8w8uZb5k2dFvMktlKqi00nmXUsCP4EhuWR676z4L/o2TES6+EZ3AG",
+	"Vrx1jFFSdZdKZGR7d2c9IHseOw6yKPOcXuTMCwzdiUqxjC3jpdYy5YC0mEgJvSoxuzOmk8nGxtUD8lnG",
+	"VlYy6w5uqCn12FXkae3ctnaHZMlFUdqbMsu4vTU+BJRocdQ9RQT7keB9koT3KpAmhct4prbkSK3I3GyL",
+	"uXFCSuccICTxmwAncwIOSFSeihoIYl9YWhq29NOO8WIrOdp9bmxOhczGAQkBbKBt6ExXt36XcVNDp+5W",
+	"l7dA56F5zytqaB3qUingeXaBRK4sHwzQ6S62gokMkJa49yZywowJzrLILVdPrMdXXPHTaUsfY7N9qPip",
+	"zC9fqnTDr1gg4TdBovZ75Dx+UiXeka5FQlY01/hLKdxvNYFdSJkzKiyD2sortryW6tIoxnruZDsCF2u4",
+	"dLQkthfuhaND/YisuSF+HJ2QS8YKLtYLYTaMK3KhqEg3TB+Tl74Pud5Izao+JKXikSEXzA2fgWQjpFn4",
+	"BWSECvxRsUIqYD1UkxXlOcuOFyK6vPqU6N7XnA7wNg+xVR3Vv1tmZnk8/hOY2u9DV+2WizP78ekIQYQg",
+	"JvUOj5LIGNk2f7VoWvrdGkLGhhqHVSSfAu6hCDbg0vh9urSRzHSZpkzrxmOmcZtV+9bGkOvYRck+Z+u9",
+	"vGJ+kb0HbCXzjKnojfeJqjUzxLYgZ6/JY7iUAUX2LEiipDTzUqwAdU8arN4NW8kJo9f5FLolZ6+1n/5e",
+	"qHUapr8TnfZg4Z+NSj8ybaRirxVdmX4yHSQP7FvxZSRMO6h9JmZcp1RlLCOV1PBwSKe1/O9EOw4//+Tk",
+	"80qKFV/3Iy3Fh/WSXlHumE+fXsE9weG17RsTavC6T3GSUrGMOL1m99J1E2XMsBSEUWzYfUGURm6p4SnN",
+	"8x3xjf3c0Ic8hqduxlcrpizt1rM/iT4P7cTx+Zwome/CNQSzjcrf4ehJF5s9W2K4KD037Jfr8lxes2zZ",
+	"owF4aT+7x37OtZntQ5O0AOl4qVEbsexTVrzEVnAcdKi2iOK51EZul1xoo8rUxA/bK2xEGo0iY2Vcj6z+",
+	"ddXipgjY0i9LU6oYlO/pF6CHK6a00x5gO+RrfFtuQ7bGhWFrhorbbVosLRmNPQzev/pgDyZ0K5jacssF",
+	"LXZxzRGoXn3AtaLOqO4URSBq57tD/MKuCX6CHU0dHaKCpSGZ/CKvCc0yq5ImGyqyHB6sqG1jxA4Ym3WE",
+	"mH712rQRWmodMbuWSSdpv6vBndamRiNQxtafl+mG51lsyQVVTJjeMbCzbdOnDCq7veA3nLFPTTI0G3aM",
+	"TtZ79YYqhC5SYou81YVUnas3V1GDgH/Jj+mYaMNQPCo+V8PqHr1CZXi2DaxuFg4c3EZ6ol4B0KBl7l5r",
+	"o0DtQYM9BBSYiFr8wtp9iG8wqjqdphdlsGlL+3PnObQrGOhjGswTOwTY8/Yop38C5Pp/K6bLHNpaDgE/",
+	"b7i4tLhWLAV1cY/upoU5MLYGalMuzJ9ezGJMm2vQtRU5M16DsqIAwynqSpIeYagiC7KhmiiWMlRFVPB3",
+	"5R+36mVZRhXLn89ee0WWEz9cB/wJUUhSb0RKSKmt/KUJBTK9JIXk8S12ZxfRW2oWPVMfsI1dVKnBgI20",
+	"L5iGY+apvzO0kjnrJzv4Sh5bw5r9BVehnwSkUGqm4BRpzbWhItj536Ns7x8lEzHb17n7QkS5vWCKcNEg",
+	"wfBy+yFGBIMMtV+Rj0iNqgWsXvlKWnstKgYqblKjoWdAUMguvYm3OfD/ef7rL8S2R3Kp9dfV+HigRicZ",
+	"UFHDp32Hs4S/7OVFTvcNjYb4UTjWSqp+3CJQZ6+t9c+Ny5FjT9OYNxXlnq4azK3BHcdusgMpjLuX4401",
+	"x2j5YrUKv+eR0Wci+oh2odo8GbNVDBuKDm2T2cfU8guQsGOn5i7MLpW4tIc5pb0j+wmrg0KRHbotEbUM",
+	"koJdTxELw4luIeYhRG9RxdlLfO6uXcJTLJXaLEsdW5zUhuR8y621vNJhcYH763SvqL3RzBAhBWtYTGUJ",
+	"AlgFo70i8JUZTC9LA1zXyEsWexH+ip8Jft4flu6FE059TfN8meYyvYweQ4FPL75lbl4uyJbnOdcslSLT",
+	"N8JJvzgUP092H0nGdZHTHXH0XtPXW4U8PSMflAQKwLcy/fKOiTVoXZ6enODbufq7X04ZEE9qJbsXTgAz",
+	"j7f0C3lOcnbFch1VsNuR7VNqRB6PObr0U7f3heyl75SadLMsi4ZcOdOXvJi1uR78SKQi0t5DEUk9Jg68",
+	"5VfsyDrkQQPCvhTK2QGkIv+xkaXKdwn5j4xy/P81Y5f4j60UZpPvYjMxAQhqysJxZWUyy2kp0s3Yrebx",
+	"9M62DqhshCbQtzUiTdiBEFdWBcm1PSFcaMNoBqxfCkLbSJno+xF3d3JrHSAHO8lbJbefnCtoP+cDXfb4",
+	"c8MOTCjJQvV9uEwLFRwEHn93FIpLxc2uF4u+AXmcy+uECEAQeoxt+HoTl/i4ydmAHyx+jvS7oooDbel+",
+	"gSCit2tO8jeal6yy+Vcut/XQDecNa1+enc62lMPuG55eMtj9N7/89QieiFEfDrA9c7FeZlz1K7F0A4BH",
+	"mrheDQ+7LmEN006/LrjC1tKPH/Odm72s2pGgnX9SplQQai0KDavGf8+PN+WWipzumJrnEj3l5lcU/z3f",
+	"7mhR7GfwQJ+0wW0edJto+QtGXGfRHV6XFzgRyaq2mlyyHcvIRXVJdRA+olj+bcMNy7k2QGMNFXMTZ4rR",
+	"bAlW3Vkyu1bcMPvH74fXwXs/VTpdF09LI5ew04VZsowbPc5q3girUSiNPLI98ZqF3tXye606Q05hnzWz",
+	"HDp4Tgh2XdFk851e8WfUk/Sou7go2QTm6VrijFupDepphInNDIJTeOyja8WT+tof77PVL9K8+cL1FOw6",
+	"Rg6QdPgEOGpyQzLJ0LOFsC/WlBGB4IYmFsS7XXXU2kLFmilZ6ny3BDlkGRoXRpfmrpHKfQe8NYMRCYwY",
+	"miuIFzBiKxwCZQm3tSyb1+ZfTuC/pN9rHNsR1zUqPA8BGxOae2TIULofNV/9lNP00nOZjOsBRtN+mu7F",
+	"YfaTM2r5wgscBn6GLQXk1bJGm5aCHVzRPL+g6eVy0LHaSPBFywm0hD+uwaEMTkeh+JaqHcHehGt0wc4l",
+	"zVgWomWmpRAsyvsGvHXcMwYm53nDU41wcUw+VS+nR5pclNmaGU9AGrhvvlsIZwPDp5gVAZxqgeSMXjFN",
+	"SqGZsZ5n/a4+EaDVNVXZciPl5YQzdw4XAbtiakegBynoDnDkLXQZZVspEnK94SDglRc51xsrsWwJ1QsB",
+	"nZaV1toq93qc5SqI+m7ySYqtn6W8fA+vIXtAOmTapEbLn3Hm+j5HKE/JB8VAQ/RZs4R8kNr4Pxbis2bq",
+	"A16J5+XFlpuEnBuJj6pzJyjA3wsREwq4SHNrx1eG03zpVNZTtsIoRrekUmETq0kA1TxHneSaCaaoYVlS",
+	"7USGm1B1WWYsN9RtAzxc5hoHnQ9uDCiIl3XsznQFG+qQz7GjVRZw4JROibotNWpogesBOT22twj6RdiV",
+	"PYnhb1iDg6FOqixM48jh840btKBAXyslbMt009LXT1DlTFDhjANR7ZSDhIqd3003aADXM3fxRNwA6Jel",
+	"YiYuoH9kutw2WU9ZVHEsOCX6/VuOyK1Dkra3Kl0Io6jQnAljbfMJyfklvA8BbMuUEkJFwDTJyw9ncAQo",
+	"efXubCFSRfXmmLz2XK3BMB5pAnDvUCtFDbxtjCaaGVC0/Ds5WQi4qeCVZdtxsW4xuud9+Oh3tAB/iriz",
+	"RW3LOxn1vBhRo8X3nq4MU0R5FVu1AaGE0Fjdn9yeT9Cj3dAXZOzS9LFqwTICc5os0HOvuhttbFLMlBb4",
+	"nGwxXvBrnAfX7fBGbs996u/HhNg3w5uMG50sRJFTVEFd7Aqq9YdqFN26GKHdbBi+m/jEuKdR1GrZVIh4",
+	"pZxVe8ySER1JJadUJyaVIkUnsnTnpQKqGFGMgtbrdCEiWpVj8p8lK+soGQ2ClkLptJpJqoyphgd7uAT5",
+	"ZbekBV9esohiB478JdtZnEBTeNVtmDAufLR/yAuq2bJUEUT/RDUjnz++CwbVTF3xtKkI3hhT6NP5XBZM",
+	"KFkapo4pn9OCz6+e9k8bUzkOPVLt/DA+HFxLk1wHByKmBoOJ8HgtfVRf3zmro7qC1brZGquFVVI+Xxfm",
+	"6MUeXlhngoOQ4TyxGm+zeuyfWV6QLSOoWyCUfNiZjRTO+QpYQaFkyrQmr87/huJs3ESP/BykbLlaRdYb",
+	"voVADKfckAu2koo50UDhs9moXULs7ZshwEDdRAq2EJaJcnNMPmsfrBfcgP9Osp67ZiEawPl7pn2jTOa4",
+	"rv/SBqJGHX2xAXENvBoA7klNHpeaqQSQCiJNYuNmnxyTX52pZyFs80e6WtC/w00Ld+QOnQ/dQEIKZtew",
+	"h3+whUzHTB9mg7ofD61vilueAEPhThnmP4B41xNbl+fLQuY83cXUXhSpKpNWJArvybVkunoEOim1ughg",
+	"1IVw7+tTIqThqx2RIt+RVU7XmoBIwqv7VxtZ2N8ueW5t/Vu2EPAH/KwrsoaoHvtVex/jhraGohLJgXi8",
+	"EKFIY4FoP8KCCeP4UTw1y+bFPcyOfoVVltpiC64iG8rvQq+DkaJRSIdxluxVx1c6EPwekxoqzAAL+WDx",
+	"DgfkvNfB84qpC6nZZEbt2jspOoqGQWX7bx1d2dAy5hu5ZXM4yHN3jmNrCKPahuSy31w7i0e9j1tqU6W/",
+	"n52/zyHDm/h7AnYFu57kLBofdChad6LbQMyb9ObuA6/ZRbk+Eys5GrmQ0oJe8Jx3LSVRu1Koa4zaFAur",
+	"MdVl4cIJL3b22mrFIsA556BkqRQUFSS72AvZgeu6RRzaW+O/enfm52jsydPjk+OnT0/ikQ680hJ2t/rd",
+	"GXEfw1gHOE8B52oKc/kumoEip9qAKAUiUhazK2pD7Oe0Dr73JjvYchAzibOd1NM9O3n24ujk6dHTHz49",
+	"PTl9fnJ6cvL/To7Wj4d3+OsTJj7/z3fcDM0fsI/QHGbllePsInq4+B8xSyj/I75euLgudoa1nrcv/vzD",
+	"j3+aJuQYOmxXmzBGi+48fDA014anLRuq9weCYK4fnIecnp0+e/5jxVv07PTFs2g0PNwCy1SWMZ/AX6yv",
+	"JuDJKhW4aGBsxGuzxUpcBA5uSHNij7XmAYlznZRn4z5zvRktqivXtSCP66xJUpGMiV3TQ+Udqjg1XbFK",
+	"omdRq1fGUh7nGx5aUjWp9QF265h1Tt+NJ3aphpiCnP2utSo9UUtOUCpwEOYrF38XPWr3F0RX2fx8aqb+",
+	"1Q+uE1MzhftfyTVLIc3SJk2KpjFyGZzaw/4MbOpIMZqhuMVCbDYm6hodm+ZGEjA/wa6PBuSnOKcF20k9",
+	"eIF8F54UHatmlN+OTOk2SftsLrEndQaXDXNRnDUkqetiMwS4vU72pCC7qUkQueC4TQewGPXg3r/GxGMx",
+	"XhLTQ9TkQh6z4/VxQmw6r6dN9lHn+IowjCrR2XTLQOA9whwEwtj0Tl2N/61pspuNbDTY0p4fP1gvsicc",
+	"z9FUZ27D4qQQnTkezOTZ4fRdwIGOdMFSkKDwOoxtQJ0e6PRrbIQbpDyyP4wgB8aGOJ8OarB3CFfSz1Hr",
+	"UXpjiNy7tx09JNj1MnDh9v9cVlFX9YvHhnEt0w0Y+OFDqENY2hwWjfZOhRL0aNnoZsmsYToNOv+jZCUL",
+	"BvU5PZeqjEBSfdWGFd3PflBrhF6yLyljWXtted79ZZnmjKrG71bL5nN6xoOn3vKcoXk2Qr/W5/dDlOt/",
+	"ZDk1/MpFZqOMY5uD5OM+GenUiJpBHhPblK+Iy2d4kbMmU9MqnWPIKVN6vir/+GN3jh2P1zJGs1xXt3NP",
+	"Ahy+suphrsGR0jf2yXAAaK8+rYDAT9EoLkASy85Exr7EdIyvNlTR1DBFCqmdR5pcEdfNaXxT36jp6fHs",
+	"efL8afL8T8nzH5Pnf06e/yXi6RGI8W0lYk9A/YWWeWncDhlZgYLPEVg7uiY0L2awpOt5xq68HmW+56bo",
+	"VKqYeh3mJv8oKTySCTYij8EgwhTszgUzhqkGNfx5suAf0qkHoLNfTXKJMSY4CeeCFnojo5J/T3wTdPOB",
+	"TYQaot0QpI/V3iTyErZsOf7QHXrY+v0Ef9jjYneroDaUtFKvQfI4Cyeugg6nKJD8vOE66+jW0Wgs69sz",
+	"lh1rSmynizMAduH79rsD7reD/yvg5cEFvAykefSEEE/yOOrT9a8dSpPM/A3YOHkdV81zqYw1dqOJCUzg",
+	"FsbHJ0cc2Xb2JLpxXr4Z1S41aQWjRNtXXjQo2MlyexzwWKSp2+BGDsZg5H5Wdrusi3aM6SoO2/5QiQ/9",
+	"7DcNXn0r1eVYSMTNA+qlDZlHy3USBtMEgcBTbfn/WdnwLRtSl7U5pXGCPqkdoWvKRUIuSoOJELEL+gSm",
+	"6KZVivoFcJPgd7dcmyygscqGuvnZqEjVb9p6W4t+IPIM5MyDr2AfHb93PzJTKuFMxtAtIewLumOGMSzR",
+	"OxgviMYMz06SHh8zUTEE6+rokqDB3JbFOv+yk5NRdzOQTaI5iUL9F47v3jyWs4U+AUPSdlQFSL/4jGon",
+	"g/nVeikVty54hBmmRCuTNcj32Kx55zz74U+jd45iumCp+Ss3fC0q4b8RPtiRmA1sR2nsps8tV9b2gQLX",
+	"0fHaD+bBjRFBlHT9Fk0j4T6Wt2WGTuG1drD3vrXFBlBYzwuIZa0l68rSqFjOrqhlQdM4bfVyH2O2Hqak",
+	"XlcMPT8zmpvNwD3ACiYyJlL3d8wo2/19en65Cy7A/z9MMxcXv+/Z7rsQEcOv1dYi4/UxUQlp+5QnNmW7",
+	"fhJ3RZ9qKanz8QlpGsgal8wG35CtjVjtN3ZZrBXN2HLDY1LZOTO1D2UTowRCYjQJ9zUB8dj65rItegRt",
+	"5LXNP4uTTIGn1+DeXKYHwqnbF97Ovpg92WOW5dTN89OlG5Ze1kav/cLTh9IMxqyxdd6rymH4Em2DiEzU",
+	"DNa3AX4awmbd9OT46fHJuIOInb0eI8p9pLx8VbswdO2uUd+G8w3L88q7wW2sKoXzmwdaa4TJSEG0yXjz",
+	"+lvL1daQo2tyHHeyCmLPGnO7V6nzmqwjbVOWe881n1Mk8GpMejV6wb05q7u2bCywGmieWLHJL50HDGyC",
+	"NaXfEh7G63T2oQoJmhz647c0moaxmqUvF5FURLE1+1JtpIvGIkYmKHrXMUHAJBYiiAs6Jm/QQ9NOo13Q",
+	"lJEyb7sHguv6//eb4mb8YWcR0Ie3D5bK9jP6BDFPnkwTS73w09LmJQJsJKT2tLKrxZgQHAqVZgkp8lKT",
+	"KjFNQuq8NNDB/unrR1nfVvjJYjZ+J1XxEzd0arthfq0uw6ocSaFFg181vtyF+0C8usLNnQrquI+u4JkW",
+	"584f64Yh9O9ffbAjdAWe97Swb134bA+YkZVLWCdfmnvK2axsAI1aa1jXEZp4j4D9wPIq3gzAH9nBj4Ke",
+	"kQvpWxwpDu6IIjfmIO04C6FqXW4BBTZzGbB36dbYVGU1IU8C5fh+kbX9jnYOIiOJC90dA6kHZdHcLFe3",
+	"yJ3xRlxxJQWgqU6YMQbc19nrNz99/uvsdAanJZovY8NoNkKrI5D9/OnTB+KGAcS5cEwLG36Mg/Z/HzmG",
+	"dHT22rET+MMVc+sAGk9aaQkOr1PyGAJYSHvWBAspkQpRTzoxL7HNisbR4LBMZJivEQNqhteIo5/O5xiP",
+	"sJHanP74448/uoia+TYtpmUZec/UmnmP5v5kcLG6H8Oqm6rkh++EV0zGcmYY+vPbFCwu1m7L1LrBn0Om",
+	"2wHa172MSII3sr00ioyNJRDtFPrs1e8HuntGTakYLJ9fMbWL32msmC48eRScG1bEuND+OupWGp52dh33",
+	"iVB0NBeMZZpc2Zw7NrliwEhdNp3fb1xdLFSQW7yE0O2nNPeYOvMZBwdLzD3AzYxnnbIzDC34dnYCP8p0",
+	"ueVDXaw2+kSzqXT3osibHOfqpT1+kFslcjsdKmcc27P/uyeSAUG1eZ5cbHFCKrygy0W/j+uN6OljKfr4",
+	"w81Sbo1lxJpg9gpx2sZgUJrMn/oR436w0sOQOxDvTSge8dyZ2cfKD/q4cx/JBmsm11S7fCohJQTKgJud",
+	"pP5T0U+7A/FB3kXTWZZyapg2xC2358Wm9oW57/C4LOsJ2e8U9fFRR3HVXo3s9KFMoQ2Ku6k9tHFzRL2S",
+	"uCjZEsIOI5uI9IZV1dxeN6o/RJMJ2uhKCGUlZ2YhrOuc1/zvLD1wjaR8TDAMEugDjS+JD763igfIBkDj",
+	"WelAUgSXA0z4UM9vlTs9oe8WCL2UIr5Q5wDu0phYcqkjmrmGNdlo+04+N2sa2+8VeNsEmJGdsjqbLc3Q",
+	"oJszY/AFkvE1B5weIdqWE7Io7J3G0lPZoUn/5nT/ERO2ed/TJigYhVbq3gg09CDCLUfXR2C42Pp2EWVN",
+	"G++Im512uRNjj0P0XRiPjMJbw8Fdm/Ane0XWSGpOOYzsQxFAPeLNScCfnbHsuj3B7KhCRVPAlmtMULDx",
+	"qb9sYB8SRiavxSkmf4OsBKlUWZUsy/VLbFi8u7KBHUKodB4mErJspSxaemWX7vcgQu++WYCtelyxtFQw",
+	"BPFe4A3eNzshfyH/Rv6NbOOpQYLsHF1jakejCzMMSttIknVm32kLvy2frQH8DVMgh5cZLTMeFWYE+xJC",
+	"2jqj7IvPiJcQeqGZMJZEKFwxR+j869Fti4SUYjLvGUl8DN3s1nam0pPnOKx7mz+LNblUm7bfO761h30o",
+	"qNJdONG0cvKjNdalIv7hYYVuC4eeJS1O0p9dd49cru2Ej7dPAzupLNp+Bc8OmqPtIeVlu6s0bFZvWadd",
+	"g1dHqVj/lFWms++cpWxKTrIJycUey6LUCbEpxDBfFeQQezI7XA6x8ZxcB0lSfpvcS1D8hHauCBS8ZcHg",
+	"24ePtWuBc0a58/xLZSy/0mxq2oBpuX8q3xxPfdhTk8c2pU6YyUeqMIXPk0n1AG+USh69gFjmj+KGDXB4",
+	"vkJvKM16U78Eaqx6w/97rlghb1GJsC4FcRvtlB9lf+k4qowdU5FOIRw3fp9q1H/PoFzTHiqfAZ3Tp00l",
+	"ZmfdlG7jaiPfN/G1fKVyMnxsFKP4es3UreWgEFFttLRmGfAdaGzooV5hwZC3f4YdGqhbQNT0uu2C45wZ",
+	"3kdTwUFf4pu0Q4yab+0/xQ4GUEb2a2n6Y0696zfVxMANKFCCyUp0t/DuZFNiTo00NLfOWJGlfIKvlXsT",
+	"xtH7GwT1aCA0WDf5YK4Xz6JrgqHOUyoEy/omqr3oWy7MrlsDcy+e/9idpxP2F0zaWmwSbmKA8zg5aO+Z",
+	"+M9dOmPveEV/VY8FLN6gKISfoq4CgclNgyIRPXOlkP106ZNvuJKGfS+AWt2F3YKcHSKeexmD4MbT8Fsg",
+	"sFDHfgBAl97Jfzg5mTj9eG2MKqckdFNAej2Z2CbVgW0ajOISlWvls3IN5ocYL15r04MsgzCcdgEO0JJc",
+	"c5HJ6yCK1BbkyFijBt/TP/15KmJ7H7GWR8F3YOmfzxtIPDk+CZOJr3KJl3HPfPUbtqmo60GrJ9n9k27c",
+	"rvrGbyiwA+CE5nlQrrg6qHU5fV+suMrlWWqGOV50oxzn1HIc7EvBFdNRvJyd/1qjwr4qBmuCADUQNyC8",
+	"QS0jfnJjyvT3xnKr+zdtyvX/4oeJRMkybqTCVB6sp27rRS4vgMnYpq64BpyHRnWvxvSzrwsfAr+YneK/",
+	"tczZcS7XjxeLxQwc3yX848m/L2bJYpaWSkv1wUXeLmanz158m4Ivtlqx1PArtvRnuo9X2iNmvxI0JtjS",
+	"8FC+gqSRE9/gnU8nsm58uiwvuYgzTa0x9yBJaanRTEa9sF9F3egSLIkaM+NbBsWyZCHqzPgJZsXG14FX",
+	"27Q092HXXmP7sjcRUifow/P2fv+PCbVLgkTX5ILlEnSgRg5Gpo/uvg1bRfPxcnqcqYvTduGmIVwg89ox",
+	"q/DTvQLv/Tg9kffeazxjK7QyRNOeTr3xB2SMSZSK5gwKZ4ebXY/SXhviW9zggpicLCLU24T+JcjvD6av",
+	"jWSGGJt4T+Xsz/I6VMGGo7tqz7U6iHZ1s7ObVIiYkHdiMnpvqIFF/V4kmf2Uokc4cJxvlHnuqynFj5OV",
+	"LY9A8Xv04ujp0bOTZz+c/PkkarwboY36WPXWTflh2rFyOSyGhGeXy6KWmJuZngZi8ZGB2Bl6hOnJ5RA8",
+	"L6wqIjDVcd++w4II/oFm5+dVcbXDF0Vw9oFK6419+6ohSK2Pnj47ubixYt4mcrHOZL35vn2JBMVWNDV+",
+	"wWmPjUGxFNbYI8hbOTpAWELS0hAoTHCxI9Q7CyiGQCXIADwn4gJK84Tp6MlnoZkhIBzlAL6w3kwTjb9o",
+	"MxgGsintkGsOtdQYsSw1S2wVMqsNxx+RNpxD3Z6A9Pio/Ia1xezY7hBIxdc8fMS6w5iQk+rZ59tE2WNf",
+	"7nMnFoBHeg8PGy5FP8H8sUEjrO4zgJySmAFkIQILSBVpyKjQjRIEcXiGCbF51UAlhTXceZq4vn6LrTsL",
+	"N3AeszK1EY7CTHcDqNXowykOEJhz2xi7yQJUKzrmkPLbZhd62EDbonmOT0krtaGvdwUlqiQ66kiBkiY3",
+	"2t7HOvFr922rge3a4fq2i08Wwk69dOc16APoRGGMRVyBsJisvBY921ZuoTZhRHV4dlTbqF2rlmNC077o",
+	"GBbLWpV54KIu48WTeyxlkDzkiGUcE17XBw8bh1O+35GzbSEVlqj7RPXlBPvY96vFYG9apL+upX7VjC9y",
+	"AUWwjz75HPq9Dg6PfSLXuB2ruVWuljN0HBw07iD4V6AxD2t4jtEJjYvE3Qq2OKCNuMqm2ZgcewycmX22",
+	"k4aDTedFMqAuv6W90m38HjYby0bQNHIoS5IH4uaGpJC3RerpKitn4vfIsXahybbUqnOBt9esc9qYBcpZ",
+	"4Mv++d8KWa7+xI/gCgBnEd+nNr9lSpVNAou5ZuOZXN1ifAF6WA7N819Xs9O/T0Kj72jDqL4lh4i8a/sI",
+	"mm2RD7oI3llUW6NmfKmZhvvcZZ+TK+duUWoWjXNLfDn5Wwa83SzC7ffu5vaEuj1Qb7ZIUXPblFV1axul",
+	"jsAHfocaRcoF+frVo+3bN1LkNGUba3mcXP58P+32hNRBh3fQu7uakXu4dUUiIfkXQsk7LhhVpDoM+yal",
+	"PKS32GD5ww/wmbgiiAkRDKK7baK3yE3rqvfbVHVGlSz6+h9/p09/XcdfxEsTsOyBp7HLCjJ0Hpo79/Wr",
+	"3bJv31oObJiNUBvy9avla9++TfLhaimFD3Rk9xM9bzxRPCamtQEDQoJnvQeRm/xge4sqvqM+rAhVw3NL",
+	"UeqzN4i0AzYGnQL2MuB3G4+36Cgv+5PK9ri7oXfKgDL+vNzWGkGessybf/QjtErraVr4yod40u59KpXD",
+	"+Ni+NRLYtNbSQmAbW8MuHf1b5RczRi0HOVAOC3tS68ApQt2XHvKmgO82B6Bha2lTTw7kH48ry32b0ATc",
+	"pU37wBgYpmNG7o7hyhwPDGJbgMOvOPJwJQT+wuGfDI0fCx3/zm+5nOpNbwK5eNSla25TIdnMMxgrBUOR",
+	"QrEV/9JUbVihfdlXu9rWl40QDf7u+YNP2XZkS81CwYxCPgHRbJ3LC/gBvTNAjfIkeGVi41kys42aKbD8",
+	"t2kB0RbKMSQe7IoJN+bm2+uqDBwsT3VY7eHmUBmqTCNFQU9+nZtlY2g/ZjEZi6Oiq8bTVmMAdOv9+tW/",
+	"X09nb375K+RGejElx8OY8k+ugildqS/xyGbfl6IBwZB7fQeMTxtZrjd71d5ACydVl6C5rYpwPMbEiFyQ",
+	"NTNuTOKT3j3pq67RffuAMfTo6bOjZy+O3I/H27jrhpLCbKkxbLS+lAPnbdCjN964WWHHZcg3dgA9b2BZ",
+	"b6hi2dwHss8ngz6l7JODOVr4ycUaVwh0I/7ev7tvm8jqnOD4O8kGM7sKS7EGfulMjWRp2W93aquHoes9",
+	"3/RGFjyN50yZgJw+neR5QxfpyIFkMsUceBGlJBfwglsr67/stZFRDaKD4nYCmRtkOg8N6au3JNjAQv3W",
+	"z5IqG4JXVEBec4i+SmbSbJgaWvSh7pVq+Te9UmqZ/ju/oiY7LQdGXlpZH62Nry72F/EYHvRjsk67CQH/",
+	"kNoUa71XIBJUSPuuij6j9nTknai7HsWYW20UVS99+bi6+AQEDOK/epz3tnq9PHn68qdXvW4+vaq+GvVo",
+	"HXMvUKpj3j5WE3j0wnr7/PD0xSRvn14/narSUzSii+rL2jO5khzKC8yD3k9ACWFoUa9cxinefUDJo1Ju",
+	"sCsebXf2wh1JXvUZbyyrr+2vKWzP3T6Z2R9b7FgEIJIwLsMmrOdSNPNIzkutbBbJ+QUX89RHvY6nHO9Z",
+	"kK9S07Og/nfvS/tlXgrXJqzg9DilOqUZc9Uz7FPzSdw5fVIxrV/YtQ0IGC1i9fgE5rQWWcLNk4PX1AJQ",
+	"5F51tSIg3by0Fkyvxt0ch9DQ79kYf1rDlG4pnUJXloruqM4VTFy0a109lsreLIBxJaV5sn8Zq/YkWipT",
+	"1XpsVrCaqCCxeKgDiPsYRJDtZmJeGCi2AKfomkPZxUbgtJGEtpPF7J375bZJWUa2ty8JytiyXGKeKvvI",
+	"xJDevo0ZKUM1NbzQjkboQ4wyHOfOQ16kPXGFzczTQEbzjGv4fxg/iGexDi/cOwqpby5ia9HiP0cjj/ae",
+	"denid6PhRb8WboNdo2gBwykwddjuP3kU0j7hLu/h9gnSFdLqSkQOXt9Q4Pvlvj25XRTM3nb0BIisFlHd",
+	"Pfzk+7nsPz/6wQvyz06evXh68uzZDezlzlKOa8CmLafEVjazI6mOjo+Pb+U1X0+lmbqyD7qD+sxHGIKd",
+	"D8bz4nJfnEW8LMR+7vL1xgWLdZM3FkuF2ShQEs39ph77TY37ZEdStHf8hy3rxpypNNfVWWk6Oa4DT8UE",
+	"CxICYrjRC2EdAo7Jp2boDRaa8rYq3vLLTCmofy+Ymyxr+D4HWLyhw3Gf/6u9VvsdX72YiY/QX+iW7e19",
+	"4Kbwi93DB9ZW9vsvuRGjGSH7BRAY5NxlOxuQQrBqXAZavivuqzGMXYy+F/G9fBm06MbJwiy5WBqWsy0z",
+	"MT/oXwtzhP50MM6RxPgJuNjwIsPceCIjDMMiFSukMtNrAYRYuNXye9dMcn7JyK8FEx+R5xys6PNkvCGj",
+	"2BtbB8jkF0Hffkn7mjR6G8VxY58nq0v/RnMOAFbZWXsPypSsrnDZX7kR+5zaBbs+6nVsj9lHJoLd64lA",
+	"xSvckP4CihjIUC0EnhIXrHKRt+YwFxTEvnBtMEwOg8ri6pWppewQYw5dw9HNdtqpC3Cto6B9KajIWPYh",
+	"uplwafkWtdmM/DdRTMv8imU32tNkxnW1T8NrwDltyuxqNT34N6qMor9FQRUuGiuPkZSv8fKrY+SxQNdm",
+	"RK1wyb5DccDraDv33SMNCXJR34FueNL39hKDn9/mn/DUVwpgtP89vzbzRXly8jyFMfBfbG7lhCa1j4SD",
+	"SDcdCv+JF7y1dyOuAXykSerc922Ppqqd8qjSYyRaxEi3rubMmzxzi6tQmzn3Efy5yUqYWFtT/ARJ4Bva",
+	"IVbSW8FpampvltnPoBh4R3dMkXNb+2/mag9VgnStOzjO2FXXp+Djm/NP6DCLpYjq8VxYFOwMnm2duFsT",
+	"aMKvcksFXbMtEwYyvWOKEZoj5axyea0TvMYUozneRS5dgDaK2dKeYb1PJzJaSS9c2GsLiIczKCJ5ioU6",
+	"T+w9ywQt+Ox09twVpKzqNM9txVVQQGAQKPxWSG1iN4FtoW2RVthhLrgVEPCpeWzFdDdiq0J1hamzLBjr",
+	"5dpF5Cl7K/0ks13Lo4EWRe6eW/P/cjF19krsXgXuUL6OyareMS0uqFpPI7cwB9xu9PoK5vs9Spt1Y+c/",
+	"7f0sENxnJye3WKxF82TDK6J61OzqBo2vpu3Rieq4VQkJdDzOWEbcEN+S2YuTkz6oKjzMf6KZF0m+JbMf",
+	"pnQ5cymh8MLFJVTxhxVl1aV5PUDeR+HvM0d1v0PPefXKXOJLdP61NvB+w0Jizk11dvoVm7tjDH/P1iyW",
+	"1IJrQ6rT7gjb1Vv16XvqhCc8N8x52zePCAzzspoMVfp0ywwK8H//Gi8ZfrFrZsni8M1Hwjmm6Bqc+WSM",
+	"lrTadP77LUl1kBL9qiqZLkJdiEVwAPWND0Id8b0JSaOa7ndr4Igl78Irzt3u7cGQm+CtQhS74uy6s7G2",
+	"u5/oFrxvCMfNSaoDNoUnPb0zIPp327fxYtF9cQ+/ta1N7SGQBj+Yf+XZt16m8FcGF6axuQlAYoGXKJxT",
+	"egHKAEp0wVK+4mls7ib9/JWZgHhabCG29LpJBe1ZNvsuR3zSnlu8uBvjxfgG/iLNW1mK7CA7DhtD25BM",
+	"3e55xlKn6YyzCtvdapaY2BEwYo3t72sc83BbfHjm0oRwL+ZycmdA9BMatLRx5li/pMFdDgIK0tUQBGcC",
+	"tQAk85BIVdMBzRWj2Y5YWsru5xhYbOKjdTrvqyMyozzvIzOKsytWPTTdo6lRUDkIK2j68DjX2Q7vc9Gd",
+	"d0hZ3h+pfz9fNVag3DozogOR+GDcKYa1YFMqleDv1lM69jx3enpVCnxoRvfBZwWcsAuh29YdyS8xz7Dv",
+	"zGD2JQOnCO4QwX3IMW7Dp5MOHOeMXZTrI69OGRBjLsp1RIYJssXUZxr00qCyQmO6rWvnqbANVeekv4aJ",
+	"zgCcO71G3CTDN0h7yX1nvnt6211D/Ntq5g77TReZkadHrb0AlEJGQMEADHtmLbcd0L/YcWql7aEUMEWv",
+	"2jnr2BGsqH9TE8Fda1f8Q2SiSj7IuRPPJ9eLGNerhaCpys8+Y40f9eA3UpcCA4KGoDHPTVz6+yHpAItb",
+	"2nYEdeuoI9GNCNKoXuRtFcQ+qBU5c7XyK8O8gwk0+361PVoSy8HYyzprfE0o7byDHQvFXb6s3NKnqE78",
+	"DhxOcZLn1aDBprtfJipM3H5jiTa1poL/EajMNXkMZU+fk5xdsVwTwTTcUE96GJid+k5VKE1f7u+sQPGT",
+	"9++1bdF73L/rK+dvtaXT2uYfs+P1cYKFbDNWmA3xKe0Swt2LCI7bk8MypprIokQa8KZDqWyq2ToiTEWg",
+	"w9pb74c96KWHbMrdDZ5LZbM2Od6XUncyqd67tmfVhKOHkQ0+pNwQtcRwTF56v7LaHzOM1wDWWfE45wvP",
+	"zXHP0+oBks1dPfFuwF/vgWhH3nYPjb8+uZ/T1TwdjxVzBbvlFUu8EPaknymXf/yxO3IR2lhCqf8d9MH6",
+	"gmmCnWyNJzh71qJm/cP8IQVLtz9yjmUH4ra1rXth1BeL0hIzo17siGI5QxcwHIKkG6poapg6QgkFiyzm",
+	"fL1Bz9ngkjheiAWmkGSp0eR4zQ1fC6kYDOnkx2Piam35mOMKyh+I94eXPj2XXoiCKsx1pZhGxwqExzvS",
+	"I0FYJ4EmQ3kLCLITWen8bg5ze5r7OtAdMPqPUwv7D0NxgwvwBdJARsaDENCz7nlubRjNzaZXmHm1Yeml",
+	"d0z2WhpdBb3B+HaEXUyM+dkOfocbZ2cY3i50hweoPaRN1NkhSAor7VOy+JKYR6oUHfGvs+wgccneZpii",
+	"7nvXxrZGfpV+BPpmsOl1svo6dyuj6QaTltyjIa6uWWprgvtN9LDr3n2cA+R6/hX+922uvDdx/PpwpXPr",
+	"0jiGFTbpX2JT1zt3f0SVOzTwzw3NXJfjhTiHCYlLRkukS7tNlakqNFeMPcaYwTqy8+uCsW5LYkk3FQYr",
+	"usGlHP7vUkREZENtIXkYj4pbUjYr4Pp2tUi+u7j2ycNg0zMYRzn3c7qQ2mqCb5QGnnDOdC+bBMVQ3es7",
+	"0MIk1VcN98GUX0WwyBi6+pRfr1maY8E5sla0wIzqXsPpSBNej5ZSkH1oyz/qFFJcLAQwI1t1Wy8lMKIr",
+	"Vksux+TM6CoHase/kYpsIWwaUo3ZQUttj0qdHVR/+5aQr8g89bF11QV+gP9ix1bi/PbNh/QsxAqLWdjf",
+	"bZExhJ8bDyRakkXWP6ZP1PTt20K4YZ2s4wqcsIJcK2mY85D27ecVsuCgLwTQFhclwxJdlnmHYzzSgTu3",
+	"NoxmuAEuEbnVFi2EFCzGoq1KyW/yHUnOfniXVvz7KhgrBjuFu44oGe/FUas6llOYWCXwZQzOTeys2ncY",
+	"hir4ZQMZO1lAH5N3rfrb1pv/khURZY4dLqCfm93vcfnxReRV7EG264vt1Pf3K8nZlH1KRoXwA6Pv5F7O",
+	"0EMQrfvvrzJqpsO00cF1JFfBYMfkDQTgAC+F84Gxso3sh2xXFasCht2n8TzcLj8IDn0/1HVIF5T7EFEt",
+	"pd2Ap8+B9kYtze4V16LgBEQAdNzmKuKNGcq3H0uhHz4bKoWe+li6JytMQ562cOwnU2NaWUKha+KkaOBA",
+	"7ayrKIqCJCokkbYgvGHFMXkDwT0L4YXfZoJYIhjLNKEQuFlGxcJ2UtuHyLP6Eu/el3y5xwPeXRf/NHzL",
+	"E+NE1ZViKRPmqArAG3adta3znS2B2n7bcWZT5vyj5OllnR6pI0N9xFE+4JQjRsX39AvfllsiqrTnCKlN",
+	"KwN8tMdhxhe0jrjJPDvB/GUwbJ29zP0VSQR2l0wyQMQQPdpmduUHk8DsVsb2sOGSaV8Vjlh8+q7xyw0N",
+	"zK515gJL01KpMB7V6xuil9x5NdUd4r+aZIoSp178wS4dHSyyQnn1W/+FUymMPSql6GaJs/ZYspidkL+Q",
+	"fyP/RrZSLGZ4QJm9cd5LkdEdoYb8hW4Tn9HQmmMeaReEaviWYbZ7VANRQ2y2t34FhV/AnfpBtbPwfeeL",
+	"pJ5+wELk2jxMRYWudylGeY3TvpeiwvcKFRVkwzXo/m6irwjIaT+xxgMyWV9RbdgD1FeMbFe/vuKO0Hdy",
+	"P0fp3r21dBuSXsY96LFVDRP6bL3aULH2DwfD0eqHHhdHmDHNfVmIqrNisIYSTh4cNAGV+lQpYqy5mbv0",
+	"9sRwV/5XN+Lr90SM/9TqDUeIN7gI9tJueM7vrDJuoAlKjmonbqLkCMk1GX9SwIJu86L44T7fECGiJpHt",
+	"PStadAOOAaJjdd7YgcQWbUfWOqdFlcvimPxUebm5PdYEszzljFbmT70Qj5sjCUnSDc8zxcQT8I4z0P6K",
+	"aahA+X9gsAUQzZo1oYhxXyToOl3r4IvXHqAIfGQAvD6areCNE248f+a3pCefRzX/xY5gp/isFvGtGcPh",
+	"jlztslPSU7ss2JOjKlzmtFt9DbEEbbDXaSsxrvuKeTbllhsDc/j9f/nuXYBZIWtyebIIi0VbSGdBImZf",
+	"3+33SEzUBMRVWc6PyZt2WYDGBsvSO4cf9yG6ysp7Xx4xncJ8Q+9n1/aArKQ+UhGFxVj4j8icf5SN0XCB",
+	"xq9kFuYIjT5wq693+L5tJjO/l0wp7crzsUvFB8cd7on74tmzw0XTeqcTf40NRtX6xiSTzLpIYTY/pBSr",
+	"hYd8dixMCnN7OvaaHCDBmuz69G/uz7ljRgOZPmwDeD/U+eG3ZW54kbNGEYsqDX9F6h2y/6nML92AwS12",
+	"F8QfzHRPL4AGBP3EAs1qjNW+T0AUz05+/N7gfHDO+O783Zd2CbFCO3UJhvl0g7DBberI13eu9E4TtaAo",
+	"FlHiu6OzlU0pkMD1ntugCPuIbjibwfXER3zWXOVsHbfX+TlJqZket9k1GOxbJbdVRed9nzp+4jt8mfcC",
+	"+694O+37aPoXvc6qhIJKboMjOYEDQHhW/5l/L6P3mC0nU0VT9UTAAnuCAb7DJRZOc483WROM0ZOiMTiu",
+	"e1AOfaNNBat1rZEjouU22HbnPm4kwn1ft15YAUWHJVAmULti2kg1QPAfbYOa5jOuU6oyiBlsvnYvaArF",
+	"+/zPth5D7Ai4IV9Du7s8A4157vEQtOAYSAGY5xZ7mrh9ufujMBm4ByLiTabHCcTvCsH2KfjO67DDishL",
+	"eKyQ8/98R96d/V9vUGDjTBOaKqm1LSqSEAetTWiNMh1ZcZZnoJsDZVilBFo49c5i1la1hQVUErw8cXXu",
+	"n37JSVNHWAfuGlnUg0mVYSbiix3Bwsg0NfyKm90SncJ0ygTkpjpeiHegYrb87NkJ2Uptaoebrczs3VYz",
+	"v2YRiqhrGGJwqubR4dshTKo6jhlj0bTp4Fcq3xrRi5XwdbU7fVpJ/2d9SNrlBEf1ahEdvgtDvoUa/2no",
+	"GHS/On1XUwc3ZELQsFv8ffEEB8UeJ/9AmU769HRg964+7Wk6qvJRf48dnvJ6uX+bdwuQPm3rsMXbDeJf",
+	"5FWCx7DE4FZmqLKqFf0oxXi2HQj4lt9c8zwnF5UldsDwfSBquDOz9w3UvfdCjA8i84jPX+uCxY2iwpV1",
+	"lSoscGML49yneb1N9RNZ49yHEU5IvRgojq2fue/rymBQYd/9gedlshBcbJiyoeLcaOhzxZS2aPMme5Ce",
+	"Lspszcwx+Q1GNhtGGFU5Z2ohavHGBqQ3htAFE8alzKulhw3z48VUYg7sh3tUWxDel/arDUX/OfklII1u",
+	"JvvvF3beIA2fX9qSiBTMK18tadha1/d023ncAh9ZSXVZK7KmHlzoNeHQ4puh1ltvpEYsGfDg4rpV/rGB",
+	"vrJYCCOtc2WV1hbar/kVE7ZoT1IXtcRXB2BXA7X6vEEQwh3W1IT1QiNfLOzzZzBPf5IyJynN89oe7aVs",
+	"ywwwVPqSFJILg36cOVsZIktj61HaRfoYaEqMKkWKt0cqi53jGwvhDK/IxRFXCdGyWfnKaJavfF1LDhuo",
+	"yqLnwn8r1eXD5SEBdA+ffwCwLLt3FuKv+5rY8OVp/pk4y9sGN/FqcXtyMPde85hPZTcbqrIj6658hPWN",
+	"h7y0PzDQFFh1QuYdi63SRqpaqdEE0kYEuKzZHPDpquHlO1tR+XghXoalZ1MpNLcKD/zuOtmsI2TLqOBi",
+	"vSpz4ogHnW+cYkFIb4Lz7lpYwxc/hFWwn8QO/s9UZdZfGv1qUKN2J6/AiOs4zthUgJGig+7vf3TOmyWB",
+	"7YZIhX+4vZ9XG38/JyNGlcJB2kDo5DMh5eVArr43IrPHzqf5wPahg5IPTLIh4tDumqpsie2OyRuabiD7",
+	"3S6XNAPEFuVFzjU0h9csDrdULGX4gLVF9KQgc1tIb44/6GN/7T3S2IEE49m3sB2O9+RqwtF/lvLyzZVN",
+	"nfTQ7jmA7YNd07QLLpY/wuHEI/O+4sBxdkK9h1i4X1NpspJZ+unynGHm/Fq8IZqvwY8VDUo+M55/wZGU",
+	"WlU4N8TIhfAOKJDVJ2X4LI9Rzpkf/IGrx9pwTuJxtVx4v+oJD5AVVuutM9TcU8aNCp1dSppKwZYnjgeB",
+	"VSJFQyfhc45dMCZq9rpjpifo67te3q8b8PYHf93fvc1FYNVl9xyKNuFSHo1bbYyRBBo8x9JQ9LSNjGyd",
+	"oI5zPQ56UIo5RO2RtFnT5Gz1izRvgrrazaINUT1B1w/NytKZZFo8cv488foe6KvW3YAzwdGIbL8DbjVc",
+	"O0a6y228/Ikd+HsUQDmQxrriNv/DDvS/qGPZjZ4EQdHckQQXmPevzPO4RhyeDLRmW5UCbiH8DAkxldbM",
+	"+h/g305vdrwYslW+91A+UKHsVYCSkTpkNeoq1N+b+TKNgjORchQbKUP+EdWbhDblHai/hG4ejFxvWJhX",
+	"gVxjMSx5LZKqAORC2EpDRhZLxaiWwjVeKubSzyR1+f+G7hg1rXU1d024nTzIA4zl/alSoAyGtfji+3Yc",
+	"W69LX4NJB6P1fYFo/zzWdMsCooZ8lw4p1fMWHq1Zho4rC1EN3dJc9zxkYaA7EfTuRWPqmjil9/3pe66d",
+	"vJ2WEHq1AiMA9QToaCqxROp0ptWWomr1IWtSOwduYI1TT7kWtNAbaSZcEFjPoWpPUlqYEk5CVqoq55e/",
+	"H/RGXuPt4DObIx5dCn1Tu7GgPgqDcQzfsuFL4rwC9aF6tngABy0LDSzeY/GdJhwTyaWEK2SaLGHkJRME",
+	"OyCrTaU2VXZ7qjXXhgpTGd58dEpFQjLPqjj5QOAguryga1b3hPQREtg2Vcxqg0p4xAYqfJfUp1A8RULF",
+	"0BXk/ooRUWItNWX17XohvMGPkkshrwV2G6HMz4iVh+1vhTAOESY2wGN4v/lRQ7qpoJlInvBsNIqxIWXN",
+	"RwYe885wbIjvErBVhbpIa8m9UBSkb64xUw8pRc60Xgg7+LL+qsFVpC49hVKNwpmW1Qz4aPCCRbIQl4wV",
+	"nnPakeKSwraOGfjNL/AWxNZxKX2Za+kNEACLB/iRdmD1OJQ2kPBwCjNOEFY8Gt0W3Z+04myDFYlIhcRk",
+	"wLvP6X7uS9bAU0IDZ4zrmvb2OozzLVProcAm+BwcApcwpTMv4cLJ72iR9WcPpf6F8JFuwPft0cVpbYYT",
+	"l99NsUJqDu/ZR5psKRe2Ro0sQSTccLBubUttbFL/9kTYFB4SpamyUJRw39j8ClCQSuAzGLP8t84R/JPg",
+	"2BeMVH3g5hKrnKf4YEF4bVYweoFlr2LsALF1QG5wR1YwBNPDF/h73LNq7Ce7l4jqeypEUvsd1eRY65No",
+	"nstrpD17au7l8NsTGT2DFbPvYwE51ZsjoHAqsgmPCmxPfHtCryjPUT5zR7Z2fO6ohKMCGQz3ys8+EvXx",
+	"W3tEqxWuQm/SepzY9ecAWmZc7VWnp5sqJSw15ieZFj7yXTOehLidlPaksbf3FaKBOfcrsmrB1E/Hoc/C",
+	"7PQr/OZDeYdr8LiBPlWN757jVXPtkYyG1Ks5dFaaYOgavzU+QiPZQG6AIC/AneTlbs5yL0VfWjBMERg9",
+	"ah9oatUWlD373zhMkRSr0WSoHaq4ZbaI0ZyobYw/xNyo0/A9kCP1TrF68hBOyn1Wepm8P4MVXzBntQ+k",
+	"sa8jP+AxeVlleCOFkl92S1rw5SXbYQEY7RWwGNB9yXajcWIPORvLzVn2gyDE/ylFYfZh8q5W3KDA9Mk3",
+	"GquybjMKQgZG22MoE6P7FFFJzWieB1kOFXOx+MmsyCmG8EKDKSkOf6KaBY8HoC/KhdXo2SXNh58Or/d8",
+	"Odwlh/W7MEWE9Pi3tcEPJj42hiWP/c4kBDcmIcykx2HV8opwmsQ2t9G6Q/WEXc/xhKQ5RUt2Qc3G1wT3",
+	"0wQ778q/6w1VLJsrFpROP95mfckAXLnXWzwc/ycS4LD+pCYQH0TxT8NA0bYRW0AvQZeaqaMqfnZUlQLN",
+	"SaHYiikmUlerXNfht51T8FkzdV5/v7OdDecZtkAxVQFMlFtX9748yFaU4WQNPYD7aTywfz+E204dnN9V",
+	"XH0T6fcSXD91332bQwpIhwpjn0AmcFRd6D07qrWT/aGwYEgAf1Ya8G70I7QUdL1hWCKNG5JSEboTtknq",
+	"b27W14FK9C4oqjPPPRFUBI4pzppBXgTrjXgQAvHAtDcRWEFAKG+5S+wOnZm6iksa77DgkHPhsc1myaxU",
+	"+ex0tjGmOJ3PsSbRRmpz+uOPP/44pwWfXz3Ft5abqqPA2GnDtmTDaG42PumzKTVhLg5M1/KAbRsrXe99",
+	"ifmKpbs0Z2RLBV37uvW+e53guiO126TfUq2p4H/YXQjzWtWD2JaxMc6nl9PyAAUVq9qj/UK3LHN9gH1r",
+	"5nz+uiB9CnSknVw9ihabZvQcOkCuGMvAdMFVmJkJvV6w9GE9+oegNnp79J/LLRVHXByZDTvKpSyId3RE",
+	"Q8gql9cBnC/dt9hIHxnNjwzfMueyaDXbIM9V3TGULtb3vcxYbvUKNdUgrmiOJ8NKx0pecdy6emnQZRbN",
+	"Cs+ItoTpDB6wpYJe8bUP//XkwOPb9xKdfzKu0W3P5uqI0SS2iyPEzZzJtIQ+lu3ybZHjEJZG/WPQU4IX",
+	"zb79/u3/HwBU3BP/N4QBAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
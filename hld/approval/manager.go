@@ -389,6 +389,41 @@ func (m *manager) CreateApprovalWithToolUseID(ctx context.Context, sessionID, to
 	return approval, nil
 }
 
+// ReattachApproval links a pending approval to a new tool use of its session.
+// A session resumed after a daemon restart asks again for the approvals it was
+// waiting on, and a decision on them answers the new request.
+func (m *manager) ReattachApproval(ctx context.Context, id string, toolUseID string) (*store.Approval, error) {
+	approval, err := m.store.GetApproval(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+
+	if err := m.store.ReassignApproval(ctx, id, approval.SessionID, approval.RunID, toolUseID); err != nil {
+		return nil, fmt.Errorf("failed to reattach approval: %w", err)
+	}
+	approval.ToolUseID = &toolUseID
+
+	if err := m.store.LinkConversationEventToApprovalUsingToolID(ctx, approval.SessionID, toolUseID, approval.ID); err != nil {
+		return nil, fmt.Errorf("failed to correlate approval: %w", err)
+	}
+
+	if err := m.updateSessionStatus(ctx, approval.SessionID, store.SessionStatusWaitingInput); err != nil {
+		slog.Warn("failed to update session status",
+			"error", err,
+			"session_id", approval.SessionID)
+	}
+
+	// Clients see the approval again under the session now waiting on it
+	m.publishNewApprovalEvent(approval)
+
+	slog.Info("reattached approval",
+		"approval_id", approval.ID,
+		"session_id", approval.SessionID,
+		"tool_use_id", toolUseID)
+
+	return approval, nil
+}
+
 // isEditTool checks if a tool name is one of the edit tools
 func isEditTool(toolName string) bool {
 	return toolName == "Edit" || toolName == "Write" || toolName == "MultiEdit"
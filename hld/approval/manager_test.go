@@ -169,6 +169,54 @@ func TestManager_ApproveToolCall(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestManager_ReattachApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockEventBus := bus.NewMockEventBus(ctrl)
+
+	manager := NewManager(mockStore, mockEventBus)
+
+	ctx := context.Background()
+	approvalID := "local-approval-123"
+	sessionID := "resumed-session-456"
+	oldToolUseID := "toolu_before_restart"
+	newToolUseID := "toolu_after_restart"
+
+	approval := &store.Approval{
+		ID:        approvalID,
+		RunID:     "run-456",
+		SessionID: sessionID,
+		ToolUseID: &oldToolUseID,
+		Status:    store.ApprovalStatusLocalPending,
+		ToolName:  "Bash",
+	}
+
+	mockStore.EXPECT().GetApproval(ctx, approvalID).Return(approval, nil)
+	mockStore.EXPECT().ReassignApproval(ctx, approvalID, sessionID, "run-456", newToolUseID).Return(nil)
+	mockStore.EXPECT().LinkConversationEventToApprovalUsingToolID(ctx, sessionID, newToolUseID, approvalID).Return(nil)
+
+	// The session waits on the approval again
+	mockStore.EXPECT().UpdateSession(ctx, sessionID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id string, update store.SessionUpdate) error {
+			require.NotNil(t, update.Status)
+			assert.Equal(t, store.SessionStatusWaitingInput, *update.Status)
+			return nil
+		})
+
+	mockEventBus.EXPECT().Publish(gomock.Any()).Do(func(event bus.Event) {
+		assert.Equal(t, bus.EventNewApproval, event.Type)
+		assert.Equal(t, approvalID, event.Data["approval_id"])
+		assert.Equal(t, sessionID, event.Data["session_id"])
+	})
+
+	reattached, err := manager.ReattachApproval(ctx, approvalID, newToolUseID)
+	require.NoError(t, err)
+	require.NotNil(t, reattached.ToolUseID)
+	assert.Equal(t, newToolUseID, *reattached.ToolUseID)
+}
+
 func TestManager_DenyToolCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -19,6 +19,9 @@ type Manager interface {
 	GetPendingApprovals(ctx context.Context, sessionID string) ([]*store.Approval, error)
 	GetApproval(ctx context.Context, id string) (*store.Approval, error)
 
+	// Reattach a pending approval to the tool use of a session that asked for it again
+	ReattachApproval(ctx context.Context, id string, toolUseID string) (*store.Approval, error)
+
 	// Decision methods
 	ApproveToolCall(ctx context.Context, id string, comment string) error
 	DenyToolCall(ctx context.Context, id string, reason string) error
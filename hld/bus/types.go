@@ -21,6 +21,47 @@ const (
 	// Data includes: session_id, run_id, changed settings, and optional "reason" field
 	// For dangerous skip permissions expiry: reason="expired", expired_at=timestamp
 	EventSessionSettingsChanged EventType = "session_settings_changed"
+	// EventAssistantDelta carries incremental assistant output for sessions launched with
+	// partial messages enabled. It is not persisted; the complete message follows as a
+	// conversation_updated event.
+	// Data includes: session_id, run_id, message_id, index, block_type, delta_type and one of
+	// text, thinking or partial_json
+	EventAssistantDelta EventType = "assistant_delta"
+	// EventHookReceived carries a Claude hook payload for sessions launched with
+	// forward_hooks enabled.
+	// Data includes: session_id, run_id, hook_event_name, the tool_name and tool_use_id of
+	// tool hooks, and the full payload
+	EventHookReceived EventType = "hook_received"
+	// EventSessionQueueUpdated reports the position of a session waiting for a slot under
+	// the concurrency limits. It is published for every queued session whenever the queue
+	// changes.
+	// Data includes: session_id, run_id, position (1-based), queue_length and priority
+	EventSessionQueueUpdated EventType = "session_queue_updated"
+	// EventPipelineRunStatusChanged indicates a pipeline run has started, completed or failed,
+	// or was resumed by retrying a step.
+	// Data includes: pipeline_id, pipeline_run_id, new_status, the old_status of existing runs
+	// and the error of failed runs
+	EventPipelineRunStatusChanged EventType = "pipeline_run_status_changed"
+	// EventPipelineStepStatusChanged indicates a step of a pipeline run has changed status.
+	// Data includes: pipeline_id, pipeline_run_id, step, attempt, old_status, new_status, the
+	// session_id of launched steps and the error of failed steps
+	EventPipelineStepStatusChanged EventType = "pipeline_step_status_changed"
+	// EventSessionBudgetExceeded indicates a session crossed one of its budget limits and
+	// is being interrupted.
+	// Data includes: session_id, run_id, limit (max_cost_usd, max_output_tokens or
+	// max_wall_clock), value, threshold and message
+	EventSessionBudgetExceeded EventType = "session_budget_exceeded"
+	// EventSessionStalled indicates a running session went without events for the stall
+	// timeout. Its stall policy then decides whether it is interrupted or killed and resumed.
+	// Data includes: session_id, run_id, last_activity_at, idle_seconds and policy
+	EventSessionStalled EventType = "session_stalled"
+	// EventSessionStallCleared indicates a stalled session produced events again
+	// Data includes: session_id, run_id
+	EventSessionStallCleared EventType = "session_stall_cleared"
+	// EventSessionRetryScheduled indicates a session failed with a transient error and will
+	// be resumed in a new session once retry_at passes.
+	// Data includes: session_id, run_id, attempt, max_attempts, retry_at and error_kind
+	EventSessionRetryScheduled EventType = "session_retry_scheduled"
 )
 
 // SessionSettingsChangeReason represents reasons for session settings changes
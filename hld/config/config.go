@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -43,6 +44,26 @@ type Config struct {
 
 	// Claude configuration
 	ClaudePath string `mapstructure:"claude_path"`
+
+	// Pricing configuration: a JSON file of per-model prices that extends
+	// the built-in Anthropic prices, e.g. for proxied models
+	PricingFile string `mapstructure:"pricing_file"`
+
+	// Scheduling: sessions over these limits are queued until a running
+	// session ends. Zero means unlimited.
+	MaxConcurrentSessions   int `mapstructure:"max_concurrent_sessions"`
+	MaxSessionsPerDirectory int `mapstructure:"max_sessions_per_directory"`
+
+	// Retries: sessions that fail with a transient error are resumed up to
+	// RetryMaxAttempts times, waiting RetryBackoff before the first retry and
+	// twice as long before each one after it. Launches can set their own.
+	RetryMaxAttempts int           `mapstructure:"retry_max_attempts"`
+	RetryBackoff     time.Duration `mapstructure:"retry_backoff"`
+
+	// Recovery: what happens on startup to sessions that were active when
+	// the daemon went down. fail marks them failed, offer leaves them
+	// interrupted to be resumed with one click, auto resumes them.
+	RecoveryMode string `mapstructure:"recovery_mode"`
 }
 
 // Load loads configuration with priority: flags > env vars > config file > defaults
@@ -72,6 +93,12 @@ func Load() (*Config, error) {
 	_ = v.BindEnv("http_port", "HUMANLAYER_DAEMON_HTTP_PORT")
 	_ = v.BindEnv("http_host", "HUMANLAYER_DAEMON_HTTP_HOST")
 	_ = v.BindEnv("claude_path", "HUMANLAYER_CLAUDE_PATH")
+	_ = v.BindEnv("pricing_file", "HUMANLAYER_PRICING_FILE")
+	_ = v.BindEnv("max_concurrent_sessions", "HUMANLAYER_MAX_CONCURRENT_SESSIONS")
+	_ = v.BindEnv("max_sessions_per_directory", "HUMANLAYER_MAX_SESSIONS_PER_DIRECTORY")
+	_ = v.BindEnv("retry_max_attempts", "HUMANLAYER_RETRY_MAX_ATTEMPTS")
+	_ = v.BindEnv("retry_backoff", "HUMANLAYER_RETRY_BACKOFF")
+	_ = v.BindEnv("recovery_mode", "HUMANLAYER_RECOVERY_MODE")
 
 	// Set defaults
 	setDefaults(v)
@@ -93,6 +120,7 @@ func Load() (*Config, error) {
 	config.SocketPath = expandHome(config.SocketPath)
 	config.DatabasePath = expandHome(config.DatabasePath)
 	config.ClaudePath = expandHome(config.ClaudePath)
+	config.PricingFile = expandHome(config.PricingFile)
 
 	return &config, nil
 }
@@ -112,6 +140,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("http_port", port)
 	v.SetDefault("http_host", "127.0.0.1")
 	v.SetDefault("claude_path", DefaultClaudePath)
+	v.SetDefault("recovery_mode", "offer")
 }
 
 // getDefaultConfigDir returns the default configuration directory
@@ -148,6 +177,11 @@ func (c *Config) Validate() error {
 	if c.SocketPath == "" {
 		return fmt.Errorf("socket path cannot be empty")
 	}
+	switch c.RecoveryMode {
+	case "", "fail", "offer", "auto":
+	default:
+		return fmt.Errorf("invalid recovery_mode %q: must be fail, offer or auto", c.RecoveryMode)
+	}
 	return nil
 }
 
@@ -178,6 +212,12 @@ func Save(cfg *Config) error {
 	v.Set("http_port", cfg.HTTPPort)
 	v.Set("http_host", cfg.HTTPHost)
 	v.Set("claude_path", cfg.ClaudePath)
+	v.Set("pricing_file", cfg.PricingFile)
+	v.Set("max_concurrent_sessions", cfg.MaxConcurrentSessions)
+	v.Set("max_sessions_per_directory", cfg.MaxSessionsPerDirectory)
+	v.Set("retry_max_attempts", cfg.RetryMaxAttempts)
+	v.Set("retry_backoff", cfg.RetryBackoff.String())
+	v.Set("recovery_mode", cfg.RecoveryMode)
 
 	// Set config file path explicitly
 	configFile := filepath.Join(configDir, "humanlayer.json")
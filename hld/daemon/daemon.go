@@ -49,6 +49,52 @@ func getPermissionMonitorInterval() time.Duration {
 	return 30 * time.Second
 }
 
+// getScheduleRunnerInterval returns how often due schedules are checked
+func getScheduleRunnerInterval() time.Duration {
+	if intervalStr := os.Getenv("HLD_SCHEDULE_RUNNER_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			return interval
+		}
+		slog.Warn("invalid HLD_SCHEDULE_RUNNER_INTERVAL, using default", "value", intervalStr)
+	}
+	return 30 * time.Second
+}
+
+// getPipelineRunnerInterval returns how often running pipelines are checked
+// for step sessions that ended without an event, like while the daemon was down
+func getPipelineRunnerInterval() time.Duration {
+	if intervalStr := os.Getenv("HLD_PIPELINE_RUNNER_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			return interval
+		}
+		slog.Warn("invalid HLD_PIPELINE_RUNNER_INTERVAL, using default", "value", intervalStr)
+	}
+	return 30 * time.Second
+}
+
+// getStallTimeout returns how long a running session may go without events
+// before it is flagged as stalled
+func getStallTimeout() time.Duration {
+	if timeoutStr := os.Getenv("HLD_STALL_TIMEOUT"); timeoutStr != "" {
+		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
+			return timeout
+		}
+		slog.Warn("invalid HLD_STALL_TIMEOUT, using default", "value", timeoutStr)
+	}
+	return 10 * time.Minute
+}
+
+// getStallMonitorInterval returns how often sessions are checked for stalls
+func getStallMonitorInterval() time.Duration {
+	if intervalStr := os.Getenv("HLD_STALL_MONITOR_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil {
+			return interval
+		}
+		slog.Warn("invalid HLD_STALL_MONITOR_INTERVAL, using default", "value", intervalStr)
+	}
+	return 30 * time.Second
+}
+
 // Daemon coordinates all daemon functionality
 type Daemon struct {
 	config            *config.Config
@@ -61,6 +107,9 @@ type Daemon struct {
 	eventBus          bus.EventBus
 	store             store.ConversationStore
 	permissionMonitor *session.PermissionMonitor
+	scheduleRunner    *session.ScheduleRunner
+	pipelineRunner    *session.PipelineRunner
+	stallMonitor      *session.StallMonitor
 }
 
 // New creates a new daemon instance
@@ -128,18 +177,26 @@ func New() (*Daemon, error) {
 	approvalManager := approval.NewManager(conversationStore, eventBus)
 	slog.Debug("local approval manager created successfully")
 
+	// Pipeline runs are started through the HTTP API and advanced once the daemon runs
+	pipelineRunner := session.NewPipelineRunner(conversationStore, sessionManager, eventBus, getPipelineRunnerInterval())
+
+	// Stalled sessions are flagged and handled by their stall policy once the daemon runs
+	stallMonitor := session.NewStallMonitor(sessionManager, getStallTimeout(), getStallMonitorInterval())
+
 	// Create HTTP server (always enabled, port 0 means dynamic allocation)
 	slog.Info("creating HTTP server", "port", cfg.HTTPPort)
-	httpServer := NewHTTPServer(cfg, sessionManager, approvalManager, conversationStore, eventBus)
+	httpServer := NewHTTPServer(cfg, sessionManager, approvalManager, conversationStore, eventBus, pipelineRunner)
 
 	return &Daemon{
-		config:     cfg,
-		socketPath: socketPath,
-		sessions:   sessionManager,
-		approvals:  approvalManager,
-		eventBus:   eventBus,
-		store:      conversationStore,
-		httpServer: httpServer,
+		config:         cfg,
+		socketPath:     socketPath,
+		sessions:       sessionManager,
+		approvals:      approvalManager,
+		eventBus:       eventBus,
+		store:          conversationStore,
+		httpServer:     httpServer,
+		pipelineRunner: pipelineRunner,
+		stallMonitor:   stallMonitor,
 	}, nil
 }
 
@@ -196,9 +253,10 @@ func (d *Daemon) Run(ctx context.Context) error {
 		d.rpcServer = rpc.NewServer()
 	}
 
-	// Mark orphaned sessions as failed (from previous daemon run)
-	if err := d.markOrphanedSessionsAsFailed(ctx); err != nil {
-		slog.Warn("failed to mark orphaned sessions as failed", "error", err)
+	// Handle sessions the previous daemon run left active
+	orphaned, err := d.recoverOrphanedSessions(ctx)
+	if err != nil {
+		slog.Warn("failed to recover orphaned sessions", "error", err)
 		// Don't fail startup for this
 	}
 
@@ -212,6 +270,27 @@ func (d *Daemon) Run(ctx context.Context) error {
 	}()
 	slog.Info("started dangerous skip permissions expiry monitor")
 
+	// Start launching scheduled sessions, catching up on runs missed while down
+	scheduleRunner := session.NewScheduleRunner(d.store, d.sessions, getScheduleRunnerInterval())
+	d.scheduleRunner = scheduleRunner
+	go func() {
+		scheduleRunner.Start(ctx)
+	}()
+
+	// Advance pipeline runs as their step sessions end
+	if d.pipelineRunner != nil {
+		go func() {
+			d.pipelineRunner.Start(ctx)
+		}()
+	}
+
+	// Flag running sessions that stopped producing events
+	if d.stallMonitor != nil {
+		go func() {
+			d.stallMonitor.Start(ctx)
+		}()
+	}
+
 	// Register subscription handlers
 	subscriptionHandlers := rpc.NewSubscriptionHandlers(d.eventBus)
 	d.rpcServer.SetSubscriptionHandlers(subscriptionHandlers)
@@ -237,6 +316,12 @@ func (d *Daemon) Run(ctx context.Context) error {
 		}()
 	}
 
+	// Resume what the previous daemon run left behind once the MCP approval
+	// server the resumed sessions connect to is being served
+	if d.sessions != nil {
+		go d.resumeOrphanedSessions(ctx, orphaned)
+	}
+
 	slog.Info("daemon started", "socket", d.socketPath, "http_enabled", d.httpServer != nil)
 
 	// Accept connections until context is cancelled
@@ -338,9 +423,111 @@ func (d *Daemon) handleConnection(ctx context.Context, conn net.Conn) {
 	slog.Debug("client disconnected", "remote", conn.RemoteAddr())
 }
 
+// recoverOrphanedSessions handles the sessions that were active when the
+// previous daemon run went down, according to the recovery mode. Their
+// processes are gone, but a session whose conversation started is left
+// interrupted so it can be resumed, and a queued session becomes a draft
+// again, which resumes its parent's conversation if it continued one. It
+// returns the sessions to resume in auto mode.
+func (d *Daemon) recoverOrphanedSessions(ctx context.Context) ([]*store.Session, error) {
+	if d.store == nil {
+		return nil, nil
+	}
+
+	mode := session.RecoveryModeOffer
+	if d.config != nil && d.config.RecoveryMode != "" {
+		mode = session.RecoveryMode(d.config.RecoveryMode)
+	}
+	if mode == session.RecoveryModeFail {
+		return nil, d.markOrphanedSessionsAsFailed(ctx)
+	}
+
+	sessions, err := d.store.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	var resume []*store.Session
+	recoverableCount := 0
+	for _, s := range sessions {
+		active := s.Status == store.SessionStatusRunning ||
+			s.Status == store.SessionStatusWaitingInput ||
+			s.Status == store.SessionStatusStarting
+		now := time.Now()
+
+		var update store.SessionUpdate
+		switch {
+		case s.Status == store.SessionStatusQueued:
+			draftStatus := store.SessionStatusDraft
+			update = store.SessionUpdate{Status: &draftStatus}
+		case active && s.ClaudeSessionID != "":
+			interruptedStatus := store.SessionStatusInterrupted
+			stopReason := session.StopReasonDaemonRestarted
+			errorMsg := "daemon restarted while session was active"
+			update = store.SessionUpdate{
+				Status:       &interruptedStatus,
+				CompletedAt:  &now,
+				StopReason:   &stopReason,
+				ErrorMessage: &errorMsg,
+			}
+		case active:
+			// Without a conversation there is nothing to resume
+			failedStatus := store.SessionStatusFailed
+			errorMsg := "daemon restarted before session started"
+			update = store.SessionUpdate{
+				Status:       &failedStatus,
+				CompletedAt:  &now,
+				ErrorMessage: &errorMsg,
+			}
+		default:
+			continue
+		}
+
+		if err := d.store.UpdateSession(ctx, s.ID, update); err != nil {
+			slog.Error("failed to update orphaned session",
+				"session_id", s.ID,
+				"error", err)
+			continue
+		}
+		if *update.Status == store.SessionStatusFailed {
+			continue
+		}
+		recoverableCount++
+		if mode == session.RecoveryModeAuto {
+			resume = append(resume, s)
+		}
+	}
+
+	if recoverableCount > 0 {
+		slog.Info("kept orphaned sessions for recovery", "count", recoverableCount, "mode", mode)
+	}
+
+	return resume, nil
+}
+
+// resumeOrphanedSessions resumes the sessions recoverOrphanedSessions returned
+// and the retries that were pending when the previous daemon run went down
+func (d *Daemon) resumeOrphanedSessions(ctx context.Context, orphaned []*store.Session) {
+	if err := d.sessions.ResumePendingRetries(ctx); err != nil {
+		slog.Error("failed to resume pending retries", "error", err)
+	}
+
+	for _, s := range orphaned {
+		if s.Status == store.SessionStatusQueued {
+			if err := d.sessions.LaunchDraftSession(ctx, s.ID, s.Query, false); err != nil {
+				slog.Error("failed to relaunch queued session", "session_id", s.ID, "error", err)
+			}
+			continue
+		}
+		if _, err := d.sessions.RecoverSession(ctx, s.ID); err != nil {
+			slog.Error("failed to recover session", "session_id", s.ID, "error", err)
+		}
+	}
+}
+
 // markOrphanedSessionsAsFailed marks any sessions that were running or waiting
 // when the daemon restarted as failed. Sessions with status interrupting, interrupted,
-// completed, or failed are left as-is.
+// completed, or failed are left as-is. Used in the fail recovery mode.
 func (d *Daemon) markOrphanedSessionsAsFailed(ctx context.Context) error {
 	if d.store == nil {
 		return nil
@@ -354,14 +541,18 @@ func (d *Daemon) markOrphanedSessionsAsFailed(ctx context.Context) error {
 
 	orphanedCount := 0
 	for _, session := range sessions {
-		// Mark only truly orphaned sessions as failed (running, waiting_input, starting).
+		// Mark only truly orphaned sessions as failed (running, waiting_input, starting, queued).
 		// Sessions with status interrupting, interrupted, completed, or failed are left as-is
 		// to allow interrupted sessions to be resumed after daemon restart.
 		if session.Status == store.SessionStatusRunning ||
 			session.Status == store.SessionStatusWaitingInput ||
-			session.Status == store.SessionStatusStarting {
+			session.Status == store.SessionStatusStarting ||
+			session.Status == store.SessionStatusQueued {
 			failedStatus := store.SessionStatusFailed
 			errorMsg := "daemon restarted while session was active"
+			if session.Status == store.SessionStatusQueued {
+				errorMsg = "daemon restarted while session was queued"
+			}
 			now := time.Now()
 			update := store.SessionUpdate{
 				Status:       &failedStatus,
@@ -2,8 +2,11 @@ package daemon
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
+	"github.com/humanlayer/humanlayer/hld/config"
+	"github.com/humanlayer/humanlayer/hld/session"
 	"github.com/humanlayer/humanlayer/hld/store"
 	"go.uber.org/mock/gomock"
 )
@@ -98,3 +101,83 @@ func TestDaemon_MarkOrphanedSessions_NoStore(t *testing.T) {
 		t.Fatalf("expected no error with nil store, got: %v", err)
 	}
 }
+
+func TestDaemon_RecoverOrphanedSessions(t *testing.T) {
+	sessions := []*store.Session{
+		{ID: "sess-running", Status: store.SessionStatusRunning, ClaudeSessionID: "claude-running"},
+		{ID: "sess-waiting", Status: store.SessionStatusWaitingInput, ClaudeSessionID: "claude-waiting"},
+		{ID: "sess-starting", Status: store.SessionStatusStarting},
+		{ID: "sess-queued", Status: store.SessionStatusQueued},
+		{ID: "sess-completed", Status: store.SessionStatusCompleted, ClaudeSessionID: "claude-completed"},
+		{ID: "sess-interrupted", Status: store.SessionStatusInterrupted, ClaudeSessionID: "claude-interrupted"},
+	}
+	// Statuses the orphaned sessions are left in, whatever the mode
+	expected := map[string]string{
+		"sess-running":  store.SessionStatusInterrupted,
+		"sess-waiting":  store.SessionStatusInterrupted,
+		"sess-starting": store.SessionStatusFailed, // No conversation to resume
+		"sess-queued":   store.SessionStatusDraft,
+	}
+
+	for _, tc := range []struct {
+		mode   string
+		resume []string
+	}{
+		{"offer", nil},
+		{"auto", []string{"sess-running", "sess-waiting", "sess-queued"}},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := store.NewMockConversationStore(ctrl)
+			mockStore.EXPECT().ListSessions(gomock.Any()).Return(sessions, nil)
+			for id, status := range expected {
+				mockStore.EXPECT().
+					UpdateSession(gomock.Any(), id, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id string, update store.SessionUpdate) error {
+						if update.Status == nil || *update.Status != status {
+							t.Errorf("expected %s to be updated to %s, got %v", id, status, update.Status)
+						}
+						if status == store.SessionStatusInterrupted &&
+							(update.StopReason == nil || *update.StopReason != session.StopReasonDaemonRestarted) {
+							t.Errorf("expected %s to record the daemon restart, got %v", id, update.StopReason)
+						}
+						return nil
+					})
+			}
+
+			d := &Daemon{
+				config: &config.Config{RecoveryMode: tc.mode},
+				store:  mockStore,
+			}
+			resume, err := d.recoverOrphanedSessions(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, s := range resume {
+				ids = append(ids, s.ID)
+			}
+			if !reflect.DeepEqual(ids, tc.resume) {
+				t.Errorf("expected to resume %v, got %v", tc.resume, ids)
+			}
+		})
+	}
+}
+
+func TestDaemon_ResumeOrphanedSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSessions := session.NewMockSessionManager(ctrl)
+
+	gomock.InOrder(
+		mockSessions.EXPECT().ResumePendingRetries(gomock.Any()).Return(nil),
+		mockSessions.EXPECT().RecoverSession(gomock.Any(), "sess-running").Return(&session.Session{ID: "sess-resumed"}, nil),
+		mockSessions.EXPECT().LaunchDraftSession(gomock.Any(), "sess-queued", "queued query", false).Return(nil),
+	)
+
+	d := &Daemon{sessions: mockSessions}
+	d.resumeOrphanedSessions(context.Background(), []*store.Session{
+		{ID: "sess-running", Status: store.SessionStatusRunning},
+		{ID: "sess-queued", Status: store.SessionStatusQueued, Query: "queued query"},
+	})
+}
@@ -51,6 +51,9 @@ type HTTPServer struct {
 	agentHandlers    *handlers.AgentHandlers
 	folderHandlers   *handlers.FolderHandlers
 	thoughtHandlers  *handlers.ThoughtHandlers
+	scheduleHandlers *handlers.ScheduleHandlers
+	templateHandlers *handlers.TemplateHandlers
+	pipelineHandlers *handlers.PipelineHandlers
 	approvalManager  approval.Manager
 	eventBus         bus.EventBus
 
@@ -65,6 +68,7 @@ func NewHTTPServer(
 	approvalManager approval.Manager,
 	conversationStore store.ConversationStore,
 	eventBus bus.EventBus,
+	pipelineRunner *session.PipelineRunner,
 ) *HTTPServer {
 	// Set Gin mode to release
 	gin.SetMode(gin.ReleaseMode)
@@ -100,6 +104,9 @@ func NewHTTPServer(
 	agentHandlers := handlers.NewAgentHandlers()
 	folderHandlers := handlers.NewFolderHandlers(conversationStore)
 	thoughtHandlers := handlers.NewThoughtHandlers()
+	scheduleHandlers := handlers.NewScheduleHandlers(conversationStore)
+	templateHandlers := handlers.NewTemplateHandlers(conversationStore)
+	pipelineHandlers := handlers.NewPipelineHandlers(conversationStore, pipelineRunner)
 
 	return &HTTPServer{
 		config:           cfg,
@@ -115,6 +122,9 @@ func NewHTTPServer(
 		agentHandlers:    agentHandlers,
 		folderHandlers:   folderHandlers,
 		thoughtHandlers:  thoughtHandlers,
+		scheduleHandlers: scheduleHandlers,
+		templateHandlers: templateHandlers,
+		pipelineHandlers: pipelineHandlers,
 		approvalManager:  approvalManager,
 		eventBus:         eventBus,
 	}
@@ -123,7 +133,7 @@ func NewHTTPServer(
 // Start starts the HTTP server
 func (s *HTTPServer) Start(ctx context.Context) error {
 	// Create server implementation combining all handlers
-	serverImpl := handlers.NewServerImpl(s.sessionHandlers, s.approvalHandlers, s.fileHandlers, s.sseHandler, s.settingsHandlers, s.agentHandlers, s.folderHandlers, s.thoughtHandlers)
+	serverImpl := handlers.NewServerImpl(s.sessionHandlers, s.approvalHandlers, s.fileHandlers, s.sseHandler, s.settingsHandlers, s.agentHandlers, s.folderHandlers, s.thoughtHandlers, s.scheduleHandlers, s.templateHandlers, s.pipelineHandlers)
 
 	// Create strict handler with middleware
 	strictHandler := api.NewStrictHandler(serverImpl, nil)
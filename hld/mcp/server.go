@@ -7,10 +7,12 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"reflect"
 	"sync"
 
 	"github.com/humanlayer/humanlayer/hld/approval"
 	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -135,11 +137,16 @@ func (s *MCPServer) handleRequestApproval(ctx context.Context, request mcp.CallT
 		return nil, fmt.Errorf("failed to marshal input: %w", err)
 	}
 
-	// Create approval with tool_use_id
-	approval, err := s.approvalManager.CreateApprovalWithToolUseID(ctx, sessionID, toolName, inputJSON, toolUseID)
-	if err != nil {
-		slog.Error("Failed to create approval", "error", err)
-		return nil, fmt.Errorf("failed to create approval: %w", err)
+	// A session resumed after a daemon restart asks again for the approvals
+	// it was waiting on, which were carried over to it
+	approval := s.recoveredApproval(ctx, sessionID, toolName, inputJSON, toolUseID)
+	if approval == nil {
+		// Create approval with tool_use_id
+		approval, err = s.approvalManager.CreateApprovalWithToolUseID(ctx, sessionID, toolName, inputJSON, toolUseID)
+		if err != nil {
+			slog.Error("Failed to create approval", "error", err)
+			return nil, fmt.Errorf("failed to create approval: %w", err)
+		}
 	}
 
 	slog.Info("Created approval", "approval_id", approval.ID, "status", approval.Status)
@@ -201,6 +208,45 @@ func (s *MCPServer) handleRequestApproval(ctx context.Context, request mcp.CallT
 	}
 }
 
+// recoveredApproval returns the session's pending approval for the same tool
+// call that no request is waiting on, reattached to the new tool use. Those
+// are approvals a session was waiting on when the daemon went down.
+func (s *MCPServer) recoveredApproval(ctx context.Context, sessionID, toolName string, inputJSON json.RawMessage, toolUseID string) *store.Approval {
+	pending, err := s.approvalManager.GetPendingApprovals(ctx, sessionID)
+	if err != nil {
+		slog.Warn("Failed to look up recovered approvals", "session_id", sessionID, "error", err)
+		return nil
+	}
+	for _, candidate := range pending {
+		if candidate.ToolUseID == nil || *candidate.ToolUseID == toolUseID || candidate.ToolName != toolName {
+			continue
+		}
+		if _, waiting := s.pendingApprovals.Load(*candidate.ToolUseID); waiting {
+			continue
+		}
+		if !sameJSON(candidate.ToolInput, inputJSON) {
+			continue
+		}
+		approval, err := s.approvalManager.ReattachApproval(ctx, candidate.ID, toolUseID)
+		if err != nil {
+			slog.Warn("Failed to reattach recovered approval", "approval_id", candidate.ID, "error", err)
+			return nil
+		}
+		slog.Info("Reattached recovered approval", "approval_id", approval.ID, "tool_use_id", toolUseID)
+		return approval
+	}
+	return nil
+}
+
+// sameJSON reports whether two JSON documents hold the same value
+func sameJSON(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
 func (s *MCPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Extract session_id from header and add to context
 	sessionID := r.Header.Get("X-Session-ID")
@@ -397,6 +397,7 @@ func (h *SessionHandlers) HandleGetSessionState(ctx context.Context, params json
 		CreatedAt:                  session.CreatedAt.Format(time.RFC3339),
 		LastActivityAt:             session.LastActivityAt.Format(time.RFC3339),
 		ErrorMessage:               session.ErrorMessage,
+		ErrorKind:                  session.ErrorKind,
 		AutoAcceptEdits:            session.AutoAcceptEdits,
 		DangerouslySkipPermissions: session.DangerouslySkipPermissions,
 		Archived:                   session.Archived,
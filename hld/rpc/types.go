@@ -71,6 +71,7 @@ type SessionState struct {
 	LastActivityAt                      string  `json:"last_activity_at"`
 	CompletedAt                         string  `json:"completed_at,omitempty"`
 	ErrorMessage                        string  `json:"error_message,omitempty"`
+	ErrorKind                           string  `json:"error_kind,omitempty"`
 	CostUSD                             float64 `json:"cost_usd,omitempty"`
 	InputTokens                         int     `json:"input_tokens,omitempty"`
 	OutputTokens                        int     `json:"output_tokens,omitempty"`
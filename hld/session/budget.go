@@ -0,0 +1,350 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// StopReasonBudgetExceeded is recorded on sessions the daemon interrupted
+// because they crossed one of their budget limits
+const StopReasonBudgetExceeded = "budget_exceeded"
+
+// Budget caps what a session may spend. Zero fields are unlimited.
+type Budget struct {
+	MaxCostUSD      float64
+	MaxOutputTokens int
+	MaxWallClock    time.Duration
+}
+
+// IsZero reports whether the budget sets no limit
+func (b Budget) IsZero() bool {
+	return b.MaxCostUSD == 0 && b.MaxOutputTokens == 0 && b.MaxWallClock == 0
+}
+
+// Validate rejects negative limits
+func (b Budget) Validate() error {
+	switch {
+	case b.MaxCostUSD < 0:
+		return fmt.Errorf("max_cost_usd must not be negative")
+	case b.MaxOutputTokens < 0:
+		return fmt.Errorf("max_output_tokens must not be negative")
+	case b.MaxWallClock < 0:
+		return fmt.Errorf("max_wall_clock must not be negative")
+	}
+	return nil
+}
+
+// withDefaults fills the limits b leaves unset from defaults
+func (b Budget) withDefaults(defaults Budget) Budget {
+	if b.MaxCostUSD == 0 {
+		b.MaxCostUSD = defaults.MaxCostUSD
+	}
+	if b.MaxOutputTokens == 0 {
+		b.MaxOutputTokens = defaults.MaxOutputTokens
+	}
+	if b.MaxWallClock == 0 {
+		b.MaxWallClock = defaults.MaxWallClock
+	}
+	return b
+}
+
+// applyTo records the budget's limits on a stored session
+func (b Budget) applyTo(s *store.Session) {
+	if b.MaxCostUSD > 0 {
+		s.MaxCostUSD = &b.MaxCostUSD
+	}
+	if b.MaxOutputTokens > 0 {
+		s.MaxOutputTokens = &b.MaxOutputTokens
+	}
+	if b.MaxWallClock > 0 {
+		ms := b.MaxWallClock.Milliseconds()
+		s.MaxWallClockMs = &ms
+	}
+}
+
+// sessionBudget returns the limits a stored session set for itself
+func sessionBudget(s *store.Session) Budget {
+	var b Budget
+	if s.MaxCostUSD != nil {
+		b.MaxCostUSD = *s.MaxCostUSD
+	}
+	if s.MaxOutputTokens != nil {
+		b.MaxOutputTokens = *s.MaxOutputTokens
+	}
+	if s.MaxWallClockMs != nil {
+		b.MaxWallClock = time.Duration(*s.MaxWallClockMs) * time.Millisecond
+	}
+	return b
+}
+
+// folderBudget returns the budget defaults a folder sets for its sessions
+func folderBudget(f *store.Folder) Budget {
+	var b Budget
+	if f.DefaultMaxCostUSD != nil {
+		b.MaxCostUSD = *f.DefaultMaxCostUSD
+	}
+	if f.DefaultMaxOutputTokens != nil {
+		b.MaxOutputTokens = *f.DefaultMaxOutputTokens
+	}
+	if f.DefaultMaxWallClockMs != nil {
+		b.MaxWallClock = time.Duration(*f.DefaultMaxWallClockMs) * time.Millisecond
+	}
+	return b
+}
+
+// effectiveBudget returns the limits that apply to a stored session
+func (m *Manager) effectiveBudget(ctx context.Context, s *store.Session) Budget {
+	return m.withFolderDefaults(ctx, sessionBudget(s), s.FolderID)
+}
+
+// withFolderDefaults fills the limits budget leaves unset from the defaults
+// of the folder, then of the folder's parents
+func (m *Manager) withFolderDefaults(ctx context.Context, budget Budget, folderID *string) Budget {
+	// Folders nest a few levels deep; the bound guards against parent cycles
+	for depth := 0; folderID != nil && depth < 10; depth++ {
+		folder, err := m.store.GetFolder(ctx, *folderID)
+		if err != nil {
+			slog.Error("failed to get folder for budget defaults",
+				"folder_id", *folderID,
+				"error", err)
+			break
+		}
+		if folder == nil {
+			break
+		}
+		budget = budget.withDefaults(folderBudget(folder))
+		folderID = folder.ParentID
+	}
+	return budget
+}
+
+// BudgetExceededError reports a session that can't be continued because its
+// conversation already spent one of its budget limits
+type BudgetExceededError struct {
+	Message string
+}
+
+func (e *BudgetExceededError) Error() string {
+	return e.Message
+}
+
+// budgetSpend is what a conversation spent against its budget limits
+type budgetSpend struct {
+	costUSD      float64
+	outputTokens int
+	wallClock    time.Duration
+}
+
+// add returns the combined spend of s and other
+func (s budgetSpend) add(other budgetSpend) budgetSpend {
+	return budgetSpend{
+		costUSD:      s.costUSD + other.costUSD,
+		outputTokens: s.outputTokens + other.outputTokens,
+		wallClock:    s.wallClock + other.wallClock,
+	}
+}
+
+// exceeded returns the first limit the spend reached, with the spend and the
+// limit formatted for messages. The limit is empty when the spend is within
+// the budget.
+func (b Budget) exceeded(spent budgetSpend) (limit, value, threshold string) {
+	switch {
+	case b.MaxWallClock > 0 && spent.wallClock >= b.MaxWallClock:
+		return "max_wall_clock", spent.wallClock.Round(time.Second).String(), b.MaxWallClock.String()
+	case b.MaxCostUSD > 0 && spent.costUSD >= b.MaxCostUSD:
+		return "max_cost_usd", fmt.Sprintf("$%.2f", spent.costUSD), fmt.Sprintf("$%.2f", b.MaxCostUSD)
+	case b.MaxOutputTokens > 0 && spent.outputTokens >= b.MaxOutputTokens:
+		return "max_output_tokens", fmt.Sprint(spent.outputTokens), fmt.Sprint(b.MaxOutputTokens)
+	}
+	return "", "", ""
+}
+
+// usageSpend returns the cost and output tokens of every message of a
+// session, subagents included
+func (m *Manager) usageSpend(ctx context.Context, sessionID string) (budgetSpend, error) {
+	turns, err := m.store.GetTurnUsage(ctx, sessionID)
+	if err != nil {
+		return budgetSpend{}, err
+	}
+	var spent budgetSpend
+	for _, turn := range turns {
+		if turn.CostUSD != nil {
+			spent.costUSD += *turn.CostUSD
+		}
+		spent.outputTokens += turn.OutputTokens
+	}
+	return spent, nil
+}
+
+// usageTally is the running spend of a monitored session, seeded with what
+// the sessions it continues spent, so budget checks don't reread every turn
+// of the session from the store. A message reported again replaces its
+// earlier report, as it does in the store.
+type usageTally struct {
+	budget Budget
+
+	mu       sync.Mutex
+	total    budgetSpend
+	messages map[string]budgetSpend
+	unpriced map[string]bool // Models already warned about
+}
+
+func newUsageTally(budget Budget, spent budgetSpend) *usageTally {
+	return &usageTally{
+		budget:   budget,
+		total:    spent,
+		messages: make(map[string]budgetSpend),
+		unpriced: make(map[string]bool),
+	}
+}
+
+// record adds a message's usage to the tally
+func (t *usageTally) record(turn *store.TurnUsage) {
+	spend := budgetSpend{outputTokens: turn.OutputTokens}
+	if turn.CostUSD != nil {
+		spend.costUSD = *turn.CostUSD
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous := t.messages[turn.MessageID]
+	t.total.costUSD += spend.costUSD - previous.costUSD
+	t.total.outputTokens += spend.outputTokens - previous.outputTokens
+	t.messages[turn.MessageID] = spend
+
+	// An unpriced message costs nothing as far as the budget knows
+	if turn.CostUSD == nil && t.budget.MaxCostUSD > 0 && !t.unpriced[turn.Model] {
+		t.unpriced[turn.Model] = true
+		slog.Warn("no price for model, its messages don't count against the session's cost limit",
+			"session_id", turn.SessionID,
+			"model", turn.Model,
+			"max_cost_usd", t.budget.MaxCostUSD)
+	}
+}
+
+// spent returns the spend so far, wall clock time aside
+func (t *usageTally) spent() budgetSpend {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// chainSpend returns what a session and the sessions it continues spent, so
+// continuing, retrying or recovering a conversation doesn't reset its budget.
+// Each session's wall clock time is how long it ran, as reported by Claude,
+// or else from its creation to its completion.
+func (m *Manager) chainSpend(ctx context.Context, s *store.Session) budgetSpend {
+	var total budgetSpend
+	visited := make(map[string]bool)
+	for s != nil && !visited[s.ID] {
+		visited[s.ID] = true
+		spent, err := m.usageSpend(ctx, s.ID)
+		if err != nil {
+			slog.Error("failed to get turn usage for budget", "session_id", s.ID, "error", err)
+		}
+		switch {
+		case s.DurationMS != nil:
+			spent.wallClock = time.Duration(*s.DurationMS) * time.Millisecond
+		case s.CompletedAt != nil:
+			spent.wallClock = s.CompletedAt.Sub(s.CreatedAt)
+		}
+		total = total.add(spent)
+
+		if s.ParentSessionID == "" {
+			break
+		}
+		parent, err := m.store.GetSession(ctx, s.ParentSessionID)
+		if err != nil {
+			slog.Error("failed to get parent session for budget",
+				"session_id", s.ID,
+				"parent_session_id", s.ParentSessionID,
+				"error", err)
+			break
+		}
+		s = parent
+	}
+	return total
+}
+
+// checkBudget interrupts a running session that has crossed one of the
+// limits it was launched with. spent is the cost and output tokens of every
+// message of the session, subagents included, and what the sessions it
+// continues spent; wall clock time counts from when Claude started, on top of
+// how long those sessions ran.
+func (m *Manager) checkBudget(ctx context.Context, sessionID string, budget Budget, spent budgetSpend, startTime time.Time) {
+	if budget.IsZero() {
+		return
+	}
+	total := spent
+	total.wallClock += time.Since(startTime)
+	limit, value, threshold := budget.exceeded(total)
+	if limit == "" {
+		return
+	}
+
+	dbSession, err := m.store.GetSession(ctx, sessionID)
+	if err != nil || dbSession == nil {
+		return
+	}
+	switch Status(dbSession.Status) {
+	case StatusRunning, StatusWaitingInput:
+	default:
+		return
+	}
+	if dbSession.StopReason != "" {
+		return
+	}
+
+	message := fmt.Sprintf("budget exceeded: %s reached %s, limit %s", limit, value, threshold)
+	slog.Warn("interrupting session over budget",
+		"session_id", sessionID,
+		"limit", limit,
+		"value", value,
+		"threshold", threshold)
+
+	// Record the reason first so the interrupted session reports it, and so
+	// later events don't interrupt it again
+	reason := StopReasonBudgetExceeded
+	if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{
+		StopReason:   &reason,
+		ErrorMessage: &message,
+	}); err != nil {
+		slog.Error("failed to record budget stop reason", "session_id", sessionID, "error", err)
+	}
+	if err := m.InterruptSession(ctx, sessionID); err != nil {
+		slog.Error("failed to interrupt session over budget", "session_id", sessionID, "error", err)
+	}
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionBudgetExceeded,
+			Data: map[string]interface{}{
+				"session_id": sessionID,
+				"run_id":     dbSession.RunID,
+				"limit":      limit,
+				"value":      value,
+				"threshold":  threshold,
+				"message":    message,
+			},
+		})
+	}
+}
+
+// watchWallClock checks the session's budget when its wall clock limit
+// passes, since an idle session produces no events to check it on. The
+// returned function stops the timer.
+func (m *Manager) watchWallClock(ctx context.Context, sessionID string, budget Budget, tally *usageTally, startTime time.Time) func() {
+	if budget.MaxWallClock == 0 {
+		return func() {}
+	}
+	timer := time.AfterFunc(time.Until(startTime.Add(budget.MaxWallClock-tally.spent().wallClock)), func() {
+		m.checkBudget(ctx, sessionID, budget, tally.spent(), startTime)
+	})
+	return func() { timer.Stop() }
+}
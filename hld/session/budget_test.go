@@ -0,0 +1,187 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckBudget(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+	eventBus := bus.NewEventBus()
+	events := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventSessionBudgetExceeded}})
+	manager, err := NewManager(eventBus, sqliteStore, "")
+	require.NoError(t, err)
+
+	cost, tokens := 1.0, 5000
+	require.NoError(t, sqliteStore.CreateFolder(ctx, &store.Folder{
+		ID: "folder_team", Name: "Team", DefaultMaxCostUSD: &cost, DefaultMaxOutputTokens: &tokens,
+	}))
+	parentID := "folder_team"
+	require.NoError(t, sqliteStore.CreateFolder(ctx, &store.Folder{
+		ID: "folder_nightly", Name: "Nightly", ParentID: &parentID,
+	}))
+
+	// newSession stores a running session with an active process, spending
+	// costUSD and outputTokens in one message
+	newSession := func(t *testing.T, s *store.Session, costUSD float64, outputTokens int) *MockClaudeSession {
+		s.RunID = "run_" + s.ID
+		s.Query = "q"
+		s.Status = store.SessionStatusRunning
+		require.NoError(t, sqliteStore.CreateSession(ctx, s))
+		require.NoError(t, sqliteStore.UpsertTurnUsage(ctx, &store.TurnUsage{
+			SessionID: s.ID, MessageID: "msg_" + s.ID, OutputTokens: outputTokens, CostUSD: &costUSD,
+		}))
+		process := NewMockClaudeSession(gomock.NewController(t))
+		manager.mu.Lock()
+		manager.activeProcesses[s.ID] = process
+		manager.mu.Unlock()
+		return process
+	}
+	// usageOf is what monitorSession would have tallied for the session
+	usageOf := func(sessionID string) budgetSpend {
+		spent, err := manager.usageSpend(ctx, sessionID)
+		require.NoError(t, err)
+		return spent
+	}
+	budgetOf := func(sessionID string) Budget {
+		return manager.effectiveBudget(ctx, mustGetSession(t, sqliteStore, sessionID))
+	}
+	assertStopped := func(t *testing.T, sessionID, limit string) {
+		stored, err := sqliteStore.GetSession(ctx, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, store.SessionStatusInterrupting, stored.Status)
+		assert.Equal(t, StopReasonBudgetExceeded, stored.StopReason)
+		assert.Contains(t, stored.ErrorMessage, limit)
+
+		select {
+		case event := <-events.Channel:
+			assert.Equal(t, sessionID, event.Data["session_id"])
+			assert.Equal(t, limit, event.Data["limit"])
+		case <-time.After(time.Second):
+			t.Fatal("no budget exceeded event")
+		}
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		// Nothing to check, the session isn't even looked up
+		manager.checkBudget(ctx, "unlimited", Budget{}, budgetSpend{}, time.Now())
+	})
+
+	t.Run("under its limits", func(t *testing.T) {
+		maxTokens := 1000
+		newSession(t, &store.Session{ID: "under", MaxOutputTokens: &maxTokens}, 0.5, 999)
+		manager.checkBudget(ctx, "under", budgetOf("under"), usageOf("under"), time.Now())
+
+		stored, err := sqliteStore.GetSession(ctx, "under")
+		require.NoError(t, err)
+		assert.Equal(t, store.SessionStatusRunning, stored.Status)
+		assert.Empty(t, stored.StopReason)
+	})
+
+	t.Run("output tokens", func(t *testing.T) {
+		maxTokens := 1000
+		process := newSession(t, &store.Session{ID: "tokens", MaxOutputTokens: &maxTokens}, 0.5, 1000)
+		process.EXPECT().Interrupt().Return(nil).Times(1)
+
+		manager.checkBudget(ctx, "tokens", budgetOf("tokens"), usageOf("tokens"), time.Now())
+		assertStopped(t, "tokens", "max_output_tokens")
+
+		// The session is already being stopped
+		manager.checkBudget(ctx, "tokens", budgetOf("tokens"), usageOf("tokens"), time.Now())
+	})
+
+	t.Run("folder defaults apply to limits the session leaves unset", func(t *testing.T) {
+		folderID := "folder_nightly"
+		maxTokens := 100000
+		process := newSession(t, &store.Session{
+			ID: "folder", FolderID: &folderID, MaxOutputTokens: &maxTokens,
+		}, 1.25, 6000)
+		process.EXPECT().Interrupt().Return(nil).Times(1)
+
+		assert.Equal(t, Budget{MaxCostUSD: 1, MaxOutputTokens: 100000}, budgetOf("folder"))
+
+		manager.checkBudget(ctx, "folder", budgetOf("folder"), usageOf("folder"), time.Now())
+		assertStopped(t, "folder", "max_cost_usd")
+	})
+
+	t.Run("wall clock", func(t *testing.T) {
+		maxWallClock := int64(time.Hour / time.Millisecond)
+		process := newSession(t, &store.Session{ID: "clock", MaxWallClockMs: &maxWallClock}, 0, 0)
+		process.EXPECT().Interrupt().Return(nil).Times(1)
+
+		manager.checkBudget(ctx, "clock", budgetOf("clock"), budgetSpend{}, time.Now().Add(-59*time.Minute))
+		assert.Equal(t, store.SessionStatusRunning, mustGetSession(t, sqliteStore, "clock").Status)
+
+		manager.checkBudget(ctx, "clock", budgetOf("clock"), budgetSpend{}, time.Now().Add(-61*time.Minute))
+		assertStopped(t, "clock", "max_wall_clock")
+	})
+
+	t.Run("earlier sessions of the conversation count", func(t *testing.T) {
+		maxCost := 1.0
+		newSession(t, &store.Session{ID: "first", MaxCostUSD: &maxCost, ClaudeSessionID: "claude_first"}, 0.6, 0)
+		completed := store.SessionStatusCompleted
+		durationMS := 1000
+		require.NoError(t, sqliteStore.UpdateSession(ctx, "first", store.SessionUpdate{
+			Status: &completed, DurationMS: &durationMS,
+		}))
+		process := newSession(t, &store.Session{
+			ID: "second", ParentSessionID: "first", MaxCostUSD: &maxCost,
+			ClaudeSessionID: "claude_second", WorkingDir: t.TempDir(),
+		}, 0.5, 0)
+		process.EXPECT().Interrupt().Return(nil).Times(1)
+
+		spent := manager.chainSpend(ctx, mustGetSession(t, sqliteStore, "first"))
+		assert.Equal(t, budgetSpend{costUSD: 0.6, wallClock: time.Second}, spent)
+
+		manager.checkBudget(ctx, "second", budgetOf("second"), spent.add(usageOf("second")), time.Now())
+		assertStopped(t, "second", "max_cost_usd")
+
+		// Continuing the conversation again doesn't get a fresh budget
+		require.NoError(t, sqliteStore.UpdateSession(ctx, "second", store.SessionUpdate{Status: &completed}))
+		_, err := manager.ContinueSession(ctx, ContinueSessionConfig{ParentSessionID: "second", Query: "more"})
+		var budgetErr *BudgetExceededError
+		require.ErrorAs(t, err, &budgetErr)
+		assert.Contains(t, budgetErr.Message, "max_cost_usd reached $1.10")
+	})
+}
+
+func TestUsageTally(t *testing.T) {
+	cost := func(usd float64) *float64 { return &usd }
+	tally := newUsageTally(Budget{MaxCostUSD: 1}, budgetSpend{costUSD: 0.25, outputTokens: 10, wallClock: time.Second})
+
+	tally.record(&store.TurnUsage{MessageID: "msg_1", OutputTokens: 100, CostUSD: cost(0.1)})
+	// The CLI reports a message again as more of its content arrives
+	tally.record(&store.TurnUsage{MessageID: "msg_1", OutputTokens: 300, CostUSD: cost(0.3)})
+	tally.record(&store.TurnUsage{MessageID: "msg_2", OutputTokens: 50, Model: "unpriced"})
+
+	spent := tally.spent()
+	assert.InDelta(t, 0.55, spent.costUSD, 1e-9)
+	assert.Equal(t, 360, spent.outputTokens)
+	assert.Equal(t, time.Second, spent.wallClock)
+	assert.True(t, tally.unpriced["unpriced"])
+}
+
+func mustGetSession(t *testing.T, s store.ConversationStore, id string) *store.Session {
+	t.Helper()
+	session, err := s.GetSession(context.Background(), id)
+	require.NoError(t, err)
+	return session
+}
+
+func TestBudgetValidate(t *testing.T) {
+	assert.NoError(t, Budget{}.Validate())
+	assert.NoError(t, Budget{MaxCostUSD: 1, MaxOutputTokens: 10, MaxWallClock: time.Minute}.Validate())
+	assert.EqualError(t, Budget{MaxOutputTokens: -1}.Validate(), "max_output_tokens must not be negative")
+	assert.EqualError(t, Budget{MaxWallClock: -time.Second}.Validate(), "max_wall_clock must not be negative")
+}
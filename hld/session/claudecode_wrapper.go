@@ -1,6 +1,9 @@
 package session
 
 import (
+	"context"
+	"time"
+
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 )
 
@@ -14,12 +17,19 @@ type ClaudeSession interface {
 	// Kill forcefully terminates the session process
 	Kill() error
 
+	// Stop escalates from SIGINT to SIGTERM to SIGKILL across the session's
+	// process group, allowing grace for each signal, and waits for it to exit
+	Stop(ctx context.Context, grace time.Duration) error
+
 	// GetID returns the session ID
 	GetID() string
 
 	// Wait blocks until the session completes and returns the result
 	Wait() (*claudecode.Result, error)
 
+	// WaitContext blocks until the session completes or ctx is done, killing the process in the latter case
+	WaitContext(ctx context.Context) (*claudecode.Result, error)
+
 	// GetEvents returns the events channel for streaming
 	GetEvents() <-chan claudecode.StreamEvent
 }
@@ -44,6 +54,11 @@ func (w *ClaudeSessionWrapper) Kill() error {
 	return w.session.Kill()
 }
 
+// Stop implements the ClaudeSession interface
+func (w *ClaudeSessionWrapper) Stop(ctx context.Context, grace time.Duration) error {
+	return w.session.Stop(ctx, grace)
+}
+
 // GetID implements the ClaudeSession interface
 func (w *ClaudeSessionWrapper) GetID() string {
 	return w.session.ID
@@ -54,6 +69,11 @@ func (w *ClaudeSessionWrapper) Wait() (*claudecode.Result, error) {
 	return w.session.Wait()
 }
 
+// WaitContext implements the ClaudeSession interface
+func (w *ClaudeSessionWrapper) WaitContext(ctx context.Context) (*claudecode.Result, error) {
+	return w.session.WaitContext(ctx)
+}
+
 // GetEvents implements the ClaudeSession interface
 func (w *ClaudeSessionWrapper) GetEvents() <-chan claudecode.StreamEvent {
 	return w.session.Events
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+	} {
+		_, err := parseCron(expr)
+		assert.Error(t, err, "expected %q to be rejected", expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Wednesday 2026-01-14 10:30
+	from := time.Date(2026, 1, 14, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 14, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 1, 18, 12, 0, 0, 0, time.UTC)},
+		{"0,30 11,13 * * *", time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		// Restricting both day fields matches either
+		{"0 0 1 * fri", time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			cron, err := parseCron(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, cron.next(from))
+		})
+	}
+}
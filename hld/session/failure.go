@@ -0,0 +1,98 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// FailureAction describes how the daemon responds to a failed Claude run
+type FailureAction string
+
+const (
+	FailureActionRetry  FailureAction = "retry"  // Transient failure, the session can be resumed after a delay
+	FailureActionFail   FailureAction = "fail"   // The run itself failed, nothing to retry
+	FailureActionNotify FailureAction = "notify" // The user needs to fix their environment before retrying
+)
+
+// classifyFailure returns the classified form of a Claude failure. Errors that
+// did not come from claudecode are reported as ErrUnknown.
+func classifyFailure(err error) *claudecode.ClaudeError {
+	var claudeErr *claudecode.ClaudeError
+	if errors.As(err, &claudeErr) {
+		return claudeErr
+	}
+	return &claudecode.ClaudeError{Kind: claudecode.ErrUnknown, Message: err.Error(), ExitCode: -1, Err: err}
+}
+
+// failureActionFor decides what to do about a failure of the given kind
+func failureActionFor(kind *claudecode.ErrorKind) FailureAction {
+	switch {
+	case kind.Retryable():
+		return FailureActionRetry
+	case kind == claudecode.ErrAuth, kind == claudecode.ErrBilling, kind == claudecode.ErrBinaryMissing,
+		kind == claudecode.ErrUnsupported:
+		return FailureActionNotify
+	default:
+		return FailureActionFail
+	}
+}
+
+// failSession marks a session failed with errorMsg, recording the kind of err
+// and publishing the failure so clients can surface it
+func (m *Manager) failSession(ctx context.Context, sessionID, runID, errorMsg string, err error) {
+	claudeErr := classifyFailure(err)
+	action := failureActionFor(claudeErr.Kind)
+
+	slog.Warn("session failed",
+		"session_id", sessionID,
+		"error_kind", claudeErr.Kind.String(),
+		"action", action,
+		"retry_after", claudeErr.RetryAfter)
+
+	status := string(StatusFailed)
+	errorKind := claudeErr.Kind.String()
+	now := time.Now()
+	update := store.SessionUpdate{
+		Status:       &status,
+		ErrorMessage: &errorMsg,
+		ErrorKind:    &errorKind,
+		CompletedAt:  &now,
+	}
+	if updateErr := m.store.UpdateSession(ctx, sessionID, update); updateErr != nil {
+		slog.Error("failed to update session status in database", "error", updateErr)
+	}
+
+	m.mu.Lock()
+	delete(m.activeProcesses, sessionID)
+	m.mu.Unlock()
+	m.scheduler.release(sessionID)
+	m.pendingQueries.Delete(sessionID)
+	m.pendingRecoveries.Delete(sessionID)
+
+	// Record the pending retry before announcing the failure, so a pipeline
+	// reacting to it waits for the retry
+	m.scheduleRetry(ctx, sessionID, err, claudeErr)
+
+	if m.eventBus != nil {
+		data := map[string]interface{}{
+			"session_id": sessionID,
+			"run_id":     runID,
+			"new_status": string(StatusFailed),
+			"error_kind": errorKind,
+			"action":     string(action),
+		}
+		if claudeErr.RetryAfter > 0 {
+			data["retry_after_ms"] = claudeErr.RetryAfter.Milliseconds()
+		}
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStatusChanged,
+			Data: data,
+		})
+	}
+}
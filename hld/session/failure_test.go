@@ -0,0 +1,88 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFailureActionFor(t *testing.T) {
+	cases := map[*claudecode.ErrorKind]FailureAction{
+		claudecode.ErrRateLimited:    FailureActionRetry,
+		claudecode.ErrOverloaded:     FailureActionRetry,
+		claudecode.ErrNetwork:        FailureActionRetry,
+		claudecode.ErrAuth:           FailureActionNotify,
+		claudecode.ErrBilling:        FailureActionNotify,
+		claudecode.ErrBinaryMissing:  FailureActionNotify,
+		claudecode.ErrUnsupported:    FailureActionNotify,
+		claudecode.ErrContextTooLong: FailureActionFail,
+		claudecode.ErrMaxTurns:       FailureActionFail,
+		claudecode.ErrUnknown:        FailureActionFail,
+	}
+	for kind, want := range cases {
+		assert.Equal(t, want, failureActionFor(kind), kind.String())
+	}
+}
+
+func TestClassifyFailure(t *testing.T) {
+	wrapped := fmt.Errorf("launch: %w", &claudecode.ClaudeError{Kind: claudecode.ErrOverloaded, Message: "overloaded"})
+	assert.Equal(t, claudecode.ErrOverloaded, classifyFailure(wrapped).Kind)
+
+	plain := errors.New("something else")
+	claudeErr := classifyFailure(plain)
+	assert.Equal(t, claudecode.ErrUnknown, claudeErr.Kind)
+	assert.ErrorIs(t, claudeErr, plain)
+}
+
+func TestFailSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockConversationStore(ctrl)
+	eventBus := bus.NewEventBus()
+	manager, err := NewManager(eventBus, mockStore, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventSessionStatusChanged}})
+
+	result := &claudecode.Result{IsError: true, Error: "API Error: 429 rate_limit_error, retry-after: 30"}
+	mockStore.EXPECT().
+		UpdateSession(gomock.Any(), "sess-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, update store.SessionUpdate) error {
+			require.NotNil(t, update.Status)
+			assert.Equal(t, string(StatusFailed), *update.Status)
+			require.NotNil(t, update.ErrorKind)
+			assert.Equal(t, "rate_limited", *update.ErrorKind)
+			require.NotNil(t, update.ErrorMessage)
+			assert.Equal(t, result.Error, *update.ErrorMessage)
+			assert.NotNil(t, update.CompletedAt)
+			return nil
+		})
+
+	// Rate limits are retried when the session's policy allows it
+	mockStore.EXPECT().
+		GetSession(gomock.Any(), "sess-1").
+		Return(&store.Session{ID: "sess-1", RunID: "run-1", ClaudeSessionID: "claude-1"}, nil)
+
+	manager.failSession(ctx, "sess-1", "run-1", result.Error, result.Err())
+
+	select {
+	case event := <-sub.Channel:
+		assert.Equal(t, "rate_limited", event.Data["error_kind"])
+		assert.Equal(t, string(FailureActionRetry), event.Data["action"])
+		assert.Equal(t, int64(30000), event.Data["retry_after_ms"])
+	case <-time.After(time.Second):
+		t.Fatal("expected a status change event")
+	}
+}
@@ -0,0 +1,220 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humanlayer/humanlayer/claudecode-go/transcript"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// ForkPointError reports a fork point that doesn't identify a message of the
+// session's conversation
+type ForkPointError struct {
+	Message string
+}
+
+func (e *ForkPointError) Error() string {
+	return e.Message
+}
+
+// findTranscript returns the transcript file of a Claude conversation,
+// looking in the working directory's project first
+func findTranscript(workingDir, claudeSessionID string) (string, error) {
+	if dir, err := transcript.ProjectDir(workingDir); err == nil {
+		path := filepath.Join(dir, claudeSessionID+".jsonl")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return transcript.FindSession(claudeSessionID)
+}
+
+// transcriptEntry is the part of a transcript line needed to truncate it
+type transcriptEntry struct {
+	Type    string `json:"type"`
+	UUID    string `json:"uuid"`
+	Message *struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// hasToolUse reports whether the entry is an assistant message calling a tool
+func (e transcriptEntry) hasToolUse() bool {
+	if e.Type != "assistant" || e.Message == nil {
+		return false
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(e.Message.Content, &blocks); err != nil {
+		return false
+	}
+	for _, block := range blocks {
+		if block.Type == "tool_use" {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateTranscript copies the transcript at src to dst as conversation
+// sessionID, up to and including the message with messageUUID. Tool calls
+// left without their results at the end of the copy are dropped too, since a
+// resumed conversation can't continue from them.
+func truncateTranscript(src, dst, messageUUID, sessionID string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	var lines [][]byte
+	var entries []transcriptEntry
+	found := false
+	reader := bufio.NewReader(in)
+	for !found {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var fields map[string]json.RawMessage
+			if jsonErr := json.Unmarshal(line, &fields); jsonErr != nil {
+				return fmt.Errorf("invalid transcript line: %w", jsonErr)
+			}
+			var entry transcriptEntry
+			_ = json.Unmarshal(line, &entry)
+
+			// The copy is a conversation of its own
+			if _, ok := fields["sessionId"]; ok {
+				fields["sessionId"], _ = json.Marshal(sessionID)
+			}
+			rewritten, jsonErr := json.Marshal(fields)
+			if jsonErr != nil {
+				return fmt.Errorf("failed to rewrite transcript line: %w", jsonErr)
+			}
+			lines = append(lines, rewritten)
+			entries = append(entries, entry)
+			found = entry.UUID == messageUUID
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read transcript: %w", err)
+		}
+	}
+	if !found {
+		return &ForkPointError{Message: fmt.Sprintf("message %s not found in the transcript", messageUUID)}
+	}
+
+	for len(entries) > 0 && entries[len(entries)-1].hasToolUse() {
+		entries = entries[:len(entries)-1]
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return &ForkPointError{Message: "nothing to fork: the conversation starts with a tool call"}
+	}
+
+	var out bytes.Buffer
+	for _, line := range lines {
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	if err := os.WriteFile(dst, out.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write forked transcript: %w", err)
+	}
+	return nil
+}
+
+// forkTranscript copies the parent's transcript, truncated after the message
+// of the fork point, into a new Claude conversation. It returns the new
+// conversation's ID, its transcript file and the fork point's event.
+func (m *Manager) forkTranscript(ctx context.Context, parent *store.Session, req ForkSessionConfig) (string, string, *store.ConversationEvent, error) {
+	events, err := m.store.GetConversation(ctx, parent.ClaudeSessionID)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	var forkPoint *store.ConversationEvent
+	for _, event := range events {
+		if (req.MessageUUID != "" && event.MessageUUID == req.MessageUUID) ||
+			(req.MessageUUID == "" && event.Sequence == req.Sequence) {
+			forkPoint = event
+			break
+		}
+	}
+	switch {
+	case forkPoint == nil && req.MessageUUID != "":
+		return "", "", nil, &ForkPointError{Message: fmt.Sprintf("no event with message UUID %s", req.MessageUUID)}
+	case forkPoint == nil:
+		return "", "", nil, &ForkPointError{Message: fmt.Sprintf("no event with sequence %d", req.Sequence)}
+	case forkPoint.MessageUUID == "":
+		return "", "", nil, &ForkPointError{Message: fmt.Sprintf(
+			"event %d has no message UUID, it was recorded before forking was supported", forkPoint.Sequence)}
+	}
+
+	workingDir := parent.WorkingDir
+	if strings.HasPrefix(workingDir, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			workingDir = strings.Replace(workingDir, "~", home, 1)
+		}
+	}
+	transcriptPath, err := findTranscript(workingDir, parent.ClaudeSessionID)
+	if err != nil {
+		return "", "", nil, err
+	}
+	forkID := uuid.New().String()
+	forkTranscript := filepath.Join(filepath.Dir(transcriptPath), forkID+".jsonl")
+	if err := truncateTranscript(transcriptPath, forkTranscript, forkPoint.MessageUUID, forkID); err != nil {
+		return "", "", nil, err
+	}
+	slog.Info("forking session",
+		"parent_session_id", parent.ID,
+		"forked_from_sequence", forkPoint.Sequence,
+		"message_uuid", forkPoint.MessageUUID,
+		"claude_session_id", forkID)
+
+	return forkID, forkTranscript, forkPoint, nil
+}
+
+// ForkSession starts a child session that replays the parent's conversation
+// only up to the given event. The parent's transcript is copied, truncated
+// after the event's message, into a new Claude conversation which the child
+// resumes; the child records the event's sequence as forked_from_sequence.
+func (m *Manager) ForkSession(ctx context.Context, req ForkSessionConfig) (*Session, error) {
+	parent, err := m.store.GetSession(ctx, req.ParentSessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent session: %w", err)
+	}
+	if parent.ClaudeSessionID == "" {
+		return nil, &ForkPointError{Message: "session has no conversation to fork"}
+	}
+
+	forkID, forkTranscript, forkPoint, err := m.forkTranscript(ctx, parent, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := forkPoint.Sequence
+	child, err := m.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID:       parent.ID,
+		Query:                 req.Query,
+		ResumeClaudeSessionID: forkID,
+		ForkedFromSequence:    &sequence,
+	})
+	if err != nil {
+		if removeErr := os.Remove(forkTranscript); removeErr != nil {
+			slog.Warn("failed to remove forked transcript", "path", forkTranscript, "error", removeErr)
+		}
+		return nil, err
+	}
+	return child, nil
+}
@@ -0,0 +1,121 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTranscript = `{"type":"summary","summary":"Fix the tests","leafUuid":"u6"}
+{"type":"user","uuid":"u1","sessionId":"parent","message":{"role":"user","content":"fix the tests"}}
+{"type":"assistant","uuid":"u2","parentUuid":"u1","sessionId":"parent","message":{"role":"assistant","content":[{"type":"text","text":"Looking"}]}}
+{"type":"assistant","uuid":"u3","parentUuid":"u2","sessionId":"parent","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{}}]}}
+{"type":"user","uuid":"u4","parentUuid":"u3","sessionId":"parent","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"ok"}]}}
+{"type":"assistant","uuid":"u5","parentUuid":"u4","sessionId":"parent","message":{"role":"assistant","content":[{"type":"text","text":"Done"}]}}
+`
+
+func transcriptUUIDs(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var uuids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry struct {
+			UUID      string `json:"uuid"`
+			SessionID string `json:"sessionId"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry.UUID != "" {
+			assert.Equal(t, "fork", entry.SessionID)
+		}
+		uuids = append(uuids, entry.UUID)
+	}
+	return uuids
+}
+
+func TestTruncateTranscript(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "parent.jsonl")
+	require.NoError(t, os.WriteFile(src, []byte(testTranscript), 0600))
+	dst := filepath.Join(dir, "fork.jsonl")
+
+	require.NoError(t, truncateTranscript(src, dst, "u4", "fork"))
+	assert.Equal(t, []string{"", "u1", "u2", "u3", "u4"}, transcriptUUIDs(t, dst))
+
+	// A tool call without its result can't be resumed from, so it is dropped
+	require.NoError(t, truncateTranscript(src, dst, "u3", "fork"))
+	assert.Equal(t, []string{"", "u1", "u2"}, transcriptUUIDs(t, dst))
+
+	err := truncateTranscript(src, dst, "missing", "fork")
+	var forkErr *ForkPointError
+	require.True(t, errors.As(err, &forkErr))
+	assert.Equal(t, "message missing not found in the transcript", err.Error())
+}
+
+func TestFindTranscript(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+
+	project := filepath.Join(configDir, "projects", "-Users-dex-src-my-app")
+	require.NoError(t, os.MkdirAll(project, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(project, "abc.jsonl"), []byte(testTranscript), 0600))
+
+	path, err := findTranscript("/Users/dex/src/my_app", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(project, "abc.jsonl"), path)
+
+	path, err = findTranscript("/somewhere/else", "abc")
+	require.NoError(t, err, "falls back to searching every project")
+	assert.Equal(t, filepath.Join(project, "abc.jsonl"), path)
+
+	_, err = findTranscript("/Users/dex/src/my_app", "nope")
+	assert.Error(t, err)
+}
+
+func TestForkSessionInvalidForkPoint(t *testing.T) {
+	ctx := context.Background()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+	manager, err := NewManager(bus.NewEventBus(), sqliteStore, "")
+	require.NoError(t, err)
+
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID: "parent", RunID: "run-parent", ClaudeSessionID: "claude-parent",
+		Status: store.SessionStatusCompleted, WorkingDir: "/repo",
+	}))
+	for _, event := range []*store.ConversationEvent{
+		{SessionID: "parent", ClaudeSessionID: "claude-parent", EventType: store.EventTypeMessage, Role: "user", Content: "old"},
+		{SessionID: "parent", ClaudeSessionID: "claude-parent", EventType: store.EventTypeMessage, Role: "assistant", Content: "hi", MessageUUID: "u2"},
+	} {
+		require.NoError(t, sqliteStore.AddConversationEvent(ctx, event))
+	}
+
+	tests := []struct {
+		name string
+		req  ForkSessionConfig
+		want string
+	}{
+		{"unknown sequence", ForkSessionConfig{Sequence: 9}, "no event with sequence 9"},
+		{"unknown message", ForkSessionConfig{MessageUUID: "u9"}, "no event with message UUID u9"},
+		{"event without uuid", ForkSessionConfig{Sequence: 1}, "event 1 has no message UUID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.ParentSessionID = "parent"
+			_, err := manager.ForkSession(ctx, tt.req)
+			var forkErr *ForkPointError
+			require.True(t, errors.As(err, &forkErr), "got %v", err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// HandleHookEvent publishes a hook payload Claude sent for a session launched
+// with ForwardHooks. Hooks report tool execution as it happens, including
+// the tool input before it runs and its raw response, which stream-json only
+// shows once the turn's message is complete.
+func (m *Manager) HandleHookEvent(ctx context.Context, sessionID string, payload map[string]interface{}) error {
+	session, err := m.store.GetSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	// A hook firing means the session is alive, even while a tool runs for
+	// long without output
+	now := time.Now()
+	if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{LastActivityAt: &now}); err != nil {
+		slog.Warn("failed to update session activity from hook", "session_id", sessionID, "error", err)
+	}
+
+	data := map[string]interface{}{
+		"session_id": sessionID,
+		"run_id":     session.RunID,
+		"payload":    payload,
+	}
+	// Lift the fields subscribers filter on out of the payload
+	for _, key := range []string{"hook_event_name", "tool_name", "tool_use_id"} {
+		if value, ok := payload[key].(string); ok {
+			data[key] = value
+		}
+	}
+
+	slog.Debug("received hook event",
+		"session_id", sessionID,
+		"hook_event_name", data["hook_event_name"],
+		"tool_name", data["tool_name"])
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventHookReceived,
+			Data: data,
+		})
+	}
+	return nil
+}
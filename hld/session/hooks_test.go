@@ -0,0 +1,80 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleHookEventPublishes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+
+	eventBus := bus.NewEventBus()
+	manager, err := NewManager(eventBus, sqliteStore, "")
+	require.NoError(t, err)
+
+	stale := time.Now().Add(-time.Hour)
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID: "sess-1", RunID: "run-1", Status: store.SessionStatusRunning, Query: "q",
+		CreatedAt: stale, LastActivityAt: stale,
+	}))
+
+	sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventHookReceived}})
+	payload := map[string]interface{}{
+		"hook_event_name": "PreToolUse",
+		"tool_name":       "Bash",
+		"tool_use_id":     "toolu_1",
+		"tool_input":      map[string]interface{}{"command": "make test"},
+	}
+	require.NoError(t, manager.HandleHookEvent(ctx, "sess-1", payload))
+
+	select {
+	case event := <-sub.Channel:
+		assert.Equal(t, "sess-1", event.Data["session_id"])
+		assert.Equal(t, "run-1", event.Data["run_id"])
+		assert.Equal(t, "PreToolUse", event.Data["hook_event_name"])
+		assert.Equal(t, "Bash", event.Data["tool_name"])
+		assert.Equal(t, "toolu_1", event.Data["tool_use_id"])
+		assert.Equal(t, payload, event.Data["payload"])
+	case <-time.After(time.Second):
+		t.Fatal("expected a hook_received event")
+	}
+
+	session, err := sqliteStore.GetSession(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.True(t, session.LastActivityAt.After(stale), "a hook counts as session activity")
+
+	assert.Error(t, manager.HandleHookEvent(ctx, "missing", payload))
+}
+
+func TestForwardedHooks(t *testing.T) {
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+
+	manager, err := NewManager(nil, sqliteStore, "")
+	require.NoError(t, err)
+	manager.SetHTTPPort(8123)
+
+	hooks := manager.forwardedHooks("sess-1")
+	for _, event := range []claudecode.HookEvent{
+		claudecode.HookPreToolUse, claudecode.HookPostToolUse, claudecode.HookUserPromptSubmit,
+		claudecode.HookStop, claudecode.HookSubagentStop,
+	} {
+		require.Len(t, hooks[event], 1, "expected a forwarding hook for %s", event)
+		command := hooks[event][0].Hooks[0].Command
+		assert.True(t, strings.Contains(command, "http://localhost:8123/api/v1/sessions/sess-1/hooks"), command)
+	}
+	require.NoError(t, claudecode.SessionConfig{Query: "q", Hooks: hooks}.Validate())
+}
@@ -18,6 +18,17 @@ import (
 	"github.com/humanlayer/humanlayer/hld/store"
 )
 
+// interruptWaitTimeout bounds how long ContinueSession waits for an interrupted
+// parent to exit before the process is killed
+const interruptWaitTimeout = 30 * time.Second
+
+// forceKillWait bounds how long shutdown waits for force killed sessions to exit
+const forceKillWait = 5 * time.Second
+
+// versionRetryInterval is how long a failed claude --version is remembered
+// before it is tried again
+const versionRetryInterval = time.Minute
+
 // Manager handles the lifecycle of Claude Code sessions
 type Manager struct {
 	activeProcesses    map[string]ClaudeSession // Maps session ID to active Claude process
@@ -26,12 +37,20 @@ type Manager struct {
 	claudeClientErr    error              // Store initialization error
 	claudePath         string             // Configured Claude path
 	lastCheckedPath    string             // Last path we checked
+	versionErr         error              // Last failed version detection, retried after versionRetryInterval
+	versionErrAt       time.Time
 	eventBus           bus.EventBus
 	store              store.ConversationStore
 	approvalReconciler ApprovalReconciler
 	pendingQueries     sync.Map // map[sessionID]query - stores queries waiting for Claude session ID
+	pendingRecoveries  sync.Map // map[sessionID]content - recovered events waiting for Claude session ID
 	socketPath         string   // Daemon socket path for MCP servers
 	httpPort           int      // HTTP server port for proxy endpoint
+	pricing            *claudecode.PricingTable
+	scheduler          *scheduler  // Concurrency limits and the launch queue
+	stalled            sync.Map    // map[sessionID]struct{} - sessions flagged as stalled, cleared by their next event
+	usageTallies       sync.Map    // map[sessionID]*usageTally - running spend of monitored sessions with a budget
+	retryDefaults      RetryPolicy // Retry policy for launches that don't set their own
 }
 
 // Compile-time check that Manager implements SessionManager
@@ -50,7 +69,11 @@ func NewManager(eventBus bus.EventBus, store store.ConversationStore, socketPath
 		eventBus:        eventBus,
 		store:           store,
 		socketPath:      socketPath,
+		pricing:         claudecode.DefaultPricing(),
+		scheduler:       newScheduler(),
 	}
+	m.scheduler.start = m.startQueued
+	m.scheduler.queueChanged = m.publishQueuePositions
 
 	// Try to initialize Claude client but don't fail if unavailable
 	m.initializeClaudeClient()
@@ -76,6 +99,21 @@ func NewManagerWithConfig(eventBus bus.EventBus, store store.ConversationStore,
 		store:           store,
 		socketPath:      socketPath,
 		claudePath:      cfg.ClaudePath, // Use configured Claude path
+		pricing:         claudecode.DefaultPricing(),
+		scheduler:       newScheduler(),
+	}
+	m.scheduler.start = m.startQueued
+	m.scheduler.queueChanged = m.publishQueuePositions
+
+	m.scheduler.setLimits(cfg.MaxConcurrentSessions, cfg.MaxSessionsPerDirectory)
+	m.retryDefaults = RetryPolicy{MaxAttempts: cfg.RetryMaxAttempts, Backoff: cfg.RetryBackoff}
+
+	if cfg.PricingFile != "" {
+		pricing, err := claudecode.LoadPricing(cfg.PricingFile)
+		if err != nil {
+			return nil, err
+		}
+		m.pricing = pricing
 	}
 
 	// Try to initialize Claude client but don't fail if unavailable
@@ -149,10 +187,15 @@ func (m *Manager) initializeClaudeClient() {
 		}
 	}
 
+	if client != nil {
+		client.SetLogger(slog.With("component", "claudecode"))
+	}
+
 	// Update state
 	m.lastCheckedPath = m.claudePath
 	m.client = client
 	m.claudeClientErr = err
+	m.versionErr = nil
 
 	if err != nil {
 		slog.Warn("Claude binary not found, sessions will not be launchable",
@@ -170,19 +213,73 @@ func (m *Manager) initializeClaudeClient() {
 func (m *Manager) getClaudeClient() (*claudecode.Client, error) {
 	m.initializeClaudeClient()
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	if m.claudeClientErr != nil {
-		return nil, fmt.Errorf("claude not available: %w", m.claudeClientErr)
+	client, clientErr := m.client, m.claudeClientErr
+	m.mu.RUnlock()
+	if clientErr != nil {
+		return nil, fmt.Errorf("claude not available: %w", clientErr)
 	}
-	return m.client, nil
+
+	// Detect the version once so launches refuse options the CLI lacks
+	if _, ok := client.Version(); !ok {
+		if _, err := m.detectVersion(client); err != nil {
+			slog.Warn("failed to detect Claude version, launch options will not be checked", "error", err)
+		}
+	}
+	return client, nil
+}
+
+// detectVersion runs claude --version for client. It runs without m.mu held,
+// since it can take seconds, and a failure is returned again without rerunning
+// it until versionRetryInterval has passed.
+func (m *Manager) detectVersion(client *claudecode.Client) (claudecode.Version, error) {
+	m.mu.RLock()
+	lastErr, lastErrAt := m.versionErr, m.versionErrAt
+	m.mu.RUnlock()
+	if lastErr != nil && time.Since(lastErrAt) < versionRetryInterval {
+		return claudecode.Version{}, lastErr
+	}
+
+	version, err := client.DetectVersion()
+	m.mu.Lock()
+	if m.client == client {
+		m.versionErr, m.versionErrAt = err, time.Now()
+	}
+	m.mu.Unlock()
+	return version, err
+}
+
+// withEventDelivery spills events to disk when monitorSession falls behind,
+// so slow database writes never stall reading the CLI's output
+func withEventDelivery(config claudecode.SessionConfig) claudecode.SessionConfig {
+	if config.OverflowPolicy == "" {
+		config.OverflowPolicy = claudecode.OverflowSpill
+	}
+	return config
+}
+
+// forwardedHooks returns hooks that POST every hook payload of the session to
+// the daemon's hooks endpoint
+func (m *Manager) forwardedHooks(sessionID string) claudecode.Hooks {
+	m.mu.RLock()
+	httpPort := m.httpPort
+	m.mu.RUnlock()
+	if httpPort == 0 {
+		httpPort = 7777 // fallback to default
+	}
+	hook := claudecode.HTTPHook(fmt.Sprintf("http://localhost:%d/api/v1/sessions/%s/hooks", httpPort, sessionID))
+	return claudecode.Hooks{}.
+		PreToolUse("", hook).
+		PostToolUse("", hook).
+		UserPromptSubmit(hook).
+		Stop(hook).
+		SubagentStop(hook)
 }
 
 // LaunchSession starts a new Claude Code session
 // TODO(0): Consider whether we need to support non-draft session creation directly in daemon post-implementation
 func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig, isDraft bool) (*Session, error) {
-	// Get Claude client (will attempt initialization if needed)
-	client, err := m.getClaudeClient()
-	if err != nil {
+	// Fail fast when Claude is not available
+	if _, err := m.getClaudeClient(); err != nil {
 		return nil, fmt.Errorf("cannot launch session: %w", err)
 	}
 	// Generate unique IDs
@@ -324,12 +421,38 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig,
 		}
 	}
 
+	// Run the session in its own worktree when asked. Drafts only record the
+	// branch; the worktree is created when the draft is launched.
+	var worktree WorktreeConfig
+	var worktreePath string
+	if config.Worktree != nil {
+		worktree = *config.Worktree
+		if worktree.Branch == "" {
+			worktree.Branch = defaultWorktreeBranch(sessionID)
+		}
+		if !isDraft {
+			var err error
+			worktree, err = resolveWorktreeConfig(ctx, claudeConfig.WorkingDir, sessionID, worktree)
+			if err != nil {
+				return nil, err
+			}
+			worktreePath, claudeConfig.WorkingDir, err = createWorktree(ctx, claudeConfig.WorkingDir, worktree)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Create session record directly in database
 	startTime := time.Now()
 
 	// Store session in database
 	dbSession := store.NewSessionFromConfig(sessionID, runID, claudeConfig)
 	dbSession.Summary = CalculateSummary(claudeConfig.Query)
+	// A caller-chosen conversation ID is known before Claude starts
+	if claudeConfig.NewSessionID != "" {
+		dbSession.ClaudeSessionID = claudeConfig.NewSessionID
+	}
 
 	// Set initial status based on isDraft
 	if isDraft {
@@ -364,7 +487,22 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig,
 		dbSession.ProxyAPIKey = config.ProxyAPIKey
 	}
 
+	dbSession.ForwardHooks = config.ForwardHooks
+	dbSession.Priority = string(config.Priority)
+	dbSession.WorktreePath = worktreePath
+	dbSession.WorktreeBranch = worktree.Branch
+	dbSession.WorktreeBaseRef = worktree.BaseRef
+	if config.FolderID != "" {
+		dbSession.FolderID = &config.FolderID
+	}
+	// Sessions record the limits they launch with, folder defaults included
+	budget := m.withFolderDefaults(ctx, config.Budget, dbSession.FolderID)
+	budget.applyTo(dbSession)
+	dbSession.StallPolicy = string(config.StallPolicy)
+	m.retryPolicyFor(config.Retry).applyTo(dbSession)
+
 	if err := m.store.CreateSession(ctx, dbSession); err != nil {
+		discardWorktree(ctx, worktreePath, worktree.Branch)
 		return nil, fmt.Errorf("failed to store session in database: %w", err)
 	}
 
@@ -408,12 +546,18 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig,
 			"has_env_key", os.Getenv("OPENROUTER_API_KEY") != "")
 	}
 
+	// Forwarded hooks are added after the session is stored, so only the
+	// caller's hooks are persisted
+	if config.ForwardHooks {
+		claudeConfig.Hooks = claudeConfig.Hooks.Merge(m.forwardedHooks(sessionID))
+	}
+
 	// Log final configuration before launching
 	var mcpServersDetail string
 	var mcpServerCount int
 	if claudeConfig.MCPConfig != nil {
 		mcpServerCount = len(claudeConfig.MCPConfig.MCPServers)
-		for name, server := range claudeConfig.MCPConfig.MCPServers {
+		for name, server := range claudeConfig.MCPConfig.Redacted().MCPServers {
 			if server.Type == "http" {
 				mcpServersDetail += fmt.Sprintf("[%s: type=http url=%s headers=%v] ", name, server.URL, server.Headers)
 			} else {
@@ -450,15 +594,133 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig,
 		"mcp_servers", mcpServerCount,
 		"mcp_servers_detail", mcpServersDetail)
 
-	// Launch Claude session (without daemon-level settings)
-	claudeSession, err := client.Launch(claudeConfig)
+	// Start Claude now, or queue the launch when the concurrency limits are
+	// reached (without daemon-level settings)
+	l := &launch{sessionID: sessionID, runID: runID, config: claudeConfig, priority: config.Priority, budget: budget}
+	queued, err := m.schedule(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+	status := StatusRunning
+	if queued {
+		status = StatusQueued
+	}
+
+	// Return minimal session info for launch response
+	return &Session{
+		ID:        sessionID,
+		RunID:     runID,
+		Status:    status,
+		StartTime: startTime,
+		Config:    claudeConfig,
+	}, nil
+}
+
+// schedule starts a stored session's Claude process, or queues it when the
+// concurrency limits are reached and reports true. Queued sessions start as
+// running sessions end.
+func (m *Manager) schedule(ctx context.Context, l *launch) (bool, error) {
+	if m.scheduler.admit(l) {
+		return false, m.startLaunch(ctx, l)
+	}
+
+	status := string(StatusQueued)
+	if err := m.store.UpdateSession(ctx, l.sessionID, store.SessionUpdate{Status: &status}); err != nil {
+		slog.Error("failed to update session status to queued", "session_id", l.sessionID, "error", err)
+	}
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStatusChanged,
+			Data: map[string]interface{}{
+				"session_id": l.sessionID,
+				"run_id":     l.runID,
+				"old_status": string(StatusStarting),
+				"new_status": string(StatusQueued),
+			},
+		})
+	}
+	slog.Info("queued session launch",
+		"session_id", l.sessionID,
+		"priority", l.priority,
+		"working_dir", l.config.WorkingDir,
+		"position", m.scheduler.position(l.sessionID))
+
+	close(l.ready)
+	m.publishQueuePositions(m.scheduler.snapshot())
+	return true, nil
+}
+
+// startQueued starts a launch the scheduler took off the queue. The request
+// that queued it is long gone, so it runs without its context.
+func (m *Manager) startQueued(l *launch) {
+	ctx := context.Background()
+
+	status := string(StatusStarting)
+	if err := m.store.UpdateSession(ctx, l.sessionID, store.SessionUpdate{Status: &status}); err != nil {
+		slog.Error("failed to update session status to starting", "session_id", l.sessionID, "error", err)
+	}
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStatusChanged,
+			Data: map[string]interface{}{
+				"session_id": l.sessionID,
+				"run_id":     l.runID,
+				"old_status": string(StatusQueued),
+				"new_status": string(StatusStarting),
+			},
+		})
+	}
+	slog.Info("starting queued session",
+		"session_id", l.sessionID,
+		"queued_for", time.Since(l.queuedAt))
+
+	if err := m.startLaunch(ctx, l); err != nil {
+		slog.Error("failed to start queued session", "session_id", l.sessionID, "error", err)
+	}
+}
+
+// publishQueuePositions publishes the position of every queued session
+func (m *Manager) publishQueuePositions(queue []*launch) {
+	if m.eventBus == nil {
+		return
+	}
+	for i, l := range queue {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionQueueUpdated,
+			Data: map[string]interface{}{
+				"session_id":   l.sessionID,
+				"run_id":       l.runID,
+				"position":     i + 1,
+				"queue_length": len(queue),
+				"priority":     string(l.priority),
+			},
+		})
+	}
+}
+
+// startLaunch starts the Claude process of a stored session that holds a
+// scheduler slot, and monitors it until it exits
+func (m *Manager) startLaunch(ctx context.Context, l *launch) error {
+	sessionID, runID := l.sessionID, l.runID
+	failure := "failed to launch Claude session"
+	if l.parentSessionID != "" {
+		failure = "failed to launch resumed Claude session"
+	}
+
+	client, err := m.getClaudeClient()
 	if err != nil {
-		slog.Error("failed to launch Claude session",
+		m.failSession(ctx, sessionID, runID, err.Error(), err)
+		return fmt.Errorf("%s: %w", failure, err)
+	}
+	claudeSession, err := client.Launch(withEventDelivery(l.config))
+	if err != nil {
+		slog.Error(failure,
 			"session_id", sessionID,
-			"error", err,
-			"config", fmt.Sprintf("%+v", claudeConfig))
-		m.updateSessionStatus(ctx, sessionID, StatusFailed, err.Error())
-		return nil, fmt.Errorf("failed to launch Claude session: %w", err)
+			"parent_session_id", l.parentSessionID,
+			"working_dir", l.config.WorkingDir,
+			"error", err)
+		m.failSession(ctx, sessionID, runID, err.Error(), err)
+		return fmt.Errorf("%s: %w", failure, err)
 	}
 
 	// Wrap the session for storage
@@ -483,31 +745,28 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig,
 
 	// Publish status change event
 	if m.eventBus != nil {
-		event := bus.Event{
-			Type: bus.EventSessionStatusChanged,
-			Data: map[string]interface{}{
-				"session_id": sessionID,
-				"run_id":     runID,
-				"old_status": string(StatusStarting),
-				"new_status": string(StatusRunning),
-			},
+		data := map[string]interface{}{
+			"session_id": sessionID,
+			"run_id":     runID,
+			"old_status": string(StatusStarting),
+			"new_status": string(StatusRunning),
 		}
-		slog.Info("publishing session status changed event",
-			"session_id", sessionID,
-			"run_id", runID,
-			"event_type", event.Type,
-			"event_data", event.Data,
-		)
-		m.eventBus.Publish(event)
+		if l.parentSessionID != "" {
+			data["parent_session_id"] = l.parentSessionID
+		}
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStatusChanged,
+			Data: data,
+		})
 	}
 
 	// Store query for injection after Claude session ID is captured
-	m.pendingQueries.Store(sessionID, claudeConfig.Query)
+	m.pendingQueries.Store(sessionID, l.config.Query)
 
 	// Monitor session lifecycle in background
-	go m.monitorSession(ctx, sessionID, runID, wrappedSession, startTime, claudeConfig)
+	go m.monitorSession(ctx, sessionID, runID, wrappedSession, now, l.config, l.budget, l.spent)
 
-	// Reconcile any existing approvals for this run_id
+	// Reconcile any existing approvals for this run_id (continuations reuse it)
 	if m.approvalReconciler != nil {
 		go func() {
 			// Give the session a moment to start (with cancellation support)
@@ -530,24 +789,26 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig,
 
 	slog.Info("launched Claude session",
 		"session_id", sessionID,
+		"parent_session_id", l.parentSessionID,
 		"run_id", runID,
-		"query", claudeConfig.Query,
-		"permission_prompt_tool", claudeConfig.PermissionPromptTool)
-
-	// Return minimal session info for launch response
-	return &Session{
-		ID:        sessionID,
-		RunID:     runID,
-		Status:    StatusRunning,
-		StartTime: startTime,
-		Config:    claudeConfig,
-	}, nil
+		"query", l.config.Query,
+		"permission_prompt_tool", l.config.PermissionPromptTool)
+	return nil
 }
 
 // monitorSession tracks the lifecycle of a Claude session
-func (m *Manager) monitorSession(ctx context.Context, sessionID, runID string, claudeSession ClaudeSession, startTime time.Time, config claudecode.SessionConfig) {
+func (m *Manager) monitorSession(ctx context.Context, sessionID, runID string, claudeSession ClaudeSession, startTime time.Time, config claudecode.SessionConfig, budget Budget, spent budgetSpend) {
 	// Get the session ID from the Claude session once available
 	var claudeSessionID string
+	// Assemblers for partial messages, only used when they are enabled
+	assemblers := make(partialAssemblers)
+	tally := newUsageTally(budget, spent)
+	if !budget.IsZero() {
+		m.usageTallies.Store(sessionID, tally)
+		defer m.usageTallies.Delete(sessionID)
+	}
+	stopWallClock := m.watchWallClock(ctx, sessionID, budget, tally, startTime)
+	defer stopWallClock()
 
 eventLoop:
 	for {
@@ -570,6 +831,18 @@ eventLoop:
 				return
 			}
 
+			// Any event, partial messages included, shows a stalled session
+			// is alive again
+			if _, stalled := m.stalled.LoadAndDelete(sessionID); stalled {
+				m.clearStall(ctx, sessionID, runID)
+			}
+
+			// Partial messages go straight to the bus, skipping the database
+			if event.Type == "stream_event" {
+				m.publishPartialEvent(sessionID, runID, assemblers, event)
+				continue
+			}
+
 			// Store raw event for debugging
 			eventJSON, err := json.Marshal(event)
 			if err != nil {
@@ -623,12 +896,21 @@ eventLoop:
 						}
 					}
 				}
+				// Mark the cut in a recovered session's own conversation
+				if content, ok := m.pendingRecoveries.LoadAndDelete(sessionID); ok {
+					m.addRecoveredEvent(ctx, sessionID, claudeSessionID, content.(string))
+				}
 			}
 
 			// Process and store event
 			if err := m.processStreamEvent(ctx, sessionID, claudeSessionID, event); err != nil {
 				slog.Error("failed to process stream event", "error", err)
 			}
+
+			// Usage only grows with assistant messages
+			if event.Type == "assistant" && event.Message != nil && event.Message.Usage != nil {
+				m.checkBudget(ctx, sessionID, budget, tally.spent(), startTime)
+			}
 		}
 	}
 
@@ -651,39 +933,19 @@ eventLoop:
 		slog.Debug("session was interrupted, marking as interrupted",
 			"session_id", sessionID,
 			"status", session.Status)
-		interruptedStatus := string(StatusInterrupted)
-		now := time.Now()
-		update := store.SessionUpdate{
-			Status:      &interruptedStatus,
-			CompletedAt: &now,
-		}
-		if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
-			slog.Error("failed to update session to interrupted status", "error", err)
-		}
-		// Publish status change event
-		if m.eventBus != nil {
-			m.eventBus.Publish(bus.Event{
-				Type: bus.EventSessionStatusChanged,
-				Data: map[string]interface{}{
-					"session_id": sessionID,
-					"run_id":     runID,
-					"old_status": string(StatusInterrupting),
-					"new_status": string(StatusInterrupted),
-				},
-			})
-		}
+		m.markInterrupted(ctx, sessionID, runID)
 	} else if err != nil {
 		slog.Error("claude process failed",
 			"session_id", sessionID,
 			"error", err.Error(),
 			"duration", endTime.Sub(startTime))
-		m.updateSessionStatus(ctx, sessionID, StatusFailed, err.Error())
+		m.failSession(ctx, sessionID, runID, err.Error(), err)
 	} else if result != nil && result.IsError {
 		slog.Error("claude process failed with error result",
 			"session_id", sessionID,
 			"error", result.Error,
 			"duration", endTime.Sub(startTime))
-		m.updateSessionStatus(ctx, sessionID, StatusFailed, result.Error)
+		m.failSession(ctx, sessionID, runID, result.Error, result.Err())
 	} else {
 		// No longer updating in-memory session
 
@@ -696,6 +958,8 @@ eventLoop:
 		if result != nil {
 			if result.CostUSD > 0 {
 				update.CostUSD = &result.CostUSD
+			} else if cost := m.computedCost(ctx, sessionID); cost != nil {
+				update.CostUSD = cost
 			}
 			duration := int(endTime.Sub(startTime).Milliseconds())
 			update.DurationMS = &duration
@@ -751,9 +1015,43 @@ eventLoop:
 	m.mu.Lock()
 	delete(m.activeProcesses, sessionID)
 	m.mu.Unlock()
+	m.scheduler.release(sessionID)
 
 	// Clean up any pending queries that weren't injected
 	m.pendingQueries.Delete(sessionID)
+	m.pendingRecoveries.Delete(sessionID)
+	m.stalled.Delete(sessionID)
+
+	// A stalled session killed by its stall policy carries on in a new session
+	if dbErr == nil && session != nil && session.StopReason == StopReasonStalled &&
+		StallPolicy(session.StallPolicy) == StallPolicyKillResume {
+		m.resumeStalled(ctx, session)
+	}
+}
+
+// markInterrupted records that an interrupted session's process has exited
+func (m *Manager) markInterrupted(ctx context.Context, sessionID, runID string) {
+	interruptedStatus := string(StatusInterrupted)
+	now := time.Now()
+	update := store.SessionUpdate{
+		Status:      &interruptedStatus,
+		CompletedAt: &now,
+	}
+	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+		slog.Error("failed to update session to interrupted status", "error", err)
+	}
+	// Publish status change event
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStatusChanged,
+			Data: map[string]interface{}{
+				"session_id": sessionID,
+				"run_id":     runID,
+				"old_status": string(StatusInterrupting),
+				"new_status": string(StatusInterrupted),
+			},
+		})
+	}
 }
 
 // updateSessionStatus updates the status of a session in the database
@@ -774,9 +1072,11 @@ func (m *Manager) updateSessionStatus(ctx context.Context, sessionID string, sta
 		m.mu.Lock()
 		delete(m.activeProcesses, sessionID)
 		m.mu.Unlock()
+		m.scheduler.release(sessionID)
 
 		// Clean up any pending queries
 		m.pendingQueries.Delete(sessionID)
+		m.pendingRecoveries.Delete(sessionID)
 	}
 	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
 		slog.Error("failed to update session status in database", "error", err)
@@ -803,6 +1103,7 @@ func (m *Manager) GetSessionInfo(sessionID string) (*Info, error) {
 		StartTime:                           dbSession.CreatedAt,
 		LastActivityAt:                      dbSession.LastActivityAt,
 		Error:                               dbSession.ErrorMessage,
+		ErrorKind:                           dbSession.ErrorKind,
 		Query:                               dbSession.Query,
 		Summary:                             dbSession.Summary,
 		Title:                               dbSession.Title,
@@ -818,6 +1119,16 @@ func (m *Manager) GetSessionInfo(sessionID string) (*Info, error) {
 		ProxyBaseURL:                        dbSession.ProxyBaseURL,
 		ProxyModelOverride:                  dbSession.ProxyModelOverride,
 		ProxyAPIKey:                         dbSession.ProxyAPIKey,
+		MaxCostUSD:                          dbSession.MaxCostUSD,
+		MaxOutputTokens:                     dbSession.MaxOutputTokens,
+		MaxWallClockMs:                      dbSession.MaxWallClockMs,
+		StopReason:                          dbSession.StopReason,
+		StallPolicy:                         dbSession.StallPolicy,
+		StalledAt:                           dbSession.StalledAt,
+		MaxRetries:                          dbSession.MaxRetries,
+		RetryCount:                          dbSession.RetryCount,
+		RetryAt:                             dbSession.RetryAt,
+		RecoveredAt:                         dbSession.RecoveredAt,
 	}
 
 	if dbSession.CompletedAt != nil {
@@ -874,6 +1185,7 @@ func (m *Manager) ListSessions() []Info {
 			StartTime:                           dbSession.CreatedAt,
 			LastActivityAt:                      dbSession.LastActivityAt,
 			Error:                               dbSession.ErrorMessage,
+			ErrorKind:                           dbSession.ErrorKind,
 			Query:                               dbSession.Query,
 			Summary:                             dbSession.Summary,
 			Title:                               dbSession.Title,
@@ -890,6 +1202,16 @@ func (m *Manager) ListSessions() []Info {
 			ProxyModelOverride:                  dbSession.ProxyModelOverride,
 			ProxyAPIKey:                         dbSession.ProxyAPIKey,
 			FolderID:                            dbSession.FolderID,
+			MaxCostUSD:                          dbSession.MaxCostUSD,
+			MaxOutputTokens:                     dbSession.MaxOutputTokens,
+			MaxWallClockMs:                      dbSession.MaxWallClockMs,
+			StopReason:                          dbSession.StopReason,
+			StallPolicy:                         dbSession.StallPolicy,
+			StalledAt:                           dbSession.StalledAt,
+			MaxRetries:                          dbSession.MaxRetries,
+			RetryCount:                          dbSession.RetryCount,
+			RetryAt:                             dbSession.RetryAt,
+			RecoveredAt:                         dbSession.RecoveredAt,
 		}
 
 		// Set end time if completed
@@ -954,6 +1276,9 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 
 	// Process token updates from assistant messages even without claudeSessionID
 	if event.Type == "assistant" && event.Message != nil && event.Message.Role == "assistant" && event.Message.Usage != nil {
+		// Every message is billed, so record usage for subagents too
+		m.recordTurnUsage(ctx, sessionID, claudeSessionID, event)
+
 		// QUICK FIX: Skip token updates for subagent events
 		// Subagents have parent_tool_use_id set at the event level
 		if event.ParentToolUseID != "" {
@@ -1129,6 +1454,7 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 						Role:            event.Message.Role,
 						Content:         content.Text,
 						ParentToolUseID: event.ParentToolUseID,
+						MessageUUID:     event.UUID,
 					}
 					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
 						return err
@@ -1168,6 +1494,7 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 						ToolName:        content.Name,
 						ToolInputJSON:   string(inputJSON),
 						ParentToolUseID: event.ParentToolUseID, // Capture from event level
+						MessageUUID:     event.UUID,
 						// We don't know yet if this needs approval - that comes from HumanLayer API
 					}
 					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
@@ -1210,6 +1537,7 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 						ToolResultForID:   content.ToolUseID,
 						ToolResultContent: content.Content.Value,
 						ParentToolUseID:   event.ParentToolUseID,
+						MessageUUID:       event.UUID,
 					}
 					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
 						return err
@@ -1257,6 +1585,7 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 						Role:            event.Message.Role,
 						Content:         content.Thinking,
 						ParentToolUseID: event.ParentToolUseID,
+						MessageUUID:     event.UUID,
 					}
 					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
 						return err
@@ -1299,6 +1628,12 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 			CostUSD:        &event.CostUSD,
 			DurationMS:     &event.DurationMS,
 		}
+		if event.CostUSD == 0 {
+			// Proxied sessions don't report a cost, so fall back to our own
+			if cost := m.computedCost(ctx, sessionID); cost != nil {
+				update.CostUSD = cost
+			}
+		}
 
 		// Process usage data from result event
 		if event.Usage != nil {
@@ -1315,6 +1650,11 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 		if event.Error != "" {
 			update.ErrorMessage = &event.Error
 		}
+		if event.IsError {
+			result := claudecode.Result{Subtype: event.Subtype, IsError: true, Error: event.Error, Result: event.Result}
+			errorKind := classifyFailure(result.Err()).Kind.String()
+			update.ErrorKind = &errorKind
+		}
 
 		return m.store.UpdateSession(ctx, sessionID, update)
 	}
@@ -1457,8 +1797,9 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		return nil, fmt.Errorf("parent session missing working_dir (cannot resume session without working directory)")
 	}
 
-	// If session is running, interrupt it and wait for completion
-	if parentSession.Status == store.SessionStatusRunning {
+	// If session is running, interrupt it and wait for completion. Forks
+	// resume a copy of the transcript, so the parent can keep running.
+	if parentSession.Status == store.SessionStatusRunning && req.ResumeClaudeSessionID == "" {
 		slog.Info("interrupting running session before resume",
 			"parent_session_id", req.ParentSessionID)
 
@@ -1472,7 +1813,10 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		m.mu.RUnlock()
 
 		if exists {
-			_, err := claudeSession.Wait()
+			// Bound the wait so a process that ignores SIGINT can't block the resume forever
+			waitCtx, cancel := context.WithTimeout(ctx, interruptWaitTimeout)
+			_, err := claudeSession.WaitContext(waitCtx)
+			cancel()
 			if err != nil {
 				slog.Debug("interrupted session exited",
 					"parent_session_id", req.ParentSessionID,
@@ -1506,6 +1850,13 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		PermissionPromptTool: parentSession.PermissionPromptTool,
 		// MaxTurns intentionally NOT inherited - let it default or be specified
 	}
+	applyStoredCLIOptions(&config, parentSession)
+
+	// Forks resume their own truncated copy of the parent's transcript
+	if req.ResumeClaudeSessionID != "" {
+		config.SessionID = req.ResumeClaudeSessionID
+		config.ForkSession = false
+	}
 
 	// Deserialize JSON arrays for tools
 	if parentSession.AllowedTools != "" {
@@ -1615,6 +1966,7 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 	dbSession := store.NewSessionFromConfig(sessionID, runID, config)
 	dbSession.ParentSessionID = req.ParentSessionID
 	dbSession.Summary = CalculateSummary(req.Query)
+	dbSession.ForkedFromSequence = req.ForkedFromSequence
 	// Inherit auto-accept setting from parent
 	dbSession.AutoAcceptEdits = parentSession.AutoAcceptEdits
 	// Inherit dangerously skip permissions from parent
@@ -1658,6 +2010,30 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		}
 	}
 
+	dbSession.ForwardHooks = parentSession.ForwardHooks
+	dbSession.Priority = parentSession.Priority
+	// Resumed sessions keep working in the parent's worktree
+	dbSession.WorktreePath = parentSession.WorktreePath
+	dbSession.WorktreeBranch = parentSession.WorktreeBranch
+	dbSession.WorktreeBaseRef = parentSession.WorktreeBaseRef
+	// Resumed sessions get the limits that applied to the parent, folder
+	// defaults included
+	budget := m.effectiveBudget(ctx, parentSession)
+	budget.applyTo(dbSession)
+	// The budget caps the whole conversation, not each session of it
+	var spent budgetSpend
+	if !budget.IsZero() {
+		spent = m.chainSpend(ctx, parentSession)
+		if limit, value, threshold := budget.exceeded(spent); limit != "" {
+			return nil, &BudgetExceededError{Message: fmt.Sprintf(
+				"cannot continue session: budget exceeded: %s reached %s, limit %s", limit, value, threshold)}
+		}
+	}
+	dbSession.StallPolicy = parentSession.StallPolicy
+	// Retries of a retry count towards the same limit
+	sessionRetryPolicy(parentSession).applyTo(dbSession)
+	dbSession.RetryCount = req.RetryCount
+
 	// Inherit additional directories from parent if not already set
 	// This ensures that directories updated on the parent session are properly inherited
 	if dbSession.AdditionalDirectories == "" || dbSession.AdditionalDirectories == "[]" {
@@ -1669,6 +2045,17 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		return nil, fmt.Errorf("failed to store session in database: %w", err)
 	}
 
+	// A failed session continued, by hand or by its retry, no longer needs
+	// its pending retry
+	if parentSession.RetryAt != nil {
+		var noRetry *time.Time
+		if err := m.store.UpdateSession(ctx, parentSession.ID, store.SessionUpdate{RetryAt: &noRetry}); err != nil {
+			slog.Warn("failed to cancel pending retry of continued session",
+				"parent_session_id", parentSession.ID,
+				"error", err)
+		}
+	}
+
 	// Re-apply MCP servers to the new session
 	// This ensures that forked sessions retain the MCP configuration
 
@@ -1767,9 +2154,12 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 			"has_openrouter_key", os.Getenv("OPENROUTER_API_KEY") != "")
 	}
 
-	// Get Claude client (will attempt initialization if needed)
-	client, err := m.getClaudeClient()
-	if err != nil {
+	if dbSession.ForwardHooks {
+		config.Hooks = config.Hooks.Merge(m.forwardedHooks(sessionID))
+	}
+
+	// Fail fast when Claude is not available
+	if _, err := m.getClaudeClient(); err != nil {
 		return nil, fmt.Errorf("cannot continue session: %w", err)
 	}
 
@@ -1784,90 +2174,29 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		"proxy_base_url", dbSession.ProxyBaseURL,
 		"proxy_model", dbSession.ProxyModelOverride)
 
-	claudeSession, err := client.Launch(config)
-	if err != nil {
-		slog.Error("failed to resume Claude session from failed parent",
-			"session_id", sessionID,
-			"parent_session_id", req.ParentSessionID,
-			"parent_status", parentSession.Status,
-			"claude_session_id", parentSession.ClaudeSessionID,
-			"error", err)
-		m.updateSessionStatus(ctx, sessionID, StatusFailed, err.Error())
-		return nil, fmt.Errorf("failed to launch resumed Claude session: %w", err)
+	l := &launch{
+		sessionID:       sessionID,
+		runID:           runID,
+		parentSessionID: req.ParentSessionID,
+		config:          config,
+		priority:        Priority(dbSession.Priority),
+		budget:          budget,
+		spent:           spent,
 	}
-
-	// Wrap the session for storage
-	wrappedSession := NewClaudeSessionWrapper(claudeSession)
-
-	// Store active Claude process
-	m.mu.Lock()
-	m.activeProcesses[sessionID] = wrappedSession
-	m.mu.Unlock()
-
-	// Update database with running status
-	statusRunning := string(StatusRunning)
-	now := time.Now()
-	update := store.SessionUpdate{
-		Status:         &statusRunning,
-		LastActivityAt: &now,
-	}
-	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
-		slog.Error("failed to update session status to running", "error", err)
+	queued, err := m.schedule(ctx, l)
+	if err != nil {
+		return nil, err
 	}
-
-	// Publish status change event
-	if m.eventBus != nil {
-		m.eventBus.Publish(bus.Event{
-			Type: bus.EventSessionStatusChanged,
-			Data: map[string]interface{}{
-				"session_id":        sessionID,
-				"run_id":            runID,
-				"parent_session_id": req.ParentSessionID,
-				"old_status":        string(StatusStarting),
-				"new_status":        string(StatusRunning),
-			},
-		})
+	status := StatusRunning
+	if queued {
+		status = StatusQueued
 	}
 
-	// Store query for injection after Claude session ID is captured
-	m.pendingQueries.Store(sessionID, req.Query)
-
-	// Monitor session lifecycle in background
-	go m.monitorSession(ctx, sessionID, runID, wrappedSession, time.Now(), config)
-
-	// Reconcile any existing approvals for this run_id (same run_id is reused for continuations)
-	if m.approvalReconciler != nil {
-		go func() {
-			// Give the session a moment to start (with cancellation support)
-			select {
-			case <-time.After(2 * time.Second):
-				// Continue with reconciliation
-			case <-ctx.Done():
-				// Context cancelled, exit early
-				return
-			}
-
-			if err := m.approvalReconciler.ReconcileApprovalsForSession(ctx, runID); err != nil {
-				slog.Error("failed to reconcile approvals for continued session",
-					"session_id", sessionID,
-					"parent_session_id", req.ParentSessionID,
-					"run_id", runID,
-					"error", err)
-			}
-		}()
-	}
-
-	slog.Info("continued Claude session",
-		"session_id", sessionID,
-		"parent_session_id", req.ParentSessionID,
-		"run_id", runID,
-		"query", req.Query)
-
 	// Return minimal session info
 	return &Session{
 		ID:        sessionID,
 		RunID:     runID,
-		Status:    StatusRunning,
+		Status:    status,
 		StartTime: time.Now(),
 		Config:    config,
 	}, nil
@@ -1875,6 +2204,13 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 
 // InterruptSession interrupts a running session
 func (m *Manager) InterruptSession(ctx context.Context, sessionID string) error {
+	// A queued session has no process yet, so it is just taken off the queue,
+	// or kept from starting if it was already taken off it
+	if m.scheduler.remove(sessionID) {
+		m.cancelQueued(ctx, sessionID)
+		return nil
+	}
+
 	// Hold lock to ensure session reference remains valid during interrupt
 	m.mu.Lock()
 	claudeSession, exists := m.activeProcesses[sessionID]
@@ -1892,6 +2228,43 @@ func (m *Manager) InterruptSession(ctx context.Context, sessionID string) error
 		return fmt.Errorf("failed to interrupt Claude session: %w", err)
 	}
 
+	m.markInterrupting(ctx, sessionID)
+	return nil
+}
+
+// cancelQueued fails a session that was interrupted before it left the queue.
+// It never ran, so there is nothing to resume.
+func (m *Manager) cancelQueued(ctx context.Context, sessionID string) {
+	status := string(StatusFailed)
+	errorMsg := "interrupted while queued"
+	now := time.Now()
+	update := store.SessionUpdate{
+		Status:       &status,
+		ErrorMessage: &errorMsg,
+		CompletedAt:  &now,
+	}
+	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+		slog.Error("failed to update queued session status after interrupt",
+			"session_id", sessionID,
+			"error", err)
+	}
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStatusChanged,
+			Data: map[string]interface{}{
+				"session_id":    sessionID,
+				"old_status":    string(StatusQueued),
+				"new_status":    string(StatusFailed),
+				"error_message": errorMsg,
+			},
+		})
+	}
+	slog.Info("removed session from the launch queue", "session_id", sessionID)
+}
+
+// markInterrupting records that a session has been signaled and is shutting down
+func (m *Manager) markInterrupting(ctx context.Context, sessionID string) {
 	// Update database to show session is interrupting after interrupt
 	status := string(StatusInterrupting)
 	now := time.Now()
@@ -1918,15 +2291,14 @@ func (m *Manager) InterruptSession(ctx context.Context, sessionID string) error
 			},
 		})
 	}
-
-	return nil
 }
 
-// launchDraftWithConfig launches a draft session using the existing launch flow
-func (m *Manager) launchDraftWithConfig(ctx context.Context, sessionID, runID string, config LaunchSessionConfig) error {
-	// Get Claude client (will attempt initialization if needed)
-	client, err := m.getClaudeClient()
-	if err != nil {
+// launchDraftWithConfig launches a draft session using the existing launch
+// flow. A draft continuing a session names its parent, and what the
+// conversation spent before it.
+func (m *Manager) launchDraftWithConfig(ctx context.Context, sessionID, runID string, config LaunchSessionConfig, parentSessionID string, spent budgetSpend) error {
+	// Fail fast when Claude is not available
+	if _, err := m.getClaudeClient(); err != nil {
 		return fmt.Errorf("cannot launch session: %w", err)
 	}
 
@@ -1998,6 +2370,10 @@ func (m *Manager) launchDraftWithConfig(ctx context.Context, sessionID, runID st
 		claudeConfig.Env["ANTHROPIC_API_KEY"] = "proxy-handled"
 	}
 
+	if config.ForwardHooks {
+		claudeConfig.Hooks = claudeConfig.Hooks.Merge(m.forwardedHooks(sessionID))
+	}
+
 	// Launch Claude session
 	slog.Info("launching draft session with Claude",
 		"session_id", sessionID,
@@ -2005,84 +2381,20 @@ func (m *Manager) launchDraftWithConfig(ctx context.Context, sessionID, runID st
 		"query", claudeConfig.Query,
 		"working_dir", claudeConfig.WorkingDir)
 
-	claudeSession, err := client.Launch(claudeConfig)
-	if err != nil {
-		slog.Error("failed to launch Claude session from draft",
-			"session_id", sessionID,
-			"error", err)
-		m.updateSessionStatus(ctx, sessionID, StatusFailed, err.Error())
-		return fmt.Errorf("failed to launch Claude session: %w", err)
-	}
-
-	// Wrap the session for storage
-	wrappedSession := NewClaudeSessionWrapper(claudeSession)
-
-	// Store active Claude process
-	m.mu.Lock()
-	m.activeProcesses[sessionID] = wrappedSession
-	m.mu.Unlock()
-
-	// Update database with running status
-	statusRunning := string(StatusRunning)
-	now := time.Now()
-	update := store.SessionUpdate{
-		Status:         &statusRunning,
-		LastActivityAt: &now,
-	}
-	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
-		slog.Error("failed to update session status to running", "error", err)
-		// Continue anyway
-	}
-
-	// Publish status change event
-	if m.eventBus != nil {
-		event := bus.Event{
-			Type: bus.EventSessionStatusChanged,
-			Data: map[string]interface{}{
-				"session_id": sessionID,
-				"run_id":     runID,
-				"old_status": string(StatusStarting),
-				"new_status": string(StatusRunning),
-			},
-		}
-		m.eventBus.Publish(event)
-	}
-
-	// Store query for injection after Claude session ID is captured
-	m.pendingQueries.Store(sessionID, claudeConfig.Query)
-
-	// Monitor session lifecycle in background
-	go m.monitorSession(ctx, sessionID, runID, wrappedSession, time.Now(), claudeConfig)
-
-	// Reconcile any existing approvals for this run_id
-	if m.approvalReconciler != nil {
-		go func() {
-			select {
-			case <-time.After(2 * time.Second):
-				// Continue with reconciliation
-			case <-ctx.Done():
-				// Context cancelled, exit early
-				return
-			}
-
-			if err := m.approvalReconciler.ReconcileApprovalsForSession(ctx, runID); err != nil {
-				slog.Error("failed to reconcile approvals for launched draft session",
-					"session_id", sessionID,
-					"run_id", runID,
-					"error", err)
-			}
-		}()
-	}
-
-	slog.Info("successfully launched draft session",
-		"session_id", sessionID,
-		"run_id", runID,
-		"query", claudeConfig.Query)
-
-	return nil
+	_, err := m.schedule(ctx, &launch{
+		sessionID:       sessionID,
+		runID:           runID,
+		parentSessionID: parentSessionID,
+		config:          claudeConfig,
+		priority:        config.Priority,
+		budget:          config.Budget,
+		spent:           spent,
+	})
+	return err
 }
 
-// LaunchDraftSession launches a draft session by transitioning it to running state
+// LaunchDraftSession launches a draft session by transitioning it to running state.
+// A draft continuing a session resumes its parent's conversation.
 func (m *Manager) LaunchDraftSession(ctx context.Context, sessionID string, prompt string, createDirectoryIfNotExists bool) error {
 	// Get the session from store
 	sess, err := m.store.GetSession(ctx, sessionID)
@@ -2145,6 +2457,64 @@ func (m *Manager) LaunchDraftSession(ctx context.Context, sessionID string, prom
 		}
 	}
 
+	// A continuation put back as a draft, like one that was queued when the
+	// daemon restarted, resumes its parent's conversation: the parent's
+	// conversation, or a fork's own copy of it
+	var resumeID string
+	var forkSession bool
+	var spent budgetSpend
+	if sess.ParentSessionID != "" {
+		parent, err := m.store.GetSession(ctx, sess.ParentSessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get parent session: %w", err)
+		}
+		if parent.ClaudeSessionID == "" {
+			return fmt.Errorf("parent session missing claude_session_id (cannot resume)")
+		}
+		if budget := m.effectiveBudget(ctx, sess); !budget.IsZero() {
+			spent = m.chainSpend(ctx, parent)
+			if limit, value, threshold := budget.exceeded(spent); limit != "" {
+				return &BudgetExceededError{Message: fmt.Sprintf(
+					"cannot continue session: budget exceeded: %s reached %s, limit %s", limit, value, threshold)}
+			}
+		}
+		resumeID, forkSession = parent.ClaudeSessionID, true
+		if sess.ForkedFromSequence != nil {
+			forkID, _, _, err := m.forkTranscript(ctx, parent, ForkSessionConfig{Sequence: *sess.ForkedFromSequence})
+			if err != nil {
+				return err
+			}
+			resumeID, forkSession = forkID, false
+		}
+	}
+
+	// Create the worktree the draft asked for; the session runs there instead
+	// of the repository it was drafted in
+	var worktree WorktreeConfig
+	var worktreePath string
+	if sess.WorktreeBranch != "" && sess.WorktreePath == "" {
+		repoDir := sess.WorkingDir
+		if strings.HasPrefix(repoDir, "~") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("failed to get home directory: %w", err)
+			}
+			repoDir = strings.Replace(repoDir, "~", home, 1)
+		}
+		var err error
+		worktree, err = resolveWorktreeConfig(ctx, repoDir, sessionID, WorktreeConfig{
+			Branch:  sess.WorktreeBranch,
+			BaseRef: sess.WorktreeBaseRef,
+		})
+		if err != nil {
+			return err
+		}
+		worktreePath, sess.WorkingDir, err = createWorktree(ctx, repoDir, worktree)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Update the query with the actual prompt and clear editor state
 	queryUpdate := prompt
 	summaryUpdate := CalculateSummary(prompt)
@@ -2158,7 +2528,14 @@ func (m *Manager) LaunchDraftSession(ctx context.Context, sessionID string, prom
 		LastActivityAt: &now,
 		EditorState:    &emptyString, // Clear editor state when launching
 	}
+	if worktreePath != "" {
+		update.WorkingDir = &sess.WorkingDir
+		update.WorktreePath = &worktreePath
+		update.WorktreeBaseRef = &worktree.BaseRef
+	}
 	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+		// Leave no worktree behind, or the draft could never be launched again
+		discardWorktree(ctx, worktreePath, worktree.Branch)
 		return fmt.Errorf("failed to update draft session: %w", err)
 	}
 
@@ -2176,6 +2553,13 @@ func (m *Manager) LaunchDraftSession(ctx context.Context, sessionID string, prom
 		PermissionPromptTool: sess.PermissionPromptTool,
 		MaxTurns:             sess.MaxTurns,
 	}
+	applyStoredCLIOptions(&claudeConfig, sess)
+	// Drafts created with a conversation ID keep it
+	claudeConfig.NewSessionID = sess.ClaudeSessionID
+	if resumeID != "" {
+		claudeConfig.SessionID = resumeID // This triggers --resume flag
+		claudeConfig.ForkSession = forkSession
+	}
 
 	// Set model if available
 	if sess.Model != "" {
@@ -2255,6 +2639,10 @@ func (m *Manager) LaunchDraftSession(ctx context.Context, sessionID string, prom
 		ProxyBaseURL:               sess.ProxyBaseURL,
 		ProxyModelOverride:         sess.ProxyModelOverride,
 		ProxyAPIKey:                sess.ProxyAPIKey,
+		ForwardHooks:               sess.ForwardHooks,
+		Priority:                   Priority(sess.Priority),
+		Budget:                     m.effectiveBudget(ctx, sess),
+		StallPolicy:                StallPolicy(sess.StallPolicy),
 	}
 
 	// If dangerously skip permissions has an expiry, calculate the timeout
@@ -2268,7 +2656,7 @@ func (m *Manager) LaunchDraftSession(ctx context.Context, sessionID string, prom
 
 	// Actually launch the session using the existing flow
 	// We need to launch it properly with Claude, not just update the database
-	return m.launchDraftWithConfig(ctx, sessionID, sess.RunID, launchConfig)
+	return m.launchDraftWithConfig(ctx, sessionID, sess.RunID, launchConfig, sess.ParentSessionID, spent)
 }
 
 // injectQueryAsFirstEvent adds the user's query as the first conversation event
@@ -2328,17 +2716,35 @@ func (m *Manager) GetClaudeBinaryPath() string {
 // GetClaudeVersion returns the Claude binary version if available
 func (m *Manager) GetClaudeVersion() (string, error) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
+	client, clientErr := m.client, m.claudeClientErr
+	m.mu.RUnlock()
 
-	if m.client == nil {
+	if client == nil {
 		return "", fmt.Errorf("claude client not available")
 	}
 
-	if m.claudeClientErr != nil {
-		return "", fmt.Errorf("claude not available: %w", m.claudeClientErr)
+	if clientErr != nil {
+		return "", fmt.Errorf("claude not available: %w", clientErr)
+	}
+
+	return client.GetVersion()
+}
+
+// DetectClaudeVersion returns the parsed Claude binary version if available
+func (m *Manager) DetectClaudeVersion() (claudecode.Version, error) {
+	m.mu.RLock()
+	client, clientErr := m.client, m.claudeClientErr
+	m.mu.RUnlock()
+
+	if client == nil {
+		return claudecode.Version{}, fmt.Errorf("claude client not available")
+	}
+
+	if clientErr != nil {
+		return claudecode.Version{}, fmt.Errorf("claude not available: %w", clientErr)
 	}
 
-	return m.client.GetVersion()
+	return m.detectVersion(client)
 }
 
 // StopAllSessions gracefully stops all active sessions with a timeout
@@ -2374,35 +2780,24 @@ func (m *Manager) StopAllSessions(timeout time.Duration) error {
 
 	slog.Info("stopping active sessions", "count", len(activeSessionsToStop))
 
-	// Interrupt all sessions
-	var wg sync.WaitGroup
-	errors := make(chan error, len(activeSessionsToStop))
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	for sessionID := range activeSessionsToStop {
-		wg.Add(1)
-		go func(id string) {
-			defer wg.Done()
-			slog.Info("sending interrupt to session",
-				"session_id", id)
-			if err := m.InterruptSession(context.Background(), id); err != nil {
-				errors <- fmt.Errorf("session %s: %w", id, err)
+	// Stop all sessions, escalating from SIGINT to SIGTERM to SIGKILL across
+	// each process group. Splitting the timeout leaves the last third for the
+	// kill, should a session ignore both signals.
+	grace := timeout / 3
+	for sessionID, claudeSession := range activeSessionsToStop {
+		slog.Info("stopping session", "session_id", sessionID)
+		m.markInterrupting(ctx, sessionID)
+		go func(id string, claudeSession ClaudeSession) {
+			if err := claudeSession.Stop(ctx, grace); err != nil {
+				slog.Error("failed to stop session", "session_id", id, "error", err)
 			}
-		}(sessionID)
-	}
-
-	// Wait for interrupts to be sent
-	wg.Wait()
-	close(errors)
-
-	// Log any errors from interrupt attempts
-	for err := range errors {
-		slog.Error("failed to interrupt session", "error", err)
+		}(sessionID, claudeSession)
 	}
 
 	// Wait for sessions to complete with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -2435,18 +2830,45 @@ func (m *Manager) StopAllSessions(timeout time.Duration) error {
 	}
 }
 
-// forceKillRemaining forcefully terminates any remaining sessions
+// forceKillRemaining forcefully terminates any remaining sessions along with
+// their process groups
 func (m *Manager) forceKillRemaining() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
+	m.mu.RLock()
+	remaining := make(map[string]ClaudeSession, len(m.activeProcesses))
 	for id, session := range m.activeProcesses {
-		slog.Warn("force killing session", "session_id", id)
-		if err := session.Kill(); err != nil {
-			slog.Error("failed to force kill session",
-				"session_id", id,
-				"error", err)
-		}
+		remaining[id] = session
+	}
+	m.mu.RUnlock()
+
+	// A canceled context makes Stop skip straight to SIGKILL
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var wg sync.WaitGroup
+	for id, session := range remaining {
+		wg.Add(1)
+		go func(id string, session ClaudeSession) {
+			defer wg.Done()
+			slog.Warn("force killing session", "session_id", id)
+			if err := session.Stop(ctx, 0); err != nil {
+				slog.Error("failed to force kill session",
+					"session_id", id,
+					"error", err)
+			}
+		}(id, session)
+	}
+
+	// Killed processes exit promptly, but don't hold up shutdown on one that
+	// cannot be reaped
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(forceKillWait):
+		slog.Warn("timed out waiting for killed sessions to exit")
 	}
 }
 
@@ -32,7 +32,7 @@ func TestStopAllSessions_Success(t *testing.T) {
 		mockSession.EXPECT().GetID().Return(sessionID).AnyTimes()
 
 		// Session should be interrupted exactly once
-		mockSession.EXPECT().Interrupt().Return(nil).Times(1)
+		mockSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 		manager.activeProcesses[sessionID] = mockSession
 
@@ -86,8 +86,8 @@ func TestStopAllSessions_Timeout(t *testing.T) {
 	mockSession := NewMockClaudeSession(ctrl)
 
 	mockSession.EXPECT().GetID().Return(stubbornID).AnyTimes()
-	mockSession.EXPECT().Interrupt().Return(nil).Times(1)
-	mockSession.EXPECT().Kill().Return(nil).Times(1) // Force kill should be attempted
+	// Stopped once, then force killed on timeout
+	mockSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(2)
 
 	manager.activeProcesses[stubbornID] = mockSession
 
@@ -125,11 +125,10 @@ func TestStopAllSessions_ConcurrentOperations(t *testing.T) {
 		mockSession := NewMockClaudeSession(ctrl)
 
 		mockSession.EXPECT().GetID().Return(sessionID).AnyTimes()
-		mockSession.EXPECT().Interrupt().DoAndReturn(func() error {
+		mockSession.EXPECT().Stop(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, time.Duration) error {
 			atomic.AddInt32(&interruptCount, 1)
 			return nil
-		}).MaxTimes(1)
-		mockSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+		}).MaxTimes(2) // May be force killed on timeout
 
 		manager.activeProcesses[sessionID] = mockSession
 
@@ -170,11 +169,10 @@ func TestStopAllSessions_ConcurrentOperations(t *testing.T) {
 			mockSession := NewMockClaudeSession(ctrl)
 
 			mockSession.EXPECT().GetID().Return(sessionID).AnyTimes()
-			mockSession.EXPECT().Interrupt().DoAndReturn(func() error {
+			mockSession.EXPECT().Stop(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, time.Duration) error {
 				atomic.AddInt32(&interruptCount, 1)
 				return nil
-			}).MaxTimes(1)
-			mockSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+			}).MaxTimes(2) // May be force killed on timeout
 
 			manager.mu.Lock()
 			manager.activeProcesses[sessionID] = mockSession
@@ -226,14 +224,14 @@ func TestStopAllSessions_InterruptError(t *testing.T) {
 	// Success session
 	mockSuccessSession := NewMockClaudeSession(ctrl)
 	mockSuccessSession.EXPECT().GetID().Return(successID).AnyTimes()
-	mockSuccessSession.EXPECT().Interrupt().Return(nil)
+	mockSuccessSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil)
 	manager.activeProcesses[successID] = mockSuccessSession
 
 	// Error session
 	mockErrorSession := NewMockClaudeSession(ctrl)
 	mockErrorSession.EXPECT().GetID().Return(errorID).AnyTimes()
-	mockErrorSession.EXPECT().Interrupt().Return(fmt.Errorf("interrupt failed"))
-	mockErrorSession.EXPECT().Kill().Return(nil) // Should attempt force kill on timeout
+	mockErrorSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(fmt.Errorf("stop failed"))
+	mockErrorSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil) // Should attempt force kill on timeout
 	manager.activeProcesses[errorID] = mockErrorSession
 
 	// Mock store expectations
@@ -254,11 +252,12 @@ func TestStopAllSessions_InterruptError(t *testing.T) {
 			return nil
 		})
 
-	// Error session expectations - GetSession fails due to interrupt error
+	// Error session expectations - it stays active after failing to stop
 	mockStore.EXPECT().GetSession(gomock.Any(), errorID).Return(&store.Session{
 		ID:     errorID,
 		Status: store.SessionStatusRunning,
 	}, nil)
+	mockStore.EXPECT().UpdateSession(gomock.Any(), errorID, gomock.Any()).Return(nil)
 
 	// Call StopAllSessions
 	err := manager.StopAllSessions(200 * time.Millisecond)
@@ -300,7 +299,9 @@ func TestForceKillRemaining(t *testing.T) {
 		mockSession := NewMockClaudeSession(ctrl)
 
 		mockSession.EXPECT().GetID().Return(sessionID).AnyTimes()
-		mockSession.EXPECT().Kill().DoAndReturn(func() error {
+		mockSession.EXPECT().Stop(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, grace time.Duration) error {
+			// Force kills skip the graceful signals
+			assert.Error(t, ctx.Err())
 			mu.Lock()
 			killCalled[sessionID] = true
 			mu.Unlock()
@@ -339,21 +340,21 @@ func TestStopAllSessions_MixedStatuses(t *testing.T) {
 	// Running session - should be interrupted
 	mockRunningSession := NewMockClaudeSession(ctrl)
 	mockRunningSession.EXPECT().GetID().Return(runningID).AnyTimes()
-	mockRunningSession.EXPECT().Interrupt().Return(nil).Times(1)
+	mockRunningSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	manager.activeProcesses[runningID] = mockRunningSession
 
 	// Completed session - should NOT be interrupted
 	mockCompletedSession := NewMockClaudeSession(ctrl)
 	mockCompletedSession.EXPECT().GetID().Return(completedID).AnyTimes()
-	// No Interrupt() expectation
-	mockCompletedSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+	// No Stop() expectation
+	mockCompletedSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1) // May be force killed on timeout
 	manager.activeProcesses[completedID] = mockCompletedSession
 
 	// Interrupted session - should NOT be interrupted again
 	mockInterruptedSession := NewMockClaudeSession(ctrl)
 	mockInterruptedSession.EXPECT().GetID().Return(interruptedID).AnyTimes()
-	// No Interrupt() expectation
-	mockInterruptedSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+	// No Stop() expectation
+	mockInterruptedSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1) // May be force killed on timeout
 	manager.activeProcesses[interruptedID] = mockInterruptedSession
 
 	// Mock store expectations
@@ -403,7 +404,7 @@ func TestStopAllSessions_SessionWaitingInput(t *testing.T) {
 	mockWaitingSession := NewMockClaudeSession(ctrl)
 
 	mockWaitingSession.EXPECT().GetID().Return(waitingID).AnyTimes()
-	mockWaitingSession.EXPECT().Interrupt().Return(nil).Times(1)
+	mockWaitingSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 	manager.activeProcesses[waitingID] = mockWaitingSession
 
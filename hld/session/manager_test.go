@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/config"
 	"github.com/humanlayer/humanlayer/hld/store"
 	"go.uber.org/mock/gomock"
 )
@@ -720,13 +724,13 @@ func TestStopAllSessions_FiltersSessionsByStatus(t *testing.T) {
 	mockClaudeSession3 := NewMockClaudeSession(ctrl)
 
 	// Set up expectations for the running session (should be interrupted)
-	mockClaudeSession1.EXPECT().Interrupt().Return(nil).Times(1)
+	mockClaudeSession1.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 	// Set up expectations for the waiting session (should be interrupted)
-	mockClaudeSession3.EXPECT().Interrupt().Return(nil).Times(1)
+	mockClaudeSession3.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-	// Add Kill() expectations for sessions that might timeout
-	mockClaudeSession2.EXPECT().Kill().Return(nil).MaxTimes(1) // Completed session might be force killed
+	// Add Stop() expectations for sessions that might be force killed
+	mockClaudeSession2.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1) // Completed session might be force killed
 
 	// Manually populate activeProcesses for testing
 	manager.activeProcesses["session-running"] = mockClaudeSession1
@@ -789,7 +793,7 @@ func TestStopAllSessions_HandlesInterruptErrors(t *testing.T) {
 
 	// Create a session that will fail to get info
 	mockClaudeSession := NewMockClaudeSession(ctrl)
-	mockClaudeSession.EXPECT().Interrupt().Return(nil).Times(1)
+	mockClaudeSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	manager.activeProcesses["session-error"] = mockClaudeSession
 
 	// Mock GetSessionInfo to return error
@@ -841,8 +845,8 @@ func TestStopAllSessions_TimeoutBehavior(t *testing.T) {
 	// Add a mock session that won't be removed
 	mockClaudeSession := NewMockClaudeSession(ctrl)
 	// Set up expectations for forced kill since it won't stop gracefully
-	mockClaudeSession.EXPECT().Interrupt().Return(nil).Times(1)
-	mockClaudeSession.EXPECT().Kill().Return(nil).Times(1)
+	// Stopped once, then force killed on timeout
+	mockClaudeSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).Times(2)
 	manager.activeProcesses["stuck-session"] = mockClaudeSession
 
 	// Mock GetSessionInfo
@@ -882,8 +886,7 @@ func TestStopAllSessions_RaceConditions(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		sessionID := fmt.Sprintf("session-%d", i)
 		mockSession := NewMockClaudeSession(ctrl)
-		mockSession.EXPECT().Interrupt().Return(nil).Times(1)
-		mockSession.EXPECT().Kill().Return(nil).MaxTimes(1) // Might be force killed if timeout
+		mockSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).MinTimes(1).MaxTimes(2) // Might be force killed if timeout
 		manager.activeProcesses[sessionID] = mockSession
 
 		// Mock GetSessionInfo for each session
@@ -931,8 +934,7 @@ func TestStopAllSessions_RaceConditions(t *testing.T) {
 			manager.mu.Lock()
 			newSessionID := fmt.Sprintf("new-session-%d", i)
 			mockNewSession := NewMockClaudeSession(ctrl)
-			mockNewSession.EXPECT().Interrupt().Return(nil).MaxTimes(1)
-			mockNewSession.EXPECT().Kill().Return(nil).MaxTimes(1) // Might be force killed
+			mockNewSession.EXPECT().Stop(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2) // Might be force killed
 			manager.activeProcesses[newSessionID] = mockNewSession
 			manager.mu.Unlock()
 
@@ -975,12 +977,12 @@ func TestStopAllSessions_ForceKillBehavior(t *testing.T) {
 	mockStore := store.NewMockConversationStore(ctrl)
 	manager, _ := NewManager(nil, mockStore, "")
 
-	// Track interrupt calls on mock Claude session
+	// Track stop calls on mock Claude session
 	mockClaudeSession := NewMockClaudeSession(ctrl)
-	// Session should be interrupted multiple times as we retry
-	mockClaudeSession.EXPECT().Interrupt().Return(nil).MinTimes(1)
+	// Session should be stopped gracefully with a third of the timeout per signal
+	mockClaudeSession.EXPECT().Stop(gomock.Any(), 50*time.Millisecond/3).Return(nil).Times(1)
 	// Eventually it should be force killed
-	mockClaudeSession.EXPECT().Kill().Return(nil).Times(1)
+	mockClaudeSession.EXPECT().Stop(gomock.Any(), time.Duration(0)).Return(nil).Times(1)
 
 	manager.activeProcesses["stubborn-session"] = mockClaudeSession
 
@@ -1002,3 +1004,35 @@ func TestStopAllSessions_ForceKillBehavior(t *testing.T) {
 	}
 
 }
+
+func TestGetClaudeClient_RemembersFailedVersionDetection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A claude whose --version fails and counts its invocations
+	claudePath := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(claudePath, []byte("#!/bin/sh\necho x >> \"$0.calls\"\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	manager, err := NewManagerWithConfig(nil, store.NewMockConversationStore(ctrl), "", &config.Config{ClaudePath: claudePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 3 {
+		if _, err := manager.getClaudeClient(); err != nil {
+			t.Fatalf("a failed version detection should not fail the client: %v", err)
+		}
+	}
+	if _, err := manager.DetectClaudeVersion(); err == nil {
+		t.Error("expected the failed detection to be reported")
+	}
+
+	calls, err := os.ReadFile(claudePath + ".calls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(calls), "x"); n != 1 {
+		t.Errorf("expected claude --version to run once, ran %d times", n)
+	}
+}
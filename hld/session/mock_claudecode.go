@@ -1,20 +1,28 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: session/claudecode_wrapper.go
+//
+// Generated by this command:
+//
+//	mockgen -source=session/claudecode_wrapper.go -destination=session/mock_claudecode.go -package=session ClaudeSession
+//
 
 // Package session is a generated GoMock package.
 package session
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
-	gomock "github.com/golang/mock/gomock"
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockClaudeSession is a mock of ClaudeSession interface.
 type MockClaudeSession struct {
 	ctrl     *gomock.Controller
 	recorder *MockClaudeSessionMockRecorder
+	isgomock struct{}
 }
 
 // MockClaudeSessionMockRecorder is the mock recorder for MockClaudeSession.
@@ -90,6 +98,20 @@ func (mr *MockClaudeSessionMockRecorder) Kill() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockClaudeSession)(nil).Kill))
 }
 
+// Stop mocks base method.
+func (m *MockClaudeSession) Stop(ctx context.Context, grace time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx, grace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClaudeSessionMockRecorder) Stop(ctx, grace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClaudeSession)(nil).Stop), ctx, grace)
+}
+
 // Wait mocks base method.
 func (m *MockClaudeSession) Wait() (*claudecode.Result, error) {
 	m.ctrl.T.Helper()
@@ -104,3 +126,18 @@ func (mr *MockClaudeSessionMockRecorder) Wait() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockClaudeSession)(nil).Wait))
 }
+
+// WaitContext mocks base method.
+func (m *MockClaudeSession) WaitContext(ctx context.Context) (*claudecode.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitContext", ctx)
+	ret0, _ := ret[0].(*claudecode.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitContext indicates an expected call of WaitContext.
+func (mr *MockClaudeSessionMockRecorder) WaitContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitContext", reflect.TypeOf((*MockClaudeSession)(nil).WaitContext), ctx)
+}
@@ -0,0 +1,75 @@
+package session
+
+import (
+	"log/slog"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+)
+
+// partialAssemblers tracks the message being streamed for the main agent and
+// each sub-task, keyed by parent tool use ID
+type partialAssemblers map[string]*claudecode.MessageAssembler
+
+// publishPartialEvent feeds a stream_event line to its assembler and publishes
+// content deltas for live rendering. Partial events are never persisted; the
+// complete assistant message that follows is stored as usual.
+func (m *Manager) publishPartialEvent(sessionID, runID string, assemblers partialAssemblers, event claudecode.StreamEvent) {
+	typed, err := event.Typed()
+	if err != nil {
+		slog.Debug("failed to decode partial event", "session_id", sessionID, "error", err)
+		return
+	}
+	partial, ok := typed.(*claudecode.PartialEvent)
+	if !ok {
+		return
+	}
+
+	assembler, ok := assemblers[partial.ParentToolUseID]
+	if !ok {
+		assembler = claudecode.NewMessageAssembler()
+		assemblers[partial.ParentToolUseID] = assembler
+	}
+	if _, _, err := assembler.Add(partial); err != nil {
+		slog.Debug("failed to assemble partial event", "session_id", sessionID, "error", err)
+	}
+
+	if m.eventBus == nil || partial.Kind != claudecode.PartialContentBlockDelta {
+		return
+	}
+
+	data := map[string]interface{}{
+		"session_id": sessionID,
+		"run_id":     runID,
+		"message_id": assembler.MessageID(),
+		"index":      partial.Index,
+		"delta_type": partial.Delta.DeltaType(),
+	}
+	if partial.ParentToolUseID != "" {
+		data["parent_tool_use_id"] = partial.ParentToolUseID
+	}
+	if block, ok := assembler.Block(partial.Index); ok {
+		data["block_type"] = block.BlockType()
+		if toolUse, ok := block.(*claudecode.ToolUseBlock); ok {
+			data["tool_id"] = toolUse.ID
+			data["tool_name"] = toolUse.Name
+		}
+	}
+
+	switch d := partial.Delta.(type) {
+	case *claudecode.TextDelta:
+		data["text"] = d.Text
+	case *claudecode.ThinkingDelta:
+		data["thinking"] = d.Thinking
+	case *claudecode.InputJSONDelta:
+		data["partial_json"] = d.PartialJSON
+	default:
+		// Signatures and unknown deltas have nothing to render
+		return
+	}
+
+	m.eventBus.Publish(bus.Event{
+		Type: bus.EventAssistantDelta,
+		Data: data,
+	})
+}
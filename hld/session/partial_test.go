@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPublishPartialEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No store calls are expected, partial events are not persisted
+	mockStore := store.NewMockConversationStore(ctrl)
+	eventBus := bus.NewEventBus()
+	manager, err := NewManager(eventBus, mockStore, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventAssistantDelta}})
+
+	lines := []string{
+		`{"type":"stream_event","session_id":"c1","event":{"type":"message_start","message":{"id":"msg_1"}}}`,
+		`{"type":"stream_event","session_id":"c1","event":{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}}`,
+		`{"type":"stream_event","session_id":"c1","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}}`,
+		`{"type":"stream_event","session_id":"c1","event":{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"Bash","input":{}}}}`,
+		`{"type":"stream_event","session_id":"c1","event":{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"command\""}}}`,
+		`{"type":"stream_event","session_id":"c1","event":{"type":"content_block_stop","index":1}}`,
+	}
+	assemblers := make(partialAssemblers)
+	for _, line := range lines {
+		var event claudecode.StreamEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		manager.publishPartialEvent("sess-1", "run-1", assemblers, event)
+	}
+
+	next := func() bus.Event {
+		select {
+		case event := <-sub.Channel:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("expected an assistant delta event")
+			return bus.Event{}
+		}
+	}
+
+	text := next()
+	assert.Equal(t, "sess-1", text.Data["session_id"])
+	assert.Equal(t, "msg_1", text.Data["message_id"])
+	assert.Equal(t, "text", text.Data["block_type"])
+	assert.Equal(t, "Hel", text.Data["text"])
+
+	input := next()
+	assert.Equal(t, 1, input.Data["index"])
+	assert.Equal(t, "tool_use", input.Data["block_type"])
+	assert.Equal(t, "Bash", input.Data["tool_name"])
+	assert.Equal(t, `{"command"`, input.Data["partial_json"])
+
+	select {
+	case event := <-sub.Channel:
+		t.Fatalf("only deltas should be published, got %+v", event)
+	default:
+	}
+}
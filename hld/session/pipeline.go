@@ -0,0 +1,824 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// PipelineStep is one session launch of a pipeline. Its query, working
+// directory and prompts may use the run's {{variables}} and the outputs of
+// the steps it depends on: {{steps.<name>.result}} is a step's final result
+// and {{steps.<name>.thoughts}} lists the files it wrote under thoughts/.
+type PipelineStep struct {
+	Name      string   `json:"name"`
+	DependsOn []string `json:"depends_on,omitempty"`
+	// ContinueFrom resumes the session of an earlier step instead of
+	// launching a new one, keeping its conversation. Only the query, prompts,
+	// tools, additional directories and max turns of Launch apply then.
+	ContinueFrom string          `json:"continue_from,omitempty"`
+	Launch       ScheduledLaunch `json:"launch"`
+}
+
+// dependencies returns the steps that must complete before this one starts
+func (s PipelineStep) dependencies() []string {
+	deps := s.DependsOn
+	if s.ContinueFrom != "" && !containsString(deps, s.ContinueFrom) {
+		deps = append(append([]string(nil), deps...), s.ContinueFrom)
+	}
+	return deps
+}
+
+// templatedFields returns the step fields that may contain variables
+func (s PipelineStep) templatedFields() []string {
+	return []string{s.Launch.Query, s.Launch.WorkingDir, s.Launch.SystemPrompt, s.Launch.AppendSystemPrompt}
+}
+
+// pipelineStepName is the format of step names, which must fit in a
+// {{steps.<name>.result}} placeholder
+var pipelineStepName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// stepOutputs are the values a step makes available to later steps
+var stepOutputs = []string{"result", "thoughts"}
+
+// PipelineStateError reports a pipeline request that the run doesn't allow,
+// like retrying a step that hasn't failed
+type PipelineStateError struct {
+	Message string
+}
+
+func (e *PipelineStateError) Error() string {
+	return e.Message
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// stepReference splits a {{steps.<name>.<output>}} variable, reporting
+// whether the variable refers to a step
+func stepReference(variable string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(variable, "steps.")
+	if !ok {
+		return "", "", false
+	}
+	i := strings.LastIndex(rest, ".")
+	if i < 0 {
+		return rest, "", true
+	}
+	return rest[:i], rest[i+1:], true
+}
+
+// ParsePipelineSteps decodes and validates the steps of a pipeline: names
+// are unique, dependencies exist and have no cycles, and steps only use the
+// outputs of steps they depend on, directly or not
+func ParsePipelineSteps(data string) ([]PipelineStep, error) {
+	var steps []PipelineStep
+	if err := json.Unmarshal([]byte(data), &steps); err != nil {
+		return nil, fmt.Errorf("invalid pipeline steps: %w", err)
+	}
+	if len(steps) == 0 {
+		return nil, errors.New("a pipeline needs at least one step")
+	}
+
+	byName := make(map[string]PipelineStep, len(steps))
+	for _, step := range steps {
+		if !pipelineStepName.MatchString(step.Name) {
+			return nil, fmt.Errorf("invalid step name %q: use letters, digits, - and _", step.Name)
+		}
+		if _, ok := byName[step.Name]; ok {
+			return nil, fmt.Errorf("duplicate step name %q", step.Name)
+		}
+		byName[step.Name] = step
+	}
+	for _, step := range steps {
+		for _, dep := range step.dependencies() {
+			if dep == step.Name {
+				return nil, fmt.Errorf("step %s depends on itself", step.Name)
+			}
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("step %s depends on unknown step %q", step.Name, dep)
+			}
+		}
+	}
+
+	ancestors, err := pipelineAncestors(steps)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range steps {
+		if strings.TrimSpace(step.Launch.Query) == "" {
+			return nil, fmt.Errorf("step %s has no query", step.Name)
+		}
+		if !step.Launch.Priority.Valid() {
+			return nil, fmt.Errorf("step %s has invalid priority %q: must be low, normal or high", step.Name, step.Launch.Priority)
+		}
+		for _, text := range step.templatedFields() {
+			for _, match := range templateVariable.FindAllStringSubmatch(text, -1) {
+				name, output, ok := stepReference(match[1])
+				if !ok {
+					continue
+				}
+				if !containsString(stepOutputs, output) {
+					return nil, fmt.Errorf("step %s uses unknown output {{%s}}: use result or thoughts", step.Name, match[1])
+				}
+				if !ancestors[step.Name][name] {
+					return nil, fmt.Errorf("step %s uses {{%s}} but doesn't depend on step %q", step.Name, match[1], name)
+				}
+			}
+		}
+		if err := step.Launch.LaunchConfig("").Validate(); err != nil {
+			return nil, fmt.Errorf("step %s: %w", step.Name, err)
+		}
+	}
+	return steps, nil
+}
+
+// pipelineAncestors returns, for every step, the steps it depends on directly
+// or not. It fails when the dependencies have a cycle.
+func pipelineAncestors(steps []PipelineStep) (map[string]map[string]bool, error) {
+	byName := make(map[string]PipelineStep, len(steps))
+	for _, step := range steps {
+		byName[step.Name] = step
+	}
+
+	ancestors := make(map[string]map[string]bool, len(steps))
+	visiting := make(map[string]bool)
+	var visit func(name string) error
+	visit = func(name string) error {
+		if ancestors[name] != nil {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("pipeline steps have a dependency cycle through %s", name)
+		}
+		visiting[name] = true
+		set := make(map[string]bool)
+		for _, dep := range byName[name].dependencies() {
+			if err := visit(dep); err != nil {
+				return err
+			}
+			set[dep] = true
+			for ancestor := range ancestors[dep] {
+				set[ancestor] = true
+			}
+		}
+		visiting[name] = false
+		ancestors[name] = set
+		return nil
+	}
+	for _, step := range steps {
+		if err := visit(step.Name); err != nil {
+			return nil, err
+		}
+	}
+	return ancestors, nil
+}
+
+// PipelineVariables returns the run variables the steps use, not counting
+// step outputs, sorted by name
+func PipelineVariables(steps []PipelineStep) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, step := range steps {
+		for _, text := range step.templatedFields() {
+			for _, match := range templateVariable.FindAllStringSubmatch(text, -1) {
+				if _, _, ok := stepReference(match[1]); ok || seen[match[1]] {
+					continue
+				}
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// thoughtsFileTools are the tools whose file_path input is a file the
+// session wrote
+var thoughtsFileTools = map[string]bool{"Write": true, "Edit": true, "MultiEdit": true}
+
+// isThoughtsPath reports whether path is in a thoughts/ directory
+func isThoughtsPath(path string) bool {
+	return strings.HasPrefix(path, "thoughts/") || strings.Contains(path, "/thoughts/")
+}
+
+// PipelineRunner starts pipeline runs and launches each step once the steps
+// it depends on have completed. Step sessions are followed through session
+// status events, with a periodic check of running pipelines to catch up on
+// anything missed, like sessions that ended while the daemon was down.
+type PipelineRunner struct {
+	store    store.ConversationStore
+	sessions SessionManager
+	eventBus bus.EventBus
+	interval time.Duration
+
+	// mu serializes changes to pipeline runs
+	mu sync.Mutex
+}
+
+// NewPipelineRunner creates a runner that checks running pipelines every interval
+func NewPipelineRunner(store store.ConversationStore, sessions SessionManager, eventBus bus.EventBus, interval time.Duration) *PipelineRunner {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &PipelineRunner{
+		store:    store,
+		sessions: sessions,
+		eventBus: eventBus,
+		interval: interval,
+	}
+}
+
+// Start advances running pipelines as their step sessions end, until ctx is
+// cancelled
+func (r *PipelineRunner) Start(ctx context.Context) {
+	slog.Info("starting pipeline runner", "interval", r.interval)
+
+	var events <-chan bus.Event
+	if r.eventBus != nil {
+		sub := r.eventBus.Subscribe(ctx, bus.EventFilter{
+			Types: []bus.EventType{bus.EventSessionStatusChanged},
+		})
+		defer r.eventBus.Unsubscribe(sub.ID)
+		events = sub.Channel
+	}
+
+	r.advanceRunning(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("pipeline runner shutting down")
+			return
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			r.handleSessionStatus(ctx, event)
+		case <-ticker.C:
+			r.advanceRunning(ctx)
+		}
+	}
+}
+
+// handleSessionStatus advances the pipeline run of a step session that ended
+func (r *PipelineRunner) handleSessionStatus(ctx context.Context, event bus.Event) {
+	switch newStatus, _ := event.Data["new_status"].(string); newStatus {
+	case string(StatusCompleted), string(StatusFailed), string(StatusInterrupted):
+	default:
+		return
+	}
+	sessionID, _ := event.Data["session_id"].(string)
+	if sessionID == "" {
+		return
+	}
+	step, err := r.stepRunOfSession(ctx, sessionID)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			slog.Error("failed to look up pipeline step of session", "session_id", sessionID, "error", err)
+		}
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.advance(ctx, step.RunID); err != nil {
+		slog.Error("failed to advance pipeline run", "pipeline_run_id", step.RunID, "error", err)
+	}
+}
+
+// stepRunOfSession returns the pipeline step a session does the work of,
+// directly or by carrying on a step session the step hasn't followed yet
+func (r *PipelineRunner) stepRunOfSession(ctx context.Context, sessionID string) (*store.PipelineStepRun, error) {
+	step, err := r.store.GetPipelineStepRunBySession(ctx, sessionID)
+	for depth := 0; errors.Is(err, store.ErrNotFound) && depth < 10; depth++ {
+		sess, getErr := r.store.GetSession(ctx, sessionID)
+		if getErr != nil || sess.ParentSessionID == "" {
+			break
+		}
+		sessionID = sess.ParentSessionID
+		step, err = r.store.GetPipelineStepRunBySession(ctx, sessionID)
+	}
+	return step, err
+}
+
+// advanceRunning advances every running pipeline run
+func (r *PipelineRunner) advanceRunning(ctx context.Context) {
+	if r.store == nil {
+		return
+	}
+	runs, err := r.store.ListPipelineRuns(ctx, "", store.PipelineStatusRunning)
+	if err != nil {
+		slog.Error("failed to list running pipeline runs", "error", err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, run := range runs {
+		if err := r.advance(ctx, run.ID); err != nil {
+			slog.Error("failed to advance pipeline run", "pipeline_run_id", run.ID, "error", err)
+		}
+	}
+}
+
+// StartRun starts a run of a pipeline, launching the steps that depend on no
+// other step. Steps without a working directory run in workingDir. Every
+// variable the steps use needs a value, otherwise a *MissingVariablesError is
+// returned.
+func (r *PipelineRunner) StartRun(ctx context.Context, pipelineID, workingDir string, vars map[string]string) (*store.PipelineRun, error) {
+	pipeline, err := r.store.GetPipeline(ctx, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := ParsePipelineSteps(pipeline.Steps)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, name := range PipelineVariables(steps) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &MissingVariablesError{Names: missing}
+	}
+
+	variables, err := json.Marshal(vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pipeline variables: %w", err)
+	}
+	run := &store.PipelineRun{
+		ID:           "prun_" + uuid.New().String()[:8],
+		PipelineID:   pipeline.ID,
+		PipelineName: pipeline.Name,
+		Definition:   pipeline.Steps,
+		WorkingDir:   workingDir,
+		Variables:    string(variables),
+		Status:       store.PipelineStatusRunning,
+	}
+	for i, step := range steps {
+		run.Steps = append(run.Steps, &store.PipelineStepRun{
+			Name:     step.Name,
+			Position: i,
+			Status:   store.PipelineStatusPending,
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.CreatePipelineRun(ctx, run); err != nil {
+		return nil, err
+	}
+	slog.Info("started pipeline run",
+		"pipeline_id", pipeline.ID,
+		"pipeline_run_id", run.ID,
+		"steps", len(steps))
+	r.publishRunStatus(run, "", store.PipelineStatusRunning, "")
+
+	if err := r.advance(ctx, run.ID); err != nil {
+		return nil, err
+	}
+	return r.store.GetPipelineRun(ctx, run.ID)
+}
+
+// RetryStep relaunches a failed step of a pipeline run, resuming the run if
+// it had failed. Steps waiting on the failed step start once it completes.
+func (r *PipelineRunner) RetryStep(ctx context.Context, runID, stepName string) (*store.PipelineRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run, err := r.store.GetPipelineRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	var step *store.PipelineStepRun
+	for _, s := range run.Steps {
+		if s.Name == stepName {
+			step = s
+		}
+	}
+	if step == nil {
+		return nil, &PipelineStateError{Message: fmt.Sprintf("pipeline run has no step %q", stepName)}
+	}
+	if step.Status != store.PipelineStatusFailed {
+		return nil, &PipelineStateError{Message: fmt.Sprintf("step %s is %s, only failed steps can be retried", stepName, step.Status)}
+	}
+
+	pending := store.PipelineStatusPending
+	empty := ""
+	var noTime *time.Time
+	if err := r.store.UpdatePipelineStepRun(ctx, runID, stepName, store.PipelineStepRunUpdate{
+		Status:      &pending,
+		Error:       &empty,
+		CompletedAt: &noTime,
+	}); err != nil {
+		return nil, err
+	}
+	r.publishStepStatus(run, step, store.PipelineStatusFailed, pending)
+
+	if run.Status != store.PipelineStatusRunning {
+		running := store.PipelineStatusRunning
+		if err := r.store.UpdatePipelineRun(ctx, runID, store.PipelineRunUpdate{
+			Status:      &running,
+			Error:       &empty,
+			CompletedAt: &noTime,
+		}); err != nil {
+			return nil, err
+		}
+		r.publishRunStatus(run, run.Status, running, "")
+	}
+	slog.Info("retrying pipeline step", "pipeline_run_id", runID, "step", stepName, "attempt", step.Attempts+1)
+
+	if err := r.advance(ctx, runID); err != nil {
+		return nil, err
+	}
+	return r.store.GetPipelineRun(ctx, runID)
+}
+
+// advance brings a running pipeline run up to date: steps whose session
+// ended are marked completed or failed, steps whose dependencies have all
+// completed are launched, and the run completes once every step has, or
+// fails once a failed step leaves nothing else to do. Callers hold r.mu.
+func (r *PipelineRunner) advance(ctx context.Context, runID string) error {
+	run, err := r.store.GetPipelineRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if run.Status != store.PipelineStatusRunning {
+		return nil
+	}
+	steps, err := ParsePipelineSteps(run.Definition)
+	if err != nil {
+		return r.finishRun(ctx, run, store.PipelineStatusFailed, err.Error())
+	}
+	states := make(map[string]*store.PipelineStepRun, len(run.Steps))
+	for _, state := range run.Steps {
+		states[state.Name] = state
+	}
+
+	// Settle steps whose session has ended, unless the session carries on
+	for _, state := range run.Steps {
+		if state.Status != store.PipelineStatusRunning {
+			continue
+		}
+		sess, err := r.stepSession(ctx, run, state)
+		if err != nil {
+			slog.Error("failed to get pipeline step session",
+				"pipeline_run_id", run.ID,
+				"step", state.Name,
+				"session_id", state.SessionID,
+				"error", err)
+			continue
+		}
+		if r.continuationPending(ctx, sess) {
+			continue
+		}
+		switch sess.Status {
+		case store.SessionStatusCompleted:
+			r.finishStep(ctx, run, state, store.PipelineStatusCompleted, "")
+		case store.SessionStatusFailed:
+			message := sess.ErrorMessage
+			if message == "" {
+				message = "session failed"
+			}
+			r.finishStep(ctx, run, state, store.PipelineStatusFailed, message)
+		case store.SessionStatusInterrupted:
+			r.finishStep(ctx, run, state, store.PipelineStatusFailed, "session was interrupted")
+		}
+	}
+
+	// Launch the steps whose dependencies have all completed
+	for _, step := range steps {
+		state := states[step.Name]
+		if state == nil || state.Status != store.PipelineStatusPending {
+			continue
+		}
+		ready := true
+		for _, dep := range step.dependencies() {
+			if states[dep] == nil || states[dep].Status != store.PipelineStatusCompleted {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			r.launchStep(ctx, run, step, states)
+		}
+	}
+
+	completed, running := 0, 0
+	var failed []string
+	for _, state := range run.Steps {
+		switch state.Status {
+		case store.PipelineStatusCompleted:
+			completed++
+		case store.PipelineStatusRunning:
+			running++
+		case store.PipelineStatusFailed:
+			failed = append(failed, state.Name)
+		}
+	}
+	switch {
+	case completed == len(run.Steps):
+		return r.finishRun(ctx, run, store.PipelineStatusCompleted, "")
+	case running == 0 && len(failed) > 0:
+		// Steps still pending wait on a failed step, directly or not
+		return r.finishRun(ctx, run, store.PipelineStatusFailed,
+			fmt.Sprintf("failed steps: %s", strings.Join(failed, ", ")))
+	}
+	return nil
+}
+
+// stepSession returns the session doing a step's work. Retries, stall
+// resumes and restart recoveries carry a failed or interrupted session on in
+// a new one, which the step then follows.
+func (r *PipelineRunner) stepSession(ctx context.Context, run *store.PipelineRun, state *store.PipelineStepRun) (*store.Session, error) {
+	sess, err := r.store.GetSession(ctx, state.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	for sess.Status == store.SessionStatusFailed || sess.Status == store.SessionStatusInterrupted {
+		next, err := r.store.GetLatestChildSession(ctx, sess.ID)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		sess = next
+	}
+
+	if sess.ID != state.SessionID {
+		if err := r.store.UpdatePipelineStepRun(ctx, run.ID, state.Name, store.PipelineStepRunUpdate{
+			SessionID: &sess.ID,
+		}); err != nil {
+			return nil, err
+		}
+		slog.Info("pipeline step carried on in a new session",
+			"pipeline_run_id", run.ID,
+			"step", state.Name,
+			"previous_session_id", state.SessionID,
+			"session_id", sess.ID)
+		state.SessionID = sess.ID
+	}
+	return sess, nil
+}
+
+// continuationPending reports whether an ended step session is about to be
+// carried on in a new one: a retry is scheduled, its stall policy resumes
+// it, or it was cut off by a daemon restart and can be recovered
+func (r *PipelineRunner) continuationPending(ctx context.Context, sess *store.Session) bool {
+	switch sess.Status {
+	case store.SessionStatusFailed, store.SessionStatusInterrupted:
+	default:
+		return false
+	}
+	return sess.RetryAt != nil ||
+		sess.StopReason == StopReasonDaemonRestarted ||
+		willResumeStalled(ctx, r.store, sess)
+}
+
+// launchStep launches the session of a step, or continues the session of
+// the step it continues from, and records it as running. A launch that fails
+// fails the step.
+func (r *PipelineRunner) launchStep(ctx context.Context, run *store.PipelineRun, step PipelineStep, states map[string]*store.PipelineStepRun) {
+	state := states[step.Name]
+	attempts := state.Attempts + 1
+	now := time.Now()
+	startedAt := &now
+	if err := r.store.UpdatePipelineStepRun(ctx, run.ID, step.Name, store.PipelineStepRunUpdate{
+		Attempts:  &attempts,
+		StartedAt: &startedAt,
+	}); err != nil {
+		slog.Error("failed to record pipeline step attempt", "pipeline_run_id", run.ID, "step", step.Name, "error", err)
+		return
+	}
+	state.Attempts = attempts
+	state.StartedAt = startedAt
+
+	var sess *Session
+	vars, err := r.stepVariables(ctx, run, states)
+	if err == nil {
+		missing := make(map[string]bool)
+		launch := step.Launch
+		launch.Query = renderTemplate(launch.Query, vars, missing)
+		launch.WorkingDir = renderTemplate(launch.WorkingDir, vars, missing)
+		launch.SystemPrompt = renderTemplate(launch.SystemPrompt, vars, missing)
+		launch.AppendSystemPrompt = renderTemplate(launch.AppendSystemPrompt, vars, missing)
+		switch {
+		case len(missing) > 0:
+			names := make([]string, 0, len(missing))
+			for name := range missing {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			err = &MissingVariablesError{Names: names}
+		case step.ContinueFrom != "":
+			sess, err = r.sessions.ContinueSession(ctx, ContinueSessionConfig{
+				ParentSessionID:       states[step.ContinueFrom].SessionID,
+				Query:                 launch.Query,
+				SystemPrompt:          launch.SystemPrompt,
+				AppendSystemPrompt:    launch.AppendSystemPrompt,
+				AllowedTools:          launch.AllowedTools,
+				DisallowedTools:       launch.DisallowedTools,
+				AdditionalDirectories: launch.AdditionalDirectories,
+				MaxTurns:              launch.MaxTurns,
+			})
+		default:
+			if launch.WorkingDir == "" {
+				launch.WorkingDir = run.WorkingDir
+			}
+			sess, err = r.sessions.LaunchSession(ctx, launch.LaunchConfig(run.PipelineName+": "+step.Name), false)
+		}
+	}
+	if err != nil {
+		slog.Error("failed to launch pipeline step",
+			"pipeline_run_id", run.ID,
+			"step", step.Name,
+			"error", err)
+		r.finishStep(ctx, run, state, store.PipelineStatusFailed, err.Error())
+		return
+	}
+
+	running := store.PipelineStatusRunning
+	if err := r.store.UpdatePipelineStepRun(ctx, run.ID, step.Name, store.PipelineStepRunUpdate{
+		Status:    &running,
+		SessionID: &sess.ID,
+	}); err != nil {
+		slog.Error("failed to record pipeline step session", "pipeline_run_id", run.ID, "step", step.Name, "error", err)
+		return
+	}
+	old := state.Status
+	state.Status = running
+	state.SessionID = sess.ID
+	slog.Info("launched pipeline step",
+		"pipeline_run_id", run.ID,
+		"step", step.Name,
+		"session_id", sess.ID,
+		"attempt", attempts)
+	r.publishStepStatus(run, state, old, running)
+}
+
+// stepVariables returns the run variables and the outputs of the completed steps
+func (r *PipelineRunner) stepVariables(ctx context.Context, run *store.PipelineRun, states map[string]*store.PipelineStepRun) (map[string]string, error) {
+	vars := make(map[string]string)
+	if run.Variables != "" {
+		if err := json.Unmarshal([]byte(run.Variables), &vars); err != nil {
+			return nil, fmt.Errorf("invalid pipeline run variables: %w", err)
+		}
+	}
+	for name, state := range states {
+		if state.Status != store.PipelineStatusCompleted {
+			continue
+		}
+		sess, err := r.store.GetSession(ctx, state.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session of step %s: %w", name, err)
+		}
+		thoughts, err := r.thoughtsFiles(ctx, sess.ClaudeSessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find thoughts of step %s: %w", name, err)
+		}
+		vars["steps."+name+".result"] = sess.ResultContent
+		vars["steps."+name+".thoughts"] = strings.Join(thoughts, "\n")
+	}
+	return vars, nil
+}
+
+// thoughtsFiles returns the files under thoughts/ a Claude session wrote or
+// edited, in order of first change
+func (r *PipelineRunner) thoughtsFiles(ctx context.Context, claudeSessionID string) ([]string, error) {
+	if claudeSessionID == "" {
+		return nil, nil
+	}
+	events, err := r.store.GetConversation(ctx, claudeSessionID)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	seen := make(map[string]bool)
+	for _, event := range events {
+		if event.EventType != store.EventTypeToolCall || !thoughtsFileTools[event.ToolName] {
+			continue
+		}
+		var input struct {
+			FilePath string `json:"file_path"`
+		}
+		if err := json.Unmarshal([]byte(event.ToolInputJSON), &input); err != nil {
+			continue
+		}
+		if isThoughtsPath(input.FilePath) && !seen[input.FilePath] {
+			seen[input.FilePath] = true
+			paths = append(paths, input.FilePath)
+		}
+	}
+	return paths, nil
+}
+
+// finishStep records that a step completed or failed
+func (r *PipelineRunner) finishStep(ctx context.Context, run *store.PipelineRun, state *store.PipelineStepRun, status, message string) {
+	now := time.Now()
+	completedAt := &now
+	if err := r.store.UpdatePipelineStepRun(ctx, run.ID, state.Name, store.PipelineStepRunUpdate{
+		Status:      &status,
+		Error:       &message,
+		CompletedAt: &completedAt,
+	}); err != nil {
+		slog.Error("failed to update pipeline step", "pipeline_run_id", run.ID, "step", state.Name, "error", err)
+		return
+	}
+	old := state.Status
+	state.Status = status
+	state.Error = message
+	state.CompletedAt = completedAt
+	slog.Info("pipeline step finished",
+		"pipeline_run_id", run.ID,
+		"step", state.Name,
+		"status", status,
+		"error", message)
+	r.publishStepStatus(run, state, old, status)
+}
+
+// finishRun records that a pipeline run completed or failed
+func (r *PipelineRunner) finishRun(ctx context.Context, run *store.PipelineRun, status, message string) error {
+	now := time.Now()
+	completedAt := &now
+	if err := r.store.UpdatePipelineRun(ctx, run.ID, store.PipelineRunUpdate{
+		Status:      &status,
+		Error:       &message,
+		CompletedAt: &completedAt,
+	}); err != nil {
+		return err
+	}
+	slog.Info("pipeline run finished",
+		"pipeline_id", run.PipelineID,
+		"pipeline_run_id", run.ID,
+		"status", status,
+		"error", message)
+	r.publishRunStatus(run, run.Status, status, message)
+	run.Status = status
+	return nil
+}
+
+func (r *PipelineRunner) publishRunStatus(run *store.PipelineRun, oldStatus, newStatus, message string) {
+	if r.eventBus == nil {
+		return
+	}
+	data := map[string]interface{}{
+		"pipeline_id":     run.PipelineID,
+		"pipeline_run_id": run.ID,
+		"new_status":      newStatus,
+	}
+	if oldStatus != "" {
+		data["old_status"] = oldStatus
+	}
+	if message != "" {
+		data["error"] = message
+	}
+	r.eventBus.Publish(bus.Event{Type: bus.EventPipelineRunStatusChanged, Data: data})
+}
+
+func (r *PipelineRunner) publishStepStatus(run *store.PipelineRun, state *store.PipelineStepRun, oldStatus, newStatus string) {
+	if r.eventBus == nil {
+		return
+	}
+	data := map[string]interface{}{
+		"pipeline_id":     run.PipelineID,
+		"pipeline_run_id": run.ID,
+		"step":            state.Name,
+		"attempt":         state.Attempts,
+		"old_status":      oldStatus,
+		"new_status":      newStatus,
+	}
+	if state.SessionID != "" {
+		data["session_id"] = state.SessionID
+	}
+	if newStatus == store.PipelineStatusFailed && state.Error != "" {
+		data["error"] = state.Error
+	}
+	r.eventBus.Publish(bus.Event{Type: bus.EventPipelineStepStatusChanged, Data: data})
+}
@@ -0,0 +1,281 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testPipelineSteps = `[
+	{"name": "research", "launch": {"query": "Research {{ticket}}"}},
+	{"name": "plan", "depends_on": ["research"], "launch": {
+		"query": "Plan {{ticket}} from:\n{{steps.research.result}}\n{{ steps.research.thoughts }}"}},
+	{"name": "implement", "continue_from": "plan", "launch": {"query": "Implement the plan"}},
+	{"name": "docs", "depends_on": ["research"], "launch": {"query": "Document {{steps.research.result}}"}}
+]`
+
+func TestParsePipelineSteps(t *testing.T) {
+	steps, err := ParsePipelineSteps(testPipelineSteps)
+	require.NoError(t, err)
+	require.Len(t, steps, 4)
+	assert.Equal(t, []string{"plan"}, steps[2].dependencies())
+	assert.Equal(t, []string{"ticket"}, PipelineVariables(steps))
+
+	testCases := []struct {
+		name  string
+		steps string
+		want  string
+	}{
+		{"no steps", `[]`, "at least one step"},
+		{"bad name", `[{"name": "a.b", "launch": {"query": "q"}}]`, `invalid step name "a.b"`},
+		{"duplicate", `[{"name": "a", "launch": {"query": "q"}}, {"name": "a", "launch": {"query": "q"}}]`, `duplicate step name "a"`},
+		{"unknown dependency", `[{"name": "a", "depends_on": ["b"], "launch": {"query": "q"}}]`, `unknown step "b"`},
+		{"cycle", `[{"name": "a", "depends_on": ["b"], "launch": {"query": "q"}},
+			{"name": "b", "continue_from": "a", "launch": {"query": "q"}}]`, "dependency cycle"},
+		{"no query", `[{"name": "a", "launch": {}}]`, "step a has no query"},
+		{"unknown output", `[{"name": "a", "launch": {"query": "q"}},
+			{"name": "b", "depends_on": ["a"], "launch": {"query": "{{steps.a.cost}}"}}]`, "unknown output"},
+		{"output of a step it doesn't depend on", `[{"name": "a", "launch": {"query": "q"}},
+			{"name": "b", "launch": {"query": "{{steps.a.result}}"}}]`, `doesn't depend on step "a"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParsePipelineSteps(tc.steps)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+// pipelineTestEnv runs pipelines against a real store, with a mock session
+// manager whose sessions are recorded as running until the test ends them
+type pipelineTestEnv struct {
+	ctx      context.Context
+	store    *store.SQLiteStore
+	sessions *MockSessionManager
+	runner   *PipelineRunner
+	events   *bus.Subscriber
+	launched []string
+}
+
+func newPipelineTestEnv(t *testing.T) *pipelineTestEnv {
+	t.Helper()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+	eventBus := bus.NewEventBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	env := &pipelineTestEnv{
+		ctx:      ctx,
+		store:    sqliteStore,
+		sessions: NewMockSessionManager(gomock.NewController(t)),
+		events: eventBus.Subscribe(ctx, bus.EventFilter{
+			Types: []bus.EventType{bus.EventPipelineRunStatusChanged},
+		}),
+	}
+	env.runner = NewPipelineRunner(sqliteStore, env.sessions, eventBus, time.Minute)
+	return env
+}
+
+func (env *pipelineTestEnv) createSession(t *testing.T, query string) *Session {
+	t.Helper()
+	id := "sess_" + string(rune('a'+len(env.launched)))
+	require.NoError(t, env.store.CreateSession(env.ctx, &store.Session{
+		ID:              id,
+		RunID:           "run_" + id,
+		ClaudeSessionID: "claude_" + id,
+		Query:           query,
+		Status:          store.SessionStatusRunning,
+	}))
+	env.launched = append(env.launched, id)
+	return &Session{ID: id, RunID: "run_" + id}
+}
+
+// endSession records a step session as ended and advances its pipeline
+func (env *pipelineTestEnv) endSession(t *testing.T, id, status, result string) {
+	t.Helper()
+	update := store.SessionUpdate{Status: &status}
+	if result != "" {
+		update.ResultContent = &result
+	}
+	require.NoError(t, env.store.UpdateSession(env.ctx, id, update))
+	env.runner.handleSessionStatus(env.ctx, bus.Event{
+		Type: bus.EventSessionStatusChanged,
+		Data: map[string]interface{}{"session_id": id, "new_status": status},
+	})
+}
+
+func (env *pipelineTestEnv) stepStatuses(t *testing.T, runID string) map[string]string {
+	t.Helper()
+	run, err := env.store.GetPipelineRun(env.ctx, runID)
+	require.NoError(t, err)
+	statuses := make(map[string]string)
+	for _, step := range run.Steps {
+		statuses[step.Name] = step.Status
+	}
+	return statuses
+}
+
+func TestPipelineRunner(t *testing.T) {
+	env := newPipelineTestEnv(t)
+	require.NoError(t, env.store.CreatePipeline(env.ctx, &store.Pipeline{
+		ID: "pipe_1", Name: "Ticket", Steps: testPipelineSteps,
+	}))
+
+	_, err := env.runner.StartRun(env.ctx, "pipe_1", "/repo", nil)
+	var missingErr *MissingVariablesError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []string{"ticket"}, missingErr.Names)
+
+	var queries []string
+	env.sessions.EXPECT().
+		LaunchSession(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, config LaunchSessionConfig, _ bool) (*Session, error) {
+			assert.Equal(t, "/repo", config.WorkingDir)
+			queries = append(queries, config.Query)
+			return env.createSession(t, config.Query), nil
+		}).
+		AnyTimes()
+
+	run, err := env.runner.StartRun(env.ctx, "pipe_1", "/repo", map[string]string{"ticket": "ENG-1"})
+	require.NoError(t, err)
+	assert.Equal(t, store.PipelineStatusRunning, run.Status)
+	assert.Equal(t, []string{"Research ENG-1"}, queries)
+	assert.Equal(t, map[string]string{
+		"research": "running", "plan": "pending", "implement": "pending", "docs": "pending",
+	}, env.stepStatuses(t, run.ID))
+
+	// The research step writes a document, then completes
+	require.NoError(t, env.store.AddConversationEvent(env.ctx, &store.ConversationEvent{
+		SessionID:       "sess_a",
+		ClaudeSessionID: "claude_sess_a",
+		EventType:       store.EventTypeToolCall,
+		ToolName:        "Write",
+		ToolInputJSON:   `{"file_path": "/repo/thoughts/shared/research/eng-1.md", "content": "..."}`,
+	}))
+	env.endSession(t, "sess_a", store.SessionStatusCompleted, "The bug is in the parser")
+	// Steps are launched in definition order
+	assert.Equal(t, []string{
+		"Research ENG-1",
+		"Plan ENG-1 from:\nThe bug is in the parser\n/repo/thoughts/shared/research/eng-1.md",
+		"Document The bug is in the parser",
+	}, queries)
+
+	// A failed step fails the run once nothing else runs, leaving its dependents pending
+	planSession, docsSession := env.launched[1], env.launched[2]
+	env.endSession(t, planSession, store.SessionStatusFailed, "")
+	run, err = env.store.GetPipelineRun(env.ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.PipelineStatusRunning, run.Status, "docs is still running")
+	env.endSession(t, docsSession, store.SessionStatusCompleted, "Documented")
+	run, err = env.store.GetPipelineRun(env.ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.PipelineStatusFailed, run.Status)
+	assert.Equal(t, "failed steps: plan", run.Error)
+	assert.Equal(t, "pending", env.stepStatuses(t, run.ID)["implement"])
+
+	_, err = env.runner.RetryStep(env.ctx, run.ID, "docs")
+	var stateErr *PipelineStateError
+	require.ErrorAs(t, err, &stateErr)
+
+	// Retrying the failed step resumes the run
+	run, err = env.runner.RetryStep(env.ctx, run.ID, "plan")
+	require.NoError(t, err)
+	assert.Equal(t, store.PipelineStatusRunning, run.Status)
+	assert.Empty(t, run.Error)
+	planSession = env.launched[3]
+	for _, step := range run.Steps {
+		if step.Name == "plan" {
+			assert.Equal(t, 2, step.Attempts)
+			assert.Equal(t, planSession, step.SessionID)
+		}
+	}
+
+	// The implement step continues the plan session
+	env.sessions.EXPECT().
+		ContinueSession(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, config ContinueSessionConfig) (*Session, error) {
+			assert.Equal(t, planSession, config.ParentSessionID)
+			assert.Equal(t, "Implement the plan", config.Query)
+			return env.createSession(t, config.Query), nil
+		})
+	env.endSession(t, planSession, store.SessionStatusCompleted, "Plan")
+	env.endSession(t, env.launched[4], store.SessionStatusCompleted, "Done")
+
+	run, err = env.store.GetPipelineRun(env.ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.PipelineStatusCompleted, run.Status)
+	assert.NotNil(t, run.CompletedAt)
+
+	var statuses []string
+	for len(env.events.Channel) > 0 {
+		event := <-env.events.Channel
+		statuses = append(statuses, event.Data["new_status"].(string))
+	}
+	assert.Equal(t, []string{"running", "failed", "running", "completed"}, statuses)
+}
+
+func TestPipelineRunnerFollowsContinuations(t *testing.T) {
+	env := newPipelineTestEnv(t)
+	require.NoError(t, env.store.CreatePipeline(env.ctx, &store.Pipeline{
+		ID: "pipe_1", Name: "Build", Steps: `[{"name": "build", "launch": {"query": "Build it"}}]`,
+	}))
+	env.sessions.EXPECT().
+		LaunchSession(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, config LaunchSessionConfig, _ bool) (*Session, error) {
+			return env.createSession(t, config.Query), nil
+		})
+	run, err := env.runner.StartRun(env.ctx, "pipe_1", "/repo", nil)
+	require.NoError(t, err)
+
+	// continueSession records a session carrying on parentID
+	continueSession := func(t *testing.T, id, parentID string) {
+		require.NoError(t, env.store.CreateSession(env.ctx, &store.Session{
+			ID:              id,
+			RunID:           "run_" + id,
+			ParentSessionID: parentID,
+			Query:           "Continue",
+			Status:          store.SessionStatusRunning,
+			CreatedAt:       time.Now(),
+		}))
+	}
+	stepSessionID := func(t *testing.T) string {
+		run, err := env.store.GetPipelineRun(env.ctx, run.ID)
+		require.NoError(t, err)
+		return run.Steps[0].SessionID
+	}
+
+	// The step waits for the retry of a session that failed transiently
+	retryAt := time.Now().Add(time.Minute)
+	retryAtPtr := &retryAt
+	require.NoError(t, env.store.UpdateSession(env.ctx, "sess_a", store.SessionUpdate{RetryAt: &retryAtPtr}))
+	env.endSession(t, "sess_a", store.SessionStatusFailed, "")
+	assert.Equal(t, "running", env.stepStatuses(t, run.ID)["build"])
+
+	continueSession(t, "sess_a_retry", "sess_a")
+	var noRetry *time.Time
+	require.NoError(t, env.store.UpdateSession(env.ctx, "sess_a", store.SessionUpdate{RetryAt: &noRetry}))
+
+	// The retry is cut off by a daemon restart, and the step waits for its recovery
+	reason := StopReasonDaemonRestarted
+	require.NoError(t, env.store.UpdateSession(env.ctx, "sess_a_retry", store.SessionUpdate{StopReason: &reason}))
+	env.endSession(t, "sess_a_retry", store.SessionStatusInterrupted, "")
+	assert.Equal(t, "running", env.stepStatuses(t, run.ID)["build"])
+	assert.Equal(t, "sess_a_retry", stepSessionID(t))
+
+	// The recovered session's result settles the step
+	continueSession(t, "sess_a_recovered", "sess_a_retry")
+	env.endSession(t, "sess_a_recovered", store.SessionStatusCompleted, "Built")
+	assert.Equal(t, "sess_a_recovered", stepSessionID(t))
+	run, err = env.store.GetPipelineRun(env.ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.PipelineStatusCompleted, run.Status)
+}
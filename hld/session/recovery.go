@@ -0,0 +1,167 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// StopReasonDaemonRestarted is recorded on sessions that were active when the
+// daemon went down. Their process is gone, but the conversation can be resumed.
+const StopReasonDaemonRestarted = "daemon_restarted"
+
+// RecoveryMode decides what happens on startup to the sessions that were
+// active when the daemon went down
+type RecoveryMode string
+
+const (
+	RecoveryModeFail  RecoveryMode = "fail"  // Mark them failed
+	RecoveryModeOffer RecoveryMode = "offer" // Leave them interrupted, to be resumed with one click
+	RecoveryModeAuto  RecoveryMode = "auto"  // Resume them as soon as the daemon is up
+)
+
+// Valid reports whether m is a known recovery mode. Empty means RecoveryModeOffer.
+func (m RecoveryMode) Valid() bool {
+	switch m {
+	case "", RecoveryModeFail, RecoveryModeOffer, RecoveryModeAuto:
+		return true
+	}
+	return false
+}
+
+// recoveryQuery is sent to the session resuming one cut off by a daemon restart
+const recoveryQuery = "The daemon running your previous turn restarted and it was cut off. Continue where you left off."
+
+// RecoveryError reports a session that can't be recovered
+type RecoveryError struct {
+	Message string
+}
+
+func (e *RecoveryError) Error() string {
+	return e.Message
+}
+
+// RecoverSession resumes a session that was active when the daemon went down
+// in a new session. The approvals it was waiting on are carried over, so the
+// resumed session is answered by a decision on them, and a recovered event
+// marks the cut in the resumed session's conversation.
+func (m *Manager) RecoverSession(ctx context.Context, sessionID string) (*Session, error) {
+	dbSession, err := m.store.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if dbSession.StopReason != StopReasonDaemonRestarted {
+		return nil, &RecoveryError{Message: fmt.Sprintf("session %s was not cut off by a daemon restart", sessionID)}
+	}
+	if dbSession.RecoveredAt != nil {
+		return nil, &RecoveryError{Message: fmt.Sprintf("session %s was already recovered", sessionID)}
+	}
+
+	// Claim the session first so it isn't recovered twice
+	now := time.Now()
+	recoveredAt := &now
+	if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{RecoveredAt: &recoveredAt}); err != nil {
+		return nil, fmt.Errorf("failed to record recovery: %w", err)
+	}
+
+	pending, err := m.store.GetPendingApprovals(ctx, sessionID)
+	if err != nil {
+		slog.Warn("failed to get pending approvals of recovered session", "session_id", sessionID, "error", err)
+	}
+
+	recovered, err := m.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: sessionID,
+		Query:           recoveryQuery,
+	})
+	if err != nil {
+		var notRecovered *time.Time
+		if updateErr := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{RecoveredAt: &notRecovered}); updateErr != nil {
+			slog.Error("failed to release session after failed recovery", "session_id", sessionID, "error", updateErr)
+		}
+		return nil, err
+	}
+
+	// The MCP approval server hands these to the resumed session when it
+	// requests the same tool calls again
+	for _, approval := range pending {
+		if approval.ToolUseID == nil {
+			continue
+		}
+		if err := m.store.ReassignApproval(ctx, approval.ID, recovered.ID, recovered.RunID, *approval.ToolUseID); err != nil {
+			slog.Warn("failed to carry approval over to recovered session",
+				"approval_id", approval.ID,
+				"session_id", sessionID,
+				"error", err)
+		}
+	}
+
+	// The recovered event belongs to the resumed session's conversation,
+	// which only exists once Claude reports its ID. If it already did, the
+	// event is added here instead.
+	content := fmt.Sprintf("Daemon restarted; resumed from session %s", sessionID)
+	m.pendingRecoveries.Store(recovered.ID, content)
+	if child, err := m.store.GetSession(ctx, recovered.ID); err == nil && child.ClaudeSessionID != "" {
+		if content, ok := m.pendingRecoveries.LoadAndDelete(recovered.ID); ok {
+			m.addRecoveredEvent(ctx, recovered.ID, child.ClaudeSessionID, content.(string))
+		}
+	}
+
+	slog.Info("recovered session after daemon restart",
+		"session_id", sessionID,
+		"recovered_session_id", recovered.ID,
+		"approvals", len(pending))
+	return recovered, nil
+}
+
+// addRecoveredEvent marks in a recovered session's conversation that it
+// resumes one cut off by a daemon restart
+func (m *Manager) addRecoveredEvent(ctx context.Context, sessionID, claudeSessionID, content string) {
+	if err := m.store.AddConversationEvent(ctx, &store.ConversationEvent{
+		SessionID:       sessionID,
+		ClaudeSessionID: claudeSessionID,
+		EventType:       store.EventTypeRecovered,
+		Role:            "system",
+		Content:         content,
+	}); err != nil {
+		slog.Error("failed to record recovered event", "session_id", sessionID, "error", err)
+		return
+	}
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventConversationUpdated,
+			Data: map[string]interface{}{
+				"session_id":        sessionID,
+				"claude_session_id": claudeSessionID,
+				"event_type":        store.EventTypeRecovered,
+				"content":           content,
+				"content_type":      "system",
+			},
+		})
+	}
+}
+
+// ResumePendingRetries arms the retries of failed sessions that were waiting
+// for their backoff when the daemon went down. Overdue retries start now.
+func (m *Manager) ResumePendingRetries(ctx context.Context) error {
+	sessions, err := m.store.ListSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	for _, s := range sessions {
+		if s.Status != store.SessionStatusFailed || s.RetryAt == nil {
+			continue
+		}
+		sessionID := s.ID
+		delay := max(time.Until(*s.RetryAt), 0)
+		slog.Info("rescheduling pending retry", "session_id", sessionID, "delay", delay)
+		time.AfterFunc(delay, func() {
+			m.retrySession(context.Background(), sessionID)
+		})
+	}
+	return nil
+}
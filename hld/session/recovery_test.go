@@ -0,0 +1,166 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/config"
+	"github.com/humanlayer/humanlayer/hld/internal/testutil"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverSession(t *testing.T) {
+	ctx := context.Background()
+	// Launches and retries use the store from other goroutines, so it can't be in memory
+	sqliteStore, err := store.NewSQLiteStore(testutil.DatabasePath(t, "recovery"))
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+
+	// A stand-in for Claude that records its arguments, reports a
+	// conversation and exits
+	claudePath := filepath.Join(t.TempDir(), "claude")
+	require.NoError(t, os.WriteFile(claudePath, []byte(`#!/bin/sh
+echo "$@" >> "$0.args"
+echo "{\"type\":\"system\",\"subtype\":\"init\",\"session_id\":\"claude_$$\"}"
+`), 0755))
+	manager, err := NewManagerWithConfig(bus.NewEventBus(), sqliteStore, "", &config.Config{ClaudePath: claudePath})
+	require.NoError(t, err)
+
+	newSession := func(t *testing.T, id, status, stopReason string) {
+		require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+			ID:              id,
+			RunID:           "run_" + id,
+			ClaudeSessionID: "claude_" + id,
+			Query:           "q",
+			Status:          status,
+			WorkingDir:      t.TempDir(),
+			CreatedAt:       time.Now(),
+			LastActivityAt:  time.Now(),
+		}))
+		require.NoError(t, sqliteStore.UpdateSession(ctx, id, store.SessionUpdate{StopReason: &stopReason}))
+	}
+	// waitForExit waits for the stand-in Claude of a session to exit
+	waitForExit := func(t *testing.T, sessionID string) {
+		require.Eventually(t, func() bool {
+			status := mustGetSession(t, sqliteStore, sessionID).Status
+			return status == store.SessionStatusCompleted || status == store.SessionStatusFailed
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	t.Run("not cut off by a restart", func(t *testing.T) {
+		newSession(t, "done", store.SessionStatusCompleted, "")
+		_, err := manager.RecoverSession(ctx, "done")
+		var recoveryErr *RecoveryError
+		assert.ErrorAs(t, err, &recoveryErr)
+	})
+
+	t.Run("resumes the session with its pending approvals", func(t *testing.T) {
+		newSession(t, "cut", store.SessionStatusInterrupted, StopReasonDaemonRestarted)
+		toolUseID := "toolu_before_restart"
+		require.NoError(t, sqliteStore.CreateApproval(ctx, &store.Approval{
+			ID:        "local-approval",
+			RunID:     "run_cut",
+			SessionID: "cut",
+			ToolUseID: &toolUseID,
+			Status:    store.ApprovalStatusLocalPending,
+			CreatedAt: time.Now(),
+			ToolName:  "Bash",
+			ToolInput: json.RawMessage(`{"command":"make deploy"}`),
+		}))
+
+		recovered, err := manager.RecoverSession(ctx, "cut")
+		require.NoError(t, err)
+		waitForExit(t, recovered.ID)
+
+		child := mustGetSession(t, sqliteStore, recovered.ID)
+		assert.Equal(t, "cut", child.ParentSessionID)
+		assert.Equal(t, recoveryQuery, child.Query)
+		assert.NotNil(t, mustGetSession(t, sqliteStore, "cut").RecoveredAt)
+
+		approval, err := sqliteStore.GetApproval(ctx, "local-approval")
+		require.NoError(t, err)
+		assert.Equal(t, recovered.ID, approval.SessionID)
+		assert.Equal(t, recovered.RunID, approval.RunID)
+		assert.Equal(t, store.ApprovalStatusLocalPending, approval.Status)
+
+		// The cut is marked in the conversation the resumed session continues
+		require.NotEmpty(t, child.ClaudeSessionID)
+		events, err := sqliteStore.GetConversation(ctx, child.ClaudeSessionID)
+		require.NoError(t, err)
+		var recoveredEvents []*store.ConversationEvent
+		for _, event := range events {
+			if event.EventType == store.EventTypeRecovered {
+				recoveredEvents = append(recoveredEvents, event)
+			}
+		}
+		require.Len(t, recoveredEvents, 1)
+		assert.Equal(t, recovered.ID, recoveredEvents[0].SessionID)
+		assert.Contains(t, recoveredEvents[0].Content, "cut")
+
+		// A session is recovered once
+		_, err = manager.RecoverSession(ctx, "cut")
+		var recoveryErr *RecoveryError
+		assert.ErrorAs(t, err, &recoveryErr)
+	})
+
+	t.Run("a continuation requeued as a draft resumes its parent", func(t *testing.T) {
+		newSession(t, "parent", store.SessionStatusCompleted, "")
+		require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+			ID:              "requeued",
+			RunID:           "run_requeued",
+			ParentSessionID: "parent",
+			Query:           "and now the tests",
+			Status:          store.SessionStatusDraft,
+			WorkingDir:      t.TempDir(),
+			CreatedAt:       time.Now(),
+			LastActivityAt:  time.Now(),
+		}))
+		require.NoError(t, os.Remove(claudePath+".args"))
+
+		require.NoError(t, manager.LaunchDraftSession(ctx, "requeued", "and now the tests", false))
+		waitForExit(t, "requeued")
+		args, err := os.ReadFile(claudePath + ".args")
+		require.NoError(t, err)
+		assert.Contains(t, string(args), "--resume claude_parent")
+		assert.Contains(t, string(args), "--fork-session")
+	})
+
+	t.Run("pending retries are rescheduled", func(t *testing.T) {
+		newSession(t, "flaky", store.SessionStatusFailed, "")
+		retryAt := time.Now().Add(-time.Minute)
+		retryAtPtr := &retryAt
+		require.NoError(t, sqliteStore.UpdateSession(ctx, "flaky", store.SessionUpdate{RetryAt: &retryAtPtr}))
+
+		require.NoError(t, manager.ResumePendingRetries(ctx))
+		var retry *store.Session
+		require.Eventually(t, func() bool {
+			sessions, err := sqliteStore.ListSessions(ctx)
+			if err != nil {
+				return false
+			}
+			for _, s := range sessions {
+				if s.ParentSessionID == "flaky" {
+					retry = s
+				}
+			}
+			return retry != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 1, retry.RetryCount)
+		assert.Nil(t, mustGetSession(t, sqliteStore, "flaky").RetryAt)
+		waitForExit(t, retry.ID)
+	})
+}
+
+func TestRecoveryModeValid(t *testing.T) {
+	for _, mode := range []RecoveryMode{"", RecoveryModeFail, RecoveryModeOffer, RecoveryModeAuto} {
+		assert.True(t, mode.Valid(), mode)
+	}
+	assert.False(t, RecoveryMode("reattach").Valid())
+}
@@ -0,0 +1,183 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// maxRetryBackoff is as long as the delay between retries grows, unless the
+// policy's own backoff is longer
+const maxRetryBackoff = 30 * time.Minute
+
+// defaultRetryBackoff is the delay before the first retry when the policy sets none
+const defaultRetryBackoff = 30 * time.Second
+
+// retryQuery is sent to the session resuming a failed session
+const retryQuery = "The previous attempt failed with a temporary error. Continue where you left off."
+
+// RetryPolicy resumes sessions that fail with a transient error
+type RetryPolicy struct {
+	MaxAttempts int           // Retries after the first failure, 0 never retries
+	Backoff     time.Duration // Delay before the first retry, doubled for each one after it
+}
+
+// Validate rejects negative settings
+func (p RetryPolicy) Validate() error {
+	switch {
+	case p.MaxAttempts < 0:
+		return fmt.Errorf("max_retries must not be negative")
+	case p.Backoff < 0:
+		return fmt.Errorf("retry_backoff must not be negative")
+	}
+	return nil
+}
+
+// applyTo records the policy on a stored session
+func (p RetryPolicy) applyTo(s *store.Session) {
+	s.MaxRetries = p.MaxAttempts
+	s.RetryBackoffMs = p.Backoff.Milliseconds()
+}
+
+// delay returns how long to wait before the given attempt, counting from 1.
+// A retry-after the CLI reported is waited out even when it is longer.
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	backoff := p.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	limit := max(maxRetryBackoff, backoff)
+	for i := 1; i < attempt && backoff < limit; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, limit)
+	if retryAfter > backoff {
+		return retryAfter
+	}
+	return backoff
+}
+
+// sessionRetryPolicy returns the retry policy a stored session launched with
+func sessionRetryPolicy(s *store.Session) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: s.MaxRetries,
+		Backoff:     time.Duration(s.RetryBackoffMs) * time.Millisecond,
+	}
+}
+
+// retryPolicyFor returns the launch's retry policy, or the daemon's default
+// when the launch doesn't set one. A launch that only sets the number of
+// attempts keeps the default backoff.
+func (m *Manager) retryPolicyFor(policy *RetryPolicy) RetryPolicy {
+	if policy == nil {
+		return m.retryDefaults
+	}
+	resolved := *policy
+	if resolved.Backoff == 0 {
+		resolved.Backoff = m.retryDefaults.Backoff
+	}
+	return resolved
+}
+
+// isTransient reports whether a failure is worth retrying: the CLI classified
+// the error as temporary, like a rate limit or an overloaded API, or the CLI
+// crashed, exiting without reporting a result
+func isTransient(err error, claudeErr *claudecode.ClaudeError) bool {
+	if failureActionFor(claudeErr.Kind) == FailureActionRetry {
+		return true
+	}
+	// Errors that didn't come from the CLI, like a missing binary, are no crash
+	var cliErr *claudecode.ClaudeError
+	return errors.As(err, &cliErr) && claudeErr.Kind == claudecode.ErrUnknown && claudeErr.Subtype == ""
+}
+
+// scheduleRetry resumes a failed session after a backoff when its retry
+// policy allows another attempt
+func (m *Manager) scheduleRetry(ctx context.Context, sessionID string, err error, claudeErr *claudecode.ClaudeError) {
+	if !isTransient(err, claudeErr) {
+		return
+	}
+	dbSession, getErr := m.store.GetSession(ctx, sessionID)
+	if getErr != nil || dbSession == nil {
+		return
+	}
+	policy := sessionRetryPolicy(dbSession)
+	// A session that failed before its conversation started has nothing to resume
+	if dbSession.RetryCount >= policy.MaxAttempts || dbSession.ClaudeSessionID == "" {
+		return
+	}
+
+	attempt := dbSession.RetryCount + 1
+	delay := policy.delay(attempt, claudeErr.RetryAfter)
+	retryAt := time.Now().Add(delay)
+	retryAtPtr := &retryAt
+	if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{RetryAt: &retryAtPtr}); err != nil {
+		slog.Error("failed to record pending retry", "session_id", sessionID, "error", err)
+		return
+	}
+
+	slog.Info("scheduling retry of failed session",
+		"session_id", sessionID,
+		"attempt", attempt,
+		"max_attempts", policy.MaxAttempts,
+		"delay", delay)
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionRetryScheduled,
+			Data: map[string]interface{}{
+				"session_id":   sessionID,
+				"run_id":       dbSession.RunID,
+				"attempt":      attempt,
+				"max_attempts": policy.MaxAttempts,
+				"retry_at":     retryAt,
+				"error_kind":   claudeErr.Kind.String(),
+			},
+		})
+	}
+
+	// The failure's context ends with the failed run, so the retry gets its own
+	time.AfterFunc(delay, func() {
+		m.retrySession(context.Background(), sessionID)
+	})
+}
+
+// retrySession resumes a failed session whose retry is due in a new session,
+// the next attempt
+func (m *Manager) retrySession(ctx context.Context, sessionID string) {
+	dbSession, err := m.store.GetSession(ctx, sessionID)
+	if err != nil || dbSession == nil {
+		slog.Warn("failed session to retry is gone", "session_id", sessionID, "error", err)
+		return
+	}
+	// The session was continued by hand in the meantime, or resumed otherwise
+	if dbSession.RetryAt == nil || dbSession.Status != store.SessionStatusFailed {
+		return
+	}
+
+	// Continuing the session clears its pending retry once the retry exists,
+	// so a pipeline following the session always sees one or the other
+	retried, err := m.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: sessionID,
+		Query:           retryQuery,
+		RetryCount:      dbSession.RetryCount + 1,
+	})
+	if err != nil {
+		slog.Error("failed to retry session", "session_id", sessionID, "error", err)
+		var noRetry *time.Time
+		if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{RetryAt: &noRetry}); err != nil {
+			slog.Error("failed to clear pending retry", "session_id", sessionID, "error", err)
+		}
+		return
+	}
+	slog.Info("retried failed session",
+		"session_id", sessionID,
+		"retry_session_id", retried.ID,
+		"attempt", dbSession.RetryCount+1)
+}
@@ -0,0 +1,142 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/config"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, Backoff: time.Minute}
+	assert.Equal(t, time.Minute, policy.delay(1, 0))
+	assert.Equal(t, 2*time.Minute, policy.delay(2, 0))
+	assert.Equal(t, 8*time.Minute, policy.delay(4, 0))
+	assert.Equal(t, maxRetryBackoff, policy.delay(10, 0))
+	// A backoff longer than the limit isn't cut short, nor doubled
+	assert.Equal(t, time.Hour, RetryPolicy{Backoff: time.Hour}.delay(3, 0))
+	// A longer retry-after from the CLI wins
+	assert.Equal(t, 5*time.Minute, policy.delay(1, 5*time.Minute))
+
+	assert.Equal(t, defaultRetryBackoff, RetryPolicy{MaxAttempts: 1}.delay(1, 0))
+
+	assert.NoError(t, RetryPolicy{}.Validate())
+	assert.EqualError(t, RetryPolicy{MaxAttempts: -1}.Validate(), "max_retries must not be negative")
+	assert.EqualError(t, RetryPolicy{Backoff: -time.Second}.Validate(), "retry_backoff must not be negative")
+}
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"overloaded", &claudecode.ClaudeError{Kind: claudecode.ErrOverloaded}, true},
+		{"crash", fmt.Errorf("wait: %w", &claudecode.ClaudeError{Kind: claudecode.ErrUnknown, ExitCode: 1}), true},
+		{"error result", &claudecode.ClaudeError{Kind: claudecode.ErrUnknown, Subtype: "error_during_execution"}, false},
+		{"auth", &claudecode.ClaudeError{Kind: claudecode.ErrAuth}, false},
+		{"not from the CLI", errors.New("claude not found"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isTransient(tc.err, classifyFailure(tc.err)))
+		})
+	}
+}
+
+func TestScheduleRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+	eventBus := bus.NewEventBus()
+	events := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventSessionRetryScheduled}})
+	// The retried session must not start a real Claude process
+	manager, err := NewManagerWithConfig(eventBus, sqliteStore, "", &config.Config{
+		ClaudePath: filepath.Join(t.TempDir(), "claude"),
+	})
+	require.NoError(t, err)
+
+	overloaded := &claudecode.ClaudeError{Kind: claudecode.ErrOverloaded, Message: "API Error: 529"}
+	newSession := func(t *testing.T, id string, maxRetries, retryCount int) {
+		require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+			ID:              id,
+			RunID:           "run_" + id,
+			ClaudeSessionID: "claude_" + id,
+			Query:           "q",
+			Status:          store.SessionStatusRunning,
+			MaxRetries:      maxRetries,
+			WorkingDir:      t.TempDir(),
+			RetryBackoffMs:  int64(time.Minute / time.Millisecond),
+			RetryCount:      retryCount,
+		}))
+	}
+
+	t.Run("retries left", func(t *testing.T) {
+		newSession(t, "flaky", 3, 1)
+		manager.failSession(ctx, "flaky", "run_flaky", overloaded.Message, overloaded)
+
+		stored := mustGetSession(t, sqliteStore, "flaky")
+		assert.Equal(t, store.SessionStatusFailed, stored.Status)
+		require.NotNil(t, stored.RetryAt)
+		// The second retry waits twice the backoff
+		assert.WithinDuration(t, time.Now().Add(2*time.Minute), *stored.RetryAt, 10*time.Second)
+
+		select {
+		case event := <-events.Channel:
+			assert.Equal(t, "flaky", event.Data["session_id"])
+			assert.Equal(t, 2, event.Data["attempt"])
+			assert.Equal(t, "overloaded", event.Data["error_kind"])
+		case <-time.After(time.Second):
+			t.Fatal("no retry scheduled event")
+		}
+	})
+
+	t.Run("out of retries", func(t *testing.T) {
+		newSession(t, "broken", 2, 2)
+		manager.failSession(ctx, "broken", "run_broken", overloaded.Message, overloaded)
+		assert.Nil(t, mustGetSession(t, sqliteStore, "broken").RetryAt)
+	})
+
+	t.Run("not transient", func(t *testing.T) {
+		newSession(t, "denied", 3, 0)
+		authErr := &claudecode.ClaudeError{Kind: claudecode.ErrAuth, Message: "invalid api key"}
+		manager.failSession(ctx, "denied", "run_denied", authErr.Message, authErr)
+		assert.Nil(t, mustGetSession(t, sqliteStore, "denied").RetryAt)
+	})
+
+	t.Run("retry launches the next attempt", func(t *testing.T) {
+		manager.retrySession(ctx, "flaky")
+
+		assert.Nil(t, mustGetSession(t, sqliteStore, "flaky").RetryAt)
+		sessions, err := sqliteStore.ListSessions(ctx)
+		require.NoError(t, err)
+		var retries []*store.Session
+		for _, s := range sessions {
+			if s.ParentSessionID == "flaky" {
+				retries = append(retries, s)
+			}
+		}
+		require.Len(t, retries, 1)
+		assert.Equal(t, 2, retries[0].RetryCount)
+		assert.Equal(t, 3, retries[0].MaxRetries)
+		assert.Equal(t, retryQuery, retries[0].Query)
+
+		// Once launched, the retry is not launched again
+		manager.retrySession(ctx, "flaky")
+		sessions, err = sqliteStore.ListSessions(ctx)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 4)
+	})
+	assert.Empty(t, events.Channel)
+}
@@ -0,0 +1,293 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// Catch-up policies decide what happens to runs a schedule missed while the
+// daemon was down
+const (
+	CatchUpSkip = "skip" // Record the missed runs and wait for the next one
+	CatchUpOnce = "once" // Run once on startup, however many runs were missed
+)
+
+// ScheduledLaunch is the launch configuration stored with a schedule
+type ScheduledLaunch struct {
+	Query                 string      `json:"query"`
+	Title                 string      `json:"title,omitempty"`
+	WorkingDir            string      `json:"working_dir,omitempty"`
+	Model                 string      `json:"model,omitempty"`
+	PermissionMode        string      `json:"permission_mode,omitempty"`
+	MaxTurns              int         `json:"max_turns,omitempty"`
+	SystemPrompt          string      `json:"system_prompt,omitempty"`
+	AppendSystemPrompt    string      `json:"append_system_prompt,omitempty"`
+	AllowedTools          []string    `json:"allowed_tools,omitempty"`
+	DisallowedTools       []string    `json:"disallowed_tools,omitempty"`
+	AdditionalDirectories []string    `json:"additional_directories,omitempty"`
+	Priority              Priority    `json:"priority,omitempty"`
+	MaxCostUSD            float64     `json:"max_cost_usd,omitempty"`
+	MaxOutputTokens       int         `json:"max_output_tokens,omitempty"`
+	MaxWallClockMs        int64       `json:"max_wall_clock,omitempty"`
+	StallPolicy           StallPolicy `json:"stall_policy,omitempty"`
+	MaxRetries            *int        `json:"max_retries,omitempty"`
+	RetryBackoffMs        int64       `json:"retry_backoff,omitempty"`
+}
+
+// LaunchConfig returns the session launch configuration. Sessions are titled
+// after the schedule unless the launch sets a title.
+func (l ScheduledLaunch) LaunchConfig(scheduleName string) LaunchSessionConfig {
+	config := LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:                 l.Query,
+			Model:                 claudecode.Model(l.Model),
+			WorkingDir:            l.WorkingDir,
+			MaxTurns:              l.MaxTurns,
+			SystemPrompt:          l.SystemPrompt,
+			AppendSystemPrompt:    l.AppendSystemPrompt,
+			AllowedTools:          l.AllowedTools,
+			DisallowedTools:       l.DisallowedTools,
+			AdditionalDirectories: l.AdditionalDirectories,
+			PermissionMode:        claudecode.PermissionMode(l.PermissionMode),
+			OutputFormat:          claudecode.OutputStreamJSON,
+		},
+		Title:    l.Title,
+		Priority: l.Priority,
+		Budget: Budget{
+			MaxCostUSD:      l.MaxCostUSD,
+			MaxOutputTokens: l.MaxOutputTokens,
+			MaxWallClock:    time.Duration(l.MaxWallClockMs) * time.Millisecond,
+		},
+		StallPolicy: l.StallPolicy,
+	}
+	if l.MaxRetries != nil {
+		config.Retry = &RetryPolicy{
+			MaxAttempts: *l.MaxRetries,
+			Backoff:     time.Duration(l.RetryBackoffMs) * time.Millisecond,
+		}
+	}
+	if config.Title == "" {
+		config.Title = scheduleName
+	}
+	return config
+}
+
+// ParseScheduledLaunch decodes and validates the launch configuration of a
+// schedule
+func ParseScheduledLaunch(data string) (ScheduledLaunch, error) {
+	var l ScheduledLaunch
+	if err := json.Unmarshal([]byte(data), &l); err != nil {
+		return l, fmt.Errorf("invalid launch configuration: %w", err)
+	}
+	if strings.TrimSpace(l.Query) == "" {
+		return l, errors.New("launch configuration has no query")
+	}
+	if !l.Priority.Valid() {
+		return l, fmt.Errorf("invalid priority %q: must be low, normal or high", l.Priority)
+	}
+	if err := l.LaunchConfig("").Validate(); err != nil {
+		return l, err
+	}
+	return l, nil
+}
+
+// ValidateSchedule checks a schedule before it is stored
+func ValidateSchedule(schedule *store.Schedule) error {
+	if strings.TrimSpace(schedule.Name) == "" {
+		return errors.New("schedule name is required")
+	}
+	switch {
+	case schedule.CronExpr != "" && schedule.RunAt != nil:
+		return errors.New("a schedule takes either cron or run_at, not both")
+	case schedule.CronExpr != "":
+		if _, err := parseCron(schedule.CronExpr); err != nil {
+			return err
+		}
+	case schedule.RunAt == nil:
+		return errors.New("a schedule needs cron or run_at")
+	}
+	switch schedule.CatchUp {
+	case CatchUpSkip, CatchUpOnce:
+	default:
+		return fmt.Errorf("invalid catch_up %q: must be skip or once", schedule.CatchUp)
+	}
+	_, err := ParseScheduledLaunch(schedule.LaunchConfig)
+	return err
+}
+
+// NextScheduleRun returns when a schedule runs next after t, or nil when it
+// will not run again. One-shot schedules run at RunAt until they have run,
+// even when RunAt has passed.
+func NextScheduleRun(schedule *store.Schedule, t time.Time) (*time.Time, error) {
+	if schedule.CronExpr == "" {
+		if schedule.RunAt == nil || (schedule.LastRunAt != nil && !schedule.LastRunAt.Before(*schedule.RunAt)) {
+			return nil, nil
+		}
+		next := *schedule.RunAt
+		return &next, nil
+	}
+	cron, err := parseCron(schedule.CronExpr)
+	if err != nil {
+		return nil, err
+	}
+	next := cron.next(t.In(time.Local))
+	if next.IsZero() {
+		return nil, nil
+	}
+	return &next, nil
+}
+
+// ScheduleRunner launches the sessions of due schedules
+type ScheduleRunner struct {
+	store    store.ConversationStore
+	sessions SessionManager
+	interval time.Duration
+}
+
+// NewScheduleRunner creates a runner that checks for due schedules every interval
+func NewScheduleRunner(store store.ConversationStore, sessions SessionManager, interval time.Duration) *ScheduleRunner {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &ScheduleRunner{
+		store:    store,
+		sessions: sessions,
+		interval: interval,
+	}
+}
+
+// Start applies the catch-up policies to runs missed while the daemon was
+// down, then runs due schedules until ctx is cancelled
+func (r *ScheduleRunner) Start(ctx context.Context) {
+	slog.Info("starting schedule runner", "interval", r.interval)
+
+	r.catchUp(ctx, time.Now())
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("schedule runner shutting down")
+			return
+		case now := <-ticker.C:
+			r.runDue(ctx, now)
+		}
+	}
+}
+
+// catchUp handles the schedules whose next run passed while the daemon was down
+func (r *ScheduleRunner) catchUp(ctx context.Context, now time.Time) {
+	for _, schedule := range r.dueSchedules(ctx, now) {
+		if schedule.CatchUp == CatchUpOnce {
+			r.run(ctx, schedule, *schedule.NextRunAt, now)
+			continue
+		}
+
+		run := &store.ScheduleRun{
+			ScheduleID:   schedule.ID,
+			ScheduledFor: *schedule.NextRunAt,
+			TriggeredAt:  now,
+			Status:       store.ScheduleRunMissed,
+			Error:        "daemon was not running",
+		}
+		if err := r.store.CreateScheduleRun(ctx, run); err != nil {
+			slog.Error("failed to record missed schedule run", "schedule_id", schedule.ID, "error", err)
+		}
+		slog.Info("skipped missed schedule run",
+			"schedule_id", schedule.ID,
+			"scheduled_for", schedule.NextRunAt)
+		r.advance(ctx, schedule, now, nil)
+	}
+}
+
+// runDue launches the sessions of the schedules that are due
+func (r *ScheduleRunner) runDue(ctx context.Context, now time.Time) {
+	for _, schedule := range r.dueSchedules(ctx, now) {
+		r.run(ctx, schedule, *schedule.NextRunAt, now)
+	}
+}
+
+func (r *ScheduleRunner) dueSchedules(ctx context.Context, now time.Time) []*store.Schedule {
+	if r.store == nil {
+		return nil
+	}
+	schedules, err := r.store.ListSchedules(ctx)
+	if err != nil {
+		slog.Error("failed to list schedules", "error", err)
+		return nil
+	}
+	var due []*store.Schedule
+	for _, schedule := range schedules {
+		if schedule.Enabled && schedule.NextRunAt != nil && !schedule.NextRunAt.After(now) {
+			due = append(due, schedule)
+		}
+	}
+	return due
+}
+
+// run launches a session for a schedule, records the run and moves the
+// schedule to its next run
+func (r *ScheduleRunner) run(ctx context.Context, schedule *store.Schedule, scheduledFor, now time.Time) {
+	run := &store.ScheduleRun{
+		ScheduleID:   schedule.ID,
+		ScheduledFor: scheduledFor,
+		TriggeredAt:  now,
+		Status:       store.ScheduleRunLaunched,
+	}
+
+	launch, err := ParseScheduledLaunch(schedule.LaunchConfig)
+	if err == nil {
+		var sess *Session
+		sess, err = r.sessions.LaunchSession(ctx, launch.LaunchConfig(schedule.Name), false)
+		if err == nil {
+			run.SessionID = sess.ID
+		}
+	}
+	if err != nil {
+		run.Status = store.ScheduleRunFailed
+		run.Error = err.Error()
+		slog.Error("failed to launch scheduled session",
+			"schedule_id", schedule.ID,
+			"scheduled_for", scheduledFor,
+			"error", err)
+	} else {
+		slog.Info("launched scheduled session",
+			"schedule_id", schedule.ID,
+			"session_id", run.SessionID,
+			"scheduled_for", scheduledFor)
+	}
+
+	if err := r.store.CreateScheduleRun(ctx, run); err != nil {
+		slog.Error("failed to record schedule run", "schedule_id", schedule.ID, "error", err)
+	}
+	r.advance(ctx, schedule, now, &now)
+}
+
+// advance moves a schedule to its first run after now. One-shot schedules
+// have no next run once they have run or been skipped.
+func (r *ScheduleRunner) advance(ctx context.Context, schedule *store.Schedule, now time.Time, lastRunAt *time.Time) {
+	var next *time.Time
+	if schedule.CronExpr != "" {
+		var err error
+		if next, err = NextScheduleRun(schedule, now); err != nil {
+			slog.Error("failed to compute next schedule run", "schedule_id", schedule.ID, "error", err)
+		}
+	}
+	update := store.ScheduleUpdate{
+		NextRunAt: &next,
+		LastRunAt: lastRunAt,
+	}
+	if err := r.store.UpdateSchedule(ctx, schedule.ID, update); err != nil {
+		slog.Error("failed to update schedule after run", "schedule_id", schedule.ID, "error", err)
+	}
+}
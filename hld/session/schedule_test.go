@@ -0,0 +1,174 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	runAt := time.Now()
+	valid := store.Schedule{Name: "audit", CronExpr: "0 9 * * mon", CatchUp: CatchUpSkip, LaunchConfig: `{"query":"audit"}`}
+	require.NoError(t, ValidateSchedule(&valid))
+
+	testCases := []struct {
+		name   string
+		modify func(*store.Schedule)
+		want   string
+	}{
+		{"no name", func(s *store.Schedule) { s.Name = " " }, "schedule name is required"},
+		{"no timing", func(s *store.Schedule) { s.CronExpr = "" }, "a schedule needs cron or run_at"},
+		{"both timings", func(s *store.Schedule) { s.RunAt = &runAt }, "either cron or run_at"},
+		{"bad cron", func(s *store.Schedule) { s.CronExpr = "0 25 * * *" }, "invalid hour"},
+		{"bad catch-up", func(s *store.Schedule) { s.CatchUp = "all" }, `invalid catch_up "all"`},
+		{"no query", func(s *store.Schedule) { s.LaunchConfig = `{"working_dir":"~/repo"}` }, "no query"},
+		{"bad priority", func(s *store.Schedule) { s.LaunchConfig = `{"query":"q","priority":"urgent"}` }, "invalid priority"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule := valid
+			tc.modify(&schedule)
+			err := ValidateSchedule(&schedule)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestNextScheduleRunOneShot(t *testing.T) {
+	runAt := time.Now().Add(-time.Hour)
+	schedule := &store.Schedule{RunAt: &runAt}
+
+	next, err := NextScheduleRun(schedule, time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, next, "a one-shot schedule in the past still runs once")
+	assert.True(t, next.Equal(runAt))
+
+	lastRun := time.Now()
+	schedule.LastRunAt = &lastRun
+	next, err = NextScheduleRun(schedule, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, next)
+}
+
+func newTestScheduleRunner(t *testing.T) (*ScheduleRunner, *store.SQLiteStore, *MockSessionManager) {
+	t.Helper()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+	sessions := NewMockSessionManager(gomock.NewController(t))
+	return NewScheduleRunner(sqliteStore, sessions, time.Minute), sqliteStore, sessions
+}
+
+func TestScheduleRunnerRunsDueSchedules(t *testing.T) {
+	ctx := context.Background()
+	runner, sqliteStore, sessions := newTestScheduleRunner(t)
+
+	now := time.Date(2026, 1, 19, 9, 0, 30, 0, time.Local)
+	due := now.Add(-30 * time.Second)
+	later := now.Add(time.Hour)
+	for _, schedule := range []*store.Schedule{
+		{ID: "weekly", Name: "Weekly audit", CronExpr: "0 9 * * mon", CatchUp: CatchUpSkip, Enabled: true,
+			LaunchConfig: `{"query":"audit","working_dir":"/repo","priority":"low"}`, NextRunAt: &due},
+		{ID: "once", Name: "One-off", RunAt: &due, CatchUp: CatchUpSkip, Enabled: true,
+			LaunchConfig: `{"query":"boom"}`, NextRunAt: &due},
+		{ID: "disabled", Name: "Off", CronExpr: "* * * * *", CatchUp: CatchUpSkip, Enabled: false,
+			LaunchConfig: `{"query":"q"}`, NextRunAt: &due},
+		{ID: "later", Name: "Later", CronExpr: "0 10 * * *", CatchUp: CatchUpSkip, Enabled: true,
+			LaunchConfig: `{"query":"q"}`, NextRunAt: &later},
+	} {
+		require.NoError(t, sqliteStore.CreateSchedule(ctx, schedule))
+	}
+
+	sessions.EXPECT().
+		LaunchSession(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, config LaunchSessionConfig, _ bool) (*Session, error) {
+			if config.Query == "boom" {
+				return nil, errors.New("claude not found")
+			}
+			assert.Equal(t, "audit", config.Query)
+			assert.Equal(t, "/repo", config.WorkingDir)
+			assert.Equal(t, "Weekly audit", config.Title)
+			assert.Equal(t, PriorityLow, config.Priority)
+			return &Session{ID: "sess_1"}, nil
+		}).
+		Times(2)
+
+	runner.runDue(ctx, now)
+
+	weekly, err := sqliteStore.GetSchedule(ctx, "weekly")
+	require.NoError(t, err)
+	require.NotNil(t, weekly.NextRunAt)
+	assert.True(t, weekly.NextRunAt.Equal(due.AddDate(0, 0, 7)), "next run is a week later, got %v", weekly.NextRunAt)
+	require.NotNil(t, weekly.LastRunAt)
+	runs, err := sqliteStore.ListScheduleRuns(ctx, "weekly", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, store.ScheduleRunLaunched, runs[0].Status)
+	assert.Equal(t, "sess_1", runs[0].SessionID)
+
+	once, err := sqliteStore.GetSchedule(ctx, "once")
+	require.NoError(t, err)
+	assert.Nil(t, once.NextRunAt, "a one-shot schedule runs once even when the launch fails")
+	runs, err = sqliteStore.ListScheduleRuns(ctx, "once", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, store.ScheduleRunFailed, runs[0].Status)
+	assert.Equal(t, "claude not found", runs[0].Error)
+
+	for _, id := range []string{"disabled", "later"} {
+		runs, err = sqliteStore.ListScheduleRuns(ctx, id, 0)
+		require.NoError(t, err)
+		assert.Empty(t, runs, "%s should not run", id)
+	}
+}
+
+func TestScheduleRunnerCatchUp(t *testing.T) {
+	ctx := context.Background()
+	runner, sqliteStore, sessions := newTestScheduleRunner(t)
+
+	now := time.Date(2026, 1, 21, 12, 0, 0, 0, time.Local)
+	missed := time.Date(2026, 1, 19, 9, 0, 0, 0, time.Local)
+	for _, schedule := range []*store.Schedule{
+		{ID: "skip", Name: "Skip", CronExpr: "0 9 * * mon", CatchUp: CatchUpSkip, Enabled: true,
+			LaunchConfig: `{"query":"skip"}`, NextRunAt: &missed},
+		{ID: "once", Name: "Once", CronExpr: "0 9 * * *", CatchUp: CatchUpOnce, Enabled: true,
+			LaunchConfig: `{"query":"once"}`, NextRunAt: &missed},
+	} {
+		require.NoError(t, sqliteStore.CreateSchedule(ctx, schedule))
+	}
+
+	sessions.EXPECT().
+		LaunchSession(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, config LaunchSessionConfig, _ bool) (*Session, error) {
+			assert.Equal(t, "once", config.Query)
+			return &Session{ID: "sess_once"}, nil
+		}).
+		Times(1)
+
+	runner.catchUp(ctx, now)
+
+	skip, err := sqliteStore.GetSchedule(ctx, "skip")
+	require.NoError(t, err)
+	assert.True(t, skip.NextRunAt.Equal(time.Date(2026, 1, 26, 9, 0, 0, 0, time.Local)))
+	assert.Nil(t, skip.LastRunAt)
+	runs, err := sqliteStore.ListScheduleRuns(ctx, "skip", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, store.ScheduleRunMissed, runs[0].Status)
+	assert.True(t, runs[0].ScheduledFor.Equal(missed))
+
+	once, err := sqliteStore.GetSchedule(ctx, "once")
+	require.NoError(t, err)
+	assert.True(t, once.NextRunAt.Equal(time.Date(2026, 1, 22, 9, 0, 0, 0, time.Local)))
+	runs, err = sqliteStore.ListScheduleRuns(ctx, "once", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "sess_once", runs[0].SessionID)
+}
@@ -0,0 +1,258 @@
+package session
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// Priority orders queued sessions. Higher priorities start first, and
+// sessions of the same priority start in the order they were launched.
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+)
+
+// Valid reports whether p is a known priority. Empty means PriorityNormal.
+func (p Priority) Valid() bool {
+	switch p {
+	case "", PriorityLow, PriorityNormal, PriorityHigh:
+		return true
+	}
+	return false
+}
+
+func (p Priority) rank() int {
+	switch p {
+	case PriorityHigh:
+		return 2
+	case PriorityLow:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// launch is a stored session whose Claude process has not been started yet
+type launch struct {
+	sessionID       string
+	runID           string
+	parentSessionID string // Set when continuing a session
+	config          claudecode.SessionConfig
+	priority        Priority
+	budget          Budget      // Limits the session is interrupted at
+	spent           budgetSpend // What the sessions it continues spent against the budget
+
+	// Set while queued
+	seq      uint64
+	queuedAt time.Time
+	ready    chan struct{} // Closed once the session is recorded as queued
+}
+
+// workingDir is the directory the per-directory limit applies to
+func (l *launch) workingDir() string {
+	if l.config.WorkingDir == "" {
+		return ""
+	}
+	return filepath.Clean(l.config.WorkingDir)
+}
+
+// scheduler limits how many Claude processes run at once, in total and per
+// working directory. Launches over the limits wait in a priority queue and
+// are started as running sessions end.
+type scheduler struct {
+	mu         sync.Mutex
+	maxTotal   int                // Zero means unlimited
+	maxPerDir  int                // Zero means unlimited
+	running    map[string]string  // Session ID to working directory
+	queue      []*launch          // In start order
+	dispatched map[string]*launch // Taken off the queue but not started yet
+	seq        uint64
+
+	// start runs a launch taken off the queue
+	start func(*launch)
+	// queueChanged receives the queue, in order, whenever it changes
+	queueChanged func([]*launch)
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		running:      make(map[string]string),
+		dispatched:   make(map[string]*launch),
+		start:        func(*launch) {},
+		queueChanged: func([]*launch) {},
+	}
+}
+
+// setLimits changes the limits, starting queued launches they now allow
+func (s *scheduler) setLimits(maxTotal, maxPerDir int) {
+	s.mu.Lock()
+	s.maxTotal = maxTotal
+	s.maxPerDir = maxPerDir
+	started, queue := s.dispatchLocked()
+	s.mu.Unlock()
+	s.launched(started, queue)
+}
+
+// admit reserves a slot for l and returns true, or queues l and returns
+// false. A queued launch is not started before its ready channel is closed.
+func (s *scheduler) admit(l *launch) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := l.workingDir()
+	if s.hasSlotLocked(dir) {
+		s.running[l.sessionID] = dir
+		return true
+	}
+
+	s.seq++
+	l.seq = s.seq
+	l.queuedAt = time.Now()
+	l.ready = make(chan struct{})
+	s.queue = append(s.queue, l)
+	sort.SliceStable(s.queue, func(i, j int) bool {
+		if a, b := s.queue[i].priority.rank(), s.queue[j].priority.rank(); a != b {
+			return a > b
+		}
+		return s.queue[i].seq < s.queue[j].seq
+	})
+	return false
+}
+
+// release frees the slot of a session that has stopped running and starts
+// the queued launches that now fit. It is a no-op for sessions without one.
+func (s *scheduler) release(sessionID string) {
+	s.mu.Lock()
+	if _, ok := s.running[sessionID]; !ok {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.running, sessionID)
+	started, queue := s.dispatchLocked()
+	s.mu.Unlock()
+	s.launched(started, queue)
+}
+
+// remove takes a launch off the queue, or keeps one already taken off it
+// from starting, reporting whether it did. The session is still queued as far
+// as the store knows until its launch starts.
+func (s *scheduler) remove(sessionID string) bool {
+	s.mu.Lock()
+	if _, ok := s.dispatched[sessionID]; ok {
+		delete(s.dispatched, sessionID)
+		s.mu.Unlock()
+		return true
+	}
+	index := -1
+	for i, l := range s.queue {
+		if l.sessionID == sessionID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		s.mu.Unlock()
+		return false
+	}
+	s.queue = append(s.queue[:index], s.queue[index+1:]...)
+	queue := append([]*launch(nil), s.queue...)
+	s.mu.Unlock()
+
+	s.queueChanged(queue)
+	return true
+}
+
+// position returns the 1-based queue position of a session, or 0 when it is
+// not queued
+func (s *scheduler) position(sessionID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, l := range s.queue {
+		if l.sessionID == sessionID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// snapshot returns the queue in start order
+func (s *scheduler) snapshot() []*launch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*launch(nil), s.queue...)
+}
+
+func (s *scheduler) hasSlotLocked(dir string) bool {
+	if s.maxTotal > 0 && len(s.running) >= s.maxTotal {
+		return false
+	}
+	if s.maxPerDir > 0 {
+		count := 0
+		for _, runningDir := range s.running {
+			if runningDir == dir {
+				count++
+			}
+		}
+		if count >= s.maxPerDir {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatchLocked reserves slots for the queued launches that fit, in queue
+// order. A launch waiting for a busy directory does not hold up launches for
+// other directories.
+func (s *scheduler) dispatchLocked() (started, queue []*launch) {
+	remaining := s.queue[:0]
+	for _, l := range s.queue {
+		dir := l.workingDir()
+		if s.hasSlotLocked(dir) {
+			s.running[l.sessionID] = dir
+			s.dispatched[l.sessionID] = l
+			started = append(started, l)
+		} else {
+			remaining = append(remaining, l)
+		}
+	}
+	s.queue = remaining
+	return started, append([]*launch(nil), s.queue...)
+}
+
+// launched starts dequeued launches once they are ready and reports the new
+// queue
+func (s *scheduler) launched(started, queue []*launch) {
+	if len(started) == 0 {
+		return
+	}
+	for _, l := range started {
+		go func(l *launch) {
+			<-l.ready
+			if s.claim(l) {
+				s.start(l)
+			}
+		}(l)
+	}
+	s.queueChanged(queue)
+}
+
+// claim takes a dispatched launch to start it. It reports false, and frees
+// the slot, when the launch was removed since it left the queue.
+func (s *scheduler) claim(l *launch) bool {
+	s.mu.Lock()
+	if s.dispatched[l.sessionID] == l {
+		delete(s.dispatched, l.sessionID)
+		s.mu.Unlock()
+		return true
+	}
+	s.mu.Unlock()
+	s.release(l.sessionID)
+	return false
+}
@@ -0,0 +1,202 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLaunch(id, dir string, priority Priority) *launch {
+	return &launch{
+		sessionID: id,
+		runID:     "run-" + id,
+		config:    claudecode.SessionConfig{Query: "q", WorkingDir: dir},
+		priority:  priority,
+	}
+}
+
+// recordingScheduler returns a scheduler that reports started launches on a channel
+func recordingScheduler(maxTotal, maxPerDir int) (*scheduler, chan string) {
+	started := make(chan string, 10)
+	s := newScheduler()
+	s.setLimits(maxTotal, maxPerDir)
+	s.start = func(l *launch) { started <- l.sessionID }
+	return s, started
+}
+
+func admitQueued(t *testing.T, s *scheduler, l *launch) {
+	t.Helper()
+	require.False(t, s.admit(l), "expected %s to be queued", l.sessionID)
+	close(l.ready)
+}
+
+func expectStarted(t *testing.T, started chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-started:
+		assert.Equal(t, want, got)
+	case <-time.After(time.Second):
+		t.Fatalf("expected %s to start", want)
+	}
+}
+
+func TestSchedulerUnlimited(t *testing.T) {
+	s, _ := recordingScheduler(0, 0)
+	for _, id := range []string{"a", "b", "c"} {
+		assert.True(t, s.admit(newTestLaunch(id, "/repo", "")))
+	}
+	assert.Empty(t, s.snapshot())
+}
+
+func TestSchedulerGlobalLimit(t *testing.T) {
+	s, started := recordingScheduler(2, 0)
+
+	assert.True(t, s.admit(newTestLaunch("a", "/one", "")))
+	assert.True(t, s.admit(newTestLaunch("b", "/two", "")))
+	admitQueued(t, s, newTestLaunch("c", "/three", ""))
+	assert.Equal(t, 1, s.position("c"))
+
+	// Releasing a session without a slot changes nothing
+	s.release("unknown")
+	assert.Equal(t, 1, s.position("c"))
+
+	s.release("a")
+	expectStarted(t, started, "c")
+	assert.Equal(t, 0, s.position("c"))
+	assert.False(t, s.admit(newTestLaunch("d", "/four", "")), "c now holds a's slot")
+}
+
+func TestSchedulerPerDirectoryLimit(t *testing.T) {
+	s, started := recordingScheduler(0, 1)
+
+	assert.True(t, s.admit(newTestLaunch("a", "/repo", "")))
+	admitQueued(t, s, newTestLaunch("b", "/repo/", ""))
+	assert.True(t, s.admit(newTestLaunch("c", "/other", "")), "other directories are not limited")
+
+	s.release("c")
+	select {
+	case id := <-started:
+		t.Fatalf("%s started while its directory was busy", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.release("a")
+	expectStarted(t, started, "b")
+}
+
+func TestSchedulerPriorityOrder(t *testing.T) {
+	s, started := recordingScheduler(1, 0)
+	var positions [][]string
+	s.queueChanged = func(queue []*launch) {
+		var ids []string
+		for _, l := range queue {
+			ids = append(ids, l.sessionID)
+		}
+		positions = append(positions, ids)
+	}
+
+	assert.True(t, s.admit(newTestLaunch("running", "/repo", "")))
+	admitQueued(t, s, newTestLaunch("low", "/repo", PriorityLow))
+	admitQueued(t, s, newTestLaunch("normal-1", "/repo", ""))
+	admitQueued(t, s, newTestLaunch("high", "/repo", PriorityHigh))
+	admitQueued(t, s, newTestLaunch("normal-2", "/repo", PriorityNormal))
+
+	ids := func() []string {
+		var ids []string
+		for _, l := range s.snapshot() {
+			ids = append(ids, l.sessionID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"high", "normal-1", "normal-2", "low"}, ids())
+
+	s.release("running")
+	expectStarted(t, started, "high")
+	assert.Equal(t, []string{"normal-1", "normal-2", "low"}, positions[len(positions)-1])
+
+	assert.True(t, s.remove("normal-2"))
+	assert.False(t, s.remove("normal-2"))
+	assert.Equal(t, []string{"normal-1", "low"}, positions[len(positions)-1])
+}
+
+func TestSchedulerRemoveDispatched(t *testing.T) {
+	s, started := recordingScheduler(1, 0)
+	assert.True(t, s.admit(newTestLaunch("running", "/repo", "")))
+	dispatched := newTestLaunch("dispatched", "/repo", "")
+	require.False(t, s.admit(dispatched))
+	admitQueued(t, s, newTestLaunch("next", "/repo", ""))
+
+	// dispatched leaves the queue but is not ready to start yet
+	s.release("running")
+	assert.Equal(t, 0, s.position("dispatched"))
+	assert.True(t, s.remove("dispatched"))
+	assert.False(t, s.remove("dispatched"))
+
+	// Once ready it gives its slot up instead of starting
+	close(dispatched.ready)
+	expectStarted(t, started, "next")
+}
+
+func TestSchedulerSetLimitsStartsQueued(t *testing.T) {
+	s, started := recordingScheduler(1, 0)
+	assert.True(t, s.admit(newTestLaunch("a", "/repo", "")))
+	admitQueued(t, s, newTestLaunch("b", "/repo", ""))
+
+	s.setLimits(2, 0)
+	expectStarted(t, started, "b")
+}
+
+func TestManagerQueuesOverLimit(t *testing.T) {
+	ctx := context.Background()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+
+	eventBus := bus.NewEventBus()
+	manager, err := NewManager(eventBus, sqliteStore, "")
+	require.NoError(t, err)
+	manager.scheduler.setLimits(1, 0)
+	require.True(t, manager.scheduler.admit(newTestLaunch("busy", "/repo", "")))
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sub := eventBus.Subscribe(subCtx, bus.EventFilter{Types: []bus.EventType{bus.EventSessionQueueUpdated}})
+
+	now := time.Now()
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID: "sess-1", RunID: "run-1", Status: store.SessionStatusStarting, Query: "q",
+		CreatedAt: now, LastActivityAt: now,
+	}))
+
+	queued, err := manager.schedule(ctx, newTestLaunch("sess-1", "/repo", PriorityHigh))
+	require.NoError(t, err)
+	assert.True(t, queued)
+
+	stored, err := sqliteStore.GetSession(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, store.SessionStatusQueued, stored.Status)
+
+	select {
+	case event := <-sub.Channel:
+		assert.Equal(t, "sess-1", event.Data["session_id"])
+		assert.Equal(t, 1, event.Data["position"])
+		assert.Equal(t, 1, event.Data["queue_length"])
+		assert.Equal(t, "high", event.Data["priority"])
+	case <-time.After(time.Second):
+		t.Fatal("expected a queue event")
+	}
+
+	// Interrupting a queued session takes it off the queue
+	require.NoError(t, manager.InterruptSession(ctx, "sess-1"))
+	assert.Empty(t, manager.scheduler.snapshot())
+	stored, err = sqliteStore.GetSession(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, store.SessionStatusFailed, stored.Status)
+	assert.Equal(t, "interrupted while queued", stored.ErrorMessage)
+}
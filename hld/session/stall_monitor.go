@@ -0,0 +1,278 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// StopReasonStalled is recorded on sessions the daemon stopped because they
+// produced no events for the stall timeout
+const StopReasonStalled = "stalled"
+
+// StallPolicy decides what happens to a session once it is flagged as stalled
+type StallPolicy string
+
+const (
+	StallPolicyNotify     StallPolicy = "notify"      // Only flag the session
+	StallPolicyInterrupt  StallPolicy = "interrupt"   // Interrupt the session, leaving it resumable
+	StallPolicyKillResume StallPolicy = "kill_resume" // Kill the process and resume in a new session
+)
+
+// Valid reports whether p is a known stall policy. Empty means StallPolicyNotify.
+func (p StallPolicy) Valid() bool {
+	switch p {
+	case "", StallPolicyNotify, StallPolicyInterrupt, StallPolicyKillResume:
+		return true
+	}
+	return false
+}
+
+// maxStallResumes bounds how many times in a row a session that keeps
+// stalling is killed and resumed
+const maxStallResumes = 3
+
+// stallResumeQuery is sent to the session resuming a killed stalled session
+const stallResumeQuery = "Your previous run stopped responding and was restarted. Continue where you left off."
+
+// StallMonitor periodically looks for running sessions that stopped producing
+// events, and for sessions that never finished interrupting
+type StallMonitor struct {
+	manager  *Manager
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewStallMonitor creates a monitor that flags running sessions as stalled
+// once they go timeout without an event
+func NewStallMonitor(manager *Manager, timeout, interval time.Duration) *StallMonitor {
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &StallMonitor{
+		manager:  manager,
+		timeout:  timeout,
+		interval: interval,
+	}
+}
+
+// Start begins monitoring for stalled sessions
+func (sm *StallMonitor) Start(ctx context.Context) {
+	slog.Info("starting stalled session monitor", "timeout", sm.timeout, "interval", sm.interval)
+
+	ticker := time.NewTicker(sm.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stalled session monitor shutting down")
+			return
+		case <-ticker.C:
+			sm.check(ctx)
+		}
+	}
+}
+
+func (sm *StallMonitor) check(ctx context.Context) {
+	// Guard against nil store (can happen during shutdown)
+	if sm.manager.store == nil {
+		return
+	}
+
+	now := time.Now()
+	running, err := sm.manager.store.GetIdleSessions(ctx, store.SessionStatusRunning, now.Add(-sm.timeout))
+	if err != nil {
+		slog.Error("failed to query idle running sessions", "error", err)
+		return
+	}
+	for _, session := range running {
+		if session.StalledAt == nil {
+			sm.flagStalled(ctx, session)
+		}
+	}
+
+	interrupting, err := sm.manager.store.GetIdleSessions(ctx, store.SessionStatusInterrupting, now.Add(-interruptWaitTimeout))
+	if err != nil {
+		slog.Error("failed to query idle interrupting sessions", "error", err)
+		return
+	}
+	for _, session := range interrupting {
+		sm.finishInterrupt(ctx, session)
+	}
+}
+
+// flagStalled marks a session as stalled and applies its stall policy
+func (sm *StallMonitor) flagStalled(ctx context.Context, session *store.Session) {
+	m := sm.manager
+	policy := StallPolicy(session.StallPolicy)
+	if policy == "" {
+		policy = StallPolicyNotify
+	}
+	idle := time.Since(session.LastActivityAt).Round(time.Second)
+
+	now := time.Now()
+	stalledAt := &now
+	if err := m.store.UpdateSession(ctx, session.ID, store.SessionUpdate{StalledAt: &stalledAt}); err != nil {
+		slog.Error("failed to flag session as stalled", "session_id", session.ID, "error", err)
+		return
+	}
+	// The next event the session produces clears the flag
+	m.stalled.Store(session.ID, struct{}{})
+
+	slog.Warn("session stalled",
+		"session_id", session.ID,
+		"idle", idle,
+		"policy", policy)
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStalled,
+			Data: map[string]interface{}{
+				"session_id":       session.ID,
+				"run_id":           session.RunID,
+				"last_activity_at": session.LastActivityAt,
+				"idle_seconds":     int(idle.Seconds()),
+				"policy":           string(policy),
+			},
+		})
+	}
+
+	if policy == StallPolicyNotify {
+		return
+	}
+
+	// Record the reason first so the stopped session reports it
+	reason := StopReasonStalled
+	message := fmt.Sprintf("stalled: no events for %s", idle)
+	if err := m.store.UpdateSession(ctx, session.ID, store.SessionUpdate{
+		StopReason:   &reason,
+		ErrorMessage: &message,
+	}); err != nil {
+		slog.Error("failed to record stall stop reason", "session_id", session.ID, "error", err)
+	}
+
+	switch policy {
+	case StallPolicyInterrupt:
+		if err := m.InterruptSession(ctx, session.ID); err != nil {
+			slog.Error("failed to interrupt stalled session", "session_id", session.ID, "error", err)
+		}
+	case StallPolicyKillResume:
+		// monitorSession resumes the session once the killed process exits
+		m.mu.RLock()
+		claudeSession, exists := m.activeProcesses[session.ID]
+		m.mu.RUnlock()
+		if !exists {
+			slog.Error("no process to kill for stalled session", "session_id", session.ID)
+			return
+		}
+		m.markInterrupting(ctx, session.ID)
+		if err := claudeSession.Kill(); err != nil {
+			slog.Error("failed to kill stalled session", "session_id", session.ID, "error", err)
+		}
+	}
+}
+
+// finishInterrupt settles a session that has been interrupting for longer
+// than an interrupt takes. A process that ignored the interrupt is killed;
+// without a process nothing is left to report the session's end, so it is
+// marked interrupted here.
+func (sm *StallMonitor) finishInterrupt(ctx context.Context, session *store.Session) {
+	m := sm.manager
+	m.mu.RLock()
+	claudeSession, exists := m.activeProcesses[session.ID]
+	m.mu.RUnlock()
+
+	if exists {
+		slog.Warn("killing session that ignored its interrupt", "session_id", session.ID)
+		if err := claudeSession.Kill(); err != nil {
+			slog.Debug("failed to kill interrupting session", "session_id", session.ID, "error", err)
+		}
+		return
+	}
+
+	slog.Warn("marking session interrupted after its process exited", "session_id", session.ID)
+	m.markInterrupted(ctx, session.ID, session.RunID)
+}
+
+// clearStall removes the stalled flag from a session that produced an event.
+// Not every event counts as activity, so the session's activity time moves
+// too, or the session would still look idle and be flagged again on the next
+// check.
+func (m *Manager) clearStall(ctx context.Context, sessionID, runID string) {
+	var stalledAt *time.Time
+	now := time.Now()
+	if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{
+		StalledAt:      &stalledAt,
+		LastActivityAt: &now,
+	}); err != nil {
+		slog.Error("failed to clear session stall", "session_id", sessionID, "error", err)
+		return
+	}
+	slog.Info("stalled session produced events again", "session_id", sessionID)
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionStallCleared,
+			Data: map[string]interface{}{
+				"session_id": sessionID,
+				"run_id":     runID,
+			},
+		})
+	}
+}
+
+// stallResumes counts the stalled sessions a session resumed in a row, up to
+// maxStallResumes
+func stallResumes(ctx context.Context, s store.ConversationStore, session *store.Session) int {
+	resumes := 0
+	for parentID := session.ParentSessionID; parentID != "" && resumes < maxStallResumes; resumes++ {
+		parent, err := s.GetSession(ctx, parentID)
+		if err != nil || parent == nil || parent.StopReason != StopReasonStalled {
+			break
+		}
+		parentID = parent.ParentSessionID
+	}
+	return resumes
+}
+
+// willResumeStalled reports whether a session's kill_resume stall policy
+// resumes it once it has been killed
+func willResumeStalled(ctx context.Context, s store.ConversationStore, session *store.Session) bool {
+	return session.StopReason == StopReasonStalled &&
+		StallPolicy(session.StallPolicy) == StallPolicyKillResume &&
+		stallResumes(ctx, s, session) < maxStallResumes
+}
+
+// resumeStalled continues a session its kill_resume stall policy killed,
+// resuming the conversation in a new session
+func (m *Manager) resumeStalled(ctx context.Context, session *store.Session) {
+	// A session that hangs every time isn't restarted forever
+	if !willResumeStalled(ctx, m.store, session) {
+		slog.Warn("not resuming session that stalled repeatedly",
+			"session_id", session.ID,
+			"resumes", maxStallResumes)
+		return
+	}
+
+	resumed, err := m.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: session.ID,
+		Query:           stallResumeQuery,
+	})
+	if err != nil {
+		slog.Error("failed to resume stalled session", "session_id", session.ID, "error", err)
+		// Nothing carries the session on, so it failed after all
+		m.updateSessionStatus(ctx, session.ID, StatusFailed, fmt.Sprintf("failed to resume stalled session: %v", err))
+		return
+	}
+	slog.Info("resumed stalled session",
+		"session_id", session.ID,
+		"resumed_session_id", resumed.ID)
+}
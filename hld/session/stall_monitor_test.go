@@ -0,0 +1,148 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStallMonitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+	eventBus := bus.NewEventBus()
+	events := eventBus.Subscribe(ctx, bus.EventFilter{
+		Types: []bus.EventType{bus.EventSessionStalled, bus.EventSessionStallCleared},
+	})
+	manager, err := NewManager(eventBus, sqliteStore, "")
+	require.NoError(t, err)
+	monitor := NewStallMonitor(manager, 10*time.Minute, time.Minute)
+
+	// newSession stores a session last active idle ago, with an active process
+	// unless it is in the interrupting state
+	newSession := func(t *testing.T, id, status string, policy StallPolicy, idle time.Duration) *MockClaudeSession {
+		require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+			ID:             id,
+			RunID:          "run_" + id,
+			Query:          "q",
+			Status:         status,
+			StallPolicy:    string(policy),
+			CreatedAt:      time.Now().Add(-idle),
+			LastActivityAt: time.Now().Add(-idle),
+		}))
+		process := NewMockClaudeSession(gomock.NewController(t))
+		if status == store.SessionStatusRunning {
+			manager.mu.Lock()
+			manager.activeProcesses[id] = process
+			manager.mu.Unlock()
+		}
+		return process
+	}
+	nextEvent := func(t *testing.T) bus.Event {
+		t.Helper()
+		select {
+		case event := <-events.Channel:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("no stall event")
+			return bus.Event{}
+		}
+	}
+
+	t.Run("notify", func(t *testing.T) {
+		newSession(t, "busy", store.SessionStatusRunning, "", time.Minute)
+		newSession(t, "quiet", store.SessionStatusRunning, "", 11*time.Minute)
+		monitor.check(ctx)
+
+		assert.Nil(t, mustGetSession(t, sqliteStore, "busy").StalledAt)
+		quiet := mustGetSession(t, sqliteStore, "quiet")
+		assert.NotNil(t, quiet.StalledAt)
+		assert.Equal(t, store.SessionStatusRunning, quiet.Status)
+		assert.Empty(t, quiet.StopReason)
+
+		event := nextEvent(t)
+		assert.Equal(t, bus.EventSessionStalled, event.Type)
+		assert.Equal(t, "quiet", event.Data["session_id"])
+		assert.Equal(t, "notify", event.Data["policy"])
+
+		// A session is flagged once
+		monitor.check(ctx)
+		assert.Empty(t, events.Channel)
+
+		// Its next event clears the flag
+		_, stalled := manager.stalled.LoadAndDelete("quiet")
+		require.True(t, stalled)
+		manager.clearStall(ctx, "quiet", "run_quiet")
+		assert.Nil(t, mustGetSession(t, sqliteStore, "quiet").StalledAt)
+		assert.Equal(t, bus.EventSessionStallCleared, nextEvent(t).Type)
+
+		// An event that isn't activity still restarts the timeout, so the
+		// session isn't flagged again right away
+		monitor.check(ctx)
+		assert.Empty(t, events.Channel)
+		assert.Nil(t, mustGetSession(t, sqliteStore, "quiet").StalledAt)
+	})
+
+	t.Run("interrupt", func(t *testing.T) {
+		process := newSession(t, "hung", store.SessionStatusRunning, StallPolicyInterrupt, time.Hour)
+		process.EXPECT().Interrupt().Return(nil).Times(1)
+		monitor.check(ctx)
+		nextEvent(t)
+
+		stored := mustGetSession(t, sqliteStore, "hung")
+		assert.Equal(t, store.SessionStatusInterrupting, stored.Status)
+		assert.Equal(t, StopReasonStalled, stored.StopReason)
+		assert.Contains(t, stored.ErrorMessage, "stalled: no events for")
+	})
+
+	t.Run("kill and resume", func(t *testing.T) {
+		process := newSession(t, "frozen", store.SessionStatusRunning, StallPolicyKillResume, time.Hour)
+		process.EXPECT().Kill().Return(nil).Times(1)
+		monitor.check(ctx)
+		nextEvent(t)
+
+		stored := mustGetSession(t, sqliteStore, "frozen")
+		assert.Equal(t, store.SessionStatusInterrupting, stored.Status)
+		assert.Equal(t, StopReasonStalled, stored.StopReason)
+	})
+
+	t.Run("interrupting after the process exited", func(t *testing.T) {
+		newSession(t, "orphan", store.SessionStatusInterrupting, "", time.Minute)
+		newSession(t, "stopping", store.SessionStatusInterrupting, "", time.Second)
+		monitor.check(ctx)
+
+		orphan := mustGetSession(t, sqliteStore, "orphan")
+		assert.Equal(t, store.SessionStatusInterrupted, orphan.Status)
+		assert.NotNil(t, orphan.CompletedAt)
+		assert.Equal(t, store.SessionStatusInterrupting, mustGetSession(t, sqliteStore, "stopping").Status)
+	})
+
+	t.Run("interrupting process that ignored the interrupt", func(t *testing.T) {
+		process := newSession(t, "stubborn", store.SessionStatusInterrupting, "", time.Minute)
+		manager.mu.Lock()
+		manager.activeProcesses["stubborn"] = process
+		manager.mu.Unlock()
+		process.EXPECT().Kill().Return(nil).Times(1)
+		monitor.check(ctx)
+
+		// The session's monitor marks it interrupted once the process exits
+		assert.Equal(t, store.SessionStatusInterrupting, mustGetSession(t, sqliteStore, "stubborn").Status)
+	})
+}
+
+func TestStallPolicyValid(t *testing.T) {
+	for _, policy := range []StallPolicy{"", StallPolicyNotify, StallPolicyInterrupt, StallPolicyKillResume} {
+		assert.True(t, policy.Valid(), policy)
+	}
+	assert.False(t, StallPolicy("restart").Valid())
+	assert.EqualError(t, LaunchSessionConfig{StallPolicy: "restart"}.Validate(),
+		`invalid stall_policy "restart": must be notify, interrupt or kill_resume`)
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// templateVariable matches {{name}} placeholders, allowing spaces inside the braces
+var templateVariable = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// MissingVariablesError reports template variables that were not given a value
+type MissingVariablesError struct {
+	Names []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("missing template variables: %s", strings.Join(e.Names, ", "))
+}
+
+// templatedFields returns the template fields that may contain variables
+func templatedFields(template *store.SessionTemplate) []string {
+	return []string{template.QueryTemplate, template.WorkingDir, template.SystemPrompt, template.AppendSystemPrompt}
+}
+
+// TemplateVariables returns the variables a template uses, in order of first use
+func TemplateVariables(template *store.SessionTemplate) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, text := range templatedFields(template) {
+		for _, match := range templateVariable.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
+
+// renderTemplate replaces the variables in text, collecting the names that
+// have no value in missing
+func renderTemplate(text string, vars map[string]string, missing map[string]bool) string {
+	return templateVariable.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templateVariable.FindStringSubmatch(placeholder)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing[name] = true
+			return placeholder
+		}
+		return value
+	})
+}
+
+// TemplateLaunchConfig builds the launch configuration of a session from a
+// template, filling in its variables. Every variable the template uses needs
+// a value, otherwise a *MissingVariablesError is returned.
+func TemplateLaunchConfig(template *store.SessionTemplate, vars map[string]string) (LaunchSessionConfig, error) {
+	missing := make(map[string]bool)
+	config := LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:              renderTemplate(template.QueryTemplate, vars, missing),
+			WorkingDir:         renderTemplate(template.WorkingDir, vars, missing),
+			SystemPrompt:       renderTemplate(template.SystemPrompt, vars, missing),
+			AppendSystemPrompt: renderTemplate(template.AppendSystemPrompt, vars, missing),
+			Model:              claudecode.Model(template.Model),
+			PermissionMode:     claudecode.PermissionMode(template.PermissionMode),
+			OutputFormat:       claudecode.OutputStreamJSON,
+		},
+		AutoAcceptEdits:            template.AutoAcceptEdits,
+		DangerouslySkipPermissions: template.DangerouslySkipPermissions,
+		ProxyEnabled:               template.ProxyEnabled,
+		ProxyBaseURL:               template.ProxyBaseURL,
+		ProxyModelOverride:         template.ProxyModelOverride,
+		ProxyAPIKey:                template.ProxyAPIKey,
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return config, &MissingVariablesError{Names: names}
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+		dest  interface{}
+	}{
+		{"allowed_tools", template.AllowedTools, &config.AllowedTools},
+		{"disallowed_tools", template.DisallowedTools, &config.DisallowedTools},
+		{"additional_directories", template.AdditionalDirectories, &config.AdditionalDirectories},
+		{"mcp_config", template.MCPConfig, &config.MCPConfig},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(field.value), field.dest); err != nil {
+			return config, fmt.Errorf("template has invalid %s: %w", field.name, err)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,49 @@
+package session
+
+import (
+	"errors"
+	"testing"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateLaunchConfig(t *testing.T) {
+	template := &store.SessionTemplate{
+		QueryTemplate:         "Fix {{ticket}} and open a PR against {{ branch }}",
+		WorkingDir:            "~/src/{{repo}}",
+		AppendSystemPrompt:    "Mention {{ticket}} in the PR title",
+		Model:                 "opus",
+		AllowedTools:          `["Read","Edit"]`,
+		AdditionalDirectories: `["~/shared"]`,
+		MCPConfig:             `{"mcpServers":{"linear":{"command":"linear-mcp"}}}`,
+		ProxyEnabled:          true,
+		ProxyBaseURL:          "http://localhost:9000",
+		AutoAcceptEdits:       true,
+	}
+	assert.Equal(t, []string{"ticket", "branch", "repo"}, TemplateVariables(template))
+
+	config, err := TemplateLaunchConfig(template, map[string]string{
+		"ticket": "ENG-1234", "branch": "main", "repo": "humanlayer", "unused": "x",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Fix ENG-1234 and open a PR against main", config.Query)
+	assert.Equal(t, "~/src/humanlayer", config.WorkingDir)
+	assert.Equal(t, "Mention ENG-1234 in the PR title", config.AppendSystemPrompt)
+	assert.Equal(t, claudecode.ModelOpus, config.Model)
+	assert.Equal(t, []string{"Read", "Edit"}, config.AllowedTools)
+	assert.Equal(t, []string{"~/shared"}, config.AdditionalDirectories)
+	require.NotNil(t, config.MCPConfig)
+	assert.Equal(t, "linear-mcp", config.MCPConfig.MCPServers["linear"].Command)
+	assert.True(t, config.ProxyEnabled)
+	assert.Equal(t, "http://localhost:9000", config.ProxyBaseURL)
+	assert.True(t, config.AutoAcceptEdits)
+
+	_, err = TemplateLaunchConfig(template, map[string]string{"ticket": "ENG-1234"})
+	var missing *MissingVariablesError
+	require.True(t, errors.As(err, &missing))
+	assert.Equal(t, []string{"branch", "repo"}, missing.Names)
+	assert.EqualError(t, err, "missing template variables: branch, repo")
+}
@@ -2,6 +2,9 @@ package session
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
 	"time"
 
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
@@ -17,7 +20,8 @@ type ApprovalReconciler interface {
 type Status string
 
 const (
-	StatusDraft        Status = "draft" // Session in configuration state
+	StatusDraft        Status = "draft"  // Session in configuration state
+	StatusQueued       Status = "queued" // Waiting for a slot under the concurrency limits
 	StatusStarting     Status = "starting"
 	StatusRunning      Status = "running"
 	StatusCompleted    Status = "completed"
@@ -51,6 +55,7 @@ type Info struct {
 	EndTime                             *time.Time         `json:"end_time,omitempty"`
 	LastActivityAt                      time.Time          `json:"last_activity_at"`
 	Error                               string             `json:"error,omitempty"`
+	ErrorKind                           string             `json:"error_kind,omitempty"` // Classified failure, see claudecode.ErrorKind
 	Query                               string             `json:"query"`
 	Summary                             string             `json:"summary"`
 	Title                               string             `json:"title"`
@@ -68,6 +73,20 @@ type Info struct {
 	ProxyModelOverride                  string             `json:"proxy_model_override,omitempty"`
 	ProxyAPIKey                         string             `json:"proxy_api_key,omitempty"`
 	FolderID                            *string            `json:"folder_id,omitempty"`
+	WorktreePath                        string             `json:"worktree_path,omitempty"`
+	WorktreeBranch                      string             `json:"worktree_branch,omitempty"`
+	WorktreeBaseRef                     string             `json:"worktree_base_ref,omitempty"`
+	ForkedFromSequence                  *int               `json:"forked_from_sequence,omitempty"`
+	MaxCostUSD                          *float64           `json:"max_cost_usd,omitempty"`
+	MaxOutputTokens                     *int               `json:"max_output_tokens,omitempty"`
+	MaxWallClockMs                      *int64             `json:"max_wall_clock_ms,omitempty"`
+	StopReason                          string             `json:"stop_reason,omitempty"`
+	StallPolicy                         string             `json:"stall_policy,omitempty"`
+	StalledAt                           *time.Time         `json:"stalled_at,omitempty"`
+	MaxRetries                          int                `json:"max_retries,omitempty"`
+	RetryCount                          int                `json:"retry_count,omitempty"`
+	RetryAt                             *time.Time         `json:"retry_at,omitempty"`
+	RecoveredAt                         *time.Time         `json:"recovered_at,omitempty"`
 }
 
 // LaunchSessionConfig contains the configuration for launching a new session
@@ -84,6 +103,43 @@ type LaunchSessionConfig struct {
 	ProxyBaseURL       string // Proxy base URL
 	ProxyModelOverride string // Model to use with proxy
 	ProxyAPIKey        string // API key for proxy service
+	// ForwardHooks adds hooks that send every hook payload to the daemon,
+	// which publishes them as hook_received events
+	ForwardHooks bool
+	// Priority orders the launch among queued sessions when the concurrency
+	// limits are reached
+	Priority Priority
+	// Worktree runs the session in a new git worktree of the working
+	// directory's repository
+	Worktree *WorktreeConfig
+	// FolderID files the session in a folder, whose budget defaults apply
+	// to limits the session leaves unset
+	FolderID string
+	// Budget interrupts the session when it crosses one of its limits
+	Budget Budget
+	// StallPolicy decides what happens once the session goes without events
+	// for the stall timeout
+	StallPolicy StallPolicy
+	// Retry resumes the session after transient failures. Nil uses the
+	// daemon's retry settings.
+	Retry *RetryPolicy
+}
+
+// Validate rejects option combinations the CLI would refuse, invalid budget
+// limits and retry settings, and unknown stall policies
+func (c LaunchSessionConfig) Validate() error {
+	if err := c.SessionConfig.Validate(); err != nil {
+		return err
+	}
+	if !c.StallPolicy.Valid() {
+		return fmt.Errorf("invalid stall_policy %q: must be notify, interrupt or kill_resume", c.StallPolicy)
+	}
+	if c.Retry != nil {
+		if err := c.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+	return c.Budget.Validate()
 }
 
 // ContinueSessionConfig contains the configuration for continuing a session
@@ -103,6 +159,21 @@ type ContinueSessionConfig struct {
 	ProxyBaseURL          string                // Proxy base URL
 	ProxyModelOverride    string                // Model to use with proxy
 	ProxyAPIKey           string                // API key for proxy service
+	// Set by ForkSession: the conversation to resume instead of the parent's,
+	// and the parent event sequence it was truncated at
+	ResumeClaudeSessionID string
+	ForkedFromSequence    *int
+	// Set by retries: which retry of the original session the new one is
+	RetryCount int
+}
+
+// ForkSessionConfig contains the configuration for forking a session from a
+// point in its conversation, given by event sequence or Claude message UUID
+type ForkSessionConfig struct {
+	ParentSessionID string // The session to fork
+	Sequence        int    // Sequence of the event to fork after, within the parent's own conversation
+	MessageUUID     string // Or the UUID of the Claude message to fork after
+	Query           string // The new query
 }
 
 // DirectoryNotFoundError indicates a directory doesn't exist and needs creation
@@ -123,6 +194,9 @@ type SessionManager interface {
 	// ContinueSession resumes an existing completed session with a new query and optional config overrides
 	ContinueSession(ctx context.Context, req ContinueSessionConfig) (*Session, error)
 
+	// ForkSession starts a child session from a point in a session's conversation
+	ForkSession(ctx context.Context, req ForkSessionConfig) (*Session, error)
+
 	// GetSessionInfo returns session info from the database by ID
 	GetSessionInfo(sessionID string) (*Info, error)
 
@@ -141,6 +215,15 @@ type SessionManager interface {
 	// UpdateSessionSettings updates session settings and publishes events
 	UpdateSessionSettings(ctx context.Context, sessionID string, updates store.SessionUpdate) error
 
+	// RecoverSession resumes a session cut off by a daemon restart in a new session
+	RecoverSession(ctx context.Context, sessionID string) (*Session, error)
+
+	// ResumePendingRetries arms the retries that were pending when the daemon went down
+	ResumePendingRetries(ctx context.Context) error
+
+	// HandleHookEvent publishes a hook payload forwarded by a session's hooks
+	HandleHookEvent(ctx context.Context, sessionID string, payload map[string]interface{}) error
+
 	// SetHTTPPort sets the HTTP port for the proxy endpoint
 	SetHTTPPort(port int)
 
@@ -158,6 +241,16 @@ type SessionManager interface {
 
 	// GetClaudeVersion returns the Claude binary version if available
 	GetClaudeVersion() (string, error)
+
+	// DetectClaudeVersion returns the parsed Claude binary version, which
+	// launches check their options against
+	DetectClaudeVersion() (claudecode.Version, error)
+
+	// MergeWorktree merges a session's worktree branch into its base branch
+	MergeWorktree(ctx context.Context, sessionID string) error
+
+	// RemoveWorktree removes a session's worktree, and its branch when deleteBranch is set
+	RemoveWorktree(ctx context.Context, sessionID string, deleteBranch bool) error
 }
 
 // ReadToolResult represents the JSON structure of a Read tool result
@@ -183,6 +276,7 @@ func SessionToInfo(s store.Session) Info {
 		StartTime:                           s.CreatedAt,
 		LastActivityAt:                      s.LastActivityAt,
 		Error:                               s.ErrorMessage,
+		ErrorKind:                           s.ErrorKind,
 		Query:                               s.Query,
 		Summary:                             s.Summary,
 		Title:                               s.Title,
@@ -198,6 +292,20 @@ func SessionToInfo(s store.Session) Info {
 		ProxyModelOverride:                  s.ProxyModelOverride,
 		ProxyAPIKey:                         s.ProxyAPIKey,
 		FolderID:                            s.FolderID,
+		WorktreePath:                        s.WorktreePath,
+		WorktreeBranch:                      s.WorktreeBranch,
+		WorktreeBaseRef:                     s.WorktreeBaseRef,
+		ForkedFromSequence:                  s.ForkedFromSequence,
+		MaxCostUSD:                          s.MaxCostUSD,
+		MaxOutputTokens:                     s.MaxOutputTokens,
+		MaxWallClockMs:                      s.MaxWallClockMs,
+		StopReason:                          s.StopReason,
+		StallPolicy:                         s.StallPolicy,
+		StalledAt:                           s.StalledAt,
+		MaxRetries:                          s.MaxRetries,
+		RetryCount:                          s.RetryCount,
+		RetryAt:                             s.RetryAt,
+		RecoveredAt:                         s.RecoveredAt,
 		// Note: CLICommand is not stored in database, it's a build-time constant
 	}
 
@@ -233,3 +341,40 @@ func SessionToInfo(s store.Session) Info {
 
 	return info
 }
+
+// applyStoredCLIOptions copies the extended CLI options persisted on a session
+// into config, so continued and draft sessions launch the way they were created
+func applyStoredCLIOptions(config *claudecode.SessionConfig, s *store.Session) {
+	config.PermissionMode = claudecode.PermissionMode(s.PermissionMode)
+	config.FallbackModel = claudecode.Model(s.FallbackModel)
+	config.Settings = s.Settings
+	config.StrictMCPConfig = s.StrictMCPConfig
+	config.IncludePartialMessages = s.IncludePartialMessages
+	if s.JSONSchema != "" {
+		config.JSONSchema = json.RawMessage(s.JSONSchema)
+	}
+	if s.SettingSources != "" {
+		var sources []claudecode.SettingSource
+		if err := json.Unmarshal([]byte(s.SettingSources), &sources); err == nil {
+			config.SettingSources = sources
+		} else {
+			slog.Error("failed to unmarshal setting sources", "session_id", s.ID, "error", err)
+		}
+	}
+	if s.Agents != "" {
+		var agents map[string]claudecode.AgentDefinition
+		if err := json.Unmarshal([]byte(s.Agents), &agents); err == nil {
+			config.Agents = agents
+		} else {
+			slog.Error("failed to unmarshal agents", "session_id", s.ID, "error", err)
+		}
+	}
+	if s.Hooks != "" {
+		var hooks claudecode.Hooks
+		if err := json.Unmarshal([]byte(s.Hooks), &hooks); err == nil {
+			config.Hooks = hooks
+		} else {
+			slog.Error("failed to unmarshal hooks", "session_id", s.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"context"
+	"log/slog"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// recordTurnUsage stores the token usage and cost of an assistant message.
+// The CLI reports a message once per content block, so later reports of the
+// same message replace the earlier ones.
+func (m *Manager) recordTurnUsage(ctx context.Context, sessionID, claudeSessionID string, event claudecode.StreamEvent) {
+	msg := event.Message
+	if msg.ID == "" {
+		return
+	}
+
+	usage := &store.TurnUsage{
+		SessionID:                sessionID,
+		ClaudeSessionID:          claudeSessionID,
+		MessageID:                msg.ID,
+		ParentToolUseID:          event.ParentToolUseID,
+		Model:                    m.pricingModel(ctx, sessionID, msg.Model),
+		InputTokens:              msg.Usage.InputTokens,
+		OutputTokens:             msg.Usage.OutputTokens,
+		CacheCreationInputTokens: msg.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     msg.Usage.CacheReadInputTokens,
+	}
+	if m.pricing != nil {
+		if cost, ok := m.pricing.Cost(usage.Model, msg.Usage); ok {
+			usage.CostUSD = &cost
+		} else {
+			slog.Debug("no price for model", "session_id", sessionID, "model", usage.Model)
+		}
+	}
+
+	if err := m.store.UpsertTurnUsage(ctx, usage); err != nil {
+		slog.Error("failed to record turn usage",
+			"session_id", sessionID,
+			"message_id", msg.ID,
+			"error", err)
+	}
+	if tally, ok := m.usageTallies.Load(sessionID); ok {
+		tally.(*usageTally).record(usage)
+	}
+}
+
+// pricingModel returns the model a message is billed as. Proxied sessions
+// report the Claude model that was requested, while the proxy actually runs
+// the override model.
+func (m *Manager) pricingModel(ctx context.Context, sessionID, reported string) string {
+	session, err := m.store.GetSession(ctx, sessionID)
+	if err != nil || session == nil {
+		return reported
+	}
+	if session.ProxyEnabled && session.ProxyModelOverride != "" {
+		return session.ProxyModelOverride
+	}
+	return reported
+}
+
+// computedCost sums the cost of the session's recorded messages. It returns
+// nil when no message could be priced.
+func (m *Manager) computedCost(ctx context.Context, sessionID string) *float64 {
+	turns, err := m.store.GetTurnUsage(ctx, sessionID)
+	if err != nil {
+		slog.Error("failed to get turn usage", "session_id", sessionID, "error", err)
+		return nil
+	}
+
+	var total float64
+	var priced bool
+	for _, turn := range turns {
+		if turn.CostUSD != nil {
+			total += *turn.CostUSD
+			priced = true
+		}
+	}
+	if !priced {
+		return nil
+	}
+	return &total
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessStreamEventRecordsTurnUsage(t *testing.T) {
+	ctx := context.Background()
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+
+	manager, err := NewManager(nil, sqliteStore, "")
+	require.NoError(t, err)
+	manager.pricing.Set("openai/gpt-oss-120b", claudecode.ModelPrice{Input: 0.1, Output: 0.5})
+
+	process := func(sessionID string, lines ...string) {
+		for _, line := range lines {
+			var event claudecode.StreamEvent
+			require.NoError(t, json.Unmarshal([]byte(line), &event))
+			require.NoError(t, manager.processStreamEvent(ctx, sessionID, "c1", event))
+		}
+	}
+
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID: "direct", RunID: "run-direct", Status: store.SessionStatusRunning, Query: "q",
+	}))
+	// A monitored session with a budget keeps a running tally of its spend
+	tally := newUsageTally(Budget{MaxOutputTokens: 1000}, budgetSpend{})
+	manager.usageTallies.Store("direct", tally)
+	process("direct",
+		// The same message is reported once per content block
+		`{"type":"assistant","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet-4-20250514","content":[{"type":"text","text":"a"}],"usage":{"input_tokens":1000,"output_tokens":10}}}`,
+		`{"type":"assistant","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet-4-20250514","content":[{"type":"tool_use","id":"toolu_1","name":"Task"}],"usage":{"input_tokens":1000,"output_tokens":200}}}`,
+		`{"type":"assistant","parent_tool_use_id":"toolu_1","message":{"id":"msg_2","role":"assistant","model":"claude-3-5-haiku-20241022","content":[],"usage":{"input_tokens":500,"output_tokens":50,"cache_read_input_tokens":10000}}}`,
+		`{"type":"assistant","message":{"id":"msg_3","role":"assistant","model":"mystery-model","content":[],"usage":{"input_tokens":1,"output_tokens":1}}}`,
+		`{"type":"result","subtype":"success","total_cost_usd":0.5,"duration_ms":10}`,
+	)
+
+	turns, err := sqliteStore.GetTurnUsage(ctx, "direct")
+	require.NoError(t, err)
+	require.Len(t, turns, 3)
+	assert.Equal(t, 200, turns[0].OutputTokens)
+	require.NotNil(t, turns[0].CostUSD)
+	assert.InDelta(t, 0.006, *turns[0].CostUSD, 1e-9)
+	assert.Equal(t, "toolu_1", turns[1].ParentToolUseID)
+	require.NotNil(t, turns[1].CostUSD)
+	assert.InDelta(t, 0.0014, *turns[1].CostUSD, 1e-9)
+	assert.Nil(t, turns[2].CostUSD, "unknown models are recorded without a cost")
+	assert.Equal(t, 251, tally.spent().outputTokens)
+
+	session, err := sqliteStore.GetSession(ctx, "direct")
+	require.NoError(t, err)
+	require.NotNil(t, session.CostUSD)
+	assert.Equal(t, 0.5, *session.CostUSD, "the CLI's own cost is kept when reported")
+
+	// Proxied sessions are priced by the override model and the result
+	// falls back to the computed cost
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID: "proxied", RunID: "run-proxied", Status: store.SessionStatusRunning, Query: "q",
+		ProxyEnabled: true, ProxyModelOverride: "openai/gpt-oss-120b",
+	}))
+	process("proxied",
+		`{"type":"assistant","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet-4-20250514","content":[],"usage":{"input_tokens":1000000,"output_tokens":1000000}}}`,
+		`{"type":"result","subtype":"success","duration_ms":10}`,
+	)
+
+	turns, err = sqliteStore.GetTurnUsage(ctx, "proxied")
+	require.NoError(t, err)
+	require.Len(t, turns, 1)
+	assert.Equal(t, "openai/gpt-oss-120b", turns[0].Model)
+
+	session, err = sqliteStore.GetSession(ctx, "proxied")
+	require.NoError(t, err)
+	require.NotNil(t, session.CostUSD)
+	assert.InDelta(t, 0.6, *session.CostUSD, 1e-9)
+}
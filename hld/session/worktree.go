@@ -0,0 +1,272 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// WorktreeConfig asks for a session to run in its own git worktree, on a new
+// branch, so parallel sessions in one repository don't share a working tree
+type WorktreeConfig struct {
+	Branch  string // Branch to create, defaults to hld/<first 8 characters of the session ID>
+	BaseRef string // Ref the branch starts from, defaults to the repository's current branch
+}
+
+// WorktreeStateError reports a worktree request that the session or
+// repository doesn't allow, like a base ref that doesn't exist or a merge of
+// uncommitted work
+type WorktreeStateError struct {
+	Message string
+}
+
+func (e *WorktreeStateError) Error() string {
+	return e.Message
+}
+
+// worktreesDir is where worktrees are created, one directory per repository,
+// the same layout as hack/create_worktree.sh
+func worktreesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, "wt"), nil
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if err != nil {
+		if output == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], output)
+	}
+	return output, nil
+}
+
+// defaultWorktreeBranch names the branch of a session's worktree
+func defaultWorktreeBranch(sessionID string) string {
+	if len(sessionID) > 8 {
+		sessionID = sessionID[:8]
+	}
+	return "hld/" + sessionID
+}
+
+// resolveWorktreeConfig fills in the defaults of a worktree request for the
+// repository containing dir
+func resolveWorktreeConfig(ctx context.Context, dir, sessionID string, config WorktreeConfig) (WorktreeConfig, error) {
+	if config.Branch == "" {
+		config.Branch = defaultWorktreeBranch(sessionID)
+	}
+	if _, err := git(ctx, dir, "check-ref-format", "--branch", config.Branch); err != nil {
+		return config, &WorktreeStateError{Message: fmt.Sprintf("invalid worktree branch %q", config.Branch)}
+	}
+	if config.BaseRef == "" {
+		branch, err := git(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return config, &WorktreeStateError{Message: fmt.Sprintf("%s is not a git repository", dir)}
+		}
+		config.BaseRef = branch
+	}
+	return config, nil
+}
+
+// createWorktree adds a worktree for the repository containing dir, on a new
+// branch from the base ref. It returns the worktree's root and the directory
+// in it matching dir, so a session launched in a subdirectory of the
+// repository runs in the same subdirectory of the worktree.
+func createWorktree(ctx context.Context, dir string, config WorktreeConfig) (string, string, error) {
+	root, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", &WorktreeStateError{Message: fmt.Sprintf("%s is not a git repository", dir)}
+	}
+	if _, err := git(ctx, root, "rev-parse", "--verify", "--quiet", config.BaseRef+"^{commit}"); err != nil {
+		return "", "", &WorktreeStateError{Message: fmt.Sprintf("unknown worktree base ref %q", config.BaseRef)}
+	}
+
+	base, err := worktreesDir()
+	if err != nil {
+		return "", "", err
+	}
+	path := filepath.Join(base, filepath.Base(root), strings.ReplaceAll(config.Branch, "/", "-"))
+	if _, err := os.Stat(path); err == nil {
+		return "", "", &WorktreeStateError{Message: fmt.Sprintf("worktree path already exists: %s", path)}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+
+	if _, err := git(ctx, root, "worktree", "add", "-b", config.Branch, path, config.BaseRef); err != nil {
+		return "", "", fmt.Errorf("failed to create worktree: %w", err)
+	}
+	slog.Info("created worktree", "path", path, "branch", config.Branch, "base_ref", config.BaseRef)
+
+	workingDir := path
+	// Resolve symlinks so the relative path is computed between real paths
+	if realDir, err := filepath.EvalSymlinks(dir); err == nil {
+		if rel, err := filepath.Rel(root, realDir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			workingDir = filepath.Join(path, rel)
+		}
+	}
+	return path, workingDir, nil
+}
+
+// worktreeRepository returns the main checkout of the repository a worktree
+// belongs to
+func worktreeRepository(ctx context.Context, path string) (string, error) {
+	commonDir, err := git(ctx, path, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(commonDir), nil
+}
+
+// removeWorktree removes a worktree and, when deleteBranch is set, its branch
+func removeWorktree(ctx context.Context, path, branch string, deleteBranch bool) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		slog.Warn("worktree already removed", "path", path)
+		return nil
+	}
+	repo, err := worktreeRepository(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to find repository of worktree %s: %w", path, err)
+	}
+	if _, err := git(ctx, repo, "worktree", "remove", "--force", path); err != nil {
+		return fmt.Errorf("failed to remove worktree: %w", err)
+	}
+	if deleteBranch && branch != "" {
+		if _, err := git(ctx, repo, "branch", "-D", branch); err != nil {
+			return fmt.Errorf("failed to delete branch %s: %w", branch, err)
+		}
+	}
+	slog.Info("removed worktree", "path", path, "branch", branch, "branch_deleted", deleteBranch)
+	return nil
+}
+
+// discardWorktree removes a worktree, and the branch created with it, that
+// was made for a session that then failed to be stored. Nothing is done when
+// path is empty.
+func discardWorktree(ctx context.Context, path, branch string) {
+	if path == "" {
+		return
+	}
+	if err := removeWorktree(ctx, path, branch, true); err != nil {
+		slog.Error("failed to remove worktree of session that was not stored", "path", path, "error", err)
+	}
+}
+
+// mergeWorktree merges a worktree's branch into its base branch. The merge
+// happens in the repository's main checkout, which must have the base branch
+// checked out and no uncommitted changes; the worktree must have its work
+// committed. A conflicting merge is aborted.
+func mergeWorktree(ctx context.Context, path, branch, baseRef string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &WorktreeStateError{Message: fmt.Sprintf("worktree %s no longer exists", path)}
+	}
+	if status, err := git(ctx, path, "status", "--porcelain"); err != nil {
+		return err
+	} else if status != "" {
+		return &WorktreeStateError{Message: "worktree has uncommitted changes"}
+	}
+
+	repo, err := worktreeRepository(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to find repository of worktree %s: %w", path, err)
+	}
+	current, err := git(ctx, repo, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return err
+	}
+	if current != baseRef {
+		return &WorktreeStateError{Message: fmt.Sprintf(
+			"cannot merge into %s: %s has %s checked out", baseRef, repo, current)}
+	}
+	if status, err := git(ctx, repo, "status", "--porcelain", "--untracked-files=no"); err != nil {
+		return err
+	} else if status != "" {
+		return &WorktreeStateError{Message: fmt.Sprintf("%s has uncommitted changes", repo)}
+	}
+
+	if _, err := git(ctx, repo, "merge", "--no-edit", branch); err != nil {
+		if _, abortErr := git(ctx, repo, "merge", "--abort"); abortErr != nil {
+			slog.Warn("failed to abort merge", "repo", repo, "error", abortErr)
+		}
+		return &WorktreeStateError{Message: fmt.Sprintf("merging %s into %s failed: %v", branch, baseRef, err)}
+	}
+	slog.Info("merged worktree branch", "branch", branch, "base_ref", baseRef, "repo", repo)
+	return nil
+}
+
+// sessionWorktree returns a session whose worktree can be merged or removed,
+// along with every session sharing that worktree. Sessions continued from one
+// another share their worktree, so it is kept while any of them is active.
+func (m *Manager) sessionWorktree(ctx context.Context, sessionID string) (*store.Session, []*store.Session, error) {
+	sess, err := m.store.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if sess.WorktreePath == "" {
+		return nil, nil, &WorktreeStateError{Message: "session has no worktree"}
+	}
+
+	sessions, err := m.store.ListSessions(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	var sharing []*store.Session
+	for _, s := range sessions {
+		if s.WorktreePath != sess.WorktreePath {
+			continue
+		}
+		switch s.Status {
+		case store.SessionStatusQueued, store.SessionStatusStarting, store.SessionStatusRunning,
+			store.SessionStatusWaitingInput, store.SessionStatusInterrupting:
+			if s.ID == sessionID {
+				return nil, nil, &WorktreeStateError{Message: fmt.Sprintf("session is still %s", s.Status)}
+			}
+			return nil, nil, &WorktreeStateError{Message: fmt.Sprintf(
+				"worktree is in use by session %s, which is still %s", s.ID, s.Status)}
+		}
+		sharing = append(sharing, s)
+	}
+	return sess, sharing, nil
+}
+
+// MergeWorktree merges a session's worktree branch into its base branch
+func (m *Manager) MergeWorktree(ctx context.Context, sessionID string) error {
+	sess, _, err := m.sessionWorktree(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	return mergeWorktree(ctx, sess.WorktreePath, sess.WorktreeBranch, sess.WorktreeBaseRef)
+}
+
+// RemoveWorktree removes a session's worktree, and its branch when
+// deleteBranch is set. The sessions that shared the worktree keep its branch
+// name but no longer have a worktree path.
+func (m *Manager) RemoveWorktree(ctx context.Context, sessionID string, deleteBranch bool) error {
+	sess, sharing, err := m.sessionWorktree(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := removeWorktree(ctx, sess.WorktreePath, sess.WorktreeBranch, deleteBranch); err != nil {
+		return err
+	}
+	empty := ""
+	for _, s := range sharing {
+		if err := m.store.UpdateSession(ctx, s.ID, store.SessionUpdate{WorktreePath: &empty}); err != nil {
+			return fmt.Errorf("failed to clear worktree of session %s: %w", s.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo creates a git repository with one commit on main and points
+// HOME at a temporary directory, so worktrees are created under it
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repo := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "pkg"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "pkg", "main.go"), []byte("package main\n"), 0644))
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "initial")
+	return repo
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := git(context.Background(), dir, args...)
+	require.NoError(t, err)
+	return out
+}
+
+func TestWorktreeCreateMergeRemove(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	config, err := resolveWorktreeConfig(ctx, filepath.Join(repo, "pkg"), "0123456789abcdef", WorktreeConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, WorktreeConfig{Branch: "hld/01234567", BaseRef: "main"}, config)
+
+	path, workingDir, err := createWorktree(ctx, filepath.Join(repo, "pkg"), config)
+	require.NoError(t, err)
+	home, _ := os.UserHomeDir()
+	assert.Equal(t, filepath.Join(home, "wt", "app", "hld-01234567"), path)
+	assert.Equal(t, filepath.Join(path, "pkg"), workingDir, "the session runs in the same subdirectory")
+	assert.Equal(t, "hld/01234567", runGit(t, path, "rev-parse", "--abbrev-ref", "HEAD"))
+
+	_, _, err = createWorktree(ctx, repo, config)
+	var stateErr *WorktreeStateError
+	require.True(t, errors.As(err, &stateErr), "creating the same worktree twice fails: %v", err)
+
+	// Uncommitted work is not merged
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "fix.go"), []byte("package main\n"), 0644))
+	err = mergeWorktree(ctx, path, config.Branch, config.BaseRef)
+	require.True(t, errors.As(err, &stateErr))
+	assert.Equal(t, "worktree has uncommitted changes", err.Error())
+
+	runGit(t, path, "add", ".")
+	runGit(t, path, "commit", "-q", "-m", "fix")
+
+	// The main checkout must have the base branch checked out
+	runGit(t, repo, "checkout", "-q", "-b", "other")
+	err = mergeWorktree(ctx, path, config.Branch, config.BaseRef)
+	require.True(t, errors.As(err, &stateErr))
+	assert.Contains(t, err.Error(), "has other checked out")
+	runGit(t, repo, "checkout", "-q", "main")
+
+	require.NoError(t, mergeWorktree(ctx, path, config.Branch, config.BaseRef))
+	assert.FileExists(t, filepath.Join(repo, "pkg", "fix.go"))
+
+	require.NoError(t, removeWorktree(ctx, path, config.Branch, true))
+	assert.NoDirExists(t, path)
+	assert.Empty(t, runGit(t, repo, "branch", "--list", config.Branch))
+	require.NoError(t, removeWorktree(ctx, path, config.Branch, true), "removing a missing worktree is a no-op")
+}
+
+func TestWorktreeInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	var stateErr *WorktreeStateError
+
+	_, err := resolveWorktreeConfig(ctx, repo, "sess", WorktreeConfig{Branch: "bad..name"})
+	require.True(t, errors.As(err, &stateErr))
+	assert.Contains(t, err.Error(), "invalid worktree branch")
+
+	_, err = resolveWorktreeConfig(ctx, t.TempDir(), "sess", WorktreeConfig{})
+	require.True(t, errors.As(err, &stateErr))
+	assert.Contains(t, err.Error(), "is not a git repository")
+
+	_, _, err = createWorktree(ctx, repo, WorktreeConfig{Branch: "feature", BaseRef: "nope"})
+	require.True(t, errors.As(err, &stateErr))
+	assert.Equal(t, `unknown worktree base ref "nope"`, err.Error())
+}
+
+func TestLaunchDraftSessionCreatesWorktree(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = sqliteStore.Close() }()
+	manager, err := NewManager(bus.NewEventBus(), sqliteStore, "")
+	require.NoError(t, err)
+
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID:             "draft-worktree",
+		RunID:          "run-draft-worktree",
+		Status:         store.SessionStatusDraft,
+		WorkingDir:     filepath.Join(repo, "pkg"),
+		WorktreeBranch: "eng-1234",
+	}))
+
+	// The launch fails without a Claude binary, after the worktree is created
+	_ = manager.LaunchDraftSession(ctx, "draft-worktree", "fix it", false)
+
+	sess, err := sqliteStore.GetSession(ctx, "draft-worktree")
+	require.NoError(t, err)
+	require.NotEmpty(t, sess.WorktreePath)
+	assert.Equal(t, filepath.Join(sess.WorktreePath, "pkg"), sess.WorkingDir)
+	assert.Equal(t, "main", sess.WorktreeBaseRef)
+	assert.DirExists(t, sess.WorktreePath)
+
+	status := store.SessionStatusRunning
+	require.NoError(t, sqliteStore.UpdateSession(ctx, "draft-worktree", store.SessionUpdate{Status: &status}))
+	var stateErr *WorktreeStateError
+	err = manager.RemoveWorktree(ctx, "draft-worktree", false)
+	require.True(t, errors.As(err, &stateErr), "a running session's worktree is kept")
+
+	// A session continued from it works in the same worktree
+	status = store.SessionStatusCompleted
+	require.NoError(t, sqliteStore.UpdateSession(ctx, "draft-worktree", store.SessionUpdate{Status: &status}))
+	require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+		ID:              "continued-worktree",
+		RunID:           "run-continued-worktree",
+		ParentSessionID: "draft-worktree",
+		Status:          store.SessionStatusRunning,
+		WorkingDir:      sess.WorkingDir,
+		WorktreePath:    sess.WorktreePath,
+		WorktreeBranch:  "eng-1234",
+	}))
+	err = manager.RemoveWorktree(ctx, "draft-worktree", false)
+	require.True(t, errors.As(err, &stateErr), "a worktree shared with a running session is kept")
+	assert.DirExists(t, sess.WorktreePath)
+
+	require.NoError(t, sqliteStore.UpdateSession(ctx, "continued-worktree", store.SessionUpdate{Status: &status}))
+	require.NoError(t, manager.RemoveWorktree(ctx, "draft-worktree", false))
+	assert.NoDirExists(t, sess.WorktreePath)
+	assert.Equal(t, "eng-1234", runGit(t, repo, "branch", "--list", "--format=%(refname:short)", "eng-1234"))
+
+	sess, err = sqliteStore.GetSession(ctx, "draft-worktree")
+	require.NoError(t, err)
+	assert.Empty(t, sess.WorktreePath)
+	assert.Equal(t, "eng-1234", sess.WorktreeBranch)
+	assert.Empty(t, mustGetSession(t, sqliteStore, "continued-worktree").WorktreePath)
+}
@@ -1181,114 +1181,712 @@ func (s *SQLiteStore) applyMigrations() error {
 		slog.Info("Migration 23 applied successfully")
 	}
 
-	return nil
-}
+	// Migration 24: Add error_kind to sessions for classified failures
+	if currentVersion < 24 {
+		slog.Info("Applying migration 24: Add error_kind to sessions")
 
-// validateSchema ensures the database schema is in the expected state
-func (s *SQLiteStore) validateSchema() error {
-	// Validate user_settings table exists
-	var userSettingsExists int
-	err := s.db.QueryRow(`
-		SELECT COUNT(*) FROM sqlite_master
-		WHERE type='table' AND name='user_settings'
-	`).Scan(&userSettingsExists)
-	if err != nil {
-		return fmt.Errorf("failed to validate user_settings table: %w", err)
+		var colExists int
+		err = s.db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = 'error_kind'
+		`).Scan(&colExists)
+		if err != nil {
+			return fmt.Errorf("migration 24 failed to check error_kind column: %w", err)
+		}
+
+		if colExists == 0 {
+			_, err = s.db.Exec(`ALTER TABLE sessions ADD COLUMN error_kind TEXT`)
+			if err != nil {
+				return fmt.Errorf("migration 24 failed to add error_kind column: %w", err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (24, 'Add error_kind to sessions for classified failures')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 24: %w", err)
+		}
+
+		slog.Info("Migration 24 applied successfully")
 	}
-	if userSettingsExists == 0 {
-		return fmt.Errorf("schema validation failed: user_settings table missing")
+
+	// Migration 25: Add include_partial_messages to sessions for live token streaming
+	if currentVersion < 25 {
+		slog.Info("Applying migration 25: Add include_partial_messages to sessions")
+
+		var colExists int
+		err = s.db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = 'include_partial_messages'
+		`).Scan(&colExists)
+		if err != nil {
+			return fmt.Errorf("migration 25 failed to check include_partial_messages column: %w", err)
+		}
+
+		if colExists == 0 {
+			_, err = s.db.Exec(`ALTER TABLE sessions ADD COLUMN include_partial_messages BOOLEAN DEFAULT 0`)
+			if err != nil {
+				return fmt.Errorf("migration 25 failed to add include_partial_messages column: %w", err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (25, 'Add include_partial_messages to sessions for live token streaming')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 25: %w", err)
+		}
+
+		slog.Info("Migration 25 applied successfully")
 	}
 
-	// Validate additional_directories column exists
-	var additionalDirsExists int
-	err = s.db.QueryRow(`
-		SELECT COUNT(*) FROM pragma_table_info('sessions')
-		WHERE name = 'additional_directories'
-	`).Scan(&additionalDirsExists)
-	if err != nil {
-		return fmt.Errorf("failed to validate additional_directories column: %w", err)
+	// Migration 26: Add extended Claude CLI options to sessions
+	if currentVersion < 26 {
+		slog.Info("Applying migration 26: Add extended CLI options to sessions")
+
+		columns := []struct {
+			name       string
+			definition string
+		}{
+			{"permission_mode", "TEXT"},
+			{"fallback_model", "TEXT"},
+			{"settings", "TEXT"},
+			{"setting_sources", "TEXT"},
+			{"agents", "TEXT"},
+			{"strict_mcp_config", "BOOLEAN DEFAULT 0"},
+			{"json_schema", "TEXT"},
+		}
+		for _, col := range columns {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = ?
+			`, col.name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 26 failed to check %s column: %w", col.name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s %s`, col.name, col.definition))
+			if err != nil {
+				return fmt.Errorf("migration 26 failed to add %s column: %w", col.name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (26, 'Add extended Claude CLI options to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 26: %w", err)
+		}
+
+		slog.Info("Migration 26 applied successfully")
 	}
-	if additionalDirsExists == 0 {
-		return fmt.Errorf("schema validation failed: additional_directories column missing")
+
+	// Migration 27: Add turn_usage table for per-message token usage and cost
+	if currentVersion < 27 {
+		slog.Info("Applying migration 27: Add turn_usage table")
+
+		_, err = s.db.Exec(`
+			CREATE TABLE IF NOT EXISTS turn_usage (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				session_id TEXT NOT NULL,
+				claude_session_id TEXT,
+				message_id TEXT NOT NULL, -- Assistant message ID, one row per API call
+				parent_tool_use_id TEXT, -- Set for subagent messages
+				model TEXT,
+				input_tokens INTEGER NOT NULL DEFAULT 0,
+				output_tokens INTEGER NOT NULL DEFAULT 0,
+				cache_creation_input_tokens INTEGER NOT NULL DEFAULT 0,
+				cache_read_input_tokens INTEGER NOT NULL DEFAULT 0,
+				cost_usd REAL, -- NULL when the model has no price
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+				FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE,
+				UNIQUE (session_id, message_id)
+			);
+			CREATE INDEX IF NOT EXISTS idx_turn_usage_session
+				ON turn_usage(session_id, id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create turn_usage table: %w", err)
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (27, 'Add turn_usage table for per-message token usage and cost')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 27: %w", err)
+		}
+
+		slog.Info("Migration 27 applied successfully")
 	}
 
-	// Validate schema version is at least 19
-	var currentVersion int
-	err = s.db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&currentVersion)
-	if err != nil {
-		return fmt.Errorf("failed to get schema version: %w", err)
+	// Migration 28: Add hooks to sessions
+	if currentVersion < 28 {
+		slog.Info("Applying migration 28: Add hooks to sessions")
+
+		columns := []struct {
+			name       string
+			definition string
+		}{
+			{"hooks", "TEXT"},
+			{"forward_hooks", "BOOLEAN DEFAULT 0"},
+		}
+		for _, col := range columns {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = ?
+			`, col.name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 28 failed to check %s column: %w", col.name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s %s`, col.name, col.definition))
+			if err != nil {
+				return fmt.Errorf("migration 28 failed to add %s column: %w", col.name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (28, 'Add hooks to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 28: %w", err)
+		}
+
+		slog.Info("Migration 28 applied successfully")
 	}
-	if currentVersion < 19 {
-		return fmt.Errorf("schema validation failed: version %d is less than required 19", currentVersion)
+
+	// Migration 29: Add priority to sessions for the launch queue
+	if currentVersion < 29 {
+		slog.Info("Applying migration 29: Add priority to sessions")
+
+		var colExists int
+		err = s.db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = 'priority'
+		`).Scan(&colExists)
+		if err != nil {
+			return fmt.Errorf("migration 29 failed to check priority column: %w", err)
+		}
+		if colExists == 0 {
+			_, err = s.db.Exec(`ALTER TABLE sessions ADD COLUMN priority TEXT`)
+			if err != nil {
+				return fmt.Errorf("migration 29 failed to add priority column: %w", err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (29, 'Add priority to sessions for the launch queue')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 29: %w", err)
+		}
+
+		slog.Info("Migration 29 applied successfully")
 	}
 
-	slog.Info("Schema validation successful",
-		"version", currentVersion,
-		"user_settings_table", "present",
-		"additional_directories_column", "present")
+	// Migration 30: Add schedules and schedule_runs tables
+	if currentVersion < 30 {
+		slog.Info("Applying migration 30: Add schedules tables")
 
-	return nil
-}
+		_, err = s.db.Exec(`
+			CREATE TABLE IF NOT EXISTS schedules (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				cron_expr TEXT, -- NULL for one-shot schedules
+				run_at TIMESTAMP, -- Set for one-shot schedules
+				catch_up TEXT NOT NULL DEFAULT 'skip',
+				enabled BOOLEAN NOT NULL DEFAULT 1,
+				launch_config TEXT NOT NULL, -- JSON launch settings
+				next_run_at TIMESTAMP,
+				last_run_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_schedules_next_run
+				ON schedules(enabled, next_run_at);
 
-// Close closes the database connection
-func (s *SQLiteStore) Close() error {
-	return s.db.Close()
-}
+			CREATE TABLE IF NOT EXISTS schedule_runs (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				schedule_id TEXT NOT NULL,
+				session_id TEXT, -- NULL unless a session was launched
+				scheduled_for TIMESTAMP NOT NULL,
+				triggered_at TIMESTAMP NOT NULL,
+				status TEXT NOT NULL, -- launched, failed or missed
+				error TEXT,
+
+				FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+			);
+			CREATE INDEX IF NOT EXISTS idx_schedule_runs_schedule
+				ON schedule_runs(schedule_id, id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create schedules tables: %w", err)
+		}
 
-// CreateSession creates a new session
-func (s *SQLiteStore) CreateSession(ctx context.Context, session *Session) error {
-	query := `
-		INSERT INTO sessions (
-			id, run_id, claude_session_id, parent_session_id,
-			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools,
-			status, created_at, last_activity_at, auto_accept_edits, archived, dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
-			dangerously_skip_permissions_timeout_ms,
-			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (30, 'Add schedules and schedule_runs tables')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 30: %w", err)
+		}
 
-	_, err := s.db.ExecContext(ctx, query,
-		session.ID, session.RunID, session.ClaudeSessionID, session.ParentSessionID,
-		session.Query, session.Summary, session.Title, session.Model, session.ModelID, session.WorkingDir, session.MaxTurns,
-		session.SystemPrompt, session.AppendSystemPrompt, session.CustomInstructions,
-		session.PermissionPromptTool, session.AllowedTools, session.DisallowedTools,
-		session.Status, session.CreatedAt, session.LastActivityAt, session.AutoAcceptEdits, session.Archived,
-		session.DangerouslySkipPermissions, session.DangerouslySkipPermissionsExpiresAt,
-		session.DangerouslySkipPermissionsTimeoutMs,
-		session.ProxyEnabled, session.ProxyBaseURL, session.ProxyModelOverride, session.ProxyAPIKey,
-		session.AdditionalDirectories, session.EditorState, session.FolderID,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create session: %w", err)
+		slog.Info("Migration 30 applied successfully")
 	}
-	return nil
-}
 
-// UpdateSession updates session fields
-func (s *SQLiteStore) UpdateSession(ctx context.Context, sessionID string, updates SessionUpdate) error {
-	query := `UPDATE sessions SET`
-	args := []interface{}{}
-	setParts := []string{}
+	// Migration 31: Add session_templates table for launch presets
+	if currentVersion < 31 {
+		slog.Info("Applying migration 31: Add session_templates table")
 
-	if updates.LastActivityAt != nil {
-		setParts = append(setParts, "last_activity_at = ?")
-		args = append(args, *updates.LastActivityAt)
-	}
-	if updates.ClaudeSessionID != nil {
-		setParts = append(setParts, "claude_session_id = ?")
-		args = append(args, *updates.ClaudeSessionID)
+		_, err = s.db.Exec(`
+			CREATE TABLE IF NOT EXISTS session_templates (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				description TEXT,
+				query_template TEXT NOT NULL,
+				working_dir TEXT,
+				model TEXT,
+				permission_mode TEXT,
+				system_prompt TEXT,
+				append_system_prompt TEXT,
+				allowed_tools TEXT, -- JSON array
+				disallowed_tools TEXT, -- JSON array
+				additional_directories TEXT, -- JSON array
+				mcp_config TEXT, -- JSON MCP configuration
+				proxy_enabled BOOLEAN NOT NULL DEFAULT 0,
+				proxy_base_url TEXT,
+				proxy_model_override TEXT,
+				proxy_api_key TEXT,
+				auto_accept_edits BOOLEAN NOT NULL DEFAULT 0,
+				dangerously_skip_permissions BOOLEAN NOT NULL DEFAULT 0,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create session_templates table: %w", err)
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (31, 'Add session_templates table for launch presets')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 31: %w", err)
+		}
+
+		slog.Info("Migration 31 applied successfully")
 	}
-	if updates.Query != nil {
-		setParts = append(setParts, "query = ?")
-		args = append(args, *updates.Query)
+
+	// Migration 32: Add git worktree columns to sessions
+	if currentVersion < 32 {
+		slog.Info("Applying migration 32: Add worktree columns to sessions")
+
+		for _, name := range []string{"worktree_path", "worktree_branch", "worktree_base_ref"} {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = ?
+			`, name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 32 failed to check %s column: %w", name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s TEXT`, name))
+			if err != nil {
+				return fmt.Errorf("migration 32 failed to add %s column: %w", name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (32, 'Add git worktree columns to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 32: %w", err)
+		}
+
+		slog.Info("Migration 32 applied successfully")
 	}
-	if updates.Status != nil {
-		setParts = append(setParts, "status = ?")
-		args = append(args, *updates.Status)
+
+	// Migration 33: Add message UUIDs to conversation events and fork lineage to sessions
+	if currentVersion < 33 {
+		slog.Info("Applying migration 33: Add message_uuid and forked_from_sequence")
+
+		columns := []struct {
+			table      string
+			name       string
+			definition string
+		}{
+			{"conversation_events", "message_uuid", "TEXT DEFAULT ''"},
+			{"sessions", "forked_from_sequence", "INTEGER"},
+		}
+		for _, col := range columns {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?
+			`, col.table, col.name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 33 failed to check %s column: %w", col.name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, col.table, col.name, col.definition))
+			if err != nil {
+				return fmt.Errorf("migration 33 failed to add %s column: %w", col.name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (33, 'Add message_uuid to conversation events and forked_from_sequence to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 33: %w", err)
+		}
+
+		slog.Info("Migration 33 applied successfully")
 	}
-	if updates.CompletedAt != nil {
-		setParts = append(setParts, "completed_at = ?")
+
+	// Migration 34: Add pipelines, pipeline_runs and pipeline_run_steps tables
+	if currentVersion < 34 {
+		slog.Info("Applying migration 34: Add pipelines tables")
+
+		_, err = s.db.Exec(`
+			CREATE TABLE IF NOT EXISTS pipelines (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				description TEXT,
+				steps TEXT NOT NULL, -- JSON step definitions
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS pipeline_runs (
+				id TEXT PRIMARY KEY,
+				pipeline_id TEXT NOT NULL,
+				pipeline_name TEXT NOT NULL,
+				definition TEXT NOT NULL, -- JSON step definitions at the start of the run
+				working_dir TEXT,
+				variables TEXT, -- JSON object
+				status TEXT NOT NULL, -- running, completed or failed
+				error TEXT,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				completed_at TIMESTAMP,
+
+				FOREIGN KEY (pipeline_id) REFERENCES pipelines(id) ON DELETE CASCADE
+			);
+			CREATE INDEX IF NOT EXISTS idx_pipeline_runs_pipeline
+				ON pipeline_runs(pipeline_id, created_at);
+			CREATE INDEX IF NOT EXISTS idx_pipeline_runs_status
+				ON pipeline_runs(status);
+
+			CREATE TABLE IF NOT EXISTS pipeline_run_steps (
+				run_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				status TEXT NOT NULL, -- pending, running, completed or failed
+				session_id TEXT, -- Session of the latest attempt
+				attempts INTEGER NOT NULL DEFAULT 0,
+				error TEXT,
+				started_at TIMESTAMP,
+				completed_at TIMESTAMP,
+
+				PRIMARY KEY (run_id, name),
+				FOREIGN KEY (run_id) REFERENCES pipeline_runs(id) ON DELETE CASCADE
+			);
+			CREATE INDEX IF NOT EXISTS idx_pipeline_run_steps_session
+				ON pipeline_run_steps(session_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create pipelines tables: %w", err)
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (34, 'Add pipelines, pipeline_runs and pipeline_run_steps tables')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 34: %w", err)
+		}
+
+		slog.Info("Migration 34 applied successfully")
+	}
+
+	// Migration 35: Add budget limits to sessions and budget defaults to folders
+	if currentVersion < 35 {
+		slog.Info("Applying migration 35: Add session budget limits")
+
+		columns := []struct {
+			table      string
+			name       string
+			definition string
+		}{
+			{"sessions", "max_cost_usd", "REAL"},
+			{"sessions", "max_output_tokens", "INTEGER"},
+			{"sessions", "max_wall_clock_ms", "INTEGER"},
+			{"sessions", "stop_reason", "TEXT DEFAULT ''"},
+			{"folders", "default_max_cost_usd", "REAL"},
+			{"folders", "default_max_output_tokens", "INTEGER"},
+			{"folders", "default_max_wall_clock_ms", "INTEGER"},
+		}
+		for _, col := range columns {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?
+			`, col.table, col.name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 35 failed to check %s column: %w", col.name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, col.table, col.name, col.definition))
+			if err != nil {
+				return fmt.Errorf("migration 35 failed to add %s column: %w", col.name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (35, 'Add budget limits to sessions and budget defaults to folders')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 35: %w", err)
+		}
+
+		slog.Info("Migration 35 applied successfully")
+	}
+
+	// Migration 36: Add stall policy and stall flag to sessions
+	if currentVersion < 36 {
+		slog.Info("Applying migration 36: Add session stall tracking")
+
+		columns := []struct {
+			name       string
+			definition string
+		}{
+			{"stall_policy", "TEXT DEFAULT ''"},
+			{"stalled_at", "TIMESTAMP"},
+		}
+		for _, col := range columns {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = ?
+			`, col.name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 36 failed to check %s column: %w", col.name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s %s`, col.name, col.definition))
+			if err != nil {
+				return fmt.Errorf("migration 36 failed to add %s column: %w", col.name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (36, 'Add stall policy and stall flag to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 36: %w", err)
+		}
+
+		slog.Info("Migration 36 applied successfully")
+	}
+
+	// Migration 37: Add retry policy and retry tracking to sessions
+	if currentVersion < 37 {
+		slog.Info("Applying migration 37: Add session retries")
+
+		columns := []struct {
+			name       string
+			definition string
+		}{
+			{"max_retries", "INTEGER DEFAULT 0"},
+			{"retry_backoff_ms", "INTEGER DEFAULT 0"},
+			{"retry_count", "INTEGER DEFAULT 0"},
+			{"retry_at", "TIMESTAMP"},
+		}
+		for _, col := range columns {
+			var colExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = ?
+			`, col.name).Scan(&colExists)
+			if err != nil {
+				return fmt.Errorf("migration 37 failed to check %s column: %w", col.name, err)
+			}
+			if colExists > 0 {
+				continue
+			}
+			_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s %s`, col.name, col.definition))
+			if err != nil {
+				return fmt.Errorf("migration 37 failed to add %s column: %w", col.name, err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (37, 'Add retry policy and retry tracking to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 37: %w", err)
+		}
+
+		slog.Info("Migration 37 applied successfully")
+	}
+
+	// Migration 38: Add recovery tracking to sessions
+	if currentVersion < 38 {
+		slog.Info("Applying migration 38: Add session recovery")
+
+		var colExists int
+		err = s.db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info('sessions') WHERE name = 'recovered_at'
+		`).Scan(&colExists)
+		if err != nil {
+			return fmt.Errorf("migration 38 failed to check recovered_at column: %w", err)
+		}
+		if colExists == 0 {
+			_, err = s.db.Exec(`ALTER TABLE sessions ADD COLUMN recovered_at TIMESTAMP`)
+			if err != nil {
+				return fmt.Errorf("migration 38 failed to add recovered_at column: %w", err)
+			}
+		}
+
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (38, 'Add recovery tracking to sessions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 38: %w", err)
+		}
+
+		slog.Info("Migration 38 applied successfully")
+	}
+
+	return nil
+}
+
+// validateSchema ensures the database schema is in the expected state
+func (s *SQLiteStore) validateSchema() error {
+	// Validate user_settings table exists
+	var userSettingsExists int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type='table' AND name='user_settings'
+	`).Scan(&userSettingsExists)
+	if err != nil {
+		return fmt.Errorf("failed to validate user_settings table: %w", err)
+	}
+	if userSettingsExists == 0 {
+		return fmt.Errorf("schema validation failed: user_settings table missing")
+	}
+
+	// Validate additional_directories column exists
+	var additionalDirsExists int
+	err = s.db.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('sessions')
+		WHERE name = 'additional_directories'
+	`).Scan(&additionalDirsExists)
+	if err != nil {
+		return fmt.Errorf("failed to validate additional_directories column: %w", err)
+	}
+	if additionalDirsExists == 0 {
+		return fmt.Errorf("schema validation failed: additional_directories column missing")
+	}
+
+	// Validate schema version is at least 19
+	var currentVersion int
+	err = s.db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&currentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if currentVersion < 19 {
+		return fmt.Errorf("schema validation failed: version %d is less than required 19", currentVersion)
+	}
+
+	slog.Info("Schema validation successful",
+		"version", currentVersion,
+		"user_settings_table", "present",
+		"additional_directories_column", "present")
+
+	return nil
+}
+
+// Close closes the database connection
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// CreateSession creates a new session
+func (s *SQLiteStore) CreateSession(ctx context.Context, session *Session) error {
+	query := `
+		INSERT INTO sessions (
+			id, run_id, claude_session_id, parent_session_id,
+			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
+			permission_prompt_tool, allowed_tools, disallowed_tools,
+			status, created_at, last_activity_at, auto_accept_edits, archived, dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
+			dangerously_skip_permissions_timeout_ms,
+			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id, error_kind, include_partial_messages, permission_mode, fallback_model, settings, setting_sources, agents, strict_mcp_config, json_schema, hooks, forward_hooks, priority, worktree_path, worktree_branch, worktree_base_ref, forked_from_sequence, max_cost_usd, max_output_tokens, max_wall_clock_ms, stall_policy, max_retries, retry_backoff_ms, retry_count
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		session.ID, session.RunID, session.ClaudeSessionID, session.ParentSessionID,
+		session.Query, session.Summary, session.Title, session.Model, session.ModelID, session.WorkingDir, session.MaxTurns,
+		session.SystemPrompt, session.AppendSystemPrompt, session.CustomInstructions,
+		session.PermissionPromptTool, session.AllowedTools, session.DisallowedTools,
+		session.Status, session.CreatedAt, session.LastActivityAt, session.AutoAcceptEdits, session.Archived,
+		session.DangerouslySkipPermissions, session.DangerouslySkipPermissionsExpiresAt,
+		session.DangerouslySkipPermissionsTimeoutMs,
+		session.ProxyEnabled, session.ProxyBaseURL, session.ProxyModelOverride, session.ProxyAPIKey,
+		session.AdditionalDirectories, session.EditorState, session.FolderID, session.ErrorKind, session.IncludePartialMessages, session.PermissionMode, session.FallbackModel, session.Settings, session.SettingSources, session.Agents, session.StrictMCPConfig, session.JSONSchema, session.Hooks, session.ForwardHooks, session.Priority, session.WorktreePath, session.WorktreeBranch, session.WorktreeBaseRef, session.ForkedFromSequence, session.MaxCostUSD, session.MaxOutputTokens, session.MaxWallClockMs, session.StallPolicy, session.MaxRetries, session.RetryBackoffMs, session.RetryCount,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// UpdateSession updates session fields
+func (s *SQLiteStore) UpdateSession(ctx context.Context, sessionID string, updates SessionUpdate) error {
+	query := `UPDATE sessions SET`
+	args := []interface{}{}
+	setParts := []string{}
+
+	if updates.LastActivityAt != nil {
+		setParts = append(setParts, "last_activity_at = ?")
+		args = append(args, *updates.LastActivityAt)
+	}
+	if updates.ClaudeSessionID != nil {
+		setParts = append(setParts, "claude_session_id = ?")
+		args = append(args, *updates.ClaudeSessionID)
+	}
+	if updates.Query != nil {
+		setParts = append(setParts, "query = ?")
+		args = append(args, *updates.Query)
+	}
+	if updates.Status != nil {
+		setParts = append(setParts, "status = ?")
+		args = append(args, *updates.Status)
+	}
+	if updates.CompletedAt != nil {
+		setParts = append(setParts, "completed_at = ?")
 		args = append(args, *updates.CompletedAt)
 	}
 	if updates.CostUSD != nil {
@@ -1408,6 +2006,46 @@ func (s *SQLiteStore) UpdateSession(ctx context.Context, sessionID string, updat
 			args = append(args, nil)
 		}
 	}
+	if updates.ErrorKind != nil {
+		setParts = append(setParts, "error_kind = ?")
+		args = append(args, *updates.ErrorKind)
+	}
+	if updates.WorktreePath != nil {
+		setParts = append(setParts, "worktree_path = ?")
+		args = append(args, *updates.WorktreePath)
+	}
+	if updates.StopReason != nil {
+		setParts = append(setParts, "stop_reason = ?")
+		args = append(args, *updates.StopReason)
+	}
+	if updates.StalledAt != nil {
+		setParts = append(setParts, "stalled_at = ?")
+		if *updates.StalledAt != nil {
+			args = append(args, **updates.StalledAt)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if updates.RetryAt != nil {
+		setParts = append(setParts, "retry_at = ?")
+		if *updates.RetryAt != nil {
+			args = append(args, **updates.RetryAt)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if updates.RecoveredAt != nil {
+		setParts = append(setParts, "recovered_at = ?")
+		if *updates.RecoveredAt != nil {
+			args = append(args, **updates.RecoveredAt)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if updates.WorktreeBaseRef != nil {
+		setParts = append(setParts, "worktree_base_ref = ?")
+		args = append(args, *updates.WorktreeBaseRef)
+	}
 
 	if len(setParts) == 0 {
 		// No fields to update is OK - this is a no-op
@@ -1419,1965 +2057,3195 @@ func (s *SQLiteStore) UpdateSession(ctx context.Context, sessionID string, updat
 	query += " WHERE id = ?"
 	args = append(args, sessionID)
 
-	result, err := s.db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update session: %w", err)
-	}
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return nil
+}
+
+// HardDeleteSession permanently deletes a session from the database
+func (s *SQLiteStore) HardDeleteSession(ctx context.Context, sessionID string) error {
+	query := `DELETE FROM sessions WHERE id = ?`
+	result, err := s.db.ExecContext(ctx, query, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by ID
+func (s *SQLiteStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	query := `
+		SELECT id, run_id, claude_session_id, parent_session_id,
+			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
+			permission_prompt_tool, allowed_tools, disallowed_tools,
+			status, created_at, last_activity_at, completed_at,
+			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
+			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
+			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id, error_kind, include_partial_messages, permission_mode, fallback_model, settings, setting_sources, agents, strict_mcp_config, json_schema, hooks, forward_hooks, priority, worktree_path, worktree_branch, worktree_base_ref, forked_from_sequence, max_cost_usd, max_output_tokens, max_wall_clock_ms, stop_reason, stall_policy, stalled_at, max_retries, retry_backoff_ms, retry_count, retry_at, recovered_at
+		FROM sessions WHERE id = ?
+	`
+
+	var session Session
+	var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
+	var permissionPromptTool, allowedTools, disallowedTools sql.NullString
+	var completedAt sql.NullTime
+	var costUSD sql.NullFloat64
+	var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
+	var durationMS, numTurns sql.NullInt64
+	var resultContent, errorMessage sql.NullString
+	var archived sql.NullBool
+	var dangerouslySkipPermissionsExpiresAt sql.NullTime
+	var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
+	var proxyEnabled sql.NullBool
+	var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
+	var additionalDirectories sql.NullString
+	var editorState sql.NullString
+	var folderID sql.NullString
+	var errorKind sql.NullString
+	var includePartialMessages sql.NullBool
+	var permissionMode sql.NullString
+	var fallbackModel sql.NullString
+	var settings sql.NullString
+	var settingSources sql.NullString
+	var agents sql.NullString
+	var strictMCPConfig sql.NullBool
+	var jsonSchema sql.NullString
+	var hooks sql.NullString
+	var forwardHooks sql.NullBool
+	var priority sql.NullString
+	var worktreePath sql.NullString
+	var worktreeBranch sql.NullString
+	var worktreeBaseRef sql.NullString
+	var forkedFromSequence sql.NullInt64
+	var maxCostUSD sql.NullFloat64
+	var maxOutputTokens sql.NullInt64
+	var maxWallClockMs sql.NullInt64
+	var stopReason sql.NullString
+	var stallPolicy sql.NullString
+	var stalledAt sql.NullTime
+	var maxRetries sql.NullInt64
+	var retryBackoffMs sql.NullInt64
+	var retryCount sql.NullInt64
+	var retryAt sql.NullTime
+	var recoveredAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
+		&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
+		&systemPrompt, &appendSystemPrompt, &customInstructions,
+		&permissionPromptTool, &allowedTools, &disallowedTools,
+		&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
+		&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
+		&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+		&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
+		&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID, &errorKind, &includePartialMessages, &permissionMode, &fallbackModel, &settings, &settingSources, &agents, &strictMCPConfig, &jsonSchema, &hooks, &forwardHooks, &priority, &worktreePath, &worktreeBranch, &worktreeBaseRef, &forkedFromSequence, &maxCostUSD, &maxOutputTokens, &maxWallClockMs, &stopReason, &stallPolicy, &stalledAt, &maxRetries, &retryBackoffMs, &retryCount, &retryAt, &recoveredAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	// Handle nullable fields
+	session.ClaudeSessionID = claudeSessionID.String
+	session.ParentSessionID = parentSessionID.String
+	session.Summary = summary.String
+	session.Title = title.String
+	session.Model = model.String
+	session.ModelID = modelID.String
+	session.WorkingDir = workingDir.String
+	session.SystemPrompt = systemPrompt.String
+	session.AppendSystemPrompt = appendSystemPrompt.String
+	session.CustomInstructions = customInstructions.String
+	session.PermissionPromptTool = permissionPromptTool.String
+	session.AllowedTools = allowedTools.String
+	session.DisallowedTools = disallowedTools.String
+	session.ResultContent = resultContent.String
+	session.ErrorMessage = errorMessage.String
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+	if costUSD.Valid {
+		session.CostUSD = &costUSD.Float64
+	}
+	if inputTokens.Valid {
+		tokens := int(inputTokens.Int64)
+		session.InputTokens = &tokens
+	}
+	if outputTokens.Valid {
+		tokens := int(outputTokens.Int64)
+		session.OutputTokens = &tokens
+	}
+	if cacheCreationInputTokens.Valid {
+		tokens := int(cacheCreationInputTokens.Int64)
+		session.CacheCreationInputTokens = &tokens
+	}
+	if cacheReadInputTokens.Valid {
+		tokens := int(cacheReadInputTokens.Int64)
+		session.CacheReadInputTokens = &tokens
+	}
+	if effectiveContextTokens.Valid {
+		tokens := int(effectiveContextTokens.Int64)
+		session.EffectiveContextTokens = &tokens
+	}
+	if durationMS.Valid {
+		duration := int(durationMS.Int64)
+		session.DurationMS = &duration
+	}
+	if numTurns.Valid {
+		turns := int(numTurns.Int64)
+		session.NumTurns = &turns
+	}
+
+	// Handle archived field - default to false if NULL
+	session.Archived = archived.Valid && archived.Bool
+
+	// Handle dangerously skip permissions expires at
+	if dangerouslySkipPermissionsExpiresAt.Valid {
+		session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+	}
+
+	// Handle dangerously skip permissions timeout ms
+	if dangerouslySkipPermissionsTimeoutMs.Valid {
+		session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
+	}
+
+	// Handle proxy fields
+	session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
+	session.ProxyBaseURL = proxyBaseURL.String
+	session.ProxyModelOverride = proxyModelOverride.String
+	session.ProxyAPIKey = proxyAPIKey.String
+
+	// Handle additional directories
+	session.AdditionalDirectories = additionalDirectories.String
+
+	// Handle editor state
+	if editorState.Valid {
+		session.EditorState = &editorState.String
+	}
+
+	// Handle folder ID
+	if folderID.Valid {
+		session.FolderID = &folderID.String
+	}
+
+	// Handle error kind
+	session.ErrorKind = errorKind.String
+
+	// Handle partial message streaming
+	session.IncludePartialMessages = includePartialMessages.Bool
+
+	// Handle extended CLI options
+	session.PermissionMode = permissionMode.String
+	session.FallbackModel = fallbackModel.String
+	session.Settings = settings.String
+	session.SettingSources = settingSources.String
+	session.Agents = agents.String
+	session.StrictMCPConfig = strictMCPConfig.Bool
+	session.JSONSchema = jsonSchema.String
+	session.Hooks = hooks.String
+	session.ForwardHooks = forwardHooks.Valid && forwardHooks.Bool
+	session.Priority = priority.String
+	session.WorktreePath = worktreePath.String
+	session.WorktreeBranch = worktreeBranch.String
+	session.WorktreeBaseRef = worktreeBaseRef.String
+	if forkedFromSequence.Valid {
+		seq := int(forkedFromSequence.Int64)
+		session.ForkedFromSequence = &seq
+	}
+	if maxCostUSD.Valid {
+		session.MaxCostUSD = &maxCostUSD.Float64
+	}
+	if maxOutputTokens.Valid {
+		tokens := int(maxOutputTokens.Int64)
+		session.MaxOutputTokens = &tokens
+	}
+	if maxWallClockMs.Valid {
+		session.MaxWallClockMs = &maxWallClockMs.Int64
+	}
+	session.StopReason = stopReason.String
+	session.StallPolicy = stallPolicy.String
+	if stalledAt.Valid {
+		session.StalledAt = &stalledAt.Time
+	}
+	session.MaxRetries = int(maxRetries.Int64)
+	session.RetryBackoffMs = retryBackoffMs.Int64
+	session.RetryCount = int(retryCount.Int64)
+	if retryAt.Valid {
+		session.RetryAt = &retryAt.Time
+	}
+	if recoveredAt.Valid {
+		session.RecoveredAt = &recoveredAt.Time
+	}
+
+	return &session, nil
+}
+
+// GetSessionByRunID retrieves a session by its run_id
+func (s *SQLiteStore) GetSessionByRunID(ctx context.Context, runID string) (*Session, error) {
+	query := `
+		SELECT id, run_id, claude_session_id, parent_session_id,
+			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
+			permission_prompt_tool, allowed_tools, disallowed_tools,
+			status, created_at, last_activity_at, completed_at,
+			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
+			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
+			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id, error_kind, include_partial_messages, permission_mode, fallback_model, settings, setting_sources, agents, strict_mcp_config, json_schema, hooks, forward_hooks, priority, worktree_path, worktree_branch, worktree_base_ref, forked_from_sequence, max_cost_usd, max_output_tokens, max_wall_clock_ms, stop_reason, stall_policy, stalled_at, max_retries, retry_backoff_ms, retry_count, retry_at, recovered_at
+		FROM sessions
+		WHERE run_id = ?
+	`
+
+	var session Session
+	var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
+	var permissionPromptTool, allowedTools, disallowedTools sql.NullString
+	var completedAt sql.NullTime
+	var costUSD sql.NullFloat64
+	var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
+	var durationMS, numTurns sql.NullInt64
+	var resultContent, errorMessage sql.NullString
+	var archived sql.NullBool
+	var dangerouslySkipPermissionsExpiresAt sql.NullTime
+	var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
+	var proxyEnabled sql.NullBool
+	var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
+	var additionalDirectories sql.NullString
+	var editorState sql.NullString
+	var folderID sql.NullString
+	var errorKind sql.NullString
+	var includePartialMessages sql.NullBool
+	var permissionMode sql.NullString
+	var fallbackModel sql.NullString
+	var settings sql.NullString
+	var settingSources sql.NullString
+	var agents sql.NullString
+	var strictMCPConfig sql.NullBool
+	var jsonSchema sql.NullString
+	var hooks sql.NullString
+	var forwardHooks sql.NullBool
+	var priority sql.NullString
+	var worktreePath sql.NullString
+	var worktreeBranch sql.NullString
+	var worktreeBaseRef sql.NullString
+	var forkedFromSequence sql.NullInt64
+	var maxCostUSD sql.NullFloat64
+	var maxOutputTokens sql.NullInt64
+	var maxWallClockMs sql.NullInt64
+	var stopReason sql.NullString
+	var stallPolicy sql.NullString
+	var stalledAt sql.NullTime
+	var maxRetries sql.NullInt64
+	var retryBackoffMs sql.NullInt64
+	var retryCount sql.NullInt64
+	var retryAt sql.NullTime
+	var recoveredAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, runID).Scan(
+		&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
+		&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
+		&systemPrompt, &appendSystemPrompt, &customInstructions,
+		&permissionPromptTool, &allowedTools, &disallowedTools,
+		&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
+		&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
+		&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+		&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
+		&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID, &errorKind, &includePartialMessages, &permissionMode, &fallbackModel, &settings, &settingSources, &agents, &strictMCPConfig, &jsonSchema, &hooks, &forwardHooks, &priority, &worktreePath, &worktreeBranch, &worktreeBaseRef, &forkedFromSequence, &maxCostUSD, &maxOutputTokens, &maxWallClockMs, &stopReason, &stallPolicy, &stalledAt, &maxRetries, &retryBackoffMs, &retryCount, &retryAt, &recoveredAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // No session found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session by run_id: %w", err)
+	}
+
+	// Convert nullable fields
+	session.ClaudeSessionID = claudeSessionID.String
+	session.ParentSessionID = parentSessionID.String
+	session.Summary = summary.String
+	session.Title = title.String
+	session.Model = model.String
+	session.ModelID = modelID.String
+	session.WorkingDir = workingDir.String
+	session.SystemPrompt = systemPrompt.String
+	session.AppendSystemPrompt = appendSystemPrompt.String
+	session.CustomInstructions = customInstructions.String
+	session.PermissionPromptTool = permissionPromptTool.String
+	session.AllowedTools = allowedTools.String
+	session.DisallowedTools = disallowedTools.String
+	session.ResultContent = resultContent.String
+	session.ErrorMessage = errorMessage.String
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+	if costUSD.Valid {
+		session.CostUSD = &costUSD.Float64
+	}
+	if inputTokens.Valid {
+		tokens := int(inputTokens.Int64)
+		session.InputTokens = &tokens
+	}
+	if outputTokens.Valid {
+		tokens := int(outputTokens.Int64)
+		session.OutputTokens = &tokens
+	}
+	if cacheCreationInputTokens.Valid {
+		tokens := int(cacheCreationInputTokens.Int64)
+		session.CacheCreationInputTokens = &tokens
+	}
+	if cacheReadInputTokens.Valid {
+		tokens := int(cacheReadInputTokens.Int64)
+		session.CacheReadInputTokens = &tokens
+	}
+	if effectiveContextTokens.Valid {
+		tokens := int(effectiveContextTokens.Int64)
+		session.EffectiveContextTokens = &tokens
+	}
+	if durationMS.Valid {
+		duration := int(durationMS.Int64)
+		session.DurationMS = &duration
+	}
+	if numTurns.Valid {
+		turns := int(numTurns.Int64)
+		session.NumTurns = &turns
+	}
+
+	// Handle archived field - default to false if NULL
+	session.Archived = archived.Valid && archived.Bool
+
+	// Handle dangerously skip permissions expires at
+	if dangerouslySkipPermissionsExpiresAt.Valid {
+		session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+	}
+
+	// Handle dangerously skip permissions timeout ms
+	if dangerouslySkipPermissionsTimeoutMs.Valid {
+		session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
+	}
+
+	// Handle proxy fields
+	session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
+	session.ProxyBaseURL = proxyBaseURL.String
+	session.ProxyModelOverride = proxyModelOverride.String
+	session.ProxyAPIKey = proxyAPIKey.String
+
+	// Handle additional directories
+	session.AdditionalDirectories = additionalDirectories.String
+
+	// Handle editor state
+	if editorState.Valid {
+		session.EditorState = &editorState.String
+	}
+
+	// Handle folder ID
+	if folderID.Valid {
+		session.FolderID = &folderID.String
+	}
+
+	// Handle error kind
+	session.ErrorKind = errorKind.String
+
+	// Handle partial message streaming
+	session.IncludePartialMessages = includePartialMessages.Bool
+
+	// Handle extended CLI options
+	session.PermissionMode = permissionMode.String
+	session.FallbackModel = fallbackModel.String
+	session.Settings = settings.String
+	session.SettingSources = settingSources.String
+	session.Agents = agents.String
+	session.StrictMCPConfig = strictMCPConfig.Bool
+	session.JSONSchema = jsonSchema.String
+	session.Hooks = hooks.String
+	session.ForwardHooks = forwardHooks.Valid && forwardHooks.Bool
+	session.Priority = priority.String
+	session.WorktreePath = worktreePath.String
+	session.WorktreeBranch = worktreeBranch.String
+	session.WorktreeBaseRef = worktreeBaseRef.String
+	if forkedFromSequence.Valid {
+		seq := int(forkedFromSequence.Int64)
+		session.ForkedFromSequence = &seq
+	}
+	if maxCostUSD.Valid {
+		session.MaxCostUSD = &maxCostUSD.Float64
+	}
+	if maxOutputTokens.Valid {
+		tokens := int(maxOutputTokens.Int64)
+		session.MaxOutputTokens = &tokens
+	}
+	if maxWallClockMs.Valid {
+		session.MaxWallClockMs = &maxWallClockMs.Int64
+	}
+	session.StopReason = stopReason.String
+	session.StallPolicy = stallPolicy.String
+	if stalledAt.Valid {
+		session.StalledAt = &stalledAt.Time
+	}
+	session.MaxRetries = int(maxRetries.Int64)
+	session.RetryBackoffMs = retryBackoffMs.Int64
+	session.RetryCount = int(retryCount.Int64)
+	if retryAt.Valid {
+		session.RetryAt = &retryAt.Time
+	}
+	if recoveredAt.Valid {
+		session.RecoveredAt = &recoveredAt.Time
+	}
+
+	return &session, nil
+}
+
+// ListSessions retrieves all sessions
+func (s *SQLiteStore) ListSessions(ctx context.Context) ([]*Session, error) {
+	query := `
+		SELECT id, run_id, claude_session_id, parent_session_id,
+			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
+			permission_prompt_tool, allowed_tools, disallowed_tools,
+			status, created_at, last_activity_at, completed_at,
+			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
+		duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
+			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id, error_kind, include_partial_messages, permission_mode, fallback_model, settings, setting_sources, agents, strict_mcp_config, json_schema, hooks, forward_hooks, priority, worktree_path, worktree_branch, worktree_base_ref, forked_from_sequence, max_cost_usd, max_output_tokens, max_wall_clock_ms, stop_reason, stall_policy, stalled_at, max_retries, retry_backoff_ms, retry_count, retry_at, recovered_at
+		FROM sessions
+		ORDER BY last_activity_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var sessions []*Session
+	for rows.Next() {
+		var session Session
+		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
+		var permissionPromptTool, allowedTools, disallowedTools sql.NullString
+		var completedAt sql.NullTime
+		var costUSD sql.NullFloat64
+		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
+		var durationMS, numTurns sql.NullInt64
+		var resultContent, errorMessage sql.NullString
+		var archived sql.NullBool
+		var dangerouslySkipPermissionsExpiresAt sql.NullTime
+		var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
+		var proxyEnabled sql.NullBool
+		var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
+		var additionalDirectories sql.NullString
+		var editorState sql.NullString
+		var folderID sql.NullString
+		var errorKind sql.NullString
+		var includePartialMessages sql.NullBool
+		var permissionMode sql.NullString
+		var fallbackModel sql.NullString
+		var settings sql.NullString
+		var settingSources sql.NullString
+		var agents sql.NullString
+		var strictMCPConfig sql.NullBool
+		var jsonSchema sql.NullString
+		var hooks sql.NullString
+		var forwardHooks sql.NullBool
+		var priority sql.NullString
+		var worktreePath sql.NullString
+		var worktreeBranch sql.NullString
+		var worktreeBaseRef sql.NullString
+		var forkedFromSequence sql.NullInt64
+		var maxCostUSD sql.NullFloat64
+		var maxOutputTokens sql.NullInt64
+		var maxWallClockMs sql.NullInt64
+		var stopReason sql.NullString
+		var stallPolicy sql.NullString
+		var stalledAt sql.NullTime
+		var maxRetries sql.NullInt64
+		var retryBackoffMs sql.NullInt64
+		var retryCount sql.NullInt64
+		var retryAt sql.NullTime
+		var recoveredAt sql.NullTime
+
+		err := rows.Scan(
+			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
+			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
+			&systemPrompt, &appendSystemPrompt, &customInstructions,
+			&permissionPromptTool, &allowedTools, &disallowedTools,
+			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
+			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
+			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
+			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID, &errorKind, &includePartialMessages, &permissionMode, &fallbackModel, &settings, &settingSources, &agents, &strictMCPConfig, &jsonSchema, &hooks, &forwardHooks, &priority, &worktreePath, &worktreeBranch, &worktreeBaseRef, &forkedFromSequence, &maxCostUSD, &maxOutputTokens, &maxWallClockMs, &stopReason, &stallPolicy, &stalledAt, &maxRetries, &retryBackoffMs, &retryCount, &retryAt, &recoveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		// Handle nullable fields
+		session.ClaudeSessionID = claudeSessionID.String
+		session.ParentSessionID = parentSessionID.String
+		session.Summary = summary.String
+		session.Title = title.String
+		session.Model = model.String
+		session.ModelID = modelID.String
+		session.WorkingDir = workingDir.String
+		session.SystemPrompt = systemPrompt.String
+		session.AppendSystemPrompt = appendSystemPrompt.String
+		session.CustomInstructions = customInstructions.String
+		session.PermissionPromptTool = permissionPromptTool.String
+		session.AllowedTools = allowedTools.String
+		session.DisallowedTools = disallowedTools.String
+		session.ResultContent = resultContent.String
+		session.ErrorMessage = errorMessage.String
+		if completedAt.Valid {
+			session.CompletedAt = &completedAt.Time
+		}
+		if costUSD.Valid {
+			session.CostUSD = &costUSD.Float64
+		}
+		if inputTokens.Valid {
+			tokens := int(inputTokens.Int64)
+			session.InputTokens = &tokens
+		}
+		if outputTokens.Valid {
+			tokens := int(outputTokens.Int64)
+			session.OutputTokens = &tokens
+		}
+		if cacheCreationInputTokens.Valid {
+			tokens := int(cacheCreationInputTokens.Int64)
+			session.CacheCreationInputTokens = &tokens
+		}
+		if cacheReadInputTokens.Valid {
+			tokens := int(cacheReadInputTokens.Int64)
+			session.CacheReadInputTokens = &tokens
+		}
+		if effectiveContextTokens.Valid {
+			tokens := int(effectiveContextTokens.Int64)
+			session.EffectiveContextTokens = &tokens
+		}
+		if durationMS.Valid {
+			duration := int(durationMS.Int64)
+			session.DurationMS = &duration
+		}
+		if numTurns.Valid {
+			turns := int(numTurns.Int64)
+			session.NumTurns = &turns
+		}
+		session.ResultContent = resultContent.String
+		session.ErrorMessage = errorMessage.String
+
+		// Handle archived field - default to false if NULL
+		session.Archived = archived.Valid && archived.Bool
+
+		// Handle dangerously skip permissions expires at
+		if dangerouslySkipPermissionsExpiresAt.Valid {
+			session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+		}
+
+		// Handle dangerously skip permissions timeout ms
+		if dangerouslySkipPermissionsTimeoutMs.Valid {
+			session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
+		}
+
+		// Handle proxy fields
+		session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
+		session.ProxyBaseURL = proxyBaseURL.String
+		session.ProxyModelOverride = proxyModelOverride.String
+		session.ProxyAPIKey = proxyAPIKey.String
+
+		// Handle additional directories
+		session.AdditionalDirectories = additionalDirectories.String
+
+		// Handle editor state
+		if editorState.Valid {
+			session.EditorState = &editorState.String
+		}
+
+		// Handle folder ID
+		if folderID.Valid {
+			session.FolderID = &folderID.String
+		}
+
+		// Handle error kind
+		session.ErrorKind = errorKind.String
+
+		// Handle partial message streaming
+		session.IncludePartialMessages = includePartialMessages.Bool
+
+		// Handle extended CLI options
+		session.PermissionMode = permissionMode.String
+		session.FallbackModel = fallbackModel.String
+		session.Settings = settings.String
+		session.SettingSources = settingSources.String
+		session.Agents = agents.String
+		session.StrictMCPConfig = strictMCPConfig.Bool
+		session.JSONSchema = jsonSchema.String
+		session.Hooks = hooks.String
+		session.ForwardHooks = forwardHooks.Valid && forwardHooks.Bool
+		session.Priority = priority.String
+		session.WorktreePath = worktreePath.String
+		session.WorktreeBranch = worktreeBranch.String
+		session.WorktreeBaseRef = worktreeBaseRef.String
+		if forkedFromSequence.Valid {
+			seq := int(forkedFromSequence.Int64)
+			session.ForkedFromSequence = &seq
+		}
+		if maxCostUSD.Valid {
+			session.MaxCostUSD = &maxCostUSD.Float64
+		}
+		if maxOutputTokens.Valid {
+			tokens := int(maxOutputTokens.Int64)
+			session.MaxOutputTokens = &tokens
+		}
+		if maxWallClockMs.Valid {
+			session.MaxWallClockMs = &maxWallClockMs.Int64
+		}
+		session.StopReason = stopReason.String
+		session.StallPolicy = stallPolicy.String
+		if stalledAt.Valid {
+			session.StalledAt = &stalledAt.Time
+		}
+		session.MaxRetries = int(maxRetries.Int64)
+		session.RetryBackoffMs = retryBackoffMs.Int64
+		session.RetryCount = int(retryCount.Int64)
+		if retryAt.Valid {
+			session.RetryAt = &retryAt.Time
+		}
+		if recoveredAt.Valid {
+			session.RecoveredAt = &recoveredAt.Time
+		}
+
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, nil
+}
+
+// SearchSessionsByTitle searches for sessions by title using SQL LIKE
+func (s *SQLiteStore) SearchSessionsByTitle(ctx context.Context, query string, limit int) ([]*Session, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	// Build the SQL query with LIKE pattern
+	// Use % wildcards for substring matching anywhere in title
+	// Filter to only leaf sessions (sessions with no children)
+	// Filter to only "normal" sessions (not archived, not draft, not discarded)
+	sqlQuery := `
+		SELECT id, run_id, claude_session_id, parent_session_id,
+			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
+			permission_prompt_tool, allowed_tools, disallowed_tools,
+			status, created_at, last_activity_at, completed_at,
+			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
+			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
+			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id, error_kind, include_partial_messages, permission_mode, fallback_model, settings, setting_sources, agents, strict_mcp_config, json_schema, hooks, forward_hooks, priority, worktree_path, worktree_branch, worktree_base_ref, forked_from_sequence, max_cost_usd, max_output_tokens, max_wall_clock_ms, stop_reason, stall_policy, stalled_at, max_retries, retry_backoff_ms, retry_count, retry_at, recovered_at
+		FROM sessions
+		WHERE 1=1
+		AND NOT EXISTS (
+			SELECT 1 FROM sessions children
+			WHERE children.parent_session_id = sessions.id
+		)
+		AND (archived IS NULL OR archived = 0)
+		AND status != 'draft'
+		AND status != 'discarded'`
+
+	var args []interface{}
+
+	// Search across title, summary, and query fields to match UI display logic
+	// UI shows: title || summary || query, so search should match this behavior
+	if query != "" {
+		sqlQuery += " AND (title LIKE ? OR summary LIKE ? OR query LIKE ?)"
+		args = append(args, "%"+query+"%", "%"+query+"%", "%"+query+"%")
+	}
+
+	// Order by last activity and limit
+	// Look at 20 most recent sessions for performance
+	sqlQuery += `
+		ORDER BY last_activity_at DESC
+		LIMIT 20`
+
+	// Then apply the user's limit in Go after fetching
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search sessions query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var sessions []*Session
+	for rows.Next() {
+		var session Session
+		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
+		var permissionPromptTool, allowedTools, disallowedTools sql.NullString
+		var completedAt sql.NullTime
+		var costUSD sql.NullFloat64
+		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
+		var durationMS, numTurns sql.NullInt64
+		var resultContent, errorMessage sql.NullString
+		var archived sql.NullBool
+		var dangerouslySkipPermissionsExpiresAt sql.NullTime
+		var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
+		var proxyEnabled sql.NullBool
+		var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
+		var additionalDirectories sql.NullString
+		var editorState sql.NullString
+		var folderID sql.NullString
+		var errorKind sql.NullString
+		var includePartialMessages sql.NullBool
+		var permissionMode sql.NullString
+		var fallbackModel sql.NullString
+		var settings sql.NullString
+		var settingSources sql.NullString
+		var agents sql.NullString
+		var strictMCPConfig sql.NullBool
+		var jsonSchema sql.NullString
+		var hooks sql.NullString
+		var forwardHooks sql.NullBool
+		var priority sql.NullString
+		var worktreePath sql.NullString
+		var worktreeBranch sql.NullString
+		var worktreeBaseRef sql.NullString
+		var forkedFromSequence sql.NullInt64
+		var maxCostUSD sql.NullFloat64
+		var maxOutputTokens sql.NullInt64
+		var maxWallClockMs sql.NullInt64
+		var stopReason sql.NullString
+		var stallPolicy sql.NullString
+		var stalledAt sql.NullTime
+		var maxRetries sql.NullInt64
+		var retryBackoffMs sql.NullInt64
+		var retryCount sql.NullInt64
+		var retryAt sql.NullTime
+		var recoveredAt sql.NullTime
+
+		err := rows.Scan(
+			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
+			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
+			&systemPrompt, &appendSystemPrompt, &customInstructions,
+			&permissionPromptTool, &allowedTools, &disallowedTools,
+			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
+			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
+			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
+			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID, &errorKind, &includePartialMessages, &permissionMode, &fallbackModel, &settings, &settingSources, &agents, &strictMCPConfig, &jsonSchema, &hooks, &forwardHooks, &priority, &worktreePath, &worktreeBranch, &worktreeBaseRef, &forkedFromSequence, &maxCostUSD, &maxOutputTokens, &maxWallClockMs, &stopReason, &stallPolicy, &stalledAt, &maxRetries, &retryBackoffMs, &retryCount, &retryAt, &recoveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		// Handle nullable fields
+		session.ClaudeSessionID = claudeSessionID.String
+		session.ParentSessionID = parentSessionID.String
+		session.Summary = summary.String
+		session.Title = title.String
+		session.Model = model.String
+		session.ModelID = modelID.String
+		session.WorkingDir = workingDir.String
+		session.SystemPrompt = systemPrompt.String
+		session.AppendSystemPrompt = appendSystemPrompt.String
+		session.CustomInstructions = customInstructions.String
+		session.PermissionPromptTool = permissionPromptTool.String
+		session.AllowedTools = allowedTools.String
+		session.DisallowedTools = disallowedTools.String
+		session.ResultContent = resultContent.String
+		session.ErrorMessage = errorMessage.String
+		if completedAt.Valid {
+			session.CompletedAt = &completedAt.Time
+		}
+		if costUSD.Valid {
+			session.CostUSD = &costUSD.Float64
+		}
+		if inputTokens.Valid {
+			tokens := int(inputTokens.Int64)
+			session.InputTokens = &tokens
+		}
+		if outputTokens.Valid {
+			tokens := int(outputTokens.Int64)
+			session.OutputTokens = &tokens
+		}
+		if cacheCreationInputTokens.Valid {
+			tokens := int(cacheCreationInputTokens.Int64)
+			session.CacheCreationInputTokens = &tokens
+		}
+		if cacheReadInputTokens.Valid {
+			tokens := int(cacheReadInputTokens.Int64)
+			session.CacheReadInputTokens = &tokens
+		}
+		if effectiveContextTokens.Valid {
+			tokens := int(effectiveContextTokens.Int64)
+			session.EffectiveContextTokens = &tokens
+		}
+		if durationMS.Valid {
+			duration := int(durationMS.Int64)
+			session.DurationMS = &duration
+		}
+		if numTurns.Valid {
+			turns := int(numTurns.Int64)
+			session.NumTurns = &turns
+		}
+		session.ResultContent = resultContent.String
+		session.ErrorMessage = errorMessage.String
+
+		// Handle archived field - default to false if NULL
+		session.Archived = archived.Valid && archived.Bool
+
+		// Handle dangerously skip permissions expires at
+		if dangerouslySkipPermissionsExpiresAt.Valid {
+			session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+		}
+
+		// Handle dangerously skip permissions timeout ms
+		if dangerouslySkipPermissionsTimeoutMs.Valid {
+			session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
+		}
+
+		// Handle proxy fields
+		session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
+		session.ProxyBaseURL = proxyBaseURL.String
+		session.ProxyModelOverride = proxyModelOverride.String
+		session.ProxyAPIKey = proxyAPIKey.String
+
+		// Handle additional directories
+		session.AdditionalDirectories = additionalDirectories.String
+
+		// Handle editor state
+		if editorState.Valid {
+			session.EditorState = &editorState.String
+		}
+
+		// Handle folder ID
+		if folderID.Valid {
+			session.FolderID = &folderID.String
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+		// Handle error kind
+		session.ErrorKind = errorKind.String
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("session not found: %s", sessionID)
-	}
+		// Handle partial message streaming
+		session.IncludePartialMessages = includePartialMessages.Bool
 
-	return nil
-}
+		// Handle extended CLI options
+		session.PermissionMode = permissionMode.String
+		session.FallbackModel = fallbackModel.String
+		session.Settings = settings.String
+		session.SettingSources = settingSources.String
+		session.Agents = agents.String
+		session.StrictMCPConfig = strictMCPConfig.Bool
+		session.JSONSchema = jsonSchema.String
+		session.Hooks = hooks.String
+		session.ForwardHooks = forwardHooks.Valid && forwardHooks.Bool
+		session.Priority = priority.String
+		session.WorktreePath = worktreePath.String
+		session.WorktreeBranch = worktreeBranch.String
+		session.WorktreeBaseRef = worktreeBaseRef.String
+		if forkedFromSequence.Valid {
+			seq := int(forkedFromSequence.Int64)
+			session.ForkedFromSequence = &seq
+		}
+		if maxCostUSD.Valid {
+			session.MaxCostUSD = &maxCostUSD.Float64
+		}
+		if maxOutputTokens.Valid {
+			tokens := int(maxOutputTokens.Int64)
+			session.MaxOutputTokens = &tokens
+		}
+		if maxWallClockMs.Valid {
+			session.MaxWallClockMs = &maxWallClockMs.Int64
+		}
+		session.StopReason = stopReason.String
+		session.StallPolicy = stallPolicy.String
+		if stalledAt.Valid {
+			session.StalledAt = &stalledAt.Time
+		}
+		session.MaxRetries = int(maxRetries.Int64)
+		session.RetryBackoffMs = retryBackoffMs.Int64
+		session.RetryCount = int(retryCount.Int64)
+		if retryAt.Valid {
+			session.RetryAt = &retryAt.Time
+		}
+		if recoveredAt.Valid {
+			session.RecoveredAt = &recoveredAt.Time
+		}
 
-// HardDeleteSession permanently deletes a session from the database
-func (s *SQLiteStore) HardDeleteSession(ctx context.Context, sessionID string) error {
-	query := `DELETE FROM sessions WHERE id = ?`
-	result, err := s.db.ExecContext(ctx, query, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to delete session: %w", err)
+		sessions = append(sessions, &session)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+	// Apply the user's requested limit
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
 	}
 
-	return nil
+	return sessions, nil
 }
 
-// GetSession retrieves a session by ID
-func (s *SQLiteStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+// GetExpiredDangerousPermissionsSessions returns sessions where dangerous permissions have expired
+func (s *SQLiteStore) GetExpiredDangerousPermissionsSessions(ctx context.Context) ([]*Session, error) {
+	now := time.Now()
 	query := `
 		SELECT id, run_id, claude_session_id, parent_session_id,
 			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
 			permission_prompt_tool, allowed_tools, disallowed_tools,
 			status, created_at, last_activity_at, completed_at,
 			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+		duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
 			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
-			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id
-		FROM sessions WHERE id = ?
+			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state
+		FROM sessions
+		WHERE dangerously_skip_permissions = 1
+			AND dangerously_skip_permissions_expires_at IS NOT NULL
+			AND dangerously_skip_permissions_expires_at < ?
+			AND status IN ('running', 'waiting_input', 'starting')
+		ORDER BY dangerously_skip_permissions_expires_at ASC
 	`
 
-	var session Session
-	var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-	var permissionPromptTool, allowedTools, disallowedTools sql.NullString
-	var completedAt sql.NullTime
-	var costUSD sql.NullFloat64
-	var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
-	var durationMS, numTurns sql.NullInt64
-	var resultContent, errorMessage sql.NullString
-	var archived sql.NullBool
-	var dangerouslySkipPermissionsExpiresAt sql.NullTime
-	var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
-	var proxyEnabled sql.NullBool
-	var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
-	var additionalDirectories sql.NullString
-	var editorState sql.NullString
-	var folderID sql.NullString
-
-	err := s.db.QueryRowContext(ctx, query, sessionID).Scan(
-		&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
-		&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
-		&systemPrompt, &appendSystemPrompt, &customInstructions,
-		&permissionPromptTool, &allowedTools, &disallowedTools,
-		&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
-		&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-		&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
-		&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
-		&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID,
-	)
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("session not found: %s", sessionID)
-	}
+	rows, err := s.db.QueryContext(ctx, query, now)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get session: %w", err)
+		return nil, fmt.Errorf("failed to query expired dangerous skip permissions sessions: %w", err)
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Handle nullable fields
-	session.ClaudeSessionID = claudeSessionID.String
-	session.ParentSessionID = parentSessionID.String
-	session.Summary = summary.String
-	session.Title = title.String
-	session.Model = model.String
-	session.ModelID = modelID.String
-	session.WorkingDir = workingDir.String
-	session.SystemPrompt = systemPrompt.String
-	session.AppendSystemPrompt = appendSystemPrompt.String
-	session.CustomInstructions = customInstructions.String
-	session.PermissionPromptTool = permissionPromptTool.String
-	session.AllowedTools = allowedTools.String
-	session.DisallowedTools = disallowedTools.String
-	session.ResultContent = resultContent.String
-	session.ErrorMessage = errorMessage.String
-	if completedAt.Valid {
-		session.CompletedAt = &completedAt.Time
-	}
-	if costUSD.Valid {
-		session.CostUSD = &costUSD.Float64
-	}
-	if inputTokens.Valid {
-		tokens := int(inputTokens.Int64)
-		session.InputTokens = &tokens
-	}
-	if outputTokens.Valid {
-		tokens := int(outputTokens.Int64)
-		session.OutputTokens = &tokens
-	}
-	if cacheCreationInputTokens.Valid {
-		tokens := int(cacheCreationInputTokens.Int64)
-		session.CacheCreationInputTokens = &tokens
-	}
-	if cacheReadInputTokens.Valid {
-		tokens := int(cacheReadInputTokens.Int64)
-		session.CacheReadInputTokens = &tokens
-	}
-	if effectiveContextTokens.Valid {
-		tokens := int(effectiveContextTokens.Int64)
-		session.EffectiveContextTokens = &tokens
-	}
-	if durationMS.Valid {
-		duration := int(durationMS.Int64)
-		session.DurationMS = &duration
-	}
-	if numTurns.Valid {
-		turns := int(numTurns.Int64)
-		session.NumTurns = &turns
-	}
+	var sessions []*Session
+	for rows.Next() {
+		var session Session
+		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
+		var permissionPromptTool, allowedTools, disallowedTools sql.NullString
+		var completedAt sql.NullTime
+		var costUSD sql.NullFloat64
+		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
+		var durationMS, numTurns sql.NullInt64
+		var resultContent, errorMessage sql.NullString
+		var archived sql.NullBool
+		var dangerouslySkipPermissionsExpiresAt sql.NullTime
+		var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
+		var proxyEnabled sql.NullBool
+		var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
+		var additionalDirectories sql.NullString
+		var editorState sql.NullString
 
-	// Handle archived field - default to false if NULL
-	session.Archived = archived.Valid && archived.Bool
+		err := rows.Scan(
+			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
+			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
+			&systemPrompt, &appendSystemPrompt, &customInstructions,
+			&permissionPromptTool, &allowedTools, &disallowedTools,
+			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
+			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
+			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
+			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		// Handle nullable fields
+		session.ClaudeSessionID = claudeSessionID.String
+		session.ParentSessionID = parentSessionID.String
+		session.Summary = summary.String
+		session.Title = title.String
+		session.Model = model.String
+		session.ModelID = modelID.String
+		session.WorkingDir = workingDir.String
+		session.SystemPrompt = systemPrompt.String
+		session.AppendSystemPrompt = appendSystemPrompt.String
+		session.CustomInstructions = customInstructions.String
+		session.PermissionPromptTool = permissionPromptTool.String
+		session.AllowedTools = allowedTools.String
+		session.DisallowedTools = disallowedTools.String
+		session.ResultContent = resultContent.String
+		session.ErrorMessage = errorMessage.String
+		if completedAt.Valid {
+			session.CompletedAt = &completedAt.Time
+		}
+		if costUSD.Valid {
+			session.CostUSD = &costUSD.Float64
+		}
+		if inputTokens.Valid {
+			tokens := int(inputTokens.Int64)
+			session.InputTokens = &tokens
+		}
+		if outputTokens.Valid {
+			tokens := int(outputTokens.Int64)
+			session.OutputTokens = &tokens
+		}
+		if cacheCreationInputTokens.Valid {
+			tokens := int(cacheCreationInputTokens.Int64)
+			session.CacheCreationInputTokens = &tokens
+		}
+		if cacheReadInputTokens.Valid {
+			tokens := int(cacheReadInputTokens.Int64)
+			session.CacheReadInputTokens = &tokens
+		}
+		if effectiveContextTokens.Valid {
+			tokens := int(effectiveContextTokens.Int64)
+			session.EffectiveContextTokens = &tokens
+		}
+		if durationMS.Valid {
+			duration := int(durationMS.Int64)
+			session.DurationMS = &duration
+		}
+		if numTurns.Valid {
+			turns := int(numTurns.Int64)
+			session.NumTurns = &turns
+		}
+		session.ResultContent = resultContent.String
+		session.ErrorMessage = errorMessage.String
+
+		// Handle archived field - default to false if NULL
+		session.Archived = archived.Valid && archived.Bool
+
+		// Handle dangerously skip permissions expires at
+		if dangerouslySkipPermissionsExpiresAt.Valid {
+			session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+		}
+
+		// Handle dangerously skip permissions timeout ms
+		if dangerouslySkipPermissionsTimeoutMs.Valid {
+			session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
+		}
+
+		// Handle proxy fields
+		session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
+		session.ProxyBaseURL = proxyBaseURL.String
+		session.ProxyModelOverride = proxyModelOverride.String
+		session.ProxyAPIKey = proxyAPIKey.String
+
+		// Handle additional directories
+		session.AdditionalDirectories = additionalDirectories.String
+
+		// Handle editor state
+		if editorState.Valid {
+			session.EditorState = &editorState.String
+		}
 
-	// Handle dangerously skip permissions expires at
-	if dangerouslySkipPermissionsExpiresAt.Valid {
-		session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+		sessions = append(sessions, &session)
 	}
 
-	// Handle dangerously skip permissions timeout ms
-	if dangerouslySkipPermissionsTimeoutMs.Valid {
-		session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Handle proxy fields
-	session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
-	session.ProxyBaseURL = proxyBaseURL.String
-	session.ProxyModelOverride = proxyModelOverride.String
-	session.ProxyAPIKey = proxyAPIKey.String
+	return sessions, nil
+}
 
-	// Handle additional directories
-	session.AdditionalDirectories = additionalDirectories.String
+// GetIdleSessions returns sessions in status whose last activity was before idleSince
+func (s *SQLiteStore) GetIdleSessions(ctx context.Context, status string, idleSince time.Time) ([]*Session, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM sessions
+		WHERE status = ? AND last_activity_at < ?
+		ORDER BY last_activity_at ASC
+	`, status, idleSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idle sessions: %w", err)
+	}
 
-	// Handle editor state
-	if editorState.Valid {
-		session.EditorState = &editorState.String
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan idle session: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Handle folder ID
-	if folderID.Valid {
-		session.FolderID = &folderID.String
+	sessions := make([]*Session, 0, len(ids))
+	for _, id := range ids {
+		session, err := s.GetSession(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
 	}
+	return sessions, nil
+}
 
-	return &session, nil
+// GetLatestChildSession returns the most recently created session continuing
+// parentSessionID, or nil if none does
+func (s *SQLiteStore) GetLatestChildSession(ctx context.Context, parentSessionID string) (*Session, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id FROM sessions
+		WHERE parent_session_id = ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, parentSessionID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest child session: %w", err)
+	}
+	return s.GetSession(ctx, id)
 }
 
-// GetSessionByRunID retrieves a session by its run_id
-func (s *SQLiteStore) GetSessionByRunID(ctx context.Context, runID string) (*Session, error) {
+// GetRecentWorkingDirs retrieves recently used working directories
+func (s *SQLiteStore) GetRecentWorkingDirs(ctx context.Context, limit int) ([]RecentPath, error) {
+	if limit <= 0 {
+		limit = 20 // Default to 20 recent paths
+	}
+
 	query := `
-		SELECT id, run_id, claude_session_id, parent_session_id,
-			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools,
-			status, created_at, last_activity_at, completed_at,
-			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
-			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
-			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id
+		SELECT
+			working_dir as path,
+			datetime(MAX(last_activity_at)) as last_used,
+			COUNT(*) as usage_count
 		FROM sessions
-		WHERE run_id = ?
+		WHERE working_dir IS NOT NULL
+			AND working_dir != ''
+			AND working_dir != '.'
+		GROUP BY working_dir
+		ORDER BY MAX(last_activity_at) DESC
+		LIMIT ?
 	`
 
-	var session Session
-	var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-	var permissionPromptTool, allowedTools, disallowedTools sql.NullString
-	var completedAt sql.NullTime
-	var costUSD sql.NullFloat64
-	var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
-	var durationMS, numTurns sql.NullInt64
-	var resultContent, errorMessage sql.NullString
-	var archived sql.NullBool
-	var dangerouslySkipPermissionsExpiresAt sql.NullTime
-	var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
-	var proxyEnabled sql.NullBool
-	var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
-	var additionalDirectories sql.NullString
-	var editorState sql.NullString
-	var folderID sql.NullString
-
-	err := s.db.QueryRowContext(ctx, query, runID).Scan(
-		&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
-		&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
-		&systemPrompt, &appendSystemPrompt, &customInstructions,
-		&permissionPromptTool, &allowedTools, &disallowedTools,
-		&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
-		&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-		&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
-		&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
-		&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID,
-	)
-	if err == sql.ErrNoRows {
-		return nil, nil // No session found
-	}
+	rows, err := s.db.QueryContext(ctx, query, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get session by run_id: %w", err)
+		return nil, fmt.Errorf("query recent paths: %w", err)
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Convert nullable fields
-	session.ClaudeSessionID = claudeSessionID.String
-	session.ParentSessionID = parentSessionID.String
-	session.Summary = summary.String
-	session.Title = title.String
-	session.Model = model.String
-	session.ModelID = modelID.String
-	session.WorkingDir = workingDir.String
-	session.SystemPrompt = systemPrompt.String
-	session.AppendSystemPrompt = appendSystemPrompt.String
-	session.CustomInstructions = customInstructions.String
-	session.PermissionPromptTool = permissionPromptTool.String
-	session.AllowedTools = allowedTools.String
-	session.DisallowedTools = disallowedTools.String
-	session.ResultContent = resultContent.String
-	session.ErrorMessage = errorMessage.String
-	if completedAt.Valid {
-		session.CompletedAt = &completedAt.Time
-	}
-	if costUSD.Valid {
-		session.CostUSD = &costUSD.Float64
+	var paths []RecentPath
+	for rows.Next() {
+		var p RecentPath
+		var lastUsedStr string
+		if err := rows.Scan(&p.Path, &lastUsedStr, &p.UsageCount); err != nil {
+			return nil, fmt.Errorf("scan recent path: %w", err)
+		}
+		// Parse the datetime string from SQLite
+		if t, err := time.Parse("2006-01-02 15:04:05", lastUsedStr); err == nil {
+			p.LastUsed = t
+		}
+		paths = append(paths, p)
 	}
-	if inputTokens.Valid {
-		tokens := int(inputTokens.Int64)
-		session.InputTokens = &tokens
+
+	return paths, rows.Err()
+}
+
+// GetUserSettings retrieves the user settings from the database
+func (s *SQLiteStore) GetUserSettings(ctx context.Context) (*UserSettings, error) {
+	var settings UserSettings
+	err := s.db.QueryRowContext(ctx, `
+		SELECT advanced_providers, opt_in_telemetry, created_at, updated_at
+		FROM user_settings WHERE id = 1
+	`).Scan(&settings.AdvancedProviders, &settings.OptInTelemetry, &settings.CreatedAt, &settings.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		// Return defaults if not found (for backwards compatibility)
+		return &UserSettings{
+			AdvancedProviders: false,
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+		}, nil
 	}
-	if outputTokens.Valid {
-		tokens := int(outputTokens.Int64)
-		session.OutputTokens = &tokens
+	return &settings, err
+}
+
+// UpdateUserSettings updates the user settings in the database
+func (s *SQLiteStore) UpdateUserSettings(ctx context.Context, settings UserSettings) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE user_settings
+		SET advanced_providers = ?, opt_in_telemetry = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = 1
+	`, settings.AdvancedProviders, settings.OptInTelemetry)
+	return err
+}
+
+// AddConversationEvent adds a new conversation event
+func (s *SQLiteStore) AddConversationEvent(ctx context.Context, event *ConversationEvent) error {
+	// Use a transaction to avoid race conditions with sequence numbers
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	if cacheCreationInputTokens.Valid {
-		tokens := int(cacheCreationInputTokens.Int64)
-		session.CacheCreationInputTokens = &tokens
+	defer func() { _ = tx.Rollback() }()
+
+	// Get next sequence number for this claude session within the transaction
+	var maxSeq sql.NullInt64
+	err = tx.QueryRowContext(ctx,
+		"SELECT MAX(sequence) FROM conversation_events WHERE claude_session_id = ?",
+		event.ClaudeSessionID,
+	).Scan(&maxSeq)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get max sequence: %w", err)
 	}
-	if cacheReadInputTokens.Valid {
-		tokens := int(cacheReadInputTokens.Int64)
-		session.CacheReadInputTokens = &tokens
+
+	event.Sequence = int(maxSeq.Int64) + 1
+
+	query := `
+		INSERT INTO conversation_events (
+			session_id, claude_session_id, sequence, event_type,
+			role, content,
+			tool_id, tool_name, tool_input_json, parent_tool_use_id,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		event.SessionID, event.ClaudeSessionID, event.Sequence, event.EventType,
+		event.Role, event.Content,
+		event.ToolID, event.ToolName, event.ToolInputJSON, event.ParentToolUseID,
+		event.ToolResultForID, event.ToolResultContent,
+		event.IsCompleted, event.ApprovalStatus, event.ApprovalID, event.MessageUUID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add conversation event: %w", err)
 	}
-	if effectiveContextTokens.Valid {
-		tokens := int(effectiveContextTokens.Int64)
-		session.EffectiveContextTokens = &tokens
+
+	id, err := result.LastInsertId()
+	if err == nil {
+		event.ID = id
 	}
-	if durationMS.Valid {
-		duration := int(durationMS.Int64)
-		session.DurationMS = &duration
+
+	return tx.Commit()
+}
+
+// GetConversation retrieves all events for a Claude session
+func (s *SQLiteStore) GetConversation(ctx context.Context, claudeSessionID string) ([]*ConversationEvent, error) {
+	query := `
+		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
+			role, content,
+			tool_id, tool_name, tool_input_json, parent_tool_use_id,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		FROM conversation_events
+		WHERE claude_session_id = ?
+		ORDER BY sequence
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, claudeSessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
 	}
-	if numTurns.Valid {
-		turns := int(numTurns.Int64)
-		session.NumTurns = &turns
+	defer func() { _ = rows.Close() }()
+
+	var events []*ConversationEvent
+	for rows.Next() {
+		event := &ConversationEvent{}
+		err := rows.Scan(
+			&event.ID, &event.SessionID, &event.ClaudeSessionID,
+			&event.Sequence, &event.EventType, &event.CreatedAt,
+			&event.Role, &event.Content,
+			&event.ToolID, &event.ToolName, &event.ToolInputJSON, &event.ParentToolUseID,
+			&event.ToolResultForID, &event.ToolResultContent,
+			&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID, &event.MessageUUID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
 	}
 
-	// Handle archived field - default to false if NULL
-	session.Archived = archived.Valid && archived.Bool
+	return events, nil
+}
 
-	// Handle dangerously skip permissions expires at
-	if dangerouslySkipPermissionsExpiresAt.Valid {
-		session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
-	}
+// GetSessionConversation retrieves all events for a session including parent history.
+// A forked session only includes its parent's events up to the fork point.
+func (s *SQLiteStore) GetSessionConversation(ctx context.Context, sessionID string) ([]*ConversationEvent, error) {
+	// Walk up the parent chain to get all related claude session IDs
+	claudeSessionIDs := []string{}
+	// Highest sequence to include per claude session, for parents of forks
+	sequenceLimits := make(map[string]int64)
+	currentID := sessionID
+	isFirstSession := true
+	var forkLimit sql.NullInt64
 
-	// Handle dangerously skip permissions timeout ms
-	if dangerouslySkipPermissionsTimeoutMs.Valid {
-		session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
-	}
+	for currentID != "" {
+		var claudeSessionID sql.NullString
+		var parentID sql.NullString
+		var forkedFromSequence sql.NullInt64
 
-	// Handle proxy fields
-	session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
-	session.ProxyBaseURL = proxyBaseURL.String
-	session.ProxyModelOverride = proxyModelOverride.String
-	session.ProxyAPIKey = proxyAPIKey.String
+		err := s.db.QueryRowContext(ctx,
+			"SELECT claude_session_id, parent_session_id, forked_from_sequence FROM sessions WHERE id = ?",
+			currentID,
+		).Scan(&claudeSessionID, &parentID, &forkedFromSequence)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// If the requested session doesn't exist, return error
+				if isFirstSession {
+					return nil, fmt.Errorf("session not found: %s", sessionID)
+				}
+				// Otherwise, parent not found, just stop walking
+				break
+			}
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+		isFirstSession = false
 
-	// Handle additional directories
-	session.AdditionalDirectories = additionalDirectories.String
+		// Add claude session ID if present (in reverse order for chronological events)
+		if claudeSessionID.Valid && claudeSessionID.String != "" {
+			claudeSessionIDs = append([]string{claudeSessionID.String}, claudeSessionIDs...)
+			// The child walked up from was forked from this session
+			if forkLimit.Valid {
+				sequenceLimits[claudeSessionID.String] = forkLimit.Int64
+			}
+		}
+		forkLimit = forkedFromSequence
 
-	// Handle editor state
-	if editorState.Valid {
-		session.EditorState = &editorState.String
+		// Move to parent
+		if parentID.Valid {
+			currentID = parentID.String
+		} else {
+			currentID = ""
+		}
 	}
 
-	// Handle folder ID
-	if folderID.Valid {
-		session.FolderID = &folderID.String
+	if len(claudeSessionIDs) == 0 {
+		// No claude sessions yet, return empty
+		return []*ConversationEvent{}, nil
 	}
 
-	return &session, nil
-}
+	// Get all events for all claude session IDs in chronological order
+	conditions := make([]string, len(claudeSessionIDs))
+	args := make([]interface{}, 0, len(claudeSessionIDs)*3)
+	for i, id := range claudeSessionIDs {
+		if limit, ok := sequenceLimits[id]; ok {
+			conditions[i] = "(claude_session_id = ? AND sequence <= ?)"
+			args = append(args, id, limit)
+		} else {
+			conditions[i] = "claude_session_id = ?"
+			args = append(args, id)
+		}
+	}
 
-// ListSessions retrieves all sessions
-func (s *SQLiteStore) ListSessions(ctx context.Context) ([]*Session, error) {
-	query := `
-		SELECT id, run_id, claude_session_id, parent_session_id,
-			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools,
-			status, created_at, last_activity_at, completed_at,
-			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-		duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
-			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
-			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id
-		FROM sessions
-		ORDER BY last_activity_at DESC
-	`
+	// Build query that orders by the position in the claude session ID list first
+	// This ensures parent events come before child events
+	orderCases := make([]string, len(claudeSessionIDs))
+	for i := range claudeSessionIDs {
+		orderCases[i] = fmt.Sprintf("WHEN claude_session_id = ? THEN %d", i)
+		args = append(args, claudeSessionIDs[i])
+	}
 
-	rows, err := s.db.QueryContext(ctx, query)
+	query := fmt.Sprintf(`
+		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
+			role, content,
+			tool_id, tool_name, tool_input_json, parent_tool_use_id,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		FROM conversation_events
+		WHERE %s
+		ORDER BY
+			CASE %s END,
+			sequence
+	`, strings.Join(conditions, " OR "), strings.Join(orderCases, " "))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sessions: %w", err)
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var sessions []*Session
+	var events []*ConversationEvent
 	for rows.Next() {
-		var session Session
-		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-		var permissionPromptTool, allowedTools, disallowedTools sql.NullString
-		var completedAt sql.NullTime
-		var costUSD sql.NullFloat64
-		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
-		var durationMS, numTurns sql.NullInt64
-		var resultContent, errorMessage sql.NullString
-		var archived sql.NullBool
-		var dangerouslySkipPermissionsExpiresAt sql.NullTime
-		var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
-		var proxyEnabled sql.NullBool
-		var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
-		var additionalDirectories sql.NullString
-		var editorState sql.NullString
-		var folderID sql.NullString
-
+		event := &ConversationEvent{}
 		err := rows.Scan(
-			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
-			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
-			&systemPrompt, &appendSystemPrompt, &customInstructions,
-			&permissionPromptTool, &allowedTools, &disallowedTools,
-			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
-			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
-			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
-			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID,
+			&event.ID, &event.SessionID, &event.ClaudeSessionID,
+			&event.Sequence, &event.EventType, &event.CreatedAt,
+			&event.Role, &event.Content,
+			&event.ToolID, &event.ToolName, &event.ToolInputJSON, &event.ParentToolUseID,
+			&event.ToolResultForID, &event.ToolResultContent,
+			&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID, &event.MessageUUID,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan session: %w", err)
+			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
+		events = append(events, event)
+	}
 
-		// Handle nullable fields
-		session.ClaudeSessionID = claudeSessionID.String
-		session.ParentSessionID = parentSessionID.String
-		session.Summary = summary.String
-		session.Title = title.String
-		session.Model = model.String
-		session.ModelID = modelID.String
-		session.WorkingDir = workingDir.String
-		session.SystemPrompt = systemPrompt.String
-		session.AppendSystemPrompt = appendSystemPrompt.String
-		session.CustomInstructions = customInstructions.String
-		session.PermissionPromptTool = permissionPromptTool.String
-		session.AllowedTools = allowedTools.String
-		session.DisallowedTools = disallowedTools.String
-		session.ResultContent = resultContent.String
-		session.ErrorMessage = errorMessage.String
-		if completedAt.Valid {
-			session.CompletedAt = &completedAt.Time
-		}
-		if costUSD.Valid {
-			session.CostUSD = &costUSD.Float64
-		}
-		if inputTokens.Valid {
-			tokens := int(inputTokens.Int64)
-			session.InputTokens = &tokens
-		}
-		if outputTokens.Valid {
-			tokens := int(outputTokens.Int64)
-			session.OutputTokens = &tokens
-		}
-		if cacheCreationInputTokens.Valid {
-			tokens := int(cacheCreationInputTokens.Int64)
-			session.CacheCreationInputTokens = &tokens
-		}
-		if cacheReadInputTokens.Valid {
-			tokens := int(cacheReadInputTokens.Int64)
-			session.CacheReadInputTokens = &tokens
-		}
-		if effectiveContextTokens.Valid {
-			tokens := int(effectiveContextTokens.Int64)
-			session.EffectiveContextTokens = &tokens
-		}
-		if durationMS.Valid {
-			duration := int(durationMS.Int64)
-			session.DurationMS = &duration
-		}
-		if numTurns.Valid {
-			turns := int(numTurns.Int64)
-			session.NumTurns = &turns
-		}
-		session.ResultContent = resultContent.String
-		session.ErrorMessage = errorMessage.String
+	return events, nil
+}
 
-		// Handle archived field - default to false if NULL
-		session.Archived = archived.Valid && archived.Bool
+// GetPendingToolCall finds the most recent uncompleted tool call for a given session and tool name
+func (s *SQLiteStore) GetPendingToolCall(ctx context.Context, sessionID string, toolName string) (*ConversationEvent, error) {
+	// Find the most recent uncompleted tool call by sequence number (temporal proximity)
+	query := `
+		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
+			role, content,
+			tool_id, tool_name, tool_input_json,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		FROM conversation_events
+		WHERE tool_name = ?
+		  AND session_id = ?
+		  AND event_type = 'tool_call'
+		  AND is_completed = FALSE
+		ORDER BY sequence DESC  -- Most recent first
+		LIMIT 1
+	`
 
-		// Handle dangerously skip permissions expires at
-		if dangerouslySkipPermissionsExpiresAt.Valid {
-			session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
-		}
+	event := &ConversationEvent{}
+	err := s.db.QueryRowContext(ctx, query, toolName, sessionID).Scan(
+		&event.ID, &event.SessionID, &event.ClaudeSessionID,
+		&event.Sequence, &event.EventType, &event.CreatedAt,
+		&event.Role, &event.Content,
+		&event.ToolID, &event.ToolName, &event.ToolInputJSON,
+		&event.ToolResultForID, &event.ToolResultContent,
+		&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID, &event.MessageUUID,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // No pending tool call found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending tool call: %w", err)
+	}
+
+	return event, nil
+}
+
+// GetUncorrelatedPendingToolCall finds the most recent uncompleted tool call without approval correlation
+func (s *SQLiteStore) GetUncorrelatedPendingToolCall(ctx context.Context, sessionID string, toolName string) (*ConversationEvent, error) {
+	// Find the most recent uncompleted tool call by sequence number without approval
+	query := `
+		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
+			role, content,
+			tool_id, tool_name, tool_input_json,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		FROM conversation_events
+		WHERE tool_name = ?
+		  AND session_id = ?
+		  AND event_type = 'tool_call'
+		  AND is_completed = FALSE
+		  AND (approval_status IS NULL OR approval_status = '')
+		ORDER BY sequence DESC  -- Most recent first
+		LIMIT 1
+	`
 
-		// Handle dangerously skip permissions timeout ms
-		if dangerouslySkipPermissionsTimeoutMs.Valid {
-			session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
-		}
+	event := &ConversationEvent{}
+	err := s.db.QueryRowContext(ctx, query, toolName, sessionID).Scan(
+		&event.ID, &event.SessionID, &event.ClaudeSessionID,
+		&event.Sequence, &event.EventType, &event.CreatedAt,
+		&event.Role, &event.Content,
+		&event.ToolID, &event.ToolName, &event.ToolInputJSON,
+		&event.ToolResultForID, &event.ToolResultContent,
+		&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID, &event.MessageUUID,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // No pending tool call found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uncorrelated pending tool call: %w", err)
+	}
 
-		// Handle proxy fields
-		session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
-		session.ProxyBaseURL = proxyBaseURL.String
-		session.ProxyModelOverride = proxyModelOverride.String
-		session.ProxyAPIKey = proxyAPIKey.String
+	return event, nil
+}
 
-		// Handle additional directories
-		session.AdditionalDirectories = additionalDirectories.String
+// GetPendingToolCalls finds all uncompleted tool calls for a given session
+func (s *SQLiteStore) GetPendingToolCalls(ctx context.Context, sessionID string) ([]*ConversationEvent, error) {
+	query := `
+		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
+			role, content,
+			tool_id, tool_name, tool_input_json,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		FROM conversation_events
+		WHERE session_id = ?
+		  AND event_type = 'tool_call'
+		  AND is_completed = FALSE
+		ORDER BY sequence DESC
+	`
 
-		// Handle editor state
-		if editorState.Valid {
-			session.EditorState = &editorState.String
-		}
+	rows, err := s.db.QueryContext(ctx, query, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending tool calls: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
 
-		// Handle folder ID
-		if folderID.Valid {
-			session.FolderID = &folderID.String
+	var events []*ConversationEvent
+	for rows.Next() {
+		event := &ConversationEvent{}
+		err := rows.Scan(
+			&event.ID, &event.SessionID, &event.ClaudeSessionID,
+			&event.Sequence, &event.EventType, &event.CreatedAt,
+			&event.Role, &event.Content,
+			&event.ToolID, &event.ToolName, &event.ToolInputJSON,
+			&event.ToolResultForID, &event.ToolResultContent,
+			&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID, &event.MessageUUID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
-
-		sessions = append(sessions, &session)
+		events = append(events, event)
 	}
 
-	return sessions, nil
+	return events, nil
 }
 
-// SearchSessionsByTitle searches for sessions by title using SQL LIKE
-func (s *SQLiteStore) SearchSessionsByTitle(ctx context.Context, query string, limit int) ([]*Session, error) {
-	if limit <= 0 {
-		limit = 10
+// GetToolCallByID retrieves a specific tool call by its ID
+func (s *SQLiteStore) GetToolCallByID(ctx context.Context, toolID string) (*ConversationEvent, error) {
+	query := `
+		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
+			role, content,
+			tool_id, tool_name, tool_input_json, parent_tool_use_id,
+			tool_result_for_id, tool_result_content,
+			is_completed, approval_status, approval_id, message_uuid
+		FROM conversation_events
+		WHERE tool_id = ?
+		  AND event_type = 'tool_call'
+		LIMIT 1
+	`
+
+	event := &ConversationEvent{}
+	err := s.db.QueryRowContext(ctx, query, toolID).Scan(
+		&event.ID, &event.SessionID, &event.ClaudeSessionID,
+		&event.Sequence, &event.EventType, &event.CreatedAt,
+		&event.Role, &event.Content,
+		&event.ToolID, &event.ToolName, &event.ToolInputJSON, &event.ParentToolUseID,
+		&event.ToolResultForID, &event.ToolResultContent,
+		&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID, &event.MessageUUID,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // Tool call not found
 	}
-	if limit > 50 {
-		limit = 50
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool call by ID: %w", err)
 	}
 
-	// Build the SQL query with LIKE pattern
-	// Use % wildcards for substring matching anywhere in title
-	// Filter to only leaf sessions (sessions with no children)
-	// Filter to only "normal" sessions (not archived, not draft, not discarded)
-	sqlQuery := `
-		SELECT id, run_id, claude_session_id, parent_session_id,
-			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools,
-			status, created_at, last_activity_at, completed_at,
-			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
-			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
-			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state, folder_id
-		FROM sessions
-		WHERE 1=1
-		AND NOT EXISTS (
-			SELECT 1 FROM sessions children
-			WHERE children.parent_session_id = sessions.id
-		)
-		AND (archived IS NULL OR archived = 0)
-		AND status != 'draft'
-		AND status != 'discarded'`
-
-	var args []interface{}
-
-	// Search across title, summary, and query fields to match UI display logic
-	// UI shows: title || summary || query, so search should match this behavior
-	if query != "" {
-		sqlQuery += " AND (title LIKE ? OR summary LIKE ? OR query LIKE ?)"
-		args = append(args, "%"+query+"%", "%"+query+"%", "%"+query+"%")
-	}
+	return event, nil
+}
 
-	// Order by last activity and limit
-	// Look at 20 most recent sessions for performance
-	sqlQuery += `
-		ORDER BY last_activity_at DESC
-		LIMIT 20`
+// MarkToolCallCompleted marks a tool call as completed when its result is received
+func (s *SQLiteStore) MarkToolCallCompleted(ctx context.Context, toolID string, sessionID string) error {
+	query := `
+		UPDATE conversation_events
+		SET is_completed = TRUE
+		WHERE tool_id = ?
+		  AND session_id = ?
+		  AND event_type = 'tool_call'
+	`
 
-	// Then apply the user's limit in Go after fetching
-	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	result, err := s.db.ExecContext(ctx, query, toolID, sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("search sessions query: %w", err)
+		return fmt.Errorf("failed to mark tool call completed: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
 
-	var sessions []*Session
-	for rows.Next() {
-		var session Session
-		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-		var permissionPromptTool, allowedTools, disallowedTools sql.NullString
-		var completedAt sql.NullTime
-		var costUSD sql.NullFloat64
-		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
-		var durationMS, numTurns sql.NullInt64
-		var resultContent, errorMessage sql.NullString
-		var archived sql.NullBool
-		var dangerouslySkipPermissionsExpiresAt sql.NullTime
-		var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
-		var proxyEnabled sql.NullBool
-		var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
-		var additionalDirectories sql.NullString
-		var editorState sql.NullString
-		var folderID sql.NullString
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		slog.Debug("no matching tool call found to mark completed",
+			"tool_id", toolID,
+			"session_id", sessionID)
+	}
 
-		err := rows.Scan(
-			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
-			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
-			&systemPrompt, &appendSystemPrompt, &customInstructions,
-			&permissionPromptTool, &allowedTools, &disallowedTools,
-			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
-			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
-			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
-			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState, &folderID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan session: %w", err)
-		}
+	return nil
+}
 
-		// Handle nullable fields
-		session.ClaudeSessionID = claudeSessionID.String
-		session.ParentSessionID = parentSessionID.String
-		session.Summary = summary.String
-		session.Title = title.String
-		session.Model = model.String
-		session.ModelID = modelID.String
-		session.WorkingDir = workingDir.String
-		session.SystemPrompt = systemPrompt.String
-		session.AppendSystemPrompt = appendSystemPrompt.String
-		session.CustomInstructions = customInstructions.String
-		session.PermissionPromptTool = permissionPromptTool.String
-		session.AllowedTools = allowedTools.String
-		session.DisallowedTools = disallowedTools.String
-		session.ResultContent = resultContent.String
-		session.ErrorMessage = errorMessage.String
-		if completedAt.Valid {
-			session.CompletedAt = &completedAt.Time
-		}
-		if costUSD.Valid {
-			session.CostUSD = &costUSD.Float64
-		}
-		if inputTokens.Valid {
-			tokens := int(inputTokens.Int64)
-			session.InputTokens = &tokens
-		}
-		if outputTokens.Valid {
-			tokens := int(outputTokens.Int64)
-			session.OutputTokens = &tokens
-		}
-		if cacheCreationInputTokens.Valid {
-			tokens := int(cacheCreationInputTokens.Int64)
-			session.CacheCreationInputTokens = &tokens
-		}
-		if cacheReadInputTokens.Valid {
-			tokens := int(cacheReadInputTokens.Int64)
-			session.CacheReadInputTokens = &tokens
-		}
-		if effectiveContextTokens.Valid {
-			tokens := int(effectiveContextTokens.Int64)
-			session.EffectiveContextTokens = &tokens
-		}
-		if durationMS.Valid {
-			duration := int(durationMS.Int64)
-			session.DurationMS = &duration
-		}
-		if numTurns.Valid {
-			turns := int(numTurns.Int64)
-			session.NumTurns = &turns
-		}
-		session.ResultContent = resultContent.String
-		session.ErrorMessage = errorMessage.String
+// CorrelateApproval correlates an approval with a tool call
+func (s *SQLiteStore) CorrelateApproval(ctx context.Context, sessionID string, toolName string, approvalID string) error {
+	// Find the pending tool call
+	toolCall, err := s.GetPendingToolCall(ctx, sessionID, toolName)
+	if err != nil {
+		return fmt.Errorf("failed to find pending tool call: %w", err)
+	}
+	if toolCall == nil {
+		slog.Debug("no matching tool call found for approval",
+			"session_id", sessionID,
+			"tool_name", toolName,
+			"approval_id", approvalID)
+		return nil // Not an error - approval might be for a different session
+	}
 
-		// Handle archived field - default to false if NULL
-		session.Archived = archived.Valid && archived.Bool
+	// Ensure it doesn't already have an approval
+	if toolCall.ApprovalStatus != "" {
+		slog.Warn("tool call already has approval status",
+			"tool_id", toolCall.ToolID,
+			"existing_status", toolCall.ApprovalStatus,
+			"approval_id", approvalID)
+		return nil
+	}
 
-		// Handle dangerously skip permissions expires at
-		if dangerouslySkipPermissionsExpiresAt.Valid {
-			session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
-		}
+	slog.Debug("found tool call to correlate",
+		"event_id", toolCall.ID,
+		"tool_id", toolCall.ToolID,
+		"session_id", sessionID,
+		"tool_name", toolName,
+		"approval_id", approvalID)
 
-		// Handle dangerously skip permissions timeout ms
-		if dangerouslySkipPermissionsTimeoutMs.Valid {
-			session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
-		}
+	// Update the found event
+	updateQuery := `
+		UPDATE conversation_events
+		SET approval_status = 'pending', approval_id = ?
+		WHERE id = ?
+	`
 
-		// Handle proxy fields
-		session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
-		session.ProxyBaseURL = proxyBaseURL.String
-		session.ProxyModelOverride = proxyModelOverride.String
-		session.ProxyAPIKey = proxyAPIKey.String
+	result, err := s.db.ExecContext(ctx, updateQuery, approvalID, toolCall.ID)
+	if err != nil {
+		return fmt.Errorf("failed to correlate approval: %w", err)
+	}
 
-		// Handle additional directories
-		session.AdditionalDirectories = additionalDirectories.String
+	rows, _ := result.RowsAffected()
+	slog.Info("updated tool call with approval",
+		"event_id", toolCall.ID,
+		"rows_affected", rows)
 
-		// Handle editor state
-		if editorState.Valid {
-			session.EditorState = &editorState.String
-		}
+	return nil
+}
 
-		// Handle folder ID
-		if folderID.Valid {
-			session.FolderID = &folderID.String
-		}
+// LinkConversationEventToApprovalUsingToolID correlates an approval with a specific tool call by tool_id
+func (s *SQLiteStore) LinkConversationEventToApprovalUsingToolID(ctx context.Context, sessionID string, toolID string, approvalID string) error {
+	// Update the tool call directly by tool_id
+	updateQuery := `
+		UPDATE conversation_events
+		SET approval_status = 'pending', approval_id = ?
+		WHERE session_id = ?
+		  AND tool_id = ?
+		  AND event_type = 'tool_call'
+		  AND is_completed = FALSE
+		  AND (approval_status IS NULL OR approval_status = '')
+	`
 
-		sessions = append(sessions, &session)
+	result, err := s.db.ExecContext(ctx, updateQuery, approvalID, sessionID, toolID)
+	if err != nil {
+		return fmt.Errorf("failed to correlate approval by tool_id: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration: %w", err)
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		slog.Debug("no matching tool call found for approval by tool_id",
+			"session_id", sessionID,
+			"tool_id", toolID,
+			"approval_id", approvalID)
+		return nil // Not an error
 	}
 
-	// Apply the user's requested limit
-	if len(sessions) > limit {
-		sessions = sessions[:limit]
-	}
+	slog.Info("correlated approval with tool call by tool_id",
+		"tool_id", toolID,
+		"session_id", sessionID,
+		"approval_id", approvalID,
+		"rows_affected", rows)
 
-	return sessions, nil
+	return nil
 }
 
-// GetExpiredDangerousPermissionsSessions returns sessions where dangerous permissions have expired
-func (s *SQLiteStore) GetExpiredDangerousPermissionsSessions(ctx context.Context) ([]*Session, error) {
-	now := time.Now()
+// UpdateApprovalStatus updates the status of an approval
+func (s *SQLiteStore) UpdateApprovalStatus(ctx context.Context, approvalID string, status string) error {
+	// Special handling for resolved status - don't overwrite approved/denied
+	if status == ApprovalStatusResolved {
+		query := `
+			UPDATE conversation_events
+			SET approval_status = ?
+			WHERE approval_id = ? AND approval_status = ?
+		`
+		_, err := s.db.ExecContext(ctx, query, status, approvalID, ApprovalStatusPending)
+		if err != nil {
+			return fmt.Errorf("failed to update approval status: %w", err)
+		}
+		return nil
+	}
+
+	// For approved/denied, always update
 	query := `
-		SELECT id, run_id, claude_session_id, parent_session_id,
-			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools,
-			status, created_at, last_activity_at, completed_at,
-			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-		duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
-			dangerously_skip_permissions, dangerously_skip_permissions_expires_at, dangerously_skip_permissions_timeout_ms,
-			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key, additional_directories, editor_state
-		FROM sessions
-		WHERE dangerously_skip_permissions = 1
-			AND dangerously_skip_permissions_expires_at IS NOT NULL
-			AND dangerously_skip_permissions_expires_at < ?
-			AND status IN ('running', 'waiting_input', 'starting')
-		ORDER BY dangerously_skip_permissions_expires_at ASC
+		UPDATE conversation_events
+		SET approval_status = ?
+		WHERE approval_id = ?
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, now)
+	_, err := s.db.ExecContext(ctx, query, status, approvalID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query expired dangerous skip permissions sessions: %w", err)
+		return fmt.Errorf("failed to update approval status: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
+	return nil
+}
 
-	var sessions []*Session
-	for rows.Next() {
-		var session Session
-		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-		var permissionPromptTool, allowedTools, disallowedTools sql.NullString
-		var completedAt sql.NullTime
-		var costUSD sql.NullFloat64
-		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
-		var durationMS, numTurns sql.NullInt64
-		var resultContent, errorMessage sql.NullString
-		var archived sql.NullBool
-		var dangerouslySkipPermissionsExpiresAt sql.NullTime
-		var dangerouslySkipPermissionsTimeoutMs sql.NullInt64
-		var proxyEnabled sql.NullBool
-		var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
-		var additionalDirectories sql.NullString
-		var editorState sql.NullString
+// StoreMCPServers stores MCP server configurations
+func (s *SQLiteStore) StoreMCPServers(ctx context.Context, sessionID string, servers []MCPServer) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
 
-		err := rows.Scan(
-			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
-			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
-			&systemPrompt, &appendSystemPrompt, &customInstructions,
-			&permissionPromptTool, &allowedTools, &disallowedTools,
-			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
-			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
-			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt, &dangerouslySkipPermissionsTimeoutMs,
-			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey, &additionalDirectories, &editorState,
-		)
+	query := `
+		INSERT INTO mcp_servers (session_id, name, command, args_json, env_json)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	for _, server := range servers {
+		_, err := tx.ExecContext(ctx, query,
+			sessionID, server.Name, server.Command, server.ArgsJSON, server.EnvJSON)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan session: %w", err)
+			return fmt.Errorf("failed to insert MCP server: %w", err)
 		}
+	}
 
-		// Handle nullable fields
-		session.ClaudeSessionID = claudeSessionID.String
-		session.ParentSessionID = parentSessionID.String
-		session.Summary = summary.String
-		session.Title = title.String
-		session.Model = model.String
-		session.ModelID = modelID.String
-		session.WorkingDir = workingDir.String
-		session.SystemPrompt = systemPrompt.String
-		session.AppendSystemPrompt = appendSystemPrompt.String
-		session.CustomInstructions = customInstructions.String
-		session.PermissionPromptTool = permissionPromptTool.String
-		session.AllowedTools = allowedTools.String
-		session.DisallowedTools = disallowedTools.String
-		session.ResultContent = resultContent.String
-		session.ErrorMessage = errorMessage.String
-		if completedAt.Valid {
-			session.CompletedAt = &completedAt.Time
-		}
-		if costUSD.Valid {
-			session.CostUSD = &costUSD.Float64
-		}
-		if inputTokens.Valid {
-			tokens := int(inputTokens.Int64)
-			session.InputTokens = &tokens
-		}
-		if outputTokens.Valid {
-			tokens := int(outputTokens.Int64)
-			session.OutputTokens = &tokens
-		}
-		if cacheCreationInputTokens.Valid {
-			tokens := int(cacheCreationInputTokens.Int64)
-			session.CacheCreationInputTokens = &tokens
-		}
-		if cacheReadInputTokens.Valid {
-			tokens := int(cacheReadInputTokens.Int64)
-			session.CacheReadInputTokens = &tokens
-		}
-		if effectiveContextTokens.Valid {
-			tokens := int(effectiveContextTokens.Int64)
-			session.EffectiveContextTokens = &tokens
-		}
-		if durationMS.Valid {
-			duration := int(durationMS.Int64)
-			session.DurationMS = &duration
-		}
-		if numTurns.Valid {
-			turns := int(numTurns.Int64)
-			session.NumTurns = &turns
-		}
-		session.ResultContent = resultContent.String
-		session.ErrorMessage = errorMessage.String
+	return tx.Commit()
+}
 
-		// Handle archived field - default to false if NULL
-		session.Archived = archived.Valid && archived.Bool
+// GetMCPServers retrieves MCP servers for a session
+func (s *SQLiteStore) GetMCPServers(ctx context.Context, sessionID string) ([]MCPServer, error) {
+	query := `
+		SELECT id, session_id, name, command, args_json, env_json
+		FROM mcp_servers
+		WHERE session_id = ?
+		ORDER BY id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MCP servers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
 
-		// Handle dangerously skip permissions expires at
-		if dangerouslySkipPermissionsExpiresAt.Valid {
-			session.DangerouslySkipPermissionsExpiresAt = &dangerouslySkipPermissionsExpiresAt.Time
+	var servers []MCPServer
+	for rows.Next() {
+		var server MCPServer
+		err := rows.Scan(
+			&server.ID, &server.SessionID, &server.Name,
+			&server.Command, &server.ArgsJSON, &server.EnvJSON,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan MCP server: %w", err)
 		}
+		servers = append(servers, server)
+	}
 
-		// Handle dangerously skip permissions timeout ms
-		if dangerouslySkipPermissionsTimeoutMs.Valid {
-			session.DangerouslySkipPermissionsTimeoutMs = &dangerouslySkipPermissionsTimeoutMs.Int64
-		}
+	return servers, nil
+}
 
-		// Handle proxy fields
-		session.ProxyEnabled = proxyEnabled.Valid && proxyEnabled.Bool
-		session.ProxyBaseURL = proxyBaseURL.String
-		session.ProxyModelOverride = proxyModelOverride.String
-		session.ProxyAPIKey = proxyAPIKey.String
+// StoreRawEvent stores a raw event for debugging
+func (s *SQLiteStore) StoreRawEvent(ctx context.Context, sessionID string, eventJSON string) error {
+	query := `
+		INSERT INTO raw_events (session_id, event_json)
+		VALUES (?, ?)
+	`
 
-		// Handle additional directories
-		session.AdditionalDirectories = additionalDirectories.String
+	_, err := s.db.ExecContext(ctx, query, sessionID, eventJSON)
+	if err != nil {
+		return fmt.Errorf("failed to store raw event: %w", err)
+	}
+	return nil
+}
 
-		// Handle editor state
-		if editorState.Valid {
-			session.EditorState = &editorState.String
-		}
+// CreateApproval creates a new approval
+func (s *SQLiteStore) CreateApproval(ctx context.Context, approval *Approval) error {
+	// Validate status
+	if !approval.Status.IsValid() {
+		return fmt.Errorf("invalid approval status: %s", approval.Status)
+	}
 
-		sessions = append(sessions, &session)
+	query := `
+		INSERT INTO approvals (
+			id, run_id, session_id, tool_use_id, status, created_at,
+			tool_name, tool_input, comment
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		approval.ID, approval.RunID, approval.SessionID, approval.ToolUseID, approval.Status.String(), approval.CreatedAt,
+		approval.ToolName, string(approval.ToolInput), approval.Comment,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create approval: %w", err)
 	}
+	return nil
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+// GetApproval retrieves an approval by ID
+func (s *SQLiteStore) GetApproval(ctx context.Context, id string) (*Approval, error) {
+	query := `
+		SELECT id, run_id, session_id, tool_use_id, status, created_at, responded_at,
+			tool_name, tool_input, comment
+		FROM approvals WHERE id = ?
+	`
+
+	var approval Approval
+	var toolUseID sql.NullString
+	var respondedAt sql.NullTime
+	var comment sql.NullString
+	var statusStr string
+	var toolInputStr string
+
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&approval.ID, &approval.RunID, &approval.SessionID, &toolUseID, &statusStr,
+		&approval.CreatedAt, &respondedAt,
+		&approval.ToolName, &toolInputStr, &comment,
+	)
+	if err == sql.ErrNoRows {
+		return nil, &NotFoundError{Type: "approval", ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval: %w", err)
 	}
 
-	return sessions, nil
-}
+	// Convert status string to ApprovalStatus
+	approval.Status = ApprovalStatus(statusStr)
+	if !approval.Status.IsValid() {
+		return nil, fmt.Errorf("invalid approval status in database: %s", statusStr)
+	}
 
-// GetRecentWorkingDirs retrieves recently used working directories
-func (s *SQLiteStore) GetRecentWorkingDirs(ctx context.Context, limit int) ([]RecentPath, error) {
-	if limit <= 0 {
-		limit = 20 // Default to 20 recent paths
+	// Handle nullable fields
+	if toolUseID.Valid {
+		approval.ToolUseID = &toolUseID.String
 	}
+	if respondedAt.Valid {
+		approval.RespondedAt = &respondedAt.Time
+	}
+	approval.Comment = comment.String
+	approval.ToolInput = json.RawMessage(toolInputStr)
+
+	return &approval, nil
+}
 
+// GetPendingApprovals retrieves all pending approvals for a session
+func (s *SQLiteStore) GetPendingApprovals(ctx context.Context, sessionID string) ([]*Approval, error) {
 	query := `
-		SELECT
-			working_dir as path,
-			datetime(MAX(last_activity_at)) as last_used,
-			COUNT(*) as usage_count
-		FROM sessions
-		WHERE working_dir IS NOT NULL
-			AND working_dir != ''
-			AND working_dir != '.'
-		GROUP BY working_dir
-		ORDER BY MAX(last_activity_at) DESC
-		LIMIT ?
+		SELECT id, run_id, session_id, tool_use_id, status, created_at, responded_at,
+			tool_name, tool_input, comment
+		FROM approvals
+		WHERE session_id = ? AND status = ?
+		ORDER BY created_at ASC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, limit)
+	rows, err := s.db.QueryContext(ctx, query, sessionID, ApprovalStatusLocalPending.String())
 	if err != nil {
-		return nil, fmt.Errorf("query recent paths: %w", err)
+		return nil, fmt.Errorf("failed to get pending approvals: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var paths []RecentPath
+	var approvals []*Approval
 	for rows.Next() {
-		var p RecentPath
-		var lastUsedStr string
-		if err := rows.Scan(&p.Path, &lastUsedStr, &p.UsageCount); err != nil {
-			return nil, fmt.Errorf("scan recent path: %w", err)
-		}
-		// Parse the datetime string from SQLite
-		if t, err := time.Parse("2006-01-02 15:04:05", lastUsedStr); err == nil {
-			p.LastUsed = t
+		var approval Approval
+		var toolUseID sql.NullString
+		var respondedAt sql.NullTime
+		var comment sql.NullString
+		var statusStr string
+		var toolInputStr string
+
+		err := rows.Scan(
+			&approval.ID, &approval.RunID, &approval.SessionID, &toolUseID, &statusStr,
+			&approval.CreatedAt, &respondedAt,
+			&approval.ToolName, &toolInputStr, &comment,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
 		}
-		paths = append(paths, p)
-	}
 
-	return paths, rows.Err()
-}
+		// Convert status string to ApprovalStatus
+		approval.Status = ApprovalStatus(statusStr)
+		if !approval.Status.IsValid() {
+			return nil, fmt.Errorf("invalid approval status in database: %s", statusStr)
+		}
 
-// GetUserSettings retrieves the user settings from the database
-func (s *SQLiteStore) GetUserSettings(ctx context.Context) (*UserSettings, error) {
-	var settings UserSettings
-	err := s.db.QueryRowContext(ctx, `
-		SELECT advanced_providers, opt_in_telemetry, created_at, updated_at
-		FROM user_settings WHERE id = 1
-	`).Scan(&settings.AdvancedProviders, &settings.OptInTelemetry, &settings.CreatedAt, &settings.UpdatedAt)
+		// Handle nullable fields
+		if toolUseID.Valid {
+			approval.ToolUseID = &toolUseID.String
+		}
+		if respondedAt.Valid {
+			approval.RespondedAt = &respondedAt.Time
+		}
+		approval.Comment = comment.String
+		approval.ToolInput = json.RawMessage(toolInputStr)
 
-	if err == sql.ErrNoRows {
-		// Return defaults if not found (for backwards compatibility)
-		return &UserSettings{
-			AdvancedProviders: false,
-			CreatedAt:         time.Now(),
-			UpdatedAt:         time.Now(),
-		}, nil
+		approvals = append(approvals, &approval)
 	}
-	return &settings, err
-}
 
-// UpdateUserSettings updates the user settings in the database
-func (s *SQLiteStore) UpdateUserSettings(ctx context.Context, settings UserSettings) error {
-	_, err := s.db.ExecContext(ctx, `
-		UPDATE user_settings
-		SET advanced_providers = ?, opt_in_telemetry = ?, updated_at = CURRENT_TIMESTAMP
-		WHERE id = 1
-	`, settings.AdvancedProviders, settings.OptInTelemetry)
-	return err
+	return approvals, nil
 }
 
-// AddConversationEvent adds a new conversation event
-func (s *SQLiteStore) AddConversationEvent(ctx context.Context, event *ConversationEvent) error {
-	// Use a transaction to avoid race conditions with sequence numbers
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+// UpdateApprovalResponse updates the status and comment of an approval
+func (s *SQLiteStore) UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, comment string) error {
+	// Validate status
+	if !status.IsValid() {
+		return fmt.Errorf("invalid approval status: %s", status)
 	}
-	defer func() { _ = tx.Rollback() }()
 
-	// Get next sequence number for this claude session within the transaction
-	var maxSeq sql.NullInt64
-	err = tx.QueryRowContext(ctx,
-		"SELECT MAX(sequence) FROM conversation_events WHERE claude_session_id = ?",
-		event.ClaudeSessionID,
-	).Scan(&maxSeq)
-	if err != nil && err != sql.ErrNoRows {
-		return fmt.Errorf("failed to get max sequence: %w", err)
+	// Check current status first
+	approval, err := s.GetApproval(ctx, id)
+	if err != nil {
+		return err // This already returns proper error types
 	}
 
-	event.Sequence = int(maxSeq.Int64) + 1
+	// Check if already decided
+	if approval.Status != ApprovalStatusLocalPending {
+		return &AlreadyDecidedError{ID: id, Status: approval.Status.String()}
+	}
 
 	query := `
-		INSERT INTO conversation_events (
-			session_id, claude_session_id, sequence, event_type,
-			role, content,
-			tool_id, tool_name, tool_input_json, parent_tool_use_id,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		UPDATE approvals
+		SET status = ?, comment = ?, responded_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = ?
 	`
 
-	result, err := tx.ExecContext(ctx, query,
-		event.SessionID, event.ClaudeSessionID, event.Sequence, event.EventType,
-		event.Role, event.Content,
-		event.ToolID, event.ToolName, event.ToolInputJSON, event.ParentToolUseID,
-		event.ToolResultForID, event.ToolResultContent,
-		event.IsCompleted, event.ApprovalStatus, event.ApprovalID,
-	)
+	result, err := s.db.ExecContext(ctx, query, status.String(), comment, id, ApprovalStatusLocalPending.String())
 	if err != nil {
-		return fmt.Errorf("failed to add conversation event: %w", err)
+		return fmt.Errorf("failed to update approval response: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	id, err := result.LastInsertId()
-	if err == nil {
-		event.ID = id
+	if rowsAffected == 0 {
+		// This shouldn't happen since we checked above, but just in case
+		return &NotFoundError{Type: "approval", ID: id}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-// GetConversation retrieves all events for a Claude session
-func (s *SQLiteStore) GetConversation(ctx context.Context, claudeSessionID string) ([]*ConversationEvent, error) {
-	query := `
-		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
-			role, content,
-			tool_id, tool_name, tool_input_json, parent_tool_use_id,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		FROM conversation_events
-		WHERE claude_session_id = ?
-		ORDER BY sequence
-	`
-
-	rows, err := s.db.QueryContext(ctx, query, claudeSessionID)
+// ReassignApproval moves a pending approval to a session and tool use, for
+// when the session that requested it is resumed in another one
+func (s *SQLiteStore) ReassignApproval(ctx context.Context, id string, sessionID string, runID string, toolUseID string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE approvals
+		SET session_id = ?, run_id = ?, tool_use_id = ?
+		WHERE id = ? AND status = ?
+	`, sessionID, runID, toolUseID, id, ApprovalStatusLocalPending.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get conversation: %w", err)
+		return fmt.Errorf("failed to reassign approval: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
 
-	var events []*ConversationEvent
-	for rows.Next() {
-		event := &ConversationEvent{}
-		err := rows.Scan(
-			&event.ID, &event.SessionID, &event.ClaudeSessionID,
-			&event.Sequence, &event.EventType, &event.CreatedAt,
-			&event.Role, &event.Content,
-			&event.ToolID, &event.ToolName, &event.ToolInputJSON, &event.ParentToolUseID,
-			&event.ToolResultForID, &event.ToolResultContent,
-			&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID,
-		)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		approval, err := s.GetApproval(ctx, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
+			return err
 		}
-		events = append(events, event)
+		return &AlreadyDecidedError{ID: id, Status: approval.Status.String()}
 	}
-
-	return events, nil
+	return nil
 }
 
-// GetSessionConversation retrieves all events for a session including parent history
-func (s *SQLiteStore) GetSessionConversation(ctx context.Context, sessionID string) ([]*ConversationEvent, error) {
-	// Walk up the parent chain to get all related claude session IDs
-	claudeSessionIDs := []string{}
-	currentID := sessionID
-	isFirstSession := true
+// Helper function to convert MCP config to store format
+func MCPServersFromConfig(sessionID string, config map[string]claudecode.MCPServer) ([]MCPServer, error) {
+	// First, collect all server names and sort them for deterministic ordering
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	// Sort names to ensure consistent ordering
+	sort.Strings(names)
 
-	for currentID != "" {
-		var claudeSessionID sql.NullString
-		var parentID sql.NullString
+	servers := make([]MCPServer, 0, len(config))
+	for _, name := range names {
+		server := config[name]
 
-		err := s.db.QueryRowContext(ctx,
-			"SELECT claude_session_id, parent_session_id FROM sessions WHERE id = ?",
-			currentID,
-		).Scan(&claudeSessionID, &parentID)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				// If the requested session doesn't exist, return error
-				if isFirstSession {
-					return nil, fmt.Errorf("session not found: %s", sessionID)
-				}
-				// Otherwise, parent not found, just stop walking
-				break
-			}
-			return nil, fmt.Errorf("failed to get session: %w", err)
-		}
-		isFirstSession = false
+		// For HTTP servers, store the configuration differently
+		// We'll use Command field to store the type, ArgsJSON for URL, and EnvJSON for headers
+		var command string
+		var argsJSON string
+		var envJSON string
 
-		// Add claude session ID if present (in reverse order for chronological events)
-		if claudeSessionID.Valid && claudeSessionID.String != "" {
-			claudeSessionIDs = append([]string{claudeSessionID.String}, claudeSessionIDs...)
-		}
+		if server.Type == "http" {
+			// HTTP server
+			command = "http"                             // Use "http" as the command to indicate HTTP type
+			argsJSON = fmt.Sprintf(`["%s"]`, server.URL) // Store URL as single-element array
 
-		// Move to parent
-		if parentID.Valid {
-			currentID = parentID.String
+			// Store headers in EnvJSON
+			headersData, err := json.Marshal(server.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal headers: %w", err)
+			}
+			envJSON = string(headersData)
 		} else {
-			currentID = ""
+			// Traditional stdio server
+			command = server.Command
+
+			argsData, err := json.Marshal(server.Args)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal args: %w", err)
+			}
+			argsJSON = string(argsData)
+
+			envData, err := json.Marshal(server.Env)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal env: %w", err)
+			}
+			envJSON = string(envData)
 		}
-	}
 
-	if len(claudeSessionIDs) == 0 {
-		// No claude sessions yet, return empty
-		return []*ConversationEvent{}, nil
+		servers = append(servers, MCPServer{
+			SessionID: sessionID,
+			Name:      name,
+			Command:   command,
+			ArgsJSON:  argsJSON,
+			EnvJSON:   envJSON,
+		})
 	}
+	return servers, nil
+}
 
-	// Get all events for all claude session IDs in chronological order
-	placeholders := make([]string, len(claudeSessionIDs))
-	args := make([]interface{}, len(claudeSessionIDs))
-	for i, id := range claudeSessionIDs {
-		placeholders[i] = "?"
-		args[i] = id
+// CreateFileSnapshot stores a new file snapshot
+func (s *SQLiteStore) CreateFileSnapshot(ctx context.Context, snapshot *FileSnapshot) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO file_snapshots (
+			tool_id, session_id, file_path, content
+		) VALUES (?, ?, ?, ?)
+	`, snapshot.ToolID, snapshot.SessionID, snapshot.FilePath, snapshot.Content)
+	return err
+}
+
+// GetFileSnapshots retrieves all snapshots for a session
+func (s *SQLiteStore) GetFileSnapshots(ctx context.Context, sessionID string) ([]FileSnapshot, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, tool_id, session_id, file_path, content, created_at
+		FROM file_snapshots
+		WHERE session_id = ?
+		ORDER BY created_at DESC
+	`, sessionID)
+	if err != nil {
+		return nil, err
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Build query that orders by the position in the claude session ID list first
-	// This ensures parent events come before child events
-	orderCases := make([]string, len(claudeSessionIDs))
-	for i := range claudeSessionIDs {
-		orderCases[i] = fmt.Sprintf("WHEN claude_session_id = ? THEN %d", i)
-		args = append(args, claudeSessionIDs[i])
+	var snapshots []FileSnapshot
+	for rows.Next() {
+		var s FileSnapshot
+		if err := rows.Scan(&s.ID, &s.ToolID, &s.SessionID, &s.FilePath,
+			&s.Content, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
 	}
+	return snapshots, rows.Err()
+}
 
-	query := fmt.Sprintf(`
-		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
-			role, content,
-			tool_id, tool_name, tool_input_json, parent_tool_use_id,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		FROM conversation_events
-		WHERE claude_session_id IN (%s)
-		ORDER BY
-			CASE %s END,
-			sequence
-	`, strings.Join(placeholders, ","), strings.Join(orderCases, " "))
+// UpsertTurnUsage records the usage of an assistant message. The CLI reports
+// each content block of a message separately, so later reports for the same
+// message replace the earlier ones rather than adding to them.
+func (s *SQLiteStore) UpsertTurnUsage(ctx context.Context, usage *TurnUsage) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO turn_usage (
+			session_id, claude_session_id, message_id, parent_tool_use_id, model,
+			input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, cost_usd
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (session_id, message_id) DO UPDATE SET
+			input_tokens = excluded.input_tokens,
+			output_tokens = excluded.output_tokens,
+			cache_creation_input_tokens = excluded.cache_creation_input_tokens,
+			cache_read_input_tokens = excluded.cache_read_input_tokens,
+			cost_usd = excluded.cost_usd
+	`, usage.SessionID, usage.ClaudeSessionID, usage.MessageID, usage.ParentToolUseID, usage.Model,
+		usage.InputTokens, usage.OutputTokens, usage.CacheCreationInputTokens, usage.CacheReadInputTokens, usage.CostUSD)
+	return err
+}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+// GetTurnUsage retrieves the usage rows of a session in the order the
+// messages were first seen
+func (s *SQLiteStore) GetTurnUsage(ctx context.Context, sessionID string) ([]*TurnUsage, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, session_id, claude_session_id, message_id, parent_tool_use_id, model,
+			input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, cost_usd, created_at
+		FROM turn_usage
+		WHERE session_id = ?
+		ORDER BY id
+	`, sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get conversation: %w", err)
+		return nil, err
 	}
 	defer func() { _ = rows.Close() }()
 
-	var events []*ConversationEvent
+	var turns []*TurnUsage
 	for rows.Next() {
-		event := &ConversationEvent{}
-		err := rows.Scan(
-			&event.ID, &event.SessionID, &event.ClaudeSessionID,
-			&event.Sequence, &event.EventType, &event.CreatedAt,
-			&event.Role, &event.Content,
-			&event.ToolID, &event.ToolName, &event.ToolInputJSON, &event.ParentToolUseID,
-			&event.ToolResultForID, &event.ToolResultContent,
-			&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
+		var u TurnUsage
+		var claudeSessionID, parentToolUseID, model sql.NullString
+		var costUSD sql.NullFloat64
+		if err := rows.Scan(&u.ID, &u.SessionID, &claudeSessionID, &u.MessageID, &parentToolUseID, &model,
+			&u.InputTokens, &u.OutputTokens, &u.CacheCreationInputTokens, &u.CacheReadInputTokens, &costUSD, &u.CreatedAt); err != nil {
+			return nil, err
 		}
-		events = append(events, event)
+		u.ClaudeSessionID = claudeSessionID.String
+		u.ParentToolUseID = parentToolUseID.String
+		u.Model = model.String
+		if costUSD.Valid {
+			u.CostUSD = &costUSD.Float64
+		}
+		turns = append(turns, &u)
 	}
+	return turns, rows.Err()
+}
 
-	return events, nil
+// GetSessionCount returns the total number of sessions
+func (s *SQLiteStore) GetSessionCount(ctx context.Context) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sessions").Scan(&count)
+	return count, err
 }
 
-// GetPendingToolCall finds the most recent uncompleted tool call for a given session and tool name
-func (s *SQLiteStore) GetPendingToolCall(ctx context.Context, sessionID string, toolName string) (*ConversationEvent, error) {
-	// Find the most recent uncompleted tool call by sequence number (temporal proximity)
-	query := `
-		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
-			role, content,
-			tool_id, tool_name, tool_input_json,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		FROM conversation_events
-		WHERE tool_name = ?
-		  AND session_id = ?
-		  AND event_type = 'tool_call'
-		  AND is_completed = FALSE
-		ORDER BY sequence DESC  -- Most recent first
-		LIMIT 1
-	`
+// GetApprovalCount returns the total number of approvals
+func (s *SQLiteStore) GetApprovalCount(ctx context.Context) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM approvals").Scan(&count)
+	return count, err
+}
+
+// GetEventCount returns the total number of conversation events
+func (s *SQLiteStore) GetEventCount(ctx context.Context) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM conversation_events").Scan(&count)
+	return count, err
+}
+
+// folderBudget scans a folder's nullable budget defaults
+type folderBudget struct {
+	maxCostUSD      sql.NullFloat64
+	maxOutputTokens sql.NullInt64
+	maxWallClockMs  sql.NullInt64
+}
+
+func (b folderBudget) apply(folder *Folder) {
+	if b.maxCostUSD.Valid {
+		folder.DefaultMaxCostUSD = &b.maxCostUSD.Float64
+	}
+	if b.maxOutputTokens.Valid {
+		tokens := int(b.maxOutputTokens.Int64)
+		folder.DefaultMaxOutputTokens = &tokens
+	}
+	if b.maxWallClockMs.Valid {
+		folder.DefaultMaxWallClockMs = &b.maxWallClockMs.Int64
+	}
+}
+
+// CreateFolder creates a new folder
+func (s *SQLiteStore) CreateFolder(ctx context.Context, folder *Folder) error {
+	now := time.Now()
+	if folder.CreatedAt.IsZero() {
+		folder.CreatedAt = now
+	}
+	if folder.UpdatedAt.IsZero() {
+		folder.UpdatedAt = now
+	}
 
-	event := &ConversationEvent{}
-	err := s.db.QueryRowContext(ctx, query, toolName, sessionID).Scan(
-		&event.ID, &event.SessionID, &event.ClaudeSessionID,
-		&event.Sequence, &event.EventType, &event.CreatedAt,
-		&event.Role, &event.Content,
-		&event.ToolID, &event.ToolName, &event.ToolInputJSON,
-		&event.ToolResultForID, &event.ToolResultContent,
-		&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID,
+	query := `
+		INSERT INTO folders (id, name, parent_id, position, archived, created_at, updated_at,
+			default_max_cost_usd, default_max_output_tokens, default_max_wall_clock_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := s.db.ExecContext(ctx, query,
+		folder.ID, folder.Name, folder.ParentID, folder.Position, folder.Archived,
+		folder.CreatedAt, folder.UpdatedAt,
+		folder.DefaultMaxCostUSD, folder.DefaultMaxOutputTokens, folder.DefaultMaxWallClockMs,
 	)
-	if err == sql.ErrNoRows {
-		return nil, nil // No pending tool call found
-	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pending tool call: %w", err)
+		return fmt.Errorf("failed to create folder: %w", err)
 	}
-
-	return event, nil
+	return nil
 }
 
-// GetUncorrelatedPendingToolCall finds the most recent uncompleted tool call without approval correlation
-func (s *SQLiteStore) GetUncorrelatedPendingToolCall(ctx context.Context, sessionID string, toolName string) (*ConversationEvent, error) {
-	// Find the most recent uncompleted tool call by sequence number without approval
+// GetFolder retrieves a folder by ID with session count
+func (s *SQLiteStore) GetFolder(ctx context.Context, id string) (*Folder, error) {
 	query := `
-		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
-			role, content,
-			tool_id, tool_name, tool_input_json,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		FROM conversation_events
-		WHERE tool_name = ?
-		  AND session_id = ?
-		  AND event_type = 'tool_call'
-		  AND is_completed = FALSE
-		  AND (approval_status IS NULL OR approval_status = '')
-		ORDER BY sequence DESC  -- Most recent first
-		LIMIT 1
+		SELECT f.id, f.name, f.parent_id, f.position, f.archived, f.created_at, f.updated_at,
+			f.default_max_cost_usd, f.default_max_output_tokens, f.default_max_wall_clock_ms,
+			(SELECT COUNT(*) FROM sessions WHERE folder_id = f.id) as session_count
+		FROM folders f
+		WHERE f.id = ?
 	`
 
-	event := &ConversationEvent{}
-	err := s.db.QueryRowContext(ctx, query, toolName, sessionID).Scan(
-		&event.ID, &event.SessionID, &event.ClaudeSessionID,
-		&event.Sequence, &event.EventType, &event.CreatedAt,
-		&event.Role, &event.Content,
-		&event.ToolID, &event.ToolName, &event.ToolInputJSON,
-		&event.ToolResultForID, &event.ToolResultContent,
-		&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID,
+	var folder Folder
+	var parentID sql.NullString
+	var budget folderBudget
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&folder.ID, &folder.Name, &parentID, &folder.Position, &folder.Archived,
+		&folder.CreatedAt, &folder.UpdatedAt,
+		&budget.maxCostUSD, &budget.maxOutputTokens, &budget.maxWallClockMs, &folder.SessionCount,
 	)
 	if err == sql.ErrNoRows {
-		return nil, nil // No pending tool call found
+		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get uncorrelated pending tool call: %w", err)
+		return nil, fmt.Errorf("failed to get folder: %w", err)
 	}
 
-	return event, nil
+	if parentID.Valid {
+		folder.ParentID = &parentID.String
+	}
+	budget.apply(&folder)
+
+	return &folder, nil
 }
 
-// GetPendingToolCalls finds all uncompleted tool calls for a given session
-func (s *SQLiteStore) GetPendingToolCalls(ctx context.Context, sessionID string) ([]*ConversationEvent, error) {
+// ListFolders retrieves all folders with session counts
+func (s *SQLiteStore) ListFolders(ctx context.Context, includeArchived bool) ([]*Folder, error) {
 	query := `
-		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
-			role, content,
-			tool_id, tool_name, tool_input_json,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		FROM conversation_events
-		WHERE session_id = ?
-		  AND event_type = 'tool_call'
-		  AND is_completed = FALSE
-		ORDER BY sequence DESC
+		SELECT f.id, f.name, f.parent_id, f.position, f.archived, f.created_at, f.updated_at,
+			f.default_max_cost_usd, f.default_max_output_tokens, f.default_max_wall_clock_ms,
+			(SELECT COUNT(*) FROM sessions WHERE folder_id = f.id) as session_count
+		FROM folders f
 	`
+	if !includeArchived {
+		query += " WHERE f.archived = 0"
+	}
+	query += " ORDER BY f.position, f.created_at"
 
-	rows, err := s.db.QueryContext(ctx, query, sessionID)
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pending tool calls: %w", err)
+		return nil, fmt.Errorf("failed to list folders: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var events []*ConversationEvent
+	var folders []*Folder
 	for rows.Next() {
-		event := &ConversationEvent{}
+		var folder Folder
+		var parentID sql.NullString
+		var budget folderBudget
 		err := rows.Scan(
-			&event.ID, &event.SessionID, &event.ClaudeSessionID,
-			&event.Sequence, &event.EventType, &event.CreatedAt,
-			&event.Role, &event.Content,
-			&event.ToolID, &event.ToolName, &event.ToolInputJSON,
-			&event.ToolResultForID, &event.ToolResultContent,
-			&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID,
+			&folder.ID, &folder.Name, &parentID, &folder.Position, &folder.Archived,
+			&folder.CreatedAt, &folder.UpdatedAt,
+			&budget.maxCostUSD, &budget.maxOutputTokens, &budget.maxWallClockMs, &folder.SessionCount,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
+			return nil, fmt.Errorf("failed to scan folder: %w", err)
 		}
-		events = append(events, event)
+		if parentID.Valid {
+			folder.ParentID = &parentID.String
+		}
+		budget.apply(&folder)
+		folders = append(folders, &folder)
 	}
 
-	return events, nil
+	return folders, rows.Err()
 }
 
-// GetToolCallByID retrieves a specific tool call by its ID
-func (s *SQLiteStore) GetToolCallByID(ctx context.Context, toolID string) (*ConversationEvent, error) {
-	query := `
-		SELECT id, session_id, claude_session_id, sequence, event_type, created_at,
-			role, content,
-			tool_id, tool_name, tool_input_json, parent_tool_use_id,
-			tool_result_for_id, tool_result_content,
-			is_completed, approval_status, approval_id
-		FROM conversation_events
-		WHERE tool_id = ?
-		  AND event_type = 'tool_call'
-		LIMIT 1
-	`
+// UpdateFolder updates folder properties
+func (s *SQLiteStore) UpdateFolder(ctx context.Context, id string, updates FolderUpdate) error {
+	setParts := []string{"updated_at = ?"}
+	args := []interface{}{time.Now()}
 
-	event := &ConversationEvent{}
-	err := s.db.QueryRowContext(ctx, query, toolID).Scan(
-		&event.ID, &event.SessionID, &event.ClaudeSessionID,
-		&event.Sequence, &event.EventType, &event.CreatedAt,
-		&event.Role, &event.Content,
-		&event.ToolID, &event.ToolName, &event.ToolInputJSON, &event.ParentToolUseID,
-		&event.ToolResultForID, &event.ToolResultContent,
-		&event.IsCompleted, &event.ApprovalStatus, &event.ApprovalID,
-	)
-	if err == sql.ErrNoRows {
-		return nil, nil // Tool call not found
+	if updates.Name != nil {
+		setParts = append(setParts, "name = ?")
+		args = append(args, *updates.Name)
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tool call by ID: %w", err)
+	if updates.ParentID != nil {
+		setParts = append(setParts, "parent_id = ?")
+		if *updates.ParentID != nil {
+			args = append(args, **updates.ParentID)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if updates.Position != nil {
+		setParts = append(setParts, "position = ?")
+		args = append(args, *updates.Position)
+	}
+	if updates.Archived != nil {
+		setParts = append(setParts, "archived = ?")
+		args = append(args, *updates.Archived)
+	}
+	if updates.DefaultMaxCostUSD != nil {
+		setParts = append(setParts, "default_max_cost_usd = ?")
+		args = append(args, *updates.DefaultMaxCostUSD)
+	}
+	if updates.DefaultMaxOutputTokens != nil {
+		setParts = append(setParts, "default_max_output_tokens = ?")
+		args = append(args, *updates.DefaultMaxOutputTokens)
+	}
+	if updates.DefaultMaxWallClockMs != nil {
+		setParts = append(setParts, "default_max_wall_clock_ms = ?")
+		args = append(args, *updates.DefaultMaxWallClockMs)
 	}
 
-	return event, nil
-}
-
-// MarkToolCallCompleted marks a tool call as completed when its result is received
-func (s *SQLiteStore) MarkToolCallCompleted(ctx context.Context, toolID string, sessionID string) error {
-	query := `
-		UPDATE conversation_events
-		SET is_completed = TRUE
-		WHERE tool_id = ?
-		  AND session_id = ?
-		  AND event_type = 'tool_call'
-	`
+	query := fmt.Sprintf("UPDATE folders SET %s WHERE id = ?", strings.Join(setParts, ", "))
+	args = append(args, id)
 
-	result, err := s.db.ExecContext(ctx, query, toolID, sessionID)
+	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to mark tool call completed: %w", err)
+		return fmt.Errorf("failed to update folder: %w", err)
 	}
 
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		slog.Debug("no matching tool call found to mark completed",
-			"tool_id", toolID,
-			"session_id", sessionID)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
 	}
 
 	return nil
 }
 
-// CorrelateApproval correlates an approval with a tool call
-func (s *SQLiteStore) CorrelateApproval(ctx context.Context, sessionID string, toolName string, approvalID string) error {
-	// Find the pending tool call
-	toolCall, err := s.GetPendingToolCall(ctx, sessionID, toolName)
-	if err != nil {
-		return fmt.Errorf("failed to find pending tool call: %w", err)
+// GetFolderDepth returns the nesting depth of a folder (1 for root folders)
+func (s *SQLiteStore) GetFolderDepth(ctx context.Context, id string) (int, error) {
+	depth := 0
+	currentID := id
+
+	for currentID != "" {
+		var parentID sql.NullString
+		err := s.db.QueryRowContext(ctx,
+			"SELECT parent_id FROM folders WHERE id = ?", currentID,
+		).Scan(&parentID)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get folder parent: %w", err)
+		}
+		depth++
+		if parentID.Valid {
+			currentID = parentID.String
+		} else {
+			break
+		}
 	}
-	if toolCall == nil {
-		slog.Debug("no matching tool call found for approval",
-			"session_id", sessionID,
-			"tool_name", toolName,
-			"approval_id", approvalID)
-		return nil // Not an error - approval might be for a different session
+
+	return depth, nil
+}
+
+// IsDescendant checks if potentialDescendant is a descendant of ancestorID
+// by walking up the parent chain from potentialDescendant
+func (s *SQLiteStore) IsDescendant(ctx context.Context, ancestorID, potentialDescendant string) (bool, error) {
+	if ancestorID == potentialDescendant {
+		return true, nil // A folder is considered its own descendant for this check
 	}
 
-	// Ensure it doesn't already have an approval
-	if toolCall.ApprovalStatus != "" {
-		slog.Warn("tool call already has approval status",
-			"tool_id", toolCall.ToolID,
-			"existing_status", toolCall.ApprovalStatus,
-			"approval_id", approvalID)
-		return nil
+	currentID := potentialDescendant
+	visited := make(map[string]bool) // Cycle detection
+
+	for currentID != "" {
+		if visited[currentID] {
+			// Cycle detected in existing data - treat as not descendant
+			return false, nil
+		}
+		visited[currentID] = true
+
+		var parentID sql.NullString
+		err := s.db.QueryRowContext(ctx,
+			"SELECT parent_id FROM folders WHERE id = ?", currentID,
+		).Scan(&parentID)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get folder parent: %w", err)
+		}
+
+		if !parentID.Valid {
+			return false, nil // Reached root without finding ancestor
+		}
+
+		if parentID.String == ancestorID {
+			return true, nil // Found the ancestor
+		}
+
+		currentID = parentID.String
 	}
 
-	slog.Debug("found tool call to correlate",
-		"event_id", toolCall.ID,
-		"tool_id", toolCall.ToolID,
-		"session_id", sessionID,
-		"tool_name", toolName,
-		"approval_id", approvalID)
+	return false, nil
+}
 
-	// Update the found event
-	updateQuery := `
-		UPDATE conversation_events
-		SET approval_status = 'pending', approval_id = ?
-		WHERE id = ?
+// GetSubtreeMaxDepth returns the maximum depth of children under folderID
+// Returns 0 if folder has no children
+func (s *SQLiteStore) GetSubtreeMaxDepth(ctx context.Context, folderID string) (int, error) {
+	// Use recursive CTE to find all descendants and their depths
+	query := `
+		WITH RECURSIVE descendants AS (
+			SELECT id, parent_id, 1 as depth
+			FROM folders
+			WHERE parent_id = ?
+
+			UNION ALL
+
+			SELECT f.id, f.parent_id, d.depth + 1
+			FROM folders f
+			INNER JOIN descendants d ON f.parent_id = d.id
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM descendants
 	`
 
-	result, err := s.db.ExecContext(ctx, updateQuery, approvalID, toolCall.ID)
+	var maxDepth int
+	err := s.db.QueryRowContext(ctx, query, folderID).Scan(&maxDepth)
 	if err != nil {
-		return fmt.Errorf("failed to correlate approval: %w", err)
+		return 0, fmt.Errorf("failed to get subtree max depth: %w", err)
 	}
 
-	rows, _ := result.RowsAffected()
-	slog.Info("updated tool call with approval",
-		"event_id", toolCall.ID,
-		"rows_affected", rows)
-
-	return nil
+	return maxDepth, nil
 }
 
-// LinkConversationEventToApprovalUsingToolID correlates an approval with a specific tool call by tool_id
-func (s *SQLiteStore) LinkConversationEventToApprovalUsingToolID(ctx context.Context, sessionID string, toolID string, approvalID string) error {
-	// Update the tool call directly by tool_id
-	updateQuery := `
-		UPDATE conversation_events
-		SET approval_status = 'pending', approval_id = ?
-		WHERE session_id = ?
-		  AND tool_id = ?
-		  AND event_type = 'tool_call'
-		  AND is_completed = FALSE
-		  AND (approval_status IS NULL OR approval_status = '')
-	`
+// ArchiveFolderCascade archives a folder and all sessions within it
+func (s *SQLiteStore) ArchiveFolderCascade(ctx context.Context, id string) error {
+	// Start a transaction
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
 
-	result, err := s.db.ExecContext(ctx, updateQuery, approvalID, sessionID, toolID)
+	// Archive the folder
+	_, err = tx.ExecContext(ctx,
+		"UPDATE folders SET archived = 1, updated_at = ? WHERE id = ?",
+		time.Now(), id,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to correlate approval by tool_id: %w", err)
+		return fmt.Errorf("failed to archive folder: %w", err)
 	}
 
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		slog.Debug("no matching tool call found for approval by tool_id",
-			"session_id", sessionID,
-			"tool_id", toolID,
-			"approval_id", approvalID)
-		return nil // Not an error
+	// Archive all sessions in the folder
+	_, err = tx.ExecContext(ctx,
+		"UPDATE sessions SET archived = 1 WHERE folder_id = ?",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive folder sessions: %w", err)
 	}
 
-	slog.Info("correlated approval with tool call by tool_id",
-		"tool_id", toolID,
-		"session_id", sessionID,
-		"approval_id", approvalID,
-		"rows_affected", rows)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
 	return nil
 }
 
-// UpdateApprovalStatus updates the status of an approval
-func (s *SQLiteStore) UpdateApprovalStatus(ctx context.Context, approvalID string, status string) error {
-	// Special handling for resolved status - don't overwrite approved/denied
-	if status == ApprovalStatusResolved {
-		query := `
-			UPDATE conversation_events
-			SET approval_status = ?
-			WHERE approval_id = ? AND approval_status = ?
-		`
-		_, err := s.db.ExecContext(ctx, query, status, approvalID, ApprovalStatusPending)
-		if err != nil {
-			return fmt.Errorf("failed to update approval status: %w", err)
-		}
-		return nil
+// CreateSchedule creates a new schedule
+func (s *SQLiteStore) CreateSchedule(ctx context.Context, schedule *Schedule) error {
+	now := time.Now()
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = now
+	}
+	if schedule.UpdatedAt.IsZero() {
+		schedule.UpdatedAt = now
 	}
 
-	// For approved/denied, always update
 	query := `
-		UPDATE conversation_events
-		SET approval_status = ?
-		WHERE approval_id = ?
+		INSERT INTO schedules (
+			id, name, cron_expr, run_at, catch_up, enabled, launch_config,
+			next_run_at, last_run_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-
-	_, err := s.db.ExecContext(ctx, query, status, approvalID)
+	_, err := s.db.ExecContext(ctx, query,
+		schedule.ID, schedule.Name, sql.NullString{String: schedule.CronExpr, Valid: schedule.CronExpr != ""},
+		schedule.RunAt, schedule.CatchUp, schedule.Enabled, schedule.LaunchConfig,
+		schedule.NextRunAt, schedule.LastRunAt, schedule.CreatedAt, schedule.UpdatedAt,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to update approval status: %w", err)
+		return fmt.Errorf("failed to create schedule: %w", err)
 	}
 	return nil
 }
 
-// StoreMCPServers stores MCP server configurations
-func (s *SQLiteStore) StoreMCPServers(ctx context.Context, sessionID string, servers []MCPServer) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+const scheduleColumns = `id, name, cron_expr, run_at, catch_up, enabled, launch_config,
+	next_run_at, last_run_at, created_at, updated_at`
+
+// scanSchedule scans a row selected with scheduleColumns
+func scanSchedule(row interface{ Scan(...interface{}) error }) (*Schedule, error) {
+	var schedule Schedule
+	var cronExpr sql.NullString
+	var runAt, nextRunAt, lastRunAt sql.NullTime
+	err := row.Scan(
+		&schedule.ID, &schedule.Name, &cronExpr, &runAt, &schedule.CatchUp, &schedule.Enabled,
+		&schedule.LaunchConfig, &nextRunAt, &lastRunAt, &schedule.CreatedAt, &schedule.UpdatedAt,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, err
 	}
-	defer func() { _ = tx.Rollback() }()
-
-	query := `
-		INSERT INTO mcp_servers (session_id, name, command, args_json, env_json)
-		VALUES (?, ?, ?, ?, ?)
-	`
-
-	for _, server := range servers {
-		_, err := tx.ExecContext(ctx, query,
-			sessionID, server.Name, server.Command, server.ArgsJSON, server.EnvJSON)
-		if err != nil {
-			return fmt.Errorf("failed to insert MCP server: %w", err)
-		}
+	schedule.CronExpr = cronExpr.String
+	if runAt.Valid {
+		schedule.RunAt = &runAt.Time
 	}
-
-	return tx.Commit()
+	if nextRunAt.Valid {
+		schedule.NextRunAt = &nextRunAt.Time
+	}
+	if lastRunAt.Valid {
+		schedule.LastRunAt = &lastRunAt.Time
+	}
+	return &schedule, nil
 }
 
-// GetMCPServers retrieves MCP servers for a session
-func (s *SQLiteStore) GetMCPServers(ctx context.Context, sessionID string) ([]MCPServer, error) {
-	query := `
-		SELECT id, session_id, name, command, args_json, env_json
-		FROM mcp_servers
-		WHERE session_id = ?
-		ORDER BY id
-	`
+// GetSchedule retrieves a schedule by ID
+func (s *SQLiteStore) GetSchedule(ctx context.Context, id string) (*Schedule, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+scheduleColumns+" FROM schedules WHERE id = ?", id)
+	schedule, err := scanSchedule(row)
+	if err == sql.ErrNoRows {
+		return nil, &NotFoundError{Type: "schedule", ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	return schedule, nil
+}
 
-	rows, err := s.db.QueryContext(ctx, query, sessionID)
+// ListSchedules retrieves all schedules, oldest first
+func (s *SQLiteStore) ListSchedules(ctx context.Context) ([]*Schedule, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+scheduleColumns+" FROM schedules ORDER BY created_at, id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get MCP servers: %w", err)
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var servers []MCPServer
+	var schedules []*Schedule
 	for rows.Next() {
-		var server MCPServer
-		err := rows.Scan(
-			&server.ID, &server.SessionID, &server.Name,
-			&server.Command, &server.ArgsJSON, &server.EnvJSON,
-		)
+		schedule, err := scanSchedule(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan MCP server: %w", err)
+			return nil, fmt.Errorf("failed to scan schedule: %w", err)
 		}
-		servers = append(servers, server)
+		schedules = append(schedules, schedule)
 	}
-
-	return servers, nil
+	return schedules, rows.Err()
 }
 
-// StoreRawEvent stores a raw event for debugging
-func (s *SQLiteStore) StoreRawEvent(ctx context.Context, sessionID string, eventJSON string) error {
-	query := `
-		INSERT INTO raw_events (session_id, event_json)
-		VALUES (?, ?)
-	`
+// UpdateSchedule updates schedule properties
+func (s *SQLiteStore) UpdateSchedule(ctx context.Context, id string, updates ScheduleUpdate) error {
+	setParts := []string{"updated_at = ?"}
+	args := []interface{}{time.Now()}
 
-	_, err := s.db.ExecContext(ctx, query, sessionID, eventJSON)
-	if err != nil {
-		return fmt.Errorf("failed to store raw event: %w", err)
+	if updates.Name != nil {
+		setParts = append(setParts, "name = ?")
+		args = append(args, *updates.Name)
 	}
-	return nil
-}
-
-// CreateApproval creates a new approval
-func (s *SQLiteStore) CreateApproval(ctx context.Context, approval *Approval) error {
-	// Validate status
-	if !approval.Status.IsValid() {
-		return fmt.Errorf("invalid approval status: %s", approval.Status)
+	if updates.CronExpr != nil {
+		setParts = append(setParts, "cron_expr = ?")
+		args = append(args, sql.NullString{String: *updates.CronExpr, Valid: *updates.CronExpr != ""})
+	}
+	if updates.RunAt != nil {
+		setParts = append(setParts, "run_at = ?")
+		args = append(args, *updates.RunAt)
+	}
+	if updates.CatchUp != nil {
+		setParts = append(setParts, "catch_up = ?")
+		args = append(args, *updates.CatchUp)
+	}
+	if updates.Enabled != nil {
+		setParts = append(setParts, "enabled = ?")
+		args = append(args, *updates.Enabled)
+	}
+	if updates.LaunchConfig != nil {
+		setParts = append(setParts, "launch_config = ?")
+		args = append(args, *updates.LaunchConfig)
+	}
+	if updates.NextRunAt != nil {
+		setParts = append(setParts, "next_run_at = ?")
+		args = append(args, *updates.NextRunAt)
+	}
+	if updates.LastRunAt != nil {
+		setParts = append(setParts, "last_run_at = ?")
+		args = append(args, *updates.LastRunAt)
 	}
 
-	query := `
-		INSERT INTO approvals (
-			id, run_id, session_id, tool_use_id, status, created_at,
-			tool_name, tool_input, comment
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	query := fmt.Sprintf("UPDATE schedules SET %s WHERE id = ?", strings.Join(setParts, ", "))
+	args = append(args, id)
 
-	_, err := s.db.ExecContext(ctx, query,
-		approval.ID, approval.RunID, approval.SessionID, approval.ToolUseID, approval.Status.String(), approval.CreatedAt,
-		approval.ToolName, string(approval.ToolInput), approval.Comment,
-	)
+	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to create approval: %w", err)
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "schedule", ID: id}
 	}
 	return nil
 }
 
-// GetApproval retrieves an approval by ID
-func (s *SQLiteStore) GetApproval(ctx context.Context, id string) (*Approval, error) {
-	query := `
-		SELECT id, run_id, session_id, tool_use_id, status, created_at, responded_at,
-			tool_name, tool_input, comment
-		FROM approvals WHERE id = ?
-	`
-
-	var approval Approval
-	var toolUseID sql.NullString
-	var respondedAt sql.NullTime
-	var comment sql.NullString
-	var statusStr string
-	var toolInputStr string
-
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&approval.ID, &approval.RunID, &approval.SessionID, &toolUseID, &statusStr,
-		&approval.CreatedAt, &respondedAt,
-		&approval.ToolName, &toolInputStr, &comment,
-	)
-	if err == sql.ErrNoRows {
-		return nil, &NotFoundError{Type: "approval", ID: id}
+// DeleteSchedule deletes a schedule and its run history. Sessions it launched
+// are kept.
+func (s *SQLiteStore) DeleteSchedule(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM schedules WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
 	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get approval: %w", err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
-
-	// Convert status string to ApprovalStatus
-	approval.Status = ApprovalStatus(statusStr)
-	if !approval.Status.IsValid() {
-		return nil, fmt.Errorf("invalid approval status in database: %s", statusStr)
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "schedule", ID: id}
 	}
+	return nil
+}
 
-	// Handle nullable fields
-	if toolUseID.Valid {
-		approval.ToolUseID = &toolUseID.String
+// CreateScheduleRun records a run of a schedule
+func (s *SQLiteStore) CreateScheduleRun(ctx context.Context, run *ScheduleRun) error {
+	if run.TriggeredAt.IsZero() {
+		run.TriggeredAt = time.Now()
+	}
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO schedule_runs (schedule_id, session_id, scheduled_for, triggered_at, status, error)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, run.ScheduleID, sql.NullString{String: run.SessionID, Valid: run.SessionID != ""},
+		run.ScheduledFor, run.TriggeredAt, run.Status,
+		sql.NullString{String: run.Error, Valid: run.Error != ""})
+	if err != nil {
+		return fmt.Errorf("failed to create schedule run: %w", err)
 	}
-	if respondedAt.Valid {
-		approval.RespondedAt = &respondedAt.Time
+	run.ID, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get schedule run ID: %w", err)
 	}
-	approval.Comment = comment.String
-	approval.ToolInput = json.RawMessage(toolInputStr)
-
-	return &approval, nil
+	return nil
 }
 
-// GetPendingApprovals retrieves all pending approvals for a session
-func (s *SQLiteStore) GetPendingApprovals(ctx context.Context, sessionID string) ([]*Approval, error) {
+// ListScheduleRuns returns the latest runs of a schedule, newest first. A
+// limit of zero returns every run.
+func (s *SQLiteStore) ListScheduleRuns(ctx context.Context, scheduleID string, limit int) ([]*ScheduleRun, error) {
 	query := `
-		SELECT id, run_id, session_id, tool_use_id, status, created_at, responded_at,
-			tool_name, tool_input, comment
-		FROM approvals
-		WHERE session_id = ? AND status = ?
-		ORDER BY created_at ASC
+		SELECT id, schedule_id, session_id, scheduled_for, triggered_at, status, error
+		FROM schedule_runs
+		WHERE schedule_id = ?
+		ORDER BY id DESC
 	`
-
-	rows, err := s.db.QueryContext(ctx, query, sessionID, ApprovalStatusLocalPending.String())
+	args := []interface{}{scheduleID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pending approvals: %w", err)
+		return nil, fmt.Errorf("failed to list schedule runs: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var approvals []*Approval
+	var runs []*ScheduleRun
 	for rows.Next() {
-		var approval Approval
-		var toolUseID sql.NullString
-		var respondedAt sql.NullTime
-		var comment sql.NullString
-		var statusStr string
-		var toolInputStr string
-
-		err := rows.Scan(
-			&approval.ID, &approval.RunID, &approval.SessionID, &toolUseID, &statusStr,
-			&approval.CreatedAt, &respondedAt,
-			&approval.ToolName, &toolInputStr, &comment,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan approval: %w", err)
-		}
-
-		// Convert status string to ApprovalStatus
-		approval.Status = ApprovalStatus(statusStr)
-		if !approval.Status.IsValid() {
-			return nil, fmt.Errorf("invalid approval status in database: %s", statusStr)
+		var run ScheduleRun
+		var sessionID, runError sql.NullString
+		if err := rows.Scan(&run.ID, &run.ScheduleID, &sessionID, &run.ScheduledFor,
+			&run.TriggeredAt, &run.Status, &runError); err != nil {
+			return nil, fmt.Errorf("failed to scan schedule run: %w", err)
 		}
+		run.SessionID = sessionID.String
+		run.Error = runError.String
+		runs = append(runs, &run)
+	}
+	return runs, rows.Err()
+}
 
-		// Handle nullable fields
-		if toolUseID.Valid {
-			approval.ToolUseID = &toolUseID.String
-		}
-		if respondedAt.Valid {
-			approval.RespondedAt = &respondedAt.Time
-		}
-		approval.Comment = comment.String
-		approval.ToolInput = json.RawMessage(toolInputStr)
+const sessionTemplateColumns = `id, name, description, query_template, working_dir, model,
+	permission_mode, system_prompt, append_system_prompt, allowed_tools, disallowed_tools,
+	additional_directories, mcp_config, proxy_enabled, proxy_base_url, proxy_model_override,
+	proxy_api_key, auto_accept_edits, dangerously_skip_permissions, created_at, updated_at`
 
-		approvals = append(approvals, &approval)
+// CreateSessionTemplate creates a new session template
+func (s *SQLiteStore) CreateSessionTemplate(ctx context.Context, template *SessionTemplate) error {
+	now := time.Now()
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = now
+	}
+	if template.UpdatedAt.IsZero() {
+		template.UpdatedAt = now
 	}
 
-	return approvals, nil
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO session_templates (`+sessionTemplateColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, template.ID, template.Name, template.Description, template.QueryTemplate, template.WorkingDir,
+		template.Model, template.PermissionMode, template.SystemPrompt, template.AppendSystemPrompt,
+		template.AllowedTools, template.DisallowedTools, template.AdditionalDirectories, template.MCPConfig,
+		template.ProxyEnabled, template.ProxyBaseURL, template.ProxyModelOverride, template.ProxyAPIKey,
+		template.AutoAcceptEdits, template.DangerouslySkipPermissions, template.CreatedAt, template.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create session template: %w", err)
+	}
+	return nil
 }
 
-// UpdateApprovalResponse updates the status and comment of an approval
-func (s *SQLiteStore) UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, comment string) error {
-	// Validate status
-	if !status.IsValid() {
-		return fmt.Errorf("invalid approval status: %s", status)
+// scanSessionTemplate scans a row selected with sessionTemplateColumns
+func scanSessionTemplate(row interface{ Scan(...interface{}) error }) (*SessionTemplate, error) {
+	var template SessionTemplate
+	var description, workingDir, model, permissionMode, systemPrompt, appendSystemPrompt sql.NullString
+	var allowedTools, disallowedTools, additionalDirectories, mcpConfig sql.NullString
+	var proxyBaseURL, proxyModelOverride, proxyAPIKey sql.NullString
+	err := row.Scan(
+		&template.ID, &template.Name, &description, &template.QueryTemplate, &workingDir, &model,
+		&permissionMode, &systemPrompt, &appendSystemPrompt, &allowedTools, &disallowedTools,
+		&additionalDirectories, &mcpConfig, &template.ProxyEnabled, &proxyBaseURL, &proxyModelOverride,
+		&proxyAPIKey, &template.AutoAcceptEdits, &template.DangerouslySkipPermissions,
+		&template.CreatedAt, &template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
 	}
+	template.Description = description.String
+	template.WorkingDir = workingDir.String
+	template.Model = model.String
+	template.PermissionMode = permissionMode.String
+	template.SystemPrompt = systemPrompt.String
+	template.AppendSystemPrompt = appendSystemPrompt.String
+	template.AllowedTools = allowedTools.String
+	template.DisallowedTools = disallowedTools.String
+	template.AdditionalDirectories = additionalDirectories.String
+	template.MCPConfig = mcpConfig.String
+	template.ProxyBaseURL = proxyBaseURL.String
+	template.ProxyModelOverride = proxyModelOverride.String
+	template.ProxyAPIKey = proxyAPIKey.String
+	return &template, nil
+}
 
-	// Check current status first
-	approval, err := s.GetApproval(ctx, id)
+// GetSessionTemplate retrieves a session template by ID
+func (s *SQLiteStore) GetSessionTemplate(ctx context.Context, id string) (*SessionTemplate, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+sessionTemplateColumns+" FROM session_templates WHERE id = ?", id)
+	template, err := scanSessionTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, &NotFoundError{Type: "session template", ID: id}
+	}
 	if err != nil {
-		return err // This already returns proper error types
+		return nil, fmt.Errorf("failed to get session template: %w", err)
 	}
+	return template, nil
+}
 
-	// Check if already decided
-	if approval.Status != ApprovalStatusLocalPending {
-		return &AlreadyDecidedError{ID: id, Status: approval.Status.String()}
+// ListSessionTemplates retrieves all session templates by name
+func (s *SQLiteStore) ListSessionTemplates(ctx context.Context) ([]*SessionTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+sessionTemplateColumns+" FROM session_templates ORDER BY name COLLATE NOCASE, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session templates: %w", err)
 	}
+	defer func() { _ = rows.Close() }()
 
-	query := `
-		UPDATE approvals
-		SET status = ?, comment = ?, responded_at = CURRENT_TIMESTAMP
-		WHERE id = ? AND status = ?
-	`
+	var templates []*SessionTemplate
+	for rows.Next() {
+		template, err := scanSessionTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
 
-	result, err := s.db.ExecContext(ctx, query, status.String(), comment, id, ApprovalStatusLocalPending.String())
+// UpdateSessionTemplate replaces every field of a session template but its
+// creation time
+func (s *SQLiteStore) UpdateSessionTemplate(ctx context.Context, template *SessionTemplate) error {
+	template.UpdatedAt = time.Now()
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE session_templates SET
+			name = ?, description = ?, query_template = ?, working_dir = ?, model = ?,
+			permission_mode = ?, system_prompt = ?, append_system_prompt = ?, allowed_tools = ?,
+			disallowed_tools = ?, additional_directories = ?, mcp_config = ?, proxy_enabled = ?,
+			proxy_base_url = ?, proxy_model_override = ?, proxy_api_key = ?, auto_accept_edits = ?,
+			dangerously_skip_permissions = ?, updated_at = ?
+		WHERE id = ?
+	`, template.Name, template.Description, template.QueryTemplate, template.WorkingDir, template.Model,
+		template.PermissionMode, template.SystemPrompt, template.AppendSystemPrompt, template.AllowedTools,
+		template.DisallowedTools, template.AdditionalDirectories, template.MCPConfig, template.ProxyEnabled,
+		template.ProxyBaseURL, template.ProxyModelOverride, template.ProxyAPIKey, template.AutoAcceptEdits,
+		template.DangerouslySkipPermissions, template.UpdatedAt, template.ID,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to update approval response: %w", err)
+		return fmt.Errorf("failed to update session template: %w", err)
 	}
-
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
-
 	if rowsAffected == 0 {
-		// This shouldn't happen since we checked above, but just in case
-		return &NotFoundError{Type: "approval", ID: id}
+		return &NotFoundError{Type: "session template", ID: template.ID}
 	}
-
 	return nil
 }
 
-// Helper function to convert MCP config to store format
-func MCPServersFromConfig(sessionID string, config map[string]claudecode.MCPServer) ([]MCPServer, error) {
-	// First, collect all server names and sort them for deterministic ordering
-	names := make([]string, 0, len(config))
-	for name := range config {
-		names = append(names, name)
+// DeleteSessionTemplate deletes a session template
+func (s *SQLiteStore) DeleteSessionTemplate(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM session_templates WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete session template: %w", err)
 	}
-	// Sort names to ensure consistent ordering
-	sort.Strings(names)
-
-	servers := make([]MCPServer, 0, len(config))
-	for _, name := range names {
-		server := config[name]
-
-		// For HTTP servers, store the configuration differently
-		// We'll use Command field to store the type, ArgsJSON for URL, and EnvJSON for headers
-		var command string
-		var argsJSON string
-		var envJSON string
-
-		if server.Type == "http" {
-			// HTTP server
-			command = "http"                             // Use "http" as the command to indicate HTTP type
-			argsJSON = fmt.Sprintf(`["%s"]`, server.URL) // Store URL as single-element array
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "session template", ID: id}
+	}
+	return nil
+}
 
-			// Store headers in EnvJSON
-			headersData, err := json.Marshal(server.Headers)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal headers: %w", err)
-			}
-			envJSON = string(headersData)
-		} else {
-			// Traditional stdio server
-			command = server.Command
+// CreatePipeline creates a new pipeline
+func (s *SQLiteStore) CreatePipeline(ctx context.Context, pipeline *Pipeline) error {
+	now := time.Now()
+	if pipeline.CreatedAt.IsZero() {
+		pipeline.CreatedAt = now
+	}
+	if pipeline.UpdatedAt.IsZero() {
+		pipeline.UpdatedAt = now
+	}
 
-			argsData, err := json.Marshal(server.Args)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal args: %w", err)
-			}
-			argsJSON = string(argsData)
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO pipelines (id, name, description, steps, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, pipeline.ID, pipeline.Name, pipeline.Description, pipeline.Steps, pipeline.CreatedAt, pipeline.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create pipeline: %w", err)
+	}
+	return nil
+}
 
-			envData, err := json.Marshal(server.Env)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal env: %w", err)
-			}
-			envJSON = string(envData)
-		}
+const pipelineColumns = `id, name, description, steps, created_at, updated_at`
 
-		servers = append(servers, MCPServer{
-			SessionID: sessionID,
-			Name:      name,
-			Command:   command,
-			ArgsJSON:  argsJSON,
-			EnvJSON:   envJSON,
-		})
+// scanPipeline scans a row selected with pipelineColumns
+func scanPipeline(row interface{ Scan(...interface{}) error }) (*Pipeline, error) {
+	var pipeline Pipeline
+	var description sql.NullString
+	err := row.Scan(&pipeline.ID, &pipeline.Name, &description, &pipeline.Steps,
+		&pipeline.CreatedAt, &pipeline.UpdatedAt)
+	if err != nil {
+		return nil, err
 	}
-	return servers, nil
+	pipeline.Description = description.String
+	return &pipeline, nil
 }
 
-// CreateFileSnapshot stores a new file snapshot
-func (s *SQLiteStore) CreateFileSnapshot(ctx context.Context, snapshot *FileSnapshot) error {
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO file_snapshots (
-			tool_id, session_id, file_path, content
-		) VALUES (?, ?, ?, ?)
-	`, snapshot.ToolID, snapshot.SessionID, snapshot.FilePath, snapshot.Content)
-	return err
+// GetPipeline retrieves a pipeline by ID
+func (s *SQLiteStore) GetPipeline(ctx context.Context, id string) (*Pipeline, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+pipelineColumns+" FROM pipelines WHERE id = ?", id)
+	pipeline, err := scanPipeline(row)
+	if err == sql.ErrNoRows {
+		return nil, &NotFoundError{Type: "pipeline", ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+	return pipeline, nil
 }
 
-// GetFileSnapshots retrieves all snapshots for a session
-func (s *SQLiteStore) GetFileSnapshots(ctx context.Context, sessionID string) ([]FileSnapshot, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, tool_id, session_id, file_path, content, created_at
-		FROM file_snapshots
-		WHERE session_id = ?
-		ORDER BY created_at DESC
-	`, sessionID)
+// ListPipelines retrieves all pipelines by name
+func (s *SQLiteStore) ListPipelines(ctx context.Context) ([]*Pipeline, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+pipelineColumns+" FROM pipelines ORDER BY name COLLATE NOCASE, id")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var snapshots []FileSnapshot
+	var pipelines []*Pipeline
 	for rows.Next() {
-		var s FileSnapshot
-		if err := rows.Scan(&s.ID, &s.ToolID, &s.SessionID, &s.FilePath,
-			&s.Content, &s.CreatedAt); err != nil {
-			return nil, err
+		pipeline, err := scanPipeline(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pipeline: %w", err)
 		}
-		snapshots = append(snapshots, s)
+		pipelines = append(pipelines, pipeline)
 	}
-	return snapshots, rows.Err()
+	return pipelines, rows.Err()
 }
 
-// GetSessionCount returns the total number of sessions
-func (s *SQLiteStore) GetSessionCount(ctx context.Context) (int, error) {
-	var count int
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sessions").Scan(&count)
-	return count, err
+// UpdatePipeline replaces the name, description and steps of a pipeline.
+// Existing runs keep the steps they started with.
+func (s *SQLiteStore) UpdatePipeline(ctx context.Context, pipeline *Pipeline) error {
+	pipeline.UpdatedAt = time.Now()
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE pipelines SET name = ?, description = ?, steps = ?, updated_at = ?
+		WHERE id = ?
+	`, pipeline.Name, pipeline.Description, pipeline.Steps, pipeline.UpdatedAt, pipeline.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update pipeline: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "pipeline", ID: pipeline.ID}
+	}
+	return nil
 }
 
-// GetApprovalCount returns the total number of approvals
-func (s *SQLiteStore) GetApprovalCount(ctx context.Context) (int, error) {
-	var count int
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM approvals").Scan(&count)
-	return count, err
+// DeletePipeline deletes a pipeline and its runs. Sessions the runs launched
+// are kept.
+func (s *SQLiteStore) DeletePipeline(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM pipelines WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete pipeline: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "pipeline", ID: id}
+	}
+	return nil
 }
 
-// GetEventCount returns the total number of conversation events
-func (s *SQLiteStore) GetEventCount(ctx context.Context) (int, error) {
-	var count int
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM conversation_events").Scan(&count)
-	return count, err
+// CreatePipelineRun creates a pipeline run together with its steps
+func (s *SQLiteStore) CreatePipelineRun(ctx context.Context, run *PipelineRun) error {
+	now := time.Now()
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = now
+	}
+	if run.UpdatedAt.IsZero() {
+		run.UpdatedAt = now
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO pipeline_runs (
+			id, pipeline_id, pipeline_name, definition, working_dir, variables,
+			status, error, created_at, updated_at, completed_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, run.ID, run.PipelineID, run.PipelineName, run.Definition, run.WorkingDir, run.Variables,
+		run.Status, sql.NullString{String: run.Error, Valid: run.Error != ""},
+		run.CreatedAt, run.UpdatedAt, run.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create pipeline run: %w", err)
+	}
+
+	for _, step := range run.Steps {
+		step.RunID = run.ID
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO pipeline_run_steps (
+				run_id, name, position, status, session_id, attempts, error, started_at, completed_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, step.RunID, step.Name, step.Position, step.Status,
+			sql.NullString{String: step.SessionID, Valid: step.SessionID != ""}, step.Attempts,
+			sql.NullString{String: step.Error, Valid: step.Error != ""}, step.StartedAt, step.CompletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create pipeline step %s: %w", step.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pipeline run: %w", err)
+	}
+	return nil
 }
 
-// CreateFolder creates a new folder
-func (s *SQLiteStore) CreateFolder(ctx context.Context, folder *Folder) error {
-	now := time.Now()
-	if folder.CreatedAt.IsZero() {
-		folder.CreatedAt = now
+const pipelineRunColumns = `id, pipeline_id, pipeline_name, definition, working_dir, variables,
+	status, error, created_at, updated_at, completed_at`
+
+// scanPipelineRun scans a row selected with pipelineRunColumns
+func scanPipelineRun(row interface{ Scan(...interface{}) error }) (*PipelineRun, error) {
+	var run PipelineRun
+	var workingDir, variables, runError sql.NullString
+	var completedAt sql.NullTime
+	err := row.Scan(&run.ID, &run.PipelineID, &run.PipelineName, &run.Definition, &workingDir,
+		&variables, &run.Status, &runError, &run.CreatedAt, &run.UpdatedAt, &completedAt)
+	if err != nil {
+		return nil, err
 	}
-	if folder.UpdatedAt.IsZero() {
-		folder.UpdatedAt = now
+	run.WorkingDir = workingDir.String
+	run.Variables = variables.String
+	run.Error = runError.String
+	if completedAt.Valid {
+		run.CompletedAt = &completedAt.Time
 	}
+	return &run, nil
+}
 
-	query := `
-		INSERT INTO folders (id, name, parent_id, position, archived, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`
-	_, err := s.db.ExecContext(ctx, query,
-		folder.ID, folder.Name, folder.ParentID, folder.Position, folder.Archived,
-		folder.CreatedAt, folder.UpdatedAt,
-	)
+const pipelineStepRunColumns = `run_id, name, position, status, session_id, attempts, error,
+	started_at, completed_at`
+
+// scanPipelineStepRun scans a row selected with pipelineStepRunColumns
+func scanPipelineStepRun(row interface{ Scan(...interface{}) error }) (*PipelineStepRun, error) {
+	var step PipelineStepRun
+	var sessionID, stepError sql.NullString
+	var startedAt, completedAt sql.NullTime
+	err := row.Scan(&step.RunID, &step.Name, &step.Position, &step.Status, &sessionID,
+		&step.Attempts, &stepError, &startedAt, &completedAt)
 	if err != nil {
-		return fmt.Errorf("failed to create folder: %w", err)
+		return nil, err
 	}
-	return nil
+	step.SessionID = sessionID.String
+	step.Error = stepError.String
+	if startedAt.Valid {
+		step.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		step.CompletedAt = &completedAt.Time
+	}
+	return &step, nil
 }
 
-// GetFolder retrieves a folder by ID with session count
-func (s *SQLiteStore) GetFolder(ctx context.Context, id string) (*Folder, error) {
-	query := `
-		SELECT f.id, f.name, f.parent_id, f.position, f.archived, f.created_at, f.updated_at,
-			(SELECT COUNT(*) FROM sessions WHERE folder_id = f.id) as session_count
-		FROM folders f
-		WHERE f.id = ?
-	`
+// loadPipelineSteps fills in the steps of a pipeline run
+func (s *SQLiteStore) loadPipelineSteps(ctx context.Context, run *PipelineRun) error {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+pipelineStepRunColumns+" FROM pipeline_run_steps WHERE run_id = ? ORDER BY position", run.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline steps: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
 
-	var folder Folder
-	var parentID sql.NullString
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&folder.ID, &folder.Name, &parentID, &folder.Position, &folder.Archived,
-		&folder.CreatedAt, &folder.UpdatedAt, &folder.SessionCount,
-	)
+	run.Steps = nil
+	for rows.Next() {
+		step, err := scanPipelineStepRun(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan pipeline step: %w", err)
+		}
+		run.Steps = append(run.Steps, step)
+	}
+	return rows.Err()
+}
+
+// GetPipelineRun retrieves a pipeline run and its steps by ID
+func (s *SQLiteStore) GetPipelineRun(ctx context.Context, id string) (*PipelineRun, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+pipelineRunColumns+" FROM pipeline_runs WHERE id = ?", id)
+	run, err := scanPipelineRun(row)
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, &NotFoundError{Type: "pipeline run", ID: id}
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get folder: %w", err)
+		return nil, fmt.Errorf("failed to get pipeline run: %w", err)
 	}
-
-	if parentID.Valid {
-		folder.ParentID = &parentID.String
+	if err := s.loadPipelineSteps(ctx, run); err != nil {
+		return nil, err
 	}
-
-	return &folder, nil
+	return run, nil
 }
 
-// ListFolders retrieves all folders with session counts
-func (s *SQLiteStore) ListFolders(ctx context.Context, includeArchived bool) ([]*Folder, error) {
-	query := `
-		SELECT f.id, f.name, f.parent_id, f.position, f.archived, f.created_at, f.updated_at,
-			(SELECT COUNT(*) FROM sessions WHERE folder_id = f.id) as session_count
-		FROM folders f
-	`
-	if !includeArchived {
-		query += " WHERE f.archived = 0"
+// ListPipelineRuns retrieves pipeline runs and their steps, newest first. An
+// empty pipelineID or status matches every run.
+func (s *SQLiteStore) ListPipelineRuns(ctx context.Context, pipelineID, status string) ([]*PipelineRun, error) {
+	query := "SELECT " + pipelineRunColumns + " FROM pipeline_runs WHERE 1=1"
+	var args []interface{}
+	if pipelineID != "" {
+		query += " AND pipeline_id = ?"
+		args = append(args, pipelineID)
 	}
-	query += " ORDER BY f.position, f.created_at"
+	if status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY created_at DESC, id"
 
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list folders: %w", err)
+		return nil, fmt.Errorf("failed to list pipeline runs: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
-
-	var folders []*Folder
+	var runs []*PipelineRun
 	for rows.Next() {
-		var folder Folder
-		var parentID sql.NullString
-		err := rows.Scan(
-			&folder.ID, &folder.Name, &parentID, &folder.Position, &folder.Archived,
-			&folder.CreatedAt, &folder.UpdatedAt, &folder.SessionCount,
-		)
+		run, err := scanPipelineRun(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan folder: %w", err)
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan pipeline run: %w", err)
 		}
-		if parentID.Valid {
-			folder.ParentID = &parentID.String
-		}
-		folders = append(folders, &folder)
+		runs = append(runs, run)
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline runs: %w", err)
 	}
 
-	return folders, rows.Err()
+	for _, run := range runs {
+		if err := s.loadPipelineSteps(ctx, run); err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
 }
 
-// UpdateFolder updates folder properties
-func (s *SQLiteStore) UpdateFolder(ctx context.Context, id string, updates FolderUpdate) error {
+// UpdatePipelineRun updates pipeline run properties
+func (s *SQLiteStore) UpdatePipelineRun(ctx context.Context, id string, updates PipelineRunUpdate) error {
 	setParts := []string{"updated_at = ?"}
 	args := []interface{}{time.Now()}
 
-	if updates.Name != nil {
-		setParts = append(setParts, "name = ?")
-		args = append(args, *updates.Name)
-	}
-	if updates.ParentID != nil {
-		setParts = append(setParts, "parent_id = ?")
-		if *updates.ParentID != nil {
-			args = append(args, **updates.ParentID)
-		} else {
-			args = append(args, nil)
-		}
+	if updates.Status != nil {
+		setParts = append(setParts, "status = ?")
+		args = append(args, *updates.Status)
 	}
-	if updates.Position != nil {
-		setParts = append(setParts, "position = ?")
-		args = append(args, *updates.Position)
+	if updates.Error != nil {
+		setParts = append(setParts, "error = ?")
+		args = append(args, sql.NullString{String: *updates.Error, Valid: *updates.Error != ""})
 	}
-	if updates.Archived != nil {
-		setParts = append(setParts, "archived = ?")
-		args = append(args, *updates.Archived)
+	if updates.CompletedAt != nil {
+		setParts = append(setParts, "completed_at = ?")
+		args = append(args, *updates.CompletedAt)
 	}
 
-	query := fmt.Sprintf("UPDATE folders SET %s WHERE id = ?", strings.Join(setParts, ", "))
+	query := fmt.Sprintf("UPDATE pipeline_runs SET %s WHERE id = ?", strings.Join(setParts, ", "))
 	args = append(args, id)
 
 	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to update folder: %w", err)
+		return fmt.Errorf("failed to update pipeline run: %w", err)
 	}
-
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return &NotFoundError{Type: "pipeline run", ID: id}
 	}
-
 	return nil
 }
 
-// GetFolderDepth returns the nesting depth of a folder (1 for root folders)
-func (s *SQLiteStore) GetFolderDepth(ctx context.Context, id string) (int, error) {
-	depth := 0
-	currentID := id
+// UpdatePipelineStepRun updates the state of a step in a pipeline run
+func (s *SQLiteStore) UpdatePipelineStepRun(ctx context.Context, runID, name string, updates PipelineStepRunUpdate) error {
+	var setParts []string
+	var args []interface{}
 
-	for currentID != "" {
-		var parentID sql.NullString
-		err := s.db.QueryRowContext(ctx,
-			"SELECT parent_id FROM folders WHERE id = ?", currentID,
-		).Scan(&parentID)
-		if err == sql.ErrNoRows {
-			break
-		}
-		if err != nil {
-			return 0, fmt.Errorf("failed to get folder parent: %w", err)
-		}
-		depth++
-		if parentID.Valid {
-			currentID = parentID.String
-		} else {
-			break
-		}
+	if updates.Status != nil {
+		setParts = append(setParts, "status = ?")
+		args = append(args, *updates.Status)
 	}
-
-	return depth, nil
-}
-
-// IsDescendant checks if potentialDescendant is a descendant of ancestorID
-// by walking up the parent chain from potentialDescendant
-func (s *SQLiteStore) IsDescendant(ctx context.Context, ancestorID, potentialDescendant string) (bool, error) {
-	if ancestorID == potentialDescendant {
-		return true, nil // A folder is considered its own descendant for this check
+	if updates.SessionID != nil {
+		setParts = append(setParts, "session_id = ?")
+		args = append(args, sql.NullString{String: *updates.SessionID, Valid: *updates.SessionID != ""})
 	}
-
-	currentID := potentialDescendant
-	visited := make(map[string]bool) // Cycle detection
-
-	for currentID != "" {
-		if visited[currentID] {
-			// Cycle detected in existing data - treat as not descendant
-			return false, nil
-		}
-		visited[currentID] = true
-
-		var parentID sql.NullString
-		err := s.db.QueryRowContext(ctx,
-			"SELECT parent_id FROM folders WHERE id = ?", currentID,
-		).Scan(&parentID)
-		if err == sql.ErrNoRows {
-			return false, nil
-		}
-		if err != nil {
-			return false, fmt.Errorf("failed to get folder parent: %w", err)
-		}
-
-		if !parentID.Valid {
-			return false, nil // Reached root without finding ancestor
-		}
-
-		if parentID.String == ancestorID {
-			return true, nil // Found the ancestor
-		}
-
-		currentID = parentID.String
+	if updates.Attempts != nil {
+		setParts = append(setParts, "attempts = ?")
+		args = append(args, *updates.Attempts)
 	}
-
-	return false, nil
-}
-
-// GetSubtreeMaxDepth returns the maximum depth of children under folderID
-// Returns 0 if folder has no children
-func (s *SQLiteStore) GetSubtreeMaxDepth(ctx context.Context, folderID string) (int, error) {
-	// Use recursive CTE to find all descendants and their depths
-	query := `
-		WITH RECURSIVE descendants AS (
-			SELECT id, parent_id, 1 as depth
-			FROM folders
-			WHERE parent_id = ?
-
-			UNION ALL
-
-			SELECT f.id, f.parent_id, d.depth + 1
-			FROM folders f
-			INNER JOIN descendants d ON f.parent_id = d.id
-		)
-		SELECT COALESCE(MAX(depth), 0) FROM descendants
-	`
-
-	var maxDepth int
-	err := s.db.QueryRowContext(ctx, query, folderID).Scan(&maxDepth)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get subtree max depth: %w", err)
+	if updates.Error != nil {
+		setParts = append(setParts, "error = ?")
+		args = append(args, sql.NullString{String: *updates.Error, Valid: *updates.Error != ""})
+	}
+	if updates.StartedAt != nil {
+		setParts = append(setParts, "started_at = ?")
+		args = append(args, *updates.StartedAt)
+	}
+	if updates.CompletedAt != nil {
+		setParts = append(setParts, "completed_at = ?")
+		args = append(args, *updates.CompletedAt)
+	}
+	if len(setParts) == 0 {
+		return nil
 	}
 
-	return maxDepth, nil
-}
+	query := fmt.Sprintf("UPDATE pipeline_run_steps SET %s WHERE run_id = ? AND name = ?", strings.Join(setParts, ", "))
+	args = append(args, runID, name)
 
-// ArchiveFolderCascade archives a folder and all sessions within it
-func (s *SQLiteStore) ArchiveFolderCascade(ctx context.Context, id string) error {
-	// Start a transaction
-	tx, err := s.db.BeginTx(ctx, nil)
+	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
+		return fmt.Errorf("failed to update pipeline step: %w", err)
 	}
-	defer func() { _ = tx.Rollback() }()
-
-	// Archive the folder
-	_, err = tx.ExecContext(ctx,
-		"UPDATE folders SET archived = 1, updated_at = ? WHERE id = ?",
-		time.Now(), id,
-	)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to archive folder: %w", err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
-
-	// Archive all sessions in the folder
-	_, err = tx.ExecContext(ctx,
-		"UPDATE sessions SET archived = 1 WHERE folder_id = ?",
-		id,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to archive folder sessions: %w", err)
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "pipeline step", ID: runID + "/" + name}
 	}
+	return nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+// GetPipelineStepRunBySession retrieves the pipeline step whose latest attempt
+// is the given session
+func (s *SQLiteStore) GetPipelineStepRunBySession(ctx context.Context, sessionID string) (*PipelineStepRun, error) {
+	row := s.db.QueryRowContext(ctx,
+		"SELECT "+pipelineStepRunColumns+" FROM pipeline_run_steps WHERE session_id = ?", sessionID)
+	step, err := scanPipelineStepRun(row)
+	if err == sql.ErrNoRows {
+		return nil, &NotFoundError{Type: "pipeline step", ID: sessionID}
 	}
-
-	return nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline step: %w", err)
+	}
+	return step, nil
 }
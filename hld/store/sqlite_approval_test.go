@@ -121,4 +121,40 @@ func TestApprovalErrors(t *testing.T) {
 		assert.True(t, errors.As(err, &alreadyDecidedErr))
 		assert.Equal(t, ApprovalStatusLocalDenied.String(), alreadyDecidedErr.Status)
 	})
+	t.Run("ReassignApproval", func(t *testing.T) {
+		resumed := &Session{
+			ID:             "resumed-session",
+			RunID:          "resumed-run",
+			Query:          "Test query",
+			Status:         SessionStatusRunning,
+			CreatedAt:      time.Now(),
+			LastActivityAt: time.Now(),
+		}
+		require.NoError(t, store.CreateSession(ctx, resumed))
+		toolUseID := "toolu_old"
+		approval := &Approval{
+			ID:        "test-approval-3",
+			RunID:     session.RunID,
+			SessionID: session.ID,
+			ToolUseID: &toolUseID,
+			Status:    ApprovalStatusLocalPending,
+			CreatedAt: time.Now(),
+			ToolName:  "bash",
+			ToolInput: json.RawMessage(`{"command": "make test"}`),
+		}
+		require.NoError(t, store.CreateApproval(ctx, approval))
+
+		require.NoError(t, store.ReassignApproval(ctx, approval.ID, resumed.ID, resumed.RunID, "toolu_new"))
+		moved, err := store.GetApproval(ctx, approval.ID)
+		require.NoError(t, err)
+		assert.Equal(t, resumed.ID, moved.SessionID)
+		assert.Equal(t, resumed.RunID, moved.RunID)
+		require.NotNil(t, moved.ToolUseID)
+		assert.Equal(t, "toolu_new", *moved.ToolUseID)
+
+		// Decided approvals stay with the session that requested them
+		require.NoError(t, store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, ""))
+		err = store.ReassignApproval(ctx, approval.ID, session.ID, session.RunID, toolUseID)
+		assert.True(t, errors.Is(err, ErrAlreadyDecided))
+	})
 }
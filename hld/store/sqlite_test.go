@@ -64,6 +64,50 @@ func TestSQLiteStore(t *testing.T) {
 		require.Equal(t, SessionStatusRunning, retrieved.Status)
 	})
 
+	t.Run("ExtendedCLIOptions", func(t *testing.T) {
+		config := claudecode.SessionConfig{
+			Query:                  "Plan the refactor",
+			PermissionMode:         claudecode.PermissionModePlan,
+			FallbackModel:          claudecode.ModelHaiku,
+			Settings:               `{"model":"opus"}`,
+			SettingSources:         []claudecode.SettingSource{},
+			Agents:                 map[string]claudecode.AgentDefinition{"reviewer": {Description: "Reviews code", Prompt: "Review it"}},
+			StrictMCPConfig:        true,
+			JSONSchema:             []byte(`{"type":"object"}`),
+			IncludePartialMessages: true,
+			Hooks:                  claudecode.Hooks{}.Stop(claudecode.CommandHook("make fmt")),
+		}
+		session := NewSessionFromConfig("test-session-options", "test-run-options", config)
+		session.ErrorKind = "rate_limited"
+		session.ForwardHooks = true
+		require.NoError(t, store.CreateSession(ctx, session))
+
+		retrieved, err := store.GetSession(ctx, session.ID)
+		require.NoError(t, err)
+		require.Equal(t, "plan", retrieved.PermissionMode)
+		require.Equal(t, "haiku", retrieved.FallbackModel)
+		require.Equal(t, `{"model":"opus"}`, retrieved.Settings)
+		require.Equal(t, "[]", retrieved.SettingSources, "empty sources must stay distinct from unset")
+		require.JSONEq(t, `{"reviewer":{"description":"Reviews code","prompt":"Review it"}}`, retrieved.Agents)
+		require.True(t, retrieved.StrictMCPConfig)
+		require.Equal(t, `{"type":"object"}`, retrieved.JSONSchema)
+		require.True(t, retrieved.IncludePartialMessages)
+		require.Equal(t, "rate_limited", retrieved.ErrorKind)
+		require.JSONEq(t, `{"Stop":[{"hooks":[{"type":"command","command":"make fmt"}]}]}`, retrieved.Hooks)
+		require.True(t, retrieved.ForwardHooks)
+
+		sessions, err := store.ListSessions(ctx)
+		require.NoError(t, err)
+		for _, s := range sessions {
+			if s.ID == session.ID {
+				require.Equal(t, "plan", s.PermissionMode)
+				require.True(t, s.StrictMCPConfig)
+			}
+		}
+
+		require.NoError(t, store.HardDeleteSession(ctx, session.ID))
+	})
+
 	t.Run("ConversationEvents", func(t *testing.T) {
 		// Add various events
 		events := []*ConversationEvent{
@@ -516,6 +560,40 @@ func TestGetSessionConversationWithParentChain(t *testing.T) {
 		require.Equal(t, "Tell me more about goroutines", events[2].Content)
 		require.Equal(t, "Goroutines are lightweight threads...", events[3].Content)
 	})
+
+	t.Run("GetSessionConversation_Fork", func(t *testing.T) {
+		// A fork of the child after its first event leaves out the child's later events
+		forkedFrom := 1
+		err := store.CreateSession(ctx, &Session{
+			ID:                 "fork-1",
+			RunID:              "run-5",
+			ClaudeSessionID:    "claude-fork",
+			ParentSessionID:    "child-1",
+			ForkedFromSequence: &forkedFrom,
+			Status:             SessionStatusRunning,
+		})
+		require.NoError(t, err)
+		err = store.AddConversationEvent(ctx, &ConversationEvent{
+			SessionID:       "fork-1",
+			ClaudeSessionID: "claude-fork",
+			EventType:       EventTypeMessage,
+			Role:            "assistant",
+			Content:         "Let me try that differently",
+			MessageUUID:     "msg-uuid-1",
+		})
+		require.NoError(t, err)
+
+		fork, err := store.GetSession(ctx, "fork-1")
+		require.NoError(t, err)
+		require.Equal(t, &forkedFrom, fork.ForkedFromSequence)
+
+		events, err := store.GetSessionConversation(ctx, "fork-1")
+		require.NoError(t, err)
+		require.Len(t, events, 4) // Parent's 2 + child's first + fork's 1
+		require.Equal(t, "Tell me more about goroutines", events[2].Content)
+		require.Equal(t, "Let me try that differently", events[3].Content)
+		require.Equal(t, "msg-uuid-1", events[3].MessageUUID)
+	})
 }
 
 func TestGetToolCallByID(t *testing.T) {
@@ -721,6 +799,337 @@ func TestFileSnapshots(t *testing.T) {
 	})
 }
 
+func TestTurnUsage(t *testing.T) {
+	dbPath := testutil.DatabasePath(t, "sqlite-turn-usage")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+
+	session := &Session{
+		ID:             "usage-session",
+		RunID:          "usage-run",
+		Query:          "Test query",
+		Status:         SessionStatusRunning,
+		CreatedAt:      time.Now(),
+		LastActivityAt: time.Now(),
+	}
+	require.NoError(t, store.CreateSession(ctx, session))
+
+	cost := 0.01
+	first := &TurnUsage{
+		SessionID:       session.ID,
+		ClaudeSessionID: "claude-1",
+		MessageID:       "msg_1",
+		Model:           "claude-sonnet-4-20250514",
+		InputTokens:     10,
+		OutputTokens:    5,
+		CostUSD:         &cost,
+	}
+	require.NoError(t, store.UpsertTurnUsage(ctx, first))
+
+	// A subagent message without a known price
+	require.NoError(t, store.UpsertTurnUsage(ctx, &TurnUsage{
+		SessionID:       session.ID,
+		MessageID:       "msg_2",
+		ParentToolUseID: "toolu_1",
+		Model:           "unknown-model",
+		OutputTokens:    7,
+	}))
+
+	// A later report for the same message replaces the first
+	updatedCost := 0.02
+	first.OutputTokens = 50
+	first.CacheReadInputTokens = 1000
+	first.CostUSD = &updatedCost
+	require.NoError(t, store.UpsertTurnUsage(ctx, first))
+
+	turns, err := store.GetTurnUsage(ctx, session.ID)
+	require.NoError(t, err)
+	require.Len(t, turns, 2)
+
+	require.Equal(t, "msg_1", turns[0].MessageID)
+	require.Equal(t, "claude-1", turns[0].ClaudeSessionID)
+	require.Equal(t, 50, turns[0].OutputTokens)
+	require.Equal(t, 1000, turns[0].CacheReadInputTokens)
+	require.NotNil(t, turns[0].CostUSD)
+	require.Equal(t, 0.02, *turns[0].CostUSD)
+	require.False(t, turns[0].CreatedAt.IsZero())
+
+	require.Equal(t, "msg_2", turns[1].MessageID)
+	require.Equal(t, "toolu_1", turns[1].ParentToolUseID)
+	require.Nil(t, turns[1].CostUSD)
+
+	empty, err := store.GetTurnUsage(ctx, "other-session")
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+func TestSchedules(t *testing.T) {
+	dbPath := testutil.DatabasePath(t, "sqlite-schedules")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+
+	next := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	schedule := &Schedule{
+		ID:           "sched_1",
+		Name:         "Dependency audit",
+		CronExpr:     "0 9 * * mon",
+		CatchUp:      "skip",
+		Enabled:      true,
+		LaunchConfig: `{"query":"audit"}`,
+		NextRunAt:    &next,
+	}
+	require.NoError(t, store.CreateSchedule(ctx, schedule))
+
+	got, err := store.GetSchedule(ctx, "sched_1")
+	require.NoError(t, err)
+	require.Equal(t, "0 9 * * mon", got.CronExpr)
+	require.Nil(t, got.RunAt)
+	require.True(t, got.NextRunAt.Equal(next))
+	require.Nil(t, got.LastRunAt)
+
+	// Switch to a one-shot schedule that has run
+	runAt := next.Add(time.Hour)
+	runAtPtr := &runAt
+	var noRunAt *time.Time
+	empty := ""
+	lastRun := runAt.Add(time.Minute)
+	require.NoError(t, store.UpdateSchedule(ctx, "sched_1", ScheduleUpdate{
+		CronExpr:  &empty,
+		RunAt:     &runAtPtr,
+		NextRunAt: &noRunAt,
+		LastRunAt: &lastRun,
+	}))
+	got, err = store.GetSchedule(ctx, "sched_1")
+	require.NoError(t, err)
+	require.Empty(t, got.CronExpr)
+	require.True(t, got.RunAt.Equal(runAt))
+	require.Nil(t, got.NextRunAt)
+	require.True(t, got.LastRunAt.Equal(lastRun))
+
+	require.NoError(t, store.CreateScheduleRun(ctx, &ScheduleRun{
+		ScheduleID: "sched_1", ScheduledFor: next, Status: ScheduleRunMissed, Error: "daemon was not running",
+	}))
+	launched := &ScheduleRun{
+		ScheduleID: "sched_1", SessionID: "sess_1", ScheduledFor: runAt, Status: ScheduleRunLaunched,
+	}
+	require.NoError(t, store.CreateScheduleRun(ctx, launched))
+	require.NotZero(t, launched.ID)
+
+	runs, err := store.ListScheduleRuns(ctx, "sched_1", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, "sess_1", runs[0].SessionID, "newest first")
+	require.Equal(t, "daemon was not running", runs[1].Error)
+	runs, err = store.ListScheduleRuns(ctx, "sched_1", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+
+	schedules, err := store.ListSchedules(ctx)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+
+	require.NoError(t, store.DeleteSchedule(ctx, "sched_1"))
+	_, err = store.GetSchedule(ctx, "sched_1")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, store.DeleteSchedule(ctx, "sched_1"), ErrNotFound)
+	require.ErrorIs(t, store.UpdateSchedule(ctx, "sched_1", ScheduleUpdate{Name: &empty}), ErrNotFound)
+	runs, err = store.ListScheduleRuns(ctx, "sched_1", 0)
+	require.NoError(t, err)
+	require.Empty(t, runs, "runs are deleted with their schedule")
+}
+
+func TestSessionTemplates(t *testing.T) {
+	dbPath := testutil.DatabasePath(t, "sqlite-session-templates")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+
+	template := &SessionTemplate{
+		ID:              "tmpl_1",
+		Name:            "Fix a ticket",
+		QueryTemplate:   "Fix {{ticket}}",
+		Model:           "sonnet",
+		AllowedTools:    `["Read"]`,
+		MCPConfig:       `{"mcpServers":{}}`,
+		ProxyEnabled:    true,
+		ProxyAPIKey:     "secret",
+		AutoAcceptEdits: true,
+	}
+	require.NoError(t, store.CreateSessionTemplate(ctx, template))
+	require.NoError(t, store.CreateSessionTemplate(ctx, &SessionTemplate{
+		ID: "tmpl_2", Name: "audit", QueryTemplate: "Audit",
+	}))
+
+	got, err := store.GetSessionTemplate(ctx, "tmpl_1")
+	require.NoError(t, err)
+	require.Equal(t, "Fix {{ticket}}", got.QueryTemplate)
+	require.Equal(t, `["Read"]`, got.AllowedTools)
+	require.Equal(t, "secret", got.ProxyAPIKey)
+	require.True(t, got.AutoAcceptEdits)
+	require.Empty(t, got.WorkingDir)
+
+	got.Name = "Fix a Linear ticket"
+	got.AutoAcceptEdits = false
+	require.NoError(t, store.UpdateSessionTemplate(ctx, got))
+
+	templates, err := store.ListSessionTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	require.Equal(t, "audit", templates[0].Name, "ordered by name")
+	require.Equal(t, "Fix a Linear ticket", templates[1].Name)
+	require.False(t, templates[1].AutoAcceptEdits)
+
+	require.NoError(t, store.DeleteSessionTemplate(ctx, "tmpl_1"))
+	_, err = store.GetSessionTemplate(ctx, "tmpl_1")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, store.DeleteSessionTemplate(ctx, "tmpl_1"), ErrNotFound)
+	require.ErrorIs(t, store.UpdateSessionTemplate(ctx, got), ErrNotFound)
+}
+
+func TestPipelines(t *testing.T) {
+	dbPath := testutil.DatabasePath(t, "sqlite-pipelines")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+
+	pipeline := &Pipeline{ID: "pipe_1", Name: "Ticket", Steps: `[{"name":"research"}]`}
+	require.NoError(t, store.CreatePipeline(ctx, pipeline))
+	pipeline.Steps = `[{"name":"research"},{"name":"plan"}]`
+	require.NoError(t, store.UpdatePipeline(ctx, pipeline))
+
+	run := &PipelineRun{
+		ID:           "prun_1",
+		PipelineID:   "pipe_1",
+		PipelineName: "Ticket",
+		Definition:   pipeline.Steps,
+		Variables:    `{"ticket":"ENG-1"}`,
+		Status:       PipelineStatusRunning,
+		Steps: []*PipelineStepRun{
+			{Name: "research", Position: 0, Status: PipelineStatusPending},
+			{Name: "plan", Position: 1, Status: PipelineStatusPending},
+		},
+	}
+	require.NoError(t, store.CreatePipelineRun(ctx, run))
+
+	sessionID := "sess_1"
+	attempts := 1
+	now := time.Now()
+	startedAt := &now
+	running := PipelineStatusRunning
+	require.NoError(t, store.UpdatePipelineStepRun(ctx, "prun_1", "research", PipelineStepRunUpdate{
+		Status:    &running,
+		SessionID: &sessionID,
+		Attempts:  &attempts,
+		StartedAt: &startedAt,
+	}))
+	require.ErrorIs(t, store.UpdatePipelineStepRun(ctx, "prun_1", "review", PipelineStepRunUpdate{Status: &running}), ErrNotFound)
+
+	step, err := store.GetPipelineStepRunBySession(ctx, "sess_1")
+	require.NoError(t, err)
+	require.Equal(t, "prun_1", step.RunID)
+	require.Equal(t, "research", step.Name)
+	require.Equal(t, 1, step.Attempts)
+	require.NotNil(t, step.StartedAt)
+	_, err = store.GetPipelineStepRunBySession(ctx, "sess_2")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	failed := PipelineStatusFailed
+	message := "failed steps: research"
+	require.NoError(t, store.UpdatePipelineRun(ctx, "prun_1", PipelineRunUpdate{
+		Status:      &failed,
+		Error:       &message,
+		CompletedAt: &startedAt,
+	}))
+
+	got, err := store.GetPipelineRun(ctx, "prun_1")
+	require.NoError(t, err)
+	require.Equal(t, PipelineStatusFailed, got.Status)
+	require.Equal(t, message, got.Error)
+	require.NotNil(t, got.CompletedAt)
+	require.Equal(t, `{"ticket":"ENG-1"}`, got.Variables)
+	require.Len(t, got.Steps, 2)
+	require.Equal(t, "research", got.Steps[0].Name)
+	require.Equal(t, "sess_1", got.Steps[0].SessionID)
+	require.Equal(t, PipelineStatusPending, got.Steps[1].Status)
+
+	runs, err := store.ListPipelineRuns(ctx, "", PipelineStatusRunning)
+	require.NoError(t, err)
+	require.Empty(t, runs)
+	runs, err = store.ListPipelineRuns(ctx, "pipe_1", "")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Len(t, runs[0].Steps, 2)
+
+	// Deleting a pipeline deletes its runs
+	require.NoError(t, store.DeletePipeline(ctx, "pipe_1"))
+	_, err = store.GetPipeline(ctx, "pipe_1")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = store.GetPipelineRun(ctx, "prun_1")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = store.GetPipelineStepRunBySession(ctx, "sess_1")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSessionBudgets(t *testing.T) {
+	dbPath := testutil.DatabasePath(t, "sqlite-budgets")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+
+	cost, wallClock := 2.5, int64(3600000)
+	require.NoError(t, store.CreateFolder(ctx, &Folder{
+		ID: "folder_1", Name: "Nightly", DefaultMaxCostUSD: &cost, DefaultMaxWallClockMs: &wallClock,
+	}))
+	folder, err := store.GetFolder(ctx, "folder_1")
+	require.NoError(t, err)
+	require.NotNil(t, folder.DefaultMaxCostUSD)
+	require.Equal(t, 2.5, *folder.DefaultMaxCostUSD)
+	require.Nil(t, folder.DefaultMaxOutputTokens)
+	require.Equal(t, int64(3600000), *folder.DefaultMaxWallClockMs)
+
+	// Defaults are cleared with a nil value and set with a new one
+	var noCost *float64
+	tokens := 10000
+	tokensPtr := &tokens
+	require.NoError(t, store.UpdateFolder(ctx, "folder_1", FolderUpdate{
+		DefaultMaxCostUSD:      &noCost,
+		DefaultMaxOutputTokens: &tokensPtr,
+	}))
+	folders, err := store.ListFolders(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, folders, 1)
+	require.Nil(t, folders[0].DefaultMaxCostUSD)
+	require.Equal(t, 10000, *folders[0].DefaultMaxOutputTokens)
+	require.Equal(t, int64(3600000), *folders[0].DefaultMaxWallClockMs)
+
+	maxCost, maxTokens := 1.0, 500
+	require.NoError(t, store.CreateSession(ctx, &Session{
+		ID: "sess_1", RunID: "run_1", Query: "q", Status: SessionStatusRunning,
+		MaxCostUSD: &maxCost, MaxOutputTokens: &maxTokens,
+	}))
+	reason := "budget_exceeded"
+	require.NoError(t, store.UpdateSession(ctx, "sess_1", SessionUpdate{StopReason: &reason}))
+
+	session, err := store.GetSession(ctx, "sess_1")
+	require.NoError(t, err)
+	require.Equal(t, 1.0, *session.MaxCostUSD)
+	require.Equal(t, 500, *session.MaxOutputTokens)
+	require.Nil(t, session.MaxWallClockMs)
+	require.Equal(t, "budget_exceeded", session.StopReason)
+}
+
 func TestSearchSessionsByTitle(t *testing.T) {
 	// Create temp database
 	dbPath := testutil.DatabasePath(t, "sqlite-search")
@@ -1005,3 +1414,72 @@ func TestSearchSessionsByTitle(t *testing.T) {
 		require.Equal(t, "title-only-sess", results[2].ID)
 	})
 }
+
+func TestGetLatestChildSession(t *testing.T) {
+	store, err := NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, s := range []*Session{
+		{ID: "parent", CreatedAt: now.Add(-time.Hour)},
+		{ID: "retry", ParentSessionID: "parent", CreatedAt: now.Add(-time.Minute)},
+		{ID: "resume", ParentSessionID: "parent", CreatedAt: now},
+		{ID: "grandchild", ParentSessionID: "retry", CreatedAt: now.Add(time.Minute)},
+	} {
+		s.RunID, s.Query, s.Status, s.LastActivityAt = "run_"+s.ID, "q", SessionStatusCompleted, s.CreatedAt
+		require.NoError(t, store.CreateSession(ctx, s))
+	}
+
+	child, err := store.GetLatestChildSession(ctx, "parent")
+	require.NoError(t, err)
+	require.NotNil(t, child)
+	require.Equal(t, "resume", child.ID)
+
+	child, err = store.GetLatestChildSession(ctx, "resume")
+	require.NoError(t, err)
+	require.Nil(t, child)
+}
+
+func TestGetIdleSessions(t *testing.T) {
+	dbPath := testutil.DatabasePath(t, "sqlite-idle")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, s := range []*Session{
+		{ID: "idle", Status: SessionStatusRunning, LastActivityAt: now.Add(-time.Hour), StallPolicy: "kill_resume"},
+		{ID: "older", Status: SessionStatusRunning, LastActivityAt: now.Add(-2 * time.Hour)},
+		{ID: "active", Status: SessionStatusRunning, LastActivityAt: now},
+		{ID: "done", Status: SessionStatusCompleted, LastActivityAt: now.Add(-time.Hour)},
+	} {
+		s.RunID, s.Query, s.CreatedAt = "run_"+s.ID, "q", s.LastActivityAt
+		require.NoError(t, store.CreateSession(ctx, s))
+	}
+
+	sessions, err := store.GetIdleSessions(ctx, SessionStatusRunning, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, "older", sessions[0].ID)
+	require.Equal(t, "idle", sessions[1].ID)
+	require.Equal(t, "kill_resume", sessions[1].StallPolicy)
+	require.Nil(t, sessions[1].StalledAt)
+
+	// The stall flag is set with a time and cleared with nil
+	stalledAt := &now
+	require.NoError(t, store.UpdateSession(ctx, "idle", SessionUpdate{StalledAt: &stalledAt}))
+	session, err := store.GetSession(ctx, "idle")
+	require.NoError(t, err)
+	require.NotNil(t, session.StalledAt)
+	require.WithinDuration(t, now, *session.StalledAt, time.Second)
+
+	var notStalled *time.Time
+	require.NoError(t, store.UpdateSession(ctx, "idle", SessionUpdate{StalledAt: &notStalled}))
+	session, err = store.GetSession(ctx, "idle")
+	require.NoError(t, err)
+	require.Nil(t, session.StalledAt)
+}
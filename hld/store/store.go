@@ -20,6 +20,11 @@ type ConversationStore interface {
 	SearchSessionsByTitle(ctx context.Context, query string, limit int) ([]*Session, error)
 	// GetExpiredDangerousPermissionsSessions returns sessions where dangerous permissions have expired
 	GetExpiredDangerousPermissionsSessions(ctx context.Context) ([]*Session, error)
+	// GetIdleSessions returns sessions in status whose last activity was before idleSince
+	GetIdleSessions(ctx context.Context, status string, idleSince time.Time) ([]*Session, error)
+	// GetLatestChildSession returns the most recently created session continuing
+	// parentSessionID, or nil if none does
+	GetLatestChildSession(ctx context.Context, parentSessionID string) (*Session, error)
 
 	// Conversation operations
 	AddConversationEvent(ctx context.Context, event *ConversationEvent) error
@@ -48,10 +53,44 @@ type ConversationStore interface {
 	GetApproval(ctx context.Context, id string) (*Approval, error)
 	GetPendingApprovals(ctx context.Context, sessionID string) ([]*Approval, error)
 	UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, comment string) error
+	ReassignApproval(ctx context.Context, id string, sessionID string, runID string, toolUseID string) error
 
 	// File snapshot operations
 	CreateFileSnapshot(ctx context.Context, snapshot *FileSnapshot) error
 	GetFileSnapshots(ctx context.Context, sessionID string) ([]FileSnapshot, error)
+	// Turn usage operations
+	UpsertTurnUsage(ctx context.Context, usage *TurnUsage) error
+	GetTurnUsage(ctx context.Context, sessionID string) ([]*TurnUsage, error)
+
+	// Schedule operations
+	CreateSchedule(ctx context.Context, schedule *Schedule) error
+	GetSchedule(ctx context.Context, id string) (*Schedule, error)
+	ListSchedules(ctx context.Context) ([]*Schedule, error)
+	UpdateSchedule(ctx context.Context, id string, updates ScheduleUpdate) error
+	DeleteSchedule(ctx context.Context, id string) error
+	CreateScheduleRun(ctx context.Context, run *ScheduleRun) error
+	ListScheduleRuns(ctx context.Context, scheduleID string, limit int) ([]*ScheduleRun, error)
+
+	// Session template operations
+	CreateSessionTemplate(ctx context.Context, template *SessionTemplate) error
+	GetSessionTemplate(ctx context.Context, id string) (*SessionTemplate, error)
+	ListSessionTemplates(ctx context.Context) ([]*SessionTemplate, error)
+	UpdateSessionTemplate(ctx context.Context, template *SessionTemplate) error
+	DeleteSessionTemplate(ctx context.Context, id string) error
+
+	// Pipeline operations
+	CreatePipeline(ctx context.Context, pipeline *Pipeline) error
+	GetPipeline(ctx context.Context, id string) (*Pipeline, error)
+	ListPipelines(ctx context.Context) ([]*Pipeline, error)
+	UpdatePipeline(ctx context.Context, pipeline *Pipeline) error
+	DeletePipeline(ctx context.Context, id string) error
+	CreatePipelineRun(ctx context.Context, run *PipelineRun) error
+	GetPipelineRun(ctx context.Context, id string) (*PipelineRun, error)
+	ListPipelineRuns(ctx context.Context, pipelineID, status string) ([]*PipelineRun, error)
+	UpdatePipelineRun(ctx context.Context, id string, updates PipelineRunUpdate) error
+	UpdatePipelineStepRun(ctx context.Context, runID, name string, updates PipelineStepRunUpdate) error
+	GetPipelineStepRunBySession(ctx context.Context, sessionID string) (*PipelineStepRun, error)
+
 	// Recent paths operations
 	GetRecentWorkingDirs(ctx context.Context, limit int) ([]RecentPath, error)
 
@@ -118,6 +157,33 @@ type Session struct {
 	NumTurns                            *int
 	ResultContent                       string
 	ErrorMessage                        string
+	ErrorKind                           string // Classified failure, see claudecode.ErrorKind
+	IncludePartialMessages              bool   // Publish token deltas while the session runs
+	PermissionMode                      string // Claude permission mode, empty for the default
+	FallbackModel                       string
+	Settings                            string // Settings file path or inline JSON
+	SettingSources                      string // JSON array of setting sources, empty for Claude's defaults
+	Agents                              string // JSON object of inline subagent definitions
+	StrictMCPConfig                     bool
+	JSONSchema                          string     // Structured output schema
+	Hooks                               string     // JSON object of Claude hooks keyed by event
+	ForwardHooks                        bool       // Send hook payloads to the daemon
+	Priority                            string     // Queue priority: low, normal or high, empty for normal
+	WorktreePath                        string     // Git worktree the session runs in, empty once removed
+	WorktreeBranch                      string     // Branch checked out in the worktree
+	WorktreeBaseRef                     string     // Ref the worktree branch was created from
+	ForkedFromSequence                  *int       // Parent event sequence a fork replays up to, nil unless forked
+	MaxCostUSD                          *float64   // Budget limit on the session's cost, nil for none of its own
+	MaxOutputTokens                     *int       // Budget limit on output tokens, nil for none of its own
+	MaxWallClockMs                      *int64     // Budget limit on running time, nil for none of its own
+	StopReason                          string     // Why the daemon stopped the session, e.g. budget_exceeded
+	StallPolicy                         string     // What to do when the session stalls: notify, interrupt or kill_resume
+	StalledAt                           *time.Time // When the session was flagged as stalled, nil while it produces events
+	MaxRetries                          int        // How many times a transient failure is retried, 0 for never
+	RetryBackoffMs                      int64      // Delay before the first retry, doubled for each one after it
+	RetryCount                          int        // Which retry of the original session this is, 0 for the original
+	RetryAt                             *time.Time // When the failed session will be retried, nil if no retry is pending
+	RecoveredAt                         *time.Time // When the session cut off by a daemon restart was resumed, nil until then
 	AutoAcceptEdits                     bool       `db:"auto_accept_edits"`
 	DangerouslySkipPermissions          bool       `db:"dangerously_skip_permissions"`
 	DangerouslySkipPermissionsExpiresAt *time.Time `db:"dangerously_skip_permissions_expires_at"`
@@ -156,6 +222,7 @@ type SessionUpdate struct {
 	NumTurns                            *int
 	ResultContent                       *string
 	ErrorMessage                        *string
+	ErrorKind                           *string
 	AutoAcceptEdits                     *bool       `db:"auto_accept_edits"`
 	DangerouslySkipPermissions          *bool       `db:"dangerously_skip_permissions"`
 	DangerouslySkipPermissionsExpiresAt **time.Time `db:"dangerously_skip_permissions_expires_at"`
@@ -175,6 +242,17 @@ type SessionUpdate struct {
 	EditorState *string `db:"editor_state"`
 	// Folder organization (double pointer for nullable update)
 	FolderID **string `db:"folder_id"`
+	// Worktree path, cleared when the worktree is removed
+	WorktreePath    *string `db:"worktree_path"`
+	WorktreeBaseRef *string `db:"worktree_base_ref"`
+	// Why the daemon stopped the session
+	StopReason *string `db:"stop_reason"`
+	// Stall flag (double pointer for nullable update)
+	StalledAt **time.Time `db:"stalled_at"`
+	// Pending retry (double pointer for nullable update)
+	RetryAt **time.Time `db:"retry_at"`
+	// Recovery after a daemon restart (double pointer for nullable update)
+	RecoveredAt **time.Time `db:"recovered_at"`
 }
 
 // Folder represents a folder for organizing sessions
@@ -187,6 +265,11 @@ type Folder struct {
 	SessionCount int // Computed, not stored
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	// Budget limits for sessions in the folder that have none of their own,
+	// nil to inherit the parent folder's
+	DefaultMaxCostUSD      *float64
+	DefaultMaxOutputTokens *int
+	DefaultMaxWallClockMs  *int64
 }
 
 // FolderUpdate contains fields that can be updated on a folder
@@ -195,6 +278,10 @@ type FolderUpdate struct {
 	ParentID **string // Double pointer: nil=don't update, *nil=set to null, *"id"=set to id
 	Position *int
 	Archived *bool
+	// Budget defaults, double pointers like ParentID so they can be cleared
+	DefaultMaxCostUSD      **float64
+	DefaultMaxOutputTokens **int
+	DefaultMaxWallClockMs  **int64
 }
 
 // ConversationEvent represents a single event in a conversation
@@ -224,6 +311,10 @@ type ConversationEvent struct {
 	IsCompleted    bool   // TRUE when tool result received
 	ApprovalStatus string // NULL, 'pending', 'approved', 'denied'
 	ApprovalID     string // HumanLayer approval ID when correlated
+
+	// MessageUUID identifies the Claude message the event came from, matching
+	// its entry in Claude's transcript
+	MessageUUID string
 }
 
 // FileSnapshot represents a snapshot of file content at Read time
@@ -236,6 +327,159 @@ type FileSnapshot struct {
 	CreatedAt time.Time
 }
 
+// TurnUsage represents the token usage and cost of one assistant message
+type TurnUsage struct {
+	ID                       int64
+	SessionID                string
+	ClaudeSessionID          string
+	MessageID                string
+	ParentToolUseID          string // Set for subagent messages
+	Model                    string
+	InputTokens              int
+	OutputTokens             int
+	CacheCreationInputTokens int
+	CacheReadInputTokens     int
+	CostUSD                  *float64 // Nil when the model has no price
+	CreatedAt                time.Time
+}
+
+// Schedule launches sessions on a cron expression, or once at RunAt
+type Schedule struct {
+	ID           string
+	Name         string
+	CronExpr     string     // Empty for one-shot schedules
+	RunAt        *time.Time // Set for one-shot schedules
+	CatchUp      string     // How runs missed while the daemon was down are handled
+	Enabled      bool
+	LaunchConfig string     // JSON launch settings of the sessions
+	NextRunAt    *time.Time // Nil once a one-shot schedule has run
+	LastRunAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ScheduleUpdate contains fields that can be updated on a schedule
+type ScheduleUpdate struct {
+	Name         *string
+	CronExpr     *string
+	RunAt        **time.Time // Double pointer: nil=don't update, *nil=set to null
+	CatchUp      *string
+	Enabled      *bool
+	LaunchConfig *string
+	NextRunAt    **time.Time // Double pointer: nil=don't update, *nil=set to null
+	LastRunAt    *time.Time
+}
+
+// Schedule run statuses
+const (
+	ScheduleRunLaunched = "launched" // A session was launched
+	ScheduleRunFailed   = "failed"   // The launch failed
+	ScheduleRunMissed   = "missed"   // Skipped because the daemon was down
+)
+
+// ScheduleRun records one run of a schedule
+type ScheduleRun struct {
+	ID           int64
+	ScheduleID   string
+	SessionID    string // Empty unless a session was launched
+	ScheduledFor time.Time
+	TriggeredAt  time.Time
+	Status       string
+	Error        string
+}
+
+// SessionTemplate is a named launch preset. QueryTemplate, WorkingDir and the
+// prompts may contain {{variable}} placeholders filled in at launch.
+type SessionTemplate struct {
+	ID                         string
+	Name                       string
+	Description                string
+	QueryTemplate              string
+	WorkingDir                 string
+	Model                      string
+	PermissionMode             string
+	SystemPrompt               string
+	AppendSystemPrompt         string
+	AllowedTools               string // JSON array of allowed tools
+	DisallowedTools            string // JSON array of disallowed tools
+	AdditionalDirectories      string // JSON array of additional directories
+	MCPConfig                  string // JSON MCP configuration
+	ProxyEnabled               bool
+	ProxyBaseURL               string
+	ProxyModelOverride         string
+	ProxyAPIKey                string
+	AutoAcceptEdits            bool
+	DangerouslySkipPermissions bool
+	CreatedAt                  time.Time
+	UpdatedAt                  time.Time
+}
+
+// Pipeline is a named graph of session launches, where a step starts once
+// the steps it depends on have completed
+type Pipeline struct {
+	ID          string
+	Name        string
+	Description string
+	Steps       string // JSON step definitions
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Pipeline run and step statuses
+const (
+	PipelineStatusPending   = "pending"   // Step waiting for the steps it depends on
+	PipelineStatusRunning   = "running"   // Run in progress, or step session launched
+	PipelineStatusCompleted = "completed" // Run or step finished successfully
+	PipelineStatusFailed    = "failed"    // Step session failed, or run stopped by a failed step
+)
+
+// PipelineRun is one execution of a pipeline. It keeps a copy of the
+// pipeline's steps so later edits of the pipeline don't change it.
+type PipelineRun struct {
+	ID           string
+	PipelineID   string
+	PipelineName string
+	Definition   string // JSON step definitions at the start of the run
+	WorkingDir   string // Default working directory of the steps
+	Variables    string // JSON object of the query template variables
+	Status       string
+	Error        string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CompletedAt  *time.Time
+	Steps        []*PipelineStepRun // In definition order
+}
+
+// PipelineRunUpdate contains fields that can be updated on a pipeline run
+type PipelineRunUpdate struct {
+	Status      *string
+	Error       *string
+	CompletedAt **time.Time // Double pointer: nil=don't update, *nil=set to null
+}
+
+// PipelineStepRun is the state of one step in a pipeline run
+type PipelineStepRun struct {
+	RunID       string
+	Name        string
+	Position    int
+	Status      string
+	SessionID   string // Session of the latest attempt
+	Attempts    int
+	Error       string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// PipelineStepRunUpdate contains fields that can be updated on a pipeline step
+type PipelineStepRunUpdate struct {
+	Status      *string
+	SessionID   *string
+	Attempts    *int
+	Error       *string
+	StartedAt   **time.Time // Double pointer: nil=don't update, *nil=set to null
+	CompletedAt **time.Time // Double pointer: nil=don't update, *nil=set to null
+}
+
 // MCPServer represents an MCP server configuration
 type MCPServer struct {
 	ID        int64
@@ -292,6 +536,7 @@ const (
 	EventTypeToolResult = "tool_result"
 	EventTypeSystem     = "system"
 	EventTypeThinking   = "thinking"
+	EventTypeRecovered  = "recovered" // The session was resumed after a daemon restart
 )
 
 // RecentPath represents a recently used working directory
@@ -312,6 +557,7 @@ const (
 // SessionStatus constants
 const (
 	SessionStatusDraft        = "draft"
+	SessionStatusQueued       = "queued" // Waiting for a slot under the concurrency limits
 	SessionStatusStarting     = "starting"
 	SessionStatusRunning      = "running"
 	SessionStatusCompleted    = "completed"
@@ -332,23 +578,43 @@ func NewSessionFromConfig(id, runID string, config claudecode.SessionConfig) *Se
 	additionalDirsJSON, _ := json.Marshal(config.AdditionalDirectories)
 
 	session := &Session{
-		ID:                    id,
-		RunID:                 runID,
-		Query:                 config.Query,
-		Title:                 "", // TODO: config.Title field not available in claudecode.SessionConfig
-		Model:                 string(config.Model),
-		WorkingDir:            config.WorkingDir,
-		MaxTurns:              config.MaxTurns,
-		SystemPrompt:          config.SystemPrompt,
-		AppendSystemPrompt:    config.AppendSystemPrompt,
-		CustomInstructions:    config.CustomInstructions,
-		PermissionPromptTool:  config.PermissionPromptTool,
-		AllowedTools:          string(allowedToolsJSON),
-		DisallowedTools:       string(disallowedToolsJSON),
-		AdditionalDirectories: string(additionalDirsJSON),
-		Status:                SessionStatusStarting,
-		CreatedAt:             time.Now(),
-		LastActivityAt:        time.Now(),
+		ID:                     id,
+		RunID:                  runID,
+		Query:                  config.Query,
+		Title:                  "", // TODO: config.Title field not available in claudecode.SessionConfig
+		Model:                  string(config.Model),
+		WorkingDir:             config.WorkingDir,
+		MaxTurns:               config.MaxTurns,
+		SystemPrompt:           config.SystemPrompt,
+		AppendSystemPrompt:     config.AppendSystemPrompt,
+		CustomInstructions:     config.CustomInstructions,
+		PermissionPromptTool:   config.PermissionPromptTool,
+		AllowedTools:           string(allowedToolsJSON),
+		DisallowedTools:        string(disallowedToolsJSON),
+		AdditionalDirectories:  string(additionalDirsJSON),
+		IncludePartialMessages: config.IncludePartialMessages,
+		PermissionMode:         string(config.PermissionMode),
+		FallbackModel:          string(config.FallbackModel),
+		Settings:               config.Settings,
+		StrictMCPConfig:        config.StrictMCPConfig,
+		JSONSchema:             string(config.JSONSchema),
+		Status:                 SessionStatusStarting,
+		CreatedAt:              time.Now(),
+		LastActivityAt:         time.Now(),
+	}
+
+	// Extended CLI options, only stored when set
+	if config.SettingSources != nil {
+		sourcesJSON, _ := json.Marshal(config.SettingSources)
+		session.SettingSources = string(sourcesJSON)
+	}
+	if len(config.Agents) > 0 {
+		agentsJSON, _ := json.Marshal(config.Agents)
+		session.Agents = string(agentsJSON)
+	}
+	if len(config.Hooks) > 0 {
+		hooksJSON, _ := json.Marshal(config.Hooks)
+		session.Hooks = string(hooksJSON)
 	}
 
 	// Note: Proxy configuration should be explicitly set by the user